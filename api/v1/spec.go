@@ -0,0 +1,8 @@
+package v1
+
+import _ "embed"
+
+// Spec is Testkube API OpenAPI document
+//
+//go:embed testkube.yaml
+var Spec []byte
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 
@@ -18,14 +19,21 @@ import (
 	apiv1 "github.com/kubeshop/testkube/internal/app/api/v1"
 	"github.com/kubeshop/testkube/internal/migrations"
 	"github.com/kubeshop/testkube/internal/pkg/api"
+	"github.com/kubeshop/testkube/internal/pkg/api/kubecache"
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/batch"
 	"github.com/kubeshop/testkube/internal/pkg/api/repository/config"
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/lease"
 	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
 	"github.com/kubeshop/testkube/internal/pkg/api/repository/storage"
 	"github.com/kubeshop/testkube/internal/pkg/api/repository/testresult"
 	"github.com/kubeshop/testkube/pkg/analytics"
+	"github.com/kubeshop/testkube/pkg/encryption"
 	"github.com/kubeshop/testkube/pkg/migrator"
+	"github.com/kubeshop/testkube/pkg/rand"
 	"github.com/kubeshop/testkube/pkg/secret"
 	"github.com/kubeshop/testkube/pkg/ui"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type MongoConfig struct {
@@ -35,13 +43,54 @@ type MongoConfig struct {
 
 var Config MongoConfig
 
+const (
+	// migrationsLeaseName is a lease held by API server instance running server migrations
+	migrationsLeaseName = "migrations"
+	// migrationsLeaseTTL is a time after which migrations lease of not responding instance expires
+	migrationsLeaseTTL = 30 * time.Second
+)
+
+// KubeCacheConfig is a configuration of informer cache of Kubernetes objects
+type KubeCacheConfig struct {
+	Enabled      bool          `envconfig:"TESTKUBE_KUBE_CACHE_ENABLED" default:"true"`
+	Resync       time.Duration `envconfig:"TESTKUBE_KUBE_CACHE_RESYNC" default:"10m"`
+	SyncTimeout  time.Duration `envconfig:"TESTKUBE_KUBE_CACHE_SYNC_TIMEOUT" default:"30s"`
+	MaxStaleness time.Duration `envconfig:"TESTKUBE_KUBE_CACHE_MAX_STALENESS" default:"10s"`
+}
+
+var KubeCache KubeCacheConfig
+
+// EncryptionConfig is a configuration of stored execution params and output encryption, key is read from
+// secret when it's not set directly, encryption is disabled when neither is set
+type EncryptionConfig struct {
+	Key       string `envconfig:"TESTKUBE_ENCRYPTION_KEY"`
+	KeySecret string `envconfig:"TESTKUBE_ENCRYPTION_KEY_SECRET"`
+	SecretKey string `envconfig:"TESTKUBE_ENCRYPTION_KEY_SECRET_KEY" default:"key"`
+}
+
+var Encryption EncryptionConfig
+
+// OutputConfig is a configuration of stored execution outputs
+type OutputConfig struct {
+	Compression bool `envconfig:"TESTKUBE_OUTPUT_COMPRESSION" default:"true"`
+}
+
+var Output OutputConfig
+
 var verbose = flag.Bool("v", false, "enable verbosity level")
+var compressOutputs = flag.Bool("compress-outputs", false, "compress outputs of stored executions and exit")
 
 func init() {
 	flag.Parse()
 	ui.Verbose = *verbose
 	err := envconfig.Process("mongo", &Config)
 	ui.PrintOnError("Processing mongo environment config", err)
+	err = envconfig.Process("kubecache", &KubeCache)
+	ui.PrintOnError("Processing kube cache environment config", err)
+	err = envconfig.Process("encryption", &Encryption)
+	ui.PrintOnError("Processing encryption environment config", err)
+	err = envconfig.Process("output", &Output)
+	ui.PrintOnError("Processing output environment config", err)
 }
 
 func runMigrations() (err error) {
@@ -60,24 +109,12 @@ func runMigrations() (err error) {
 }
 
 func main() {
-
-	out, err := analytics.SendServerStartAnonymousInfo()
-	if err != nil {
-		ui.Debug("analytics send error", "error", err.Error())
-	}
-	ui.Debug(out)
-
 	port := os.Getenv("APISERVER_PORT")
 	namespace := "testkube"
 	if ns, ok := os.LookupEnv("TESTKUBE_NAMESPACE"); ok {
 		namespace = ns
 	}
 
-	ln, err := net.Listen("tcp", ":"+port)
-	ui.ExitOnError("Checking if port "+port+"is free", err)
-	ln.Close()
-	ui.Debug("TCP Port is available", port)
-
 	// DI
 	db, err := storage.GetMongoDataBase(Config.DSN, Config.DB)
 	ui.ExitOnError("Getting mongo database", err)
@@ -85,6 +122,12 @@ func main() {
 	kubeClient, err := kubeclient.GetClient()
 	ui.ExitOnError("Getting kubernetes client", err)
 
+	// informers of cache are stopped when API server stops
+	informersCtx, stopInformers := context.WithCancel(context.Background())
+	if KubeCache.Enabled {
+		kubeClient = getCachedClient(informersCtx, kubeClient, namespace)
+	}
+
 	secretClient, err := secret.NewClient(namespace)
 	ui.ExitOnError("Getting secret client", err)
 
@@ -95,15 +138,43 @@ func main() {
 	webhooksClient := executorsclientv1.NewWebhooksClient(kubeClient, namespace)
 	testsuitesClient := testsuitesclientv1.NewClient(kubeClient, namespace)
 
+	encrypter, err := getEncrypter(secretClient)
+	ui.ExitOnError("Getting encryption key", err)
+
 	resultsRepository := result.NewMongoRespository(db)
+	resultsRepository.Encrypter = encrypter
+	resultsRepository.CompressOutput = Output.Compression
+	err = resultsRepository.EnsureIndexes(context.Background())
+	ui.WarnOnError("Creating unique index of test execution names", err)
 	testResultsRepository := testresult.NewMongoRespository(db)
+	testResultsRepository.Encrypter = encrypter
+	testResultsRepository.CompressOutput = Output.Compression
+
+	if *compressOutputs {
+		runOutputsCompression(resultsRepository, testResultsRepository)
+		return
+	}
+
+	out, err := analytics.SendServerStartAnonymousInfo()
+	if err != nil {
+		ui.Debug("analytics send error", "error", err.Error())
+	}
+	ui.Debug(out)
+
+	ln, err := net.Listen("tcp", ":"+port)
+	ui.ExitOnError("Checking if port "+port+"is free", err)
+	ln.Close()
+	ui.Debug("TCP Port is available", port)
+
 	configRepository := config.NewMongoRespository(db)
+	leaseRepository := lease.NewMongoRespository(db)
 
 	clusterId, err := configRepository.GetUniqueClusterId(context.Background())
 	ui.WarnOnError("Getting uniqe clusterId", err)
 
 	migrations.Migrator.Add(migrations.NewVersion_0_9_2(scriptsClient, testsClientV1, testsClientV2, testsuitesClient))
-	if err := runMigrations(); err != nil {
+	migrations.Migrator.Add(migrations.NewVersion_1_5_0(db))
+	if err := runLockedMigrations(leaseRepository); err != nil {
 		ui.ExitOnError("Running server migrations", err)
 	}
 
@@ -111,6 +182,10 @@ func main() {
 		namespace,
 		resultsRepository,
 		testResultsRepository,
+		configRepository,
+		leaseRepository,
+		batch.NewMongoRepository(db, batch.DigestsCollectionName),
+		batch.NewMongoRepository(db, batch.HeldCollectionName),
 		testsClientV2,
 		executorsClient,
 		testsuitesClient,
@@ -119,5 +194,122 @@ func main() {
 		clusterId,
 	).Run()
 
+	stopInformers()
 	ui.ExitOnError("Running API Server", err)
 }
+
+// runLockedMigrations runs server migrations holding migrations lease, API server instances started at the same time
+// wait for migrations run by one of them, lease is renewed while migrating
+func runLockedMigrations(leaseRepository *lease.MongoRepository) error {
+	holder, err := os.Hostname()
+	if err != nil || holder == "" {
+		holder = rand.Name()
+	}
+
+	ctx := context.Background()
+	for {
+		acquired, err := leaseRepository.TryAcquire(ctx, migrationsLeaseName, holder, migrationsLeaseTTL)
+		if err != nil {
+			return fmt.Errorf("can't acquire migrations lease: %w", err)
+		}
+
+		if acquired {
+			break
+		}
+
+		ui.Info("Waiting for migrations run by other API server instance")
+		time.Sleep(migrationsLeaseTTL / 10)
+	}
+
+	renewCtx, stopRenew := context.WithCancel(ctx)
+	defer func() {
+		stopRenew()
+		if err := leaseRepository.Release(ctx, migrationsLeaseName, holder); err != nil {
+			ui.Warn("Releasing migrations lease", err.Error())
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(migrationsLeaseTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-renewCtx.Done():
+				return
+			case <-ticker.C:
+				if _, err := leaseRepository.TryAcquire(renewCtx, migrationsLeaseName, holder, migrationsLeaseTTL); err != nil {
+					ui.Warn("Renewing migrations lease", err.Error())
+				}
+			}
+		}
+	}()
+
+	return runMigrations()
+}
+
+// runOutputsCompression compresses outputs of executions stored before compression was enabled
+func runOutputsCompression(resultsRepository *result.MongoRepository, testResultsRepository *testresult.MongoRepository) {
+	ctx := context.Background()
+	count, err := resultsRepository.CompressOutputs(ctx)
+	ui.ExitOnError("Compressing test execution outputs", err)
+	ui.Info("Compressed test execution outputs", fmt.Sprint(count))
+
+	count, err = testResultsRepository.CompressOutputs(ctx)
+	ui.ExitOnError("Compressing test suite execution outputs", err)
+	ui.Info("Compressed test suite execution outputs", fmt.Sprint(count))
+}
+
+// getEncrypter returns encrypter of stored executions with key from config or secret, nil is returned when encryption is disabled
+func getEncrypter(secretClient *secret.Client) (*encryption.Encrypter, error) {
+	value := Encryption.Key
+	if value == "" && Encryption.KeySecret != "" {
+		data, err := secretClient.Get(Encryption.KeySecret)
+		if err != nil {
+			return nil, fmt.Errorf("getting secret %s: %w", Encryption.KeySecret, err)
+		}
+
+		var ok bool
+		if value, ok = data[Encryption.SecretKey]; !ok {
+			return nil, fmt.Errorf("secret %s has no key %s", Encryption.KeySecret, Encryption.SecretKey)
+		}
+	}
+
+	if value == "" {
+		return nil, nil
+	}
+
+	key, err := encryption.ParseKey(value)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapper, err := encryption.NewLocalKeyWrapper(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return encryption.NewEncrypter(wrapper), nil
+}
+
+// getCachedClient returns client reading tests, executors and webhooks from informer cache running until ctx is done,
+// live client is returned when cache can't be synced
+func getCachedClient(ctx context.Context, kubeClient client.Client, namespace string) client.Client {
+	kubeConfig, err := ctrl.GetConfig()
+	if err != nil {
+		ui.WarnOnError("Getting kubernetes config for informer cache", err)
+		return kubeClient
+	}
+
+	cachedClient, err := kubecache.NewClient(ctx, kubeConfig, kubeClient, kubecache.Options{
+		Namespace:    namespace,
+		Resync:       KubeCache.Resync,
+		SyncTimeout:  KubeCache.SyncTimeout,
+		MaxStaleness: KubeCache.MaxStaleness,
+	})
+	if err != nil {
+		ui.WarnOnError("Starting informer cache, reading from API server", err)
+		return kubeClient
+	}
+
+	return cachedClient
+}
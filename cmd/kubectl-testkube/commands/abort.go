@@ -5,6 +5,7 @@ import (
 
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common/validator"
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/tests"
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/testsuites"
 	"github.com/kubeshop/testkube/pkg/ui"
 )
 
@@ -21,6 +22,8 @@ func NewAbortCmd() *cobra.Command {
 		}}
 
 	cmd.AddCommand(tests.NewAbortExecutionCmd())
+	cmd.AddCommand(tests.NewAbortExecutionGroupCmd())
+	cmd.AddCommand(testsuites.NewAbortTestSuiteExecutionCmd())
 
 	return cmd
 }
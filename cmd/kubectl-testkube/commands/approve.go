@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common/validator"
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/testsuites"
+	"github.com/kubeshop/testkube/pkg/ui"
+)
+
+func NewApproveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "approve <resourceName>",
+		Short: "Approve test suite steps",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			ui.PrintOnError("Displaying help", err)
+		},
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			validator.PersistentPreRunVersionCheck(cmd, Version)
+		}}
+
+	cmd.AddCommand(testsuites.NewApproveTestSuiteStepCmd())
+
+	return cmd
+}
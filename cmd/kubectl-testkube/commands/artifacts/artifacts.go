@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common"
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common/render"
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common/validator"
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/tests"
 	"github.com/kubeshop/testkube/pkg/ui"
@@ -30,7 +31,8 @@ func NewListArtifactsCmd() *cobra.Command {
 			artifacts, err := client.GetExecutionArtifacts(executionID)
 			ui.ExitOnError("getting artifacts ", err)
 
-			ui.Table(artifacts, os.Stdout)
+			err = render.List(cmd, artifacts, os.Stdout)
+			ui.ExitOnError("rendering artifacts", err)
 		},
 	}
 
@@ -74,7 +76,7 @@ func NewDownloadAllArtifactsCmd() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			executionID := args[0]
 			client, _ := common.GetClient(cmd)
-			tests.DownloadArtifacts(executionID, downloadDir, client)
+			tests.DownloadArtifacts(executionID, downloadDir, "", tests.DefaultDownloadConcurrency, client)
 		},
 	}
 
@@ -10,6 +10,13 @@ func GetClient(cmd *cobra.Command) (client.Client, string) {
 	clientType := cmd.Flag("client").Value.String()
 	namespace := cmd.Flag("namespace").Value.String()
 
+	if apiURI := cmd.Flag("api-uri").Value.String(); apiURI != "" {
+		client, err := client.GetURIClient(apiURI, cmd.Flag("api-token").Value.String())
+		ui.ExitOnError("setting up API URI client", err)
+
+		return client, namespace
+	}
+
 	client, err := client.GetClient(client.ClientType(clientType), namespace)
 	ui.ExitOnError("setting up client type", err)
 
@@ -0,0 +1,17 @@
+package common
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// AddCRDOnlyFlag adds --crd-only flag (with --offline alias) rendering manifests instead of calling API
+func AddCRDOnlyFlag(cmd *cobra.Command, crdOnly *bool) {
+	cmd.Flags().BoolVar(crdOnly, "crd-only", false, "render custom resource manifests to stdout instead of calling API (alias --offline)")
+	cmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "offline" {
+			name = "crd-only"
+		}
+		return pflag.NormalizedName(name)
+	})
+}
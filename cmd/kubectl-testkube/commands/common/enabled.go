@@ -0,0 +1,20 @@
+package common
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// AddEnabledFlag adds --enabled flag of test and test suite enabled state
+func AddEnabledFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("enabled", true, "enabled state, disabled resources are skipped by label selector runs and schedules")
+}
+
+// NewEnabledFromFlags returns enabled state passed with flag, existing state is returned when flag isn't passed
+func NewEnabledFromFlags(cmd *cobra.Command, existing *bool) *bool {
+	if !cmd.Flags().Changed("enabled") {
+		return existing
+	}
+
+	enabled, _ := cmd.Flags().GetBool("enabled")
+	return &enabled
+}
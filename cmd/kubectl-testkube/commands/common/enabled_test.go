@@ -0,0 +1,29 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEnabledFromFlags(t *testing.T) {
+	enabled := true
+
+	t.Run("existing state is kept", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		AddEnabledFlag(cmd)
+
+		assert.Equal(t, &enabled, NewEnabledFromFlags(cmd, &enabled))
+		assert.Nil(t, NewEnabledFromFlags(cmd, nil))
+	})
+
+	t.Run("passed flag overrides existing state", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		AddEnabledFlag(cmd)
+		assert.NoError(t, cmd.Flags().Parse([]string{"--enabled=false"}))
+
+		disabled := false
+		assert.Equal(t, &disabled, NewEnabledFromFlags(cmd, &enabled))
+	})
+}
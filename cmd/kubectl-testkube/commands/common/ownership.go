@@ -0,0 +1,34 @@
+package common
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// AddOwnershipFlags adds --owner, --team and --contact flags of test and test suite ownership
+func AddOwnershipFlags(cmd *cobra.Command) {
+	cmd.Flags().String("owner", "", "owner name or handle included in failure notifications")
+	cmd.Flags().String("team", "", "team owning the resource")
+	cmd.Flags().String("contact", "", "contact of owners, e.g. Slack channel or email")
+}
+
+// NewOwnershipFromFlags returns existing ownership with fields overridden by passed flags, empty flag value clears the field
+func NewOwnershipFromFlags(cmd *cobra.Command, existing *testkube.Ownership) *testkube.Ownership {
+	var ownership testkube.Ownership
+	if existing != nil {
+		ownership = *existing
+	}
+
+	for flag, field := range map[string]*string{"owner": &ownership.Owner, "team": &ownership.Team, "contact": &ownership.Contact} {
+		if cmd.Flags().Changed(flag) {
+			*field = cmd.Flag(flag).Value.String()
+		}
+	}
+
+	if ownership.IsEmpty() {
+		return nil
+	}
+
+	return &ownership
+}
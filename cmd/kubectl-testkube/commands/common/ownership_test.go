@@ -0,0 +1,30 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestNewOwnershipFromFlags(t *testing.T) {
+	existing := &testkube.Ownership{Owner: "jane", Team: "payments", Contact: "#payments"}
+
+	t.Run("passed flags override existing fields", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		AddOwnershipFlags(cmd)
+		assert.NoError(t, cmd.Flags().Parse([]string{"--owner", "john", "--contact", ""}))
+
+		assert.Equal(t, &testkube.Ownership{Owner: "john", Team: "payments"}, NewOwnershipFromFlags(cmd, existing))
+		assert.Equal(t, "jane", existing.Owner, "existing ownership isn't changed")
+	})
+
+	t.Run("no ownership", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		AddOwnershipFlags(cmd)
+
+		assert.Nil(t, NewOwnershipFromFlags(cmd, nil))
+	})
+}
@@ -0,0 +1,59 @@
+package common
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// AddPlacementFlags adds --node-selector and --placement-file flags of test and executor scheduling constraints
+func AddPlacementFlags(cmd *cobra.Command) {
+	cmd.Flags().StringToString("node-selector", nil, "node label execution pods have to be scheduled on: --node-selector nvidia.com/gpu.present=true")
+	cmd.Flags().String("placement-file", "", "YAML or JSON file with nodeSelector, nodeAffinity, tolerations and topologySpread of execution pods")
+}
+
+// NewPlacementFromFlags returns placement read from placement file, or existing placement when file isn't passed,
+// with node selector overridden by passed node labels, empty placement file clears placement
+func NewPlacementFromFlags(cmd *cobra.Command, existing *testkube.Placement) (*testkube.Placement, error) {
+	placement := existing
+	if cmd.Flags().Changed("placement-file") {
+		placement = nil
+		if file := cmd.Flag("placement-file").Value.String(); file != "" {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("reading placement file %s: %w", file, err)
+			}
+
+			if err = yaml.Unmarshal(data, &placement); err != nil {
+				return nil, fmt.Errorf("parsing placement file %s: %w", file, err)
+			}
+		}
+	}
+
+	nodeSelector, err := cmd.Flags().GetStringToString("node-selector")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nodeSelector) > 0 {
+		withNodeSelector := testkube.Placement{}
+		if placement != nil {
+			withNodeSelector = *placement
+		}
+
+		merged := map[string]string{}
+		for _, items := range []map[string]string{withNodeSelector.NodeSelector, nodeSelector} {
+			for k, v := range items {
+				merged[k] = v
+			}
+		}
+		withNodeSelector.NodeSelector = merged
+		placement = &withNodeSelector
+	}
+
+	return placement, placement.Validate()
+}
@@ -0,0 +1,68 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestNewPlacementFromFlags(t *testing.T) {
+	existing := &testkube.Placement{NodeSelector: map[string]string{"pool": "tests"}}
+
+	parse := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		AddPlacementFlags(cmd)
+		require.NoError(t, cmd.Flags().Parse(args))
+		return cmd
+	}
+
+	t.Run("node selector is added to existing placement", func(t *testing.T) {
+		placement, err := NewPlacementFromFlags(parse("--node-selector", "nvidia.com/gpu.present=true"), existing)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"pool": "tests", "nvidia.com/gpu.present": "true"}, placement.NodeSelector)
+		assert.Len(t, existing.NodeSelector, 1, "existing placement isn't changed")
+	})
+
+	t.Run("placement file replaces existing placement", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "placement.yaml")
+		require.NoError(t, os.WriteFile(file, []byte(`nodeAffinity:
+- key: topology.kubernetes.io/zone
+  operator: In
+  values: [europe-west1-b]
+tolerations:
+- key: nvidia.com/gpu
+  operator: Exists
+  effect: NoSchedule
+`), 0o600))
+
+		placement, err := NewPlacementFromFlags(parse("--placement-file", file), existing)
+
+		assert.NoError(t, err)
+		assert.Nil(t, placement.NodeSelector)
+		assert.Equal(t, []testkube.PlacementRequirement{{Key: "topology.kubernetes.io/zone", Operator: "In", Values: []string{"europe-west1-b"}}},
+			placement.NodeAffinity)
+		assert.Equal(t, []testkube.PlacementToleration{{Key: "nvidia.com/gpu", Operator: "Exists", Effect: "NoSchedule"}}, placement.Tolerations)
+	})
+
+	t.Run("empty placement file clears placement", func(t *testing.T) {
+		placement, err := NewPlacementFromFlags(parse("--placement-file", ""), existing)
+
+		assert.NoError(t, err)
+		assert.Nil(t, placement)
+	})
+
+	t.Run("invalid placement", func(t *testing.T) {
+		_, err := NewPlacementFromFlags(parse("--node-selector", "zone=a"), &testkube.Placement{
+			NodeAffinity: []testkube.PlacementRequirement{{Key: "zone", Operator: "NotIn", Values: []string{"a"}}},
+		})
+
+		assert.Error(t, err)
+	})
+}
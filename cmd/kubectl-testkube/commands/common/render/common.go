@@ -3,9 +3,11 @@ package render
 import (
 	"encoding/json"
 	"io"
+	"reflect"
 	"text/template"
 
 	"github.com/kubeshop/testkube/pkg/ui"
+	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 )
 
@@ -16,8 +18,25 @@ const (
 	OutputJSON       OutputType = "json"
 	OutputYAML       OutputType = "yaml"
 	OutputPretty     OutputType = "pretty"
+	OutputJUnit      OutputType = "junit"
 )
 
+// AddOutputFlags adds output type and go template flags shared by get and list commands
+func AddOutputFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringP("output", "o", string(OutputPretty), "output type can be one of json|yaml|pretty|go|junit, junit is supported for executions")
+	cmd.PersistentFlags().StringP("go-template", "", "{{.}}", "go template to render")
+}
+
+// GetOutputType returns output type passed in output flag
+func GetOutputType(cmd *cobra.Command) OutputType {
+	outputType := OutputType(cmd.Flag("output").Value.String())
+	if outputType == "go-template" {
+		return OutputGoTemplate
+	}
+
+	return outputType
+}
+
 type CliObjRenderer func(ui *ui.UI, obj interface{}) error
 
 func RenderJSON(obj interface{}, w io.Writer) error {
@@ -53,6 +72,25 @@ func RenderGoTemplateList(list []interface{}, w io.Writer, tpl string) error {
 	return nil
 }
 
+// toList converts slice of any type or paginated results to list of items
+func toList(obj interface{}) ([]interface{}, bool) {
+	value := reflect.ValueOf(obj)
+	if value.Kind() == reflect.Struct {
+		value = value.FieldByName("Results")
+	}
+
+	if value.Kind() != reflect.Slice {
+		return nil, false
+	}
+
+	list := make([]interface{}, value.Len())
+	for i := range list {
+		list[i] = value.Index(i).Interface()
+	}
+
+	return list, true
+}
+
 func RenderPrettyList(obj ui.TableData, w io.Writer) error {
 	ui.NL()
 	ui.Table(obj, w)
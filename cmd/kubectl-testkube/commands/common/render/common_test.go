@@ -0,0 +1,22 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestToList(t *testing.T) {
+	list, ok := toList(testkube.Tests{{Name: "t1"}, {Name: "t2"}})
+	assert.True(t, ok)
+	assert.Len(t, list, 2)
+
+	list, ok = toList(testkube.ExecutionsResult{Results: []testkube.ExecutionSummary{{Name: "e1"}}})
+	assert.True(t, ok)
+	assert.Equal(t, testkube.ExecutionSummary{Name: "e1"}, list[0])
+
+	_, ok = toList(testkube.Execution{})
+	assert.False(t, ok)
+}
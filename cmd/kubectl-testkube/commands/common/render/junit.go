@@ -0,0 +1,189 @@
+package render
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// JUnitTestSuites is a JUnit XML report root element
+type JUnitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []JUnitTestSuite `xml:"testsuite"`
+}
+
+// JUnitTestSuite is a JUnit XML test suite
+type JUnitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []JUnitTestCase `xml:"testcase"`
+
+	duration time.Duration
+}
+
+// JUnitTestCase is a JUnit XML test case
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *JUnitMessage `xml:"failure,omitempty"`
+	Skipped   *JUnitMessage `xml:"skipped,omitempty"`
+}
+
+// JUnitMessage is a JUnit XML failure or skip reason
+type JUnitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Content string `xml:",chardata"`
+}
+
+// RenderJUnit renders executions as JUnit XML report
+func RenderJUnit(obj interface{}, w io.Writer) error {
+	report, err := NewJUnitReport(obj)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err = encoder.Encode(report); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// NewJUnitReport maps test or test suite executions to JUnit report
+func NewJUnitReport(obj interface{}) (report JUnitTestSuites, err error) {
+	switch o := obj.(type) {
+	case testkube.Execution:
+		report.TestSuites = append(report.TestSuites, mapExecutionToJUnit(o))
+	case testkube.ExecutionsResult:
+		suite := JUnitTestSuite{Name: "executions"}
+		for _, summary := range o.Results {
+			suite.add(summary.Name, summary.TestName, summary.EndTime.Sub(summary.StartTime),
+				statusString(summary.Status), "")
+		}
+		report.TestSuites = append(report.TestSuites, suite)
+	case testkube.TestSuiteExecution:
+		report.TestSuites = append(report.TestSuites, mapTestSuiteExecutionToJUnit(o))
+	case testkube.TestSuiteExecutionsResult:
+		suite := JUnitTestSuite{Name: "testsuite-executions"}
+		for _, summary := range o.Results {
+			var status string
+			if summary.Status != nil {
+				status = string(*summary.Status)
+			}
+			suite.add(summary.Name, summary.TestSuiteName, summary.EndTime.Sub(summary.StartTime), status, "")
+		}
+		report.TestSuites = append(report.TestSuites, suite)
+	default:
+		return report, fmt.Errorf("can't render %T as junit, only executions are supported", obj)
+	}
+
+	return report, nil
+}
+
+func mapExecutionToJUnit(execution testkube.Execution) JUnitTestSuite {
+	suite := JUnitTestSuite{Name: execution.TestName}
+	result := execution.ExecutionResult
+	if result == nil || len(result.Steps) == 0 {
+		var status, errorMessage string
+		if result != nil {
+			status = statusString(result.Status)
+			errorMessage = result.ErrorMessage
+		}
+
+		suite.add(execution.Name, execution.TestName, execution.CalculateDuration(), status, errorMessage)
+		return suite
+	}
+
+	for _, step := range result.Steps {
+		duration, _ := time.ParseDuration(step.Duration)
+		var errorMessage string
+		for _, assertion := range step.AssertionResults {
+			if assertion.ErrorMessage != "" {
+				errorMessage += assertion.Name + ": " + assertion.ErrorMessage + "\n"
+			}
+		}
+
+		suite.add(step.Name, execution.TestName, duration, step.Status, errorMessage)
+	}
+
+	return suite
+}
+
+func mapTestSuiteExecutionToJUnit(execution testkube.TestSuiteExecution) JUnitTestSuite {
+	var suite JUnitTestSuite
+	if execution.TestSuite != nil {
+		suite.Name = execution.TestSuite.Name
+	}
+
+	for _, stepResult := range execution.StepResults {
+		if stepResult.Execution == nil || stepResult.Execution.TestName == "" {
+			// delay steps are not tests
+			continue
+		}
+
+		stepExecution := *stepResult.Execution
+		var status, errorMessage string
+		if stepExecution.ExecutionResult != nil {
+			status = statusString(stepExecution.ExecutionResult.Status)
+			errorMessage = stepExecution.ExecutionResult.ErrorMessage
+		}
+
+		suite.add(stepExecution.TestName, suite.Name, stepExecution.CalculateDuration(), status, errorMessage)
+	}
+
+	return suite
+}
+
+// add adds test case mapped from execution status to suite
+func (s *JUnitTestSuite) add(name, className string, duration time.Duration, status, errorMessage string) {
+	if duration < 0 {
+		duration = 0
+	}
+
+	testCase := JUnitTestCase{
+		Name:      name,
+		ClassName: className,
+		Time:      formatSeconds(duration),
+	}
+
+	switch testkube.ExecutionStatus(status) {
+	case testkube.PASSED_ExecutionStatus:
+	case testkube.FAILED_ExecutionStatus, testkube.TIMEOUT_ExecutionStatus:
+		testCase.Failure = &JUnitMessage{Message: status, Content: errorMessage}
+		s.Failures++
+	default:
+		testCase.Skipped = &JUnitMessage{Message: status}
+		s.Skipped++
+	}
+
+	s.Tests++
+	s.duration += duration
+	s.Time = formatSeconds(s.duration)
+	s.TestCases = append(s.TestCases, testCase)
+}
+
+func statusString(status *testkube.ExecutionStatus) string {
+	if status == nil {
+		return ""
+	}
+
+	return string(*status)
+}
+
+func formatSeconds(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
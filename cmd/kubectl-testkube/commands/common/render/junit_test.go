@@ -0,0 +1,76 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestNewJUnitReport(t *testing.T) {
+	t.Run("maps execution steps to test cases", func(t *testing.T) {
+		execution := testkube.Execution{
+			Name:     "execution-1",
+			TestName: "api-test",
+			ExecutionResult: &testkube.ExecutionResult{
+				Status: testkube.ExecutionStatusFailed,
+				Steps: []testkube.ExecutionStepResult{
+					{Name: "health", Duration: "1.5s", Status: "passed"},
+					{Name: "login", Duration: "500ms", Status: "failed", AssertionResults: []testkube.AssertionResult{
+						{Name: "status code is 200", Status: "failed", ErrorMessage: "expected 200 got 401"},
+					}},
+				},
+			},
+		}
+
+		report, err := NewJUnitReport(execution)
+
+		assert.NoError(t, err)
+		assert.Len(t, report.TestSuites, 1)
+		suite := report.TestSuites[0]
+		assert.Equal(t, "api-test", suite.Name)
+		assert.Equal(t, 2, suite.Tests)
+		assert.Equal(t, 1, suite.Failures)
+		assert.Equal(t, "2.000", suite.Time)
+		assert.Nil(t, suite.TestCases[0].Failure)
+		assert.Equal(t, "status code is 200: expected 200 got 401\n", suite.TestCases[1].Failure.Content)
+	})
+
+	t.Run("maps executions list to test cases", func(t *testing.T) {
+		start := time.Now()
+		report, err := NewJUnitReport(testkube.ExecutionsResult{Results: []testkube.ExecutionSummary{
+			{Name: "e1", TestName: "t1", Status: testkube.ExecutionStatusPassed, StartTime: start, EndTime: start.Add(time.Second)},
+			{Name: "e2", TestName: "t1", Status: testkube.ExecutionStatusTimeout, StartTime: start, EndTime: start.Add(time.Second)},
+			{Name: "e3", TestName: "t2", Status: testkube.ExecutionStatusRunning, StartTime: start},
+		}})
+
+		assert.NoError(t, err)
+		suite := report.TestSuites[0]
+		assert.Equal(t, 3, suite.Tests)
+		assert.Equal(t, 1, suite.Failures)
+		assert.Equal(t, 1, suite.Skipped)
+		assert.Equal(t, "0.000", suite.TestCases[2].Time)
+	})
+
+	t.Run("fails for non execution objects", func(t *testing.T) {
+		_, err := NewJUnitReport(testkube.Tests{})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRenderJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	err := RenderJUnit(testkube.Execution{
+		Name:            "execution-1",
+		TestName:        "api-test",
+		ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed},
+	}, &buf)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `<testsuite name="api-test" tests="1" failures="0" skipped="0" time="0.000">`)
+	assert.Contains(t, buf.String(), `<testcase name="execution-1" classname="api-test" time="0.000"></testcase>`)
+}
@@ -9,7 +9,7 @@ import (
 )
 
 func List(cmd *cobra.Command, obj interface{}, w io.Writer) error {
-	outputType := OutputType(cmd.Flag("output").Value.String())
+	outputType := GetOutputType(cmd)
 
 	switch outputType {
 	case OutputPretty:
@@ -24,13 +24,15 @@ func List(cmd *cobra.Command, obj interface{}, w io.Writer) error {
 		return RenderJSON(obj, w)
 	case OutputGoTemplate:
 		tpl := cmd.Flag("go-template").Value.String()
-		list, ok := obj.([]interface{})
+		list, ok := toList(obj)
 		if !ok {
-			return fmt.Errorf("can't render, need list type but got: %+v", obj)
+			return RenderGoTemplate(obj, w, tpl)
 		}
 		return RenderGoTemplateList(list, w, tpl)
+	case OutputJUnit:
+		return RenderJUnit(obj, w)
 	default:
-		return RenderYaml(obj, w)
+		return fmt.Errorf("unknown output type %s", outputType)
 	}
 
 }
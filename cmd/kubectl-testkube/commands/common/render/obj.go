@@ -9,7 +9,7 @@ import (
 )
 
 func Obj(cmd *cobra.Command, obj interface{}, w io.Writer, renderer ...CliObjRenderer) error {
-	outputType := OutputType(cmd.Flag("output").Value.String())
+	outputType := GetOutputType(cmd)
 
 	switch outputType {
 	case OutputPretty:
@@ -23,14 +23,11 @@ func Obj(cmd *cobra.Command, obj interface{}, w io.Writer, renderer ...CliObjRen
 		return RenderJSON(obj, w)
 	case OutputGoTemplate:
 		tpl := cmd.Flag("go-template").Value.String()
-		// need to make type assetion to list first
-		list, ok := obj.([]interface{})
-		if !ok {
-			return fmt.Errorf("can't render, need list type but got: %+v", obj)
-		}
-		return RenderGoTemplateList(list, w, tpl)
+		return RenderGoTemplate(obj, w, tpl)
+	case OutputJUnit:
+		return RenderJUnit(obj, w)
 	default:
-		return RenderYaml(obj, w)
+		return fmt.Errorf("unknown output type %s", outputType)
 	}
 
 }
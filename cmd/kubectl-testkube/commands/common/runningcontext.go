@@ -0,0 +1,57 @@
+package common
+
+import (
+	"os"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// GetRunningContext detects CI environment CLI is running in and returns its context, nil when not in CI
+func GetRunningContext() *testkube.RunningContext {
+	return getRunningContext(os.Getenv)
+}
+
+func getRunningContext(getenv func(string) string) *testkube.RunningContext {
+	var rc testkube.RunningContext
+
+	switch {
+	case getenv("GITHUB_ACTIONS") == "true":
+		rc = testkube.RunningContext{
+			Provider: "github-actions",
+			Commit:   getenv("GITHUB_SHA"),
+			Actor:    getenv("GITHUB_ACTOR"),
+		}
+		if getenv("GITHUB_RUN_ID") != "" {
+			rc.PipelineUrl = getenv("GITHUB_SERVER_URL") + "/" + getenv("GITHUB_REPOSITORY") + "/actions/runs/" + getenv("GITHUB_RUN_ID")
+		}
+
+	case getenv("GITLAB_CI") == "true":
+		rc = testkube.RunningContext{
+			Provider:    "gitlab-ci",
+			PipelineUrl: getenv("CI_PIPELINE_URL"),
+			Commit:      getenv("CI_COMMIT_SHA"),
+			Actor:       getenv("GITLAB_USER_LOGIN"),
+		}
+
+	case getenv("JENKINS_URL") != "":
+		rc = testkube.RunningContext{
+			Provider:    "jenkins",
+			PipelineUrl: getenv("BUILD_URL"),
+			Commit:      getenv("GIT_COMMIT"),
+			Actor:       getenv("BUILD_USER_ID"),
+		}
+
+	case getenv("CIRCLECI") == "true":
+		rc = testkube.RunningContext{
+			Provider:    "circleci",
+			PipelineUrl: getenv("CIRCLE_BUILD_URL"),
+			Commit:      getenv("CIRCLE_SHA1"),
+			Actor:       getenv("CIRCLE_USERNAME"),
+		}
+
+	default:
+		return nil
+	}
+
+	return &rc
+}
@@ -0,0 +1,38 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestGetRunningContext(t *testing.T) {
+
+	t.Run("github actions context", func(t *testing.T) {
+		env := map[string]string{
+			"GITHUB_ACTIONS":    "true",
+			"GITHUB_SHA":        "abc123",
+			"GITHUB_ACTOR":      "octocat",
+			"GITHUB_SERVER_URL": "https://github.com",
+			"GITHUB_REPOSITORY": "kubeshop/testkube",
+			"GITHUB_RUN_ID":     "42",
+		}
+
+		rc := getRunningContext(func(key string) string { return env[key] })
+
+		assert.Equal(t, &testkube.RunningContext{
+			Provider:    "github-actions",
+			PipelineUrl: "https://github.com/kubeshop/testkube/actions/runs/42",
+			Commit:      "abc123",
+			Actor:       "octocat",
+		}, rc)
+	})
+
+	t.Run("no CI context", func(t *testing.T) {
+		rc := getRunningContext(func(key string) string { return "" })
+
+		assert.Nil(t, rc)
+	})
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// AddTriggerFlags adds --trigger and --trigger-param flags of tests and test suites executed on success
+func AddTriggerFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArray("trigger", nil, "test or test suite executed when execution passes in format [test/|testsuite/]name: --trigger testsuite/e2e")
+	cmd.Flags().StringToString("trigger-param", nil, "param passed to triggered executions: --trigger-param environment=staging")
+}
+
+// NewTriggersFromFlags returns existing triggers replaced by passed triggers, trigger params are set for all triggers
+// and empty trigger clears them
+func NewTriggersFromFlags(cmd *cobra.Command, existing []testkube.ExecutionTrigger) ([]testkube.ExecutionTrigger, error) {
+	if !cmd.Flags().Changed("trigger") && !cmd.Flags().Changed("trigger-param") {
+		return existing, nil
+	}
+
+	triggers := append([]testkube.ExecutionTrigger{}, existing...)
+	if cmd.Flags().Changed("trigger") {
+		values, err := cmd.Flags().GetStringArray("trigger")
+		if err != nil {
+			return nil, err
+		}
+
+		triggers = nil
+		for _, value := range values {
+			if value == "" {
+				continue
+			}
+
+			trigger, err := testkube.ParseExecutionTrigger(value)
+			if err != nil {
+				return nil, err
+			}
+
+			triggers = append(triggers, trigger)
+		}
+	}
+
+	if cmd.Flags().Changed("trigger-param") {
+		params, err := cmd.Flags().GetStringToString("trigger-param")
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range triggers {
+			triggers[i].Params = params
+		}
+	}
+
+	return triggers, testkube.ValidateExecutionTriggers(triggers)
+}
@@ -0,0 +1,43 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestNewTriggersFromFlags(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		AddTriggerFlags(cmd)
+		assert.NoError(t, cmd.Flags().Parse(args))
+		return cmd
+	}
+	existing := []testkube.ExecutionTrigger{{Type_: testkube.ExecutionTriggerTypeTest, Name: "api"}}
+
+	triggers, err := NewTriggersFromFlags(newCmd(), existing)
+	assert.NoError(t, err)
+	assert.Equal(t, existing, triggers)
+
+	triggers, err = NewTriggersFromFlags(newCmd("--trigger", "testsuite/e2e", "--trigger", "smoke", "--trigger-param", "env=staging"), existing)
+	assert.NoError(t, err)
+	assert.Equal(t, []testkube.ExecutionTrigger{
+		{Type_: testkube.ExecutionTriggerTypeTestSuite, Name: "e2e", Params: map[string]string{"env": "staging"}},
+		{Type_: testkube.ExecutionTriggerTypeTest, Name: "smoke", Params: map[string]string{"env": "staging"}},
+	}, triggers)
+
+	triggers, err = NewTriggersFromFlags(newCmd("--trigger-param", "env=staging"), existing)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"env": "staging"}, triggers[0].Params)
+	assert.Nil(t, existing[0].Params, "existing triggers aren't changed")
+
+	triggers, err = NewTriggersFromFlags(newCmd("--trigger", ""), existing)
+	assert.NoError(t, err)
+	assert.Empty(t, triggers)
+
+	_, err = NewTriggersFromFlags(newCmd("--trigger", "smoke", "--trigger", "test/smoke"), nil)
+	assert.Error(t, err)
+}
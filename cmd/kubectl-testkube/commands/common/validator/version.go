@@ -16,6 +16,11 @@ var ErrOldClientVersion = fmt.Errorf("client version is older than api version,
 func PersistentPreRunVersionCheck(cmd *cobra.Command, clientVersion string) {
 	// version validation
 	// if client version is less than server version show warning
+	// offline commands rendering manifests don't connect to API server
+	if crdOnly, err := cmd.Flags().GetBool("crd-only"); err == nil && crdOnly {
+		return
+	}
+
 	client, _ := common.GetClient(cmd)
 	info, err := client.GetServerInfo()
 	if err != nil {
@@ -0,0 +1,87 @@
+package crds
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	testsv2 "github.com/kubeshop/testkube-operator/apis/tests/v2"
+	testsuitesv1 "github.com/kubeshop/testkube-operator/apis/testsuite/v1"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/jobs"
+	testsmapper "github.com/kubeshop/testkube/pkg/mapper/tests"
+	testsuitesmapper "github.com/kubeshop/testkube/pkg/mapper/testsuites"
+	"github.com/kubeshop/testkube/pkg/secret"
+)
+
+const (
+	// ManifestSeparator separates YAML documents in rendered output
+	ManifestSeparator = "---\n"
+
+	gitUsernamePlaceholder = "<git-username>"
+	gitTokenPlaceholder    = "<git-token>"
+)
+
+// RenderTestCRD renders test upsert request as Test custom resource YAML manifest
+func RenderTestCRD(request testkube.TestUpsertRequest) (string, error) {
+	test := testsmapper.MapToSpec(request)
+	test.APIVersion = testsv2.GroupVersion.String()
+	test.Kind = "Test"
+
+	return render(test)
+}
+
+// RenderTestWithSecretCRD renders Test custom resource with git credentials moved to Secret manifest with placeholder values
+func RenderTestWithSecretCRD(request testkube.TestUpsertRequest) (string, error) {
+	if request.Content != nil && request.Content.Repository != nil {
+		content := *request.Content
+		repository := *content.Repository
+		repository.Username = ""
+		repository.Token = ""
+		content.Repository = &repository
+		request.Content = &content
+	}
+
+	test, err := RenderTestCRD(request)
+	if err != nil {
+		return "", err
+	}
+
+	testSecret, err := RenderTestSecretCRD(request.Name, request.Namespace, request.Labels)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{test, testSecret}, ManifestSeparator), nil
+}
+
+// RenderTestSecretCRD renders test git credentials Secret YAML manifest with placeholder values
+func RenderTestSecretCRD(testName, namespace string, labels map[string]string) (string, error) {
+	testSecret := secret.NewSpec(secret.GetMetadataName(testName), namespace, labels, map[string]string{
+		jobs.GitUsernameSecretName: gitUsernamePlaceholder,
+		jobs.GitTokenSecretName:    gitTokenPlaceholder,
+	})
+	testSecret.APIVersion = corev1.SchemeGroupVersion.String()
+	testSecret.Kind = "Secret"
+
+	return render(testSecret)
+}
+
+// RenderTestSuiteCRD renders test suite upsert request as TestSuite custom resource YAML manifest
+func RenderTestSuiteCRD(request testkube.TestSuiteUpsertRequest) (string, error) {
+	testSuite := testsuitesmapper.MapTestSuiteUpsertRequestToTestCRD(request)
+	testSuite.APIVersion = testsuitesv1.GroupVersion.String()
+	testSuite.Kind = "TestSuite"
+
+	return render(testSuite)
+}
+
+func render(object interface{}) (string, error) {
+	data, err := yaml.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,54 @@
+package crds
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestRenderTestWithSecretCRD(t *testing.T) {
+	request := testkube.TestUpsertRequest{
+		Name:      "private-test",
+		Namespace: "testkube",
+		Type_:     "curl/test",
+		Content: &testkube.TestContent{
+			Type_: string(testkube.TestContentTypeGitDir),
+			Repository: &testkube.Repository{
+				Type_:    "git",
+				Uri:      "https://github.com/org/repo.git",
+				Branch:   "main",
+				Username: "user",
+				Token:    "secret-token",
+			},
+		},
+	}
+
+	manifests, err := RenderTestWithSecretCRD(request)
+
+	assert.NoError(t, err)
+	documents := strings.Split(manifests, ManifestSeparator)
+	assert.Len(t, documents, 2)
+	assert.Contains(t, documents[0], "kind: Test\n")
+	assert.Contains(t, documents[1], "kind: Secret\n")
+	assert.Contains(t, documents[1], "name: private-test-secrets")
+	assert.Contains(t, documents[1], "git-token: <git-token>")
+	assert.NotContains(t, manifests, "secret-token")
+	assert.Equal(t, "secret-token", request.Content.Repository.Token)
+}
+
+func TestRenderTestSuiteCRD(t *testing.T) {
+	manifest, err := RenderTestSuiteCRD(testkube.TestSuiteUpsertRequest{
+		Name:      "smoke",
+		Namespace: "testkube",
+		Steps: []testkube.TestSuiteStep{
+			{Execute: &testkube.TestSuiteStepExecuteTest{Name: "api-test"}},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, manifest, "apiVersion: tests.testkube.io/v1\nkind: TestSuite\n")
+	assert.Contains(t, manifest, "name: api-test")
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/tests"
 	"github.com/kubeshop/testkube/pkg/ui"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -94,15 +95,21 @@ func NewDownloadSingleArtifactsCmd() *cobra.Command {
 }
 
 func NewDownloadAllArtifactsCmd() *cobra.Command {
+	var (
+		mask        string
+		concurrency int
+	)
+
 	cmd := &cobra.Command{
 		Use:     "artifacts <executionID>",
 		Aliases: []string{"a"},
 		Short:   "download artifacts",
+		Long:    `Download execution artifacts matching mask in parallel, directory structure is preserved and downloaded files are verified`,
 		Args:    validator.ExecutionID,
 		Run: func(cmd *cobra.Command, args []string) {
 			executionID := args[0]
 			client, _ := common.GetClient(cmd)
-			tests.DownloadArtifacts(executionID, downloadDir, client)
+			tests.DownloadArtifacts(executionID, downloadDir, mask, concurrency, client)
 		},
 	}
 
@@ -110,7 +117,15 @@ func NewDownloadAllArtifactsCmd() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "should I show additional debug messages")
 
 	cmd.PersistentFlags().StringVarP(&executionID, "execution-id", "e", "", "ID of the execution")
-	cmd.Flags().StringVar(&downloadDir, "download-dir", "artifacts", "download dir")
+	cmd.Flags().StringVar(&downloadDir, "dir", "artifacts", "download dir (alias --download-dir)")
+	cmd.Flags().StringVar(&mask, "mask", "", "glob mask of artifacts to download, e.g. **/*.png - all artifacts are downloaded if not set")
+	cmd.Flags().IntVar(&concurrency, "concurrency", tests.DefaultDownloadConcurrency, "number of artifacts downloaded in parallel")
+	cmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "download-dir" {
+			name = "dir"
+		}
+		return pflag.NormalizedName(name)
+	})
 
 	// output renderer flags
 	return cmd
@@ -0,0 +1,24 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/executors"
+	"github.com/kubeshop/testkube/pkg/ui"
+)
+
+func NewExecutorsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "executors <command>",
+		Aliases: []string{"executor"},
+		Short:   "Develop executors",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			ui.PrintOnError("Displaying help", err)
+		},
+	}
+
+	cmd.AddCommand(executors.NewVerifyExecutorCmd())
+
+	return cmd
+}
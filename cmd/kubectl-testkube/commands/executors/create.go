@@ -2,6 +2,7 @@ package executors
 
 import (
 	"io/ioutil"
+	"time"
 
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common"
 	apiClient "github.com/kubeshop/testkube/pkg/api/v1/client"
@@ -14,6 +15,8 @@ func NewCreateExecutorCmd() *cobra.Command {
 		types                                       []string
 		name, executorType, image, uri, jobTemplate string
 		labels                                      map[string]string
+		verifyFile, verifyFailingFile               string
+		verifyArtifacts                             bool
 	)
 
 	cmd := &cobra.Command{
@@ -43,6 +46,18 @@ func NewCreateExecutorCmd() *cobra.Command {
 				jobTemplateContent = string(b)
 			}
 
+			placement, err := common.NewPlacementFromFlags(cmd, nil)
+			ui.ExitOnError("reading placement", err)
+
+			// executor image is registered only when it passes conformance checks
+			if verifyFile != "" {
+				if image == "" || len(types) == 0 {
+					ui.Failf("pass executor image (in '--image' flag) and types (in '--types' flag) to verify executor")
+				}
+
+				verifyExecutor(image, types[0], verifyFile, verifyFailingFile, nil, 2*time.Minute, verifyArtifacts)
+			}
+
 			options := apiClient.CreateExecutorOptions{
 				Name:         name,
 				Namespace:    namespace,
@@ -52,6 +67,7 @@ func NewCreateExecutorCmd() *cobra.Command {
 				Uri:          uri,
 				JobTemplate:  jobTemplateContent,
 				Labels:       labels,
+				Placement:    placement,
 			}
 
 			_, err = client.CreateExecutor(options)
@@ -69,6 +85,10 @@ func NewCreateExecutorCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&image, "image", "i", "", "if uri is git repository we can set additional branch parameter")
 	cmd.Flags().StringVarP(&jobTemplate, "job-template", "j", "", "if executor needs to be launched using custom job specification")
 	cmd.Flags().StringToStringVarP(&labels, "label", "l", nil, "label key value pair: --label key1=value1")
+	cmd.Flags().StringVar(&verifyFile, "verify-file", "", "passing test file, executor image is verified with conformance checks before it's created when set")
+	cmd.Flags().StringVar(&verifyFailingFile, "verify-failing-file", "", "failing test file of conformance checks")
+	cmd.Flags().BoolVar(&verifyArtifacts, "verify-artifacts", false, "check passing test uploads artifacts to storage in conformance checks")
+	common.AddPlacementFlags(cmd)
 
 	return cmd
 }
@@ -0,0 +1,76 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubeshop/testkube/pkg/executor/conformance"
+	"github.com/kubeshop/testkube/pkg/ui"
+)
+
+func NewVerifyExecutorCmd() *cobra.Command {
+	var (
+		testType, file, failingFile string
+		params                      map[string]string
+		timeout                     time.Duration
+		artifacts                   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify <image>",
+		Short: "Verifies executor image conforms to executor protocol",
+		Long: `Runs executor image with docker against canned inputs and checks its output protocol, result, ` +
+			`artifacts and error handling, executors should pass before they are registered`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if file == "" {
+				ui.Failf("pass passing test file (in '--file' flag)")
+			}
+
+			verifyExecutor(args[0], testType, file, failingFile, params, timeout, artifacts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&testType, "type", "t", "", "test type handled by executor, e.g. postman/collection")
+	cmd.Flags().StringVarP(&file, "file", "f", "", "passing test file - mandatory")
+	cmd.Flags().StringVar(&failingFile, "failing-file", "", "failing test file, failing test result is checked when set")
+	cmd.Flags().StringToStringVarP(&params, "param", "p", nil, "execution param key value pair: --param key1=value1")
+	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "timeout of every check")
+	cmd.Flags().BoolVar(&artifacts, "artifacts", false, "check passing test uploads artifacts to storage")
+
+	return cmd
+}
+
+// verifyExecutor runs conformance checks of executor image with docker and fails when any check fails
+func verifyExecutor(image, testType, file, failingFile string, params map[string]string, timeout time.Duration, artifacts bool) {
+	content, err := ioutil.ReadFile(file)
+	ui.ExitOnError("reading passing test file", err)
+
+	options := conformance.Options{Type: testType, Content: string(content), Params: params, Timeout: timeout, Artifacts: artifacts}
+	if failingFile != "" {
+		failingContent, err := ioutil.ReadFile(failingFile)
+		ui.ExitOnError("reading failing test file", err)
+		options.FailingContent = string(failingContent)
+	}
+
+	failed := 0
+	for _, result := range conformance.Verify(context.Background(), conformance.DockerRunner{Image: image}, options) {
+		if result.Err != nil {
+			failed++
+			ui.Errf("%s: %s", result.Name, result.Err)
+			continue
+		}
+
+		ui.Success(result.Name)
+	}
+
+	if failed > 0 {
+		ui.Failf("executor %s failed %d conformance checks", image, failed)
+	}
+
+	ui.Success(fmt.Sprintf("Executor %s conforms to executor protocol", image))
+}
@@ -23,6 +23,7 @@ func NewGenerateCmd() *cobra.Command {
 
 	cmd.AddCommand(crds.NewCRDTestsCmd())
 	cmd.AddCommand(generate.NewDocsCmd())
+	cmd.AddCommand(generate.NewTestCmd())
 
 	return cmd
 }
@@ -0,0 +1,112 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common"
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/crds"
+	"github.com/kubeshop/testkube/pkg/api/v1/client"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/test/detector"
+	"github.com/kubeshop/testkube/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputCRD  = "crd"
+	outputJSON = "json"
+)
+
+func NewTestCmd() *cobra.Command {
+	var (
+		testName string
+		file     string
+		testType string
+		output   string
+		upload   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "test",
+		Aliases: []string{"tests", "t"},
+		Short:   "Generate test definition from existing test file",
+		Long:    `Generate test definition from existing test file as Test CRD YAML manifest or API request JSON, test type is detected from file content if not passed`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if file == "" {
+				ui.Failf("pass valid test file (in '--file' flag)")
+			}
+
+			namespace := cmd.Flag("namespace").Value.String()
+			request, err := NewTestUpsertRequestFromFile(file, testName, testType, namespace)
+			ui.ExitOnError("generating test definition", err)
+
+			if upload {
+				apiClient, _ := common.GetClient(cmd)
+				_, err = apiClient.CreateTest(client.UpsertTestOptions(request))
+				ui.ExitOnError("creating test "+request.Name+" in namespace "+namespace, err)
+
+				ui.Success("Test created", namespace, "/", request.Name)
+				return
+			}
+
+			switch output {
+			case outputCRD:
+				manifest, err := crds.RenderTestCRD(request)
+				ui.ExitOnError("rendering test CRD", err)
+				fmt.Print(manifest)
+			case outputJSON:
+				data, err := json.MarshalIndent(request, "", "  ")
+				ui.ExitOnError("rendering test request", err)
+				fmt.Println(string(data))
+			default:
+				ui.Failf("invalid output format '%s' use one of: %s, %s", output, outputCRD, outputJSON)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "test file - mandatory")
+	cmd.Flags().StringVarP(&testName, "name", "", "", "test name - defaults to file name")
+	cmd.Flags().StringVarP(&testType, "type", "t", "", "test type - detected from file content if not passed")
+	cmd.Flags().StringVarP(&output, "output", "o", outputCRD, "output format one of: crd|json")
+	cmd.Flags().BoolVarP(&upload, "upload", "", false, "create test with API instead of printing its definition")
+
+	return cmd
+}
+
+// NewTestUpsertRequestFromFile creates test upsert request with file content inlined
+func NewTestUpsertRequestFromFile(file, testName, testType, namespace string) (request testkube.TestUpsertRequest, err error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return request, err
+	}
+
+	if testName == "" {
+		testName = crds.SanitizeName(strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)))
+	}
+
+	request = testkube.TestUpsertRequest{
+		Name:      testName,
+		Namespace: namespace,
+		Type_:     testType,
+		Content: &testkube.TestContent{
+			Type_: string(testkube.TestContentTypeString),
+			Data:  string(content),
+		},
+	}
+
+	if request.Type_ == "" {
+		d := detector.NewDefaultDetector()
+		detectedType, ok := d.Detect(client.UpsertTestOptions(request))
+		if !ok {
+			return request, fmt.Errorf("can't detect test type of %s, pass it in '--type' flag", file)
+		}
+
+		request.Type_ = detectedType
+	}
+
+	return request, nil
+}
@@ -0,0 +1,48 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/crds"
+)
+
+const collection = `{"info":{"_postman_id":"3d9a6be2-bd3e-4cf7-89ca-354103aab4a7","name":"Kubeshop","schema":"https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},"item":[]}`
+
+func TestNewTestUpsertRequestFromFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "My Collection.postman_collection.json")
+	assert.NoError(t, os.WriteFile(file, []byte(collection), 0644))
+
+	t.Run("type detected from content", func(t *testing.T) {
+		request, err := NewTestUpsertRequestFromFile(file, "", "", "testkube")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "my-collection-postman-collection", request.Name)
+		assert.Equal(t, "postman/collection", request.Type_)
+		assert.Equal(t, collection, request.Content.Data)
+	})
+
+	t.Run("passed type and name", func(t *testing.T) {
+		request, err := NewTestUpsertRequestFromFile(file, "api-test", "newman/collection", "testkube")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "api-test", request.Name)
+		assert.Equal(t, "newman/collection", request.Type_)
+	})
+
+	t.Run("rendered as CRD", func(t *testing.T) {
+		request, err := NewTestUpsertRequestFromFile(file, "api-test", "", "testkube")
+		assert.NoError(t, err)
+
+		manifest, err := crds.RenderTestCRD(request)
+
+		assert.NoError(t, err)
+		assert.Contains(t, manifest, "apiVersion: tests.testkube.io/v2")
+		assert.Contains(t, manifest, "kind: Test")
+		assert.Contains(t, manifest, "name: api-test")
+		assert.Contains(t, manifest, "type: postman/collection")
+	})
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/artifacts"
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common/render"
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common/validator"
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/executors"
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/tests"
@@ -33,8 +34,7 @@ func NewGetCmd() *cobra.Command {
 	cmd.AddCommand(artifacts.NewListArtifactsCmd())
 	cmd.AddCommand(testsuites.NewTestSuiteExecutionCmd())
 
-	cmd.PersistentFlags().StringP("output", "o", "pretty", "output type can be one of json|yaml|pretty|go-template")
-	cmd.PersistentFlags().StringP("go-template", "", "{{.}}", "go template to render")
+	render.AddOutputFlags(cmd)
 
 	return cmd
 }
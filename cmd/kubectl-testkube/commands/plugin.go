@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubeshop/testkube/pkg/plugin"
+	"github.com/kubeshop/testkube/pkg/ui"
+)
+
+func NewPluginCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin <command>",
+		Short: "Manage CLI plugins",
+		Long:  `Executables named testkube-<name> on PATH are run as <name> subcommands, e.g. testkube-release-gate for "kubectl testkube release-gate"`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			ui.PrintOnError("Displaying help", err)
+		},
+	}
+
+	cmd.AddCommand(NewListPluginsCmd())
+
+	return cmd
+}
+
+func NewListPluginsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists plugins found on PATH",
+		Run: func(cmd *cobra.Command, args []string) {
+			plugins, err := plugin.List(os.Getenv("PATH"))
+			ui.ExitOnError("listing plugins", err)
+
+			if len(plugins) == 0 {
+				ui.Warn("No plugins found on PATH")
+				return
+			}
+
+			for _, p := range plugins {
+				ui.Info(p.Name, p.Path)
+			}
+		},
+	}
+}
+
+// runPlugin runs plugin for args which aren't built-in command and exits with its exit code,
+// built-in commands can't be overridden by plugins, global flags can lead plugin name
+func runPlugin(args []string) {
+	args, err := plugin.ParseFlags(RootCmd.PersistentFlags(), args)
+	if err != nil || len(args) == 0 {
+		return
+	}
+
+	if cmd, _, err := RootCmd.Find(args); err == nil && cmd != RootCmd {
+		return
+	}
+
+	path, pluginArgs, ok := plugin.Find(args, exec.LookPath)
+	if !ok {
+		return
+	}
+
+	code, err := plugin.Run(path, pluginArgs, plugin.Settings{
+		Namespace: namespace,
+		Client:    client,
+		APIURI:    apiURI,
+		APIToken:  apiToken,
+	})
+	ui.ExitOnError("running plugin", err)
+
+	os.Exit(code)
+}
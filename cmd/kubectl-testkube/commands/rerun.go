@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common/validator"
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/tests"
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/testsuites"
+	"github.com/kubeshop/testkube/pkg/ui"
+)
+
+func NewRerunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rerun <resourceName>",
+		Short: "Rerun finished executions",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			ui.PrintOnError("Displaying help", err)
+		},
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			validator.PersistentPreRunVersionCheck(cmd, Version)
+		}}
+
+	cmd.AddCommand(tests.NewRerunExecutionCmd())
+	cmd.AddCommand(testsuites.NewRerunTestSuiteExecutionCmd())
+
+	return cmd
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/tui"
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/config"
 	"github.com/kubeshop/testkube/pkg/analytics"
 	"github.com/kubeshop/testkube/pkg/ui"
@@ -21,6 +22,8 @@ var (
 	client           string
 	verbose          bool
 	namespace        string
+	apiURI           string
+	apiToken         string
 )
 
 func init() {
@@ -32,6 +35,8 @@ func init() {
 	RootCmd.AddCommand(NewRunCmd())
 	RootCmd.AddCommand(NewDeleteCmd())
 	RootCmd.AddCommand(NewAbortCmd())
+	RootCmd.AddCommand(NewApproveCmd())
+	RootCmd.AddCommand(NewRerunCmd())
 
 	RootCmd.AddCommand(NewEnableCmd())
 	RootCmd.AddCommand(NewDisableCmd())
@@ -45,10 +50,13 @@ func init() {
 	RootCmd.AddCommand(NewUninstallCmd())
 	RootCmd.AddCommand(NewWatchCmd())
 	RootCmd.AddCommand(NewDashboardCmd())
+	RootCmd.AddCommand(tui.NewTuiCmd())
 	RootCmd.AddCommand(NewMigrateCmd())
 	RootCmd.AddCommand(NewVersionCmd())
 
 	RootCmd.AddCommand(NewConfigCmd())
+	RootCmd.AddCommand(NewPluginCmd())
+	RootCmd.AddCommand(NewExecutorsCmd())
 }
 
 var RootCmd = &cobra.Command{
@@ -105,9 +113,13 @@ func Execute() {
 
 	RootCmd.PersistentFlags().BoolVarP(&analyticsEnabled, "analytics-enabled", "", cfg.AnalyticsEnabled, "enable analytics")
 	RootCmd.PersistentFlags().StringVarP(&client, "client", "c", "proxy", "client used for connecting to Testkube API one of proxy|direct")
+	RootCmd.PersistentFlags().StringVarP(&apiURI, "api-uri", "", os.Getenv("TESTKUBE_API_URI"), "Testkube API server URI e.g. exposed with Ingress, overrides client type and doesn't need kubernetes access")
+	RootCmd.PersistentFlags().StringVarP(&apiToken, "api-token", "", os.Getenv("TESTKUBE_API_TOKEN"), "bearer token passed to API server URI, prefer TESTKUBE_API_TOKEN env to keep it out of shell history")
 	RootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "s", defaultNamespace, "Kubernetes namespace, default value read from config if set")
 	RootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "show additional debug messages")
 
+	runPlugin(os.Args[1:])
+
 	if err := RootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
@@ -17,6 +17,7 @@ func NewStatusCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(analytics.NewStatusAnalyticsCmd())
+	cmd.AddCommand(NewStatusAPICmd())
 
 	return cmd
 }
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common"
+	"github.com/kubeshop/testkube/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+func NewStatusAPICmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api",
+		Short: "Get API server dependencies health",
+		Run: func(cmd *cobra.Command, args []string) {
+			client, _ := common.GetClient(cmd)
+			report, err := client.GetServerHealth()
+			ui.ExitOnError("getting API server health", err)
+
+			d := [][]string{{"Dependency", "Status", "Latency", "Error"}}
+			for _, check := range report.Checks {
+				d = append(d, []string{check.Name, check.Status, check.Latency, check.Error})
+			}
+
+			ui.Table(ui.NewArrayTable(d), ui.Writer)
+			ui.NL()
+			ui.Info("Events queue", fmt.Sprintf("%d/%d", report.EventsQueueDepth, report.EventsQueueCapacity))
+
+			if !report.IsHealthy() {
+				ui.Failf("API server is not healthy")
+			}
+
+			ui.Success("API server", "healthy")
+		},
+	}
+
+	return cmd
+}
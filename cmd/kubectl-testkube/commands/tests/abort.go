@@ -19,8 +19,31 @@ func NewAbortExecutionCmd() *cobra.Command {
 
 			client, _ := common.GetClient(cmd)
 
-			err := client.AbortExecution("test", executionID)
+			// executions are aborted under their test, so test name is read from execution
+			execution, err := client.GetExecution(executionID)
+			ui.ExitOnError(fmt.Sprintf("getting execution %s", executionID), err)
+
+			err = client.AbortExecution(execution.TestName, executionID)
 			ui.ExitOnError(fmt.Sprintf("aborting execution %s", executionID), err)
 		},
 	}
 }
+
+func NewAbortExecutionGroupCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "executiongroup <groupID>",
+		Aliases: []string{"eg", "execution-group"},
+		Short:   "Aborts executions of the execution group",
+		Long:    `Aborts queued and running executions started together by selector or matrix run, or steps of test suite execution`,
+		Args:    validator.ExecutionID,
+		Run: func(cmd *cobra.Command, args []string) {
+			groupID := args[0]
+
+			client, _ := common.GetClient(cmd)
+
+			err := client.AbortExecutionGroup(groupID)
+			ui.ExitOnError(fmt.Sprintf("aborting execution group %s", groupID), err)
+			ui.Success("Execution group aborted", groupID)
+		},
+	}
+}
@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	apiclientv1 "github.com/kubeshop/testkube/pkg/api/v1/client"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/ui"
+)
+
+// DefaultDownloadConcurrency is a default number of artifacts downloaded in parallel
+const DefaultDownloadConcurrency = 4
+
+// DownloadArtifacts downloads execution artifacts matching mask in parallel, preserving their directory structure
+func DownloadArtifacts(id, dir, mask string, concurrency int, client apiclientv1.Client) {
+	artifacts, err := client.GetPresignedExecutionArtifacts(id)
+	ui.ExitOnError("getting artifacts ", err)
+
+	artifacts, err = FilterArtifacts(artifacts, mask)
+	ui.ExitOnError("filtering artifacts by mask "+mask, err)
+
+	err = os.MkdirAll(dir, os.ModePerm)
+	ui.ExitOnError("creating dir "+dir, err)
+
+	downloadable := testkube.Artifacts{}
+	for _, artifact := range artifacts {
+		if !artifact.ScanStatus.IsDownloadable() {
+			ui.Warn(fmt.Sprintf(" - skipping file %s, scan status is %s", artifact.Name, *artifact.ScanStatus))
+			continue
+		}
+		downloadable = append(downloadable, artifact)
+	}
+	artifacts = downloadable
+
+	if len(artifacts) > 0 {
+		ui.Info("Getting artifacts", fmt.Sprintf("count = %d", len(artifacts)), "\n")
+	}
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed int
+	)
+
+	semaphore := make(chan struct{}, concurrency)
+	for _, artifact := range artifacts {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(artifact testkube.Artifact) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			path, err := client.DownloadArtifact(id, artifact, dir)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed++
+				ui.Errf("downloading file %s: %s", artifact.Name, err)
+				return
+			}
+
+			ui.Warn(" - downloaded file ", path)
+		}(artifact)
+	}
+
+	wg.Wait()
+
+	if failed > 0 {
+		ui.Failf("%d of %d artifacts failed to download", failed, len(artifacts))
+	}
+
+	ui.NL()
+	ui.NL()
+}
+
+// FilterArtifacts returns artifacts with names matching glob mask, all artifacts are returned for empty mask
+func FilterArtifacts(artifacts testkube.Artifacts, mask string) (testkube.Artifacts, error) {
+	if mask == "" {
+		return artifacts, nil
+	}
+
+	re, err := compileMask(mask)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered testkube.Artifacts
+	for _, artifact := range artifacts {
+		if re.MatchString(artifact.Name) {
+			filtered = append(filtered, artifact)
+		}
+	}
+
+	return filtered, nil
+}
+
+// compileMask converts glob mask to regular expression, "**" matches any number of directories,
+// "*" and "?" don't match path separator
+func compileMask(mask string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(mask); i++ {
+		switch {
+		case strings.HasPrefix(mask[i:], "**/"):
+			expr.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(mask[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case mask[i] == '*':
+			expr.WriteString("[^/]*")
+		case mask[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(mask[i])))
+		}
+	}
+	expr.WriteString("$")
+
+	return regexp.Compile(expr.String())
+}
@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestFilterArtifacts(t *testing.T) {
+	artifacts := testkube.Artifacts{
+		{Name: "report.html"},
+		{Name: "screenshot.png"},
+		{Name: "screenshots/login.png"},
+		{Name: "screenshots/mobile/home.png"},
+		{Name: "videos/login.mp4"},
+	}
+
+	tests := map[string]struct {
+		mask     string
+		expected []string
+	}{
+		"empty mask":          {mask: "", expected: []string{"report.html", "screenshot.png", "screenshots/login.png", "screenshots/mobile/home.png", "videos/login.mp4"}},
+		"any directory":       {mask: "**/*.png", expected: []string{"screenshot.png", "screenshots/login.png", "screenshots/mobile/home.png"}},
+		"single directory":    {mask: "screenshots/*.png", expected: []string{"screenshots/login.png"}},
+		"directory subtree":   {mask: "screenshots/**", expected: []string{"screenshots/login.png", "screenshots/mobile/home.png"}},
+		"single character":    {mask: "videos/logi?.mp4", expected: []string{"videos/login.mp4"}},
+		"literal dot":         {mask: "report.htm?", expected: []string{"report.html"}},
+		"no matching files":   {mask: "*.xml", expected: nil},
+		"root files wildcard": {mask: "*", expected: []string{"report.html", "screenshot.png"}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			filtered, err := FilterArtifacts(artifacts, tt.mask)
+
+			assert.NoError(t, err)
+			var names []string
+			for _, artifact := range filtered {
+				names = append(names, artifact.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestArtifactsTable(t *testing.T) {
+	header, output := testkube.Artifacts{{Name: "report.html", Size: 10}}.Table()
+	assert.Equal(t, []string{"Name", "Size (KB)"}, header)
+	assert.Equal(t, [][]string{{"report.html", "10"}}, output)
+
+	header, output = testkube.Artifacts{{Name: "report.html", Size: 10, ScanStatus: testkube.ArtifactScanStatusQuarantined}, {Name: "log.txt"}}.Table()
+	assert.Equal(t, []string{"Name", "Size (KB)", "Scan"}, header)
+	assert.Equal(t, [][]string{{"report.html", "10", "quarantined"}, {"log.txt", "0", ""}}, output)
+}
+
+func TestArtifactVerify(t *testing.T) {
+	artifact := testkube.Artifact{Name: "report.html", Size: 8, Checksum: "md5", Sha256: "sha256"}
+
+	assert.NoError(t, artifact.Verify(8, "MD5", "SHA256"))
+	assert.Error(t, artifact.Verify(7, "md5", "sha256"), "truncated file")
+	assert.Error(t, artifact.Verify(8, "md5", "tampered"), "tampered file")
+	assert.NoError(t, testkube.Artifact{Size: 8}.Verify(8, "md5", "sha256"), "checksums aren't known")
+}
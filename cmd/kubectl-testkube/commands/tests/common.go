@@ -2,11 +2,17 @@ package tests
 
 import (
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common"
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/crds"
 	apiclientv1 "github.com/kubeshop/testkube/pkg/api/v1/client"
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/kubeshop/testkube/pkg/executor/output"
@@ -22,6 +28,12 @@ func printExecutionDetails(execution testkube.Execution) {
 		ui.Warn("Execution ID  :", execution.Id)
 		ui.Warn("Execution name:", execution.Name)
 	}
+	if execution.RerunOf != "" {
+		ui.Warn("Rerun of      :", execution.RerunOf)
+	}
+	if !execution.RunningContext.IsEmpty() {
+		ui.Warn("Context       :", execution.RunningContext.String())
+	}
 	if len(execution.Params) > 0 {
 		ui.Warn("Params        :", fmt.Sprintf("%d", len(execution.Params)))
 		for k, v := range execution.Params {
@@ -32,27 +44,8 @@ func printExecutionDetails(execution testkube.Execution) {
 	ui.NL()
 }
 
-func DownloadArtifacts(id, dir string, client apiclientv1.Client) {
-	artifacts, err := client.GetExecutionArtifacts(id)
-	ui.ExitOnError("getting artifacts ", err)
-
-	err = os.MkdirAll(dir, os.ModePerm)
-	ui.ExitOnError("creating dir "+dir, err)
-
-	if len(artifacts) > 0 {
-		ui.Info("Getting artifacts", fmt.Sprintf("count = %d", len(artifacts)), "\n")
-	}
-	for _, artifact := range artifacts {
-		f, err := client.DownloadFile(id, artifact.Name, dir)
-		ui.ExitOnError("downloading file: "+f, err)
-		ui.Warn(" - downloading file ", f)
-	}
-
-	ui.NL()
-	ui.NL()
-}
-
-func watchLogs(id string, client apiclientv1.Client) {
+// watchLogs streams executor logs and polls execution state until it's completed, returns final execution
+func watchLogs(id string, client apiclientv1.Client) testkube.Execution {
 	ui.Info("Getting pod logs")
 
 	logs, err := client.Logs(id)
@@ -66,9 +59,6 @@ func watchLogs(id string, client apiclientv1.Client) {
 				ui.Errf("Error: %s", l.Result.ErrorMessage)
 				ui.Debug("Output: %s", l.Result.Output)
 			}
-			uiShellGetExecution(id)
-			os.Exit(1)
-			return
 		case output.TypeResult:
 			ui.Info("Execution completed", l.Result.Output)
 		default:
@@ -78,23 +68,44 @@ func watchLogs(id string, client apiclientv1.Client) {
 
 	ui.NL()
 
-	// TODO watch for success | error status - in case of connection error on logs watch need fix in 0.8
+	// logs stream can end before execution result is saved, poll for final state
+	var renderedSteps int
 	for range time.Tick(time.Second) {
 		execution, err := client.GetExecution(id)
 		ui.ExitOnError("get test execution details", err)
 
-		fmt.Print(".")
+		if execution.ExecutionResult == nil || execution.ExecutionResult.Status == nil {
+			continue
+		}
+
+		renderedSteps = renderStepsProgress(execution.ExecutionResult.Steps, renderedSteps)
 
 		if execution.ExecutionResult.IsCompleted() {
-			fmt.Println()
-
 			uiShellGetExecution(id)
+			return execution
+		}
+	}
 
-			return
+	return testkube.Execution{}
+}
+
+// renderStepsProgress prints steps finished since last render, returns number of rendered steps
+func renderStepsProgress(steps []testkube.ExecutionStepResult, rendered int) int {
+	for ; rendered < len(steps); rendered++ {
+		step := steps[rendered]
+		message := fmt.Sprintf("step %d/%d: %s", rendered+1, len(steps), step.Name)
+		if step.Duration != "" {
+			message += " (" + step.Duration + ")"
+		}
+
+		if step.Status == string(testkube.PASSED_ExecutionStatus) {
+			ui.Success(message, step.Status)
+		} else {
+			ui.Warn(message, step.Status)
 		}
 	}
 
-	uiShellGetExecution(id)
+	return rendered
 }
 
 func newContentFromFlags(cmd *cobra.Command) (content *testkube.TestContent, err error) {
@@ -108,6 +119,15 @@ func newContentFromFlags(cmd *cobra.Command) (content *testkube.TestContent, err
 	gitPath := cmd.Flag("git-path").Value.String()
 	gitUsername := cmd.Flag("git-username").Value.String()
 	gitToken := cmd.Flag("git-token").Value.String()
+	contentDir := cmd.Flag("content-dir").Value.String()
+
+	var files map[string]string
+	if contentDir != "" {
+		if files, err = readContentDir(contentDir); err != nil {
+			return content, err
+		}
+		testContentType = string(testkube.TestContentTypeFiles)
+	}
 
 	// get file content
 	if file != "" {
@@ -115,7 +135,7 @@ func newContentFromFlags(cmd *cobra.Command) (content *testkube.TestContent, err
 		if err != nil {
 			return content, fmt.Errorf("reading file "+file+" error: %w", err)
 		}
-	} else if stat, _ := os.Stdin.Stat(); (stat.Mode() & os.ModeCharDevice) == 0 {
+	} else if stat, _ := os.Stdin.Stat(); contentDir == "" && (stat.Mode()&os.ModeCharDevice) == 0 {
 		fileContent, err = ioutil.ReadAll(os.Stdin)
 		if err != nil {
 			return content, fmt.Errorf("reading stdin error: %w", err)
@@ -123,7 +143,7 @@ func newContentFromFlags(cmd *cobra.Command) (content *testkube.TestContent, err
 	}
 
 	// content is correct when is passed from file, by uri, ur by git repo
-	if len(fileContent) == 0 && uri == "" && gitUri == "" {
+	if len(fileContent) == 0 && uri == "" && gitUri == "" && len(files) == 0 {
 		return content, fmt.Errorf("empty test content, please pass some test content to create test")
 	}
 
@@ -162,16 +182,465 @@ func newContentFromFlags(cmd *cobra.Command) (content *testkube.TestContent, err
 		Data:       string(fileContent),
 		Repository: repository,
 		Uri:        uri,
+		Files:      files,
 	}
 
 	return content, nil
 }
 
+// readContentDir reads files of directory by their slash separated relative paths, hidden files and directories
+// like .git are skipped
+func readContentDir(dir string) (files map[string]string, err error) {
+	files = map[string]string{}
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path != dir && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		files[filepath.ToSlash(name)] = string(data)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading content directory %s: %w", dir, err)
+	}
+
+	return files, nil
+}
+
+// contentUriFlags are flags of file URI content options
+var contentUriFlags = []string{"uri-secret", "uri-username-key", "uri-password-key", "uri-header", "uri-retries", "uri-max-size", "uri-sha256"}
+
+// newContentUriOptionsFromFlags returns file URI content options, nil is returned when no option is passed
+func newContentUriOptionsFromFlags(cmd *cobra.Command) (options *testkube.ContentUriOptions, err error) {
+	changed := false
+	for _, flag := range contentUriFlags {
+		changed = changed || cmd.Flags().Changed(flag)
+	}
+
+	if !changed {
+		return nil, nil
+	}
+
+	headerKeys, err := cmd.Flags().GetStringToString("uri-header")
+	if err != nil {
+		return nil, err
+	}
+
+	retries, err := cmd.Flags().GetInt32("uri-retries")
+	if err != nil {
+		return nil, err
+	}
+
+	maxSize, err := cmd.Flags().GetInt64("uri-max-size")
+	if err != nil {
+		return nil, err
+	}
+
+	return &testkube.ContentUriOptions{
+		SecretName:  cmd.Flag("uri-secret").Value.String(),
+		UsernameKey: cmd.Flag("uri-username-key").Value.String(),
+		PasswordKey: cmd.Flag("uri-password-key").Value.String(),
+		HeaderKeys:  headerKeys,
+		Retries:     retries,
+		MaxSize:     maxSize,
+		Sha256:      cmd.Flag("uri-sha256").Value.String(),
+	}, nil
+}
+
+// newSecretMountsFromFlags parses secret mounts passed as secret-name/key=/path/to/file
+func newSecretMountsFromFlags(flags map[string]string) (secretMounts []testkube.SecretMount, err error) {
+	for secretKey, mountPath := range flags {
+		secretName, key, found := strings.Cut(secretKey, "/")
+		if !found || secretName == "" || key == "" || mountPath == "" {
+			return nil, fmt.Errorf("invalid secret mount %s=%s, use secret-name/key=/path/to/file", secretKey, mountPath)
+		}
+
+		secretMounts = append(secretMounts, testkube.SecretMount{
+			SecretName: secretName,
+			Key:        key,
+			MountPath:  mountPath,
+		})
+	}
+
+	sort.Slice(secretMounts, func(i, j int) bool { return secretMounts[i].MountPath < secretMounts[j].MountPath })
+	return secretMounts, nil
+}
+
+// newExecutionFilesFromFlags parses mounted files passed as local/file=/path/in/container with inline content read
+// from local file and as secret/name/key=/path/in/container or configmap/name/key=/path/in/container
+func newExecutionFilesFromFlags(files, filesFrom map[string]string, readFile func(string) ([]byte, error)) (
+	executionFiles []testkube.ExecutionFile, err error) {
+	for localPath, mountPath := range files {
+		content, err := readFile(localPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading mounted file %s: %w", localPath, err)
+		}
+
+		executionFiles = append(executionFiles, testkube.ExecutionFile{Path: mountPath, Content: string(content)})
+	}
+
+	for source, mountPath := range filesFrom {
+		parts := strings.SplitN(source, "/", 3)
+		if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("invalid mounted file source %s=%s, use secret/name/key=/path or configmap/name/key=/path", source, mountPath)
+		}
+
+		file := testkube.ExecutionFile{Path: mountPath, Key: parts[2]}
+		switch parts[0] {
+		case "secret":
+			file.SecretName = parts[1]
+		case "configmap":
+			file.ConfigMapName = parts[1]
+		default:
+			return nil, fmt.Errorf("invalid mounted file source kind %s, use secret or configmap", parts[0])
+		}
+
+		executionFiles = append(executionFiles, file)
+	}
+
+	sort.Slice(executionFiles, func(i, j int) bool { return executionFiles[i].Path < executionFiles[j].Path })
+	return executionFiles, testkube.ValidateExecutionFiles(executionFiles)
+}
+
+// mergeSecretParamFlags returns params with secret params passed in flags and sorted unique secret param names
+func mergeSecretParamFlags(params map[string]string, names []string, secretParams map[string]string) (map[string]string, []string) {
+	if len(secretParams) == 0 {
+		return params, names
+	}
+
+	if params == nil {
+		params = map[string]string{}
+	}
+
+	unique := map[string]struct{}{}
+	for _, name := range names {
+		unique[name] = struct{}{}
+	}
+
+	for name, value := range secretParams {
+		params[name] = value
+		unique[name] = struct{}{}
+	}
+
+	names = make([]string, 0, len(unique))
+	for name := range unique {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return params, names
+}
+
+// newDataFileFromFlags returns iteration data file read from local file or loaded from URI by runner
+func newDataFileFromFlags(cmd *cobra.Command) (*testkube.TestContent, error) {
+	file := cmd.Flag("data-file").Value.String()
+	uri := cmd.Flag("data-uri").Value.String()
+
+	switch {
+	case file != "" && uri != "":
+		return nil, fmt.Errorf("pass only one of --data-file and --data-uri")
+	case file != "":
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading data file %s: %w", file, err)
+		}
+		return &testkube.TestContent{Type_: string(testkube.TestContentTypeString), Data: string(data)}, nil
+	case uri != "":
+		return &testkube.TestContent{Type_: string(testkube.TestContentTypeFileURI), Uri: uri}, nil
+	}
+
+	return nil, nil
+}
+
+// newMaintenanceWindowsFromFlags returns maintenance windows in cron schedule with duration or calendar range form
+func newMaintenanceWindowsFromFlags(cmd *cobra.Command) (windows []testkube.MaintenanceWindow, err error) {
+	values, err := cmd.Flags().GetStringArray("maintenance-window")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+
+		window, err := testkube.ParseMaintenanceWindow(value)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+
+	return windows, nil
+}
+
+// newLogCollectionFromFlags returns existing log collection with passed app logs flags applied, empty selector
+// disables collection
+func newLogCollectionFromFlags(cmd *cobra.Command, existing *testkube.LogCollection) (*testkube.LogCollection, error) {
+	if !cmd.Flags().Changed("app-logs-selector") && !cmd.Flags().Changed("app-logs-namespace") &&
+		!cmd.Flags().Changed("app-logs-container") {
+		return existing, nil
+	}
+
+	logCollection := testkube.LogCollection{}
+	if existing != nil {
+		logCollection = *existing
+	}
+
+	if cmd.Flags().Changed("app-logs-selector") {
+		logCollection.Selector = cmd.Flag("app-logs-selector").Value.String()
+		if logCollection.Selector == "" {
+			return nil, nil
+		}
+	}
+
+	if cmd.Flags().Changed("app-logs-namespace") {
+		logCollection.Namespace = cmd.Flag("app-logs-namespace").Value.String()
+	}
+
+	if cmd.Flags().Changed("app-logs-container") {
+		containers, err := cmd.Flags().GetStringArray("app-logs-container")
+		if err != nil {
+			return nil, err
+		}
+		logCollection.Containers = containers
+	}
+
+	return &logCollection, logCollection.Validate()
+}
+
+var newmanFlags = []string{"newman-environment-file", "newman-globals-file", "newman-folder", "newman-iterations", "newman-bail",
+	"newman-timeout-request", "newman-insecure", "newman-ssl-client-secret", "newman-ssl-client-passphrase-key"}
+
+// newNewmanOptionsFromFlags returns existing newman options with passed newman flags applied, environment and
+// globals files are read from local files
+func newNewmanOptionsFromFlags(cmd *cobra.Command, existing *testkube.NewmanOptions) (*testkube.NewmanOptions, error) {
+	changed := false
+	for _, flag := range newmanFlags {
+		changed = changed || cmd.Flags().Changed(flag)
+	}
+
+	if !changed {
+		return existing, nil
+	}
+
+	options := testkube.NewmanOptions{}
+	if existing != nil {
+		options = *existing
+	}
+
+	for flag, file := range map[string]**testkube.TestContent{
+		"newman-environment-file": &options.EnvironmentFile,
+		"newman-globals-file":     &options.GlobalsFile,
+	} {
+		if !cmd.Flags().Changed(flag) {
+			continue
+		}
+
+		*file = nil
+		if path := cmd.Flag(flag).Value.String(); path != "" {
+			data, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("reading newman file %s: %w", path, err)
+			}
+			*file = testkube.NewStringTestContent(string(data))
+		}
+	}
+
+	if cmd.Flags().Changed("newman-folder") {
+		folders, err := cmd.Flags().GetStringArray("newman-folder")
+		if err != nil {
+			return nil, err
+		}
+
+		// empty folder clears folders
+		options.Folders = nil
+		for _, folder := range folders {
+			if folder != "" {
+				options.Folders = append(options.Folders, folder)
+			}
+		}
+	}
+
+	if cmd.Flags().Changed("newman-iterations") {
+		iterations, err := cmd.Flags().GetInt32("newman-iterations")
+		if err != nil {
+			return nil, err
+		}
+		options.Iterations = iterations
+	}
+
+	if cmd.Flags().Changed("newman-timeout-request") {
+		options.TimeoutRequest = cmd.Flag("newman-timeout-request").Value.String()
+	}
+
+	// disabled flag is the newman default, so it isn't stored with test
+	for flag, enabled := range map[string]**bool{"newman-bail": &options.Bail, "newman-insecure": &options.Insecure} {
+		if cmd.Flags().Changed(flag) {
+			value, err := cmd.Flags().GetBool(flag)
+			if err != nil {
+				return nil, err
+			}
+
+			*enabled = nil
+			if value {
+				*enabled = &value
+			}
+		}
+	}
+
+	if cmd.Flags().Changed("newman-ssl-client-secret") || cmd.Flags().Changed("newman-ssl-client-passphrase-key") {
+		cert := testkube.NewmanSslClientCert{}
+		if options.SslClientCert != nil {
+			cert = *options.SslClientCert
+		}
+
+		if cmd.Flags().Changed("newman-ssl-client-secret") {
+			cert.SecretName = cmd.Flag("newman-ssl-client-secret").Value.String()
+		}
+
+		if cmd.Flags().Changed("newman-ssl-client-passphrase-key") {
+			cert.PassphraseKey = cmd.Flag("newman-ssl-client-passphrase-key").Value.String()
+		}
+
+		options.SslClientCert = nil
+		if cert.SecretName != "" {
+			options.SslClientCert = &cert
+		}
+	}
+
+	if reflect.DeepEqual(options, testkube.NewmanOptions{}) {
+		return nil, nil
+	}
+
+	return &options, options.Validate()
+}
+
+// newPrechecksFromFlags returns existing prechecks with passed precheck flags applied, empty precheck clears prechecks
+func newPrechecksFromFlags(cmd *cobra.Command, existing *testkube.Prechecks) (*testkube.Prechecks, error) {
+	if !cmd.Flags().Changed("precheck") && !cmd.Flags().Changed("precheck-timeout") {
+		return existing, nil
+	}
+
+	prechecks := testkube.Prechecks{}
+	if existing != nil {
+		prechecks = *existing
+	}
+
+	if cmd.Flags().Changed("precheck") {
+		values, err := cmd.Flags().GetStringArray("precheck")
+		if err != nil {
+			return nil, err
+		}
+
+		prechecks.Checks = nil
+		for _, value := range values {
+			if value == "" {
+				continue
+			}
+
+			check, err := testkube.ParsePrecheck(value)
+			if err != nil {
+				return nil, err
+			}
+			prechecks.Checks = append(prechecks.Checks, check)
+		}
+
+		if len(prechecks.Checks) == 0 {
+			return nil, nil
+		}
+	}
+
+	if cmd.Flags().Changed("precheck-timeout") {
+		prechecks.Timeout = cmd.Flag("precheck-timeout").Value.String()
+	}
+
+	return &prechecks, prechecks.Validate()
+}
+
+// newDependenciesFromFlags returns existing dependencies with passed dependencies flags applied, empty required test
+// clears dependencies
+func newDependenciesFromFlags(cmd *cobra.Command, existing *testkube.TestDependencies) (*testkube.TestDependencies, error) {
+	if !cmd.Flags().Changed("requires-passing") && !cmd.Flags().Changed("dependencies-freshness") &&
+		!cmd.Flags().Changed("dependencies-on-blocked") && !cmd.Flags().Changed("dependencies-queue-timeout") {
+		return existing, nil
+	}
+
+	dependencies := testkube.TestDependencies{}
+	if existing != nil {
+		dependencies = *existing
+	}
+
+	if cmd.Flags().Changed("requires-passing") {
+		values, err := cmd.Flags().GetStringArray("requires-passing")
+		if err != nil {
+			return nil, err
+		}
+
+		dependencies.RequiresPassing = nil
+		for _, value := range values {
+			if value != "" {
+				dependencies.RequiresPassing = append(dependencies.RequiresPassing, value)
+			}
+		}
+
+		if len(dependencies.RequiresPassing) == 0 {
+			return nil, nil
+		}
+	}
+
+	if cmd.Flags().Changed("dependencies-freshness") {
+		dependencies.Freshness = cmd.Flag("dependencies-freshness").Value.String()
+	}
+
+	if cmd.Flags().Changed("dependencies-on-blocked") {
+		dependencies.OnBlocked = cmd.Flag("dependencies-on-blocked").Value.String()
+	}
+
+	if cmd.Flags().Changed("dependencies-queue-timeout") {
+		dependencies.QueueTimeout = cmd.Flag("dependencies-queue-timeout").Value.String()
+	}
+
+	return &dependencies, dependencies.Validate()
+}
+
 func NewUpsertTestOptionsFromFlags(cmd *cobra.Command, test testkube.Test) (options apiclientv1.UpsertTestOptions, err error) {
 	content, err := newContentFromFlags(cmd)
 
 	ui.ExitOnError("creating content from passed parameters", err)
 
+	uriOptions, err := newContentUriOptionsFromFlags(cmd)
+	if err != nil {
+		return options, err
+	}
+
+	// keep existing file URI options if none are passed
+	content.UriOptions = uriOptions
+	if uriOptions == nil && test.Content != nil && content.Type_ == string(testkube.TestContentTypeFileURI) {
+		content.UriOptions = test.Content.UriOptions
+	}
+
 	name := cmd.Flag("name").Value.String()
 	executorType := cmd.Flag("type").Value.String()
 	namespace := cmd.Flag("namespace").Value.String()
@@ -184,6 +653,11 @@ func NewUpsertTestOptionsFromFlags(cmd *cobra.Command, test testkube.Test) (opti
 		return options, err
 	}
 
+	secretParams, err := cmd.Flags().GetStringToString("secret-param")
+	if err != nil {
+		return options, err
+	}
+
 	schedule := cmd.Flag("schedule").Value.String()
 	options = apiclientv1.UpsertTestOptions{
 		Name:      name,
@@ -201,6 +675,75 @@ func NewUpsertTestOptionsFromFlags(cmd *cobra.Command, test testkube.Test) (opti
 		options.Labels = test.Labels
 	}
 
+	secretMounts, err := cmd.Flags().GetStringToString("secret-mount")
+	if err != nil {
+		return options, err
+	}
+
+	// secret params are passed as params, existing secret params stay secret
+	options.Params, options.SecretParams = mergeSecretParamFlags(options.Params, test.SecretParams, secretParams)
+
+	// keep existing secret mounts if none are passed
+	options.SecretMounts = test.SecretMounts
+	if len(secretMounts) > 0 {
+		if options.SecretMounts, err = newSecretMountsFromFlags(secretMounts); err != nil {
+			return options, err
+		}
+	}
+
+	dataFile, err := newDataFileFromFlags(cmd)
+	if err != nil {
+		return options, err
+	}
+
+	// keep existing data file if none is passed
+	options.DataFile = test.DataFile
+	if dataFile != nil {
+		options.DataFile = dataFile
+	}
+
+	// keep existing maintenance windows if none are passed, empty window clears them
+	options.MaintenanceWindows = test.MaintenanceWindows
+	if cmd.Flags().Changed("maintenance-window") {
+		if options.MaintenanceWindows, err = newMaintenanceWindowsFromFlags(cmd); err != nil {
+			return options, err
+		}
+	}
+
+	// keep existing log collection if no app logs flags are passed
+	if options.LogCollection, err = newLogCollectionFromFlags(cmd, test.LogCollection); err != nil {
+		return options, err
+	}
+
+	// keep existing prechecks if no precheck flags are passed
+	if options.Prechecks, err = newPrechecksFromFlags(cmd, test.Prechecks); err != nil {
+		return options, err
+	}
+
+	// keep existing dependencies if no dependencies flags are passed
+	if options.Dependencies, err = newDependenciesFromFlags(cmd, test.Dependencies); err != nil {
+		return options, err
+	}
+
+	// keep existing newman options if no newman flags are passed
+	if options.Newman, err = newNewmanOptionsFromFlags(cmd, test.Newman); err != nil {
+		return options, err
+	}
+
+	// keep existing triggers if no trigger flags are passed
+	if options.Triggers, err = common.NewTriggersFromFlags(cmd, test.Triggers); err != nil {
+		return options, err
+	}
+
+	// keep existing placement if no placement flags are passed
+	if options.Placement, err = common.NewPlacementFromFlags(cmd, test.Placement); err != nil {
+		return options, err
+	}
+
+	// keep existing ownership fields which aren't passed
+	options.Ownership = common.NewOwnershipFromFlags(cmd, test.Ownership)
+	options.Enabled = common.NewEnabledFromFlags(cmd, test.Enabled)
+
 	// try to detect type if none passed
 	if executorType == "" {
 		d := detector.NewDefaultDetector()
@@ -217,3 +760,27 @@ func NewUpsertTestOptionsFromFlags(cmd *cobra.Command, test testkube.Test) (opti
 	return options, nil
 
 }
+
+// renderTestCRD prints Test and its Secret manifests built from passed flags without calling API
+func renderTestCRD(cmd *cobra.Command) {
+	// keep stdout clean for manifests
+	ui.Writer = os.Stderr
+
+	if cmd.Flag("name").Value.String() == "" {
+		ui.Failf("pass valid test name (in '--name' flag)")
+	}
+
+	err := validateCreateOptions(cmd)
+	ui.ExitOnError("validating passed flags", err)
+
+	options, err := NewUpsertTestOptionsFromFlags(cmd, testkube.Test{})
+	ui.ExitOnError("getting test options", err)
+
+	err = validateSchedule(options.Schedule)
+	ui.ExitOnError("validating schedule", err)
+
+	manifests, err := crds.RenderTestWithSecretCRD(testkube.TestUpsertRequest(options))
+	ui.ExitOnError("rendering test CRD", err)
+
+	fmt.Print(manifests)
+}
@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestNewExecutionFilesFromFlags(t *testing.T) {
+	readFile := func(path string) ([]byte, error) {
+		if path != "config.yaml" {
+			return nil, os.ErrNotExist
+		}
+		return []byte("debug: true"), nil
+	}
+
+	files, err := newExecutionFilesFromFlags(
+		map[string]string{"config.yaml": "/etc/app/config.yaml"},
+		map[string]string{"secret/tls/ca.crt": "/etc/certs/ca.crt", "configmap/data/users.csv": "/data/users.csv"},
+		readFile,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []testkube.ExecutionFile{
+		{Path: "/data/users.csv", ConfigMapName: "data", Key: "users.csv"},
+		{Path: "/etc/app/config.yaml", Content: "debug: true"},
+		{Path: "/etc/certs/ca.crt", SecretName: "tls", Key: "ca.crt"},
+	}, files)
+
+	_, err = newExecutionFilesFromFlags(map[string]string{"missing.yaml": "/etc/app/config.yaml"}, nil, readFile)
+	assert.Error(t, err)
+
+	_, err = newExecutionFilesFromFlags(nil, map[string]string{"vault/tls/ca.crt": "/etc/certs/ca.crt"}, readFile)
+	assert.Error(t, err)
+
+	_, err = newExecutionFilesFromFlags(nil, map[string]string{"secret/tls": "/etc/certs/ca.crt"}, readFile)
+	assert.Error(t, err)
+}
+
+func TestNewContentUriOptionsFromFlags(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := NewCreateTestsCmd()
+		assert.NoError(t, cmd.ParseFlags(args))
+		return cmd
+	}
+
+	options, err := newContentUriOptionsFromFlags(newCmd())
+	assert.NoError(t, err)
+	assert.Nil(t, options)
+
+	options, err = newContentUriOptionsFromFlags(newCmd("--uri-secret", "credentials", "--uri-header", "Authorization=token",
+		"--uri-retries", "3", "--uri-max-size", "1024", "--uri-sha256", "abc"))
+	assert.NoError(t, err)
+	assert.Equal(t, &testkube.ContentUriOptions{
+		SecretName: "credentials",
+		HeaderKeys: map[string]string{"Authorization": "token"},
+		Retries:    3,
+		MaxSize:    1024,
+		Sha256:     "abc",
+	}, options)
+}
+
+func TestNewLogCollectionFromFlags(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := NewUpdateTestsCmd()
+		assert.NoError(t, cmd.ParseFlags(args))
+		return cmd
+	}
+	existing := &testkube.LogCollection{Selector: "app=backend", Namespace: "staging"}
+
+	logCollection, err := newLogCollectionFromFlags(newCmd(), existing)
+	assert.NoError(t, err)
+	assert.Equal(t, existing, logCollection)
+
+	logCollection, err = newLogCollectionFromFlags(newCmd("--app-logs-container", "api"), existing)
+	assert.NoError(t, err)
+	assert.Equal(t, &testkube.LogCollection{Selector: "app=backend", Namespace: "staging", Containers: []string{"api"}}, logCollection)
+
+	logCollection, err = newLogCollectionFromFlags(newCmd("--app-logs-selector", ""), existing)
+	assert.NoError(t, err)
+	assert.Nil(t, logCollection)
+
+	_, err = newLogCollectionFromFlags(newCmd("--app-logs-namespace", "staging"), nil)
+	assert.Error(t, err, "selector is required")
+}
+
+func TestNewPrechecksFromFlags(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := NewUpdateTestsCmd()
+		assert.NoError(t, cmd.ParseFlags(args))
+		return cmd
+	}
+	existing := &testkube.Prechecks{Timeout: "2m", Checks: []testkube.Precheck{{Resource: "deployment/backend"}}}
+
+	prechecks, err := newPrechecksFromFlags(newCmd("--precheck-timeout", "5m"), existing)
+	assert.NoError(t, err)
+	assert.Equal(t, &testkube.Prechecks{Timeout: "5m", Checks: existing.Checks}, prechecks)
+
+	prechecks, err = newPrechecksFromFlags(newCmd("--precheck", "staging/pod/cache", "--precheck", "http://backend:8080/health"), existing)
+	assert.NoError(t, err)
+	assert.Equal(t, &testkube.Prechecks{Timeout: "2m", Checks: []testkube.Precheck{
+		{Resource: "pod/cache", Namespace: "staging"},
+		{Url: "http://backend:8080/health"},
+	}}, prechecks)
+
+	prechecks, err = newPrechecksFromFlags(newCmd("--precheck", ""), existing)
+	assert.NoError(t, err)
+	assert.Nil(t, prechecks)
+
+	_, err = newPrechecksFromFlags(newCmd("--precheck", "service/backend"), nil)
+	assert.Error(t, err)
+}
+
+func TestNewDependenciesFromFlags(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := NewUpdateTestsCmd()
+		assert.NoError(t, cmd.ParseFlags(args))
+		return cmd
+	}
+	existing := &testkube.TestDependencies{RequiresPassing: []string{"smoke"}}
+
+	dependencies, err := newDependenciesFromFlags(newCmd(), existing)
+	assert.NoError(t, err)
+	assert.Equal(t, existing, dependencies)
+
+	dependencies, err = newDependenciesFromFlags(newCmd("--dependencies-on-blocked", "queue", "--dependencies-queue-timeout", "30m"), existing)
+	assert.NoError(t, err)
+	assert.Equal(t, &testkube.TestDependencies{RequiresPassing: []string{"smoke"}, OnBlocked: "queue", QueueTimeout: "30m"}, dependencies)
+	assert.Empty(t, existing.OnBlocked, "existing dependencies aren't changed")
+
+	dependencies, err = newDependenciesFromFlags(newCmd("--requires-passing", ""), existing)
+	assert.NoError(t, err)
+	assert.Nil(t, dependencies)
+
+	_, err = newDependenciesFromFlags(newCmd("--requires-passing", "smoke", "--dependencies-on-blocked", "wait"), nil)
+	assert.Error(t, err)
+}
+
+func TestNewNewmanOptionsFromFlags(t *testing.T) {
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := NewUpdateTestsCmd()
+		assert.NoError(t, cmd.ParseFlags(args))
+		return cmd
+	}
+	enabled := true
+	existing := &testkube.NewmanOptions{Folders: []string{"Users"}, Bail: &enabled}
+
+	options, err := newNewmanOptionsFromFlags(newCmd(), existing)
+	assert.NoError(t, err)
+	assert.Equal(t, existing, options)
+
+	options, err = newNewmanOptionsFromFlags(newCmd("--newman-iterations", "3", "--newman-timeout-request", "30s",
+		"--newman-insecure", "--newman-ssl-client-secret", "api-client-cert"), existing)
+	assert.NoError(t, err)
+	assert.Equal(t, &testkube.NewmanOptions{Folders: []string{"Users"}, Bail: &enabled, Iterations: 3, TimeoutRequest: "30s",
+		Insecure: &enabled, SslClientCert: &testkube.NewmanSslClientCert{SecretName: "api-client-cert"}}, options)
+
+	options, err = newNewmanOptionsFromFlags(newCmd("--newman-folder", "", "--newman-bail=false"), existing)
+	assert.NoError(t, err)
+	assert.Nil(t, options)
+
+	_, err = newNewmanOptionsFromFlags(newCmd("--newman-timeout-request", "30"), nil)
+	assert.Error(t, err)
+}
+
+func TestReadContentDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "lib"), 0755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "script.js"), []byte("import './lib/helpers.js'"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "lib", "helpers.js"), []byte("export default 1"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref: refs/heads/main"), 0644))
+
+	files, err := readContentDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"script.js": "import './lib/helpers.js'", "lib/helpers.js": "export default 1"}, files)
+
+	_, err = readContentDir(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
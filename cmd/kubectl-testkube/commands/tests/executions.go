@@ -16,6 +16,8 @@ func NewGetExecutionCmd() *cobra.Command {
 		selectors []string
 		testID    string
 		limit     int
+		diff      bool
+		base      string
 	)
 
 	cmd := &cobra.Command{
@@ -28,6 +30,15 @@ func NewGetExecutionCmd() *cobra.Command {
 
 			if len(args) == 1 {
 				executionID := args[0]
+				if diff || base != "" {
+					executionDiff, err := client.GetExecutionDiff(executionID, base)
+					ui.ExitOnError("comparing test execution: "+executionID, err)
+
+					err = render.Obj(cmd, executionDiff, os.Stdout, renderer.ExecutionDiffRenderer)
+					ui.ExitOnError("rendering execution diff", err)
+					return
+				}
+
 				execution, err := client.GetExecution(executionID)
 				ui.ExitOnError("getting test execution: "+executionID, err)
 
@@ -46,6 +57,8 @@ func NewGetExecutionCmd() *cobra.Command {
 	cmd.Flags().StringSliceVarP(&selectors, "label", "l", nil, "label key value pair: --label key1=value1")
 	cmd.Flags().StringVarP(&testID, "test", "", "", "test id")
 	cmd.Flags().IntVarP(&limit, "limit", "", 10, "records limit")
+	cmd.Flags().BoolVarP(&diff, "diff", "", false, "compare execution with previous execution of the test")
+	cmd.Flags().StringVarP(&base, "base", "", "", "execution id to compare execution with, implies --diff")
 
 	return cmd
 }
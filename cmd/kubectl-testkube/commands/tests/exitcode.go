@@ -0,0 +1,33 @@
+package tests
+
+import (
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const (
+	// ExitCodeFailed is returned when execution failed
+	ExitCodeFailed = 1
+	// ExitCodeTimeout is returned when execution timed out
+	ExitCodeTimeout = 2
+	// ExitCodeAborted is returned when execution was aborted
+	ExitCodeAborted = 3
+)
+
+// ExecutionExitCode maps final execution status to CLI exit code, zero is returned for passed or unfinished execution
+func ExecutionExitCode(execution testkube.Execution) int {
+	result := execution.ExecutionResult
+	if result == nil || result.Status == nil {
+		return 0
+	}
+
+	switch {
+	case result.IsFailed():
+		return ExitCodeFailed
+	case result.IsTimeout():
+		return ExitCodeTimeout
+	case result.IsAborted():
+		return ExitCodeAborted
+	}
+
+	return 0
+}
@@ -0,0 +1,29 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestExecutionExitCode(t *testing.T) {
+	tests := map[string]struct {
+		result *testkube.ExecutionResult
+		code   int
+	}{
+		"no result": {result: nil, code: 0},
+		"running":   {result: &testkube.ExecutionResult{Status: testkube.ExecutionStatusRunning}, code: 0},
+		"passed":    {result: &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed}, code: 0},
+		"failed":    {result: &testkube.ExecutionResult{Status: testkube.ExecutionStatusFailed}, code: ExitCodeFailed},
+		"timeout":   {result: &testkube.ExecutionResult{Status: testkube.ExecutionStatusTimeout}, code: ExitCodeTimeout},
+		"aborted":   {result: &testkube.ExecutionResult{Status: testkube.ExecutionStatusAborted}, code: ExitCodeAborted},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.code, ExecutionExitCode(testkube.Execution{ExecutionResult: tt.result}))
+		})
+	}
+}
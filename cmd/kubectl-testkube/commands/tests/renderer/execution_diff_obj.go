@@ -0,0 +1,43 @@
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/ui"
+)
+
+func ExecutionDiffRenderer(ui *ui.UI, obj interface{}) error {
+	diff, ok := obj.(testkube.ExecutionDiff)
+	if !ok {
+		return fmt.Errorf("can't render execution diff, expected obj to be testkube.ExecutionDiff but got '%T'", obj)
+	}
+
+	ui.Warn("Execution:      ", diff.ExecutionId)
+	ui.Warn("Base execution: ", diff.BaseExecutionId)
+	ui.NL()
+
+	ui.Warn("Content changed:", fmt.Sprintf("%t", diff.ContentChanged))
+	ui.Info("- base", diff.BaseContentChecksum)
+	ui.Info("- execution", diff.ContentChecksum)
+
+	ui.Warn("Status changed: ", fmt.Sprintf("%t", diff.StatusChanged))
+	ui.Info("- base", statusString(diff.BaseStatus))
+	ui.Info("- execution", statusString(diff.Status))
+
+	if len(diff.ChangedParams) > 0 {
+		ui.Warn("Changed params: ", strings.Join(diff.ChangedParams, ", "))
+	}
+
+	ui.NL()
+	return nil
+}
+
+func statusString(status *testkube.ExecutionStatus) string {
+	if status == nil {
+		return ""
+	}
+
+	return string(*status)
+}
@@ -3,6 +3,7 @@ package renderer
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/kubeshop/testkube/pkg/ui"
@@ -19,11 +20,42 @@ func ExecutionRenderer(ui *ui.UI, obj interface{}) error {
 	ui.Warn("Name:     ", execution.Name)
 	ui.Warn("Type:     ", execution.TestType)
 	ui.Warn("Duration: ", execution.Duration)
+	if execution.Timings != nil {
+		ui.Warn("Timings:  ", fmt.Sprintf("queue %s, scheduling %s, content fetch %s", durationOrNone(execution.Timings.QueueDuration),
+			durationOrNone(execution.Timings.SchedulingDuration), durationOrNone(execution.Timings.ContentFetchDuration)))
+	}
 
 	if len(execution.Labels) > 0 {
 		ui.Warn("Labels:   ", testkube.LabelsToString(execution.Labels))
 	}
 
+	if !execution.RunningContext.IsEmpty() {
+		ui.Warn("Context:  ", execution.RunningContext.String())
+	}
+
+	if execution.ContentChecksum != "" {
+		changed := ""
+		if execution.ContentChanged {
+			changed = " (changed since previous execution)"
+		}
+		ui.Warn("Content:  ", execution.ContentChecksum+changed)
+	}
+
+	if execution.ExecutorImageDigest != "" {
+		// image can be already pinned to the digest
+		image, _, _ := strings.Cut(execution.ExecutorImage, "@")
+		ui.Warn("Image:    ", image+"@"+execution.ExecutorImageDigest)
+	}
+
+	if execution.Environment != nil {
+		ui.Warn("Node:     ", execution.Environment.NodeName)
+		ui.Warn("Cluster:  ", "Kubernetes "+execution.Environment.KubernetesVersion)
+	}
+
+	if result != nil && result.RunnerVersion != "" {
+		ui.Warn("Runner:   ", result.RunnerVersion)
+	}
+
 	if len(execution.Params) > 0 {
 		ui.Warn("Params:   ", fmt.Sprintf("%d", len(execution.Params)))
 		for k, v := range execution.Params {
@@ -70,3 +102,12 @@ func ExecutionRenderer(ui *ui.UI, obj interface{}) error {
 
 	return nil
 }
+
+// durationOrNone returns duration or dash for durations which weren't measured
+func durationOrNone(duration string) string {
+	if duration == "" {
+		return "-"
+	}
+
+	return duration
+}
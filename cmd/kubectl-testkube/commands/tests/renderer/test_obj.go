@@ -2,6 +2,7 @@ package renderer
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/kubeshop/testkube/pkg/ui"
@@ -20,10 +21,23 @@ func TestRenderer(ui *ui.UI, obj interface{}) error {
 		ui.NL()
 		ui.Warn("Labels:   ", testkube.LabelsToString(test.Labels))
 	}
+	if !test.Ownership.IsEmpty() {
+		ui.NL()
+		ui.Warn("Owner:    ", test.Ownership.String())
+		if test.Ownership.Contact != "" {
+			ui.Warn("Contact:  ", test.Ownership.Contact)
+		}
+	}
 	if test.Schedule != "" {
 		ui.NL()
 		ui.Warn("Schedule: ", test.Schedule)
 	}
+	for _, window := range test.MaintenanceWindows {
+		ui.Warn("Maintenance:", window.String())
+	}
+	if test.Enabled != nil && !*test.Enabled {
+		ui.Warn("Enabled:  ", "false")
+	}
 
 	if len(test.Params) > 0 {
 		ui.NL()
@@ -53,6 +67,19 @@ func TestRenderer(ui *ui.UI, obj interface{}) error {
 		if test.Content.Data != "" {
 			ui.Warn("Data: ", "\n", test.Content.Data)
 		}
+
+		if len(test.Content.Files) > 0 {
+			names := make([]string, 0, len(test.Content.Files))
+			for name := range test.Content.Files {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			ui.Warn("Files: ")
+			for _, name := range names {
+				ui.Info("- "+name, fmt.Sprintf("%d bytes", len(test.Content.Files[name])))
+			}
+		}
 	}
 
 	return nil
@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"errors"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common"
+	"github.com/kubeshop/testkube/pkg/ui"
+)
+
+func NewRerunExecutionCmd() *cobra.Command {
+	var watchEnabled bool
+
+	cmd := &cobra.Command{
+		Use:   "execution <executionID>",
+		Short: "Reruns execution with identical inputs",
+		Long:  `Reruns execution with params, args, content commit and executor image it ran with, new execution links the original one`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please pass 'Execution ID' as argument")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			executionID := args[0]
+			client, _ := common.GetClient(cmd)
+
+			execution, err := client.RerunExecution(executionID)
+			ui.ExitOnError("rerunning execution "+executionID, err)
+			printExecutionDetails(execution)
+
+			if watchEnabled {
+				watchLogs(execution.Id, client)
+			}
+
+			execution, err = client.GetExecution(execution.Id)
+			ui.ExitOnError("getting recent execution data id:"+execution.Id, err)
+
+			uiPrintStatus(execution)
+			uiShellWatchExecution(execution.Id)
+			uiShellGetExecution(execution.Id)
+
+			if code := ExecutionExitCode(execution); code != 0 {
+				os.Exit(code)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&watchEnabled, "watch", "f", false, "watch logs and execution state until complete, exit code is set from final status: failed=1, timeout=2, aborted=3")
+
+	return cmd
+}
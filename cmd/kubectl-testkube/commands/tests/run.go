@@ -29,6 +29,12 @@ func NewRunTestCmd() *cobra.Command {
 		selectors                []string
 		concurrencyLevel         int
 		httpProxy, httpsProxy    string
+		executionLabels          map[string]string
+		secretParams             map[string]string
+		force                    bool
+		mountFiles               map[string]string
+		mountFilesFrom           map[string]string
+		executorImageDigest      string
 	)
 
 	cmd := &cobra.Command{
@@ -45,16 +51,25 @@ func NewRunTestCmd() *cobra.Command {
 				paramsFileContent = string(b)
 			}
 
+			files, err := newExecutionFilesFromFlags(mountFiles, mountFilesFrom, ioutil.ReadFile)
+			ui.ExitOnError("parsing mounted files", err)
+
 			var executions []testkube.Execution
-			var err error
 			client, namespace := common.GetClient(cmd)
+			params, secretParamNames := mergeSecretParamFlags(params, nil, secretParams)
 			options := apiv1.ExecuteTestOptions{
 				ExecutionParams:            params,
+				SecretParams:               secretParamNames,
 				ExecutionParamsFileContent: paramsFileContent,
+				Files:                      files,
 				Args:                       binaryArgs,
 				SecretEnvs:                 secretEnvs,
 				HTTPProxy:                  httpProxy,
 				HTTPSProxy:                 httpsProxy,
+				ExecutionLabels:            executionLabels,
+				RunningContext:             common.GetRunningContext(),
+				Force:                      force,
+				ExecutorImageDigest:        executorImageDigest,
 			}
 
 			switch {
@@ -66,7 +81,7 @@ func NewRunTestCmd() *cobra.Command {
 				if err != nil {
 					ui.Errf("Can't get test with name '%s'. Test does not exists in namespace '%s'", testName, namespace)
 					ui.Debug(err.Error())
-					os.Exit(1)
+					os.Exit(ui.ExitCode(err))
 				}
 
 				execution, err := client.ExecuteTest(testName, name, options)
@@ -80,12 +95,12 @@ func NewRunTestCmd() *cobra.Command {
 				ui.Failf("Pass Test name or labels to run by labels ")
 			}
 
-			var hasErrors bool
+			var exitCode int
 			for _, execution := range executions {
 				printExecutionDetails(execution)
 
 				if execution.ExecutionResult != nil && execution.ExecutionResult.ErrorMessage != "" {
-					hasErrors = true
+					exitCode = ExitCodeFailed
 				}
 
 				if execution.Id != "" {
@@ -98,10 +113,13 @@ func NewRunTestCmd() *cobra.Command {
 				}
 
 				uiPrintStatus(execution)
+				if code := ExecutionExitCode(execution); code > exitCode {
+					exitCode = code
+				}
 
 				if execution.Id != "" {
 					if downloadArtifactsEnabled {
-						DownloadArtifacts(execution.Id, downloadDir, client)
+						DownloadArtifacts(execution.Id, downloadDir, "", DefaultDownloadConcurrency, client)
 					}
 
 					uiShellWatchExecution(execution.Id)
@@ -110,8 +128,8 @@ func NewRunTestCmd() *cobra.Command {
 				uiShellGetExecution(execution.Id)
 			}
 
-			if hasErrors {
-				ui.ExitOnError("executions contain failed on errors")
+			if exitCode != 0 {
+				os.Exit(exitCode)
 			}
 		},
 	}
@@ -119,8 +137,11 @@ func NewRunTestCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&name, "name", "n", "", "execution name, if empty will be autogenerated")
 	cmd.Flags().StringVarP(&paramsFile, "params-file", "", "", "params file path, e.g. postman env file - will be passed to executor if supported")
 	cmd.Flags().StringToStringVarP(&params, "param", "p", map[string]string{}, "execution envs passed to executor")
+	cmd.Flags().StringToStringVar(&mountFiles, "mount-file", map[string]string{}, "local file mounted into executor container: --mount-file ./config.yaml=/etc/app/config.yaml")
+	cmd.Flags().StringToStringVar(&mountFilesFrom, "mount-file-from", map[string]string{}, "secret or config map key mounted into executor container: --mount-file-from secret/tls/ca.crt=/etc/certs/ca.crt")
+	cmd.Flags().StringToStringVarP(&secretParams, "secret-param", "", map[string]string{}, "secret execution param redacted in logs and results: --secret-param key1=value1")
 	cmd.Flags().StringArrayVarP(&binaryArgs, "args", "", []string{}, "executor binary additional arguments")
-	cmd.Flags().BoolVarP(&watchEnabled, "watch", "f", false, "watch for changes after start")
+	cmd.Flags().BoolVarP(&watchEnabled, "watch", "f", false, "watch logs and execution state until complete, exit code is set from final status: failed=1, timeout=2, aborted=3")
 	cmd.Flags().StringVar(&downloadDir, "download-dir", "artifacts", "download dir")
 	cmd.Flags().BoolVarP(&downloadArtifactsEnabled, "download-artifacts", "a", false, "downlaod artifacts automatically")
 	cmd.Flags().StringToStringVarP(&secretEnvs, "secret", "", map[string]string{}, "secret envs in a form of secret_name1=secret_key1 passed to executor")
@@ -128,6 +149,9 @@ func NewRunTestCmd() *cobra.Command {
 	cmd.Flags().IntVar(&concurrencyLevel, "concurrency", 10, "concurrency level for multiple test execution")
 	cmd.Flags().StringVar(&httpProxy, "http-proxy", "", "http proxy for executor containers")
 	cmd.Flags().StringVar(&httpsProxy, "https-proxy", "", "https proxy for executor containers")
+	cmd.Flags().StringToStringVarP(&executionLabels, "execution-label", "", map[string]string{}, "execution label merged with test labels: --execution-label key1=value1")
+	cmd.Flags().BoolVar(&force, "force", false, "run test even if it's disabled")
+	cmd.Flags().StringVar(&executorImageDigest, "executor-image-digest", "", "executor image digest the execution is pinned to, e.g. sha256:4bcff639...")
 
 	return cmd
 }
@@ -157,7 +181,14 @@ func uiPrintStatus(execution testkube.Execution) {
 		ui.Warn("Test test execution failed:\n")
 		ui.Errf(result.ErrorMessage)
 		ui.Info(result.Output)
-		os.Exit(1)
+
+	case result.IsTimeout():
+		ui.Warn("Test execution timed out:\n")
+		ui.Errf(result.ErrorMessage)
+		ui.Info(result.Output)
+
+	case result.IsAborted():
+		ui.Warn("Test execution aborted")
 	}
 
 	ui.NL()
@@ -23,6 +23,11 @@ func NewUpdateTestsCmd() *cobra.Command {
 		labels          map[string]string
 		params          map[string]string
 		schedule        string
+		secretMounts    map[string]string
+		dataFile        string
+		dataURI         string
+		secretParams    map[string]string
+		crdOnly         bool
 	)
 
 	cmd := &cobra.Command{
@@ -30,6 +35,11 @@ func NewUpdateTestsCmd() *cobra.Command {
 		Short: "Update test",
 		Long:  `Update Test Custom Resource`,
 		Run: func(cmd *cobra.Command, args []string) {
+			if crdOnly {
+				renderTestCRD(cmd)
+				return
+			}
+
 			var err error
 
 			client, _ := common.GetClient(cmd)
@@ -57,6 +67,7 @@ func NewUpdateTestsCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&executorType, "type", "t", "", "test type (defaults to postman-collection)")
 
+	cmd.Flags().String("content-dir", "", "directory with test files stored inline in test as files content, for small multi-file projects")
 	cmd.Flags().StringVarP(&uri, "uri", "", "", "URI of resource - will be loaded by http GET")
 	cmd.Flags().StringVarP(&gitUri, "git-uri", "", "", "Git repository uri")
 	cmd.Flags().StringVarP(&gitBranch, "git-branch", "", "", "if uri is git repository we can set additional branch parameter")
@@ -66,6 +77,41 @@ func NewUpdateTestsCmd() *cobra.Command {
 	cmd.Flags().StringToStringVarP(&labels, "label", "l", nil, "label key value pair: --label key1=value1")
 	cmd.Flags().StringToStringVarP(&params, "param", "p", nil, "param key value pair: --param key1=value1")
 	cmd.Flags().StringVarP(&schedule, "schedule", "", "", "test schedule in a cronjob form: * * * * *")
+	cmd.Flags().StringToStringVarP(&secretMounts, "secret-mount", "", nil, "secret key mounted as file into executor container: --secret-mount secret-name/key=/path/to/file")
+	cmd.Flags().StringVarP(&dataFile, "data-file", "", "", "iteration data file, CSV with header row or JSON array of objects, test runs once per row")
+	cmd.Flags().StringVarP(&dataURI, "data-uri", "", "", "URI of iteration data file - will be loaded by http GET")
+	cmd.Flags().StringToStringVarP(&secretParams, "secret-param", "", nil, "secret param key value pair redacted in logs and results: --secret-param key1=value1")
+	cmd.Flags().StringArray("maintenance-window", nil, "window when scheduled runs are skipped, cron schedule with duration or RFC3339 range: --maintenance-window '0 2 * * 6 4h'")
+	cmd.Flags().String("app-logs-selector", "", "label selector of application pods which logs are stored as app-logs.txt artifact, empty selector disables collection")
+	cmd.Flags().String("app-logs-namespace", "", "namespace of application pods logs are collected from")
+	cmd.Flags().StringArray("app-logs-container", nil, "container of application pods logs are collected from, all containers by default")
+	cmd.Flags().StringArray("precheck", nil, "readiness check met before execution, [namespace/]kind/name of deployment, statefulset, daemonset or pod, or HTTP URL: --precheck deployment/backend")
+	cmd.Flags().String("precheck-timeout", "", "time prechecks have to be met within, executions are skipped otherwise, 1m by default")
+	cmd.Flags().StringArray("requires-passing", nil, "test which latest completed execution has to be passing before the test is executed: --requires-passing smoke")
+	cmd.Flags().String("dependencies-freshness", "", "age of latest executions of required tests, 24h by default")
+	cmd.Flags().String("dependencies-on-blocked", "", "what happens with executions blocked by failing required tests, skip (default) or queue")
+	cmd.Flags().String("dependencies-queue-timeout", "", "time queued executions wait for required tests to pass, 1h by default")
+	cmd.Flags().String("newman-environment-file", "", "postman environment file of postman collection test, empty file clears it")
+	cmd.Flags().String("newman-globals-file", "", "postman globals file of postman collection test, empty file clears it")
+	cmd.Flags().StringArray("newman-folder", nil, "collection folder or request run by newman, all collection items by default, empty folder clears folders: --newman-folder Users")
+	cmd.Flags().Int32("newman-iterations", 0, "number of collection iterations run by newman, 1 by default")
+	cmd.Flags().Bool("newman-bail", false, "stop newman run on first test failure or request error")
+	cmd.Flags().String("newman-timeout-request", "", "timeout of collection requests, e.g. 30s, requests don't time out by default")
+	cmd.Flags().Bool("newman-insecure", false, "disable SSL verification of collection requests")
+	cmd.Flags().String("newman-ssl-client-secret", "", "secret with tls.crt and tls.key SSL client certificate of collection requests, empty secret clears it")
+	cmd.Flags().String("newman-ssl-client-passphrase-key", "", "key of SSL client certificate secret with private key passphrase")
+	cmd.Flags().String("uri-secret", "", "secret with file URI credentials")
+	cmd.Flags().String("uri-username-key", "", "file URI secret key with basic auth username")
+	cmd.Flags().String("uri-password-key", "", "file URI secret key with basic auth password")
+	cmd.Flags().StringToString("uri-header", nil, "file URI header with value read from secret key: --uri-header Authorization=token")
+	cmd.Flags().Int32("uri-retries", 0, "number of retries of failed file URI requests, retries are delayed with exponential backoff")
+	cmd.Flags().Int64("uri-max-size", 0, "max size of file URI content in bytes")
+	cmd.Flags().String("uri-sha256", "", "expected SHA-256 checksum of file URI content")
+	common.AddOwnershipFlags(cmd)
+	common.AddTriggerFlags(cmd)
+	common.AddPlacementFlags(cmd)
+	common.AddEnabledFlag(cmd)
+	common.AddCRDOnlyFlag(cmd, &crdOnly)
 
 	return cmd
 }
@@ -1,6 +1,8 @@
 package tests
 
 import (
+	"os"
+
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common"
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common/validator"
 	"github.com/kubeshop/testkube/pkg/ui"
@@ -24,9 +26,12 @@ func NewWatchExecutionCmd() *cobra.Command {
 			if execution.ExecutionResult.IsCompleted() {
 				ui.Completed("execution is already finished")
 			} else {
-				watchLogs(executionID, client)
+				execution = watchLogs(executionID, client)
 			}
 
+			if code := ExecutionExitCode(execution); code != 0 {
+				os.Exit(code)
+			}
 		},
 	}
 }
@@ -0,0 +1,67 @@
+package testsuites
+
+import (
+	"errors"
+	"fmt"
+	"os/user"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/ui"
+)
+
+func NewApproveTestSuiteStepCmd() *cobra.Command {
+	var (
+		reject   bool
+		approver string
+		comment  string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "testsuiteexecution <executionID> <step>",
+		Aliases: []string{"tse", "testsuites-execution", "testsuite-execution"},
+		Short:   "Approves approval step of test suite execution",
+		Long:    `Approves approval step of test suite execution, rejected step aborts test suite execution`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return errors.New("please pass 'Execution ID' and 'Step' as arguments")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			executionID := args[0]
+			step, err := strconv.Atoi(args[1])
+			ui.ExitOnError("parsing step index", err)
+
+			if approver == "" {
+				current, err := user.Current()
+				ui.ExitOnError("getting current user, pass --approver instead", err)
+				approver = current.Username
+			}
+
+			client, _ := common.GetClient(cmd)
+
+			result, err := client.ApproveTestSuiteStep(executionID, step, testkube.TestSuiteStepApprovalDecision{
+				Approved: !reject,
+				Approver: approver,
+				Comment:  comment,
+			})
+			ui.ExitOnError(fmt.Sprintf("approving step %d of test suite execution %s", step, executionID), err)
+
+			decision := "approved"
+			if result.Approval != nil {
+				decision = result.Approval.String()
+			}
+			ui.Success("Test suite step "+decision, executionID, args[1])
+		},
+	}
+
+	cmd.Flags().BoolVar(&reject, "reject", false, "reject step, test suite execution is aborted")
+	cmd.Flags().StringVar(&approver, "approver", "", "approver identity, current user by default")
+	cmd.Flags().StringVar(&comment, "comment", "", "decision comment")
+
+	return cmd
+}
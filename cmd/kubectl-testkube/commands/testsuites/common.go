@@ -1,9 +1,11 @@
 package testsuites
 
 import (
+	"fmt"
 	"os"
 	"time"
 
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/crds"
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/kubeshop/testkube/pkg/ui"
 )
@@ -71,3 +73,21 @@ func uiShellTestSuiteWatchCommandBlock(id string) {
 
 	ui.NL()
 }
+
+// renderTestSuiteCRD prints TestSuite manifest without calling API
+func renderTestSuiteCRD(options testkube.TestSuiteUpsertRequest) {
+	// keep stdout clean for manifest
+	ui.Writer = os.Stderr
+
+	if options.Name == "" {
+		ui.Failf("pass valid test suite name (in '--name' flag)")
+	}
+
+	err := validateSchedule(options.Schedule)
+	ui.ExitOnError("validating schedule", err)
+
+	manifest, err := crds.RenderTestSuiteCRD(options)
+	ui.ExitOnError("rendering test suite CRD", err)
+
+	fmt.Print(manifest)
+}
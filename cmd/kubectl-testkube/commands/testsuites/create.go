@@ -21,6 +21,7 @@ func NewCreateTestSuitesCmd() *cobra.Command {
 		labels   map[string]string
 		params   map[string]string
 		schedule string
+		crdOnly  bool
 	)
 
 	cmd := &cobra.Command{
@@ -55,6 +56,19 @@ func NewCreateTestSuitesCmd() *cobra.Command {
 				options.Name = name
 			}
 
+			if crdOnly {
+				options.Namespace = cmd.Flag("namespace").Value.String()
+				options.Labels = labels
+				options.Params = params
+				options.Schedule = schedule
+				options.Ownership = common.NewOwnershipFromFlags(cmd, options.Ownership)
+				options.Enabled = common.NewEnabledFromFlags(cmd, options.Enabled)
+				options.Triggers, err = common.NewTriggersFromFlags(cmd, options.Triggers)
+				ui.ExitOnError("validating triggers", err)
+				renderTestSuiteCRD(options)
+				return
+			}
+
 			client, namespace := common.GetClient(cmd)
 			options.Namespace = namespace
 
@@ -66,6 +80,10 @@ func NewCreateTestSuitesCmd() *cobra.Command {
 			options.Labels = labels
 			options.Params = params
 			options.Schedule = cmd.Flag("schedule").Value.String()
+			options.Ownership = common.NewOwnershipFromFlags(cmd, options.Ownership)
+			options.Enabled = common.NewEnabledFromFlags(cmd, options.Enabled)
+			options.Triggers, err = common.NewTriggersFromFlags(cmd, options.Triggers)
+			ui.ExitOnError("validating triggers", err)
 
 			err = validateSchedule(options.Schedule)
 			ui.ExitOnError("validating schedule", err)
@@ -81,6 +99,10 @@ func NewCreateTestSuitesCmd() *cobra.Command {
 	cmd.Flags().StringToStringVarP(&labels, "label", "l", nil, "label key value pair: --label key1=value1")
 	cmd.Flags().StringToStringVarP(&params, "param", "p", nil, "param key value pair: --param key1=value1")
 	cmd.Flags().StringVarP(&schedule, "schedule", "", "", "test suite schedule in a cronjob form: * * * * *")
+	common.AddOwnershipFlags(cmd)
+	common.AddTriggerFlags(cmd)
+	common.AddEnabledFlag(cmd)
+	common.AddCRDOnlyFlag(cmd, &crdOnly)
 
 	return cmd
 }
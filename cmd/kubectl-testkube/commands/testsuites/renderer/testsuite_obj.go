@@ -19,10 +19,20 @@ func TestSuiteRenderer(ui *ui.UI, obj interface{}) error {
 		ui.NL()
 		ui.Warn("Labels:   ", testkube.LabelsToString(ts.Labels))
 	}
+	if !ts.Ownership.IsEmpty() {
+		ui.NL()
+		ui.Warn("Owner:    ", ts.Ownership.String())
+		if ts.Ownership.Contact != "" {
+			ui.Warn("Contact:  ", ts.Ownership.Contact)
+		}
+	}
 	if ts.Schedule != "" {
 		ui.NL()
 		ui.Warn("Schedule: ", ts.Schedule)
 	}
+	if ts.Enabled != nil && !*ts.Enabled {
+		ui.Warn("Enabled:  ", "false")
+	}
 
 	if len(ts.Params) > 0 {
 		ui.NL()
@@ -37,11 +47,20 @@ func TestSuiteRenderer(ui *ui.UI, obj interface{}) error {
 
 	ui.NL()
 	ui.Warn("Test steps:", fmt.Sprintf("%d", len(steps)))
-	d := [][]string{{"Name", "Stop on failure", "Type"}}
+	d := [][]string{{"Name", "Stop on failure", "Continue on error", "Timeout", "Retries", "Condition", "Type"}}
 	for _, step := range steps {
+		timeout := ""
+		if step.Timeout > 0 {
+			timeout = fmt.Sprintf("%ds", step.Timeout)
+		}
+
 		d = append(d, []string{
 			step.FullName(),
 			fmt.Sprintf("%v", step.StopTestOnFailure),
+			fmt.Sprintf("%v", step.ContinueOnError),
+			timeout,
+			fmt.Sprintf("%d", step.Retries),
+			step.Condition,
 			string(*step.Type()),
 		})
 	}
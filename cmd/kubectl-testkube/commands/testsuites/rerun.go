@@ -0,0 +1,59 @@
+package testsuites
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common"
+	"github.com/kubeshop/testkube/pkg/ui"
+)
+
+func NewRerunTestSuiteExecutionCmd() *cobra.Command {
+	var watchEnabled bool
+
+	cmd := &cobra.Command{
+		Use:     "testsuiteexecution <executionID>",
+		Aliases: []string{"tse", "testsuites-execution", "testsuite-execution"},
+		Short:   "Reruns test suite execution from its first failed step",
+		Long:    `Reruns test suite execution from its first failed step, results and output variables of previous steps are carried over`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("please pass 'Execution ID' as argument")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			startTime := time.Now()
+			executionID := args[0]
+			client, _ := common.GetClient(cmd)
+
+			execution, err := client.RerunTestSuiteExecution(executionID)
+			ui.ExitOnError("rerunning test suite execution "+executionID, err)
+
+			if watchEnabled {
+				executionCh, err := client.WatchTestSuiteExecution(execution.Id)
+				for execution := range executionCh {
+					ui.ExitOnError("watching test execution", err)
+					printExecution(execution, startTime)
+				}
+			}
+
+			execution, err = client.GetTestSuiteExecution(execution.Id)
+			printExecution(execution, startTime)
+			ui.ExitOnError("getting recent execution data id:"+execution.Id, err)
+
+			uiPrintExecutionStatus(execution)
+
+			uiShellTestSuiteGetCommandBlock(execution.Id)
+			if !watchEnabled {
+				uiShellTestSuiteWatchCommandBlock(execution.Id)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&watchEnabled, "watch", "f", false, "watch for changes after start")
+
+	return cmd
+}
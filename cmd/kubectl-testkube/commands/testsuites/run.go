@@ -24,6 +24,7 @@ func NewRunTestSuiteCmd() *cobra.Command {
 		selectors                []string
 		concurrencyLevel         int
 		httpProxy, httpsProxy    string
+		force                    bool
 	)
 
 	cmd := &cobra.Command{
@@ -43,6 +44,8 @@ func NewRunTestSuiteCmd() *cobra.Command {
 				ExecutionParams: params,
 				HTTPProxy:       httpProxy,
 				HTTPSProxy:      httpsProxy,
+				Force:           force,
+				RunningContext:  common.GetRunningContext(),
 			}
 
 			switch {
@@ -107,6 +110,7 @@ func NewRunTestSuiteCmd() *cobra.Command {
 	cmd.Flags().IntVar(&concurrencyLevel, "concurrency", 10, "concurrency level for multiple test suite execution")
 	cmd.Flags().StringVar(&httpProxy, "http-proxy", "", "http proxy for executor containers")
 	cmd.Flags().StringVar(&httpsProxy, "https-proxy", "", "https proxy for executor containers")
+	cmd.Flags().BoolVar(&force, "force", false, "run test suite even if it's disabled")
 
 	return cmd
 }
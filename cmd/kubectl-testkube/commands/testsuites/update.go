@@ -8,6 +8,7 @@ import (
 
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common"
 	testkubeapiv1 "github.com/kubeshop/testkube/pkg/api/v1/client"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/kubeshop/testkube/pkg/ui"
 	"github.com/spf13/cobra"
 )
@@ -19,6 +20,7 @@ func NewUpdateTestSuitesCmd() *cobra.Command {
 		name     string
 		labels   map[string]string
 		schedule string
+		crdOnly  bool
 	)
 
 	cmd := &cobra.Command{
@@ -48,6 +50,18 @@ func NewUpdateTestSuitesCmd() *cobra.Command {
 				options.Name = name
 			}
 
+			if crdOnly {
+				options.Namespace = cmd.Flag("namespace").Value.String()
+				options.Labels = labels
+				options.Schedule = schedule
+				options.Ownership = common.NewOwnershipFromFlags(cmd, options.Ownership)
+				options.Enabled = common.NewEnabledFromFlags(cmd, options.Enabled)
+				options.Triggers, err = common.NewTriggersFromFlags(cmd, options.Triggers)
+				ui.ExitOnError("validating triggers", err)
+				renderTestSuiteCRD(testkube.TestSuiteUpsertRequest(options))
+				return
+			}
+
 			client, namespace := common.GetClient(cmd)
 			options.Namespace = namespace
 
@@ -65,6 +79,20 @@ func NewUpdateTestSuitesCmd() *cobra.Command {
 
 			options.Schedule = cmd.Flag("schedule").Value.String()
 
+			// ownership of file is used, existing ownership is kept otherwise
+			if options.Ownership == nil {
+				options.Ownership = testSuite.Ownership
+			}
+			options.Ownership = common.NewOwnershipFromFlags(cmd, options.Ownership)
+			options.Enabled = common.NewEnabledFromFlags(cmd, options.Enabled)
+
+			// triggers of file are used, existing triggers are kept otherwise
+			if options.Triggers == nil {
+				options.Triggers = testSuite.Triggers
+			}
+			options.Triggers, err = common.NewTriggersFromFlags(cmd, options.Triggers)
+			ui.ExitOnError("validating triggers", err)
+
 			err = validateSchedule(options.Schedule)
 			ui.ExitOnError("validating schedule", err)
 
@@ -79,6 +107,10 @@ func NewUpdateTestSuitesCmd() *cobra.Command {
 	cmd.Flags().StringVar(&name, "name", "", "Set/Override test suite name")
 	cmd.Flags().StringToStringVarP(&labels, "label", "l", nil, "label key value pair: --label key1=value1")
 	cmd.Flags().StringVarP(&schedule, "schedule", "", "", "test suite schedule in a cronjob form: * * * * *")
+	common.AddOwnershipFlags(cmd)
+	common.AddTriggerFlags(cmd)
+	common.AddEnabledFlag(cmd)
+	common.AddCRDOnlyFlag(cmd, &crdOnly)
 
 	return cmd
 }
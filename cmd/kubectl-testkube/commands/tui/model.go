@@ -0,0 +1,176 @@
+package tui
+
+import (
+	"sort"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// Tab is a resource list shown in terminal UI
+type Tab int
+
+const (
+	TabTests Tab = iota
+	TabTestSuites
+)
+
+// maxLogLines is a number of last log lines kept in logs pane
+const maxLogLines = 200
+
+// Row is a test or test suite with its latest execution
+type Row struct {
+	Name        string
+	Type        string
+	Status      string
+	ExecutionID string
+}
+
+// IsRunning checks if latest execution of row is still running
+func (r Row) IsRunning() bool {
+	return r.Status == string(testkube.RUNNING_ExecutionStatus) || r.Status == string(testkube.QUEUED_ExecutionStatus)
+}
+
+// Model is a state of terminal UI
+type Model struct {
+	Tab        Tab
+	Tests      []Row
+	TestSuites []Row
+	Running    []testkube.ExecutionSummary
+	Cursor     int
+	// Message is a result of last action, it's cleared by next key press
+	Message string
+	// RefreshError is an error of last refresh, it's cleared by next successful refresh
+	RefreshError string
+	// LogsID is an id of execution with logs shown, logs pane is hidden when empty
+	LogsID string
+	Logs   []string
+}
+
+// Rows returns rows of current tab
+func (m *Model) Rows() []Row {
+	if m.Tab == TabTestSuites {
+		return m.TestSuites
+	}
+
+	return m.Tests
+}
+
+// Selected returns row under cursor
+func (m *Model) Selected() (row Row, ok bool) {
+	rows := m.Rows()
+	if m.Cursor < 0 || m.Cursor >= len(rows) {
+		return row, false
+	}
+
+	return rows[m.Cursor], true
+}
+
+// MoveCursor moves cursor by delta keeping it in rows range
+func (m *Model) MoveCursor(delta int) {
+	m.Cursor += delta
+	m.clampCursor()
+}
+
+// SwitchTab toggles between tests and test suites
+func (m *Model) SwitchTab() {
+	if m.Tab == TabTests {
+		m.Tab = TabTestSuites
+	} else {
+		m.Tab = TabTests
+	}
+
+	m.Cursor = 0
+}
+
+// SetTests replaces tests rows with latest executions
+func (m *Model) SetTests(tests testkube.TestWithExecutions) {
+	m.Tests = m.Tests[:0]
+	for _, t := range tests {
+		if t.Test == nil {
+			continue
+		}
+
+		row := Row{Name: t.Test.Name, Type: t.Test.Type_}
+		if t.LatestExecution != nil {
+			row.ExecutionID = t.LatestExecution.Id
+			if t.LatestExecution.ExecutionResult != nil && t.LatestExecution.ExecutionResult.Status != nil {
+				row.Status = string(*t.LatestExecution.ExecutionResult.Status)
+			}
+		}
+
+		m.Tests = append(m.Tests, row)
+	}
+
+	sortRows(m.Tests)
+	m.clampCursor()
+}
+
+// SetTestSuites replaces test suites rows with latest executions
+func (m *Model) SetTestSuites(testSuites testkube.TestSuiteWithExecutions) {
+	m.TestSuites = m.TestSuites[:0]
+	for _, t := range testSuites {
+		if t.TestSuite == nil {
+			continue
+		}
+
+		row := Row{Name: t.TestSuite.Name, Type: "test suite"}
+		if t.LatestExecution != nil {
+			row.ExecutionID = t.LatestExecution.Id
+			if t.LatestExecution.Status != nil {
+				row.Status = string(*t.LatestExecution.Status)
+			}
+		}
+
+		m.TestSuites = append(m.TestSuites, row)
+	}
+
+	sortRows(m.TestSuites)
+	m.clampCursor()
+}
+
+// SetRunning replaces running executions with ones from executions list
+func (m *Model) SetRunning(executions testkube.ExecutionsResult) {
+	m.Running = m.Running[:0]
+	for _, execution := range executions.Results {
+		if execution.Status != nil && (*execution.Status == testkube.RUNNING_ExecutionStatus || *execution.Status == testkube.QUEUED_ExecutionStatus) {
+			m.Running = append(m.Running, execution)
+		}
+	}
+}
+
+// ShowLogs opens logs pane for execution
+func (m *Model) ShowLogs(id string) {
+	m.LogsID = id
+	m.Logs = nil
+}
+
+// HideLogs closes logs pane
+func (m *Model) HideLogs() {
+	m.LogsID = ""
+	m.Logs = nil
+}
+
+// AddLogLine appends line to logs pane keeping only last lines
+func (m *Model) AddLogLine(line string) {
+	m.Logs = append(m.Logs, line)
+	if len(m.Logs) > maxLogLines {
+		m.Logs = m.Logs[len(m.Logs)-maxLogLines:]
+	}
+}
+
+func (m *Model) clampCursor() {
+	rows := m.Rows()
+	if m.Cursor >= len(rows) {
+		m.Cursor = len(rows) - 1
+	}
+
+	if m.Cursor < 0 {
+		m.Cursor = 0
+	}
+}
+
+func sortRows(rows []Row) {
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Name < rows[j].Name
+	})
+}
@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func testModel() Model {
+	var m Model
+	m.SetTests(testkube.TestWithExecutions{
+		{Test: &testkube.Test{Name: "b-test", Type_: "curl/test"}, LatestExecution: &testkube.Execution{
+			Id:              "exec-2",
+			ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusRunning},
+		}},
+		{Test: &testkube.Test{Name: "a-test", Type_: "postman/collection"}},
+	})
+	m.SetTestSuites(testkube.TestSuiteWithExecutions{
+		{TestSuite: &testkube.TestSuite{Name: "suite"}, LatestExecution: &testkube.TestSuiteExecution{
+			Id:     "suite-exec",
+			Status: testkube.TestSuiteExecutionStatusPassed,
+		}},
+	})
+
+	return m
+}
+
+func TestModel(t *testing.T) {
+	t.Run("rows are sorted with latest execution", func(t *testing.T) {
+		m := testModel()
+
+		assert.Equal(t, []Row{
+			{Name: "a-test", Type: "postman/collection"},
+			{Name: "b-test", Type: "curl/test", Status: "running", ExecutionID: "exec-2"},
+		}, m.Tests)
+		assert.Equal(t, []Row{{Name: "suite", Type: "test suite", Status: "passed", ExecutionID: "suite-exec"}}, m.TestSuites)
+	})
+
+	t.Run("cursor stays in rows range", func(t *testing.T) {
+		m := testModel()
+
+		m.MoveCursor(-1)
+		assert.Equal(t, 0, m.Cursor)
+
+		m.MoveCursor(5)
+		row, ok := m.Selected()
+		assert.True(t, ok)
+		assert.Equal(t, "b-test", row.Name)
+		assert.True(t, row.IsRunning())
+
+		m.SwitchTab()
+		row, ok = m.Selected()
+		assert.True(t, ok)
+		assert.Equal(t, "suite", row.Name)
+	})
+
+	t.Run("running executions are filtered", func(t *testing.T) {
+		m := testModel()
+		m.SetRunning(testkube.ExecutionsResult{Results: []testkube.ExecutionSummary{
+			{Id: "1", Status: testkube.ExecutionStatusRunning},
+			{Id: "2", Status: testkube.ExecutionStatusPassed},
+			{Id: "3", Status: testkube.ExecutionStatusQueued},
+		}})
+
+		assert.Len(t, m.Running, 2)
+		assert.Equal(t, "3", m.Running[1].Id)
+	})
+
+	t.Run("only last log lines are kept", func(t *testing.T) {
+		m := testModel()
+		m.ShowLogs("exec-2")
+		for i := 0; i < maxLogLines+10; i++ {
+			m.AddLogLine("line")
+		}
+
+		assert.Len(t, m.Logs, maxLogLines)
+
+		m.HideLogs()
+		assert.Empty(t, m.LogsID)
+		assert.Empty(t, m.Logs)
+	})
+}
+
+func TestRender(t *testing.T) {
+	t.Run("tests table with cursor", func(t *testing.T) {
+		m := testModel()
+		m.MoveCursor(1)
+
+		screen := Render(m, 40)
+
+		assert.Contains(t, screen, "b-test")
+		assert.Contains(t, screen, "exec-2")
+		assert.NotContains(t, screen, "suite-exec")
+		for _, line := range strings.Split(screen, lineSeparator) {
+			if strings.Contains(line, "b-test") {
+				assert.True(t, strings.HasPrefix(line, ">"))
+			}
+		}
+	})
+
+	t.Run("logs pane shows last lines fitting screen", func(t *testing.T) {
+		m := testModel()
+		m.ShowLogs("exec-2")
+		m.AddLogLine("first")
+		m.AddLogLine("second")
+		m.AddLogLine("third")
+
+		screen := Render(m, 6)
+
+		assert.NotContains(t, screen, "first")
+		assert.Contains(t, screen, "second")
+		assert.Contains(t, screen, "third")
+	})
+}
@@ -0,0 +1,277 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common"
+	"github.com/kubeshop/testkube/pkg/api/v1/client"
+	"github.com/kubeshop/testkube/pkg/executor/output"
+	"github.com/kubeshop/testkube/pkg/ui"
+)
+
+const (
+	defaultRefreshInterval = 2 * time.Second
+	runningExecutionsLimit = 100
+
+	keyCtrlC     = "\x03"
+	keyEscape    = "\x1b"
+	keyTab       = "\t"
+	keyArrowUp   = "\x1b[A"
+	keyArrowDown = "\x1b[B"
+
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	leaveScreen = "\x1b[?25h\x1b[?1049l"
+	clearScreen = "\x1b[H\x1b[2J"
+)
+
+func NewTuiCmd() *cobra.Command {
+	var refreshInterval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "tui",
+		Short: "Open terminal UI with tests and test suites",
+		Long:  `Open terminal UI listing tests and test suites with their latest status and running executions, tests can be run, aborted and their logs tailed with keybindings`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if refreshInterval <= 0 {
+				ui.Failf("refresh interval has to be positive")
+			}
+
+			fd := int(os.Stdin.Fd())
+			if !term.IsTerminal(fd) {
+				ui.Failf("terminal UI needs interactive terminal")
+			}
+
+			apiClient, _ := common.GetClient(cmd)
+			err := run(apiClient, fd, refreshInterval)
+			ui.ExitOnError("running terminal UI", err)
+		},
+	}
+
+	cmd.Flags().DurationVarP(&refreshInterval, "refresh", "", defaultRefreshInterval, "interval of refreshing tests and executions status")
+
+	return cmd
+}
+
+// app binds terminal UI model with API client
+type app struct {
+	client client.Client
+	model  Model
+	logs   chan output.Output
+}
+
+func run(apiClient client.Client, fd int, refreshInterval time.Duration) error {
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(enterScreen)
+	defer func() {
+		fmt.Print(leaveScreen)
+		_ = term.Restore(fd, state)
+	}()
+
+	keys := make(chan string)
+	go readKeys(keys)
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	a := &app{client: apiClient}
+	a.refresh()
+	for {
+		a.draw(fd)
+
+		select {
+		case key, ok := <-keys:
+			if !ok || !a.handleKey(key) {
+				return nil
+			}
+		case out, ok := <-a.logs:
+			if !ok {
+				a.logs = nil
+				a.model.AddLogLine(ui.LightGray("--- logs stream closed ---"))
+				continue
+			}
+			a.model.AddLogLine(out.String())
+		case <-ticker.C:
+			a.refresh()
+		}
+	}
+}
+
+// readKeys sends key presses read from stdin, escape sequences are sent as single key
+func readKeys(keys chan<- string) {
+	defer close(keys)
+
+	buf := make([]byte, 16)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return
+		}
+
+		keys <- string(buf[:n])
+	}
+}
+
+func (a *app) draw(fd int) {
+	_, height, err := term.GetSize(fd)
+	if err != nil {
+		height = 0
+	}
+
+	fmt.Print(clearScreen + Render(a.model, height))
+}
+
+// handleKey applies key press action, false is returned when terminal UI should quit
+func (a *app) handleKey(key string) bool {
+	if key == "q" || key == keyCtrlC {
+		return false
+	}
+
+	// action result stays shown until next key press
+	a.model.Message = ""
+	if a.model.LogsID != "" {
+		if key == "l" || key == keyEscape {
+			a.model.HideLogs()
+			a.closeLogs()
+		}
+		return true
+	}
+
+	switch key {
+	case "j", keyArrowDown:
+		a.model.MoveCursor(1)
+	case "k", keyArrowUp:
+		a.model.MoveCursor(-1)
+	case keyTab:
+		a.model.SwitchTab()
+		a.refresh()
+	case "r":
+		a.runSelected()
+	case "a":
+		a.abortSelected()
+	case "l":
+		a.showSelectedLogs()
+	}
+
+	return true
+}
+
+// refresh fetches rows of current tab and running executions, other tab is fetched when it's switched to
+func (a *app) refresh() {
+	if a.model.Tab == TabTestSuites {
+		testSuites, err := a.client.ListTestSuiteWithExecutions("")
+		if err != nil {
+			a.model.RefreshError = ui.Red("getting test suites: " + err.Error())
+			return
+		}
+		a.model.SetTestSuites(testSuites)
+	} else {
+		tests, err := a.client.ListTestWithExecutions("")
+		if err != nil {
+			a.model.RefreshError = ui.Red("getting tests: " + err.Error())
+			return
+		}
+		a.model.SetTests(tests)
+	}
+
+	executions, err := a.client.ListExecutions("", runningExecutionsLimit, "")
+	if err != nil {
+		a.model.RefreshError = ui.Red("getting executions: " + err.Error())
+		return
+	}
+	a.model.SetRunning(executions)
+	a.model.RefreshError = ""
+}
+
+func (a *app) runSelected() {
+	row, ok := a.model.Selected()
+	if !ok {
+		return
+	}
+
+	if a.model.Tab == TabTestSuites {
+		execution, err := a.client.ExecuteTestSuite(row.Name, "", client.ExecuteTestSuiteOptions{})
+		a.setResult("running test suite "+row.Name, "started test suite execution "+execution.Name, err)
+	} else {
+		execution, err := a.client.ExecuteTest(row.Name, "", client.ExecuteTestOptions{RunningContext: common.GetRunningContext()})
+		a.setResult("running test "+row.Name, "started test execution "+execution.Name, err)
+	}
+
+	a.refresh()
+}
+
+func (a *app) abortSelected() {
+	row, ok := a.model.Selected()
+	if !ok {
+		return
+	}
+
+	if a.model.Tab == TabTestSuites {
+		a.model.Message = ui.Yellow("aborting test suite executions is not supported")
+		return
+	}
+
+	if !row.IsRunning() {
+		a.model.Message = ui.Yellow("test " + row.Name + " has no running execution")
+		return
+	}
+
+	err := a.client.AbortExecution(row.Name, row.ExecutionID)
+	a.setResult("aborting execution "+row.ExecutionID, "aborted execution "+row.ExecutionID, err)
+	a.refresh()
+}
+
+func (a *app) showSelectedLogs() {
+	row, ok := a.model.Selected()
+	if !ok {
+		return
+	}
+
+	if a.model.Tab == TabTestSuites {
+		a.model.Message = ui.Yellow("logs are available for test executions only")
+		return
+	}
+
+	if row.ExecutionID == "" {
+		a.model.Message = ui.Yellow("test " + row.Name + " has no executions")
+		return
+	}
+
+	logs, err := a.client.Logs(row.ExecutionID)
+	if err != nil {
+		a.model.Message = ui.Red("getting logs: " + err.Error())
+		return
+	}
+
+	a.model.ShowLogs(row.ExecutionID)
+	a.logs = logs
+}
+
+// closeLogs drains logs stream in background so its producer is not blocked
+func (a *app) closeLogs() {
+	if a.logs != nil {
+		go func(logs chan output.Output) {
+			for range logs {
+			}
+		}(a.logs)
+	}
+
+	a.logs = nil
+}
+
+func (a *app) setResult(action, success string, err error) {
+	if err != nil {
+		a.model.Message = ui.Red(action + ": " + err.Error())
+		return
+	}
+
+	a.model.Message = ui.Green(success)
+}
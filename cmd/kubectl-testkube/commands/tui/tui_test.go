@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/client"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// listedClient lists tests, test suites and executions, listing fails when err is set
+type listedClient struct {
+	client.Client
+	err        error
+	testSuites int
+}
+
+func (c *listedClient) ListTestWithExecutions(selector string) (testkube.TestWithExecutions, error) {
+	return testkube.TestWithExecutions{{Test: &testkube.Test{Name: "test"}}}, c.err
+}
+
+func (c *listedClient) ListTestSuiteWithExecutions(selector string) (testkube.TestSuiteWithExecutions, error) {
+	c.testSuites++
+	return testkube.TestSuiteWithExecutions{{TestSuite: &testkube.TestSuite{Name: "suite"}}}, c.err
+}
+
+func (c *listedClient) ListExecutions(id string, limit int, selector string) (testkube.ExecutionsResult, error) {
+	return testkube.ExecutionsResult{}, c.err
+}
+
+func TestRefresh(t *testing.T) {
+	apiClient := &listedClient{}
+	a := &app{client: apiClient}
+
+	a.refresh()
+	assert.Len(t, a.model.Tests, 1)
+	assert.Equal(t, 0, apiClient.testSuites, "only current tab is fetched")
+
+	a.handleKey(keyTab)
+	assert.Len(t, a.model.TestSuites, 1)
+	assert.Equal(t, 1, apiClient.testSuites)
+
+	apiClient.err = fmt.Errorf("connection refused")
+	a.refresh()
+	assert.Contains(t, a.model.RefreshError, "connection refused")
+
+	apiClient.err = nil
+	a.refresh()
+	assert.Empty(t, a.model.RefreshError, "refresh error is cleared by successful refresh")
+
+	a.model.Message = "aborted execution"
+	a.handleKey("j")
+	assert.Empty(t, a.model.Message, "action result is cleared by key press")
+}
@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/ui"
+)
+
+// lineSeparator moves cursor to line start as terminal is in raw mode
+const lineSeparator = "\r\n"
+
+const (
+	helpLine     = "j/k: move  tab: tests/suites  r: run  a: abort  l: logs  q: quit"
+	logsHelpLine = "l/esc: back  q: quit"
+)
+
+// Render renders model as terminal screen with height lines at most
+func Render(m Model, height int) string {
+	if m.LogsID != "" {
+		return strings.Join(renderLogs(m, height), lineSeparator)
+	}
+
+	var lines []string
+	lines = append(lines, renderTabs(m.Tab), "")
+
+	rows := m.Rows()
+	nameWidth, typeWidth := len("NAME"), len("TYPE")
+	for _, row := range rows {
+		nameWidth = max(nameWidth, len(row.Name))
+		typeWidth = max(typeWidth, len(row.Type))
+	}
+
+	format := fmt.Sprintf("  %%-%ds  %%-%ds  %%-10s  %%s", nameWidth, typeWidth)
+	lines = append(lines, ui.LightGray(fmt.Sprintf(format, "NAME", "TYPE", "STATUS", "EXECUTION ID")))
+	if len(rows) == 0 {
+		lines = append(lines, "  no resources found")
+	}
+
+	for i, row := range rows {
+		line := fmt.Sprintf(format, row.Name, row.Type, colorStatus(fmt.Sprintf("%-10s", row.Status)), row.ExecutionID)
+		if i == m.Cursor {
+			line = ">" + line[1:]
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", ui.LightGray(fmt.Sprintf("RUNNING EXECUTIONS (%d)", len(m.Running))))
+	for _, execution := range m.Running {
+		lines = append(lines, fmt.Sprintf("  %s  %s  %s", execution.Name, execution.TestName, execution.Id))
+	}
+
+	lines = append(lines, "")
+	if m.RefreshError != "" {
+		lines = append(lines, m.RefreshError)
+	}
+	lines = append(lines, m.Message, ui.DarkGray(helpLine))
+	return strings.Join(lines, lineSeparator)
+}
+
+func renderTabs(tab Tab) string {
+	tests, testSuites := " Tests ", " Test Suites "
+	if tab == TabTests {
+		tests = ui.LightCyan("[Tests]")
+	} else {
+		testSuites = ui.LightCyan("[Test Suites]")
+	}
+
+	return "Testkube  " + tests + " " + testSuites
+}
+
+func renderLogs(m Model, height int) []string {
+	lines := []string{"Logs of execution " + ui.LightCyan(m.LogsID), ""}
+
+	logs := m.Logs
+	// header, help line and separators are always shown
+	if limit := height - 4; limit > 0 && len(logs) > limit {
+		logs = logs[len(logs)-limit:]
+	}
+
+	lines = append(lines, logs...)
+	return append(lines, "", ui.DarkGray(logsHelpLine))
+}
+
+func colorStatus(status string) string {
+	switch testkube.ExecutionStatus(strings.TrimSpace(status)) {
+	case testkube.PASSED_ExecutionStatus:
+		return ui.Green(status)
+	case testkube.FAILED_ExecutionStatus, testkube.TIMEOUT_ExecutionStatus:
+		return ui.Red(status)
+	case testkube.RUNNING_ExecutionStatus, testkube.QUEUED_ExecutionStatus:
+		return ui.Yellow(status)
+	case testkube.ABORTED_ExecutionStatus:
+		return ui.LightRed(status)
+	}
+
+	return status
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
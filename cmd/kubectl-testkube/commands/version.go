@@ -1,6 +1,9 @@
 package commands
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common"
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common/validator"
 	"github.com/kubeshop/testkube/pkg/ui"
@@ -23,6 +26,15 @@ func NewVersionCmd() *cobra.Command {
 			ui.Logo()
 			ui.Info("Client Version", Version)
 			ui.Info("Server Version", info.Version)
+			// older servers don't report schema version and features
+			if info.SchemaVersion != "" {
+				ui.Info("Server API Schema", info.SchemaVersion)
+			}
+			if info.Features != nil {
+				ui.Info("Artifact Storage", fmt.Sprintf("%v", info.Features.ArtifactStorage))
+				ui.Info("Notifiers", strings.Join(info.Features.Notifiers, ", "))
+				ui.Info("Auth Mode", info.Features.AuthMode)
+			}
 			ui.Info("Commit", Commit)
 			ui.Info("Built by", BuiltBy)
 			ui.Info("Build date", Date)
@@ -3,6 +3,7 @@ package webhooks
 import (
 	"github.com/kubeshop/testkube/cmd/kubectl-testkube/commands/common"
 	apiv1 "github.com/kubeshop/testkube/pkg/api/v1/client"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	webhooksmapper "github.com/kubeshop/testkube/pkg/mapper/webhooks"
 	"github.com/kubeshop/testkube/pkg/ui"
 	"github.com/spf13/cobra"
@@ -10,9 +11,10 @@ import (
 
 func NewCreateWebhookCmd() *cobra.Command {
 	var (
-		events    []string
-		name, uri string
-		labels    map[string]string
+		events, statuses                   []string
+		name, uri, selector, signingSecret string
+		proxyURL, tlsSecret                string
+		labels                             map[string]string
 	)
 
 	cmd := &cobra.Command{
@@ -36,11 +38,18 @@ func NewCreateWebhookCmd() *cobra.Command {
 			}
 
 			options := apiv1.CreateWebhookOptions{
-				Name:      name,
-				Namespace: namespace,
-				Events:    webhooksmapper.MapStringArrayToCRDEvents(events),
-				Uri:       uri,
-				Labels:    labels,
+				Name:          name,
+				Namespace:     namespace,
+				Events:        webhooksmapper.MapStringArrayToCRDEvents(events),
+				Uri:           uri,
+				Selector:      selector,
+				SigningSecret: signingSecret,
+				ProxyURL:      proxyURL,
+				TLSSecret:     tlsSecret,
+				Labels:        labels,
+			}
+			for _, status := range statuses {
+				options.Statuses = append(options.Statuses, testkube.ExecutionStatus(status))
 			}
 
 			_, err = client.CreateWebhook(options)
@@ -51,8 +60,13 @@ func NewCreateWebhookCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&name, "name", "n", "", "unique webhook name - mandatory")
-	cmd.Flags().StringArrayVarP(&events, "events", "e", []string{}, "event types handled by executor e.g. start-test|end-test")
+	cmd.Flags().StringArrayVarP(&events, "events", "e", []string{}, "event types handled by executor e.g. start-test|end-test|end-testsuite")
 	cmd.Flags().StringVarP(&uri, "uri", "u", "", "URI which should be called when given event occurs")
+	cmd.Flags().StringVarP(&selector, "selector", "", "", "label selector of tests which executions are notified e.g. team=checkout")
+	cmd.Flags().StringArrayVarP(&statuses, "status", "", []string{}, "statuses of notified executions e.g. failed|passed")
+	cmd.Flags().StringVarP(&signingSecret, "signing-secret", "", "", "secret used to sign webhook payloads with HMAC-SHA256")
+	cmd.Flags().StringVarP(&proxyURL, "proxy-url", "", "", "URL of proxy used to call webhook e.g. http://proxy.example.com:3128")
+	cmd.Flags().StringVarP(&tlsSecret, "tls-secret", "", "", "name of Secret with ca.crt CA bundle and tls.crt and tls.key client certificate")
 	cmd.Flags().StringToStringVarP(&labels, "label", "l", nil, "label key value pair: --label key1=value1")
 
 	return cmd
@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/alerting"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// alertingConfig is an incident alerting configuration, alerting is disabled without PagerDuty or Opsgenie key
+type alertingConfig struct {
+	// Selector selects tests which failures open incidents
+	Selector string `default:"critical=true"`
+	// ConsecutiveFailures is a number of consecutive failed executions opening incident
+	ConsecutiveFailures int `default:"3"`
+	// Interval is an interval of checking selected tests executions
+	Interval time.Duration `default:"1m"`
+	// PagerDutyRoutingKey is a PagerDuty Events API v2 integration key
+	PagerDutyRoutingKey string
+	// OpsgenieAPIKey is an Opsgenie API integration key
+	OpsgenieAPIKey string
+}
+
+// alertingState is shared between API copies as handlers have value receivers
+type alertingState struct {
+	config   alertingConfig
+	alerters []alerting.Alerter
+	tracker  *alerting.Tracker
+}
+
+// newAlertingState returns alerting state, alerters use default client when client is nil
+func newAlertingState(config alertingConfig, client *http.Client) *alertingState {
+	state := &alertingState{config: config, tracker: alerting.NewTracker()}
+	if config.PagerDutyRoutingKey != "" {
+		alerter := alerting.NewPagerDutyAlerter(config.PagerDutyRoutingKey)
+		if client != nil {
+			alerter.Client = client
+		}
+		state.alerters = append(state.alerters, alerter)
+	}
+
+	if config.OpsgenieAPIKey != "" {
+		alerter := alerting.NewOpsgenieAlerter(config.OpsgenieAPIKey)
+		if client != nil {
+			alerter.Client = client
+		}
+		state.alerters = append(state.alerters, alerter)
+	}
+
+	return state
+}
+
+// RunIncidentAlerting periodically opens incidents of selected tests failing configured times in a row and resolves
+// them when tests pass again, only leader instance checks tests
+func (s TestkubeAPI) RunIncidentAlerting(ctx context.Context) {
+	if s.alerting == nil || len(s.alerting.alerters) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.alerting.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if s.isLeader() {
+			s.checkIncidents(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s TestkubeAPI) checkIncidents(ctx context.Context) {
+	tests, err := s.TestsClient.List(s.alerting.config.Selector)
+	if err != nil {
+		s.Log.Errorw("listing tests for incident alerting", "error", err)
+		return
+	}
+
+	settings := s.getServerSettings(ctx)
+	// only finished executions are counted, so running executions don't hide failures
+	statuses := fmt.Sprintf("%s,%s,%s", testkube.PASSED_ExecutionStatus, testkube.FAILED_ExecutionStatus, testkube.TIMEOUT_ExecutionStatus)
+	for _, test := range tests.Items {
+		filter := result.NewExecutionsFilter().
+			WithTestName(test.Name).
+			WithStatus(statuses).
+			WithPageSize(s.alerting.config.ConsecutiveFailures).
+			WithExcludedFields(result.SummaryExcludedFields())
+		executions, err := s.ExecutionResults.GetExecutions(ctx, filter)
+		if err != nil {
+			s.Log.Errorw("getting test executions for incident alerting", "test", test.Name, "error", err)
+			continue
+		}
+
+		if len(executions) == 0 {
+			continue
+		}
+
+		failures := alerting.ConsecutiveFailures(executions)
+		// incidents aren't opened in maintenance windows and quiet hours, they are opened when window ends if test still fails
+		if failures >= s.alerting.config.ConsecutiveFailures &&
+			!testkube.MaintenanceWindowsEnd(time.Now(), settings.MaintenanceWindows, settings.QuietHours,
+				testkube.MaintenanceWindowsFromAnnotations(test.Annotations)).IsZero() {
+			continue
+		}
+
+		dedupKey := alerting.DedupKey(executions[0])
+		switch s.alerting.tracker.Next(dedupKey, failures, s.alerting.config.ConsecutiveFailures) {
+		case alerting.ActionTrigger:
+			s.Log.Infow("opening test incident", "dedupKey", dedupKey, "failures", failures)
+			for _, alerter := range s.alerting.alerters {
+				if err := alerter.Trigger(alerting.NewAlert(executions[0], failures)); err != nil {
+					s.Log.Errorw("opening test incident", "dedupKey", dedupKey, "error", err)
+				}
+			}
+		case alerting.ActionResolve:
+			for _, alerter := range s.alerting.alerters {
+				if err := alerter.Resolve(dedupKey); err != nil {
+					s.Log.Errorw("resolving test incident", "dedupKey", dedupKey, "error", err)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	executorv1 "github.com/kubeshop/testkube-operator/apis/executor/v1"
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/testresult"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/problem"
+	"github.com/kubeshop/testkube/pkg/slacknotifier"
+)
+
+// approvalPollInterval is an interval of checking approval decisions stored by other API servers
+const approvalPollInterval = 2 * time.Second
+
+// ApproveTestSuiteStepHandler records decision of approval step, test suite execution continues when step
+// is approved and is aborted when it's rejected
+func (s TestkubeAPI) ApproveTestSuiteStepHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id := c.Params("executionID")
+
+		step, err := strconv.Atoi(c.Params("stepIndex"))
+		if err != nil {
+			return s.Warn(c, http.StatusBadRequest, fmt.Errorf("invalid step index %s", c.Params("stepIndex")))
+		}
+
+		var decision testkube.TestSuiteStepApprovalDecision
+		if err = c.BodyParser(&decision); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if decision.Approver == "" {
+			return s.Warn(c, http.StatusBadRequest, fmt.Errorf("approver is required"))
+		}
+
+		execution, err := s.TestExecutionResults.Get(ctx, id)
+		if err == mongo.ErrNoDocuments {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteExecutionNotFound, fmt.Errorf("test suite execution %s not found", id)))
+		}
+
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't get test suite execution %s: %w", id, err))
+		}
+
+		if project := getProject(c); project != "" && execution.Project != project {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteExecutionNotFound, fmt.Errorf("test suite execution %s not found", id)))
+		}
+
+		result, code, err := s.approveTestSuiteStep(ctx, execution, step, decision)
+		if err != nil && code == http.StatusInternalServerError {
+			return s.Error(c, code, err)
+		}
+
+		if err != nil {
+			return s.Warn(c, code, err)
+		}
+
+		return c.JSON(result)
+	}
+}
+
+// SlackInteractionsHandler records decisions made with approve and reject buttons of Slack approval messages,
+// it's an interactivity request URL of Slack app
+func (s TestkubeAPI) SlackInteractionsHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		header := http.Header{}
+		c.Request().Header.VisitAll(func(key, value []byte) {
+			header.Add(string(key), string(value))
+		})
+
+		interaction, err := slacknotifier.ParseApprovalInteraction(header, c.Body())
+		if err != nil {
+			return s.Warn(c, http.StatusUnauthorized, err)
+		}
+
+		execution, err := s.TestExecutionResults.Get(ctx, interaction.TestSuiteExecutionId)
+		if err == mongo.ErrNoDocuments {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteExecutionNotFound, fmt.Errorf("test suite execution %s not found", interaction.TestSuiteExecutionId)))
+		}
+
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		if _, code, err := s.approveTestSuiteStep(ctx, execution, interaction.Step, interaction.Decision); err != nil {
+			return s.Warn(c, code, err)
+		}
+
+		return nil
+	}
+}
+
+// approveTestSuiteStep stores decision of approval step waiting for it, status code is returned with error
+func (s TestkubeAPI) approveTestSuiteStep(ctx context.Context, execution testkube.TestSuiteExecution, step int,
+	decision testkube.TestSuiteStepApprovalDecision) (result testkube.TestSuiteStepExecutionResult, code int, err error) {
+	if step < 0 || step >= len(execution.StepResults) {
+		return result, http.StatusNotFound, fmt.Errorf("test suite execution %s has no step %d", execution.Id, step)
+	}
+
+	stepResult := &execution.StepResults[step]
+	if stepResult.Step == nil || stepResult.Step.Type() != testkube.TestSuiteStepTypeApproval {
+		return result, http.StatusBadRequest, fmt.Errorf("step %d of test suite execution %s isn't approval step", step, execution.Id)
+	}
+
+	if stepResult.Approval != nil {
+		return result, http.StatusConflict, fmt.Errorf("step %d of test suite execution %s is already %s", step, execution.Id, stepResult.Approval)
+	}
+
+	if execution.Status == nil || !execution.IsRunning() || stepResult.Execution == nil || stepResult.Execution.ExecutionResult == nil ||
+		stepResult.Execution.ExecutionResult.Status == nil || !stepResult.Execution.ExecutionResult.IsRunning() {
+		return result, http.StatusConflict, fmt.Errorf("step %d of test suite execution %s isn't waiting for approval", step, execution.Id)
+	}
+
+	// decision is stored only when step isn't decided yet, so concurrent approvers can't overwrite it
+	decision.DecisionTime = time.Now()
+	err = s.TestExecutionResults.SetStepApproval(ctx, execution.Id, step, decision)
+	if err == testresult.ErrStepDecided {
+		return result, http.StatusConflict, fmt.Errorf("step %d of test suite execution %s is already decided", step, execution.Id)
+	}
+
+	if err != nil {
+		return result, http.StatusInternalServerError, fmt.Errorf("can't update test suite execution %s: %w", execution.Id, err)
+	}
+
+	stepResult.Approval = &decision
+
+	s.Log.Infow("test suite step approval decision", "executionId", execution.Id, "step", step, "decision", decision.String())
+	s.suiteRuns.notify(execution.Id)
+	return *stepResult, http.StatusOK, nil
+}
+
+// waitForStepApproval notifies approvers and waits for decision of approval step, rejected step and step
+// not approved in time abort test suite execution
+func (s TestkubeAPI) waitForStepApproval(ctx context.Context, testsuiteExecution testkube.TestSuiteExecution, index int) {
+	result := &testsuiteExecution.StepResults[index]
+	approval := result.Step.Approval
+	s.notifyApprovalRequired(testsuiteExecution, index)
+
+	var timeout <-chan time.Time
+	if approval.Timeout > 0 {
+		timer := time.NewTimer(time.Duration(approval.Timeout) * time.Second)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	// decisions stored by this API server are noticed by update notification, other ones are polled
+	ticker := time.NewTicker(approvalPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			result.Abort()
+			return
+		case <-timeout:
+			result.Abort()
+			result.TimedOut = true
+			result.Execution.ExecutionResult.ErrorMessage = fmt.Sprintf("step wasn't approved in %ds", approval.Timeout)
+			return
+		case <-s.suiteRuns.updated(testsuiteExecution.Id):
+		case <-ticker.C:
+		}
+
+		stored, err := s.TestExecutionResults.Get(context.Background(), testsuiteExecution.Id)
+		if err != nil {
+			s.Log.Warnw("getting test suite execution approval error", "executionId", testsuiteExecution.Id, "error", err)
+			continue
+		}
+
+		if index >= len(stored.StepResults) || stored.StepResults[index].Approval == nil {
+			continue
+		}
+
+		result.Approval = stored.StepResults[index].Approval
+		if result.Approval.Approved {
+			result.Execution.ExecutionResult.Success()
+			return
+		}
+
+		result.Abort()
+		result.Execution.ExecutionResult.ErrorMessage = fmt.Sprintf("step %s", result.Approval)
+		return
+	}
+}
+
+// notifyApprovalRequired sends approval request to webhooks subscribed to approval-required event and to Slack,
+// approval requests aren't held or batched into digests as they wait for action
+func (s TestkubeAPI) notifyApprovalRequired(testsuiteExecution testkube.TestSuiteExecution, index int) {
+	request := newApprovalRequest(testsuiteExecution, index, s.suiteRuns.approvalsURI())
+	settings := s.getServerSettings(context.Background())
+
+	if settings.WebhookNotifications && s.WebhooksClient != nil {
+		webhookList, err := s.WebhooksClient.GetByEvent(testkube.WebhookTypeApprovalRequired.String())
+		if err != nil {
+			s.Log.Warnw("getting approval webhooks error", "error", err)
+			webhookList = &executorv1.WebhookList{}
+		}
+
+		for _, wh := range webhookList.Items {
+			event, err := s.newWebhookEvent(wh, testkube.WebhookTypeApprovalRequired)
+			if err != nil {
+				s.Log.Warnw("skipping webhook with invalid signing or connection options", "webhook", wh.Name, "error", err)
+				continue
+			}
+
+			event.Approval = &request
+			s.EventsEmitter.Notify(event)
+		}
+	}
+
+	if settings.SlackNotifications {
+		if err := slacknotifier.SendApprovalRequest(request); err != nil {
+			s.Log.Warnw("notify slack approval request failed", "error", err)
+		}
+	}
+}
+
+// newApprovalRequest returns approval request of test suite execution step, decision URI is set when API URI is known
+func newApprovalRequest(testsuiteExecution testkube.TestSuiteExecution, index int, apiURI string) testkube.ApprovalRequest {
+	request := testkube.ApprovalRequest{
+		TestSuiteExecutionId:   testsuiteExecution.Id,
+		TestSuiteExecutionName: testsuiteExecution.Name,
+		Step:                   int32(index),
+		Labels:                 testsuiteExecution.Labels,
+	}
+
+	if testsuiteExecution.TestSuite != nil {
+		request.TestSuiteName = testsuiteExecution.TestSuite.Name
+	}
+
+	if step := testsuiteExecution.StepResults[index].Step; step != nil && step.Approval != nil {
+		request.Message = step.Approval.Message
+		request.Timeout = step.Approval.Timeout
+	}
+
+	if apiURI != "" {
+		request.Uri = fmt.Sprintf("%s/v1/test-suite-executions/%s/steps/%d/approve", strings.TrimSuffix(apiURI, "/"), testsuiteExecution.Id, index)
+	}
+
+	return request
+}
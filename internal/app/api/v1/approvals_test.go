@@ -0,0 +1,105 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/testresult"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/server"
+)
+
+func newApprovalTestSuiteExecution(status *testkube.ExecutionStatus) testkube.TestSuiteExecution {
+	approval := testkube.TestSuiteStep{Approval: &testkube.TestSuiteStepApproval{Message: "Deploy to prod?", Timeout: 3600}}
+	test := testkube.TestSuiteStep{Execute: &testkube.TestSuiteStepExecuteTest{Name: "api"}}
+	return testkube.TestSuiteExecution{
+		Id:        "62f1",
+		Name:      "release.1",
+		TestSuite: &testkube.ObjectRef{Name: "release"},
+		Status:    testkube.TestSuiteExecutionStatusRunning,
+		Labels:    map[string]string{"team": "checkout"},
+		StepResults: []testkube.TestSuiteStepExecutionResult{
+			{Step: &test, Execution: &testkube.Execution{ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed}}},
+			{Step: &approval, Execution: &testkube.Execution{ExecutionResult: &testkube.ExecutionResult{Status: status}}},
+		},
+	}
+}
+
+func TestNewApprovalRequest(t *testing.T) {
+	request := newApprovalRequest(newApprovalTestSuiteExecution(testkube.ExecutionStatusRunning), 1, "https://testkube.example.com/")
+
+	assert.Equal(t, testkube.ApprovalRequest{
+		TestSuiteExecutionId:   "62f1",
+		TestSuiteExecutionName: "release.1",
+		TestSuiteName:          "release",
+		Step:                   1,
+		Message:                "Deploy to prod?",
+		Timeout:                3600,
+		Uri:                    "https://testkube.example.com/v1/test-suite-executions/62f1/steps/1/approve",
+		Labels:                 map[string]string{"team": "checkout"},
+	}, request)
+
+	assert.Empty(t, newApprovalRequest(newApprovalTestSuiteExecution(testkube.ExecutionStatusRunning), 1, "").Uri)
+}
+
+func TestApproveTestSuiteStepErrors(t *testing.T) {
+	s := TestkubeAPI{HTTPServer: server.NewServer(server.Config{})}
+	decision := testkube.TestSuiteStepApprovalDecision{Approved: true, Approver: "jane"}
+	approved := newApprovalTestSuiteExecution(testkube.ExecutionStatusPassed)
+	approved.StepResults[1].Approval = &decision
+
+	cases := map[string]struct {
+		execution testkube.TestSuiteExecution
+		step      int
+		code      int
+	}{
+		"missing step":         {execution: newApprovalTestSuiteExecution(testkube.ExecutionStatusRunning), step: 2, code: http.StatusNotFound},
+		"test step":            {execution: newApprovalTestSuiteExecution(testkube.ExecutionStatusRunning), step: 0, code: http.StatusBadRequest},
+		"decided step":         {execution: approved, step: 1, code: http.StatusConflict},
+		"step not started yet": {execution: newApprovalTestSuiteExecution(testkube.ExecutionStatusQueued), step: 1, code: http.StatusConflict},
+	}
+
+	for name, c := range cases {
+		_, code, err := s.approveTestSuiteStep(context.Background(), c.execution, c.step, decision)
+		assert.Error(t, err, name)
+		assert.Equal(t, c.code, code, name)
+	}
+}
+
+// decidedResults stores approval decisions like concurrent approver decided step before
+type decidedResults struct {
+	testresult.Repository
+}
+
+func (decidedResults) SetStepApproval(ctx context.Context, id string, step int, decision testkube.TestSuiteStepApprovalDecision) error {
+	return testresult.ErrStepDecided
+}
+
+func TestApproveConcurrentlyDecidedStep(t *testing.T) {
+	s := TestkubeAPI{HTTPServer: server.NewServer(server.Config{}), TestExecutionResults: decidedResults{}}
+
+	_, code, err := s.approveTestSuiteStep(context.Background(), newApprovalTestSuiteExecution(testkube.ExecutionStatusRunning), 1,
+		testkube.TestSuiteStepApprovalDecision{Approved: true, Approver: "jane"})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusConflict, code)
+}
+
+func TestWaitForAbortedStepApproval(t *testing.T) {
+	s := TestkubeAPI{HTTPServer: server.NewServer(server.Config{}), suiteRuns: newSuiteRunsState(suiteStepsConfig{})}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	execution := newApprovalTestSuiteExecution(testkube.ExecutionStatusRunning)
+	s.waitForStepApproval(ctx, execution, 1)
+
+	assert.True(t, execution.StepResults[1].IsAborted())
+	assert.Nil(t, execution.StepResults[1].Approval)
+}
+
+func TestApprovalDecisionString(t *testing.T) {
+	assert.Equal(t, "approved by jane", testkube.TestSuiteStepApprovalDecision{Approved: true, Approver: "jane"}.String())
+	assert.Equal(t, "rejected", testkube.TestSuiteStepApprovalDecision{}.String())
+}
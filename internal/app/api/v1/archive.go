@@ -0,0 +1,397 @@
+package v1
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/problem"
+	"github.com/kubeshop/testkube/pkg/storage"
+	"github.com/kubeshop/testkube/pkg/storage/minio"
+)
+
+const (
+	// archiveInterval is an interval of moving executions older than archive period to object storage
+	archiveInterval = time.Hour
+	// archiveBatchSize is a number of executions read from database in one archiving step
+	archiveBatchSize = 100
+	// archiveObjectSuffix is a suffix of gzip compressed JSON executions in archive bucket
+	archiveObjectSuffix = ".json.gz"
+	// archiveIndexPrefix is a prefix of index objects with names of archived execution objects by execution id,
+	// test names can't start with dot, so index doesn't collide with executions of any test
+	archiveIndexPrefix = ".ids/"
+	// maxArchiveIndexSize limits read index object, it contains only test name and execution id
+	maxArchiveIndexSize = 1024
+)
+
+// archiveConfig configures object storage of archived executions
+type archiveConfig struct {
+	// Bucket is a bucket of archived executions
+	Bucket string `default:"testkube-archive"`
+}
+
+// executionArchive stores test executions as gzip compressed JSON objects named by test name and execution id,
+// archived executions are found by id with index objects, so the whole archive isn't listed
+type executionArchive struct {
+	config  archiveConfig
+	storage storage.Client
+	log     *zap.SugaredLogger
+}
+
+// store uploads execution to archive, archived execution is overwritten
+func (a *executionArchive) store(execution testkube.Execution) error {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if err := json.NewEncoder(writer).Encode(execution); err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	name := archiveObjectName(execution.TestName, execution.Id)
+	if err := a.storage.UploadFile(a.config.Bucket, name, bytes.NewReader(buffer.Bytes()), int64(buffer.Len())); err != nil {
+		return err
+	}
+
+	return a.storage.UploadFile(a.config.Bucket, archiveIndexPrefix+execution.Id, strings.NewReader(name), int64(len(name)))
+}
+
+// indexed returns name of archived execution object from index
+func (a *executionArchive) indexed(id string) (string, error) {
+	object, err := a.storage.DownloadFile(a.config.Bucket, archiveIndexPrefix+id)
+	if err != nil {
+		return "", err
+	}
+	defer object.Close()
+
+	name, err := io.ReadAll(io.LimitReader(object, maxArchiveIndexSize))
+	if err != nil {
+		return "", err
+	}
+
+	return string(name), nil
+}
+
+// load downloads archived execution stored in object
+func (a *executionArchive) load(objectName string) (execution testkube.Execution, err error) {
+	object, err := a.storage.DownloadFile(a.config.Bucket, objectName)
+	if err != nil {
+		return execution, fmt.Errorf("can't download archived execution %s: %w", objectName, err)
+	}
+	defer object.Close()
+
+	reader, err := gzip.NewReader(object)
+	if err != nil {
+		return execution, fmt.Errorf("can't decompress archived execution %s: %w", objectName, err)
+	}
+	defer reader.Close()
+
+	if err = json.NewDecoder(reader).Decode(&execution); err != nil {
+		return execution, fmt.Errorf("can't decode archived execution %s: %w", objectName, err)
+	}
+
+	return execution, nil
+}
+
+// objects returns names of archived execution objects, only objects of test are listed when test name is set
+func (a *executionArchive) objects(testName string) ([]string, error) {
+	prefix := ""
+	if testName != "" {
+		prefix = testName + "/"
+	}
+
+	files, err := a.storage.ListFilesWithPrefix(a.config.Bucket, prefix)
+	if err == minio.ErrArtifactsNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("can't list archived executions: %w", err)
+	}
+
+	var names []string
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name, archiveObjectSuffix) {
+			continue
+		}
+		names = append(names, file.Name)
+	}
+
+	return names, nil
+}
+
+// list returns page of archived executions matching filter from newest, every archived execution of matching test is downloaded
+func (a *executionArchive) list(filter result.Filter) ([]testkube.Execution, error) {
+	testName := ""
+	if filter.TestNameDefined() {
+		testName = filter.TestName()
+	}
+
+	names, err := a.objects(testName)
+	if err != nil {
+		return nil, err
+	}
+
+	executions := []testkube.Execution{}
+	for _, name := range names {
+		execution, err := a.load(name)
+		if err != nil {
+			a.log.Warnw("skipping archived execution", "object", name, "error", err)
+			continue
+		}
+
+		if matchesFilter(execution, filter) {
+			executions = append(executions, execution)
+		}
+	}
+
+	sort.Slice(executions, func(i, j int) bool {
+		return executions[i].StartTime.After(executions[j].StartTime)
+	})
+
+	return pageExecutions(executions, filter.Page(), filter.PageSize()), nil
+}
+
+// get returns archived execution with given id, executions archived before index was introduced are searched
+// in archive objects
+func (a *executionArchive) get(id string) (execution testkube.Execution, err error) {
+	if name, err := a.indexed(id); err == nil && strings.HasSuffix(name, "/"+id+archiveObjectSuffix) {
+		return a.load(name)
+	}
+
+	names, err := a.objects("")
+	if err != nil {
+		return execution, err
+	}
+
+	for _, name := range names {
+		if strings.HasSuffix(name, "/"+id+archiveObjectSuffix) {
+			return a.load(name)
+		}
+	}
+
+	return execution, mongo.ErrNoDocuments
+}
+
+// RunExecutionsArchiver periodically moves test executions older than configured archive period to object storage,
+// only leader instance archives them
+func (s TestkubeAPI) RunExecutionsArchiver(ctx context.Context) {
+	ticker := time.NewTicker(archiveInterval)
+	defer ticker.Stop()
+
+	for {
+		if s.isLeader() {
+			s.archiveExecutions(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// archiveExecutions moves old executions in batches, executions are deleted from database only after they're archived
+func (s TestkubeAPI) archiveExecutions(ctx context.Context) {
+	days := s.getServerSettings(ctx).ArchiveAfterDays
+	if days <= 0 || s.archive == nil {
+		return
+	}
+
+	date := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	for ctx.Err() == nil {
+		executions, err := s.ExecutionResults.GetStartedBefore(ctx, date, archiveBatchSize)
+		if err != nil {
+			s.Log.Errorw("getting test executions to archive", "error", err)
+			return
+		}
+
+		var ids []string
+		for _, execution := range executions {
+			if execution.ExecutionResult == nil || execution.ExecutionResult.Status == nil ||
+				!(execution.ExecutionResult.IsCompleted() || execution.ExecutionResult.IsSkipped()) {
+				continue
+			}
+
+			if err = s.archive.store(execution); err != nil {
+				s.Log.Errorw("archiving test execution", "executionId", execution.Id, "error", err)
+				continue
+			}
+			ids = append(ids, execution.Id)
+		}
+
+		if err = s.ExecutionResults.DeleteByIds(ctx, ids); err != nil {
+			s.Log.Errorw("deleting archived test executions", "error", err)
+			return
+		}
+
+		if len(ids) > 0 {
+			s.Log.Infow("archived test executions", "count", len(ids))
+		}
+
+		// batch with executions which weren't archived would be read again
+		if len(executions) < archiveBatchSize || len(ids) < len(executions) {
+			return
+		}
+	}
+}
+
+// RestoreExecutionHandler rehydrates archived test execution, restored execution is stored in database again
+// until archive period since restore passes
+func (s TestkubeAPI) RestoreExecutionHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		executionID := c.Params("executionID")
+
+		if s.archive == nil {
+			return s.Error(c, http.StatusNotImplemented, fmt.Errorf("executions archive storage is not configured"))
+		}
+
+		if _, err := s.ExecutionResults.Get(ctx, executionID); err != mongo.ErrNoDocuments {
+			if err != nil {
+				return s.Error(c, http.StatusInternalServerError, err)
+			}
+
+			return s.Warn(c, http.StatusConflict, fmt.Errorf("execution %s is not archived", executionID))
+		}
+
+		execution, err := s.archive.get(executionID)
+		if err == mongo.ErrNoDocuments {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("archived execution %s not found", executionID)))
+		}
+		if err != nil {
+			return s.Error(c, http.StatusBadGateway, err)
+		}
+
+		if project := getProject(c); project != "" && execution.Project != project {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("archived execution %s not found", executionID)))
+		}
+
+		execution.RestoredTime = time.Now()
+		if err = s.ExecutionResults.Insert(ctx, execution); err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't restore execution %s: %w", executionID, err))
+		}
+
+		if err = s.ExecutionResults.EnsureExecutionNumber(ctx, execution.TestName, execution.Number); err != nil {
+			s.Logger(c.Context()).Warnw("raising execution number of restored execution", "executionId", executionID, "error", err)
+		}
+
+		s.Logger(c.Context()).Infow("restored archived execution", "executionId", executionID)
+		return c.JSON(execution)
+	}
+}
+
+// archiveObjectName returns name of archived execution object, executions are grouped by test
+func archiveObjectName(testName, id string) string {
+	return testName + "/" + id + archiveObjectSuffix
+}
+
+// matchesFilter checks archived execution against filter fields, as archived executions aren't queried in database
+func matchesFilter(execution testkube.Execution, filter result.Filter) bool {
+	if filter.TestNameDefined() && execution.TestName != filter.TestName() {
+		return false
+	}
+
+	if filter.TextSearchDefined() {
+		search := strings.ToLower(filter.TextSearch())
+		if !strings.Contains(strings.ToLower(execution.TestName), search) && !strings.Contains(strings.ToLower(execution.Name), search) {
+			return false
+		}
+	}
+
+	if filter.StartDateDefined() && execution.StartTime.Before(filter.StartDate()) {
+		return false
+	}
+
+	if filter.EndDateDefined() && execution.StartTime.After(filter.EndDate()) {
+		return false
+	}
+
+	if filter.StatusesDefined() {
+		if execution.ExecutionResult == nil || execution.ExecutionResult.Status == nil {
+			return false
+		}
+
+		matched := false
+		for _, status := range filter.Statuses() {
+			matched = matched || status == *execution.ExecutionResult.Status
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	if filter.Selector() != "" {
+		for _, item := range strings.Split(filter.Selector(), ",") {
+			key, value, hasValue := strings.Cut(item, "=")
+			label, ok := execution.Labels[key]
+			if !ok || (hasValue && label != value) {
+				return false
+			}
+		}
+	}
+
+	if filter.TypeDefined() && execution.TestType != filter.Type() {
+		return false
+	}
+
+	if filter.ProjectDefined() && execution.Project != filter.Project() {
+		return false
+	}
+
+	if filter.GroupIdDefined() && execution.GroupId != filter.GroupId() {
+		return false
+	}
+
+	if filter.ExecutorImageDigest() != "" && execution.ExecutorImageDigest != filter.ExecutorImageDigest() {
+		return false
+	}
+
+	var environment testkube.ExecutionEnvironment
+	if execution.Environment != nil {
+		environment = *execution.Environment
+	}
+
+	if (filter.NodeName() != "" && environment.NodeName != filter.NodeName()) ||
+		(filter.KubernetesVersion() != "" && environment.KubernetesVersion != filter.KubernetesVersion()) {
+		return false
+	}
+
+	if filter.RunnerVersion() != "" && (execution.ExecutionResult == nil || execution.ExecutionResult.RunnerVersion != filter.RunnerVersion()) {
+		return false
+	}
+
+	return true
+}
+
+// pageExecutions returns executions of zero based page
+func pageExecutions(executions []testkube.Execution, page, pageSize int) []testkube.Execution {
+	start := page * pageSize
+	if start >= len(executions) {
+		return []testkube.Execution{}
+	}
+
+	end := start + pageSize
+	if end > len(executions) {
+		end = len(executions)
+	}
+
+	return executions[start:end]
+}
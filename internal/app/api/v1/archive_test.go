@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestExecutionArchiveStore(t *testing.T) {
+	uploads := &uploadsStorage{objects: map[string]string{}}
+	archive := executionArchive{config: archiveConfig{Bucket: "archive"}, storage: uploads}
+
+	execution := testkube.NewExecutionWithID("1", "postman/collection", "api")
+	assert.NoError(t, archive.store(execution))
+
+	object, ok := uploads.objects["archive/api/1.json.gz"]
+	assert.True(t, ok)
+	assert.Equal(t, "api/1.json.gz", uploads.objects["archive/.ids/1"], "execution is indexed by id")
+
+	reader, err := gzip.NewReader(bytes.NewBufferString(object))
+	assert.NoError(t, err)
+
+	var archived testkube.Execution
+	assert.NoError(t, json.NewDecoder(reader).Decode(&archived))
+	assert.Equal(t, "api", archived.TestName)
+}
+
+func TestMatchesFilter(t *testing.T) {
+	now := time.Now()
+	execution := testkube.Execution{
+		Id:              "1",
+		Name:            "api-1",
+		TestName:        "api",
+		TestType:        "postman/collection",
+		StartTime:       now,
+		Labels:          map[string]string{"team": "payments"},
+		ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusFailed, RunnerVersion: "5.3.2"},
+		Environment:     &testkube.ExecutionEnvironment{NodeName: "node-1", KubernetesVersion: "v1.25.3"},
+	}
+
+	assert.True(t, matchesFilter(execution, result.NewExecutionsFilter()))
+	assert.True(t, matchesFilter(execution, result.NewExecutionsFilter().WithTestName("api").WithTextSearch("API")))
+	assert.True(t, matchesFilter(execution, result.NewExecutionsFilter().WithStatus("passed,failed").WithSelector("team=payments")))
+	assert.True(t, matchesFilter(execution, result.NewExecutionsFilter().WithStartDate(now.Add(-time.Hour)).WithEndDate(now.Add(time.Hour))))
+	assert.True(t, matchesFilter(execution, result.NewExecutionsFilter().WithNodeName("node-1").WithKubernetesVersion("v1.25.3").WithRunnerVersion("5.3.2")))
+
+	assert.False(t, matchesFilter(execution, result.NewExecutionsFilter().WithTestName("ui")))
+	assert.False(t, matchesFilter(execution, result.NewExecutionsFilter().WithStatus("passed")))
+	assert.False(t, matchesFilter(execution, result.NewExecutionsFilter().WithSelector("team=checkout")))
+	assert.False(t, matchesFilter(execution, result.NewExecutionsFilter().WithSelector("owner")))
+	assert.False(t, matchesFilter(execution, result.NewExecutionsFilter().WithStartDate(now.Add(time.Hour))))
+	assert.False(t, matchesFilter(execution, result.NewExecutionsFilter().WithNodeName("node-2")))
+	assert.False(t, matchesFilter(execution, result.NewExecutionsFilter().WithExecutorImageDigest("sha256:0")))
+}
+
+func TestPageExecutions(t *testing.T) {
+	executions := []testkube.Execution{{Id: "1"}, {Id: "2"}, {Id: "3"}}
+
+	assert.Equal(t, []testkube.Execution{{Id: "1"}, {Id: "2"}}, pageExecutions(executions, 0, 2))
+	assert.Equal(t, []testkube.Execution{{Id: "3"}}, pageExecutions(executions, 1, 2))
+	assert.Empty(t, pageExecutions(executions, 2, 2))
+}
@@ -0,0 +1,247 @@
+package v1
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/artifactscan"
+	"github.com/kubeshop/testkube/pkg/problem"
+)
+
+// artifactScanConfig configures job scanning execution artifacts before they're exposed for download
+type artifactScanConfig struct {
+	// Image is a scan job image, artifacts aren't scanned when not set
+	Image          string
+	Command        []string
+	ServiceAccount string        `envconfig:"SERVICE_ACCOUNT"`
+	Deadline       time.Duration `default:"10m"`
+}
+
+// scanArtifacts starts scanning of completed execution artifacts which weren't scanned yet and returns execution
+// artifact scans, scans are started once for execution
+func (s TestkubeAPI) scanArtifacts(ctx context.Context, execution testkube.Execution, files []testkube.Artifact) []testkube.ArtifactScanResult {
+	if s.ArtifactScanner == nil || len(files) == 0 || len(execution.ArtifactScans) > 0 ||
+		execution.ExecutionResult == nil || !execution.ExecutionResult.IsCompleted() {
+		return execution.ArtifactScans
+	}
+
+	scans := make([]testkube.ArtifactScanResult, len(files))
+	for i := range files {
+		scans[i] = testkube.ArtifactScanResult{Name: files[i].Name, Status: testkube.ArtifactScanStatusPending}
+	}
+
+	token, err := newArtifactScanToken()
+	if err != nil {
+		s.Log.Errorw("generating artifact scan token", "executionId", execution.Id, "error", err)
+		return scans
+	}
+
+	started, err := s.ExecutionResults.StartArtifactScans(ctx, execution.Id, scans, hashArtifactScanToken(token))
+	if err != nil || !started {
+		if err != nil {
+			s.Log.Errorw("starting artifact scans", "executionId", execution.Id, "error", err)
+		}
+		return scans
+	}
+
+	artifacts := make([]testkube.Artifact, len(files))
+	for i, file := range files {
+		artifacts[i] = file
+		if artifacts[i].DownloadUrl, err = s.artifacts(execution).PresignDownload(file.Name, presignedURLExpiration); err != nil {
+			s.Log.Warnw("presigning artifact download URL for scan", "executionID", execution.Id, "file", file.Name, "error", err)
+		}
+	}
+
+	results, err := s.ArtifactScanner.Scan(ctx, execution, artifacts, token)
+	if err != nil {
+		s.Log.Errorw("scanning artifacts", "executionId", execution.Id, "error", err)
+		// scan is started again on next artifacts read
+		if err = s.ExecutionResults.UpdateArtifactScans(ctx, execution.Id, nil); err != nil {
+			s.Log.Errorw("reverting artifact scans", "executionId", execution.Id, "error", err)
+		}
+		return scans
+	}
+
+	if len(results) > 0 {
+		scans = mergeArtifactScans(scans, results)
+		if err = s.ExecutionResults.UpdateArtifactScans(ctx, execution.Id, scans); err != nil {
+			s.Log.Errorw("updating artifact scans", "executionId", execution.Id, "error", err)
+		}
+	}
+
+	go s.failPendingArtifactScans(execution.Id)
+	return scans
+}
+
+// failPendingArtifactScans waits for artifact scan and marks artifacts which results weren't reported as failed,
+// so artifacts of failed or timed out scans can't be downloaded
+func (s TestkubeAPI) failPendingArtifactScans(executionID string) {
+	details := "scan finished without reporting result"
+	if err := s.ArtifactScanner.Wait(context.Background(), executionID); err != nil {
+		s.Log.Errorw("artifact scan failed", "executionId", executionID, "error", err)
+		details = err.Error()
+	}
+
+	ctx := context.Background()
+	execution, err := s.ExecutionResults.Get(ctx, executionID)
+	if err != nil {
+		s.Log.Errorw("getting execution of artifact scan", "executionId", executionID, "error", err)
+		return
+	}
+
+	var failed []testkube.ArtifactScanResult
+	for _, scan := range execution.ArtifactScans {
+		if scan.Status == nil || *scan.Status == testkube.PENDING_ArtifactScanStatus {
+			failed = append(failed, testkube.ArtifactScanResult{Name: scan.Name, Status: testkube.ArtifactScanStatusFailed, Details: details})
+		}
+	}
+
+	if len(failed) == 0 {
+		return
+	}
+
+	if err = s.ExecutionResults.UpdateArtifactScans(ctx, executionID, mergeArtifactScans(execution.ArtifactScans, failed)); err != nil {
+		s.Log.Errorw("failing pending artifact scans", "executionId", executionID, "error", err)
+	}
+}
+
+// newArtifactScanToken returns random token authenticating scan job results report
+func newArtifactScanToken() (string, error) {
+	data := make([]byte, 32)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(data), nil
+}
+
+// hashArtifactScanToken returns hash of scan token stored with execution, token itself is only passed to scan job
+func hashArtifactScanToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// applyArtifactScans sets scan status of artifacts, artifacts without scan result are pending when execution is scanned
+func applyArtifactScans(files []testkube.Artifact, scans []testkube.ArtifactScanResult) {
+	if len(scans) == 0 {
+		return
+	}
+
+	results := make(map[string]testkube.ArtifactScanResult, len(scans))
+	for _, scan := range scans {
+		results[scan.Name] = scan
+	}
+
+	for i := range files {
+		scan, ok := results[files[i].Name]
+		if !ok || scan.Status == nil {
+			files[i].ScanStatus = testkube.ArtifactScanStatusPending
+			continue
+		}
+
+		files[i].ScanStatus = scan.Status
+		files[i].ScanDetails = scan.Details
+	}
+}
+
+// artifactScanStatus returns scan status of execution artifact, nil is returned when execution artifacts aren't scanned
+func artifactScanStatus(scans []testkube.ArtifactScanResult, name string) *testkube.ArtifactScanStatus {
+	files := []testkube.Artifact{{Name: name}}
+	applyArtifactScans(files, scans)
+	return files[0].ScanStatus
+}
+
+// mergeArtifactScans replaces scans with results of the same artifacts, results of other artifacts are appended
+func mergeArtifactScans(scans, results []testkube.ArtifactScanResult) []testkube.ArtifactScanResult {
+	merged := append([]testkube.ArtifactScanResult{}, scans...)
+	for _, result := range results {
+		found := false
+		for i := range merged {
+			if merged[i].Name == result.Name {
+				merged[i] = result
+				found = true
+			}
+		}
+
+		if !found {
+			merged = append(merged, result)
+		}
+	}
+
+	return merged
+}
+
+// ReportArtifactScansHandler stores artifact scan results reported by scan job with its bearer token,
+// quarantined artifacts can't be downloaded
+func (s TestkubeAPI) ReportArtifactScansHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		executionID := c.Params("executionID")
+
+		tokenHash, err := s.ExecutionResults.GetArtifactScanTokenHash(ctx, executionID)
+		token := strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if err != nil || tokenHash == "" || token == "" ||
+			subtle.ConstantTimeCompare([]byte(hashArtifactScanToken(token)), []byte(tokenHash)) != 1 {
+			return s.Warn(c, http.StatusUnauthorized, fmt.Errorf("invalid artifact scan token of execution %s", executionID))
+		}
+
+		var results []testkube.ArtifactScanResult
+		if err := c.BodyParser(&results); err != nil {
+			return s.Error(c, http.StatusBadRequest, fmt.Errorf("can't parse artifact scan results: %w", err))
+		}
+
+		for _, result := range results {
+			if result.Name == "" || result.Status == nil {
+				return s.Warn(c, http.StatusBadRequest, fmt.Errorf("artifact scan result must have name and status"))
+			}
+
+			if err := result.Status.Validate(); err != nil {
+				return s.Warn(c, http.StatusBadRequest, err)
+			}
+		}
+
+		execution, err := s.ExecutionResults.Get(ctx, executionID)
+		if err != nil {
+			return s.Error(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("execution %s not found: %w", executionID, err)))
+		}
+
+		scans := mergeArtifactScans(execution.ArtifactScans, results)
+		if err = s.ExecutionResults.UpdateArtifactScans(ctx, executionID, scans); err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't update artifact scans: %w", err))
+		}
+
+		for _, result := range results {
+			if *result.Status == testkube.QUARANTINED_ArtifactScanStatus || *result.Status == testkube.FLAGGED_ArtifactScanStatus {
+				s.Logger(c.Context()).Warnw("artifact flagged by scan", "executionId", executionID, "file", result.Name, "status", *result.Status, "details", result.Details)
+			}
+		}
+
+		return c.JSON(scans)
+	}
+}
+
+// newArtifactScanner returns job scanner when scan job image is configured
+func newArtifactScanner(config artifactScanConfig, namespace, reportURL string) (artifactscan.Scanner, error) {
+	if config.Image == "" {
+		return nil, nil
+	}
+
+	return artifactscan.NewJobScanner(artifactscan.JobOptions{
+		Image:          config.Image,
+		Command:        config.Command,
+		Namespace:      namespace,
+		ServiceAccount: config.ServiceAccount,
+		ReportURL:      reportURL,
+		Deadline:       config.Deadline,
+	})
+}
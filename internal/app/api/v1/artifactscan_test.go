@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestApplyArtifactScans(t *testing.T) {
+	files := []testkube.Artifact{{Name: "report.html"}, {Name: "secrets.txt"}, {Name: "new.log"}}
+	applyArtifactScans(files, []testkube.ArtifactScanResult{
+		{Name: "report.html", Status: testkube.ArtifactScanStatusClean},
+		{Name: "secrets.txt", Status: testkube.ArtifactScanStatusQuarantined, Details: "aws key found"},
+	})
+
+	assert.Equal(t, testkube.ArtifactScanStatusClean, files[0].ScanStatus)
+	assert.Equal(t, testkube.ArtifactScanStatusQuarantined, files[1].ScanStatus)
+	assert.Equal(t, "aws key found", files[1].ScanDetails)
+	assert.Equal(t, testkube.ArtifactScanStatusPending, files[2].ScanStatus)
+
+	assert.Nil(t, artifactScanStatus(nil, "report.html"), "artifacts of not scanned executions have no status")
+}
+
+func TestMergeArtifactScans(t *testing.T) {
+	scans := []testkube.ArtifactScanResult{
+		{Name: "report.html", Status: testkube.ArtifactScanStatusPending},
+		{Name: "secrets.txt", Status: testkube.ArtifactScanStatusPending},
+	}
+
+	merged := mergeArtifactScans(scans, []testkube.ArtifactScanResult{
+		{Name: "secrets.txt", Status: testkube.ArtifactScanStatusFlagged},
+		{Name: "other.txt", Status: testkube.ArtifactScanStatusClean},
+	})
+
+	assert.Equal(t, []testkube.ArtifactScanResult{
+		{Name: "report.html", Status: testkube.ArtifactScanStatusPending},
+		{Name: "secrets.txt", Status: testkube.ArtifactScanStatusFlagged},
+		{Name: "other.txt", Status: testkube.ArtifactScanStatusClean},
+	}, merged)
+	assert.Equal(t, testkube.ArtifactScanStatusPending, scans[1].Status, "scans aren't changed")
+}
+
+func TestArtifactScanToken(t *testing.T) {
+	token, err := newArtifactScanToken()
+	assert.NoError(t, err)
+	assert.Len(t, token, 64)
+
+	other, err := newArtifactScanToken()
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, other)
+	assert.NotEqual(t, token, hashArtifactScanToken(token))
+	assert.Equal(t, hashArtifactScanToken(token), hashArtifactScanToken(token))
+}
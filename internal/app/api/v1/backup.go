@@ -0,0 +1,364 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	goerrors "errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/testkube/internal/pkg/api"
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/testresult"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/backup"
+)
+
+const (
+	restoreKindTests               = "tests"
+	restoreKindTestSuites          = "testSuites"
+	restoreKindExecutors           = "executors"
+	restoreKindWebhooks            = "webhooks"
+	restoreKindExecutions          = "executions"
+	restoreKindTestSuiteExecutions = "testSuiteExecutions"
+)
+
+// restoreConfig configures restore of backup archives
+type restoreConfig struct {
+	// MaxSize is a maximum size of uploaded and of decompressed archive in bytes
+	MaxSize int64 `split_words:"true" default:"268435456"`
+}
+
+// errAdminProjectScope is returned when admin endpoints are called in project scope, they access resources of all projects
+var errAdminProjectScope = fmt.Errorf("admin endpoints aren't available in project scope")
+
+// BackupHandler returns archive of test, test suite, executor and webhook definitions, execution metadata
+// without outputs are included with executions query param
+func (s TestkubeAPI) BackupHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if getProject(c) != "" {
+			return s.Warn(c, http.StatusForbidden, errAdminProjectScope)
+		}
+
+		archive := backup.Backup{Metadata: backup.Metadata{
+			FormatVersion: backup.FormatVersion,
+			ServerVersion: api.Version,
+			CreatedAt:     time.Now().UTC(),
+			Executions:    c.Query("executions") == "true",
+		}}
+
+		if err := s.backupResources(&archive); err != nil {
+			return s.Error(c, http.StatusBadGateway, fmt.Errorf("can't backup resources: %w", err))
+		}
+
+		if archive.Metadata.Executions {
+			if err := s.backupExecutions(c.Context(), &archive); err != nil {
+				return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't backup executions: %w", err))
+			}
+		}
+
+		var buffer bytes.Buffer
+		if err := backup.Write(&buffer, archive); err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't write backup archive: %w", err))
+		}
+
+		c.Set(fiber.HeaderContentType, "application/gzip")
+		c.Set(fiber.HeaderContentDisposition,
+			fmt.Sprintf("attachment; filename=\"testkube-backup-%s.tar.gz\"", archive.Metadata.CreatedAt.Format("20060102-150405")))
+		return c.Send(buffer.Bytes())
+	}
+}
+
+// RestoreHandler imports backup archive from request body, missing resources and executions are created, existing
+// resources are updated with overwrite query param and skipped otherwise, existing executions are always skipped
+func (s TestkubeAPI) RestoreHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if getProject(c) != "" {
+			return s.Warn(c, http.StatusForbidden, errAdminProjectScope)
+		}
+
+		maxSize := s.restoreConfig.MaxSize
+		if int64(len(c.Body())) > maxSize {
+			return s.Error(c, http.StatusRequestEntityTooLarge, fmt.Errorf("backup archive is bigger than %d bytes", maxSize))
+		}
+
+		archive, err := backup.Read(bytes.NewReader(c.Body()), maxSize)
+		if goerrors.Is(err, backup.ErrTooLarge) {
+			return s.Error(c, http.StatusRequestEntityTooLarge, fmt.Errorf("decompressed backup archive is bigger than %d bytes", maxSize))
+		}
+
+		if err != nil {
+			return s.Error(c, http.StatusBadRequest, fmt.Errorf("invalid backup archive: %w", err))
+		}
+
+		s.Logger(c.Context()).Infow("restoring backup", "createdAt", archive.Metadata.CreatedAt, "serverVersion", archive.Metadata.ServerVersion)
+
+		restored := testkube.NewRestoreResult()
+		s.restoreResources(archive, c.Query("overwrite") == "true", &restored)
+		s.restoreExecutions(c.Context(), archive, &restored)
+
+		return c.JSON(restored)
+	}
+}
+
+// backupResources adds resource definitions without cluster specific metadata to backup
+func (s TestkubeAPI) backupResources(archive *backup.Backup) error {
+	tests, err := s.TestsClient.List("")
+	if err != nil {
+		return err
+	}
+
+	for _, test := range tests.Items {
+		test.ObjectMeta = backupObjectMeta(test.ObjectMeta)
+		archive.Tests = append(archive.Tests, test)
+	}
+
+	testSuites, err := s.TestsSuitesClient.List("")
+	if err != nil {
+		return err
+	}
+
+	for _, testSuite := range testSuites.Items {
+		testSuite.ObjectMeta = backupObjectMeta(testSuite.ObjectMeta)
+		archive.TestSuites = append(archive.TestSuites, testSuite)
+	}
+
+	executors, err := s.ExecutorsClient.List("")
+	if err != nil {
+		return err
+	}
+
+	for _, executor := range executors.Items {
+		executor.ObjectMeta = backupObjectMeta(executor.ObjectMeta)
+		archive.Executors = append(archive.Executors, executor)
+	}
+
+	webhooks, err := s.WebhooksClient.List("")
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooks.Items {
+		webhook.ObjectMeta = backupObjectMeta(webhook.ObjectMeta)
+		archive.Webhooks = append(archive.Webhooks, webhook)
+	}
+
+	return nil
+}
+
+// backupExecutions adds execution metadata to backup, outputs aren't included
+func (s TestkubeAPI) backupExecutions(ctx context.Context, archive *backup.Backup) error {
+	for page := 0; ; page++ {
+		executions, err := s.ExecutionResults.GetExecutions(ctx,
+			result.NewExecutionsFilter().WithPage(page).WithExcludedFields(result.SummaryExcludedFields()))
+		if err != nil {
+			return err
+		}
+
+		for _, execution := range executions {
+			archive.Executions = append(archive.Executions, withoutOutput(execution))
+		}
+
+		if len(executions) < result.PageDefaultLimit {
+			break
+		}
+	}
+
+	for page := 0; ; page++ {
+		executions, err := s.TestExecutionResults.GetExecutions(ctx, testresult.NewExecutionsFilter().WithPage(page))
+		if err != nil {
+			return err
+		}
+
+		for _, execution := range executions {
+			archive.TestSuiteExecutions = append(archive.TestSuiteExecutions, withoutStepOutputs(execution))
+		}
+
+		if len(executions) < testresult.PageDefaultLimit {
+			break
+		}
+	}
+
+	return nil
+}
+
+// restoreResources restores definitions, executors and tests are restored before test suites using them
+func (s TestkubeAPI) restoreResources(archive backup.Backup, overwrite bool, restored *testkube.RestoreResult) {
+	for i := range archive.Executors {
+		item := &archive.Executors[i]
+		existing, err := s.ExecutorsClient.Get(item.Name)
+		restoreResource(restored, restoreKindExecutors, item.Name, err, overwrite, func() error {
+			item.Namespace = s.Namespace
+			_, err := s.ExecutorsClient.Create(item)
+			return err
+		}, func() error {
+			existing.Labels, existing.Annotations, existing.Spec = item.Labels, item.Annotations, item.Spec
+			_, err := s.ExecutorsClient.Update(existing)
+			return err
+		})
+	}
+
+	for i := range archive.Webhooks {
+		item := &archive.Webhooks[i]
+		existing, err := s.WebhooksClient.Get(item.Name)
+		restoreResource(restored, restoreKindWebhooks, item.Name, err, overwrite, func() error {
+			item.Namespace = s.Namespace
+			_, err := s.WebhooksClient.Create(item)
+			return err
+		}, func() error {
+			existing.Labels, existing.Annotations, existing.Spec = item.Labels, item.Annotations, item.Spec
+			_, err := s.WebhooksClient.Update(existing)
+			return err
+		})
+	}
+
+	for i := range archive.Tests {
+		item := &archive.Tests[i]
+		existing, err := s.TestsClient.Get(item.Name)
+		restoreResource(restored, restoreKindTests, item.Name, err, overwrite, func() error {
+			item.Namespace = s.Namespace
+			_, err := s.TestsClient.Create(item)
+			return err
+		}, func() error {
+			existing.Labels, existing.Annotations, existing.Spec = item.Labels, item.Annotations, item.Spec
+			_, err := s.TestsClient.Update(existing)
+			return err
+		})
+	}
+
+	for i := range archive.TestSuites {
+		item := &archive.TestSuites[i]
+		existing, err := s.TestsSuitesClient.Get(item.Name)
+		restoreResource(restored, restoreKindTestSuites, item.Name, err, overwrite, func() error {
+			item.Namespace = s.Namespace
+			_, err := s.TestsSuitesClient.Create(item)
+			return err
+		}, func() error {
+			existing.Labels, existing.Annotations, existing.Spec = item.Labels, item.Annotations, item.Spec
+			_, err := s.TestsSuitesClient.Update(existing)
+			return err
+		})
+	}
+}
+
+// restoreExecutions inserts executions which aren't stored yet, execution number counters are raised
+// so new executions don't reuse restored numbers
+func (s TestkubeAPI) restoreExecutions(ctx context.Context, archive backup.Backup, restored *testkube.RestoreResult) {
+	for _, execution := range archive.Executions {
+		_, err := s.ExecutionResults.Get(ctx, execution.Id)
+		if err == nil {
+			restored.Skipped[restoreKindExecutions]++
+			continue
+		}
+
+		if err != mongo.ErrNoDocuments {
+			restored.AddError(restoreKindExecutions, execution.Id, err)
+			continue
+		}
+
+		err = s.ExecutionResults.Insert(ctx, execution)
+		if result.IsDuplicateNameError(err) {
+			restored.Skipped[restoreKindExecutions]++
+			continue
+		}
+
+		if err == nil && execution.TestName != "" {
+			err = s.ExecutionResults.EnsureExecutionNumber(ctx, execution.TestName, execution.Number)
+		}
+
+		if err != nil {
+			restored.AddError(restoreKindExecutions, execution.Id, err)
+			continue
+		}
+
+		restored.Created[restoreKindExecutions]++
+	}
+
+	for _, execution := range archive.TestSuiteExecutions {
+		_, err := s.TestExecutionResults.Get(ctx, execution.Id)
+		if err == nil {
+			restored.Skipped[restoreKindTestSuiteExecutions]++
+			continue
+		}
+
+		if err == mongo.ErrNoDocuments {
+			err = s.TestExecutionResults.Insert(ctx, execution)
+		}
+
+		if err != nil {
+			restored.AddError(restoreKindTestSuiteExecutions, execution.Id, err)
+			continue
+		}
+
+		restored.Created[restoreKindTestSuiteExecutions]++
+	}
+}
+
+// restoreResource creates resource when it doesn't exist, existing resource is updated with overwrite or skipped otherwise
+func restoreResource(restored *testkube.RestoreResult, kind, name string, getErr error, overwrite bool, create, update func() error) {
+	var err error
+	switch {
+	case errors.IsNotFound(getErr):
+		if err = create(); err == nil {
+			restored.Created[kind]++
+		}
+	case getErr != nil:
+		err = getErr
+	case overwrite:
+		if err = update(); err == nil {
+			restored.Updated[kind]++
+		}
+	default:
+		restored.Skipped[kind]++
+	}
+
+	if err != nil {
+		restored.AddError(kind, name, err)
+	}
+}
+
+// backupObjectMeta returns object metadata without cluster specific fields, so objects can be created in other clusters
+func backupObjectMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        meta.Name,
+		Labels:      meta.Labels,
+		Annotations: meta.Annotations,
+	}
+}
+
+// withoutOutput returns execution without output and reference to output in object storage
+func withoutOutput(execution testkube.Execution) testkube.Execution {
+	if execution.ExecutionResult != nil {
+		executionResult := *execution.ExecutionResult
+		executionResult.Output = ""
+		executionResult.OutputObject = nil
+		execution.ExecutionResult = &executionResult
+	}
+
+	return execution
+}
+
+// withoutStepOutputs returns test suite execution without step execution outputs
+func withoutStepOutputs(execution testkube.TestSuiteExecution) testkube.TestSuiteExecution {
+	if execution.StepResults == nil {
+		return execution
+	}
+
+	stepResults := make([]testkube.TestSuiteStepExecutionResult, len(execution.StepResults))
+	for i, stepResult := range execution.StepResults {
+		if stepResult.Execution != nil {
+			stepExecution := withoutOutput(*stepResult.Execution)
+			stepResult.Execution = &stepExecution
+		}
+		stepResults[i] = stepResult
+	}
+	execution.StepResults = stepResults
+
+	return execution
+}
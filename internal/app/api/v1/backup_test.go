@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/server"
+)
+
+func TestRestoreResource(t *testing.T) {
+	notFound := errors.NewNotFound(schema.GroupResource{Resource: "tests"}, "api")
+	noop := func() error { return nil }
+
+	restored := testkube.NewRestoreResult()
+	restoreResource(&restored, restoreKindTests, "created", notFound, false, noop, nil)
+	restoreResource(&restored, restoreKindTests, "skipped", nil, false, nil, nil)
+	restoreResource(&restored, restoreKindTests, "updated", nil, true, nil, noop)
+	restoreResource(&restored, restoreKindTests, "failed", notFound, true, func() error { return fmt.Errorf("invalid") }, nil)
+	restoreResource(&restored, restoreKindTests, "unavailable", fmt.Errorf("timeout"), true, nil, nil)
+
+	assert.Equal(t, int32(1), restored.Created[restoreKindTests])
+	assert.Equal(t, int32(1), restored.Skipped[restoreKindTests])
+	assert.Equal(t, int32(1), restored.Updated[restoreKindTests])
+	assert.Equal(t, []string{"tests failed: invalid", "tests unavailable: timeout"}, restored.Errors)
+}
+
+func TestBackupObjectMeta(t *testing.T) {
+	meta := backupObjectMeta(metav1.ObjectMeta{
+		Name:            "api",
+		Namespace:       "testkube",
+		ResourceVersion: "123",
+		UID:             "uid",
+		Labels:          map[string]string{"app": "api"},
+		Annotations:     map[string]string{testkube.SecretParamsAnnotation: `["token"]`},
+	})
+
+	assert.Equal(t, metav1.ObjectMeta{
+		Name:        "api",
+		Labels:      map[string]string{"app": "api"},
+		Annotations: map[string]string{testkube.SecretParamsAnnotation: `["token"]`},
+	}, meta)
+}
+
+func TestWithoutStepOutputs(t *testing.T) {
+	execution := testkube.TestSuiteExecution{StepResults: []testkube.TestSuiteStepExecutionResult{
+		{Execution: &testkube.Execution{Id: "1", ExecutionResult: &testkube.ExecutionResult{
+			Output:       "output",
+			OutputObject: &testkube.StorageObject{Bucket: "outputs", Name: "1"},
+		}}},
+		{},
+	}}
+
+	stripped := withoutStepOutputs(execution)
+
+	assert.Equal(t, "1", stripped.StepResults[0].Execution.Id)
+	assert.Empty(t, stripped.StepResults[0].Execution.ExecutionResult.Output)
+	assert.Nil(t, stripped.StepResults[0].Execution.ExecutionResult.OutputObject)
+	assert.Equal(t, "output", execution.StepResults[0].Execution.ExecutionResult.Output, "passed execution isn't changed")
+}
+
+func TestAdminProjectScope(t *testing.T) {
+	s := TestkubeAPI{HTTPServer: server.NewServer(server.Config{}), restoreConfig: restoreConfig{MaxSize: 1024}}
+	s.Mux.Use(s.ProjectMiddleware())
+	s.Mux.Post("/admin/restore", s.RestoreHandler())
+
+	request := httptest.NewRequest(http.MethodPost, "/admin/restore", bytes.NewBufferString("archive"))
+	request.Header.Set(ProjectHeader, "team-a")
+	resp, err := s.Mux.Test(request)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp, err = s.Mux.Test(httptest.NewRequest(http.MethodPost, "/admin/restore", bytes.NewBufferString("not an archive")))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = s.Mux.Test(httptest.NewRequest(http.MethodPost, "/admin/restore", bytes.NewReader(make([]byte, 1025))))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+}
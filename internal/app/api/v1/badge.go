@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/kubeshop/testkube/pkg/badge"
+)
+
+// badgeMaxAge is a number of seconds badges can be cached by browsers and proxies
+const badgeMaxAge = 60
+
+// TestBadgeHandler renders SVG status badge of test latest execution, tests without executions get unknown badge
+func (s TestkubeAPI) TestBadgeHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id := c.Params("id")
+
+		execution, err := s.ExecutionResults.GetLatestByTest(ctx, id)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't get latest execution of test %s: %w", id, err))
+		}
+
+		b := badge.ForExecution(id, nil, time.Now())
+		if err == nil && (getProject(c) == "" || execution.Project == getProject(c)) {
+			b = badge.ForExecution(id, &execution, time.Now())
+		}
+
+		svg, err := b.SVG()
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't render badge of test %s: %w", id, err))
+		}
+
+		c.Set(fiber.HeaderContentType, "image/svg+xml")
+		c.Set(fiber.HeaderCacheControl, fmt.Sprintf("max-age=%d", badgeMaxAge))
+		return c.Send(svg)
+	}
+}
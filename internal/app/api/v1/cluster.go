@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/lease"
+	"github.com/kubeshop/testkube/pkg/rand"
+)
+
+const (
+	// leaseTTL is a time after which leases of not responding API server instance expire
+	leaseTTL = 30 * time.Second
+	// leaseRenewInterval is an interval of renewing instance and leader leases
+	leaseRenewInterval = 10 * time.Second
+	// leaderLeaseName is a lease held by instance running background loops
+	leaderLeaseName = "api-leader"
+	// instanceLeasePrefix is a prefix of leases held by every running API server instance
+	instanceLeasePrefix = "api-instance/"
+)
+
+// clusterState is a state of API server instance among replicas shared between API copies
+type clusterState struct {
+	instanceID string
+	leader     int32
+}
+
+func newClusterState() *clusterState {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = rand.Name()
+	}
+
+	return &clusterState{instanceID: hostname + "-" + rand.String(5)}
+}
+
+// instanceID returns identifier of API server instance
+func (s TestkubeAPI) instanceID() string {
+	if s.cluster == nil {
+		return ""
+	}
+
+	return s.cluster.instanceID
+}
+
+// isLeader checks if API server instance runs background loops, single instance without leases is always leader
+func (s TestkubeAPI) isLeader() bool {
+	if s.LeaseRepository == nil || s.cluster == nil {
+		return true
+	}
+
+	return atomic.LoadInt32(&s.cluster.leader) == 1
+}
+
+// RunLeaseRenewer periodically renews instance lease and competes for leader lease, leader lease is released on shutdown,
+// instance lease expires after shutdown so jobs watched by shutting down instance are not reconciled in the meantime
+func (s TestkubeAPI) RunLeaseRenewer(ctx context.Context) {
+	if s.LeaseRepository == nil {
+		return
+	}
+
+	ticker := time.NewTicker(leaseRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.releaseLeaderLease()
+			return
+		case <-ticker.C:
+			s.renewLeases(ctx)
+		}
+	}
+}
+
+func (s TestkubeAPI) renewLeases(ctx context.Context) {
+	id := s.instanceID()
+	if _, err := s.LeaseRepository.TryAcquire(ctx, instanceLeasePrefix+id, id, leaseTTL); err != nil {
+		s.Log.Errorw("renewing API server instance lease", "instanceId", id, "error", err)
+	}
+
+	leader, err := s.LeaseRepository.TryAcquire(ctx, leaderLeaseName, id, leaseTTL)
+	if err != nil {
+		s.Log.Errorw("renewing API server leader lease", "instanceId", id, "error", err)
+		leader = false
+	}
+
+	var value int32
+	if leader {
+		value = 1
+	}
+
+	if atomic.SwapInt32(&s.cluster.leader, value) != value {
+		s.Log.Infow("API server leadership changed", "instanceId", id, "leader", leader)
+	}
+}
+
+func (s TestkubeAPI) releaseLeaderLease() {
+	atomic.StoreInt32(&s.cluster.leader, 0)
+	if err := s.LeaseRepository.Release(context.Background(), leaderLeaseName, s.instanceID()); err != nil {
+		s.Log.Errorw("releasing API server leader lease", "error", err)
+	}
+}
+
+// activeInstances returns checker of API server instances holding not expired leases
+func (s TestkubeAPI) activeInstances(ctx context.Context) (func(instanceID string) bool, error) {
+	if s.LeaseRepository == nil {
+		id := s.instanceID()
+		return func(instanceID string) bool { return instanceID == id }, nil
+	}
+
+	leases, err := s.LeaseRepository.ListActive(ctx, instanceLeasePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return newInstanceChecker(leases), nil
+}
+
+func newInstanceChecker(leases []lease.Lease) func(instanceID string) bool {
+	instances := make(map[string]struct{}, len(leases))
+	for _, l := range leases {
+		instances[l.Holder] = struct{}{}
+	}
+
+	return func(instanceID string) bool {
+		_, ok := instances[instanceID]
+		return ok
+	}
+}
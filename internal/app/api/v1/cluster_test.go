@@ -0,0 +1,32 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/lease"
+)
+
+func TestNewInstanceChecker(t *testing.T) {
+	isInstanceActive := newInstanceChecker([]lease.Lease{
+		{Name: instanceLeasePrefix + "api-1", Holder: "api-1"},
+		{Name: instanceLeasePrefix + "api-2", Holder: "api-2"},
+	})
+
+	assert.True(t, isInstanceActive("api-1"))
+	assert.True(t, isInstanceActive("api-2"))
+	assert.False(t, isInstanceActive("api-3"))
+}
+
+func TestSingleInstanceWithoutLeases(t *testing.T) {
+	s := TestkubeAPI{cluster: newClusterState()}
+
+	assert.True(t, s.isLeader())
+
+	isInstanceActive, err := s.activeInstances(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, isInstanceActive(s.instanceID()))
+	assert.False(t, isInstanceActive("other"))
+}
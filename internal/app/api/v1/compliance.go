@@ -0,0 +1,161 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/problem"
+	"github.com/kubeshop/testkube/pkg/storage"
+	"github.com/kubeshop/testkube/pkg/storage/minio"
+	"github.com/kubeshop/testkube/pkg/webhook"
+)
+
+// complianceConfig configures signing of compliance reports
+type complianceConfig struct {
+	// SigningKey is HMAC-SHA256 key of report signatures, reports aren't signed when not set
+	SigningKey string `envconfig:"SIGNING_KEY"`
+}
+
+// complianceReportPage is rendered compliance report, signed JSON report is embedded for verification
+type complianceReportPage struct {
+	Report    testkube.ComplianceReport
+	Payload   template.JS
+	Signature string
+}
+
+var complianceReportTemplate = template.Must(template.New("compliance").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Compliance report {{ .Report.ExecutionName }}</title>
+<style>
+body { font-family: sans-serif; font-size: 12px; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 16px; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+code { word-break: break-all; }
+@media print {
+  @page { size: A4; margin: 15mm; }
+  tr { page-break-inside: avoid; }
+}
+</style>
+</head>
+<body>
+<h1>Compliance report</h1>
+<table>
+<tr><th>Test suite</th><td>{{ with .Report.TestSuite }}{{ .Name }}{{ end }}</td></tr>
+<tr><th>Execution</th><td>{{ .Report.ExecutionName }} (<code>{{ .Report.ExecutionId }}</code>)</td></tr>
+<tr><th>Status</th><td>{{ with .Report.Status }}{{ . }}{{ end }}</td></tr>
+<tr><th>Started</th><td>{{ .Report.StartTime.Format "2006-01-02 15:04:05 MST" }}</td></tr>
+<tr><th>Finished</th><td>{{ .Report.EndTime.Format "2006-01-02 15:04:05 MST" }}</td></tr>
+<tr><th>Duration</th><td>{{ .Report.Duration }}</td></tr>
+<tr><th>Operator</th><td>{{ with .Report.RunningContext }}{{ .String }}{{ else }}unknown{{ end }}</td></tr>
+</table>
+{{- if .Report.Params }}
+<h2>Parameters</h2>
+<table>
+<tr><th>Name</th><th>Value</th></tr>
+{{- range $name, $value := .Report.Params }}
+<tr><td>{{ $name }}</td><td><code>{{ $value }}</code></td></tr>
+{{- end }}
+</table>
+{{- end }}
+<h2>Steps</h2>
+<table>
+<tr><th>Step</th><th>Execution</th><th>Status</th><th>Duration</th><th>Error</th></tr>
+{{- range .Report.Steps }}
+<tr><td>{{ .Name }}</td><td><code>{{ .ExecutionId }}</code></td><td>{{ with .Status }}{{ . }}{{ end }}</td><td>{{ .Duration }}</td><td>{{ .ErrorMessage }}</td></tr>
+{{- end }}
+</table>
+<h2>Artifacts</h2>
+<table>
+<tr><th>Step</th><th>Artifact</th><th>Size (bytes)</th><th>MD5 checksum</th><th>SHA-256 checksum</th></tr>
+{{- range $step := .Report.Steps }}
+{{- range .Artifacts }}
+<tr><td>{{ $step.Name }}</td><td>{{ .Name }}</td><td>{{ .Size }}</td><td><code>{{ .Checksum }}</code></td><td><code>{{ .Sha256 }}</code></td></tr>
+{{- end }}
+{{- end }}
+</table>
+<p>Generated at {{ .Report.GeneratedAt.Format "2006-01-02 15:04:05 MST" }}</p>
+{{- if .Signature }}
+<p>Signature of embedded report: <code>{{ .Signature }}</code></p>
+{{- else }}
+<p>Report is not signed, signing key is not configured</p>
+{{- end }}
+<script type="application/json" id="compliance-report">{{ .Payload }}</script>
+</body>
+</html>
+`))
+
+// GetComplianceReportHandler returns signed and timestamped report of finished test suite execution as JSON or print-friendly HTML
+func (s TestkubeAPI) GetComplianceReportHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Params("executionID")
+
+		execution, err := s.TestExecutionResults.Get(c.Context(), id)
+		if err == mongo.ErrNoDocuments {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteExecutionNotFound, fmt.Errorf("test suite execution %s not found", id)))
+		}
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		if project := getProject(c); project != "" && execution.Project != project {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteExecutionNotFound, fmt.Errorf("test suite execution %s not found", id)))
+		}
+
+		if execution.Status == nil || !execution.IsCompleted() {
+			return s.Warn(c, http.StatusConflict, problem.WithCode(problem.CodeNotFinished, fmt.Errorf("test suite execution %s is not finished", id)))
+		}
+
+		// secret params of steps aren't exposed in reports shared with auditors
+		report := testkube.NewComplianceReport(s.redactTestSuiteExecution(c.Context(), execution), time.Now().UTC())
+		for i := range report.Steps {
+			if report.Steps[i].ExecutionId == "" || s.storageParams.Endpoint == "" {
+				continue
+			}
+
+			// steps run in test suite execution project
+			files, err := storage.NewArtifacts(s.Storage, report.Steps[i].ExecutionId, execution.Project).List()
+			if err == minio.ErrArtifactsNotFound {
+				continue
+			}
+			if err != nil {
+				return s.Error(c, http.StatusBadGateway, fmt.Errorf("can't list artifacts of execution %s: %w", report.Steps[i].ExecutionId, err))
+			}
+
+			report.Steps[i].Artifacts = files
+		}
+
+		payload, err := json.Marshal(report)
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		signature := ""
+		if s.complianceConfig.SigningKey != "" {
+			signature = webhook.Sign(s.complianceConfig.SigningKey, payload, report.GeneratedAt)
+			c.Set(webhook.SignatureHeader, signature)
+		}
+
+		if c.Query("format") != "html" {
+			c.Type("json")
+			return c.Send(payload)
+		}
+
+		var buf bytes.Buffer
+		if err = complianceReportTemplate.Execute(&buf, complianceReportPage{Report: report, Payload: template.JS(payload), Signature: signature}); err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		c.Type("html")
+		return c.Send(buf.Bytes())
+	}
+}
@@ -0,0 +1,111 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"html/template"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/redact"
+	"github.com/kubeshop/testkube/pkg/server"
+	"github.com/kubeshop/testkube/pkg/webhook"
+)
+
+func TestNewComplianceReport(t *testing.T) {
+	now := time.Now()
+	execution := testkube.TestSuiteExecution{
+		Id:             "1",
+		Name:           "release.1",
+		TestSuite:      &testkube.ObjectRef{Name: "release"},
+		Status:         testkube.TestSuiteExecutionStatusPassed,
+		Params:         map[string]string{"version": "1.2.0"},
+		RunningContext: &testkube.RunningContext{Provider: "github-actions", Actor: "octocat"},
+		StepResults: []testkube.TestSuiteStepExecutionResult{
+			{
+				Step: &testkube.TestSuiteStep{Execute: &testkube.TestSuiteStepExecuteTest{Name: "api"}},
+				Execution: &testkube.Execution{
+					Id:              "2",
+					Duration:        "1s",
+					Params:          map[string]string{"version": "1.2.0"},
+					ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed},
+				},
+			},
+			{
+				Step:      &testkube.TestSuiteStep{Delay: &testkube.TestSuiteStepDelay{Duration: 1000}},
+				Execution: &testkube.Execution{Id: "3", ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed}},
+			},
+		},
+	}
+
+	report := testkube.NewComplianceReport(execution, now)
+	assert.Equal(t, "release.1", report.ExecutionName)
+	assert.Equal(t, "octocat", report.RunningContext.Actor)
+	assert.Equal(t, now, report.GeneratedAt)
+	assert.Len(t, report.Steps, 2)
+	assert.Equal(t, "2", report.Steps[0].ExecutionId)
+	assert.Equal(t, testkube.ExecutionStatusPassed, report.Steps[0].Status)
+	assert.Equal(t, map[string]string{"version": "1.2.0"}, report.Steps[0].Params)
+	assert.Empty(t, report.Steps[1].ExecutionId, "delay steps have no test execution")
+}
+
+func TestComplianceReportTemplate(t *testing.T) {
+	report := testkube.ComplianceReport{
+		ExecutionId:   "1",
+		ExecutionName: "release.1",
+		Status:        testkube.TestSuiteExecutionStatusPassed,
+		Params:        map[string]string{"notes": "<b>release</b>"},
+		Steps: []testkube.ComplianceReportStep{{
+			Name:        "run:testkube/api",
+			ExecutionId: "2",
+			Artifacts:   []testkube.Artifact{{Name: "report.html", Size: 10, Checksum: "d41d8cd98f00b204e9800998ecf8427e"}},
+		}},
+		GeneratedAt: time.Now(),
+	}
+
+	payload, err := json.Marshal(report)
+	assert.NoError(t, err)
+	signature := webhook.Sign("key", payload, report.GeneratedAt)
+
+	var buf bytes.Buffer
+	assert.NoError(t, complianceReportTemplate.Execute(&buf, complianceReportPage{Report: report, Payload: template.JS(payload), Signature: signature}))
+	html := buf.String()
+	assert.Contains(t, html, "d41d8cd98f00b204e9800998ecf8427e")
+	assert.Contains(t, html, "&lt;b&gt;release&lt;/b&gt;")
+
+	// embedded report is verifiable with printed signature
+	_, embedded, _ := strings.Cut(html, `<script type="application/json" id="compliance-report">`)
+	embedded, _, _ = strings.Cut(embedded, "</script>")
+	assert.Equal(t, string(payload), embedded)
+	assert.NoError(t, webhook.VerifySignature("key", signature, []byte(embedded), time.Minute, time.Now()))
+}
+
+func TestRedactTestSuiteExecution(t *testing.T) {
+	s := TestkubeAPI{HTTPServer: server.NewServer(server.Config{})}
+	execution := testkube.TestSuiteExecution{
+		Params: map[string]string{"version": "1.2.0", "token": "s3cret"},
+		StepResults: []testkube.TestSuiteStepExecutionResult{{
+			Step: &testkube.TestSuiteStep{Execute: &testkube.TestSuiteStepExecuteTest{Name: "api"}},
+			Execution: &testkube.Execution{
+				Id:           "2",
+				Params:       map[string]string{"version": "1.2.0", "token": "s3cret"},
+				SecretParams: []string{"token"},
+				ExecutionResult: &testkube.ExecutionResult{
+					Status:       testkube.ExecutionStatusFailed,
+					ErrorMessage: "unauthorized token s3cret",
+				},
+			},
+		}},
+	}
+
+	report := testkube.NewComplianceReport(s.redactTestSuiteExecution(context.Background(), execution), time.Now())
+	assert.Equal(t, map[string]string{"version": "1.2.0", "token": redact.Mask}, report.Params)
+	assert.Equal(t, map[string]string{"version": "1.2.0", "token": redact.Mask}, report.Steps[0].Params)
+	assert.NotContains(t, report.Steps[0].ErrorMessage, "s3cret")
+	assert.Equal(t, "s3cret", execution.StepResults[0].Execution.Params["token"], "stored execution isn't changed")
+}
@@ -0,0 +1,208 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/redact"
+)
+
+// retentionInterval is an interval of deleting executions older than retention period
+const retentionInterval = time.Hour
+
+// defaultServerSettings returns settings used until they are changed with config API
+func (s TestkubeAPI) defaultServerSettings() testkube.ServerSettings {
+	concurrency, _ := strconv.Atoi(defaultConcurrencyLevel)
+	return testkube.ServerSettings{
+		DefaultConcurrency:   int32(concurrency),
+		WebhookNotifications: true,
+		SlackNotifications:   true,
+		DefaultNamespace:     s.Namespace,
+	}
+}
+
+// getServerSettings returns persisted server settings, defaults are returned when settings can't be loaded
+func (s TestkubeAPI) getServerSettings(ctx context.Context) testkube.ServerSettings {
+	if s.ConfigRepository == nil {
+		return s.defaultServerSettings()
+	}
+
+	config, err := s.ConfigRepository.Get(ctx)
+	if err != nil || config.Settings == nil {
+		return s.defaultServerSettings()
+	}
+
+	return *config.Settings
+}
+
+// applyServerSettingsUpdate updates settings with fields set in request
+func applyServerSettingsUpdate(settings testkube.ServerSettings, request testkube.ServerSettingsUpdateRequest) (testkube.ServerSettings, error) {
+	if request.DefaultConcurrency != nil {
+		if *request.DefaultConcurrency < 1 {
+			return settings, fmt.Errorf("default concurrency should be positive")
+		}
+		settings.DefaultConcurrency = *request.DefaultConcurrency
+	}
+
+	if request.ExecutionsRetentionDays != nil {
+		if *request.ExecutionsRetentionDays < 0 {
+			return settings, fmt.Errorf("executions retention days can't be negative")
+		}
+		settings.ExecutionsRetentionDays = *request.ExecutionsRetentionDays
+	}
+
+	if request.ArchiveAfterDays != nil {
+		if *request.ArchiveAfterDays < 0 {
+			return settings, fmt.Errorf("archive after days can't be negative")
+		}
+		settings.ArchiveAfterDays = *request.ArchiveAfterDays
+	}
+
+	if request.WebhookNotifications != nil {
+		settings.WebhookNotifications = *request.WebhookNotifications
+	}
+
+	if request.SlackNotifications != nil {
+		settings.SlackNotifications = *request.SlackNotifications
+	}
+
+	if request.NotificationsDigestMinutes != nil {
+		if *request.NotificationsDigestMinutes < 0 {
+			return settings, fmt.Errorf("notifications digest minutes can't be negative")
+		}
+		settings.NotificationsDigestMinutes = *request.NotificationsDigestMinutes
+	}
+
+	if request.RedactionPatterns != nil {
+		if _, err := redact.CompilePatterns(*request.RedactionPatterns); err != nil {
+			return settings, err
+		}
+		settings.RedactionPatterns = *request.RedactionPatterns
+	}
+
+	if request.MaintenanceWindows != nil {
+		if err := testkube.ValidateMaintenanceWindows(*request.MaintenanceWindows); err != nil {
+			return settings, err
+		}
+		settings.MaintenanceWindows = *request.MaintenanceWindows
+	}
+
+	if request.QuietHours != nil {
+		if err := testkube.ValidateMaintenanceWindows(*request.QuietHours); err != nil {
+			return settings, err
+		}
+		settings.QuietHours = *request.QuietHours
+	}
+
+	if request.ExecutionPolicies != nil {
+		if err := validateExecutionPolicies(*request.ExecutionPolicies); err != nil {
+			return settings, err
+		}
+		settings.ExecutionPolicies = *request.ExecutionPolicies
+	}
+
+	if request.Quotas != nil {
+		if err := validateQuotas(*request.Quotas); err != nil {
+			return settings, err
+		}
+		settings.Quotas = *request.Quotas
+	}
+
+	if request.CostRates != nil {
+		if err := validateCostRates(*request.CostRates); err != nil {
+			return settings, err
+		}
+		settings.CostRates = request.CostRates
+	}
+
+	if request.DefaultNamespace != nil {
+		if *request.DefaultNamespace == "" {
+			return settings, fmt.Errorf("default namespace can't be empty")
+		}
+		settings.DefaultNamespace = *request.DefaultNamespace
+	}
+
+	return settings, nil
+}
+
+// GetConfigHandler returns API server settings
+func (s TestkubeAPI) GetConfigHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.JSON(s.getServerSettings(c.Context()))
+	}
+}
+
+// UpdateConfigHandler updates API server settings, changes are applied without server restart
+func (s TestkubeAPI) UpdateConfigHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var request testkube.ServerSettingsUpdateRequest
+		if err := c.BodyParser(&request); err != nil {
+			return s.Error(c, http.StatusBadRequest, fmt.Errorf("config request body invalid: %w", err))
+		}
+
+		if s.ConfigRepository == nil {
+			return s.Error(c, http.StatusNotImplemented, fmt.Errorf("config storage is not configured"))
+		}
+
+		config, err := s.ConfigRepository.Get(c.Context())
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't get config: %w", err))
+		}
+
+		settings := s.defaultServerSettings()
+		if config.Settings != nil {
+			settings = *config.Settings
+		}
+
+		if settings, err = applyServerSettingsUpdate(settings, request); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		config.Settings = &settings
+		if err = s.ConfigRepository.Upsert(c.Context(), config); err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't save config: %w", err))
+		}
+
+		return c.JSON(settings)
+	}
+}
+
+// RunRetentionCleaner periodically deletes executions older than configured retention period, only leader instance deletes them
+func (s TestkubeAPI) RunRetentionCleaner(ctx context.Context) {
+	ticker := time.NewTicker(retentionInterval)
+	defer ticker.Stop()
+
+	for {
+		if s.isLeader() {
+			s.deleteExpiredExecutions(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s TestkubeAPI) deleteExpiredExecutions(ctx context.Context) {
+	days := s.getServerSettings(ctx).ExecutionsRetentionDays
+	if days <= 0 {
+		return
+	}
+
+	date := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	if err := s.ExecutionResults.DeleteStartedBefore(ctx, date); err != nil {
+		s.Log.Errorw("deleting expired test executions", "error", err)
+	}
+
+	if err := s.TestExecutionResults.DeleteStartedBefore(ctx, date); err != nil {
+		s.Log.Errorw("deleting expired test suite executions", "error", err)
+	}
+}
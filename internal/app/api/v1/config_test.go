@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestApplyServerSettingsUpdate(t *testing.T) {
+	settings := testkube.ServerSettings{
+		DefaultConcurrency:   10,
+		WebhookNotifications: true,
+		SlackNotifications:   true,
+		DefaultNamespace:     "testkube",
+	}
+
+	t.Run("only set fields are updated", func(t *testing.T) {
+		retention := int32(30)
+		slack := false
+
+		updated, err := applyServerSettingsUpdate(settings, testkube.ServerSettingsUpdateRequest{
+			ExecutionsRetentionDays: &retention,
+			SlackNotifications:      &slack,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, testkube.ServerSettings{
+			DefaultConcurrency:      10,
+			ExecutionsRetentionDays: 30,
+			WebhookNotifications:    true,
+			DefaultNamespace:        "testkube",
+		}, updated)
+	})
+
+	t.Run("invalid concurrency", func(t *testing.T) {
+		concurrency := int32(0)
+
+		_, err := applyServerSettingsUpdate(settings, testkube.ServerSettingsUpdateRequest{DefaultConcurrency: &concurrency})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("negative archive days", func(t *testing.T) {
+		days := int32(-1)
+
+		_, err := applyServerSettingsUpdate(settings, testkube.ServerSettingsUpdateRequest{ArchiveAfterDays: &days})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("negative digest minutes", func(t *testing.T) {
+		minutes := int32(-1)
+
+		_, err := applyServerSettingsUpdate(settings, testkube.ServerSettingsUpdateRequest{NotificationsDigestMinutes: &minutes})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid redaction pattern", func(t *testing.T) {
+		patterns := []string{`token=\w+`, `(`}
+
+		_, err := applyServerSettingsUpdate(settings, testkube.ServerSettingsUpdateRequest{RedactionPatterns: &patterns})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid maintenance window", func(t *testing.T) {
+		windows := []testkube.MaintenanceWindow{{Schedule: "0 2 * * 6"}}
+
+		_, err := applyServerSettingsUpdate(settings, testkube.ServerSettingsUpdateRequest{MaintenanceWindows: &windows})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("empty namespace", func(t *testing.T) {
+		namespace := ""
+
+		_, err := applyServerSettingsUpdate(settings, testkube.ServerSettingsUpdateRequest{DefaultNamespace: &namespace})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/content"
+	"github.com/kubeshop/testkube/pkg/problem"
+)
+
+// contentChecksumTimeout limits resolving of content checksum, e.g. git ls-remote of slow repository
+const contentChecksumTimeout = time.Minute
+
+// setContentChecksumAsync records checksum of resolved test content and whether it changed since previous execution
+// of the test in background, as resolving remote content delays executions, stored execution is updated, so
+// checksum isn't returned by execute requests, execution isn't failed when checksum can't be resolved
+func (s TestkubeAPI) setContentChecksumAsync(execution testkube.Execution) (done <-chan struct{}) {
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		ctx, cancel := context.WithTimeout(context.Background(), contentChecksumTimeout)
+		defer cancel()
+
+		fetcher := content.NewFetcher("")
+		if execution.Content != nil && execution.Content.UriOptions != nil && execution.Content.UriOptions.SecretName != "" {
+			credentials, err := s.getContentUriCredentials(*execution.Content.UriOptions)
+			if err != nil {
+				s.Log.Warnw("resolving test content checksum", "test", execution.TestName, "error", err)
+				return
+			}
+			fetcher = fetcher.WithUriCredentials(credentials)
+		}
+
+		checksum, err := fetcher.Checksum(execution.Content)
+		if err != nil {
+			s.Log.Warnw("resolving test content checksum", "test", execution.TestName, "error", err)
+			return
+		}
+
+		execution.ContentChecksum = checksum
+		// previous execution by number is used as the latest one can be the execution itself
+		var previous testkube.Execution
+		if execution.Number > 1 {
+			previous, err = s.ExecutionResults.GetByNumberAndTest(ctx, execution.Number-1, execution.TestName)
+			if err != nil && err != mongo.ErrNoDocuments {
+				s.Log.Warnw("getting previous test execution", "test", execution.TestName, "error", err)
+			}
+		}
+
+		if err = s.ExecutionResults.UpdateContentChecksum(ctx, execution.Id, checksum, execution.ContentChangedSince(previous)); err != nil {
+			s.Log.Warnw("updating test content checksum", "executionId", execution.Id, "error", err)
+		}
+	}()
+
+	return finished
+}
+
+// getContentUriCredentials reads file URI credentials from secret of content URI options, like job does
+// for fetching content
+func (s TestkubeAPI) getContentUriCredentials(options testkube.ContentUriOptions) (credentials content.UriCredentials, err error) {
+	if s.SecretClient == nil {
+		return credentials, fmt.Errorf("can't read file URI credentials: secrets client is not set")
+	}
+
+	data, err := s.SecretClient.Get(options.SecretName)
+	if err != nil {
+		return credentials, fmt.Errorf("can't read file URI credentials from secret %s: %w", options.SecretName, err)
+	}
+
+	credentials.Username, credentials.Password = data[options.UsernameKey], data[options.PasswordKey]
+	credentials.Headers = make(map[string]string, len(options.HeaderKeys))
+	for header, key := range options.HeaderKeys {
+		credentials.Headers[header] = data[key]
+	}
+
+	return credentials, nil
+}
+
+// GetExecutionDiffHandler compares test execution with base execution of the same test, previous execution
+// of the test is used when base isn't set
+func (s TestkubeAPI) GetExecutionDiffHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		executionID := c.Params("executionID")
+		project := getProject(c)
+
+		execution, err := s.ExecutionResults.Get(ctx, executionID)
+		if err == mongo.ErrNoDocuments || (err == nil && project != "" && execution.Project != project) {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("execution %s not found", executionID)))
+		}
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		var base testkube.Execution
+		if baseID := c.Query("base"); baseID != "" {
+			base, err = s.ExecutionResults.Get(ctx, baseID)
+			if err == mongo.ErrNoDocuments || (err == nil && project != "" && base.Project != project) {
+				return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("base execution %s not found", baseID)))
+			}
+			if err != nil {
+				return s.Error(c, http.StatusInternalServerError, err)
+			}
+
+			if base.TestName != execution.TestName {
+				return s.Warn(c, http.StatusBadRequest, fmt.Errorf("base execution %s is execution of other test %s", baseID, base.TestName))
+			}
+		} else {
+			err = mongo.ErrNoDocuments
+			if execution.Number > 1 {
+				base, err = s.ExecutionResults.GetByNumberAndTest(ctx, execution.Number-1, execution.TestName)
+			}
+			if err == mongo.ErrNoDocuments {
+				return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("previous execution of execution %s not found", executionID)))
+			}
+			if err != nil {
+				return s.Error(c, http.StatusInternalServerError, err)
+			}
+		}
+
+		return c.JSON(testkube.NewExecutionDiff(execution, base))
+	}
+}
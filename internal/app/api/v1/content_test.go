@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/log"
+)
+
+func TestSetContentChecksumAsync(t *testing.T) {
+	results := &storedResults{executions: map[string]testkube.Execution{
+		"1": {Id: "1", TestName: "api", Number: 1, ContentChecksum: "sha256:previous"},
+		"2": {Id: "2", TestName: "api", Number: 2},
+	}}
+	s := TestkubeAPI{ExecutionResults: results}
+	s.Log = log.DefaultLogger
+
+	<-s.setContentChecksumAsync(testkube.Execution{Id: "2", TestName: "api", Number: 2, Content: testkube.NewStringTestContent("{}")})
+
+	execution, err := results.Get(context.Background(), "2")
+	require.NoError(t, err)
+	assert.Contains(t, execution.ContentChecksum, "sha256:")
+	assert.True(t, execution.ContentChanged)
+}
@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// validateCostRates checks that cost rates aren't negative
+func validateCostRates(rates testkube.CostRates) error {
+	if rates.CpuHour < 0 || rates.MemoryGBHour < 0 {
+		return fmt.Errorf("cost rates can't be negative")
+	}
+
+	return nil
+}
+
+// costRollups returns estimated costs of usage rollups from the most expensive, rollups of the same cost are sorted
+// by name
+func costRollups(usage []result.UsageRollup, rates testkube.CostRates) []testkube.CostRollup {
+	rollups := make([]testkube.CostRollup, 0, len(usage))
+	for _, u := range usage {
+		rollups = append(rollups, testkube.CostRollup{
+			Name:               u.Name,
+			Executions:         u.Executions,
+			UnpricedExecutions: u.UnpricedExecutions,
+			CpuHours:           u.CpuHours,
+			MemoryGBHours:      u.MemoryGBHours,
+			Cost:               u.CpuHours*rates.CpuHour + u.MemoryGBHours*rates.MemoryGBHour,
+		})
+	}
+
+	sort.Slice(rollups, func(i, j int) bool {
+		if rollups[i].Cost != rollups[j].Cost {
+			return rollups[i].Cost > rollups[j].Cost
+		}
+		return rollups[i].Name < rollups[j].Name
+	})
+
+	return rollups
+}
+
+// newCostsReport estimates costs of finished executions usage per test and per execution label
+func newCostsReport(usage result.Usage, rates testkube.CostRates) (report testkube.CostsReport) {
+	report.Rates = &rates
+	report.Tests = costRollups(usage.Tests, rates)
+	report.Labels = costRollups(usage.Labels, rates)
+	// every execution belongs to one test, so test rollups add up to total cost
+	for _, rollup := range report.Tests {
+		report.Cost += rollup.Cost
+	}
+
+	return report
+}
+
+// GetCostsReportHandler returns estimated costs of test executions per test and per label
+func (s TestkubeAPI) GetCostsReportHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		period, err := parseReportPeriod(c.Query("since", defaultReportPeriod))
+		if err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		now := time.Now()
+		selector := c.Query("selector")
+		filter := result.NewExecutionsFilter().
+			WithStartDate(now.Add(-period)).
+			WithSelector(selector).
+			WithProject(getProject(c))
+
+		usage, err := s.ExecutionResults.GetUsage(c.Context(), filter)
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		var rates testkube.CostRates
+		if settingsRates := s.getServerSettings(c.Context()).CostRates; settingsRates != nil {
+			rates = *settingsRates
+		}
+
+		report := newCostsReport(usage, rates)
+		report.Selector = selector
+		report.Since = now.Add(-period)
+		report.GeneratedAt = now
+		return c.JSON(report)
+	}
+}
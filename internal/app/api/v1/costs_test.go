@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestNewCostsReport(t *testing.T) {
+	usage := result.Usage{
+		Tests: []result.UsageRollup{
+			{Name: "ui", Executions: 2, UnpricedExecutions: 1, CpuHours: 1, MemoryGBHours: 1},
+			{Name: "api", Executions: 2, CpuHours: 3, MemoryGBHours: 1.5},
+		},
+		Labels: []result.UsageRollup{
+			{Name: "team=web", Executions: 1, CpuHours: 1, MemoryGBHours: 1},
+			{Name: "team=payments", Executions: 2, CpuHours: 3, MemoryGBHours: 1.5},
+			{Name: "env=staging", Executions: 1, CpuHours: 1, MemoryGBHours: 1},
+		},
+	}
+
+	report := newCostsReport(usage, testkube.CostRates{CpuHour: 0.5, MemoryGBHour: 0.1, Currency: "USD"})
+
+	assert.InDelta(t, 2.25, report.Cost, 0.0001)
+	assert.Equal(t, "USD", report.Rates.Currency)
+	if assert.Len(t, report.Tests, 2) {
+		assert.Equal(t, "api", report.Tests[0].Name)
+		assert.Equal(t, int32(2), report.Tests[0].Executions)
+		assert.InDelta(t, 3, report.Tests[0].CpuHours, 0.0001)
+		assert.InDelta(t, 1.65, report.Tests[0].Cost, 0.0001)
+
+		assert.Equal(t, "ui", report.Tests[1].Name)
+		assert.Equal(t, int32(1), report.Tests[1].UnpricedExecutions)
+	}
+
+	if assert.Len(t, report.Labels, 3) {
+		assert.Equal(t, []string{"team=payments", "env=staging", "team=web"},
+			[]string{report.Labels[0].Name, report.Labels[1].Name, report.Labels[2].Name}, "same costs are sorted by name")
+	}
+}
@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// dependenciesInterval is a time between checks of required tests of queued executions
+var dependenciesInterval = 30 * time.Second
+
+// dependencyStatuses are statuses of completed executions required tests are checked by, skipped executions are ignored
+var dependencyStatuses = strings.Join([]string{string(testkube.PASSED_ExecutionStatus), string(testkube.FAILED_ExecutionStatus),
+	string(testkube.TIMEOUT_ExecutionStatus), string(testkube.ABORTED_ExecutionStatus)}, ",")
+
+// checkDependencies returns error of first required test which latest completed execution within freshness window
+// isn't passing
+func (s TestkubeAPI) checkDependencies(ctx context.Context, dependencies testkube.TestDependencies, now time.Time) error {
+	freshness := dependencies.GetFreshness()
+	for _, name := range dependencies.RequiresPassing {
+		filter := result.NewExecutionsFilter().WithTestName(name).WithStatus(dependencyStatuses).
+			WithStartDate(now.Add(-freshness)).WithPageSize(1).WithExcludedFields(result.SummaryExcludedFields())
+		executions, err := s.ExecutionResults.GetExecutions(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("can't get latest execution of required test %s: %w", name, err)
+		}
+
+		if len(executions) == 0 {
+			return fmt.Errorf("required test %s has no completed execution within %s", name, freshness)
+		}
+
+		latest := executions[0]
+		if latest.ExecutionResult == nil || latest.ExecutionResult.Status == nil {
+			return fmt.Errorf("required test %s latest execution %s has no result", name, latest.Name)
+		}
+
+		if !latest.ExecutionResult.IsPassed() {
+			return fmt.Errorf("required test %s latest execution %s is %s", name, latest.Name, *latest.ExecutionResult.Status)
+		}
+	}
+
+	return nil
+}
+
+// waitForDependencies checks required tests, queued executions wait until required tests pass, error of failing
+// required test is returned after queue timeout
+func (s TestkubeAPI) waitForDependencies(ctx context.Context, execution testkube.Execution, dependencies testkube.TestDependencies) error {
+	err := s.checkDependencies(ctx, dependencies, time.Now())
+	if err == nil || !dependencies.IsQueued() {
+		return err
+	}
+
+	s.Logger(ctx).Infow("test execution queued until required tests pass", "executionId", execution.Id, "reason", err)
+	if uerr := s.ExecutionResults.UpdateResult(ctx, execution.Id, testkube.ExecutionResult{Status: testkube.ExecutionStatusQueued}); uerr != nil {
+		s.Logger(ctx).Errorw("updating queued execution result", "executionId", execution.Id, "error", uerr)
+	}
+
+	timeout := dependencies.GetQueueTimeout()
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(dependenciesInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return err
+		case <-deadline.C:
+			return fmt.Errorf("queued for %s: %w", timeout, err)
+		case <-ticker.C:
+		}
+
+		// queued executions can be aborted, also by other API server
+		if _, aborted := s.getAbortedExecution(ctx, execution.Id); aborted {
+			return fmt.Errorf("test execution %s was aborted while queued", execution.Id)
+		}
+
+		if err = s.checkDependencies(ctx, dependencies, time.Now()); err == nil {
+			return nil
+		}
+	}
+}
+
+// findDependencyCycle returns path of required tests leading back to test, required tests which can't be read are
+// treated as tests without dependencies
+func (s TestkubeAPI) findDependencyCycle(name string, required, path []string, visited map[string]bool) []string {
+	for _, requiredName := range required {
+		requiredPath := append(append([]string{}, path...), requiredName)
+		if requiredName == name {
+			return requiredPath
+		}
+
+		if visited[requiredName] {
+			continue
+		}
+		visited[requiredName] = true
+
+		test, err := s.TestsClient.Get(requiredName)
+		if err != nil {
+			continue
+		}
+
+		if dependencies := testkube.DependenciesFromAnnotations(test.Annotations); dependencies != nil {
+			if cycle := s.findDependencyCycle(name, dependencies.RequiresPassing, requiredPath, visited); cycle != nil {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	testsv2 "github.com/kubeshop/testkube-operator/apis/tests/v2"
+	testsclientv2 "github.com/kubeshop/testkube-operator/client/tests/v2"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/server"
+)
+
+// newDependentTestsClient returns tests client of tests with required tests
+func newDependentTestsClient(t *testing.T, required map[string][]string) *testsclientv2.TestsClient {
+	scheme := runtime.NewScheme()
+	require.NoError(t, testsv2.AddToScheme(scheme))
+
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for name, requiresPassing := range required {
+		test := &testsv2.Test{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "testkube"}}
+		if len(requiresPassing) != 0 {
+			test.Annotations = testkube.DependenciesAnnotations(&testkube.TestDependencies{RequiresPassing: requiresPassing})
+		}
+		builder = builder.WithObjects(test)
+	}
+
+	return testsclientv2.NewClient(builder.Build(), "testkube")
+}
+
+func TestValidateTestDependencies(t *testing.T) {
+	s := TestkubeAPI{TestsClient: newDependentTestsClient(t, map[string][]string{
+		"smoke":    nil,
+		"db":       {"smoke"},
+		"api":      {"db"},
+		"checkout": {"api"},
+	})}
+
+	tests := map[string]struct {
+		name     string
+		required []string
+		err      string
+	}{
+		"existing tests":      {name: "e2e", required: []string{"api", "smoke"}},
+		"itself":              {name: "smoke", required: []string{"smoke"}, err: "test smoke can't require itself"},
+		"missing test":        {name: "e2e", required: []string{"payments"}, err: "required test payments not found"},
+		"direct cycle":        {name: "db", required: []string{"api"}, err: "tests can't require each other: db -> api -> db"},
+		"transitive cycle":    {name: "smoke", required: []string{"checkout"}, err: "smoke -> checkout -> api -> db -> smoke"},
+		"diamond isn't cycle": {name: "e2e", required: []string{"checkout", "db"}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := s.validateTestDependencies(test.name, &testkube.TestDependencies{RequiresPassing: test.required})
+			if test.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), test.err)
+			}
+		})
+	}
+}
+
+func TestCheckDependencies(t *testing.T) {
+	execution := func(testName string, status *testkube.ExecutionStatus) testkube.Execution {
+		return testkube.Execution{Id: testName, Name: testName + "-1", TestName: testName, ExecutionResult: &testkube.ExecutionResult{Status: status}}
+	}
+	s := TestkubeAPI{ExecutionResults: &storedResults{executions: map[string]testkube.Execution{
+		"smoke": execution("smoke", testkube.ExecutionStatusPassed),
+		"api":   execution("api", testkube.ExecutionStatusFailed),
+	}}}
+
+	tests := map[string]struct {
+		required []string
+		err      string
+	}{
+		"passing test":   {required: []string{"smoke"}},
+		"failing test":   {required: []string{"smoke", "api"}, err: "required test api latest execution api-1 is failed"},
+		"never run test": {required: []string{"db"}, err: "required test db has no completed execution within 24h0m0s"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := s.checkDependencies(context.Background(), testkube.TestDependencies{RequiresPassing: test.required}, time.Now())
+			if test.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), test.err)
+			}
+		})
+	}
+}
+
+func TestWaitForDependencies(t *testing.T) {
+	interval := dependenciesInterval
+	dependenciesInterval = 10 * time.Millisecond
+	defer func() { dependenciesInterval = interval }()
+
+	newAPI := func(executions ...testkube.Execution) TestkubeAPI {
+		stored := map[string]testkube.Execution{}
+		for _, execution := range executions {
+			stored[execution.Id] = execution
+		}
+
+		return TestkubeAPI{HTTPServer: server.NewServer(server.Config{}), ExecutionResults: &storedResults{executions: stored}}
+	}
+	queued := testkube.Execution{Id: "queued", TestName: "e2e"}
+	dependencies := testkube.TestDependencies{RequiresPassing: []string{"smoke"}, OnBlocked: testkube.DependenciesOnBlockedQueue, QueueTimeout: "100ms"}
+
+	t.Run("skipped when not queued", func(t *testing.T) {
+		err := newAPI().waitForDependencies(context.Background(), queued, testkube.TestDependencies{RequiresPassing: []string{"smoke"}})
+		assert.Error(t, err)
+	})
+
+	t.Run("queue timeout", func(t *testing.T) {
+		s := newAPI()
+		err := s.waitForDependencies(context.Background(), queued, dependencies)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "queued for 100ms: required test smoke has no completed execution")
+		}
+		stored, err := s.ExecutionResults.Get(context.Background(), queued.Id)
+		require.NoError(t, err)
+		assert.Equal(t, testkube.ExecutionStatusQueued, stored.ExecutionResult.Status)
+	})
+
+	t.Run("aborted while queued", func(t *testing.T) {
+		s := newAPI()
+		results := s.ExecutionResults.(*storedResults)
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			_ = results.UpdateResult(context.Background(), queued.Id, testkube.ExecutionResult{Status: testkube.ExecutionStatusAborted})
+		}()
+
+		err := s.waitForDependencies(context.Background(), queued, testkube.TestDependencies{
+			RequiresPassing: []string{"smoke"}, OnBlocked: testkube.DependenciesOnBlockedQueue, QueueTimeout: "1m",
+		})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "was aborted while queued")
+		}
+	})
+
+	t.Run("required test passed", func(t *testing.T) {
+		passed := testkube.Execution{Id: "smoke", TestName: "smoke", ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed}}
+		assert.NoError(t, newAPI(passed).waitForDependencies(context.Background(), queued, dependencies))
+	})
+}
@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/slacknotifier"
+)
+
+const (
+	// digestInterval is an interval of checking if notification digests should be sent
+	digestInterval = time.Minute
+	// slackDigestDestination is a digest destination of slack notifications
+	slackDigestDestination = "slack"
+	// webhookDigestPrefix is a prefix of webhook digest destinations, followed by webhook name
+	webhookDigestPrefix = "webhook/"
+)
+
+// isDigesting checks if end events are batched into digests instead of sending notification per execution
+func (s TestkubeAPI) isDigesting(settings testkube.ServerSettings) bool {
+	return s.digests != nil && settings.NotificationsDigestMinutes > 0
+}
+
+// RunNotificationsDigest periodically sends digests of batched notifications and of notifications held in maintenance
+// windows and quiet hours which ended, batches are stored and shared by replicas, so each digest is sent by one of them
+// and pending digests are sent after restart
+func (s TestkubeAPI) RunNotificationsDigest(ctx context.Context) {
+	if s.digests == nil {
+		return
+	}
+
+	ticker := time.NewTicker(digestInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			minutes := s.getServerSettings(ctx).NotificationsDigestMinutes
+			digests, err := s.digests.Flush(ctx, time.Duration(minutes)*time.Minute, time.Now())
+			if err != nil {
+				s.Log.Errorw("taking notification digests", "error", err)
+			}
+			s.sendDigests(digests)
+
+			if s.heldNotifications != nil {
+				held, err := s.heldNotifications.Release(ctx, time.Now())
+				if err != nil {
+					s.Log.Errorw("taking held notifications", "error", err)
+				}
+				s.sendDigests(held)
+			}
+		}
+	}
+}
+
+// batchNotification adds end event to batch of destination held until heldUntil or to its digest batch, false is
+// returned when event isn't batched or batch can't be stored, so notification is sent right away
+func (s TestkubeAPI) batchNotification(destination string, execution testkube.Execution, now, heldUntil time.Time,
+	digesting bool) bool {
+	var err error
+	switch {
+	case !heldUntil.IsZero():
+		err = s.heldNotifications.Hold(context.Background(), destination, execution, now, heldUntil)
+	case digesting:
+		err = s.digests.Add(context.Background(), destination, execution, now)
+	default:
+		return false
+	}
+
+	if err != nil {
+		s.Log.Errorw("storing notifications batch, sending notification right away", "destination", destination, "error", err)
+		return false
+	}
+
+	return true
+}
+
+// sendDigests sends digests to their destinations
+func (s TestkubeAPI) sendDigests(digests map[string]testkube.ExecutionsDigest) {
+	for destination, digest := range digests {
+		digest := digest
+		if destination == slackDigestDestination {
+			if err := slacknotifier.SendDigest(digest); err != nil {
+				s.Log.Warnw("notify slack digest failed", "error", err)
+			}
+			continue
+		}
+
+		name := strings.TrimPrefix(destination, webhookDigestPrefix)
+		wh, err := s.WebhooksClient.Get(name)
+		if err != nil {
+			s.Log.Warnw("skipping digest of missing webhook", "webhook", name, "error", err)
+			continue
+		}
+
+		event, err := s.newWebhookEvent(*wh, testkube.WebhookTypeDigest)
+		if err != nil {
+			s.Log.Warnw("skipping digest of webhook with invalid signing or connection options", "webhook", name, "error", err)
+			continue
+		}
+
+		event.Digest = &digest
+		s.Log.Debugw("Sending digest", "uri", wh.Spec.Uri, "executions", len(digest.Executions))
+		s.EventsEmitter.Notify(event)
+	}
+}
@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/problem"
+)
+
+// withExecutionGroup labels execution requests with execution group id, executions started
+// by the same trigger are grouped so they can be checked and aborted together
+func withExecutionGroup(requests []testkube.ExecutionRequest, groupId string) []testkube.ExecutionRequest {
+	for i := range requests {
+		requests[i].Labels = mergeLabels(requests[i].Labels, map[string]string{testkube.ExecutionGroupLabel: groupId})
+	}
+
+	return requests
+}
+
+// GetExecutionGroupHandler returns executions of execution group with aggregate status and duration,
+// executions of selector and matrix runs are grouped by request, test suite steps by test suite execution
+func (s TestkubeAPI) GetExecutionGroupHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Params("id")
+
+		executions, err := s.getExecutionGroup(c, id, result.SummaryExcludedFields())
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		if len(executions) == 0 {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionGroupNotFound, fmt.Errorf("execution group %s not found", id)))
+		}
+
+		return c.JSON(testkube.NewExecutionGroup(id, mapExecutionsToExecutionSummary(executions)))
+	}
+}
+
+// AbortExecutionGroupHandler aborts queued and running executions of execution group, test suite execution
+// group is aborted with its test suite execution, so queued steps are skipped
+func (s TestkubeAPI) AbortExecutionGroupHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id := c.Params("id")
+
+		executions, err := s.getExecutionGroup(c, id, nil)
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		suiteExecution, err := s.TestExecutionResults.Get(ctx, id)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't get test suite execution %s: %w", id, err))
+		}
+
+		project := getProject(c)
+		isSuite := err == nil && (project == "" || suiteExecution.Project == project)
+		if len(executions) == 0 && !isSuite {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionGroupNotFound, fmt.Errorf("execution group %s not found", id)))
+		}
+
+		if isSuite {
+			if suiteExecution.Status != nil && suiteExecution.IsCompleted() {
+				return s.Warn(c, http.StatusConflict, fmt.Errorf("execution group %s is already %s", id, *suiteExecution.Status))
+			}
+
+			accepted, err := s.abortTestSuiteExecution(ctx, suiteExecution)
+			if err != nil {
+				return s.Error(c, http.StatusInternalServerError, err)
+			}
+
+			if accepted {
+				c.Status(http.StatusAccepted)
+			}
+
+			return nil
+		}
+
+		// executions can't be mapped to summaries before abort, it stores their whole result
+		aborted := 0
+		for _, execution := range executions {
+			if execution.ExecutionResult == nil || execution.ExecutionResult.Status == nil ||
+				!(execution.ExecutionResult.IsQueued() || execution.ExecutionResult.IsRunning()) {
+				continue
+			}
+
+			if err = s.abortExecution(ctx, execution); err != nil {
+				return s.Error(c, http.StatusInternalServerError, err)
+			}
+			aborted++
+		}
+
+		if aborted == 0 {
+			return s.Warn(c, http.StatusConflict, fmt.Errorf("execution group %s has no running executions", id))
+		}
+
+		return nil
+	}
+}
+
+// getExecutionGroup gets all executions of execution group in request project without excluded fields,
+// executions are read page by page, so group status counts all of them
+func (s TestkubeAPI) getExecutionGroup(c *fiber.Ctx, id string, excludedFields []string) ([]testkube.Execution, error) {
+	var executions []testkube.Execution
+	for page := 0; ; page++ {
+		filter := result.NewExecutionsFilter().WithGroupId(id).WithExcludedFields(excludedFields).WithPage(page)
+		if project := getProject(c); project != "" {
+			filter = filter.WithProject(project)
+		}
+
+		pageExecutions, err := s.ExecutionResults.GetExecutions(c.Context(), filter)
+		if err != nil {
+			return nil, fmt.Errorf("can't get executions of execution group %s: %w", id, err)
+		}
+
+		executions = append(executions, pageExecutions...)
+		if len(pageExecutions) < result.PageDefaultLimit {
+			return executions, nil
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/server"
+)
+
+// pagedResults returns group executions page by page like stored ones
+type pagedResults struct {
+	result.Repository
+	executions []testkube.Execution
+}
+
+func (r pagedResults) GetExecutions(ctx context.Context, filter result.Filter) ([]testkube.Execution, error) {
+	start := filter.Page() * filter.PageSize()
+	if start > len(r.executions) {
+		return nil, nil
+	}
+
+	end := start + filter.PageSize()
+	if end > len(r.executions) {
+		end = len(r.executions)
+	}
+
+	return r.executions[start:end], nil
+}
+
+func TestNewExecutionGroup(t *testing.T) {
+	summary := func(statuses ...*testkube.ExecutionStatus) (executions []testkube.ExecutionSummary) {
+		for _, status := range statuses {
+			executions = append(executions, testkube.ExecutionSummary{Status: status})
+		}
+		return executions
+	}
+
+	group := testkube.NewExecutionGroup("1", summary(testkube.ExecutionStatusPassed, testkube.ExecutionStatusFailed, testkube.ExecutionStatusRunning))
+	assert.Equal(t, testkube.ExecutionStatusRunning, group.Status)
+	assert.Equal(t, testkube.ExecutionsTotals{Results: 3, Passed: 1, Failed: 1, Running: 1}, *group.Totals)
+
+	group = testkube.NewExecutionGroup("1", summary(testkube.ExecutionStatusPassed, testkube.ExecutionStatusTimeout))
+	assert.Equal(t, testkube.ExecutionStatusFailed, group.Status)
+
+	group = testkube.NewExecutionGroup("1", summary(testkube.ExecutionStatusPassed, testkube.ExecutionStatusPassed))
+	assert.Equal(t, testkube.ExecutionStatusPassed, group.Status)
+}
+
+func TestNewExecutionGroupDuration(t *testing.T) {
+	start := time.Date(2022, 8, 10, 12, 0, 0, 0, time.UTC)
+	executions := []testkube.ExecutionSummary{
+		{Status: testkube.ExecutionStatusPassed, StartTime: start.Add(time.Minute), EndTime: start.Add(3 * time.Minute)},
+		{Status: testkube.ExecutionStatusFailed, StartTime: start, EndTime: start.Add(2 * time.Minute)},
+		{Status: testkube.ExecutionStatusQueued},
+	}
+
+	group := testkube.NewExecutionGroup("1", executions)
+	assert.Equal(t, start, group.StartTime)
+	assert.True(t, group.EndTime.IsZero(), "running group has no end time")
+
+	group = testkube.NewExecutionGroup("1", executions[:2])
+	assert.Equal(t, start.Add(3*time.Minute), group.EndTime)
+	assert.Equal(t, "3m0s", group.Duration)
+}
+
+func TestWithExecutionGroup(t *testing.T) {
+	labels := map[string]string{"app": "web"}
+	requests := withExecutionGroup([]testkube.ExecutionRequest{{Labels: labels}, {}}, "62f3a1b2c4d5e6f708192a3b")
+
+	assert.Equal(t, map[string]string{"app": "web", testkube.ExecutionGroupLabel: "62f3a1b2c4d5e6f708192a3b"}, requests[0].Labels)
+	assert.Equal(t, map[string]string{testkube.ExecutionGroupLabel: "62f3a1b2c4d5e6f708192a3b"}, requests[1].Labels)
+	assert.Equal(t, map[string]string{"app": "web"}, labels, "request labels are copied")
+}
+
+func TestGetExecutionGroupHandlerAllPages(t *testing.T) {
+	executions := make([]testkube.Execution, result.PageDefaultLimit+1)
+	for i := range executions {
+		executions[i] = testkube.Execution{GroupId: "group", ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed}}
+	}
+	executions[result.PageDefaultLimit].ExecutionResult.Status = testkube.ExecutionStatusFailed
+
+	s := TestkubeAPI{HTTPServer: server.NewServer(server.Config{}), ExecutionResults: pagedResults{executions: executions}}
+	s.Mux.Get("/execution-groups/:id", s.GetExecutionGroupHandler())
+
+	resp, err := s.Mux.Test(httptest.NewRequest(http.MethodGet, "/execution-groups/group", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var group testkube.ExecutionGroup
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&group))
+	assert.Equal(t, testkube.ExecutionStatusFailed, group.Status, "execution on second page is counted")
+	assert.Equal(t, int32(result.PageDefaultLimit+1), group.Totals.Results)
+}
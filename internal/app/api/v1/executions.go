@@ -3,27 +3,43 @@ package v1
 import (
 	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/valyala/fasthttp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"k8s.io/apimachinery/pkg/api/errors"
 
+	executorv1 "github.com/kubeshop/testkube-operator/apis/executor/v1"
 	testsv2 "github.com/kubeshop/testkube-operator/apis/tests/v2"
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/kubeshop/testkube/pkg/cronjob"
 	"github.com/kubeshop/testkube/pkg/executor/client"
 	"github.com/kubeshop/testkube/pkg/executor/output"
+	"github.com/kubeshop/testkube/pkg/jobs"
 	testsmapper "github.com/kubeshop/testkube/pkg/mapper/tests"
+	webhooksmapper "github.com/kubeshop/testkube/pkg/mapper/webhooks"
+	"github.com/kubeshop/testkube/pkg/problem"
 	"github.com/kubeshop/testkube/pkg/rand"
 	"github.com/kubeshop/testkube/pkg/secret"
+	"github.com/kubeshop/testkube/pkg/server"
 	"github.com/kubeshop/testkube/pkg/slacknotifier"
+	"github.com/kubeshop/testkube/pkg/storage"
+	"github.com/kubeshop/testkube/pkg/storage/minio"
 	"github.com/kubeshop/testkube/pkg/types"
+	"github.com/kubeshop/testkube/pkg/webhook"
 	"github.com/kubeshop/testkube/pkg/workerpool"
 )
 
@@ -34,6 +50,10 @@ const (
 	testSuiteResourceURI = "test-suites"
 	// defaultConcurrencyLevel is a default concurrency level for worker pool
 	defaultConcurrencyLevel = "10"
+	// presignedURLExpiration is an expiration time of presigned artifact download URLs
+	presignedURLExpiration = 15 * time.Minute
+	// artifactDigestHeader is a header with SHA-256 digest of downloaded artifact in RFC 3230 format
+	artifactDigestHeader = "Digest"
 )
 
 // ExecuteTestsHandler calls particular executor based on execution request content and type
@@ -47,8 +67,44 @@ func (s TestkubeAPI) ExecuteTestsHandler() fiber.Handler {
 			return s.Error(c, http.StatusBadRequest, fmt.Errorf("test request body invalid: %w", err))
 		}
 
+		if err = testkube.ValidateExecutionFiles(request.Files); err != nil {
+			return s.Warn(c, http.StatusBadRequest, err)
+		}
+
+		// request labels are set on execution jobs and pods, so they can't override labels pods are looked up by
+		if err = jobs.ValidateLabels(request.Labels); err != nil {
+			return s.Warn(c, http.StatusBadRequest, err)
+		}
+
+		if request.ExecutorImageDigest != "" {
+			if err = jobs.ValidateImageDigest(request.ExecutorImageDigest); err != nil {
+				return s.Warn(c, http.StatusBadRequest, err)
+			}
+		}
+
+		if _, _, err = testkube.NewmanOptionsFromParams(request.Newman, request.Params); err != nil {
+			return s.Warn(c, http.StatusBadRequest, err)
+		}
+
+		settings := s.getServerSettings(ctx)
+		if request.Namespace == "" {
+			request.Namespace = settings.DefaultNamespace
+		}
+
 		id := c.Params("id")
 		namespace := request.Namespace
+		project := getProject(c)
+		// execution labels can't move execution out of the request project
+		request.Labels = withProjectLabel(request.Labels, project)
+		request.RunningContext = s.withRequester(c, request.RunningContext)
+
+		// params matrix starts execution of each test for each combination of params
+		requests := []testkube.ExecutionRequest{request}
+		if len(request.Matrix) != 0 {
+			if requests, err = newMatrixRequests(request); err != nil {
+				return s.Error(c, http.StatusBadRequest, fmt.Errorf("test request matrix invalid: %w", err))
+			}
+		}
 
 		var tests []testsv2.Test
 		if id != "" {
@@ -57,14 +113,31 @@ func (s TestkubeAPI) ExecuteTestsHandler() fiber.Handler {
 				return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't get test: %w", err))
 			}
 
+			if !isInProject(test.Labels, project) {
+				return s.Error(c, http.StatusNotFound, problem.WithCode(problem.CodeTestNotFound, fmt.Errorf("test %s not found", id)))
+			}
+
+			if testkube.IsDisabled(test.Labels) && c.Query("force") != "true" {
+				return s.Warn(c, http.StatusConflict, problem.WithCode(problem.CodeDisabled, fmt.Errorf("test %s is disabled, use force to run it anyway", id)))
+			}
+
+			if _, err = s.getExecutorByType(test.Spec.Type_); err != nil {
+				return s.Warn(c, executorErrorStatus(err), err)
+			}
+
 			tests = append(tests, *test)
 		} else {
-			testList, err := s.TestsClient.List(c.Query("selector"))
+			testList, err := s.TestsClient.List(projectSelector(c.Query("selector"), project))
 			if err != nil {
 				return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't get tests: %w", err))
 			}
 
+			// disabled tests are skipped by bulk runs
 			for _, item := range testList.Items {
+				if testkube.IsDisabled(item.Labels) {
+					s.Logger(c.Context()).Debugw("skipping disabled test", "test", item.Name)
+					continue
+				}
 				tests = append(tests, item)
 			}
 		}
@@ -72,6 +145,17 @@ func (s TestkubeAPI) ExecuteTestsHandler() fiber.Handler {
 		var results []testkube.Execution
 		var work []testsv2.Test
 		for _, test := range tests {
+			// scheduled runs in maintenance windows are stored as skipped, so planned downtime doesn't raise alerts
+			if c.Query("callback") != "" {
+				apiTest := testsmapper.MapTestCRToAPI(test)
+				if window := testkube.ActiveMaintenanceWindow(time.Now(), settings.MaintenanceWindows, apiTest.MaintenanceWindows); window != nil {
+					for _, request := range requests {
+						results = append(results, s.skipTest(ctx, apiTest, request, *window))
+					}
+					continue
+				}
+			}
+
 			if test.Spec.Schedule == "" || c.Query("callback") != "" {
 				work = append(work, test)
 				continue
@@ -87,6 +171,7 @@ func (s TestkubeAPI) ExecuteTestsHandler() fiber.Handler {
 				Resource: testResourceURI,
 				Data:     string(data),
 				Labels:   test.Labels,
+				Suspend:  testkube.IsDisabled(test.Labels),
 			}
 			if err = s.CronJobClient.Apply(test.Name, cronjob.GetMetadataName(test.Name, testResourceURI), options); err != nil {
 				return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't create scheduled test: %w", err))
@@ -101,22 +186,40 @@ func (s TestkubeAPI) ExecuteTestsHandler() fiber.Handler {
 		}
 
 		if len(work) != 0 {
-			concurrencyLevel, err := strconv.Atoi(c.Query("concurrency", defaultConcurrencyLevel))
+			concurrencyLevel, err := strconv.Atoi(c.Query("concurrency", strconv.Itoa(int(settings.DefaultConcurrency))))
 			if err != nil {
 				return s.Error(c, http.StatusBadRequest, fmt.Errorf("can't detect concurrency level: %w", err))
 			}
 
 			workerpoolService := workerpool.New[testkube.Test, testkube.ExecutionRequest, testkube.Execution](concurrencyLevel)
 
-			go workerpoolService.SendRequests(s.prepareTestRequests(work, request))
+			// executions started by selector or params matrix are grouped, scheduled runs start their own groups
+			if id == "" || len(request.Matrix) != 0 {
+				requests = withExecutionGroup(requests, primitive.NewObjectID().Hex())
+			}
+
+			go workerpoolService.SendRequests(s.prepareTestRequests(work, requests))
 			go workerpoolService.Run(ctx)
 
 			for r := range workerpoolService.GetResponses() {
+				if id != "" && result.IsDuplicateNameError(r.Err) {
+					return s.Error(c, http.StatusConflict, problem.WithCode(problem.CodeDuplicateExecution, fmt.Errorf(r.Result.ExecutionResult.ErrorMessage)))
+				}
+
+				if id != "" && isPolicyViolation(r.Err) {
+					return s.Warn(c, http.StatusForbidden, r.Err)
+				}
+
+				if status := quotaErrorStatus(r.Err); id != "" && status != 0 {
+					return s.Warn(c, status, r.Err)
+				}
+
 				results = append(results, r.Result)
 			}
 		}
 
-		if id != "" && len(results) != 0 {
+		// matrix executions of single test are returned as executions list
+		if id != "" && len(results) != 0 && len(request.Matrix) == 0 {
 			if results[0].ExecutionResult.IsFailed() {
 				return s.Error(c, http.StatusInternalServerError, fmt.Errorf(results[0].ExecutionResult.ErrorMessage))
 			}
@@ -128,14 +231,17 @@ func (s TestkubeAPI) ExecuteTestsHandler() fiber.Handler {
 	}
 }
 
-func (s TestkubeAPI) prepareTestRequests(work []testsv2.Test, request testkube.ExecutionRequest) []workerpool.Request[
+func (s TestkubeAPI) prepareTestRequests(work []testsv2.Test, executionRequests []testkube.ExecutionRequest) []workerpool.Request[
 	testkube.Test, testkube.ExecutionRequest, testkube.Execution] {
-	requests := make([]workerpool.Request[testkube.Test, testkube.ExecutionRequest, testkube.Execution], len(work))
+	requests := make([]workerpool.Request[testkube.Test, testkube.ExecutionRequest, testkube.Execution], 0, len(work)*len(executionRequests))
 	for i := range work {
-		requests[i] = workerpool.Request[testkube.Test, testkube.ExecutionRequest, testkube.Execution]{
-			Object:  testsmapper.MapTestCRToAPI(work[i]),
-			Options: request,
-			ExecFn:  s.executeTest,
+		test := testsmapper.MapTestCRToAPI(work[i])
+		for _, request := range executionRequests {
+			requests = append(requests, workerpool.Request[testkube.Test, testkube.ExecutionRequest, testkube.Execution]{
+				Object:  test,
+				Options: request,
+				ExecFn:  s.executeTest,
+			})
 		}
 	}
 	return requests
@@ -149,12 +255,6 @@ func (s TestkubeAPI) executeTest(ctx context.Context, test testkube.Test, reques
 		request.Name = rand.Name()
 	}
 
-	// test name + test execution name should be unique
-	execution, _ = s.ExecutionResults.GetByNameAndTest(ctx, request.Name, test.Name)
-	if execution.Name == request.Name {
-		return execution.Err(fmt.Errorf("test execution with name %s already exists", request.Name)), nil
-	}
-
 	// merge available data into execution options test spec, executor spec, request, test id
 	options, err := s.GetExecuteOptions(request.Namespace, test.Name, request)
 	if err != nil {
@@ -162,26 +262,75 @@ func (s TestkubeAPI) executeTest(ctx context.Context, test testkube.Test, reques
 	}
 
 	// store execution in storage, can be get from API now
-	execution = newExecutionFromExecutionOptions(options)
+	return s.startExecution(ctx, options, newExecutionFromExecutionOptions(options))
+}
+
+// startExecution stores new execution and starts it in executor
+func (s TestkubeAPI) startExecution(ctx context.Context, options client.ExecuteOptions, execution testkube.Execution) (
+	testkube.Execution, error) {
 	options.ID = execution.Id
+	// request id is passed to executor, so API calls can be correlated with executor logs
+	execution.RequestId = server.RequestID(ctx)
+	log := s.Logger(ctx)
+
+	// policy violations and exceeded quotas are passed to handler, denied executions aren't stored
+	err := s.checkExecutionPolicies(ctx, options)
+	if isPolicyViolation(err) {
+		return execution.Err(err), err
+	}
+
+	if err != nil {
+		return execution.Errw("can't evaluate execution policies: %w", err), nil
+	}
+
+	err = s.checkQuotas(ctx, execution)
+	if quotaErrorStatus(err) != 0 {
+		return execution.Err(err), err
+	}
+
+	if err != nil {
+		return execution.Errw("can't check quotas: %w", err), nil
+	}
+
+	// execution numbers are incremented atomically so concurrent executions of a test get distinct numbers
+	execution.Number, err = s.ExecutionResults.GetNextExecutionNumber(ctx, options.TestName)
+	if err != nil {
+		return execution.Errw("can't assign execution number: %w", err), nil
+	}
 
+	// queued executions not started before shutdown are stored as aborted so they are not lost
+	draining := s.shutdown.isDraining()
+	if draining {
+		execution = newShutdownExecution(execution)
+	}
+
+	// test name + test execution name are unique in storage, duplicated name error is passed to handler
 	err = s.ExecutionResults.Insert(ctx, execution)
+	if result.IsDuplicateNameError(err) {
+		return execution.Err(fmt.Errorf("test execution with name %s already exists", options.Request.Name)), err
+	}
+
 	if err != nil {
 		return execution.Errw("can't create new test execution, can't insert into storage: %w", err), nil
 	}
 
-	s.Log.Infow("calling executor with options", "options", options.Request)
+	s.setContentChecksumAsync(execution)
+	if draining {
+		return execution, nil
+	}
+
+	log.Infow("calling executor with options", "options", options.Request)
 	execution.Start()
 
 	err = s.notifyEvents(testkube.WebhookTypeStartTest, execution)
 	if err != nil {
-		s.Log.Infow("Notify events", "error", err)
+		log.Infow("Notify events", "error", err)
 	}
 	err = s.ExecutionResults.StartExecution(ctx, execution.Id, execution.StartTime)
 	if err != nil {
 		err = s.notifyEvents(testkube.WebhookTypeEndTest, execution)
 		if err != nil {
-			s.Log.Infow("Notify events", "error", err)
+			log.Infow("Notify events", "error", err)
 		}
 		return execution.Errw("can't execute test, can't insert into storage error: %w", err), nil
 	}
@@ -191,7 +340,7 @@ func (s TestkubeAPI) executeTest(ctx context.Context, test testkube.Test, reques
 		if !errors.IsNotFound(err) {
 			err = s.notifyEvents(testkube.WebhookTypeEndTest, execution)
 			if err != nil {
-				s.Log.Infow("Notify events", "error", err)
+				log.Infow("Notify events", "error", err)
 			}
 			return execution.Errw("can't get secrets: %w", err), nil
 		}
@@ -199,6 +348,57 @@ func (s TestkubeAPI) executeTest(ctx context.Context, test testkube.Test, reques
 		options.HasSecrets = false
 	}
 
+	// async executions wait for test prechecks and queued dependencies in background, so request isn't blocked
+	// until they are met
+	queued := options.Dependencies != nil && options.Dependencies.IsQueued()
+	if (options.Prechecks != nil || queued) && !options.Sync {
+		ctx = server.WithRequestID(context.Background(), execution.RequestId)
+		go func() {
+			if _, err := s.launchExecution(ctx, options, execution); err != nil {
+				s.Logger(ctx).Errorw("launching execution after prechecks", "executionId", execution.Id, "error", err)
+			}
+		}()
+
+		return execution, nil
+	}
+
+	return s.launchExecution(ctx, options, execution)
+}
+
+// launchExecution runs started execution in executor, executions of tests with failing required tests or prechecks
+// not met within timeout are skipped
+func (s TestkubeAPI) launchExecution(ctx context.Context, options client.ExecuteOptions, execution testkube.Execution) (
+	testkube.Execution, error) {
+	log := s.Logger(ctx)
+	if options.Dependencies != nil {
+		if err := s.waitForDependencies(ctx, execution, *options.Dependencies); err != nil {
+			if aborted, ok := s.getAbortedExecution(ctx, execution.Id); ok {
+				return aborted, nil
+			}
+
+			return s.skipBlockedExecution(ctx, execution, testkube.ErrorTypeDependencyFailed, err), nil
+		}
+	}
+
+	if options.Prechecks != nil {
+		if err := s.waitForPrechecks(ctx, *options.Prechecks); err != nil {
+			if aborted, ok := s.getAbortedExecution(ctx, execution.Id); ok {
+				return aborted, nil
+			}
+
+			return s.skipBlockedExecution(ctx, execution, testkube.ErrorTypePrecheckFailed, err), nil
+		}
+	}
+
+	// executions aborted while waiting for prechecks or dependencies aren't launched
+	if options.Dependencies != nil || options.Prechecks != nil {
+		if aborted, ok := s.getAbortedExecution(ctx, execution.Id); ok {
+			log.Infow("test execution aborted before launch", "executionId", execution.Id)
+			return aborted, nil
+		}
+	}
+
+	var err error
 	var result testkube.ExecutionResult
 
 	// sync/async test execution
@@ -208,10 +408,19 @@ func (s TestkubeAPI) executeTest(ctx context.Context, test testkube.Test, reques
 		result, err = s.Executor.Execute(execution, options)
 	}
 
+	// test suite step interrupted by test suite abort is aborted, not failed
+	if isAborted(ctx) && (result.Status == nil || !result.IsPassed()) {
+		result.Status = testkube.ExecutionStatusAborted
+		err = nil
+	}
+
+	// sync results are returned and passed to test suites and notifiers, so they are redacted and fit inline size limit
+	result = s.outputs.inline(execution.Id, s.getRedactor(ctx, execution).Result(result))
+
 	if uerr := s.ExecutionResults.UpdateResult(ctx, execution.Id, result); uerr != nil {
 		err = s.notifyEvents(testkube.WebhookTypeEndTest, execution)
 		if err != nil {
-			s.Log.Infow("Notify events", "error", err)
+			log.Infow("Notify events", "error", err)
 		}
 		return execution.Errw("update execution error: %w", uerr), nil
 	}
@@ -225,36 +434,77 @@ func (s TestkubeAPI) executeTest(ctx context.Context, test testkube.Test, reques
 	if err != nil {
 		err = s.notifyEvents(testkube.WebhookTypeEndTest, execution)
 		if err != nil {
-			s.Log.Infow("Notify events", "error", err)
+			log.Infow("Notify events", "error", err)
 		}
 		return execution.Errw("test execution failed: %w", err), nil
 	}
 
-	s.Log.Infow("test executed", "executionId", execution.Id, "status", execution.ExecutionResult.Status)
+	log.Infow("test executed", "executionId", execution.Id, "status", execution.ExecutionResult.Status)
 	err = s.notifyEvents(testkube.WebhookTypeEndTest, execution)
 	if err != nil {
-		s.Log.Infow("Notify events", "error", err)
+		log.Infow("Notify events", "error", err)
 	}
 
 	return execution, nil
 }
 
 func (s TestkubeAPI) notifyEvents(eventType *testkube.WebhookEventType, execution testkube.Execution) error {
-	webhookList, err := s.WebhooksClient.GetByEvent(eventType.String())
-	if err != nil {
-		return err
+	// tests triggers are run by end events, so they run however test was executed
+	if *eventType == testkube.END_TEST_WebhookEventType {
+		s.triggerTestExecutions(execution)
+	}
+
+	settings := s.getServerSettings(context.Background())
+	execution = redactExecution(s.newRedactor(settings, execution), execution)
+	// end events in maintenance windows and quiet hours are held until windows end, start events aren't sent
+	now := time.Now()
+	heldUntil := s.notificationsHeldUntil(settings, execution.TestName, now)
+	if !heldUntil.IsZero() && *eventType != testkube.END_TEST_WebhookEventType {
+		return nil
+	}
+
+	// only end events are batched into digests
+	digesting := s.isDigesting(settings)
+	if digesting && *eventType != testkube.END_TEST_WebhookEventType {
+		return nil
 	}
 
-	for _, wh := range webhookList.Items {
-		s.Log.Debugw("Sending event", "uri", wh.Spec.Uri, "type", eventType, "execution", execution)
-		s.EventsEmitter.Notify(testkube.WebhookEvent{
-			Uri:       wh.Spec.Uri,
-			Type_:     eventType,
-			Execution: &execution,
-		})
+	if settings.WebhookNotifications {
+		webhookList, err := s.WebhooksClient.GetByEvent(eventType.String())
+		if err != nil {
+			return err
+		}
+
+		for _, wh := range webhookList.Items {
+			matches, err := webhook.Matches(webhooksmapper.MapCRDToAPI(wh), execution)
+			if err != nil {
+				s.Log.Warnw("skipping webhook with invalid filter", "webhook", wh.Name, "error", err)
+				continue
+			}
+
+			if !matches {
+				continue
+			}
+
+			if s.batchNotification(webhookDigestPrefix+wh.Name, execution, now, heldUntil, digesting) {
+				continue
+			}
+
+			event, err := s.newWebhookEvent(wh, eventType)
+			if err != nil {
+				s.Log.Warnw("skipping webhook with invalid signing or connection options", "webhook", wh.Name, "error", err)
+				continue
+			}
+
+			event.Execution = &execution
+			s.Log.Debugw("Sending event", "uri", wh.Spec.Uri, "type", eventType, "execution", execution)
+			s.EventsEmitter.Notify(event)
+		}
 	}
 
-	s.notifySlack(eventType, execution)
+	if settings.SlackNotifications && !s.batchNotification(slackDigestDestination, execution, now, heldUntil, digesting) {
+		s.notifySlack(eventType, execution)
+	}
 
 	return nil
 }
@@ -295,6 +545,15 @@ func (s TestkubeAPI) ListExecutionsHandler() fiber.Handler {
 			Results:  mapExecutionsToExecutionSummary(executions),
 		}
 
+		// archived executions are downloaded from object storage and matched in memory
+		if c.Query("archived") == "true" && s.archive != nil {
+			archived, err := s.archive.list(filter)
+			if err != nil {
+				return s.Error(c, http.StatusBadGateway, err)
+			}
+			results.Archived = mapExecutionsToExecutionSummary(archived)
+		}
+
 		return c.JSON(results)
 	}
 }
@@ -303,10 +562,22 @@ func (s TestkubeAPI) ExecutionLogsHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		executionID := c.Params("executionID")
 
-		s.Log.Debug("getting logs", "executionID", executionID)
+		s.Logger(c.Context()).Debug("getting logs", "executionID", executionID)
 
 		ctx := c.Context()
 
+		// execution is used for project check and redaction, logs are streamed from executor pods,
+		// so secret values are masked before sending them
+		execution, err := s.ExecutionResults.Get(ctx, executionID)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't get execution %s: %w", executionID, err))
+		}
+
+		if project := getProject(c); project != "" && (err != nil || execution.Project != project) {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("execution %s not found", executionID)))
+		}
+		redactor := s.getRedactor(ctx, execution)
+
 		ctx.SetContentType("text/event-stream")
 		ctx.Response.Header.Set("Cache-Control", "no-cache")
 		ctx.Response.Header.Set("Connection", "keep-alive")
@@ -322,7 +593,12 @@ func (s TestkubeAPI) ExecutionLogsHandler() fiber.Handler {
 			var logs chan output.Output
 			var err error
 
-			logs, err = s.Executor.Logs(executionID)
+			// whole output over inline size limit is streamed from storage, as pod logs can be gone already
+			if isOutputOverflowed(execution) {
+				logs = s.storedOutputLogs(execution)
+			} else {
+				logs, err = s.Executor.Logs(executionID)
+			}
 			s.Log.Debugw("waiting for jobs channel", "channelSize", len(logs))
 			if err != nil {
 				output.PrintError(err)
@@ -334,6 +610,7 @@ func (s TestkubeAPI) ExecutionLogsHandler() fiber.Handler {
 			// loop through pods log lines - it's blocking channel
 			// and pass single log output as sse data chunk
 			for out := range logs {
+				out = redactor.Output(out)
 				s.Log.Debugw("got log", "out", out)
 				fmt.Fprintf(w, "data: ")
 				err = enc.Encode(out)
@@ -350,49 +627,144 @@ func (s TestkubeAPI) ExecutionLogsHandler() fiber.Handler {
 	}
 }
 
-// GetExecutionHandler returns test execution object for given test and execution id
+// GetExecutionHandler returns test execution object for given test and execution id, name or number
 func (s TestkubeAPI) GetExecutionHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		ctx := c.Context()
 		id := c.Params("id", "")
 		executionID := c.Params("executionID")
+		number := c.Params("number")
 
 		var execution testkube.Execution
 		var err error
 
-		if id == "" {
+		switch {
+		case number != "":
+			var n int64
+			n, err = strconv.ParseInt(number, 10, 32)
+			if err != nil || n <= 0 {
+				return s.Error(c, http.StatusBadRequest, fmt.Errorf("invalid execution number %s", number))
+			}
+
+			executionID = "#" + number
+			execution, err = s.ExecutionResults.GetByNumberAndTest(ctx, int32(n), id)
+			if err == mongo.ErrNoDocuments {
+				return s.Error(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("test %s execution #%s not found", id, number)))
+			}
+			if err != nil {
+				return s.Error(c, http.StatusInternalServerError, err)
+			}
+		case id == "":
 			execution, err = s.ExecutionResults.Get(ctx, executionID)
 			if err == mongo.ErrNoDocuments {
-				return s.Error(c, http.StatusNotFound, fmt.Errorf("test with execution id %s not found", executionID))
+				return s.Error(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("test with execution id %s not found", executionID)))
 			}
 			if err != nil {
 				return s.Error(c, http.StatusInternalServerError, err)
 			}
-		} else {
+		default:
 			execution, err = s.ExecutionResults.GetByNameAndTest(ctx, executionID, id)
 			if err == mongo.ErrNoDocuments {
-				return s.Error(c, http.StatusNotFound, fmt.Errorf("test %s/%s not found", id, executionID))
+				return s.Error(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("test %s/%s not found", id, executionID)))
 			}
 			if err != nil {
 				return s.Error(c, http.StatusInternalServerError, err)
 			}
 		}
 
+		if project := getProject(c); project != "" && execution.Project != project {
+			return s.Error(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("execution %s not found", executionID)))
+		}
+
 		execution.Duration = types.FormatDuration(execution.Duration)
+		s.loadOutput(&execution)
+		execution = redactExecution(s.getRedactor(ctx, execution), execution)
 
-		s.Log.Debugw("get test execution request - debug", "execution", execution)
+		s.Logger(c.Context()).Debugw("get test execution request - debug", "execution", execution)
 
 		return c.JSON(execution)
 	}
 }
 
-func (s TestkubeAPI) AbortExecutionHandler() fiber.Handler {
+// GetLatestExecutionHandler returns most recent execution of given test, only executions with given status are
+// considered when status is set
+func (s TestkubeAPI) GetLatestExecutionHandler(status *testkube.ExecutionStatus) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
 		id := c.Params("id")
-		return s.Executor.Abort(id)
+
+		var execution testkube.Execution
+		var err error
+		if status == nil {
+			execution, err = s.ExecutionResults.GetLatestByTest(ctx, id)
+		} else {
+			execution, err = s.ExecutionResults.GetLatestByTestAndStatus(ctx, id, *status)
+		}
+
+		if err == mongo.ErrNoDocuments {
+			return s.Error(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("latest execution of test %s not found", id)))
+		}
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		if project := getProject(c); project != "" && execution.Project != project {
+			return s.Error(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("latest execution of test %s not found", id)))
+		}
+
+		execution.Duration = types.FormatDuration(execution.Duration)
+		execution = redactExecution(s.getRedactor(ctx, execution), execution)
+
+		return c.JSON(execution)
+	}
+}
+
+func (s TestkubeAPI) AbortExecutionHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id := c.Params("executionID")
+
+		// executions of other projects and tests are not found, so callers can only abort executions they can read
+		execution, err := s.getProjectExecution(c, id)
+		if err == nil && execution.TestName != c.Params("id") {
+			err = problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("execution %s of test %s not found", id, c.Params("id")))
+		}
+
+		if problem.CodeOf(err, 0) == problem.CodeExecutionNotFound {
+			return s.Warn(c, http.StatusNotFound, err)
+		}
+
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		if err = s.abortExecution(ctx, execution); err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		return nil
 	}
 }
 
+// abortExecution aborts test execution in executor and stores its aborted status
+func (s TestkubeAPI) abortExecution(ctx context.Context, execution testkube.Execution) error {
+	if err := s.Executor.Abort(execution.Id); err != nil {
+		return fmt.Errorf("can't abort test execution %s: %w", execution.Id, err)
+	}
+
+	result := testkube.ExecutionResult{}
+	if execution.ExecutionResult != nil {
+		result = *execution.ExecutionResult
+	}
+
+	result.Status = testkube.ExecutionStatusAborted
+	if err := s.ExecutionResults.UpdateResult(ctx, execution.Id, result); err != nil {
+		return fmt.Errorf("can't update test execution %s result: %w", execution.Id, err)
+	}
+
+	return nil
+}
+
 func (s TestkubeAPI) GetArtifactHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		executionID := c.Params("executionID")
@@ -411,12 +783,49 @@ func (s TestkubeAPI) GetArtifactHandler() fiber.Handler {
 
 		//// quickfix end
 
-		file, err := s.Storage.DownloadFile(executionID, fileName)
+		execution, err := s.getProjectExecution(c, executionID)
+		if problem.CodeOf(err, 0) == problem.CodeExecutionNotFound {
+			return s.Warn(c, http.StatusNotFound, err)
+		}
+
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		artifacts := s.artifacts(execution)
+		if s.ArtifactScanner != nil {
+			// artifacts downloaded without being listed are scanned too
+			scans := execution.ArtifactScans
+			if len(scans) == 0 {
+				if files, err := artifacts.List(); err == nil {
+					scans = s.scanArtifacts(c.Context(), execution, files)
+				}
+			}
+
+			if status := artifactScanStatus(scans, fileName); !status.IsDownloadable() {
+				// pending artifacts can be downloaded once scanned, quarantined and failed ones can't
+				code := http.StatusForbidden
+				if *status == testkube.PENDING_ArtifactScanStatus {
+					code = http.StatusConflict
+				}
+
+				return s.Warn(c, code, fmt.Errorf("artifact %s can't be downloaded, scan status is %s", fileName, *status))
+			}
+		}
+
+		file, err := artifacts.Download(fileName)
 		if err != nil {
 			return s.Error(c, http.StatusInternalServerError, err)
 		}
 		defer file.Close()
 
+		// consumers can detect truncated or tampered downloads
+		if info, err := file.Stat(); err == nil {
+			if digest := artifactDigest(minio.Sha256Checksum(info.UserMetadata)); digest != "" {
+				c.Set(artifactDigestHeader, digest)
+			}
+		}
+
 		return c.SendStream(file)
 	}
 }
@@ -426,15 +835,182 @@ func (s TestkubeAPI) ListArtifactsHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 
 		executionID := c.Params("executionID")
-		files, err := s.Storage.ListFiles(executionID)
+		execution, err := s.getProjectExecution(c, executionID)
+		if problem.CodeOf(err, 0) == problem.CodeExecutionNotFound {
+			return s.Warn(c, http.StatusNotFound, err)
+		}
+
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		artifacts := s.artifacts(execution)
+		files, err := artifacts.List()
 		if err != nil {
 			return s.Error(c, http.StatusInternalServerError, err)
 		}
 
+		if s.ArtifactScanner != nil {
+			applyArtifactScans(files, s.scanArtifacts(c.Context(), execution, files))
+		}
+
+		if c.Query("presigned") == "true" {
+			for i := range files {
+				if !files[i].ScanStatus.IsDownloadable() {
+					continue
+				}
+
+				files[i].DownloadUrl, err = artifacts.PresignDownload(files[i].Name, presignedURLExpiration)
+				if err != nil {
+					s.Logger(c.Context()).Warnw("presigning artifact download URL", "executionID", executionID, "file", files[i].Name, "error", err)
+				}
+			}
+		}
+
 		return c.JSON(files)
 	}
 }
 
+// UploadArtifactHandler stores file uploaded in multipart file field as execution artifact, uploads not fitting into
+// artifact storage quotas of execution are rejected
+func (s TestkubeAPI) UploadArtifactHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		executionID := c.Params("executionID")
+		if s.storageParams.Endpoint == "" {
+			return s.Warn(c, http.StatusNotImplemented, fmt.Errorf("artifacts storage isn't configured"))
+		}
+
+		file, err := c.FormFile("file")
+		if err != nil {
+			return s.Warn(c, http.StatusBadRequest, fmt.Errorf("artifact file is required: %w", err))
+		}
+
+		name := filepath.Base(file.Filename)
+		if name == "." || name == "/" || name == ".." {
+			return s.Warn(c, http.StatusBadRequest, fmt.Errorf("invalid artifact name %q", file.Filename))
+		}
+
+		execution, err := s.getProjectExecution(c, executionID)
+		if problem.CodeOf(err, 0) == problem.CodeExecutionNotFound {
+			return s.Warn(c, http.StatusNotFound, err)
+		}
+
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		if err = s.checkArtifactsQuota(ctx, execution, file.Size); err != nil {
+			if status := quotaErrorStatus(err); status != 0 {
+				return s.Warn(c, status, err)
+			}
+
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		reader, err := file.Open()
+		if err != nil {
+			return s.Error(c, http.StatusBadRequest, fmt.Errorf("can't read artifact file: %w", err))
+		}
+		defer reader.Close()
+
+		if err = s.artifacts(execution).Upload(name, reader, file.Size); err != nil {
+			return s.Error(c, http.StatusBadGateway, fmt.Errorf("can't upload artifact %s of execution %s: %w", name, executionID, err))
+		}
+
+		s.addArtifactsUsage(ctx, execution, file.Size)
+		c.Status(http.StatusCreated)
+		return c.JSON(testkube.Artifact{Name: name, Size: int32(file.Size)})
+	}
+}
+
+// GetExecutionPodHandler returns execution job and pod state with recent kubernetes events
+func (s TestkubeAPI) GetExecutionPodHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		executionID := c.Params("executionID")
+		if err := s.checkExecutionProject(c, executionID); err != nil {
+			return s.Warn(c, http.StatusNotFound, err)
+		}
+
+		diagnostics, err := s.Executor.Diagnostics(executionID)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return s.Warn(c, http.StatusNotFound, fmt.Errorf("job for execution %s not found: %w", executionID, err))
+			}
+
+			return s.Error(c, http.StatusBadGateway, err)
+		}
+
+		return c.JSON(diagnostics)
+	}
+}
+
+// artifactDigest returns RFC 3230 digest header value of hex SHA-256 checksum, empty for invalid checksums
+func artifactDigest(checksum string) string {
+	sum, err := hex.DecodeString(checksum)
+	if err != nil || len(sum) != sha256.Size {
+		return ""
+	}
+
+	return "sha-256=" + base64.StdEncoding.EncodeToString(sum)
+}
+
+// getProjectExecution gets execution of request project, error with execution not found code is returned
+// for missing executions and executions of other projects
+func (s TestkubeAPI) getProjectExecution(c *fiber.Ctx, executionID string) (testkube.Execution, error) {
+	execution, err := s.ExecutionResults.Get(c.Context(), executionID)
+	if project := getProject(c); err == mongo.ErrNoDocuments || (err == nil && project != "" && execution.Project != project) {
+		return execution, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("execution %s not found", executionID))
+	}
+
+	if err != nil {
+		return execution, fmt.Errorf("can't get test execution %s: %w", executionID, err)
+	}
+
+	return execution, nil
+}
+
+// artifacts returns artifacts storage of execution, project executions store artifacts in project bucket
+func (s TestkubeAPI) artifacts(execution testkube.Execution) storage.Artifacts {
+	return storage.NewArtifacts(s.Storage, execution.Id, execution.Project)
+}
+
+// checkExecutionProject checks if execution belongs to request project
+func (s TestkubeAPI) checkExecutionProject(c *fiber.Ctx, executionID string) error {
+	project := getProject(c)
+	if project == "" {
+		return nil
+	}
+
+	execution, err := s.ExecutionResults.Get(c.Context(), executionID)
+	if err != nil || execution.Project != project {
+		return problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("execution %s not found", executionID))
+	}
+
+	return nil
+}
+
+// getExecutorByType returns executor handling test type, error with executor missing code is returned
+// when no executor handles it
+func (s TestkubeAPI) getExecutorByType(testType string) (*executorv1.Executor, error) {
+	executor, err := s.ExecutorsClient.GetByType(testType)
+	// failed listing of executors returns kubernetes API status error
+	if _, ok := err.(errors.APIStatus); err != nil && !ok {
+		return nil, problem.WithCode(problem.CodeExecutorMissing, err)
+	}
+
+	return executor, err
+}
+
+// executorErrorStatus returns response status of executor lookup error
+func executorErrorStatus(err error) int {
+	if problem.CodeOf(err, http.StatusInternalServerError) == problem.CodeExecutorMissing {
+		return http.StatusUnprocessableEntity
+	}
+
+	return http.StatusInternalServerError
+}
+
 func (s TestkubeAPI) GetExecuteOptions(namespace, id string, request testkube.ExecutionRequest) (options client.ExecuteOptions, err error) {
 	// get test content from kubernetes CRs
 	testCR, err := s.TestsClient.Get(id)
@@ -442,27 +1018,107 @@ func (s TestkubeAPI) GetExecuteOptions(namespace, id string, request testkube.Ex
 		return options, fmt.Errorf("can't get test custom resource %w", err)
 	}
 
-	// Test params lowest priority, then test suite, then test suite execution / test execution
+	// Test params lowest priority, then test suite, then test suite step, then previous steps output variables,
+	// then test suite execution / test execution,
+	// test suite params are merged into request params before, see mergeStepParams
 	request.Params = mergeParams(testCR.Spec.Params, request.Params)
 
+	// newman params override newman options, they're removed from params passed to postman environment
+	newman, params, err := testkube.NewmanOptionsFromParams(
+		testkube.MergeNewmanOptions(testkube.NewmanOptionsFromAnnotations(testCR.Annotations), request.Newman), request.Params)
+	if err != nil {
+		return options, err
+	}
+	request.Params = params
+
+	secretParams := mergeSecretParams(testsmapper.MapSecretParamsFromAnnotations(testCR.Annotations), request.SecretParams)
+	if newman != nil && newman.SslClientCert != nil && newman.SslClientCert.PassphraseKey != "" {
+		passphrase, err := s.getNewmanSslClientPassphrase(*newman.SslClientCert)
+		if err != nil {
+			return options, err
+		}
+
+		if request.Params == nil {
+			request.Params = map[string]string{}
+		}
+		request.Params[testkube.NewmanSslClientPassphraseParam] = passphrase
+		secretParams = mergeSecretParams(secretParams, []string{testkube.NewmanSslClientPassphraseParam})
+	}
+
 	// get executor from kubernetes CRs
-	executorCR, err := s.ExecutorsClient.GetByType(testCR.Spec.Type_)
+	executorCR, err := s.getExecutorByType(testCR.Spec.Type_)
 	if err != nil {
 		return options, fmt.Errorf("can't get executor spec: %w", err)
 	}
 
+	// executor image is pinned to requested digest, so upgraded executor custom resource doesn't change it
+	if request.ExecutorImageDigest != "" {
+		executorCR.Spec.Image = jobs.ImageWithDigest(executorCR.Spec.Image, request.ExecutorImageDigest)
+	}
+
+	// executor placement applies to all tests of its types, test placement narrows it
+	placement, err := testkube.MergePlacements(testkube.PlacementFromAnnotations(executorCR.Annotations),
+		testkube.PlacementFromAnnotations(testCR.Annotations))
+	if err != nil {
+		return options, fmt.Errorf("can't merge executor %s and test placement: %w", executorCR.Name, err)
+	}
+
 	return client.ExecuteOptions{
-		TestName:     id,
-		Namespace:    namespace,
-		TestSpec:     testCR.Spec,
-		ExecutorName: executorCR.ObjectMeta.Name,
-		ExecutorSpec: executorCR.Spec,
-		Request:      request,
-		Sync:         request.Sync,
-		Labels:       testCR.Labels,
+		TestName:          id,
+		Namespace:         namespace,
+		TestSpec:          testCR.Spec,
+		ExecutorName:      executorCR.ObjectMeta.Name,
+		ExecutorSpec:      executorCR.Spec,
+		ExecutorLabels:    executorCR.Labels,
+		Request:           request,
+		Sync:              request.Sync,
+		Labels:            mergeLabels(testCR.Labels, request.Labels),
+		SecretMounts:      append(testsmapper.MapSecretMountsFromAnnotations(testCR.Annotations), newman.SecretMounts()...),
+		DataFile:          testsmapper.MapDataFileFromAnnotations(testCR.Annotations),
+		SecretParams:      secretParams,
+		Ownership:         testkube.OwnershipFromAnnotations(testCR.Annotations),
+		ContentUriOptions: testsmapper.MapContentUriOptionsFromAnnotations(testCR.Annotations),
+		Placement:         placement,
+		LogCollection:     testkube.LogCollectionFromAnnotations(testCR.Annotations),
+		Prechecks:         testkube.PrechecksFromAnnotations(testCR.Annotations),
+		Dependencies:      testkube.DependenciesFromAnnotations(testCR.Annotations),
+		Newman:            newman,
 	}, nil
 }
 
+// getNewmanSslClientPassphrase reads SSL client private key passphrase from certificate secret
+func (s TestkubeAPI) getNewmanSslClientPassphrase(cert testkube.NewmanSslClientCert) (string, error) {
+	if s.SecretClient == nil {
+		return "", fmt.Errorf("can't read newman SSL client passphrase: secrets client is not set")
+	}
+
+	data, err := s.SecretClient.Get(cert.SecretName)
+	if err != nil {
+		return "", fmt.Errorf("can't read newman SSL client passphrase from secret %s: %w", cert.SecretName, err)
+	}
+
+	passphrase, ok := data[cert.PassphraseKey]
+	if !ok {
+		return "", fmt.Errorf("newman SSL client secret %s has no %s key", cert.SecretName, cert.PassphraseKey)
+	}
+
+	return strings.TrimSpace(passphrase), nil
+}
+
+// mergeLabels returns test labels overridden by execution request labels, test labels are left untouched
+func mergeLabels(labels map[string]string, appendLabels map[string]string) map[string]string {
+	result := make(map[string]string, len(labels)+len(appendLabels))
+	for k, v := range labels {
+		result[k] = v
+	}
+
+	for k, v := range appendLabels {
+		result[k] = v
+	}
+
+	return result
+}
+
 func mergeParams(params map[string]string, appendParams map[string]string) map[string]string {
 	if params == nil {
 		params = map[string]string{}
@@ -487,8 +1143,21 @@ func newExecutionFromExecutionOptions(options client.ExecuteOptions) testkube.Ex
 		options.Labels,
 	)
 
+	if execution.Content != nil {
+		execution.Content.UriOptions = options.ContentUriOptions
+	}
+
 	execution.Args = options.Request.Args
+	execution.DataFile = options.DataFile
+	execution.Newman = options.Newman
+	execution.SecretParams = options.SecretParams
 	execution.ParamsFile = options.Request.ParamsFile
+	execution.Files = options.Request.Files
+	execution.Project = testkube.GetProject(options.Labels)
+	execution.GroupId = testkube.GetExecutionGroup(options.Labels)
+	execution.Ownership = options.Ownership
+	execution.RunningContext = options.Request.RunningContext
+	execution.ExecutorImage = options.ExecutorSpec.Image
 
 	return execution
 }
@@ -498,15 +1167,19 @@ func mapExecutionsToExecutionSummary(executions []testkube.Execution) []testkube
 
 	for i, execution := range executions {
 		result[i] = testkube.ExecutionSummary{
-			Id:        execution.Id,
-			Name:      execution.Name,
-			TestName:  execution.TestName,
-			TestType:  execution.TestType,
-			Status:    execution.ExecutionResult.Status,
-			StartTime: execution.StartTime,
-			EndTime:   execution.EndTime,
-			Duration:  types.FormatDuration(execution.Duration),
-			Labels:    execution.Labels,
+			Id:              execution.Id,
+			Name:            execution.Name,
+			Number:          execution.Number,
+			TestName:        execution.TestName,
+			TestType:        execution.TestType,
+			Status:          execution.ExecutionResult.Status,
+			StartTime:       execution.StartTime,
+			EndTime:         execution.EndTime,
+			Duration:        types.FormatDuration(execution.Duration),
+			Labels:          execution.Labels,
+			RunningContext:  execution.RunningContext,
+			ContentChecksum: execution.ContentChecksum,
+			ContentChanged:  execution.ContentChanged,
 		}
 	}
 
@@ -1,9 +1,14 @@
 package v1
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/server"
 )
 
 func TestParamsNilAssign(t *testing.T) {
@@ -28,3 +33,56 @@ func TestParamsNilAssign(t *testing.T) {
 	})
 
 }
+
+func TestMergeLabels(t *testing.T) {
+
+	t.Run("request labels override test labels", func(t *testing.T) {
+
+		testLabels := map[string]string{"app": "backend", "env": "dev"}
+		requestLabels := map[string]string{"env": "prod", "team": "payments"}
+
+		out := mergeLabels(testLabels, requestLabels)
+
+		assert.Equal(t, map[string]string{"app": "backend", "env": "prod", "team": "payments"}, out)
+		assert.Equal(t, map[string]string{"app": "backend", "env": "dev"}, testLabels)
+	})
+
+	t.Run("merge with nil maps", func(t *testing.T) {
+
+		out := mergeLabels(nil, nil)
+
+		assert.Equal(t, map[string]string{}, out)
+	})
+}
+
+func TestArtifactDigest(t *testing.T) {
+	assert.Equal(t, "sha-256=47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=", artifactDigest("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"))
+	assert.Empty(t, artifactDigest(""))
+	assert.Empty(t, artifactDigest("d41d8cd98f00b204e9800998ecf8427e"), "MD5 checksums aren't digests")
+}
+
+func TestAbortExecutionHandlerNotFound(t *testing.T) {
+	// executor isn't set, so aborting found execution fails the test
+	s := TestkubeAPI{
+		HTTPServer: server.NewServer(server.Config{}),
+		ExecutionResults: &storedResults{executions: map[string]testkube.Execution{
+			"execution-1": {Id: "execution-1", TestName: "api", Project: "team-a"},
+		}},
+	}
+	s.Mux.Use(s.ProjectMiddleware())
+	s.Mux.Delete("/tests/:id/executions/:executionID", s.AbortExecutionHandler())
+
+	for name, request := range map[string]struct{ path, project string }{
+		"execution of other project": {path: "/tests/api/executions/execution-1", project: "team-b"},
+		"execution of other test":    {path: "/tests/ui/executions/execution-1", project: "team-a"},
+		"missing execution":          {path: "/tests/api/executions/execution-2", project: "team-a"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, request.path, nil)
+			req.Header.Set(ProjectHeader, request.project)
+			resp, err := s.Mux.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		})
+	}
+}
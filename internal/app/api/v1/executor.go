@@ -6,6 +6,9 @@ import (
 	"github.com/gofiber/fiber/v2"
 	executorv1 "github.com/kubeshop/testkube-operator/apis/executor/v1"
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/jobs"
+	"github.com/kubeshop/testkube/pkg/problem"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -17,10 +20,18 @@ func (s TestkubeAPI) CreateExecutorHandler() fiber.Handler {
 			return s.Error(c, http.StatusBadRequest, err)
 		}
 
+		if err = request.Placement.Validate(); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
 		executor := mapExecutorCreateRequestToExecutorCRD(request)
 		if executor.Spec.JobTemplate == "" {
 			executor.Spec.JobTemplate = s.jobTemplates.Job
 		}
+
+		if err = jobs.ValidateTemplatePlacement(executor.Spec.JobTemplate, request.Placement); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
 		executor.Namespace = s.Namespace
 
 		created, err := s.ExecutorsClient.Create(&executor)
@@ -53,6 +64,10 @@ func (s TestkubeAPI) GetExecutorHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		name := c.Params("name")
 		item, err := s.ExecutorsClient.Get(name)
+		if errors.IsNotFound(err) {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutorNotFound, err))
+		}
+
 		if err != nil {
 			return s.Error(c, http.StatusBadRequest, err)
 		}
@@ -67,6 +82,10 @@ func (s TestkubeAPI) DeleteExecutorHandler() fiber.Handler {
 		name := c.Params("name")
 
 		err := s.ExecutorsClient.Delete(name)
+		if errors.IsNotFound(err) {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutorNotFound, err))
+		}
+
 		if err != nil {
 			return s.Error(c, http.StatusBadRequest, err)
 		}
@@ -98,6 +117,7 @@ func mapExecutorCRDToExecutorDetails(item executorv1.Executor) testkube.Executor
 			Uri:          item.Spec.URI,
 			JobTemplate:  item.Spec.JobTemplate,
 			Labels:       item.Labels,
+			Placement:    testkube.PlacementFromAnnotations(item.Annotations),
 		},
 	}
 }
@@ -105,9 +125,10 @@ func mapExecutorCRDToExecutorDetails(item executorv1.Executor) testkube.Executor
 func mapExecutorCreateRequestToExecutorCRD(request testkube.ExecutorCreateRequest) executorv1.Executor {
 	return executorv1.Executor{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      request.Name,
-			Namespace: request.Namespace,
-			Labels:    request.Labels,
+			Name:        request.Name,
+			Namespace:   request.Namespace,
+			Labels:      request.Labels,
+			Annotations: testkube.PlacementAnnotations(request.Placement),
 		},
 		Spec: executorv1.ExecutorSpec{
 			ExecutorType: request.ExecutorType,
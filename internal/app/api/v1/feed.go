@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/feed"
+)
+
+// feedPageSize is a default number of executions in the feed
+const feedPageSize = 50
+
+// ExecutionsFeedHandler returns Atom feed of recent executions, failed executions are returned when status isn't set,
+// other filters are the same as for executions list
+func (s TestkubeAPI) ExecutionsFeedHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		query := c.Request().URI().QueryArgs()
+		if c.Query("status") == "" {
+			query.Set("status", string(testkube.FAILED_ExecutionStatus))
+		}
+		if c.Query("pageSize") == "" {
+			query.Set("pageSize", strconv.Itoa(feedPageSize))
+		}
+
+		executions, err := s.ExecutionResults.GetExecutions(c.Context(), getFilterFromRequest(c))
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't get executions for feed: %w", err))
+		}
+
+		apiURL := c.BaseURL() + "/v1"
+		title := fmt.Sprintf("Testkube %s executions", c.Query("status"))
+		out, err := feed.NewExecutionsFeed(title, apiURL, c.BaseURL()+c.OriginalURL(), executions, time.Now()).Marshal()
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't render executions feed: %w", err))
+		}
+
+		c.Set(fiber.HeaderContentType, feed.ContentType)
+		return c.Send(out)
+	}
+}
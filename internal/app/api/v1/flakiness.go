@@ -0,0 +1,149 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	testsv2 "github.com/kubeshop/testkube-operator/apis/tests/v2"
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// flakinessConfig is a flaky tests analyzer configuration
+type flakinessConfig struct {
+	// Window is a number of recent finished executions used for flakiness calculation
+	Window int `default:"20"`
+	// MinExecutions is a minimal number of finished executions required to mark test as flaky
+	MinExecutions int `default:"5"`
+	// Threshold is a flakiness rate above which test is quarantined
+	Threshold float64 `default:"0.3"`
+	// AutoQuarantine enables background analyzer labeling flaky tests as quarantined
+	AutoQuarantine bool
+	// Interval is a background analyzer run interval
+	Interval time.Duration `default:"1h"`
+}
+
+// calculateFlakiness returns rate of passed/failed status changes between consecutive finished executions
+func calculateFlakiness(executions []testkube.Execution) float64 {
+	var statuses []testkube.ExecutionStatus
+	for _, execution := range executions {
+		if execution.ExecutionResult != nil && execution.ExecutionResult.Status != nil &&
+			(execution.ExecutionResult.IsPassed() || execution.ExecutionResult.IsFailed()) {
+			statuses = append(statuses, *execution.ExecutionResult.Status)
+		}
+	}
+
+	if len(statuses) < 2 {
+		return 0
+	}
+
+	changes := 0
+	for i := 1; i < len(statuses); i++ {
+		if statuses[i] != statuses[i-1] {
+			changes++
+		}
+	}
+
+	return float64(changes) / float64(len(statuses)-1)
+}
+
+func (s TestkubeAPI) getTestFlakiness(ctx context.Context, test testsv2.Test) (testkube.TestFlakiness, error) {
+	filter := result.NewExecutionsFilter().
+		WithTestName(test.Name).
+		WithStatus(string(testkube.PASSED_ExecutionStatus) + "," + string(testkube.FAILED_ExecutionStatus)).
+		WithPageSize(s.flakinessConfig.Window).
+		WithExcludedFields(result.SummaryExcludedFields())
+
+	executions, err := s.ExecutionResults.GetExecutions(ctx, filter)
+	if err != nil {
+		return testkube.TestFlakiness{}, err
+	}
+
+	return testkube.TestFlakiness{
+		TestName:    test.Name,
+		Flakiness:   calculateFlakiness(executions),
+		Quarantined: testkube.IsQuarantined(test.Labels),
+		Executions:  mapExecutionsToExecutionSummary(executions),
+	}, nil
+}
+
+// ListFlakyTestsHandler lists tests flakiness and quarantine state with recent executions as evidence
+func (s TestkubeAPI) ListFlakyTestsHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tests, err := s.TestsClient.List(projectSelector(c.Query("selector"), getProject(c)))
+		if err != nil {
+			return s.Error(c, http.StatusBadGateway, err)
+		}
+
+		report := []testkube.TestFlakiness{}
+		for _, test := range tests.Items {
+			flakiness, err := s.getTestFlakiness(c.Context(), test)
+			if err != nil {
+				return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't get executions for test %s: %w", test.Name, err))
+			}
+
+			report = append(report, flakiness)
+		}
+
+		return c.JSON(report)
+	}
+}
+
+// RunFlakinessAnalyzer periodically labels flaky tests as quarantined, only leader instance analyzes tests
+func (s TestkubeAPI) RunFlakinessAnalyzer(ctx context.Context) {
+	ticker := time.NewTicker(s.flakinessConfig.Interval)
+	defer ticker.Stop()
+
+	for {
+		if s.isLeader() {
+			s.analyzeFlakiness(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s TestkubeAPI) analyzeFlakiness(ctx context.Context) {
+	tests, err := s.TestsClient.List("")
+	if err != nil {
+		s.Log.Errorw("listing tests for flakiness analysis", "error", err)
+		return
+	}
+
+	for i := range tests.Items {
+		test := tests.Items[i]
+		if testkube.IsQuarantined(test.Labels) {
+			continue
+		}
+
+		flakiness, err := s.getTestFlakiness(ctx, test)
+		if err != nil {
+			s.Log.Errorw("getting test executions for flakiness analysis", "test", test.Name, "error", err)
+			continue
+		}
+
+		if len(flakiness.Executions) < s.flakinessConfig.MinExecutions || flakiness.Flakiness <= s.flakinessConfig.Threshold {
+			continue
+		}
+
+		if test.Labels == nil {
+			test.Labels = map[string]string{}
+		}
+		test.Labels[testkube.QuarantineLabel] = "true"
+
+		if _, err = s.TestsClient.Update(&test); err != nil {
+			s.Log.Errorw("quarantining flaky test", "test", test.Name, "error", err)
+			continue
+		}
+
+		s.Log.Infow("flaky test quarantined", "test", test.Name, "flakiness", flakiness.Flakiness)
+	}
+}
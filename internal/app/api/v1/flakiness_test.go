@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestCalculateFlakiness(t *testing.T) {
+
+	executions := func(statuses ...testkube.ExecutionStatus) (out []testkube.Execution) {
+		for _, status := range statuses {
+			out = append(out, testkube.Execution{ExecutionResult: &testkube.ExecutionResult{Status: testkube.StatusPtr(status)}})
+		}
+		return out
+	}
+
+	passed := testkube.PASSED_ExecutionStatus
+	failed := testkube.FAILED_ExecutionStatus
+	running := testkube.RUNNING_ExecutionStatus
+
+	t.Run("stable test", func(t *testing.T) {
+		assert.Equal(t, 0.0, calculateFlakiness(executions(passed, passed, passed)))
+	})
+
+	t.Run("alternating test", func(t *testing.T) {
+		assert.Equal(t, 1.0, calculateFlakiness(executions(passed, failed, passed, failed)))
+	})
+
+	t.Run("partially flaky test skips unfinished executions", func(t *testing.T) {
+		assert.Equal(t, 0.25, calculateFlakiness(executions(running, passed, passed, failed, failed, failed)))
+	})
+
+	t.Run("not enough executions", func(t *testing.T) {
+		assert.Equal(t, 0.0, calculateFlakiness(executions(failed)))
+	})
+}
@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// healthCheck is a named dependency check
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// runHealthCheck runs check and measures its latency
+func runHealthCheck(ctx context.Context, check healthCheck) testkube.HealthCheck {
+	start := time.Now()
+	err := check.check(ctx)
+
+	result := testkube.HealthCheck{
+		Name:    check.name,
+		Status:  testkube.HealthStatusOK,
+		Latency: time.Since(start).Round(time.Microsecond).String(),
+	}
+
+	if err != nil {
+		result.Status = testkube.HealthStatusFailed
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// healthChecks returns checks of configured dependencies, storage isn't checked when artifacts storage isn't configured
+func (s TestkubeAPI) healthChecks() []healthCheck {
+	checks := []healthCheck{
+		{name: "mongo", check: func(ctx context.Context) error {
+			if s.ConfigRepository == nil {
+				return fmt.Errorf("config repository is not configured")
+			}
+
+			_, err := s.ConfigRepository.Get(ctx)
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil
+			}
+			return err
+		}},
+	}
+
+	if s.storageParams.Endpoint != "" {
+		checks = append(checks, healthCheck{name: "storage", check: func(ctx context.Context) error {
+			_, err := s.Storage.ListBuckets()
+			return err
+		}})
+	}
+
+	return append(checks, []healthCheck{
+		{name: "kubernetes", check: func(ctx context.Context) error {
+			_, err := s.ExecutorsClient.List("")
+			return err
+		}},
+		{name: "events-emitter", check: func(ctx context.Context) error {
+			if len(s.EventsEmitter.Events) == cap(s.EventsEmitter.Events) {
+				return fmt.Errorf("events queue is full")
+			}
+			return nil
+		}},
+	}...)
+}
+
+// getHealthReport runs all dependency checks
+func (s TestkubeAPI) getHealthReport(ctx context.Context) testkube.HealthReport {
+	report := testkube.HealthReport{
+		Status:              testkube.HealthStatusOK,
+		Checks:              []testkube.HealthCheck{},
+		EventsQueueDepth:    int32(len(s.EventsEmitter.Events)),
+		EventsQueueCapacity: int32(cap(s.EventsEmitter.Events)),
+	}
+
+	for _, check := range s.healthChecks() {
+		result := runHealthCheck(ctx, check)
+		if result.Status != testkube.HealthStatusOK {
+			report.Status = testkube.HealthStatusFailed
+		}
+
+		report.Checks = append(report.Checks, result)
+	}
+
+	return report
+}
+
+// HealthHandler returns API server dependencies health report
+func (s TestkubeAPI) HealthHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.JSON(s.getHealthReport(c.Context()))
+	}
+}
+
+// ReadyHandler returns dependencies health report with service unavailable status when any dependency check failed
+func (s TestkubeAPI) ReadyHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		report := s.getHealthReport(c.Context())
+		// draining server is taken out of load balancing before it stops
+		if !report.IsHealthy() || s.shutdown.isDraining() {
+			c.Status(http.StatusServiceUnavailable)
+		}
+
+		return c.JSON(report)
+	}
+}
@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestRunHealthCheck(t *testing.T) {
+
+	t.Run("passed check", func(t *testing.T) {
+		result := runHealthCheck(context.Background(), healthCheck{name: "mongo", check: func(ctx context.Context) error { return nil }})
+
+		assert.Equal(t, "mongo", result.Name)
+		assert.Equal(t, testkube.HealthStatusOK, result.Status)
+		assert.NotEmpty(t, result.Latency)
+		assert.Empty(t, result.Error)
+	})
+
+	t.Run("failed check", func(t *testing.T) {
+		result := runHealthCheck(context.Background(), healthCheck{name: "storage", check: func(ctx context.Context) error {
+			return fmt.Errorf("connection refused")
+		}})
+
+		assert.Equal(t, testkube.HealthStatusFailed, result.Status)
+		assert.Equal(t, "connection refused", result.Error)
+	})
+}
+
+func TestHealthChecksWithoutStorage(t *testing.T) {
+	names := func(s TestkubeAPI) (names []string) {
+		for _, check := range s.healthChecks() {
+			names = append(names, check.name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"mongo", "kubernetes", "events-emitter"}, names(TestkubeAPI{}))
+	assert.Equal(t, []string{"mongo", "storage", "kubernetes", "events-emitter"}, names(TestkubeAPI{storageParams: storageParams{Endpoint: "minio:9000"}}))
+}
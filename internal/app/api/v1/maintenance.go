@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/rand"
+)
+
+// skipTest stores scheduled test run started in maintenance window as skipped execution, executor isn't called
+// and no notifications are sent for it
+func (s TestkubeAPI) skipTest(ctx context.Context, test testkube.Test, request testkube.ExecutionRequest,
+	window testkube.MaintenanceWindow) (execution testkube.Execution) {
+	if request.Name == "" {
+		request.Name = rand.Name()
+	}
+
+	options, err := s.GetExecuteOptions(request.Namespace, test.Name, request)
+	if err != nil {
+		return testkube.NewFailedExecution(fmt.Errorf("can't create valid execution options: %w", err))
+	}
+
+	execution = newExecutionFromExecutionOptions(options)
+	if execution.Number, err = s.ExecutionResults.GetNextExecutionNumber(ctx, test.Name); err != nil {
+		return execution.Errw("can't assign execution number: %w", err)
+	}
+
+	execution = newMaintenanceExecution(execution, window, time.Now())
+	if err = s.ExecutionResults.Insert(ctx, execution); err != nil {
+		return execution.Errw("can't create skipped test execution, can't insert into storage: %w", err)
+	}
+
+	s.Log.Infow("scheduled test skipped in maintenance window", "test", test.Name, "executionId", execution.Id, "window", window.Name)
+	return execution
+}
+
+// skipTestSuite stores scheduled test suite run started in maintenance window as skipped execution with skipped steps
+func (s TestkubeAPI) skipTestSuite(ctx context.Context, testSuite testkube.TestSuite, request testkube.TestSuiteExecutionRequest,
+	window testkube.MaintenanceWindow) testkube.TestSuiteExecution {
+	execution := newMaintenanceTestSuiteExecution(testkube.NewStartedTestSuiteExecution(testSuite, request), window)
+	if err := s.TestExecutionResults.Insert(ctx, execution); err != nil {
+		s.Log.Errorw("inserting skipped test suite execution", "testSuite", testSuite.Name, "error", err)
+	}
+
+	s.Log.Infow("scheduled test suite skipped in maintenance window", "testSuite", testSuite.Name, "executionId", execution.Id, "window", window.Name)
+	return execution
+}
+
+// newMaintenanceExecution returns execution skipped in maintenance window
+func newMaintenanceExecution(execution testkube.Execution, window testkube.MaintenanceWindow, now time.Time) testkube.Execution {
+	execution.StartTime = now
+	execution.EndTime = now
+	execution.Duration = "0s"
+	execution.ExecutionResult = &testkube.ExecutionResult{
+		Status:       testkube.ExecutionStatusSkipped,
+		ErrorMessage: window.Reason(),
+	}
+
+	return execution
+}
+
+// newMaintenanceTestSuiteExecution returns test suite execution skipped in maintenance window, its steps aren't run
+func newMaintenanceTestSuiteExecution(execution testkube.TestSuiteExecution, window testkube.MaintenanceWindow) testkube.TestSuiteExecution {
+	execution.Status = testkube.TestSuiteExecutionStatusSkipped
+	execution.EndTime = execution.StartTime
+	execution.Duration = "0s"
+	for i := range execution.StepResults {
+		execution.StepResults[i].Skip()
+		execution.StepResults[i].Execution.ExecutionResult.ErrorMessage = window.Reason()
+	}
+
+	return execution
+}
+
+// notificationsHeldUntil returns end of global and test maintenance windows and quiet hours active at time, zero time
+// is returned when execution notifications aren't held
+func (s TestkubeAPI) notificationsHeldUntil(settings testkube.ServerSettings, testName string, now time.Time) time.Time {
+	if s.heldNotifications == nil {
+		return time.Time{}
+	}
+
+	var windows []testkube.MaintenanceWindow
+	if testName != "" && s.TestsClient != nil {
+		if test, err := s.TestsClient.Get(testName); err == nil {
+			windows = testkube.MaintenanceWindowsFromAnnotations(test.Annotations)
+		}
+	}
+
+	return testkube.MaintenanceWindowsEnd(now, settings.MaintenanceWindows, settings.QuietHours, windows)
+}
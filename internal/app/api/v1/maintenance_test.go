@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/digest"
+)
+
+func TestNewMaintenanceExecution(t *testing.T) {
+	window := testkube.MaintenanceWindow{Name: "upgrade"}
+
+	execution := newMaintenanceExecution(testkube.NewExecutionWithID("1", "postman/collection", "api"), window, time.Now())
+	assert.True(t, execution.ExecutionResult.IsSkipped())
+	assert.Equal(t, "maintenance window upgrade", execution.ExecutionResult.ErrorMessage)
+
+	testSuite := testkube.TestSuite{Name: "smoke", Steps: []testkube.TestSuiteStep{{Execute: &testkube.TestSuiteStepExecuteTest{Name: "api"}}}}
+	suiteExecution := newMaintenanceTestSuiteExecution(testkube.NewStartedTestSuiteExecution(testSuite, testkube.TestSuiteExecutionRequest{}), window)
+	assert.Equal(t, testkube.TestSuiteExecutionStatusSkipped, suiteExecution.Status)
+	assert.True(t, suiteExecution.StepResults[0].Execution.ExecutionResult.IsSkipped())
+}
+
+func TestNotificationsHeldUntil(t *testing.T) {
+	now := time.Date(2022, 7, 13, 23, 0, 0, 0, time.UTC)
+	settings := testkube.ServerSettings{QuietHours: []testkube.MaintenanceWindow{{Schedule: "0 22 * * 1-5", Duration: "10h"}}}
+
+	s := TestkubeAPI{heldNotifications: digest.NewCollector()}
+	assert.Equal(t, time.Date(2022, 7, 14, 8, 0, 0, 0, time.UTC), s.notificationsHeldUntil(settings, "", now).UTC())
+	assert.True(t, s.notificationsHeldUntil(settings, "", now.Add(12*time.Hour)).IsZero())
+	assert.True(t, TestkubeAPI{}.notificationsHeldUntil(settings, "", now).IsZero(), "notifications aren't held without collector")
+}
@@ -0,0 +1,67 @@
+package v1
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// maxMatrixCombinations is a maximum number of executions started for params matrix of a test
+const maxMatrixCombinations = 100
+
+// newMatrixRequests returns execution request for each combination of params matrix values,
+// custom execution name gets combination number suffix
+func newMatrixRequests(request testkube.ExecutionRequest) ([]testkube.ExecutionRequest, error) {
+	combinations, err := matrixCombinations(request.Matrix)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]testkube.ExecutionRequest, len(combinations))
+	for i, combination := range combinations {
+		requests[i] = request
+		requests[i].Matrix = nil
+		// request params are copied, so combinations don't share them
+		requests[i].Params = mergeParams(mergeParams(nil, request.Params), combination)
+		if request.Name != "" {
+			requests[i].Name = fmt.Sprintf("%s-%d", request.Name, i+1)
+		}
+	}
+
+	return requests, nil
+}
+
+// matrixCombinations returns all combinations of params matrix values, params are combined in name order
+// and values in given order, e.g. browser [chrome firefox] x region [eu us] gives chrome/eu, chrome/us, firefox/eu...
+func matrixCombinations(matrix map[string][]string) ([]map[string]string, error) {
+	names := make([]string, 0, len(matrix))
+	count := 1
+	for name, values := range matrix {
+		if len(values) == 0 {
+			return nil, fmt.Errorf("matrix param %s has no values", name)
+		}
+
+		count *= len(values)
+		if count > maxMatrixCombinations {
+			return nil, fmt.Errorf("matrix has more than %d combinations", maxMatrixCombinations)
+		}
+
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	combinations := make([]map[string]string, count)
+	for i := range combinations {
+		combinations[i] = make(map[string]string, len(names))
+		// combination index is a mixed radix number with digit per param, last param changes fastest
+		index := i
+		for j := len(names) - 1; j >= 0; j-- {
+			values := matrix[names[j]]
+			combinations[i][names[j]] = values[index%len(values)]
+			index /= len(values)
+		}
+	}
+
+	return combinations, nil
+}
@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestMatrixCombinations(t *testing.T) {
+	combinations, err := matrixCombinations(map[string][]string{
+		"region":  {"eu", "us"},
+		"browser": {"chrome", "firefox", "safari"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"browser": "chrome", "region": "eu"},
+		{"browser": "chrome", "region": "us"},
+		{"browser": "firefox", "region": "eu"},
+		{"browser": "firefox", "region": "us"},
+		{"browser": "safari", "region": "eu"},
+		{"browser": "safari", "region": "us"},
+	}, combinations)
+
+	_, err = matrixCombinations(map[string][]string{"browser": {"chrome"}, "region": {}})
+	assert.Error(t, err)
+
+	values := make([]string, 11)
+	_, err = matrixCombinations(map[string][]string{"a": values, "b": values})
+	assert.Error(t, err, "matrix over combinations limit")
+}
+
+func TestNewMatrixRequests(t *testing.T) {
+	requests, err := newMatrixRequests(testkube.ExecutionRequest{
+		Name:   "smoke",
+		Params: map[string]string{"env": "staging", "browser": "default"},
+		Labels: map[string]string{"app": "web"},
+		Matrix: map[string][]string{"browser": {"chrome", "firefox"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+
+	for i, browser := range []string{"chrome", "firefox"} {
+		assert.Equal(t, map[string]string{"env": "staging", "browser": browser}, requests[i].Params)
+		assert.Equal(t, map[string]string{"app": "web"}, requests[i].Labels)
+		assert.Nil(t, requests[i].Matrix)
+	}
+	assert.Equal(t, "smoke-1", requests[0].Name)
+	assert.Equal(t, "smoke-2", requests[1].Name)
+}
@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"net/http"
+
+	thttp "github.com/kubeshop/testkube/pkg/http"
+)
+
+// notifiersConfig are outgoing connection options of Slack, PagerDuty and Opsgenie notifiers
+type notifiersConfig struct {
+	// ProxyURL is an URL of proxy used to call notifiers, proxy environment variables are used when empty
+	ProxyURL string
+	// TLSSecret is a name of Secret with ca.crt CA bundle and tls.crt and tls.key mTLS client certificate
+	TLSSecret string
+}
+
+// getHTTPClient returns cached client with given proxy and TLS material from Secret
+func (s TestkubeAPI) getHTTPClient(proxyURL, tlsSecret string) (*http.Client, error) {
+	var data map[string]string
+	if tlsSecret != "" {
+		var err error
+		if data, err = s.SecretClient.Get(tlsSecret); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.httpClients.Get(thttp.NewClientOptions(proxyURL, data))
+}
@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kubeshop/testkube/pkg/openapi"
+	"github.com/kubeshop/testkube/pkg/problem"
+)
+
+// OpenAPIHandler serves OpenAPI document of the API in JSON format
+func (s TestkubeAPI) OpenAPIHandler(spec *openapi.Spec) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(spec.JSON())
+	}
+}
+
+// ValidationMiddleware rejects requests with query params or JSON body not matching OpenAPI document,
+// requests of paths missing in the document are passed to handlers
+func (s TestkubeAPI) ValidationMiddleware(spec *openapi.Spec) fiber.Handler {
+	prefix := ""
+	if group, ok := s.Routes.(*fiber.Group); ok {
+		prefix = group.Prefix
+	}
+
+	return func(c *fiber.Ctx) error {
+		operation, parameters, err := spec.Find(c.Method(), strings.TrimPrefix(c.Path(), prefix))
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("finding api operation: %w", err))
+		}
+
+		if operation == nil {
+			return c.Next()
+		}
+
+		if err = spec.ValidateQuery(parameters, func(name string) string { return c.Query(name) }); err != nil {
+			return s.Warn(c, http.StatusBadRequest, problem.WithCode(problem.CodeInvalidRequest, err))
+		}
+
+		if err = spec.ValidateBody(operation, c.Get(fiber.HeaderContentType), c.Body(), c.Method() == fiber.MethodPatch); err != nil {
+			return s.Warn(c, http.StatusBadRequest, problem.WithCode(problem.CodeInvalidRequest, err))
+		}
+
+		return c.Next()
+	}
+}
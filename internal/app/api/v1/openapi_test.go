@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv1 "github.com/kubeshop/testkube/api/v1"
+	"github.com/kubeshop/testkube/pkg/openapi"
+	"github.com/kubeshop/testkube/pkg/server"
+)
+
+func TestValidationMiddleware(t *testing.T) {
+	spec, err := openapi.Load(apiv1.Spec)
+	require.NoError(t, err)
+
+	s := TestkubeAPI{HTTPServer: server.NewServer(server.Config{})}
+	s.Routes.Use(s.ValidationMiddleware(spec))
+	s.Routes.Get("/openapi.json", s.OpenAPIHandler(spec))
+	s.Routes.Post("/tests/:id/executions", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusCreated)
+	})
+	s.Routes.Get("/unknown", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+
+	send := func(method, target, body string) *http.Response {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		resp, err := s.Mux.Test(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := send(http.MethodPost, "/v1/tests/api/executions?force=true", `{"name":"run","args":["-v"]}`)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp = send(http.MethodPost, "/v1/tests/api/executions?force=maybe", `{"args":"-v"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "query parameter force must be a boolean")
+	assert.Contains(t, string(body), `"code":"invalid_request"`)
+
+	resp = send(http.MethodPost, "/v1/tests/api/executions", `{"args":"-v"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "body.args must be an array")
+
+	resp = send(http.MethodGet, "/v1/unknown?page=first", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "paths missing in spec aren't validated")
+
+	resp = send(http.MethodGet, "/v1/openapi.json", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"openapi":"3.0.1"`)
+}
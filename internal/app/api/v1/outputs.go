@@ -0,0 +1,178 @@
+package v1
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/output"
+	"github.com/kubeshop/testkube/pkg/storage"
+)
+
+// outputConfig configures size of outputs stored in execution documents, MongoDB documents can't be over 16MB
+type outputConfig struct {
+	// MaxInlineSize is a maximum size of output in bytes stored with execution, 0 disables the limit
+	MaxInlineSize int `split_words:"true" default:"4194304"`
+	// Bucket is a bucket of outputs over the limit
+	Bucket string `default:"testkube-outputs"`
+	// Compression enables gzip compression of stored outputs
+	Compression bool `default:"true"`
+}
+
+// outputOverflow moves outputs over inline size limit to object storage, only output end is stored with execution,
+// outputs are truncated when storage isn't configured
+type outputOverflow struct {
+	config  outputConfig
+	storage storage.Client
+	log     *zap.SugaredLogger
+}
+
+// inline returns result with output fitting inline size limit, whole output is uploaded to object storage
+func (o *outputOverflow) inline(id string, executionResult testkube.ExecutionResult) testkube.ExecutionResult {
+	if o == nil || o.config.MaxInlineSize <= 0 || len(executionResult.Output) <= o.config.MaxInlineSize {
+		return executionResult
+	}
+
+	if o.storage != nil {
+		object, err := o.upload(id, executionResult.Output)
+		if err == nil {
+			executionResult.OutputObject = object
+		} else {
+			o.log.Errorw("uploading execution output over inline size limit, output is truncated", "executionId", id, "error", err)
+		}
+	}
+
+	executionResult.Output = truncateOutput(executionResult.Output, o.config.MaxInlineSize)
+	return executionResult
+}
+
+// upload uploads whole output to object storage, output is gzip compressed when compression is enabled
+func (o *outputOverflow) upload(id, output string) (*testkube.StorageObject, error) {
+	object := &testkube.StorageObject{Bucket: o.config.Bucket, Name: id, Size: int64(len(output))}
+	data := []byte(output)
+	if o.config.Compression {
+		var buffer bytes.Buffer
+		writer := gzip.NewWriter(&buffer)
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		}
+
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+
+		data = buffer.Bytes()
+		object.Name += ".gz"
+		object.ContentEncoding = "gzip"
+	}
+
+	if err := o.storage.UploadFile(object.Bucket, object.Name, bytes.NewReader(data), int64(len(data))); err != nil {
+		return nil, err
+	}
+
+	return object, nil
+}
+
+// load replaces output with whole output downloaded from object storage, inline output end is kept when it can't be downloaded
+func (o *outputOverflow) load(executionResult *testkube.ExecutionResult) error {
+	if o == nil || o.storage == nil || executionResult == nil || executionResult.OutputObject == nil {
+		return nil
+	}
+
+	object, err := o.storage.DownloadFile(executionResult.OutputObject.Bucket, executionResult.OutputObject.Name)
+	if err != nil {
+		return fmt.Errorf("can't download output %s: %w", executionResult.OutputObject.Name, err)
+	}
+	defer object.Close()
+
+	var reader io.Reader = object
+	if executionResult.OutputObject.ContentEncoding == "gzip" {
+		gzipReader, err := gzip.NewReader(object)
+		if err != nil {
+			return fmt.Errorf("can't decompress output %s: %w", executionResult.OutputObject.Name, err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("can't read output %s: %w", executionResult.OutputObject.Name, err)
+	}
+
+	executionResult.Output = string(data)
+	return nil
+}
+
+// truncateOutput returns output end of at most size bytes with note about truncated beginning
+func truncateOutput(output string, size int) string {
+	start := len(output) - size
+	for start < len(output) && !utf8.RuneStart(output[start]) {
+		start++
+	}
+
+	return fmt.Sprintf("[%d bytes of output truncated]\n", start) + output[start:]
+}
+
+// loadOutput loads whole execution output from object storage, errors are logged as truncated output is still returned
+func (s TestkubeAPI) loadOutput(execution *testkube.Execution) {
+	if err := s.outputs.load(execution.ExecutionResult); err != nil {
+		s.Log.Warnw("loading execution output from storage", "executionId", execution.Id, "error", err)
+	}
+}
+
+// isOutputOverflowed checks if completed execution output is stored in object storage
+func isOutputOverflowed(execution testkube.Execution) bool {
+	return execution.ExecutionResult != nil && execution.ExecutionResult.OutputObject != nil &&
+		execution.ExecutionResult.Status != nil && execution.ExecutionResult.IsCompleted()
+}
+
+// storedOutputLogs returns logs stream of stored execution output lines ended with execution result
+func (s TestkubeAPI) storedOutputLogs(execution testkube.Execution) chan output.Output {
+	s.loadOutput(&execution)
+
+	logs := make(chan output.Output)
+	go func() {
+		defer close(logs)
+
+		for _, line := range strings.Split(strings.TrimRight(execution.ExecutionResult.Output, "\n"), "\n") {
+			logs <- output.NewOutputLine([]byte(line))
+		}
+		// output was already sent line by line
+		result := *execution.ExecutionResult
+		result.Output = ""
+		logs <- output.NewOutputResult(result)
+	}()
+
+	return logs
+}
+
+// overflowedResults stores outputs over inline size limit in object storage, results are saved by executors too,
+// so the repository is decorated
+type overflowedResults struct {
+	result.Repository
+	outputs *outputOverflow
+}
+
+// Update updates execution with output fitting inline size limit
+func (r overflowedResults) Update(ctx context.Context, execution testkube.Execution) error {
+	if execution.ExecutionResult != nil {
+		inlined := r.outputs.inline(execution.Id, *execution.ExecutionResult)
+		execution.ExecutionResult = &inlined
+	}
+
+	return r.Repository.Update(ctx, execution)
+}
+
+// UpdateResult updates execution result with output fitting inline size limit
+func (r overflowedResults) UpdateResult(ctx context.Context, id string, executionResult testkube.ExecutionResult) error {
+	return r.Repository.UpdateResult(ctx, id, r.outputs.inline(id, executionResult))
+}
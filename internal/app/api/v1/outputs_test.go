@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/log"
+	"github.com/kubeshop/testkube/pkg/storage"
+)
+
+// uploadsStorage keeps uploaded objects in memory
+type uploadsStorage struct {
+	storage.Client
+	objects map[string]string
+	err     error
+}
+
+func (u *uploadsStorage) UploadFile(bucket, object string, reader io.Reader, size int64) error {
+	if u.err != nil {
+		return u.err
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	u.objects[bucket+"/"+object] = string(data)
+	return nil
+}
+
+func (u *uploadsStorage) DownloadFile(bucket, file string) (*minio.Object, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (u *uploadsStorage) PresignDownloadFile(bucket, file string, expires time.Duration) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func TestOutputOverflowInline(t *testing.T) {
+	uploads := &uploadsStorage{objects: map[string]string{}}
+	outputs := &outputOverflow{config: outputConfig{MaxInlineSize: 10, Bucket: "outputs"}, storage: uploads, log: log.DefaultLogger}
+
+	t.Run("output under limit is kept", func(t *testing.T) {
+		result := outputs.inline("1", testkube.ExecutionResult{Output: "short"})
+
+		assert.Equal(t, "short", result.Output)
+		assert.Nil(t, result.OutputObject)
+	})
+
+	t.Run("output over limit is uploaded", func(t *testing.T) {
+		output := strings.Repeat("a", 20) + "0123456789"
+		result := outputs.inline("2", testkube.ExecutionResult{Output: output})
+
+		assert.Equal(t, "[20 bytes of output truncated]\n0123456789", result.Output)
+		require.NotNil(t, result.OutputObject)
+		assert.Equal(t, testkube.StorageObject{Bucket: "outputs", Name: "2", Size: 30}, *result.OutputObject)
+		assert.Equal(t, output, uploads.objects["outputs/2"])
+	})
+
+	t.Run("output is truncated when upload fails", func(t *testing.T) {
+		uploads.err = errors.New("storage unavailable")
+		defer func() { uploads.err = nil }()
+
+		result := outputs.inline("3", testkube.ExecutionResult{Output: strings.Repeat("a", 30)})
+
+		assert.Equal(t, "[20 bytes of output truncated]\n"+strings.Repeat("a", 10), result.Output)
+		assert.Nil(t, result.OutputObject)
+	})
+
+	t.Run("output is compressed", func(t *testing.T) {
+		compressed := &outputOverflow{config: outputConfig{MaxInlineSize: 10, Bucket: "outputs", Compression: true}, storage: uploads, log: log.DefaultLogger}
+		output := strings.Repeat("a", 1000)
+		result := compressed.inline("5", testkube.ExecutionResult{Output: output})
+
+		require.NotNil(t, result.OutputObject)
+		assert.Equal(t, testkube.StorageObject{Bucket: "outputs", Name: "5.gz", Size: 1000, ContentEncoding: "gzip"}, *result.OutputObject)
+
+		reader, err := gzip.NewReader(strings.NewReader(uploads.objects["outputs/5.gz"]))
+		require.NoError(t, err)
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, output, string(data))
+	})
+
+	t.Run("disabled limit", func(t *testing.T) {
+		var disabled *outputOverflow
+		result := disabled.inline("4", testkube.ExecutionResult{Output: strings.Repeat("a", 30)})
+
+		assert.Len(t, result.Output, 30)
+	})
+}
+
+func TestTruncateOutput(t *testing.T) {
+	// multibyte rune on the cut isn't split
+	assert.Equal(t, "[2 bytes of output truncated]\nżb", truncateOutput("aażb", 3))
+	assert.Equal(t, "[4 bytes of output truncated]\nb", truncateOutput("aażb", 2))
+}
+
+func TestIsOutputOverflowed(t *testing.T) {
+	execution := testkube.Execution{ExecutionResult: &testkube.ExecutionResult{
+		Status:       testkube.ExecutionStatusPassed,
+		OutputObject: &testkube.StorageObject{Bucket: "outputs", Name: "1"},
+	}}
+	assert.True(t, isOutputOverflowed(execution))
+
+	execution.ExecutionResult.Status = testkube.ExecutionStatusRunning
+	assert.False(t, isOutputOverflowed(execution))
+}
@@ -0,0 +1,226 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/condition"
+	"github.com/kubeshop/testkube/pkg/executor/client"
+	"github.com/kubeshop/testkube/pkg/problem"
+)
+
+const (
+	// RequesterHeader is a header set by auth layer (e.g. oauth proxy) with user making the request, it's only
+	// trusted in requests of trusted proxies and overrides actor of running context sent by clients
+	RequesterHeader = "X-Forwarded-User"
+
+	policyRunningVariable     = "running"
+	policyRunningTestVariable = "running.test"
+)
+
+// policyVariables are variables execution policy conditions can reference
+var policyVariables = []string{"namespace", "test", "type", "requester", "labels.", "time.hour", "time.weekday",
+	policyRunningVariable, policyRunningTestVariable}
+
+// validateExecutionPolicies checks that policies are named and their conditions and timezones are valid
+func validateExecutionPolicies(policies []testkube.ExecutionPolicy) error {
+	names := map[string]bool{}
+	for _, policy := range policies {
+		if policy.Name == "" {
+			return fmt.Errorf("execution policy name can't be empty")
+		}
+
+		if names[policy.Name] {
+			return fmt.Errorf("duplicate execution policy %s", policy.Name)
+		}
+		names[policy.Name] = true
+
+		if _, err := condition.ParseWith(policy.Deny, policyVariables...); err != nil {
+			return fmt.Errorf("invalid execution policy %s deny condition: %w", policy.Name, err)
+		}
+
+		if _, err := time.LoadLocation(policy.Timezone); err != nil {
+			return fmt.Errorf("invalid execution policy %s timezone %q: %w", policy.Name, policy.Timezone, err)
+		}
+	}
+
+	return nil
+}
+
+// policyInput is an execution state policies are evaluated against
+type policyInput struct {
+	Namespace   string
+	Test        string
+	Type        string
+	Requester   string
+	Labels      map[string]string
+	Time        time.Time
+	Running     int32
+	RunningTest int32
+}
+
+// variables returns values of policy variables, time variables are in policy timezone
+func (in policyInput) variables(timezone string) map[string]string {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	t := in.Time.In(location)
+	variables := map[string]string{
+		"namespace":               in.Namespace,
+		"test":                    in.Test,
+		"type":                    in.Type,
+		"requester":               in.Requester,
+		"time.hour":               strconv.Itoa(t.Hour()),
+		"time.weekday":            strings.ToLower(t.Weekday().String()),
+		policyRunningVariable:     strconv.Itoa(int(in.Running)),
+		policyRunningTestVariable: strconv.Itoa(int(in.RunningTest)),
+	}
+
+	for k, v := range in.Labels {
+		variables["labels."+k] = v
+	}
+
+	return variables
+}
+
+// violatedPolicy returns first policy denying execution, invalid policies deny all executions
+func violatedPolicy(policies []testkube.ExecutionPolicy, in policyInput) (*testkube.ExecutionPolicy, error) {
+	for i, policy := range policies {
+		expression, err := condition.ParseWith(policy.Deny, policyVariables...)
+		if err != nil {
+			return &policies[i], fmt.Errorf("invalid execution policy %s deny condition: %w", policy.Name, err)
+		}
+
+		if expression.Evaluate(condition.Context{Variables: in.variables(policy.Timezone)}) {
+			return &policies[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// newPolicyViolation returns policy violation error of policy message
+func newPolicyViolation(policy testkube.ExecutionPolicy) error {
+	message := policy.Message
+	if message == "" {
+		message = "execution is denied"
+	}
+
+	return problem.WithCode(problem.CodePolicyViolation, fmt.Errorf("execution policy %s violated: %s", policy.Name, message))
+}
+
+// checkExecutionPolicies evaluates execution policies of server settings before execution starts, policy violation
+// error is returned for denied executions
+func (s TestkubeAPI) checkExecutionPolicies(ctx context.Context, options client.ExecuteOptions) error {
+	policies := s.getServerSettings(ctx).ExecutionPolicies
+	if len(policies) == 0 {
+		return nil
+	}
+
+	labels := make(map[string]string, len(options.Labels)+len(options.Request.Labels))
+	for _, source := range []map[string]string{options.Labels, options.Request.Labels} {
+		for k, v := range source {
+			labels[k] = v
+		}
+	}
+
+	in := policyInput{
+		Namespace: options.Namespace,
+		Test:      options.TestName,
+		Type:      options.TestSpec.Type_,
+		Labels:    labels,
+		Time:      time.Now(),
+	}
+
+	// requester is set by server from trusted auth layer header, actor sent by clients isn't trusted
+	if options.Request.RunningContext != nil {
+		in.Requester = options.Request.RunningContext.Requester
+	}
+
+	// running executions are counted only for policies limiting concurrent load
+	if referencesRunning(policies) {
+		running := result.NewExecutionsFilter().WithStatus(string(testkube.RUNNING_ExecutionStatus))
+		totals, err := s.ExecutionResults.GetExecutionTotals(ctx, false, running)
+		if err != nil {
+			return fmt.Errorf("can't count running executions: %w", err)
+		}
+		in.Running = totals.Running
+
+		runningTest := result.NewExecutionsFilter().WithStatus(string(testkube.RUNNING_ExecutionStatus)).WithTestName(options.TestName)
+		if totals, err = s.ExecutionResults.GetExecutionTotals(ctx, false, runningTest); err != nil {
+			return fmt.Errorf("can't count running test executions: %w", err)
+		}
+		in.RunningTest = totals.Running
+	}
+
+	policy, err := violatedPolicy(policies, in)
+	if err != nil {
+		return err
+	}
+
+	if policy != nil {
+		s.Logger(ctx).Infow("execution denied by policy", "test", options.TestName, "policy", policy.Name, "requester", in.Requester)
+		return newPolicyViolation(*policy)
+	}
+
+	return nil
+}
+
+// referencesRunning checks if any policy condition references running executions, invalid conditions
+// deny executions without counting them
+func referencesRunning(policies []testkube.ExecutionPolicy) bool {
+	for _, policy := range policies {
+		expression, err := condition.ParseWith(policy.Deny, policyVariables...)
+		if err != nil {
+			continue
+		}
+
+		if expression.ReferencesVariable(policyRunningVariable) || expression.ReferencesVariable(policyRunningTestVariable) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPolicyViolation checks if execution was denied by execution policy
+func isPolicyViolation(err error) bool {
+	return err != nil && problem.CodeOf(err, 0) == problem.CodePolicyViolation
+}
+
+// withRequester returns running context with requester set by auth layer, requester header is only read from
+// requests of trusted proxies and requester sent by clients is always replaced, so they can't impersonate other users,
+// triggers chain sent by clients is dropped too
+func (s TestkubeAPI) withRequester(c *fiber.Ctx, runningContext *testkube.RunningContext) *testkube.RunningContext {
+	requester := ""
+	if len(s.Config.TrustedProxies) > 0 && c.IsProxyTrusted() {
+		requester = c.Get(RequesterHeader)
+	}
+
+	if runningContext == nil && requester == "" {
+		return nil
+	}
+
+	withRequester := testkube.RunningContext{}
+	if runningContext != nil {
+		withRequester = *runningContext
+	}
+
+	// triggers chain is set by server for triggered executions only, so requests can't skip triggers
+	withRequester.TriggeredBy = nil
+	withRequester.Requester = requester
+	if requester != "" {
+		withRequester.Actor = requester
+	}
+
+	return &withRequester
+}
@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/server"
+)
+
+func TestViolatedPolicy(t *testing.T) {
+	policies := []testkube.ExecutionPolicy{
+		{
+			Name:     "business hours",
+			Deny:     `labels.type == "load" && time.hour >= 8 && time.hour < 18 && time.weekday != "saturday"`,
+			Message:  "load tests can run only outside business hours",
+			Timezone: "Europe/Warsaw",
+		},
+		{Name: "production", Deny: `namespace == "prod" && requester != "release-bot"`},
+		{Name: "load", Deny: `running >= 20 || running.test > 2`},
+	}
+	require.NoError(t, validateExecutionPolicies(policies))
+
+	// 10:00 in Warsaw on Wednesday
+	wednesday := time.Date(2022, 6, 1, 8, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		in     policyInput
+		policy string
+	}{
+		"load test in business hours": {
+			in:     policyInput{Namespace: "testkube", Labels: map[string]string{"type": "load"}, Time: wednesday},
+			policy: "business hours",
+		},
+		"load test at night": {
+			in: policyInput{Namespace: "testkube", Labels: map[string]string{"type": "load"}, Time: wednesday.Add(12 * time.Hour)},
+		},
+		"production run by user": {
+			in:     policyInput{Namespace: "prod", Requester: "octocat", Time: wednesday},
+			policy: "production",
+		},
+		"production run by release bot": {
+			in: policyInput{Namespace: "prod", Requester: "release-bot", Time: wednesday},
+		},
+		"too many running test executions": {
+			in:     policyInput{Namespace: "testkube", Running: 3, RunningTest: 3, Time: wednesday},
+			policy: "load",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			policy, err := violatedPolicy(policies, test.in)
+			require.NoError(t, err)
+
+			if test.policy == "" {
+				assert.Nil(t, policy)
+				return
+			}
+
+			require.NotNil(t, policy)
+			assert.Equal(t, test.policy, policy.Name)
+		})
+	}
+
+	violation := newPolicyViolation(policies[0])
+	assert.True(t, isPolicyViolation(violation))
+	assert.EqualError(t, violation, "execution policy business hours violated: load tests can run only outside business hours")
+}
+
+func TestValidateExecutionPolicies(t *testing.T) {
+	for name, policies := range map[string][]testkube.ExecutionPolicy{
+		"missing name":       {{Deny: `namespace == "prod"`}},
+		"duplicate name":     {{Name: "prod", Deny: `namespace == "prod"`}, {Name: "prod", Deny: `test == "api"`}},
+		"unknown variable":   {{Name: "prod", Deny: `cluster == "prod"`}},
+		"empty condition":    {{Name: "prod"}},
+		"invalid timezone":   {{Name: "night", Deny: `time.hour < 6`, Timezone: "Mars/Olympus"}},
+		"invalid expression": {{Name: "prod", Deny: `namespace ==`}},
+	} {
+		assert.Error(t, validateExecutionPolicies(policies), name)
+	}
+}
+
+func TestWithRequester(t *testing.T) {
+	newAPI := func(trustedProxies ...string) TestkubeAPI {
+		s := TestkubeAPI{HTTPServer: server.NewServer(server.Config{TrustedProxies: trustedProxies})}
+		return s
+	}
+
+	request := func(s TestkubeAPI, requester string) *testkube.RunningContext {
+		var runningContext *testkube.RunningContext
+		s.Mux.Get("/", func(c *fiber.Ctx) error {
+			runningContext = s.withRequester(c, &testkube.RunningContext{Provider: "github-actions", Actor: "octocat", Requester: "admin",
+				TriggeredBy: []string{"test/smoke"}})
+			return nil
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if requester != "" {
+			req.Header.Set(RequesterHeader, requester)
+		}
+		_, err := s.Mux.Test(req)
+		require.NoError(t, err)
+		return runningContext
+	}
+
+	assert.Equal(t, &testkube.RunningContext{Provider: "github-actions", Actor: "jane", Requester: "jane"},
+		request(newAPI("0.0.0.0"), "jane"), "trusted proxy user overrides actor")
+	assert.Equal(t, &testkube.RunningContext{Provider: "github-actions", Actor: "octocat"},
+		request(newAPI("0.0.0.0"), ""), "requester and triggers chain aren't taken from request body")
+	assert.Equal(t, &testkube.RunningContext{Provider: "github-actions", Actor: "octocat"},
+		request(newAPI(), "jane"), "header isn't trusted without trusted proxies")
+	assert.Equal(t, &testkube.RunningContext{Provider: "github-actions", Actor: "octocat"},
+		request(newAPI("10.1.1.1"), "jane"), "header isn't trusted from other clients")
+}
+
+func TestReferencesRunning(t *testing.T) {
+	assert.True(t, referencesRunning([]testkube.ExecutionPolicy{{Deny: `namespace == "prod"`}, {Deny: `running.test >= 2`}}))
+	assert.False(t, referencesRunning([]testkube.ExecutionPolicy{{Deny: `labels.state == "running"`}}), "string values aren't references")
+	assert.False(t, referencesRunning([]testkube.ExecutionPolicy{{Deny: `running >`}}))
+}
@@ -0,0 +1,111 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/precheck"
+)
+
+// prechecksConfig is prechecks configuration loaded from environment
+type prechecksConfig struct {
+	// AllowedHosts are hosts HTTP prechecks can probe, "*." prefix allows subdomains, HTTP prechecks are rejected when empty
+	AllowedHosts []string `split_words:"true"`
+}
+
+// validatePrechecks checks prechecks and that their HTTP endpoints can be probed
+func (s TestkubeAPI) validatePrechecks(prechecks *testkube.Prechecks) error {
+	if err := prechecks.Validate(); err != nil {
+		return err
+	}
+
+	if prechecks == nil {
+		return nil
+	}
+
+	for _, check := range prechecks.Checks {
+		if check.Url == "" {
+			continue
+		}
+
+		if err := precheck.ValidateURL(check.Url, s.prechecksConfig.AllowedHosts); err != nil {
+			return fmt.Errorf("invalid precheck %s: %w, allowed hosts are set in TESTKUBE_PRECHECKS_ALLOWED_HOSTS", check.Url, err)
+		}
+	}
+
+	return nil
+}
+
+// getAbortedExecution returns stored execution when it was aborted while waiting for prechecks or dependencies, so
+// it isn't launched or skipped over its aborted status
+func (s TestkubeAPI) getAbortedExecution(ctx context.Context, id string) (testkube.Execution, bool) {
+	execution, err := s.ExecutionResults.Get(ctx, id)
+	if err != nil || execution.ExecutionResult == nil || execution.ExecutionResult.Status == nil {
+		return execution, false
+	}
+
+	return execution, execution.ExecutionResult.IsAborted()
+}
+
+// waitForPrechecks waits until test prechecks are met, error of unmet check is returned after prechecks timeout
+func (s TestkubeAPI) waitForPrechecks(ctx context.Context, prechecks testkube.Prechecks) error {
+	if s.Prechecker == nil {
+		return fmt.Errorf("prechecks can't be evaluated, cluster connection isn't configured")
+	}
+
+	return s.Prechecker.Wait(ctx, prechecks)
+}
+
+// skipBlockedExecution stores started execution of test which prechecks or dependencies weren't met as skipped, so
+// unavailable services don't produce misleading test failures, executor isn't called
+func (s TestkubeAPI) skipBlockedExecution(ctx context.Context, execution testkube.Execution, errorType string, err error) testkube.Execution {
+	log := s.Logger(ctx)
+	log.Infow("test execution skipped", "test", execution.TestName, "executionId", execution.Id, "errorType", errorType, "error", err)
+
+	execution.Stop()
+	execution.ExecutionResult = &testkube.ExecutionResult{
+		Status:       testkube.ExecutionStatusSkipped,
+		ErrorType:    errorType,
+		ErrorMessage: err.Error(),
+	}
+
+	if err = s.ExecutionResults.UpdateResult(ctx, execution.Id, *execution.ExecutionResult); err != nil {
+		log.Errorw("updating skipped execution result", "executionId", execution.Id, "error", err)
+	}
+
+	if err = s.ExecutionResults.EndExecution(ctx, execution.Id, execution.EndTime, execution.CalculateDuration()); err != nil {
+		log.Errorw("ending skipped execution", "executionId", execution.Id, "error", err)
+	}
+
+	s.Metrics.IncExecution(execution)
+	if err = s.notifyEvents(testkube.WebhookTypeEndTest, execution); err != nil {
+		log.Infow("Notify events", "error", err)
+	}
+
+	return execution
+}
+
+// launchDelay returns max time execution of test waits before executor job is created
+func (s TestkubeAPI) launchDelay(testName string) time.Duration {
+	if s.TestsClient == nil || testName == "" {
+		return 0
+	}
+
+	test, err := s.TestsClient.Get(testName)
+	if err != nil {
+		return 0
+	}
+
+	var delay time.Duration
+	if prechecks := testkube.PrechecksFromAnnotations(test.Annotations); prechecks != nil {
+		delay += prechecks.GetTimeout()
+	}
+
+	if dependencies := testkube.DependenciesFromAnnotations(test.Annotations); dependencies != nil && dependencies.IsQueued() {
+		delay += dependencies.GetQueueTimeout()
+	}
+
+	return delay
+}
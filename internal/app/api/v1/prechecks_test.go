@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/client"
+	"github.com/kubeshop/testkube/pkg/precheck"
+	"github.com/kubeshop/testkube/pkg/server"
+)
+
+// storedResults keeps test executions in memory
+type storedResults struct {
+	result.Repository
+	mutex      sync.Mutex
+	executions map[string]testkube.Execution
+}
+
+func (r *storedResults) Get(ctx context.Context, id string) (testkube.Execution, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	execution, ok := r.executions[id]
+	if !ok {
+		return execution, mongo.ErrNoDocuments
+	}
+
+	return execution, nil
+}
+
+// GetExecutions returns executions of filtered test, executions are ordered from latest like stored ones
+func (r *storedResults) GetExecutions(ctx context.Context, filter result.Filter) (executions []testkube.Execution, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, execution := range r.executions {
+		if !filter.TestNameDefined() || execution.TestName == filter.TestName() {
+			executions = append(executions, execution)
+		}
+	}
+
+	return executions, nil
+}
+
+func (r *storedResults) GetByNumberAndTest(ctx context.Context, number int32, testName string) (testkube.Execution, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, execution := range r.executions {
+		if execution.Number == number && execution.TestName == testName {
+			return execution, nil
+		}
+	}
+
+	return testkube.Execution{}, mongo.ErrNoDocuments
+}
+
+func (r *storedResults) UpdateContentChecksum(ctx context.Context, id, checksum string, changed bool) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	execution := r.executions[id]
+	execution.ContentChecksum, execution.ContentChanged = checksum, changed
+	r.executions[id] = execution
+	return nil
+}
+
+func (r *storedResults) UpdateResult(ctx context.Context, id string, executionResult testkube.ExecutionResult) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	execution := r.executions[id]
+	execution.ExecutionResult = &executionResult
+	r.executions[id] = execution
+	return nil
+}
+
+func TestValidatePrechecks(t *testing.T) {
+	s := TestkubeAPI{prechecksConfig: prechecksConfig{AllowedHosts: []string{"*.staging.svc"}}}
+
+	assert.NoError(t, s.validatePrechecks(nil))
+	assert.NoError(t, s.validatePrechecks(&testkube.Prechecks{Checks: []testkube.Precheck{
+		{Resource: "deployment/backend"},
+		{Url: "http://backend.staging.svc/health"},
+	}}))
+
+	err := s.validatePrechecks(&testkube.Prechecks{Checks: []testkube.Precheck{{Url: "http://169.254.169.254/latest"}}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "TESTKUBE_PRECHECKS_ALLOWED_HOSTS")
+	}
+}
+
+func TestLaunchExecutionAbortedWhileWaiting(t *testing.T) {
+	aborted := testkube.NewQueuedExecution()
+	aborted.Id = "execution-1"
+	aborted.ExecutionResult = &testkube.ExecutionResult{Status: testkube.ExecutionStatusAborted}
+
+	// executor isn't set, so launching aborted execution fails the test
+	s := TestkubeAPI{
+		HTTPServer:       server.NewServer(server.Config{}),
+		ExecutionResults: &storedResults{executions: map[string]testkube.Execution{aborted.Id: *aborted}},
+		Prechecker:       &precheck.Checker{ClientSet: fake.NewSimpleClientset(), Namespace: "testkube"},
+	}
+
+	t.Run("prechecks met", func(t *testing.T) {
+		execution, err := s.launchExecution(context.Background(), client.ExecuteOptions{Prechecks: &testkube.Prechecks{}},
+			testkube.Execution{Id: aborted.Id})
+		require.NoError(t, err)
+		assert.Equal(t, testkube.ExecutionStatusAborted, execution.ExecutionResult.Status)
+	})
+
+	t.Run("prechecks not met", func(t *testing.T) {
+		execution, err := s.launchExecution(context.Background(), client.ExecuteOptions{Prechecks: &testkube.Prechecks{
+			Timeout: "10ms",
+			Checks:  []testkube.Precheck{{Resource: "pod/cache"}},
+		}}, testkube.Execution{Id: aborted.Id})
+		require.NoError(t, err)
+		assert.Equal(t, testkube.ExecutionStatusAborted, execution.ExecutionResult.Status, "aborted execution isn't skipped")
+	})
+}
@@ -0,0 +1,90 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const (
+	// ProjectHeader is a header set by auth layer (e.g. oauth proxy) with project the caller is allowed to access
+	ProjectHeader = "X-Testkube-Project"
+	// projectLocalsKey is a fiber context key for resolved request project
+	projectLocalsKey = "project"
+)
+
+// ProjectMiddleware resolves project for the request, project from auth layer header
+// can't be overridden by query param
+func (s TestkubeAPI) ProjectMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		project := c.Get(ProjectHeader)
+		queryProject := c.Query("project")
+
+		if project != "" && queryProject != "" && project != queryProject {
+			return s.Warn(c, http.StatusForbidden, fmt.Errorf("access to project %s is not allowed", queryProject))
+		}
+
+		if project == "" {
+			project = queryProject
+		}
+
+		c.Locals(projectLocalsKey, project)
+		return c.Next()
+	}
+}
+
+// errClusterScope is returned when cluster-wide resources are changed in project scope, they are shared by all projects
+var errClusterScope = fmt.Errorf("cluster-wide resources can't be changed in project scope")
+
+// RejectInProjectScope rejects requests changing cluster-wide resources, like executors and webhooks, in project scope
+func (s TestkubeAPI) RejectInProjectScope() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if getProject(c) != "" {
+			return s.Warn(c, http.StatusForbidden, errClusterScope)
+		}
+
+		return c.Next()
+	}
+}
+
+// getProject returns project resolved for the request
+func getProject(c *fiber.Ctx) string {
+	project, _ := c.Locals(projectLocalsKey).(string)
+	return project
+}
+
+// projectSelector extends label selector with project label
+func projectSelector(selector, project string) string {
+	if project == "" {
+		return selector
+	}
+
+	projectLabel := testkube.ProjectLabel + "=" + project
+	if selector == "" {
+		return projectLabel
+	}
+
+	return selector + "," + projectLabel
+}
+
+// isInProject checks if resource labels belong to given project, empty project matches all resources
+func isInProject(labels map[string]string, project string) bool {
+	return project == "" || testkube.GetProject(labels) == project
+}
+
+// withProjectLabel sets project label for new resources created in project scope
+func withProjectLabel(labels map[string]string, project string) map[string]string {
+	if project == "" {
+		return labels
+	}
+
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	labels[testkube.ProjectLabel] = project
+	return labels
+}
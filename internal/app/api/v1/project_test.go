@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/server"
+)
+
+func TestProjectSelector(t *testing.T) {
+
+	t.Run("no project returns selector as is", func(t *testing.T) {
+		assert.Equal(t, "app=backend", projectSelector("app=backend", ""))
+	})
+
+	t.Run("project is appended to selector", func(t *testing.T) {
+		assert.Equal(t, "app=backend,testkube.io/project=team-a", projectSelector("app=backend", "team-a"))
+	})
+
+	t.Run("project only selector", func(t *testing.T) {
+		assert.Equal(t, "testkube.io/project=team-a", projectSelector("", "team-a"))
+	})
+}
+
+func TestIsInProject(t *testing.T) {
+
+	labels := map[string]string{testkube.ProjectLabel: "team-a"}
+
+	assert.True(t, isInProject(labels, ""))
+	assert.True(t, isInProject(labels, "team-a"))
+	assert.False(t, isInProject(labels, "team-b"))
+	assert.False(t, isInProject(nil, "team-a"))
+}
+
+func TestRejectInProjectScope(t *testing.T) {
+	s := TestkubeAPI{HTTPServer: server.NewServer(server.Config{})}
+	s.Mux.Use(s.ProjectMiddleware())
+	s.Mux.Delete("/executors", s.RejectInProjectScope(), func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusNoContent)
+	})
+
+	request := httptest.NewRequest(http.MethodDelete, "/executors", nil)
+	request.Header.Set(ProjectHeader, "team-a")
+	resp, err := s.Mux.Test(request)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp, err = s.Mux.Test(httptest.NewRequest(http.MethodDelete, "/executors?project=team-a", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp, err = s.Mux.Test(httptest.NewRequest(http.MethodDelete, "/executors", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
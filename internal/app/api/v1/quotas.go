@@ -0,0 +1,333 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/problem"
+)
+
+const (
+	// artifactsUsageTTL is a time artifact storage usage of quota is cached for, usage is summed from execution buckets
+	artifactsUsageTTL = 10 * time.Minute
+	bytesInGB         = 1 << 30
+
+	quotaMaxExecutionsPerDay     = "maxExecutionsPerDay"
+	quotaMaxConcurrentExecutions = "maxConcurrentExecutions"
+	quotaMaxArtifactsGB          = "maxArtifactsGB"
+)
+
+// quotasState caches artifact storage usage of quotas shared between API copies, usage of quota scope is summed by
+// single request at a time
+type quotasState struct {
+	mutex     sync.Mutex
+	artifacts map[string]artifactsUsage
+	refreshes singleflight.Group
+}
+
+// artifactsUsage is artifact storage usage of quota scope
+type artifactsUsage struct {
+	gb        float64
+	updatedAt time.Time
+}
+
+func newQuotasState() *quotasState {
+	return &quotasState{artifacts: map[string]artifactsUsage{}}
+}
+
+// validateQuotas checks that quotas are named, scoped by selector or project and their limits aren't negative
+func validateQuotas(quotas []testkube.Quota) error {
+	names := map[string]bool{}
+	for _, quota := range quotas {
+		if quota.Name == "" {
+			return fmt.Errorf("quota name can't be empty")
+		}
+
+		if names[quota.Name] {
+			return fmt.Errorf("duplicate quota %s", quota.Name)
+		}
+		names[quota.Name] = true
+
+		if quota.Selector == "" && quota.Project == "" {
+			return fmt.Errorf("quota %s needs selector or project", quota.Name)
+		}
+
+		if quota.MaxExecutionsPerDay < 0 || quota.MaxConcurrentExecutions < 0 || quota.MaxArtifactsGB < 0 {
+			return fmt.Errorf("quota %s limits can't be negative", quota.Name)
+		}
+	}
+
+	return nil
+}
+
+// isInQuota checks if execution labels and project match quota selector and project, selector items are matched
+// the same way as label selectors of executions filter
+func isInQuota(quota testkube.Quota, labels map[string]string, project string) bool {
+	if quota.Project != "" && quota.Project != project {
+		return false
+	}
+
+	if quota.Selector == "" {
+		return true
+	}
+
+	for _, item := range strings.Split(quota.Selector, ",") {
+		key, value, hasValue := strings.Cut(item, "=")
+		actual, ok := labels[key]
+		if !ok || (hasValue && actual != value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// exceededLimits returns reached limits of quota usage, new executions don't fit into them
+func exceededLimits(usage testkube.QuotaUsage) (exceeded []string) {
+	quota := usage.Quota
+	if quota.MaxExecutionsPerDay > 0 && usage.ExecutionsToday >= quota.MaxExecutionsPerDay {
+		exceeded = append(exceeded, quotaMaxExecutionsPerDay)
+	}
+
+	if quota.MaxConcurrentExecutions > 0 && usage.ConcurrentExecutions >= quota.MaxConcurrentExecutions {
+		exceeded = append(exceeded, quotaMaxConcurrentExecutions)
+	}
+
+	if quota.MaxArtifactsGB > 0 && usage.ArtifactsGB >= quota.MaxArtifactsGB {
+		exceeded = append(exceeded, quotaMaxArtifactsGB)
+	}
+
+	return exceeded
+}
+
+// getQuotaUsage returns current usage of quota, artifact storage usage is read only for quotas limiting it
+func (s TestkubeAPI) getQuotaUsage(ctx context.Context, quota testkube.Quota) (testkube.QuotaUsage, error) {
+	usage := testkube.QuotaUsage{Quota: &quota}
+
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	startedToday := result.NewExecutionsFilter().WithSelector(quota.Selector).WithProject(quota.Project).WithStartDate(today)
+	totals, err := s.ExecutionResults.GetExecutionTotals(ctx, false, startedToday)
+	if err != nil {
+		return usage, fmt.Errorf("can't count executions of quota %s: %w", quota.Name, err)
+	}
+	usage.ExecutionsToday = totals.Results
+
+	running := result.NewExecutionsFilter().WithSelector(quota.Selector).WithProject(quota.Project).
+		WithStatus(string(testkube.RUNNING_ExecutionStatus))
+	totals, err = s.ExecutionResults.GetExecutionTotals(ctx, false, running)
+	if err != nil {
+		return usage, fmt.Errorf("can't count running executions of quota %s: %w", quota.Name, err)
+	}
+	usage.ConcurrentExecutions = totals.Running
+
+	if quota.MaxArtifactsGB > 0 {
+		if usage.ArtifactsGB, err = s.getArtifactsUsage(ctx, quota); err != nil {
+			return usage, err
+		}
+	}
+
+	usage.Exceeded = exceededLimits(usage)
+	return usage, nil
+}
+
+// getArtifactsUsage returns size of artifacts of executions in quota scope in GB, usage is cached as artifacts
+// of every execution are listed, stale usage is returned while it's refreshed in background
+func (s TestkubeAPI) getArtifactsUsage(ctx context.Context, quota testkube.Quota) (float64, error) {
+	if s.Storage == nil || s.storageParams.Endpoint == "" || s.quotas == nil {
+		return 0, nil
+	}
+
+	s.quotas.mutex.Lock()
+	cached, ok := s.quotas.artifacts[artifactsUsageKey(quota)]
+	s.quotas.mutex.Unlock()
+	if !ok {
+		return s.refreshArtifactsUsage(quota)
+	}
+
+	if time.Since(cached.updatedAt) >= artifactsUsageTTL {
+		go func() {
+			if _, err := s.refreshArtifactsUsage(quota); err != nil {
+				s.Log.Warnw("refreshing artifacts usage of quota", "quota", quota.Name, "error", err)
+			}
+		}()
+	}
+
+	return cached.gb, nil
+}
+
+// refreshArtifactsUsage sums and caches size of artifacts of executions in quota scope, concurrent refreshes of
+// quota scope share single sum
+func (s TestkubeAPI) refreshArtifactsUsage(quota testkube.Quota) (float64, error) {
+	key := artifactsUsageKey(quota)
+	gb, err, _ := s.quotas.refreshes.Do(key, func() (interface{}, error) {
+		// refresh isn't bound to request which started it, as other requests wait for it too
+		gb, err := s.sumArtifactsUsage(context.Background(), quota)
+		if err != nil {
+			return gb, err
+		}
+
+		s.quotas.mutex.Lock()
+		s.quotas.artifacts[key] = artifactsUsage{gb: gb, updatedAt: time.Now()}
+		s.quotas.mutex.Unlock()
+		return gb, nil
+	})
+
+	return gb.(float64), err
+}
+
+// sumArtifactsUsage returns size of artifacts of executions in quota scope in GB
+func (s TestkubeAPI) sumArtifactsUsage(ctx context.Context, quota testkube.Quota) (float64, error) {
+	var size int64
+	for page := 0; ; page++ {
+		filter := result.NewExecutionsFilter().WithSelector(quota.Selector).WithProject(quota.Project).
+			WithPage(page).WithExcludedFields(result.SummaryExcludedFields())
+		executions, err := s.ExecutionResults.GetExecutions(ctx, filter)
+		if err != nil {
+			return 0, fmt.Errorf("can't get executions of quota %s: %w", quota.Name, err)
+		}
+
+		for _, execution := range executions {
+			// executions without artifacts have no bucket
+			artifacts, err := s.artifacts(execution).List()
+			if err != nil {
+				continue
+			}
+
+			for _, artifact := range artifacts {
+				size += int64(artifact.Size)
+			}
+		}
+
+		if len(executions) < filter.PageSize() {
+			break
+		}
+	}
+
+	return float64(size) / bytesInGB, nil
+}
+
+// addArtifactsUsage adds size of artifact uploaded by API to cached usage of quotas matching execution, so uploads
+// are counted before usage is refreshed
+func (s TestkubeAPI) addArtifactsUsage(ctx context.Context, execution testkube.Execution, size int64) {
+	if s.quotas == nil {
+		return
+	}
+
+	quotas := s.getServerSettings(ctx).Quotas
+	s.quotas.mutex.Lock()
+	defer s.quotas.mutex.Unlock()
+	for _, quota := range quotas {
+		key := artifactsUsageKey(quota)
+		if cached, ok := s.quotas.artifacts[key]; ok && isInQuota(quota, execution.Labels, execution.Project) {
+			cached.gb += float64(size) / bytesInGB
+			s.quotas.artifacts[key] = cached
+		}
+	}
+}
+
+// artifactsUsageKey returns cache key of artifacts usage of quota scope
+func artifactsUsageKey(quota testkube.Quota) string {
+	return quota.Project + "/" + quota.Selector
+}
+
+// checkArtifactsQuota checks that artifact of given size fits into artifact storage quotas matching execution
+func (s TestkubeAPI) checkArtifactsQuota(ctx context.Context, execution testkube.Execution, size int64) error {
+	for _, quota := range s.getServerSettings(ctx).Quotas {
+		if quota.MaxArtifactsGB <= 0 || !isInQuota(quota, execution.Labels, execution.Project) {
+			continue
+		}
+
+		gb, err := s.getArtifactsUsage(ctx, quota)
+		if err != nil {
+			return err
+		}
+
+		if uploaded := float64(size) / bytesInGB; gb+uploaded > quota.MaxArtifactsGB {
+			s.Logger(ctx).Infow("artifact upload denied by quota", "executionId", execution.Id, "quota", quota.Name)
+			return problem.WithCode(problem.CodeArtifactQuotaExceeded, fmt.Errorf("quota %s exceeded: artifacts use %.2f of %.2f GB, upload needs %.2f GB",
+				quota.Name, gb, quota.MaxArtifactsGB, uploaded))
+		}
+	}
+
+	return nil
+}
+
+// checkQuotas checks quotas of server settings matching execution, quota exceeded error is returned for executions
+// which don't fit into any of them
+func (s TestkubeAPI) checkQuotas(ctx context.Context, execution testkube.Execution) error {
+	for _, quota := range s.getServerSettings(ctx).Quotas {
+		if !isInQuota(quota, execution.Labels, execution.Project) {
+			continue
+		}
+
+		usage, err := s.getQuotaUsage(ctx, quota)
+		if err != nil {
+			return err
+		}
+
+		if len(usage.Exceeded) == 0 {
+			continue
+		}
+
+		s.Logger(ctx).Infow("execution denied by quota", "test", execution.TestName, "quota", quota.Name, "exceeded", usage.Exceeded)
+		if usage.Exceeded[0] == quotaMaxArtifactsGB {
+			return problem.WithCode(problem.CodeArtifactQuotaExceeded, fmt.Errorf("quota %s exceeded: artifacts use %.2f of %.2f GB",
+				quota.Name, usage.ArtifactsGB, quota.MaxArtifactsGB))
+		}
+
+		return problem.WithCode(problem.CodeQuotaExceeded, fmt.Errorf("quota %s exceeded: %d executions today of %d, %d running of %d",
+			quota.Name, usage.ExecutionsToday, quota.MaxExecutionsPerDay, usage.ConcurrentExecutions, quota.MaxConcurrentExecutions))
+	}
+
+	return nil
+}
+
+// quotaErrorStatus returns response status of quota exceeded error, 0 is returned for other errors
+func quotaErrorStatus(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	switch problem.CodeOf(err, 0) {
+	case problem.CodeQuotaExceeded:
+		return http.StatusTooManyRequests
+	case problem.CodeArtifactQuotaExceeded:
+		return http.StatusRequestEntityTooLarge
+	default:
+		return 0
+	}
+}
+
+// ListQuotasHandler returns quotas of server settings with their current usage
+func (s TestkubeAPI) ListQuotasHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		quotas := s.getServerSettings(ctx).Quotas
+
+		usages := make([]testkube.QuotaUsage, 0, len(quotas))
+		for _, quota := range quotas {
+			if project := getProject(c); project != "" && quota.Project != project {
+				continue
+			}
+
+			usage, err := s.getQuotaUsage(ctx, quota)
+			if err != nil {
+				return s.Error(c, http.StatusInternalServerError, err)
+			}
+
+			usages = append(usages, usage)
+		}
+
+		return c.JSON(usages)
+	}
+}
@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/config"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/problem"
+	"github.com/kubeshop/testkube/pkg/server"
+	"github.com/kubeshop/testkube/pkg/storage"
+)
+
+func TestIsInQuota(t *testing.T) {
+	labels := map[string]string{"team": "payments", "canary": ""}
+
+	assert.True(t, isInQuota(testkube.Quota{Selector: "team=payments"}, labels, ""))
+	assert.True(t, isInQuota(testkube.Quota{Selector: "team=payments,canary"}, labels, ""))
+	assert.True(t, isInQuota(testkube.Quota{Project: "shop"}, labels, "shop"))
+	assert.False(t, isInQuota(testkube.Quota{Selector: "team=checkout"}, labels, ""))
+	assert.False(t, isInQuota(testkube.Quota{Selector: "region"}, labels, ""))
+	assert.False(t, isInQuota(testkube.Quota{Selector: "team=payments", Project: "shop"}, labels, "bank"))
+}
+
+func TestValidateQuotas(t *testing.T) {
+	assert.NoError(t, validateQuotas([]testkube.Quota{{Name: "payments", Selector: "team=payments", MaxExecutionsPerDay: 10}}))
+
+	for name, quotas := range map[string][]testkube.Quota{
+		"missing name":   {{Selector: "team=payments"}},
+		"duplicate name": {{Name: "payments", Selector: "team=payments"}, {Name: "payments", Project: "shop"}},
+		"missing scope":  {{Name: "payments"}},
+		"negative limit": {{Name: "payments", Project: "shop", MaxArtifactsGB: -1}},
+	} {
+		assert.Error(t, validateQuotas(quotas), name)
+	}
+}
+
+func TestExceededLimits(t *testing.T) {
+	quota := testkube.Quota{Name: "payments", MaxExecutionsPerDay: 10, MaxConcurrentExecutions: 2, MaxArtifactsGB: 1.5}
+
+	assert.Empty(t, exceededLimits(testkube.QuotaUsage{Quota: &quota, ExecutionsToday: 9, ConcurrentExecutions: 1, ArtifactsGB: 1}))
+	assert.Equal(t, []string{quotaMaxExecutionsPerDay, quotaMaxArtifactsGB},
+		exceededLimits(testkube.QuotaUsage{Quota: &quota, ExecutionsToday: 10, ConcurrentExecutions: 1, ArtifactsGB: 2}))
+	assert.Empty(t, exceededLimits(testkube.QuotaUsage{Quota: &testkube.Quota{Name: "unlimited"}, ExecutionsToday: 1000}))
+}
+
+func TestQuotaErrorStatus(t *testing.T) {
+	assert.Equal(t, http.StatusTooManyRequests, quotaErrorStatus(problem.WithCode(problem.CodeQuotaExceeded, fmt.Errorf("quota exceeded"))))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, quotaErrorStatus(problem.WithCode(problem.CodeArtifactQuotaExceeded, fmt.Errorf("quota exceeded"))))
+	assert.Equal(t, 0, quotaErrorStatus(fmt.Errorf("can't count executions")))
+	assert.Equal(t, 0, quotaErrorStatus(nil))
+}
+
+// settingsRepository returns stored server settings
+type settingsRepository struct {
+	config.Repository
+	settings testkube.ServerSettings
+}
+
+func (r settingsRepository) Get(ctx context.Context) (testkube.Config, error) {
+	return testkube.Config{Settings: &r.settings}, nil
+}
+
+// listedStorage counts listings of artifacts, listing is slow like listing of many buckets
+type listedStorage struct {
+	storage.Client
+	listings int32
+}
+
+func (l *listedStorage) ListFilesWithPrefix(bucket, prefix string) ([]testkube.Artifact, error) {
+	atomic.AddInt32(&l.listings, 1)
+	time.Sleep(20 * time.Millisecond)
+	return []testkube.Artifact{{Name: "report.html", Size: bytesInGB / 2}}, nil
+}
+
+func TestGetArtifactsUsage(t *testing.T) {
+	quota := testkube.Quota{Name: "payments", Selector: "team=payments", MaxArtifactsGB: 1}
+	listed := &listedStorage{}
+	s := TestkubeAPI{
+		HTTPServer:       server.NewServer(server.Config{}),
+		ExecutionResults: &storedResults{executions: map[string]testkube.Execution{"1": {Id: "1"}}},
+		Storage:          listed,
+		storageParams:    storageParams{Endpoint: "minio:9000"},
+		quotas:           newQuotasState(),
+	}
+
+	t.Run("concurrent requests share usage sum", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				gb, err := s.getArtifactsUsage(context.Background(), quota)
+				assert.NoError(t, err)
+				assert.Equal(t, 0.5, gb)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&listed.listings))
+	})
+
+	t.Run("stale usage is returned while it's refreshed", func(t *testing.T) {
+		s.quotas.mutex.Lock()
+		s.quotas.artifacts[artifactsUsageKey(quota)] = artifactsUsage{gb: 0.25, updatedAt: time.Now().Add(-artifactsUsageTTL)}
+		s.quotas.mutex.Unlock()
+
+		gb, err := s.getArtifactsUsage(context.Background(), quota)
+		require.NoError(t, err)
+		assert.Equal(t, 0.25, gb)
+
+		assert.Eventually(t, func() bool {
+			gb, err := s.getArtifactsUsage(context.Background(), quota)
+			return err == nil && gb == 0.5
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestUploadArtifactHandler(t *testing.T) {
+	quota := testkube.Quota{Name: "payments", Selector: "team=payments", MaxArtifactsGB: 100.0 / bytesInGB}
+	uploads := &uploadsStorage{objects: map[string]string{}}
+	s := TestkubeAPI{
+		HTTPServer: server.NewServer(server.Config{}),
+		ExecutionResults: &storedResults{executions: map[string]testkube.Execution{
+			"1": {Id: "1", Labels: map[string]string{"team": "payments"}},
+		}},
+		ConfigRepository: settingsRepository{settings: testkube.ServerSettings{Quotas: []testkube.Quota{quota}}},
+		Storage:          uploads,
+		storageParams:    storageParams{Endpoint: "minio:9000"},
+		quotas:           newQuotasState(),
+	}
+	s.quotas.artifacts[artifactsUsageKey(quota)] = artifactsUsage{gb: 40.0 / bytesInGB, updatedAt: time.Now()}
+	s.Mux.Post("/executions/:executionID/artifacts", s.UploadArtifactHandler())
+
+	upload := func(executionID, name string, size int) int {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		file, err := form.CreateFormFile("file", name)
+		require.NoError(t, err)
+		_, err = file.Write(bytes.Repeat([]byte("a"), size))
+		require.NoError(t, err)
+		require.NoError(t, form.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/executions/"+executionID+"/artifacts", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		resp, err := s.Mux.Test(req)
+		require.NoError(t, err)
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusCreated, upload("1", "../report.html", 50))
+	assert.Equal(t, strings.Repeat("a", 50), uploads.objects["1/report.html"])
+	assert.Equal(t, http.StatusRequestEntityTooLarge, upload("1", "trace.zip", 20), "uploaded artifacts are counted")
+	assert.Equal(t, http.StatusNotFound, upload("2", "report.html", 1))
+}
@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const (
+	// reconcileInterval is an interval of checking running executions against their jobs
+	reconcileInterval = time.Minute
+	// reconcileGracePeriod is a time API server has for creating job of new execution
+	reconcileGracePeriod = time.Minute
+)
+
+// RunExecutionsReconciler periodically updates running executions which jobs aren't watched by any active
+// API server instance, e.g. after API server restart, so they would stay running forever, only leader reconciles
+func (s TestkubeAPI) RunExecutionsReconciler(ctx context.Context) {
+	ticker := time.NewTicker(reconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		if s.isLeader() {
+			s.reconcileExecutions(ctx, time.Now().Add(-reconcileGracePeriod))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s TestkubeAPI) reconcileExecutions(ctx context.Context, createdBefore time.Time) {
+	isInstanceActive, err := s.activeInstances(ctx)
+	if err != nil {
+		s.Log.Errorw("getting active API server instances", "error", err)
+		return
+	}
+
+	filter := result.NewExecutionsFilter().
+		WithStatus(string(testkube.RUNNING_ExecutionStatus) + "," + string(testkube.QUEUED_ExecutionStatus))
+	executions, err := s.ExecutionResults.GetExecutions(ctx, filter)
+	if err != nil {
+		s.Log.Errorw("getting running executions to reconcile", "error", err)
+		return
+	}
+
+	for _, execution := range executions {
+		if !isCreatedBefore(execution, createdBefore) {
+			continue
+		}
+
+		// jobs of executions waiting for prechecks or required tests aren't created yet
+		if delay := s.launchDelay(execution.TestName); delay > 0 && !isCreatedBefore(execution, createdBefore.Add(-delay)) {
+			continue
+		}
+
+		reconciled, err := s.Executor.Reconcile(execution, isInstanceActive)
+		if err != nil {
+			s.Log.Errorw("reconciling execution", "executionId", execution.Id, "error", err)
+			continue
+		}
+
+		if !reconciled {
+			continue
+		}
+
+		updated, err := s.ExecutionResults.Get(ctx, execution.Id)
+		if err != nil {
+			s.Log.Errorw("getting reconciled execution", "executionId", execution.Id, "error", err)
+			continue
+		}
+
+		if err = s.notifyEvents(testkube.WebhookTypeEndTest, updated); err != nil {
+			s.Log.Infow("Notify events", "error", err)
+		}
+	}
+}
+
+// isCreatedBefore checks execution creation time encoded in its id, start time isn't set yet for queued executions
+func isCreatedBefore(execution testkube.Execution, date time.Time) bool {
+	id, err := primitive.ObjectIDFromHex(execution.Id)
+	if err != nil {
+		return !execution.StartTime.IsZero() && execution.StartTime.Before(date)
+	}
+
+	return id.Timestamp().Before(date)
+}
@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestIsCreatedBefore(t *testing.T) {
+	date := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("creation time is read from id", func(t *testing.T) {
+		before := testkube.Execution{Id: primitive.NewObjectIDFromTimestamp(date.Add(-time.Minute)).Hex()}
+		after := testkube.Execution{Id: primitive.NewObjectIDFromTimestamp(date.Add(time.Minute)).Hex()}
+
+		assert.True(t, isCreatedBefore(before, date))
+		assert.False(t, isCreatedBefore(after, date))
+	})
+
+	t.Run("start time is used for non object ids", func(t *testing.T) {
+		assert.True(t, isCreatedBefore(testkube.Execution{Id: "custom", StartTime: date.Add(-time.Minute)}, date))
+		assert.False(t, isCreatedBefore(testkube.Execution{Id: "custom"}, date))
+	})
+}
@@ -0,0 +1,199 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/redact"
+)
+
+// redactionPatterns caches compiled configured redaction patterns by pattern, invalid patterns are cached as nil,
+// so patterns are compiled once and not for each redacted execution or log stream
+var redactionPatterns sync.Map
+
+// newRedactor returns redactor of execution secret param values and configured patterns,
+// invalid configured patterns are skipped
+func (s TestkubeAPI) newRedactor(settings testkube.ServerSettings, execution testkube.Execution) *redact.Redactor {
+	var patterns []*regexp.Regexp
+	for _, pattern := range settings.RedactionPatterns {
+		if re := s.compileRedactionPattern(pattern); re != nil {
+			patterns = append(patterns, re)
+		}
+	}
+
+	return redact.New(secretParamValues(execution), patterns)
+}
+
+// compileRedactionPattern returns cached compiled redaction pattern, nil is returned for invalid pattern
+func (s TestkubeAPI) compileRedactionPattern(pattern string) *regexp.Regexp {
+	if cached, ok := redactionPatterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp)
+	}
+
+	compiled, err := redact.CompilePatterns([]string{pattern})
+	var re *regexp.Regexp
+	if err != nil {
+		s.Log.Warnw("skipping invalid redaction pattern", "error", err)
+	} else {
+		re = compiled[0]
+	}
+
+	redactionPatterns.Store(pattern, re)
+	return re
+}
+
+// getRedactor returns redactor of execution with current server settings
+func (s TestkubeAPI) getRedactor(ctx context.Context, execution testkube.Execution) *redact.Redactor {
+	return s.newRedactor(s.getServerSettings(ctx), execution)
+}
+
+// redactExecution returns execution with redacted result and masked secret param values, as it's sent to notifiers
+func redactExecution(redactor *redact.Redactor, execution testkube.Execution) testkube.Execution {
+	if execution.ExecutionResult != nil {
+		result := redactor.Result(*execution.ExecutionResult)
+		execution.ExecutionResult = &result
+	}
+
+	if len(execution.SecretParams) != 0 && execution.Params != nil {
+		params := make(map[string]string, len(execution.Params))
+		for name, value := range execution.Params {
+			params[name] = value
+		}
+
+		for _, name := range execution.SecretParams {
+			if _, ok := params[name]; ok {
+				params[name] = redact.Mask
+			}
+		}
+		execution.Params = params
+	}
+
+	execution.Newman = redactNewmanOptions(execution.Newman)
+	return execution
+}
+
+// redactNewmanOptions returns newman options with masked inline environment and globals files and git credentials,
+// executors get options from stored execution, so they're masked only when execution is returned
+func redactNewmanOptions(options *testkube.NewmanOptions) *testkube.NewmanOptions {
+	if options == nil {
+		return nil
+	}
+
+	redacted := *options
+	redacted.EnvironmentFile = redactNewmanFile(redacted.EnvironmentFile)
+	redacted.GlobalsFile = redactNewmanFile(redacted.GlobalsFile)
+	return &redacted
+}
+
+func redactNewmanFile(file *testkube.TestContent) *testkube.TestContent {
+	if file == nil {
+		return nil
+	}
+
+	redacted := *file
+	if redacted.Data != "" {
+		redacted.Data = redact.Mask
+	}
+
+	if redacted.Repository != nil {
+		repository := *redacted.Repository
+		if repository.Username != "" {
+			repository.Username = redact.Mask
+		}
+		if repository.Token != "" {
+			repository.Token = redact.Mask
+		}
+		redacted.Repository = &repository
+	}
+
+	return &redacted
+}
+
+// redactTestSuiteExecution returns test suite execution with redacted step executions, test suite params passed to
+// steps as secret params are masked too
+func (s TestkubeAPI) redactTestSuiteExecution(ctx context.Context, execution testkube.TestSuiteExecution) testkube.TestSuiteExecution {
+	settings := s.getServerSettings(ctx)
+	secretParams := map[string]bool{}
+	stepResults := make([]testkube.TestSuiteStepExecutionResult, len(execution.StepResults))
+	for i, stepResult := range execution.StepResults {
+		if stepResult.Execution != nil {
+			redacted := redactExecution(s.newRedactor(settings, *stepResult.Execution), *stepResult.Execution)
+			stepResult.Execution = &redacted
+			for _, name := range redacted.SecretParams {
+				secretParams[name] = true
+			}
+		}
+		stepResults[i] = stepResult
+	}
+	execution.StepResults = stepResults
+
+	if len(secretParams) != 0 && execution.Params != nil {
+		params := make(map[string]string, len(execution.Params))
+		for name, value := range execution.Params {
+			if secretParams[name] {
+				value = redact.Mask
+			}
+			params[name] = value
+		}
+		execution.Params = params
+	}
+
+	return execution
+}
+
+// secretParamValues returns values of execution params marked secret
+func secretParamValues(execution testkube.Execution) []string {
+	var values []string
+	for _, name := range execution.SecretParams {
+		if value, ok := execution.Params[name]; ok {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// mergeSecretParams returns unique test and execution request secret param names
+func mergeSecretParams(testSecretParams, requestSecretParams []string) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, name := range append(append([]string{}, testSecretParams...), requestSecretParams...) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// redactedResults redacts execution results before they are stored, results are saved by executors too,
+// so the repository is decorated instead of redacting them in handlers
+type redactedResults struct {
+	result.Repository
+	redactor func(ctx context.Context, execution testkube.Execution) *redact.Redactor
+}
+
+// Update updates execution with redacted result
+func (r redactedResults) Update(ctx context.Context, execution testkube.Execution) error {
+	if execution.ExecutionResult != nil {
+		redacted := r.redactor(ctx, execution).Result(*execution.ExecutionResult)
+		execution.ExecutionResult = &redacted
+	}
+
+	return r.Repository.Update(ctx, execution)
+}
+
+// UpdateResult updates execution result redacted with secret params of stored execution
+func (r redactedResults) UpdateResult(ctx context.Context, id string, executionResult testkube.ExecutionResult) error {
+	execution, err := r.Repository.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("can't get execution %s to redact its result: %w", id, err)
+	}
+
+	return r.Repository.UpdateResult(ctx, id, r.redactor(ctx, execution).Result(executionResult))
+}
@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/log"
+)
+
+func TestRedactExecution(t *testing.T) {
+	s := TestkubeAPI{}
+	s.Log = log.DefaultLogger
+
+	execution := testkube.Execution{
+		Params:          map[string]string{"token": "s3cr3t-token", "user": "admin"},
+		SecretParams:    []string{"token", "missing"},
+		ExecutionResult: &testkube.ExecutionResult{Output: "login admin with s3cr3t-token, trace id 1234"},
+	}
+
+	redacted := redactExecution(s.newRedactor(testkube.ServerSettings{RedactionPatterns: []string{`trace id \d+`, `(`}}, execution), execution)
+
+	assert.Equal(t, "login admin with ***, ***", redacted.ExecutionResult.Output)
+	assert.Equal(t, map[string]string{"token": "***", "user": "admin"}, redacted.Params)
+	assert.Equal(t, "s3cr3t-token", execution.Params["token"], "passed execution isn't changed")
+	assert.Equal(t, "login admin with s3cr3t-token, trace id 1234", execution.ExecutionResult.Output)
+	assert.Same(t, s.compileRedactionPattern(`trace id \d+`), s.compileRedactionPattern(`trace id \d+`), "pattern is compiled once")
+	assert.Nil(t, s.compileRedactionPattern(`(`))
+}
+
+func TestRedactNewmanOptions(t *testing.T) {
+	options := &testkube.NewmanOptions{
+		EnvironmentFile: testkube.NewStringTestContent(`{"values": [{"key": "token", "value": "s3cr3t-token"}]}`),
+		GlobalsFile: &testkube.TestContent{Type_: string(testkube.TestContentTypeGitFile), Repository: &testkube.Repository{
+			Uri: "https://github.com/kubeshop/testkube.git", Path: "globals.json", Token: "ghp_token"}},
+		Folders: []string{"Users"},
+	}
+
+	redacted := redactNewmanOptions(options)
+
+	assert.Equal(t, "***", redacted.EnvironmentFile.Data)
+	assert.Equal(t, "***", redacted.GlobalsFile.Repository.Token)
+	assert.Empty(t, redacted.GlobalsFile.Repository.Username)
+	assert.Equal(t, "globals.json", redacted.GlobalsFile.Repository.Path)
+	assert.Equal(t, []string{"Users"}, redacted.Folders)
+	assert.Equal(t, "ghp_token", options.GlobalsFile.Repository.Token, "passed options aren't changed")
+	assert.Nil(t, redactNewmanOptions(nil))
+}
+
+func TestMergeSecretParams(t *testing.T) {
+	assert.Equal(t, []string{"token", "password"}, mergeSecretParams([]string{"token"}, []string{"password", "token"}))
+	assert.Nil(t, mergeSecretParams(nil, nil))
+}
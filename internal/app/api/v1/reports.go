@@ -0,0 +1,235 @@
+package v1
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/failures"
+)
+
+const (
+	defaultReportPeriod = "7d"
+	// errorsReportExecutionsLimit is a maximal number of the newest failed executions analyzed in errors report
+	errorsReportExecutionsLimit = 1000
+	// errorsReportExamples is a number of example executions per error
+	errorsReportExamples = 3
+	// defaultErrorsReportLimit is a default number of listed errors
+	defaultErrorsReportLimit = 10
+)
+
+var summaryReportTemplate = template.Must(template.New("summary").Funcs(template.FuncMap{
+	"percent": func(rate float64) float64 { return rate * 100 },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Testkube summary report</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+</style>
+</head>
+<body>
+<h1>Testkube summary report</h1>
+<p>Executions since {{ .Since.Format "2006-01-02 15:04:05" }}{{ if .Selector }} for tests matching <code>{{ .Selector }}</code>{{ end }}</p>
+<table>
+<tr><th>Test</th><th>Executions</th><th>Passed</th><th>Failed</th><th>Pass rate</th><th>Mean duration</th><th>Current failure streak</th><th>Longest failure streak</th></tr>
+{{- range .Tests }}
+<tr><td>{{ .TestName }}</td><td>{{ .Executions }}</td><td>{{ .Passed }}</td><td>{{ .Failed }}</td><td>{{ printf "%.2f%%" (percent .PassRate) }}</td><td>{{ .MeanDuration }}</td><td>{{ .CurrentFailureStreak }}</td><td>{{ .LongestFailureStreak }}</td></tr>
+{{- end }}
+</table>
+<p>Generated at {{ .GeneratedAt.Format "2006-01-02 15:04:05" }}</p>
+</body>
+</html>
+`))
+
+// parseReportPeriod parses report period, beside standard durations days unit is supported e.g. 7d
+func parseReportPeriod(period string) (time.Duration, error) {
+	if days := strings.TrimSuffix(period, "d"); days != period {
+		count, err := strconv.Atoi(days)
+		if err != nil || count < 0 {
+			return 0, fmt.Errorf("invalid period %s", period)
+		}
+
+		return time.Duration(count) * 24 * time.Hour, nil
+	}
+
+	duration, err := time.ParseDuration(period)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid period %s", period)
+	}
+
+	return duration, nil
+}
+
+// newTestSummary aggregates finished test executions, executions are expected to be sorted from the newest
+func newTestSummary(testName string, executions []testkube.Execution) testkube.TestSummary {
+	summary := testkube.TestSummary{TestName: testName}
+
+	var totalDuration time.Duration
+	var durations int64
+	var streak int32
+	currentStreak := true
+
+	for _, execution := range executions {
+		if execution.ExecutionResult == nil || execution.ExecutionResult.Status == nil || !execution.ExecutionResult.IsCompleted() ||
+			execution.ExecutionResult.IsAborted() {
+			continue
+		}
+
+		summary.Executions++
+		if execution.ExecutionResult.IsPassed() {
+			summary.Passed++
+			streak = 0
+			currentStreak = false
+		} else {
+			summary.Failed++
+			streak++
+			if currentStreak {
+				summary.CurrentFailureStreak = streak
+			}
+			if streak > summary.LongestFailureStreak {
+				summary.LongestFailureStreak = streak
+			}
+		}
+
+		if duration, err := time.ParseDuration(execution.Duration); err == nil {
+			totalDuration += duration
+			durations++
+		}
+	}
+
+	if summary.Executions > 0 {
+		summary.PassRate = float64(summary.Passed) / float64(summary.Executions)
+	}
+
+	if durations > 0 {
+		summary.MeanDuration = (totalDuration / time.Duration(durations)).Round(time.Millisecond).String()
+	}
+
+	return summary
+}
+
+// GetSummaryReportHandler returns aggregated report of tests executions as JSON or HTML
+func (s TestkubeAPI) GetSummaryReportHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		period, err := parseReportPeriod(c.Query("since", defaultReportPeriod))
+		if err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		selector := c.Query("selector")
+		tests, err := s.TestsClient.List(projectSelector(selector, getProject(c)))
+		if err != nil {
+			return s.Error(c, http.StatusBadGateway, err)
+		}
+
+		now := time.Now()
+		report := testkube.TestsSummaryReport{
+			Selector:    selector,
+			Since:       now.Add(-period),
+			GeneratedAt: now,
+			Tests:       []testkube.TestSummary{},
+		}
+
+		executions, err := s.getReportExecutions(c, report.Since)
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		for _, test := range tests.Items {
+			report.Tests = append(report.Tests, newTestSummary(test.Name, executions[test.Name]))
+		}
+
+		if c.Query("format") != "html" {
+			return c.JSON(report)
+		}
+
+		var buf bytes.Buffer
+		if err = summaryReportTemplate.Execute(&buf, report); err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		c.Type("html")
+		return c.Send(buf.Bytes())
+	}
+}
+
+// getReportExecutions gets executions of request project started since given time grouped by test name, executions
+// are read page by page in single query for all tests, so tests with more executions than page size aren't under-counted
+func (s TestkubeAPI) getReportExecutions(c *fiber.Ctx, since time.Time) (map[string][]testkube.Execution, error) {
+	executions := map[string][]testkube.Execution{}
+	for page := 0; ; page++ {
+		filter := result.NewExecutionsFilter().
+			WithStartDate(since).
+			WithProject(getProject(c)).
+			WithExcludedFields(result.SummaryExcludedFields()).
+			WithPage(page)
+
+		pageExecutions, err := s.ExecutionResults.GetExecutions(c.Context(), filter)
+		if err != nil {
+			return nil, fmt.Errorf("can't get executions since %s: %w", since.Format(time.RFC3339), err)
+		}
+
+		for _, execution := range pageExecutions {
+			executions[execution.TestName] = append(executions[execution.TestName], execution)
+		}
+
+		if len(pageExecutions) < result.PageDefaultLimit {
+			return executions, nil
+		}
+	}
+}
+
+// GetErrorsReportHandler returns the most frequent error signatures of failed executions
+func (s TestkubeAPI) GetErrorsReportHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		period, err := parseReportPeriod(c.Query("since", defaultReportPeriod))
+		if err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultErrorsReportLimit)))
+		if err != nil || limit <= 0 {
+			return s.Error(c, http.StatusBadRequest, fmt.Errorf("invalid limit %s", c.Query("limit")))
+		}
+
+		now := time.Now()
+		selector := c.Query("selector")
+		filter := result.NewExecutionsFilter().
+			WithStartDate(now.Add(-period)).
+			WithStatus(string(testkube.FAILED_ExecutionStatus) + "," + string(testkube.TIMEOUT_ExecutionStatus)).
+			WithPageSize(errorsReportExecutionsLimit).
+			WithSelector(selector).
+			WithProject(getProject(c)).
+			WithExcludedFields(result.SummaryExcludedFields())
+
+		executions, err := s.ExecutionResults.GetExecutions(c.Context(), filter)
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		clusters := failures.Cluster(executions, errorsReportExamples)
+		if len(clusters) > limit {
+			clusters = clusters[:limit]
+		}
+
+		return c.JSON(testkube.ErrorsReport{
+			Selector:    selector,
+			Since:       now.Add(-period),
+			GeneratedAt: now,
+			Executions:  int32(len(executions)),
+			Errors:      clusters,
+		})
+	}
+}
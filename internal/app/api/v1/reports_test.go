@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/server"
+)
+
+func TestParseReportPeriod(t *testing.T) {
+
+	t.Run("days", func(t *testing.T) {
+		period, err := parseReportPeriod("7d")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 7*24*time.Hour, period)
+	})
+
+	t.Run("standard duration", func(t *testing.T) {
+		period, err := parseReportPeriod("12h")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 12*time.Hour, period)
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		_, err := parseReportPeriod("xd")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNewTestSummary(t *testing.T) {
+	execution := func(status testkube.ExecutionStatus, duration string) testkube.Execution {
+		return testkube.Execution{
+			Duration:        duration,
+			ExecutionResult: &testkube.ExecutionResult{Status: testkube.StatusPtr(status)},
+		}
+	}
+
+	passed := testkube.PASSED_ExecutionStatus
+	failed := testkube.FAILED_ExecutionStatus
+
+	// newest first
+	executions := []testkube.Execution{
+		execution(failed, "2s"),
+		execution(failed, "4s"),
+		execution(testkube.RUNNING_ExecutionStatus, ""),
+		execution(passed, "3s"),
+		execution(failed, "1s"),
+		execution(failed, "1s"),
+		execution(failed, "1s"),
+		execution(passed, "4s"),
+	}
+
+	summary := newTestSummary("test1", executions)
+
+	assert.Equal(t, testkube.TestSummary{
+		TestName:             "test1",
+		Executions:           7,
+		Passed:               2,
+		Failed:               5,
+		PassRate:             2.0 / 7.0,
+		MeanDuration:         "2.286s",
+		CurrentFailureStreak: 2,
+		LongestFailureStreak: 3,
+	}, summary)
+}
+
+func TestGetSummaryReportHandlerAllPages(t *testing.T) {
+	var executions []testkube.Execution
+	for i := 0; i < result.PageDefaultLimit+1; i++ {
+		executions = append(executions, testkube.Execution{
+			TestName:        "api",
+			ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed},
+		})
+	}
+	executions = append(executions, testkube.Execution{
+		TestName:        "ui",
+		ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusFailed},
+	})
+
+	s := TestkubeAPI{
+		HTTPServer:       server.NewServer(server.Config{}),
+		TestsClient:      newDependentTestsClient(t, map[string][]string{"api": nil, "ui": nil}),
+		ExecutionResults: pagedResults{executions: executions},
+	}
+	s.Mux.Get("/reports/summary", s.GetSummaryReportHandler())
+
+	resp, err := s.Mux.Test(httptest.NewRequest(http.MethodGet, "/reports/summary", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var report testkube.TestsSummaryReport
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+
+	executionsByTest := map[string]int32{}
+	for _, summary := range report.Tests {
+		executionsByTest[summary.TestName] = summary.Executions
+	}
+	assert.Equal(t, map[string]int32{"api": int32(result.PageDefaultLimit + 1), "ui": 1}, executionsByTest)
+}
@@ -0,0 +1,147 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/client"
+	"github.com/kubeshop/testkube/pkg/executor/content"
+	"github.com/kubeshop/testkube/pkg/jobs"
+	"github.com/kubeshop/testkube/pkg/problem"
+	"github.com/kubeshop/testkube/pkg/rand"
+)
+
+// RerunExecutionHandler starts new execution of the test of finished execution replaying its params, args, files,
+// content commit and executor image, the new execution links the original one in rerunOf
+func (s TestkubeAPI) RerunExecutionHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id := c.Params("executionID")
+
+		previous, err := s.ExecutionResults.Get(ctx, id)
+		if err == mongo.ErrNoDocuments {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("execution %s not found", id)))
+		}
+
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't get execution %s: %w", id, err))
+		}
+
+		if project := getProject(c); project != "" && previous.Project != project {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeExecutionNotFound, fmt.Errorf("execution %s not found", id)))
+		}
+
+		if previous.ExecutionResult == nil || previous.ExecutionResult.Status == nil || !previous.ExecutionResult.IsCompleted() {
+			return s.Warn(c, http.StatusConflict, problem.WithCode(problem.CodeNotFinished, fmt.Errorf("execution %s isn't finished yet", id)))
+		}
+
+		options, err := s.GetExecuteOptions(previous.TestNamespace, previous.TestName, newRerunRequest(previous))
+		if errors.IsNotFound(err) {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestNotFound, fmt.Errorf("test %s not found", previous.TestName)))
+		}
+
+		if err != nil {
+			return s.Error(c, executorErrorStatus(err), fmt.Errorf("can't create valid execution options: %w", err))
+		}
+
+		if testkube.IsDisabled(options.Labels) && c.Query("force") != "true" {
+			return s.Warn(c, http.StatusConflict, problem.WithCode(problem.CodeDisabled, fmt.Errorf("test %s is disabled, use force to run it anyway", previous.TestName)))
+		}
+
+		options = pinRerunOptions(options, previous)
+		options.Request.RunningContext = s.withRequester(c, options.Request.RunningContext)
+		s.Logger(c.Context()).Infow("rerunning execution", "executionId", id, "test", previous.TestName)
+		execution, err := s.startExecution(ctx, options, newRerunExecution(options, previous))
+		if isPolicyViolation(err) {
+			return s.Warn(c, http.StatusForbidden, err)
+		}
+
+		if status := quotaErrorStatus(err); status != 0 {
+			return s.Warn(c, status, err)
+		}
+
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't rerun execution %s: %w", id, err))
+		}
+
+		if execution.ExecutionResult.IsFailed() {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf(execution.ExecutionResult.ErrorMessage))
+		}
+
+		c.Status(http.StatusCreated)
+		return c.JSON(execution)
+	}
+}
+
+// newRerunRequest returns execution request with params, args and files of execution, labels of its execution
+// group aren't copied as rerun doesn't belong to the group
+func newRerunRequest(execution testkube.Execution) testkube.ExecutionRequest {
+	labels := make(map[string]string, len(execution.Labels))
+	for k, v := range execution.Labels {
+		if k != testkube.ExecutionGroupLabel {
+			labels[k] = v
+		}
+	}
+
+	return testkube.ExecutionRequest{
+		Name:         rand.Name(),
+		Namespace:    execution.TestNamespace,
+		ParamsFile:   execution.ParamsFile,
+		Files:        execution.Files,
+		Params:       execution.Params,
+		SecretParams: execution.SecretParams,
+		Args:         execution.Args,
+		Labels:       labels,
+	}
+}
+
+// pinRerunOptions replaces current test and executor spec values with ones recorded on execution,
+// so params added to the test since aren't passed and upgraded executor image isn't used
+func pinRerunOptions(options client.ExecuteOptions, execution testkube.Execution) client.ExecuteOptions {
+	options.Request.Params = execution.Params
+	options.SecretParams = execution.SecretParams
+	options.DataFile = execution.DataFile
+	options.Newman = execution.Newman
+	if execution.ExecutorImage != "" {
+		options.ExecutorSpec.Image = execution.ExecutorImage
+	}
+
+	// image tags can be moved, so image is pinned to digest recorded when the execution ran
+	if execution.ExecutorImageDigest != "" {
+		options.ExecutorSpec.Image = jobs.ImageWithDigest(options.ExecutorSpec.Image, execution.ExecutorImageDigest)
+		options.Request.ExecutorImageDigest = execution.ExecutorImageDigest
+	}
+
+	return options
+}
+
+// newRerunExecution returns new execution of rerun options with content of rerun execution
+func newRerunExecution(options client.ExecuteOptions, previous testkube.Execution) testkube.Execution {
+	execution := newExecutionFromExecutionOptions(options)
+	execution.Content = rerunContent(previous)
+	execution.RerunOf = previous.Id
+
+	return execution
+}
+
+// rerunContent returns content of execution, git content is pinned to commit the execution ran with
+func rerunContent(execution testkube.Execution) *testkube.TestContent {
+	if execution.Content == nil {
+		return nil
+	}
+
+	rerun := *execution.Content
+	if rerun.Repository != nil && strings.HasPrefix(execution.ContentChecksum, content.ChecksumPrefixGit) {
+		repository := *rerun.Repository
+		repository.Commit = strings.TrimPrefix(execution.ContentChecksum, content.ChecksumPrefixGit)
+		rerun.Repository = &repository
+	}
+
+	return &rerun
+}
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	executorv1 "github.com/kubeshop/testkube-operator/apis/executor/v1"
+	testsv2 "github.com/kubeshop/testkube-operator/apis/tests/v2"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/client"
+)
+
+func newRerunTestExecution() testkube.Execution {
+	return testkube.Execution{
+		Id:            "1",
+		TestName:      "api",
+		TestNamespace: "testkube",
+		TestType:      "postman/collection",
+		Args:          []string{"--bail"},
+		Params:        map[string]string{"ENV": "prod", "TOKEN": "secret"},
+		SecretParams:  []string{"TOKEN"},
+		ParamsFile:    `{"values":[]}`,
+		Labels:        map[string]string{"app": "api", testkube.ExecutionGroupLabel: "group"},
+		Content: &testkube.TestContent{
+			Type_:      string(testkube.TestContentTypeGitFile),
+			Repository: &testkube.Repository{Type_: "git", Uri: "https://github.com/kubeshop/testkube", Branch: "main", Path: "test.json"},
+		},
+		ContentChecksum:     "git:9d1b2f3",
+		ExecutorImage:       "kubeshop/testkube-postman-executor:1.0.0",
+		ExecutorImageDigest: "sha256:4bcff63911fcb4448bd4fdacec207030997caf25e9bea4045fa6c8c44de311d1",
+	}
+}
+
+func TestNewRerunRequest(t *testing.T) {
+	previous := newRerunTestExecution()
+	request := newRerunRequest(previous)
+
+	assert.NotEmpty(t, request.Name)
+	assert.Equal(t, "testkube", request.Namespace)
+	assert.Equal(t, previous.Params, request.Params)
+	assert.Equal(t, previous.SecretParams, request.SecretParams)
+	assert.Equal(t, previous.Args, request.Args)
+	assert.Equal(t, previous.ParamsFile, request.ParamsFile)
+	assert.Equal(t, map[string]string{"app": "api"}, request.Labels, "rerun isn't part of execution group")
+}
+
+func TestNewRerunExecution(t *testing.T) {
+	previous := newRerunTestExecution()
+	options := client.ExecuteOptions{
+		TestName:     "api",
+		Namespace:    "testkube",
+		TestSpec:     testsv2.TestSpec{Type_: "postman/collection", Content: &testsv2.TestContent{Type_: "string", Data: "changed"}},
+		ExecutorSpec: executorv1.ExecutorSpec{Image: "kubeshop/testkube-postman-executor:2.0.0"},
+		Request:      testkube.ExecutionRequest{Params: map[string]string{"ENV": "prod", "TOKEN": "secret", "ADDED": "1"}},
+	}
+
+	options = pinRerunOptions(options, previous)
+	assert.Equal(t, previous.Params, options.Request.Params, "params added to test since aren't passed")
+	assert.Equal(t, previous.ExecutorImage+"@"+previous.ExecutorImageDigest, options.ExecutorSpec.Image)
+	assert.Equal(t, previous.ExecutorImageDigest, options.Request.ExecutorImageDigest)
+
+	execution := newRerunExecution(options, previous)
+	assert.NotEqual(t, previous.Id, execution.Id)
+	assert.Equal(t, "1", execution.RerunOf)
+	assert.Equal(t, options.ExecutorSpec.Image, execution.ExecutorImage)
+	assert.Equal(t, previous.Params, execution.Params)
+	assert.Equal(t, "9d1b2f3", execution.Content.Repository.Commit)
+	assert.Equal(t, "main", execution.Content.Repository.Branch)
+	assert.Empty(t, previous.Content.Repository.Commit, "rerun execution content isn't changed")
+}
+
+func TestRerunContent(t *testing.T) {
+	assert.Nil(t, rerunContent(testkube.Execution{}))
+
+	content := testkube.TestContent{Type_: string(testkube.TestContentTypeString), Data: "test"}
+	assert.Equal(t, &content, rerunContent(testkube.Execution{Content: &content, ContentChecksum: "sha256:9f86d0"}))
+
+	previous := newRerunTestExecution()
+	previous.ContentChecksum = ""
+	assert.Empty(t, rerunContent(previous).Repository.Commit, "content without resolved commit follows branch")
+}
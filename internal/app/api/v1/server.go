@@ -1,8 +1,11 @@
 package v1
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"net/http"
 	"os"
 	"strconv"
 
@@ -16,16 +19,26 @@ import (
 	executorsclientv1 "github.com/kubeshop/testkube-operator/client/executors/v1"
 	testsclientv2 "github.com/kubeshop/testkube-operator/client/tests/v2"
 	testsuitesclientv1 "github.com/kubeshop/testkube-operator/client/testsuites/v1"
+	apiv1 "github.com/kubeshop/testkube/api/v1"
 	"github.com/kubeshop/testkube/internal/pkg/api"
 	"github.com/kubeshop/testkube/internal/pkg/api/datefilter"
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/config"
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/lease"
 	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
 	"github.com/kubeshop/testkube/internal/pkg/api/repository/testresult"
 	"github.com/kubeshop/testkube/pkg/analytics"
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/artifactscan"
 	"github.com/kubeshop/testkube/pkg/cronjob"
+	"github.com/kubeshop/testkube/pkg/digest"
 	"github.com/kubeshop/testkube/pkg/executor/client"
+	thttp "github.com/kubeshop/testkube/pkg/http"
+	"github.com/kubeshop/testkube/pkg/jobs"
+	"github.com/kubeshop/testkube/pkg/openapi"
+	"github.com/kubeshop/testkube/pkg/precheck"
 	"github.com/kubeshop/testkube/pkg/secret"
 	"github.com/kubeshop/testkube/pkg/server"
+	"github.com/kubeshop/testkube/pkg/slacknotifier"
 	"github.com/kubeshop/testkube/pkg/storage"
 	"github.com/kubeshop/testkube/pkg/storage/minio"
 	"github.com/kubeshop/testkube/pkg/utils/text"
@@ -36,6 +49,10 @@ func NewTestkubeAPI(
 	namespace string,
 	executionsResults result.Repository,
 	testExecutionsResults testresult.Repository,
+	configRepository config.Repository,
+	leaseRepository lease.Repository,
+	digests digest.Batches,
+	heldNotifications digest.Batches,
 	testsClient *testsclientv2.TestsClient,
 	executorsClient *executorsclientv1.ExecutorsClient,
 	testsuitesClient *testsuitesclientv1.TestSuitesClient,
@@ -62,6 +79,8 @@ func NewTestkubeAPI(
 		HTTPServer:           server.NewServer(httpConfig),
 		TestExecutionResults: testExecutionsResults,
 		ExecutionResults:     executionsResults,
+		ConfigRepository:     configRepository,
+		LeaseRepository:      leaseRepository,
 		TestsClient:          testsClient,
 		ExecutorsClient:      executorsClient,
 		SecretClient:         secretClient,
@@ -72,6 +91,68 @@ func NewTestkubeAPI(
 		Namespace:            namespace,
 		AnalyticsEnabled:     analyticsEnabled,
 		ClusterID:            clusterId,
+		shutdown:             newShutdownState(),
+		cluster:              newClusterState(),
+		digests:              digests,
+		heldNotifications:    heldNotifications,
+		httpClients:          thttp.NewClientCache(),
+		quotas:               newQuotasState(),
+	}
+
+	if err = envconfig.Process("STORAGE", &s.storageParams); err != nil {
+		s.Log.Infow("Processing STORAGE environment config", err)
+	}
+	s.Storage = minio.NewClient(s.storageParams.Endpoint, s.storageParams.AccessKeyId, s.storageParams.SecretAccessKey, s.storageParams.Location, s.storageParams.Token, s.storageParams.SSL)
+
+	var outputs outputConfig
+	if err = envconfig.Process("TESTKUBE_OUTPUT", &outputs); err != nil {
+		panic(err)
+	}
+	s.outputs = &outputOverflow{config: outputs, log: s.Log}
+	if s.storageParams.Endpoint != "" {
+		s.outputs.storage = s.Storage
+	}
+
+	if s.storageParams.Endpoint != "" {
+		s.archive = &executionArchive{storage: s.Storage, log: s.Log}
+		if err = envconfig.Process("TESTKUBE_ARCHIVE", &s.archive.config); err != nil {
+			panic(err)
+		}
+	}
+
+	if err = envconfig.Process("TESTKUBE_COMPLIANCE", &s.complianceConfig); err != nil {
+		panic(err)
+	}
+
+	if err = envconfig.Process("TESTKUBE_RESTORE", &s.restoreConfig); err != nil {
+		panic(err)
+	}
+
+	var artifactScan artifactScanConfig
+	if err = envconfig.Process("TESTKUBE_ARTIFACT_SCAN", &artifactScan); err != nil {
+		panic(err)
+	}
+
+	// scan jobs report results back to API service
+	reportURL := fmt.Sprintf("http://%s:%d/v1", httpConfig.Fullname, httpConfig.Port)
+	if s.ArtifactScanner, err = newArtifactScanner(artifactScan, namespace, reportURL); err != nil {
+		panic(err)
+	}
+
+	// HTTP prechecks are probed only on allowed hosts, so tests can't make API server call internal services
+	if err = envconfig.Process("TESTKUBE_PRECHECKS", &s.prechecksConfig); err != nil {
+		panic(err)
+	}
+
+	// tests with prechecks are skipped when checker can't connect to cluster
+	if s.Prechecker, err = precheck.NewChecker(namespace, s.prechecksConfig.AllowedHosts); err != nil {
+		s.Log.Warnw("can't create prechecks checker", "error", err)
+	}
+
+	// results saved by executors are redacted and overflowed too
+	s.ExecutionResults = redactedResults{
+		Repository: overflowedResults{Repository: executionsResults, outputs: s.outputs},
+		redactor:   s.getRedactor,
 	}
 
 	initImage, err := s.loadDefaultExecutors(s.Namespace, os.Getenv("TESTKUBE_DEFAULT_EXECUTORS"))
@@ -83,7 +164,62 @@ func NewTestkubeAPI(
 		panic(err)
 	}
 
-	if s.Executor, err = client.NewJobExecutor(executionsResults, s.Namespace, initImage, s.jobTemplates.Job); err != nil {
+	// all job images are pulled from registry mirror when set, e.g. in air-gapped clusters
+	registryMirror := os.Getenv("TESTKUBE_REGISTRY_MIRROR")
+
+	var gcPolicy jobs.GCPolicy
+	if err = envconfig.Process("TESTKUBE_JOB_GC", &gcPolicy); err != nil {
+		panic(err)
+	}
+
+	// executions of preempted pods, e.g. on spot nodes, are rescheduled
+	var reschedulePolicy jobs.ReschedulePolicy
+	if err = envconfig.Process("TESTKUBE_JOB", &reschedulePolicy); err != nil {
+		panic(err)
+	}
+
+	var alertConfig alertingConfig
+	if err = envconfig.Process("TESTKUBE_ALERTING", &alertConfig); err != nil {
+		panic(err)
+	}
+
+	// notifiers use default clients when proxy and TLS options aren't set or are invalid
+	var notifiers notifiersConfig
+	if err = envconfig.Process("TESTKUBE_NOTIFIERS", &notifiers); err != nil {
+		panic(err)
+	}
+
+	var notifiersClient *http.Client
+	if notifiers.ProxyURL != "" || notifiers.TLSSecret != "" {
+		if notifiersClient, err = s.getHTTPClient(notifiers.ProxyURL, notifiers.TLSSecret); err != nil {
+			s.Log.Warnw("invalid notifiers connection options", "error", err)
+		}
+	}
+
+	if notifiersClient != nil {
+		slacknotifier.SetHTTPClient(notifiersClient)
+	}
+	s.alerting = newAlertingState(alertConfig, notifiersClient)
+
+	var suiteSteps suiteStepsConfig
+	if err = envconfig.Process("TESTKUBE_SUITE_STEPS", &suiteSteps); err != nil {
+		panic(err)
+	}
+	s.suiteRuns = newSuiteRunsState(suiteSteps)
+
+	// application logs are collected only from Testkube namespace and allowed namespaces
+	if err = envconfig.Process("TESTKUBE_APP_LOGS", &s.appLogsPolicy); err != nil {
+		panic(err)
+	}
+
+	// collected application logs are stored with execution artifacts
+	var artifacts storage.Client
+	if s.storageParams.Endpoint != "" {
+		artifacts = s.Storage
+	}
+
+	if s.Executor, err = client.NewJobExecutor(s.ExecutionResults, s.Namespace, initImage, s.jobTemplates.Job, registryMirror, s.instanceID(), gcPolicy,
+		reschedulePolicy, artifacts, s.appLogsPolicy); err != nil {
 		panic(err)
 	}
 
@@ -92,6 +228,11 @@ func NewTestkubeAPI(
 		panic(err)
 	}
 
+	// instance registers before serving so other instances don't reconcile its executions
+	if s.LeaseRepository != nil {
+		s.renewLeases(context.Background())
+	}
+
 	s.Init()
 	return s
 }
@@ -100,6 +241,8 @@ type TestkubeAPI struct {
 	server.HTTPServer
 	ExecutionResults     result.Repository
 	TestExecutionResults testresult.Repository
+	ConfigRepository     config.Repository
+	LeaseRepository      lease.Repository
 	Executor             client.Executor
 	TestsSuitesClient    *testsuitesclientv1.TestSuitesClient
 	TestsClient          *testsclientv2.TestsClient
@@ -110,11 +253,28 @@ type TestkubeAPI struct {
 	CronJobClient        *cronjob.Client
 	Metrics              Metrics
 	Storage              storage.Client
+	ArtifactScanner      artifactscan.Scanner
+	Prechecker           *precheck.Checker
 	storageParams        storageParams
 	jobTemplates         jobTemplates
+	flakinessConfig      flakinessConfig
+	complianceConfig     complianceConfig
+	restoreConfig        restoreConfig
 	Namespace            string
 	AnalyticsEnabled     bool
 	ClusterID            string
+	shutdown             *shutdownState
+	cluster              *clusterState
+	digests              digest.Batches
+	heldNotifications    digest.Batches
+	alerting             *alertingState
+	httpClients          *thttp.ClientCache
+	suiteRuns            *suiteRunsState
+	appLogsPolicy        jobs.AppLogsPolicy
+	prechecksConfig      prechecksConfig
+	outputs              *outputOverflow
+	archive              *executionArchive
+	quotas               *quotasState
 }
 
 type jobTemplates struct {
@@ -153,15 +313,36 @@ type storageParams struct {
 
 // Init initializes api server settings
 func (s TestkubeAPI) Init() {
-	err := envconfig.Process("STORAGE", &s.storageParams)
+	err := envconfig.Process("TESTKUBE_FLAKINESS", &s.flakinessConfig)
 	if err != nil {
-		s.Log.Infow("Processing STORAGE environment config", err)
+		s.Log.Infow("Processing TESTKUBE_FLAKINESS environment config", err)
 	}
 
-	s.Storage = minio.NewClient(s.storageParams.Endpoint, s.storageParams.AccessKeyId, s.storageParams.SecretAccessKey, s.storageParams.Location, s.storageParams.Token, s.storageParams.SSL)
-
 	s.Routes.Static("/api-docs", "./api/v1")
 	s.Routes.Use(cors.New())
+	s.Routes.Use(s.ProjectMiddleware())
+
+	spec, err := openapi.Load(apiv1.Spec)
+	if err != nil {
+		s.Log.Errorw("loading openapi document", "error", err)
+	} else {
+		s.Mux.Get("/openapi.json", s.OpenAPIHandler(spec))
+		s.Routes.Get("/openapi.json", s.OpenAPIHandler(spec))
+		if s.Config.RequestValidation {
+			s.Routes.Use(s.ValidationMiddleware(spec))
+		}
+	}
+
+	// probes are registered before rate limiter, so kubelet probes don't use read budget and aren't rejected under load
+	s.Routes.Get("/health", s.HealthHandler())
+	s.Routes.Get("/ready", s.ReadyHandler())
+
+	// read and execution triggering endpoints have separate rate limit budgets
+	s.Routes.Use(s.RateLimiter(s.Config.ReadRateLimit, s.Config.RateLimitWindow, func(c *fiber.Ctx) bool {
+		return c.Method() != fiber.MethodGet
+	}))
+	executionLimiter := s.RateLimiter(s.Config.ExecutionRateLimit, s.Config.RateLimitWindow, nil)
+	drainingGuard := s.RejectWhenDraining()
 
 	if s.AnalyticsEnabled {
 		// global analytics tracking send async
@@ -183,30 +364,44 @@ func (s TestkubeAPI) Init() {
 	s.Routes.Get("/info", s.InfoHandler())
 	s.Routes.Get("/routes", s.RoutesHandler())
 
+	// executors and webhooks are cluster-wide, so they can't be changed in project scope
+	clusterScope := s.RejectInProjectScope()
+
 	executors := s.Routes.Group("/executors")
 
-	executors.Post("/", s.CreateExecutorHandler())
+	executors.Post("/", clusterScope, s.CreateExecutorHandler())
 	executors.Get("/", s.ListExecutorsHandler())
 	executors.Get("/:name", s.GetExecutorHandler())
-	executors.Delete("/:name", s.DeleteExecutorHandler())
-	executors.Delete("/", s.DeleteExecutorsHandler())
+	executors.Delete("/:name", clusterScope, s.DeleteExecutorHandler())
+	executors.Delete("/", clusterScope, s.DeleteExecutorsHandler())
 
 	webhooks := s.Routes.Group("/webhooks")
 
-	webhooks.Post("/", s.CreateWebhookHandler())
+	webhooks.Post("/", clusterScope, s.CreateWebhookHandler())
 	webhooks.Get("/", s.ListWebhooksHandler())
 	webhooks.Get("/:name", s.GetWebhookHandler())
-	webhooks.Delete("/:name", s.DeleteWebhookHandler())
-	webhooks.Delete("/", s.DeleteWebhooksHandler())
+	webhooks.Delete("/:name", clusterScope, s.DeleteWebhookHandler())
+	webhooks.Delete("/", clusterScope, s.DeleteWebhooksHandler())
 
 	executions := s.Routes.Group("/executions")
 
 	executions.Get("/", s.ListExecutionsHandler())
-	executions.Post("/", s.ExecuteTestsHandler())
+	executions.Post("/", drainingGuard, executionLimiter, s.ExecuteTestsHandler())
+	executions.Get("/feed", s.ExecutionsFeedHandler())
 	executions.Get("/:executionID", s.GetExecutionHandler())
 	executions.Get("/:executionID/artifacts", s.ListArtifactsHandler())
+	executions.Post("/:executionID/artifacts", s.UploadArtifactHandler())
+	executions.Get("/:executionID/diff", s.GetExecutionDiffHandler())
 	executions.Get("/:executionID/logs", s.ExecutionLogsHandler())
+	executions.Get("/:executionID/pod", s.GetExecutionPodHandler())
+	executions.Post("/:executionID/restore", s.RestoreExecutionHandler())
+	executions.Post("/:executionID/rerun", drainingGuard, executionLimiter, s.RerunExecutionHandler())
 	executions.Get("/:executionID/artifacts/:filename", s.GetArtifactHandler())
+	executions.Put("/:executionID/artifacts/scans", s.ReportArtifactScansHandler())
+
+	executionGroups := s.Routes.Group("/execution-groups")
+	executionGroups.Get("/:id", s.GetExecutionGroupHandler())
+	executionGroups.Post("/:id/abort", s.AbortExecutionGroupHandler())
 
 	tests := s.Routes.Group("/tests")
 
@@ -217,10 +412,16 @@ func (s TestkubeAPI) Init() {
 
 	tests.Get("/:id", s.GetTestHandler())
 	tests.Delete("/:id", s.DeleteTestHandler())
+	tests.Delete("/:id/jobs", s.PurgeTestJobsHandler())
+	tests.Get("/:id/badge.svg", s.TestBadgeHandler())
 
-	tests.Post("/:id/executions", s.ExecuteTestsHandler())
+	tests.Post("/:id/executions", drainingGuard, executionLimiter, s.ExecuteTestsHandler())
 
 	tests.Get("/:id/executions", s.ListExecutionsHandler())
+	tests.Get("/:id/executions/latest", s.GetLatestExecutionHandler(nil))
+	tests.Get("/:id/executions/latest-success", s.GetLatestExecutionHandler(testkube.ExecutionStatusPassed))
+	tests.Get("/:id/executions/trends", s.GetExecutionsTrendHandler())
+	tests.Get("/:id/executions/number/:number", s.GetExecutionHandler())
 	tests.Get("/:id/executions/:executionID", s.GetExecutionHandler())
 	tests.Delete("/:id/executions/:executionID", s.AbortExecutionHandler())
 
@@ -237,14 +438,20 @@ func (s TestkubeAPI) Init() {
 	testsuites.Get("/:id", s.GetTestSuiteHandler())
 	testsuites.Delete("/:id", s.DeleteTestSuiteHandler())
 
-	testsuites.Post("/:id/executions", s.ExecuteTestSuitesHandler())
+	testsuites.Post("/:id/executions", drainingGuard, executionLimiter, s.ExecuteTestSuitesHandler())
 	testsuites.Get("/:id/executions", s.ListTestSuiteExecutionsHandler())
 	testsuites.Get("/:id/executions/:executionID", s.GetTestSuiteExecutionHandler())
 
 	testExecutions := s.Routes.Group("/test-suite-executions")
 	testExecutions.Get("/", s.ListTestSuiteExecutionsHandler())
-	testExecutions.Post("/", s.ExecuteTestSuitesHandler())
+	testExecutions.Post("/", drainingGuard, executionLimiter, s.ExecuteTestSuitesHandler())
 	testExecutions.Get("/:executionID", s.GetTestSuiteExecutionHandler())
+	testExecutions.Get("/:executionID/logs", s.TestSuiteExecutionLogsHandler())
+	testExecutions.Get("/:executionID/watch", s.WatchTestSuiteExecutionHandler())
+	testExecutions.Post("/:executionID/abort", s.AbortTestSuiteExecutionHandler())
+	testExecutions.Post("/:executionID/rerun", drainingGuard, executionLimiter, s.RerunTestSuiteExecutionHandler())
+	testExecutions.Post("/:executionID/steps/:stepIndex/approve", s.ApproveTestSuiteStepHandler())
+	testExecutions.Get("/:executionID/compliance-report", s.GetComplianceReportHandler())
 
 	testSuiteWithExecutions := s.Routes.Group("/test-suite-with-executions")
 	testSuiteWithExecutions.Get("/", s.ListTestSuiteWithExecutionsHandler())
@@ -253,9 +460,39 @@ func (s TestkubeAPI) Init() {
 	labels := s.Routes.Group("/labels")
 	labels.Get("/", s.ListLabelsHandler())
 
+	reports := s.Routes.Group("/reports")
+	reports.Get("/flaky-tests", s.ListFlakyTestsHandler())
+	reports.Get("/summary", s.GetSummaryReportHandler())
+	reports.Get("/errors", s.GetErrorsReportHandler())
+	reports.Get("/costs", s.GetCostsReportHandler())
+
+	s.Routes.Post("/slack/interactions", s.SlackInteractionsHandler())
+
+	s.Routes.Get("/status/overview", s.GetStatusOverviewHandler())
+
+	s.Routes.Get("/quotas", s.ListQuotasHandler())
+
+	s.Routes.Get("/config", s.GetConfigHandler())
+	s.Routes.Patch("/config", s.UpdateConfigHandler())
+
+	admin := s.Routes.Group("/admin")
+	admin.Post("/backup", s.BackupHandler())
+	admin.Post("/restore", s.RestoreHandler())
+
 	s.EventsEmitter.RunWorkers()
 	s.HandleEmitterLogs()
 
+	if s.flakinessConfig.AutoQuarantine {
+		go s.RunFlakinessAnalyzer(s.backgroundContext())
+	}
+
+	go s.RunRetentionCleaner(s.backgroundContext())
+	go s.RunExecutionsArchiver(s.backgroundContext())
+	go s.RunExecutionsReconciler(s.backgroundContext())
+	go s.RunLeaseRenewer(s.backgroundContext())
+	go s.RunNotificationsDigest(s.backgroundContext())
+	go s.RunIncidentAlerting(s.backgroundContext())
+
 	s.Log.Infow("Testkube API configured", "namespace", s.Namespace, "clusterId", s.ClusterID)
 }
 
@@ -274,9 +511,36 @@ func (s TestkubeAPI) HandleEmitterLogs() {
 
 func (s TestkubeAPI) InfoHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		notifiers := []string{"webhook"}
+		if slacknotifier.IsConfigured() {
+			notifiers = append(notifiers, "slack")
+		}
+
+		authMode := os.Getenv("TESTKUBE_AUTH_MODE")
+		if authMode == "" {
+			authMode = "none"
+		}
+
+		var executorTypes []string
+		executors, err := s.ExecutorsClient.List("")
+		if err != nil {
+			s.Logger(c.Context()).Warnw("listing executors for server info", "error", err)
+		} else {
+			for _, executor := range executors.Items {
+				executorTypes = append(executorTypes, executor.Spec.Types...)
+			}
+		}
+
 		return c.JSON(testkube.ServerInfo{
-			Commit:  api.Commit,
-			Version: api.Version,
+			Commit:        api.Commit,
+			Version:       api.Version,
+			SchemaVersion: api.SchemaVersion,
+			Features: &testkube.Features{
+				ArtifactStorage: s.storageParams.Endpoint != "",
+				Notifiers:       notifiers,
+				AuthMode:        authMode,
+			},
+			ExecutorTypes: executorTypes,
 		})
 	}
 }
@@ -301,7 +565,8 @@ func (s TestkubeAPI) RoutesHandler() fiber.Handler {
 // currently filters for e.g. tests are done "by hand"
 func getFilterFromRequest(c *fiber.Ctx) result.Filter {
 
-	filter := result.NewExecutionsFilter()
+	// executions from request are mapped to summaries, so heavy fields aren't read
+	filter := result.NewExecutionsFilter().WithExcludedFields(result.SummaryExcludedFields())
 
 	// id for /tests/ID/executions
 	testName := c.Params("id", "")
@@ -353,6 +618,32 @@ func getFilterFromRequest(c *fiber.Ctx) result.Filter {
 		filter = filter.WithSelector(selector)
 	}
 
+	groupId := c.Query("groupId")
+	if groupId != "" {
+		filter = filter.WithGroupId(groupId)
+	}
+
+	if digest := c.Query("executorImageDigest"); digest != "" {
+		filter = filter.WithExecutorImageDigest(digest)
+	}
+
+	if nodeName := c.Query("nodeName"); nodeName != "" {
+		filter = filter.WithNodeName(nodeName)
+	}
+
+	if version := c.Query("kubernetesVersion"); version != "" {
+		filter = filter.WithKubernetesVersion(version)
+	}
+
+	if version := c.Query("runnerVersion"); version != "" {
+		filter = filter.WithRunnerVersion(version)
+	}
+
+	project := getProject(c)
+	if project != "" {
+		filter = filter.WithProject(project)
+	}
+
 	return filter
 }
 
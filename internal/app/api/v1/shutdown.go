@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// drainingRetryAfter is a number of seconds clients should wait before retrying rejected execution on other replica
+const drainingRetryAfter = "5"
+
+var errShuttingDown = errors.New("API server is shutting down")
+
+// shutdownState is shared between API copies as handlers have value receivers
+type shutdownState struct {
+	draining int32
+	// ctx is cancelled on shutdown to stop background loops
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newShutdownState() *shutdownState {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &shutdownState{ctx: ctx, cancel: cancel}
+}
+
+// isDraining checks if server stopped accepting new executions
+func (s *shutdownState) isDraining() bool {
+	return s != nil && atomic.LoadInt32(&s.draining) == 1
+}
+
+func (s *shutdownState) drain() {
+	atomic.StoreInt32(&s.draining, 1)
+	s.cancel()
+}
+
+// backgroundContext returns context of background loops cancelled on shutdown
+func (s TestkubeAPI) backgroundContext() context.Context {
+	if s.shutdown == nil {
+		return context.Background()
+	}
+
+	return s.shutdown.ctx
+}
+
+// streamContext returns context of streamed response done when request context is done or server shuts down, so
+// streams waiting for changes don't hold graceful shutdown, returned cancel function must be called when stream ends
+func (s TestkubeAPI) streamContext(requestCtx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(requestCtx)
+	go func() {
+		select {
+		case <-s.backgroundContext().Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
+
+// Run starts API server, on SIGTERM new executions are rejected and in-flight requests are finished before exit,
+// executions not finished in shutdown timeout are recovered by executions reconciler of next API server
+func (s TestkubeAPI) Run() error {
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.HTTPServer.Run()
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+	defer signal.Stop(signals)
+
+	select {
+	case err := <-errs:
+		return err
+	case sig := <-signals:
+		s.Log.Infow("shutting down API server", "signal", sig.String(), "timeout", s.Config.ShutdownTimeout)
+	}
+
+	s.shutdown.drain()
+	start := time.Now()
+	if err := s.HTTPServer.Shutdown(s.Config.ShutdownTimeout); err != nil {
+		s.Log.Warnw("API server shutdown not graceful, running executions will be reconciled after restart", "error", err)
+		return nil
+	}
+
+	s.Log.Infow("API server stopped", "duration", time.Since(start).String())
+	return nil
+}
+
+// RejectWhenDraining returns middleware rejecting new executions when server is shutting down
+func (s TestkubeAPI) RejectWhenDraining() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if s.shutdown.isDraining() {
+			c.Set(fiber.HeaderRetryAfter, drainingRetryAfter)
+			return s.Warn(c, http.StatusServiceUnavailable, errShuttingDown)
+		}
+
+		return c.Next()
+	}
+}
+
+// newShutdownExecution returns execution which couldn't be started as server started shutting down
+func newShutdownExecution(execution testkube.Execution) testkube.Execution {
+	execution.ExecutionResult = &testkube.ExecutionResult{
+		Status:       testkube.ExecutionStatusAborted,
+		ErrorMessage: errShuttingDown.Error() + ", execution wasn't started, run it again",
+	}
+
+	return execution
+}
@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/server"
+)
+
+func TestRejectWhenDraining(t *testing.T) {
+	s := TestkubeAPI{HTTPServer: server.NewServer(server.Config{}), shutdown: newShutdownState()}
+	s.Mux.Post("/executions", s.RejectWhenDraining(), func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusCreated)
+	})
+
+	resp, err := s.Mux.Test(httptest.NewRequest(http.MethodPost, "/executions", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	s.shutdown.drain()
+	assert.Error(t, s.backgroundContext().Err())
+
+	resp, err = s.Mux.Test(httptest.NewRequest(http.MethodPost, "/executions", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, drainingRetryAfter, resp.Header.Get(fiber.HeaderRetryAfter))
+}
+
+func TestNewShutdownExecution(t *testing.T) {
+	execution := newShutdownExecution(testkube.Execution{Id: "1", ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusQueued}})
+
+	assert.Equal(t, "1", execution.Id)
+	assert.True(t, execution.ExecutionResult.IsAborted())
+	assert.Contains(t, execution.ExecutionResult.ErrorMessage, "shutting down")
+}
+
+func TestStreamContext(t *testing.T) {
+	s := TestkubeAPI{shutdown: newShutdownState()}
+
+	requestCtx, cancelRequest := context.WithCancel(context.Background())
+	ctx, cancel := s.streamContext(requestCtx)
+	defer cancel()
+	cancelRequest()
+	<-ctx.Done()
+
+	ctx, cancel = s.streamContext(context.Background())
+	defer cancel()
+	s.shutdown.drain()
+	<-ctx.Done()
+}
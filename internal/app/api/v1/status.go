@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const (
+	// defaultStatusOverviewFailures is a default number of recent failures in status overview
+	defaultStatusOverviewFailures = 5
+	// maxStatusOverviewFailures is a maximal number of recent failures in status overview
+	maxStatusOverviewFailures = 100
+)
+
+// newStatusOverview returns overview with tests counted by status of their latest executions
+func newStatusOverview(testNames []string, latest []testkube.Execution) testkube.StatusOverview {
+	overview := testkube.StatusOverview{
+		Tests:               int32(len(testNames)),
+		TestsByLatestStatus: map[string]int32{},
+		RecentFailures:      []testkube.ExecutionSummary{},
+	}
+
+	executed := map[string]bool{}
+	for _, execution := range latest {
+		if execution.ExecutionResult == nil || execution.ExecutionResult.Status == nil {
+			continue
+		}
+
+		executed[execution.TestName] = true
+		overview.TestsByLatestStatus[string(*execution.ExecutionResult.Status)]++
+	}
+
+	for _, name := range testNames {
+		if !executed[name] {
+			overview.TestsWithoutExecutions++
+		}
+	}
+
+	return overview
+}
+
+// GetStatusOverviewHandler returns aggregated status of tests and executions, so dashboard homepage needs one call
+func (s TestkubeAPI) GetStatusOverviewHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		failures, err := strconv.Atoi(c.Query("failures", strconv.Itoa(defaultStatusOverviewFailures)))
+		if err != nil || failures < 0 || failures > maxStatusOverviewFailures {
+			return s.Error(c, http.StatusBadRequest, fmt.Errorf("invalid number of failures %s, should be from 0 to %d",
+				c.Query("failures"), maxStatusOverviewFailures))
+		}
+
+		ctx := c.Context()
+		project := getProject(c)
+		tests, err := s.TestsClient.List(projectSelector("", project))
+		if err != nil {
+			return s.Error(c, http.StatusBadGateway, err)
+		}
+
+		names := make([]string, len(tests.Items))
+		for i, test := range tests.Items {
+			names[i] = test.Name
+		}
+
+		latest, err := s.ExecutionResults.GetLatestByTests(ctx, names, result.SummaryExcludedFields()...)
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't get latest executions: %w", err))
+		}
+
+		overview := newStatusOverview(names, latest)
+		// totals of all executions are read from counters, counters aren't kept per project, so executions of project
+		// are aggregated by status
+		totals, err := s.ExecutionResults.GetExecutionTotals(ctx, false, result.NewExecutionsFilter().WithProject(project))
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't count executions: %w", err))
+		}
+		overview.RunningExecutions = totals.Running
+		overview.QueuedExecutions = totals.Queued
+
+		overview.GeneratedAt = time.Now()
+		last24h := result.NewExecutionsFilter().WithProject(project).WithStartDate(overview.GeneratedAt.Add(-24 * time.Hour))
+		if totals, err = s.ExecutionResults.GetExecutionTotals(ctx, false, last24h); err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't count executions of last 24 hours: %w", err))
+		}
+		overview.ExecutionsLast24h = totals.Results
+		if completed := totals.Passed + totals.Failed; completed > 0 {
+			overview.PassRateLast24h = float64(totals.Passed) / float64(completed)
+		}
+
+		if failures > 0 {
+			filter := result.NewExecutionsFilter().
+				WithProject(project).
+				WithStatus(string(testkube.FAILED_ExecutionStatus) + "," + string(testkube.TIMEOUT_ExecutionStatus)).
+				WithPageSize(failures).
+				WithExcludedFields(result.SummaryExcludedFields())
+			executions, err := s.ExecutionResults.GetExecutions(ctx, filter)
+			if err != nil {
+				return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't get recent failures: %w", err))
+			}
+			overview.RecentFailures = mapExecutionsToExecutionSummary(executions)
+		}
+
+		return c.JSON(overview)
+	}
+}
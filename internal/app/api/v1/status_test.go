@@ -0,0 +1,26 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestNewStatusOverview(t *testing.T) {
+	latest := func(testName string, status testkube.ExecutionStatus) testkube.Execution {
+		return testkube.Execution{TestName: testName, ExecutionResult: &testkube.ExecutionResult{Status: testkube.StatusPtr(status)}}
+	}
+
+	overview := newStatusOverview([]string{"api", "smoke", "e2e", "load"}, []testkube.Execution{
+		latest("api", testkube.PASSED_ExecutionStatus),
+		latest("smoke", testkube.PASSED_ExecutionStatus),
+		latest("e2e", testkube.FAILED_ExecutionStatus),
+	})
+
+	assert.Equal(t, int32(4), overview.Tests)
+	assert.Equal(t, map[string]int32{"passed": 2, "failed": 1}, overview.TestsByLatestStatus)
+	assert.Equal(t, int32(1), overview.TestsWithoutExecutions)
+	assert.NotNil(t, overview.RecentFailures)
+}
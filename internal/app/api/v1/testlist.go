@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	testsv2 "github.com/kubeshop/testkube-operator/apis/tests/v2"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const (
+	// totalCountHeader is a header with number of list items before paging
+	totalCountHeader = "X-Total-Count"
+
+	sortByName    = "name"
+	sortByLastRun = "lastRun"
+	sortByStatus  = "status"
+)
+
+// testListOptions are paging and sorting options of test lists, zero page size returns all tests
+type testListOptions struct {
+	Page     int
+	PageSize int
+	SortBy   string
+}
+
+// needsExecutions returns true when latest executions of all tests are needed before paging
+func (o testListOptions) needsExecutions() bool {
+	return o.SortBy == sortByLastRun || o.SortBy == sortByStatus
+}
+
+func parseTestListOptions(c *fiber.Ctx) (options testListOptions, err error) {
+	options.SortBy = c.Query("sortBy", sortByName)
+	switch options.SortBy {
+	case sortByName, sortByLastRun, sortByStatus:
+	default:
+		return options, fmt.Errorf("invalid sortBy %s, supported values are %s, %s and %s", options.SortBy, sortByName, sortByLastRun, sortByStatus)
+	}
+
+	if page := c.Query("page"); page != "" {
+		if options.Page, err = strconv.Atoi(page); err != nil || options.Page < 0 {
+			return options, fmt.Errorf("invalid page %s", page)
+		}
+	}
+
+	if pageSize := c.Query("pageSize"); pageSize != "" {
+		if options.PageSize, err = strconv.Atoi(pageSize); err != nil || options.PageSize < 0 {
+			return options, fmt.Errorf("invalid pageSize %s", pageSize)
+		}
+	}
+
+	return options, nil
+}
+
+// filterTestsByLatestStatus keeps tests with latest execution in given statuses, filtered in place in linear time
+func filterTestsByLatestStatus(tests []testsv2.Test, latest map[string]testkube.Execution,
+	statusMap map[testkube.ExecutionStatus]struct{}) []testsv2.Test {
+	filtered := tests[:0]
+	for _, test := range tests {
+		if status := latestStatus(latest, test.Name); status != "" {
+			if _, ok := statusMap[status]; ok {
+				filtered = append(filtered, test)
+			}
+		}
+	}
+
+	return filtered
+}
+
+// sortTests sorts tests by name, by latest execution start from the newest or by latest execution status,
+// tests without executions are last and ties are sorted by name
+func sortTests(tests []testsv2.Test, sortBy string, latest map[string]testkube.Execution) {
+	sort.SliceStable(tests, func(i, j int) bool {
+		first, second := tests[i].Name, tests[j].Name
+		switch sortBy {
+		case sortByLastRun:
+			firstExecution, firstOk := latest[first]
+			secondExecution, secondOk := latest[second]
+			if firstOk != secondOk {
+				return firstOk
+			}
+			if !firstExecution.StartTime.Equal(secondExecution.StartTime) {
+				return firstExecution.StartTime.After(secondExecution.StartTime)
+			}
+		case sortByStatus:
+			firstStatus, secondStatus := latestStatus(latest, first), latestStatus(latest, second)
+			if (firstStatus == "") != (secondStatus == "") {
+				return firstStatus != ""
+			}
+			if firstStatus != secondStatus {
+				return firstStatus < secondStatus
+			}
+		}
+
+		return first < second
+	})
+}
+
+// pageTests returns tests of given page, zero page size returns all tests
+func pageTests(tests []testsv2.Test, page, pageSize int) []testsv2.Test {
+	if pageSize == 0 {
+		return tests
+	}
+
+	start := page * pageSize
+	if start >= len(tests) {
+		return nil
+	}
+
+	end := start + pageSize
+	if end > len(tests) {
+		end = len(tests)
+	}
+
+	return tests[start:end]
+}
+
+func latestStatus(latest map[string]testkube.Execution, testName string) testkube.ExecutionStatus {
+	execution, ok := latest[testName]
+	if !ok || execution.ExecutionResult == nil || execution.ExecutionResult.Status == nil {
+		return ""
+	}
+
+	return *execution.ExecutionResult.Status
+}
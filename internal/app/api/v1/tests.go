@@ -1,12 +1,16 @@
 package v1
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	testsv2 "github.com/kubeshop/testkube-operator/apis/tests/v2"
+	"github.com/kubeshop/testkube/internal/pkg/api/kubecache"
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/kubeshop/testkube/pkg/cronjob"
 	testsmapper "github.com/kubeshop/testkube/pkg/mapper/tests"
@@ -14,7 +18,10 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/kubeshop/testkube/pkg/jobs"
+	"github.com/kubeshop/testkube/pkg/problem"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // GetTestHandler is method for getting an existing test
@@ -24,12 +31,16 @@ func (s TestkubeAPI) GetTestHandler() fiber.Handler {
 		crTest, err := s.TestsClient.Get(name)
 		if err != nil {
 			if errors.IsNotFound(err) {
-				return s.Error(c, http.StatusNotFound, err)
+				return s.Error(c, http.StatusNotFound, problem.WithCode(problem.CodeTestNotFound, err))
 			}
 
 			return s.Error(c, http.StatusBadGateway, err)
 		}
 
+		if !isInProject(crTest.Labels, getProject(c)) {
+			return s.Error(c, http.StatusNotFound, problem.WithCode(problem.CodeTestNotFound, fmt.Errorf("test %s not found", name)))
+		}
+
 		test := testsmapper.MapTestCRToAPI(*crTest)
 
 		return c.JSON(test)
@@ -43,12 +54,16 @@ func (s TestkubeAPI) GetTestWithExecutionHandler() fiber.Handler {
 		crTest, err := s.TestsClient.Get(name)
 		if err != nil {
 			if errors.IsNotFound(err) {
-				return s.Error(c, http.StatusNotFound, err)
+				return s.Error(c, http.StatusNotFound, problem.WithCode(problem.CodeTestNotFound, err))
 			}
 
 			return s.Error(c, http.StatusBadGateway, err)
 		}
 
+		if !isInProject(crTest.Labels, getProject(c)) {
+			return s.Error(c, http.StatusNotFound, problem.WithCode(problem.CodeTestNotFound, fmt.Errorf("test %s not found", name)))
+		}
+
 		ctx := c.Context()
 		execution, err := s.ExecutionResults.GetLatestByTest(ctx, name)
 		if err != nil && err != mongo.ErrNoDocuments {
@@ -68,35 +83,79 @@ func (s TestkubeAPI) GetTestWithExecutionHandler() fiber.Handler {
 }
 
 func (s TestkubeAPI) getFilteredTestList(c *fiber.Ctx) (*testsv2.TestList, error) {
-	// TODO filters looks messy need to introduce some common Filter object for Kubernetes query for List like objects
-	crTests, err := s.TestsClient.List(c.Query("selector"))
-	if err != nil {
-		return nil, err
+	selector := projectSelector(c.Query("selector"), getProject(c))
+	testType := c.Query("type")
+
+	var crTests *testsv2.TestList
+	exactType := testType != "" && c.Query("exactType") == "true"
+	if exactType && kubecache.HasIndex(s.TestsClient.Client, &testsv2.Test{}, kubecache.TestTypeField) {
+		// exact type is pushed down to informer cache index
+		list, err := s.listTestsByType(selector, testType)
+		if err != nil {
+			return nil, err
+		}
+
+		crTests, testType = list, ""
+	}
+
+	if crTests == nil {
+		list, err := s.TestsClient.List(selector)
+		if err != nil {
+			return nil, err
+		}
+		crTests = list
 	}
 
 	search := c.Query("textSearch")
-	if search != "" {
-		// filter items array
-		for i := len(crTests.Items) - 1; i >= 0; i-- {
-			if !strings.Contains(crTests.Items[i].Name, search) {
-				crTests.Items = append(crTests.Items[:i], crTests.Items[i+1:]...)
+	if search != "" || testType != "" {
+		// filter items array in place
+		items := crTests.Items[:0]
+		for _, test := range crTests.Items {
+			if strings.Contains(test.Name, search) && matchesTestType(test.Spec.Type_, testType, exactType) {
+				items = append(items, test)
 			}
 		}
+		crTests.Items = items
 	}
 
-	testType := c.Query("type")
-	if testType != "" {
-		// filter items array
-		for i := len(crTests.Items) - 1; i >= 0; i-- {
-			if !strings.Contains(crTests.Items[i].Spec.Type_, testType) {
-				crTests.Items = append(crTests.Items[:i], crTests.Items[i+1:]...)
+	if owner := c.Query("owner"); owner != "" {
+		items := crTests.Items[:0]
+		for _, test := range crTests.Items {
+			if testkube.OwnershipFromAnnotations(test.Annotations).IsOwnedBy(owner) {
+				items = append(items, test)
 			}
 		}
+		crTests.Items = items
 	}
 
 	return crTests, nil
 }
 
+// matchesTestType checks test type is equal to filter type or contains it, empty filter type matches all tests
+func matchesTestType(testType, filter string, exact bool) bool {
+	if exact && filter != "" {
+		return testType == filter
+	}
+
+	return strings.Contains(testType, filter)
+}
+
+// listTestsByType lists tests of exact type with label selector from informer cache index
+func (s TestkubeAPI) listTestsByType(selector, testType string) (*testsv2.TestList, error) {
+	labelSelector, err := labels.Parse(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &testsv2.TestList{}
+	err = s.TestsClient.Client.List(context.Background(), list,
+		client.InNamespace(s.TestsClient.Namespace),
+		client.MatchingLabelsSelector{Selector: labelSelector},
+		client.MatchingFields{kubecache.TestTypeField: testType},
+	)
+	return list, err
+}
+
 // ListTestsHandler is a method for getting list of all available tests
 func (s TestkubeAPI) ListTestsHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -111,57 +170,72 @@ func (s TestkubeAPI) ListTestsHandler() fiber.Handler {
 	}
 }
 
-// ListTestWithExecutionsHandler is a method for getting list of all available test with latest executions
+// ListTestWithExecutionsHandler is a method for getting list of all available test with latest executions,
+// tests are filtered, sorted and paged before mapping, so only latest executions of returned page are loaded in full
 func (s TestkubeAPI) ListTestWithExecutionsHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		crTests, err := s.getFilteredTestList(c)
+		options, err := parseTestListOptions(c)
 		if err != nil {
-			return s.Error(c, http.StatusBadGateway, err)
+			return s.Error(c, http.StatusBadRequest, err)
 		}
 
-		tests := testsmapper.MapTestListKubeToAPI(*crTests)
-		ctx := c.Context()
-		testWithExecutions := make([]testkube.TestWithExecution, len(tests))
-		testNames := make([]string, len(tests))
-		for i := range tests {
-			testNames[i] = tests[i].Name
+		var statusMap map[testkube.ExecutionStatus]struct{}
+		if status := c.Query("status"); status != "" {
+			statusList, err := testkube.ParseExecutionStatusList(status, ",")
+			if err != nil {
+				return s.Error(c, http.StatusBadRequest, fmt.Errorf("execution status filter invalid: %w", err))
+			}
+			statusMap = statusList.ToMap()
 		}
 
-		executions, err := s.ExecutionResults.GetLatestByTests(ctx, testNames)
-		if err != nil && err != mongo.ErrNoDocuments {
-			return s.Error(c, http.StatusInternalServerError, err)
+		// heavy fields of latest executions are returned only when requested
+		var include []string
+		if c.Query("include") != "" {
+			include = strings.Split(c.Query("include"), ",")
 		}
 
-		executionMap := make(map[string]testkube.Execution, len(executions))
-		for i := range executions {
-			executionMap[executions[i].TestName] = executions[i]
+		excludedFields, err := result.ExcludedFields(include)
+		if err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
 		}
 
-		for i := range tests {
-			testWithExecutions[i].Test = &tests[i]
-			if execution, ok := executionMap[tests[i].Name]; ok {
-				testWithExecutions[i].LatestExecution = &execution
-			}
+		crTests, err := s.getFilteredTestList(c)
+		if err != nil {
+			return s.Error(c, http.StatusBadGateway, err)
 		}
 
-		status := c.Query("status")
-		if status != "" {
-			statusList, err := testkube.ParseExecutionStatusList(status, ",")
+		ctx := c.Context()
+		items := crTests.Items
+		var latest map[string]testkube.Execution
+		if statusMap != nil || options.needsExecutions() {
+			// summaries of latest executions of all tests are enough for filtering and sorting
+			latest, err = s.getLatestExecutions(ctx, testNames(items), result.SummaryExcludedFields()...)
 			if err != nil {
-				return s.Error(c, http.StatusBadRequest, fmt.Errorf("execution status filter invalid: %w", err))
+				return s.Error(c, http.StatusInternalServerError, err)
 			}
 
-			statusMap := statusList.ToMap()
-			// filter items array
-			for i := len(testWithExecutions) - 1; i >= 0; i-- {
-				if testWithExecutions[i].LatestExecution != nil && testWithExecutions[i].LatestExecution.ExecutionResult != nil &&
-					testWithExecutions[i].LatestExecution.ExecutionResult.Status != nil {
-					if _, ok := statusMap[*testWithExecutions[i].LatestExecution.ExecutionResult.Status]; ok {
-						continue
-					}
-				}
+			if statusMap != nil {
+				items = filterTestsByLatestStatus(items, latest, statusMap)
+			}
+		}
+
+		sortTests(items, options.SortBy, latest)
+		c.Set(totalCountHeader, strconv.Itoa(len(items)))
+		items = pageTests(items, options.Page, options.PageSize)
 
-				testWithExecutions = append(testWithExecutions[:i], testWithExecutions[i+1:]...)
+		if latest == nil || len(include) != 0 {
+			latest, err = s.getLatestExecutions(ctx, testNames(items), excludedFields...)
+			if err != nil {
+				return s.Error(c, http.StatusInternalServerError, err)
+			}
+		}
+
+		testWithExecutions := make([]testkube.TestWithExecution, len(items))
+		for i := range items {
+			test := testsmapper.MapTestCRToAPI(items[i])
+			testWithExecutions[i].Test = &test
+			if execution, ok := latest[test.Name]; ok {
+				testWithExecutions[i].LatestExecution = &execution
 			}
 		}
 
@@ -169,6 +243,30 @@ func (s TestkubeAPI) ListTestWithExecutionsHandler() fiber.Handler {
 	}
 }
 
+// getLatestExecutions returns latest executions of tests by test name
+func (s TestkubeAPI) getLatestExecutions(ctx context.Context, testNames []string, excludedFields ...string) (map[string]testkube.Execution, error) {
+	executions, err := s.ExecutionResults.GetLatestByTests(ctx, testNames, excludedFields...)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	latest := make(map[string]testkube.Execution, len(executions))
+	for i := range executions {
+		latest[executions[i].TestName] = executions[i]
+	}
+
+	return latest, nil
+}
+
+func testNames(tests []testsv2.Test) []string {
+	names := make([]string, len(tests))
+	for i := range tests {
+		names[i] = tests[i].Name
+	}
+
+	return names
+}
+
 // CreateTestHandler creates new test CR based on test content
 func (s TestkubeAPI) CreateTestHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -179,7 +277,49 @@ func (s TestkubeAPI) CreateTestHandler() fiber.Handler {
 			return s.Error(c, http.StatusBadRequest, err)
 		}
 
-		s.Log.Infow("creating test", "request", request)
+		if err = validateDataFile(request.DataFile); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = testkube.ValidateContentUriOptions(request.Content); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = testkube.ValidateContentFiles(request.Content); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = testkube.ValidateMaintenanceWindows(request.MaintenanceWindows); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = s.validateTestPlacement(request.Type_, request.Placement); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = s.validateLogCollection(request.LogCollection); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = s.validatePrechecks(request.Prechecks); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = request.Newman.Validate(); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = s.validateTestDependencies(request.Name, request.Dependencies); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = s.validateExecutionTriggers(getProject(c), testkube.ExecutionTriggerSource(testkube.ExecutionTriggerTypeTest, request.Name),
+			request.Triggers); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		request.Labels = withProjectLabel(request.Labels, getProject(c))
+		s.Logger(c.Context()).Infow("creating test", "request", request)
 
 		testSpec := testsmapper.MapToSpec(request)
 		testSpec.Namespace = s.Namespace
@@ -210,7 +350,48 @@ func (s TestkubeAPI) UpdateTestHandler() fiber.Handler {
 			return s.Error(c, http.StatusBadRequest, err)
 		}
 
-		s.Log.Infow("updating test", "request", request)
+		if err = validateDataFile(request.DataFile); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = testkube.ValidateContentUriOptions(request.Content); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = testkube.ValidateContentFiles(request.Content); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = testkube.ValidateMaintenanceWindows(request.MaintenanceWindows); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = s.validateTestPlacement(request.Type_, request.Placement); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = s.validateLogCollection(request.LogCollection); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = s.validatePrechecks(request.Prechecks); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = request.Newman.Validate(); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = s.validateTestDependencies(request.Name, request.Dependencies); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = s.validateExecutionTriggers(getProject(c), testkube.ExecutionTriggerSource(testkube.ExecutionTriggerTypeTest, request.Name),
+			request.Triggers); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		s.Logger(c.Context()).Infow("updating test", "request", request)
 
 		// we need to get resource first and load its metadata.ResourceVersion
 		test, err := s.TestsClient.Get(request.Name)
@@ -218,6 +399,19 @@ func (s TestkubeAPI) UpdateTestHandler() fiber.Handler {
 			return s.Error(c, http.StatusBadGateway, err)
 		}
 
+		project := getProject(c)
+		if !isInProject(test.Labels, project) {
+			return s.Error(c, http.StatusNotFound, problem.WithCode(problem.CodeTestNotFound, fmt.Errorf("test %s not found", request.Name)))
+		}
+
+		// enabled state is kept, if it's not set in request
+		if request.Enabled == nil {
+			enabled := !testkube.IsDisabled(test.Labels)
+			request.Enabled = &enabled
+		}
+
+		request.Labels = testkube.WithEnabledLabel(withProjectLabel(request.Labels, project), request.Enabled)
+
 		// delete cron job, if schedule is cleaned
 		if test.Spec.Schedule != "" {
 			cronJob, err := s.CronJobClient.Get(cronjob.GetMetadataName(request.Name, testResourceURI))
@@ -231,6 +425,8 @@ func (s TestkubeAPI) UpdateTestHandler() fiber.Handler {
 						return s.Error(c, http.StatusBadGateway, err)
 					}
 				} else {
+					suspend := !*request.Enabled
+					cronJob.Spec.Suspend = &suspend
 					if err = s.CronJobClient.UpdateLabels(cronJob, test.Labels, request.Labels); err != nil {
 						return s.Error(c, http.StatusBadGateway, err)
 					}
@@ -242,6 +438,21 @@ func (s TestkubeAPI) UpdateTestHandler() fiber.Handler {
 		testSpec := testsmapper.MapToSpec(request)
 		test.Spec = testSpec.Spec
 		test.Labels = request.Labels
+		annotations := append([]string{testkube.SecretMountsAnnotation, testkube.DataFileAnnotation, testkube.SecretParamsAnnotation,
+			testkube.MaintenanceWindowsAnnotation, testkube.ContentUriOptionsAnnotation, testkube.PlacementAnnotation,
+			testkube.LogCollectionAnnotation, testkube.PrechecksAnnotation, testkube.DependenciesAnnotation,
+			testkube.TriggersAnnotation, testkube.NewmanOptionsAnnotation},
+			testkube.OwnershipAnnotations...)
+		for _, annotation := range annotations {
+			if value, ok := testSpec.Annotations[annotation]; ok {
+				if test.Annotations == nil {
+					test.Annotations = map[string]string{}
+				}
+				test.Annotations[annotation] = value
+			} else {
+				delete(test.Annotations, annotation)
+			}
+		}
 		test, err = s.TestsClient.Update(test)
 
 		s.Metrics.IncUpdateTest(test.Spec.Type_, err)
@@ -264,10 +475,25 @@ func (s TestkubeAPI) UpdateTestHandler() fiber.Handler {
 func (s TestkubeAPI) DeleteTestHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		name := c.Params("id")
+		if project := getProject(c); project != "" {
+			test, err := s.TestsClient.Get(name)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestNotFound, err))
+				}
+
+				return s.Error(c, http.StatusBadGateway, err)
+			}
+
+			if !isInProject(test.Labels, project) {
+				return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestNotFound, fmt.Errorf("test %s not found", name)))
+			}
+		}
+
 		err := s.TestsClient.Delete(name)
 		if err != nil {
 			if errors.IsNotFound(err) {
-				return s.Warn(c, http.StatusNotFound, err)
+				return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestNotFound, err))
 			}
 
 			return s.Error(c, http.StatusBadGateway, err)
@@ -295,7 +521,7 @@ func (s TestkubeAPI) DeleteTestHandler() fiber.Handler {
 func (s TestkubeAPI) DeleteTestsHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var err error
-		selector := c.Query("selector")
+		selector := projectSelector(c.Query("selector"), getProject(c))
 		if selector == "" {
 			err = s.TestsClient.DeleteAll()
 		} else {
@@ -304,7 +530,7 @@ func (s TestkubeAPI) DeleteTestsHandler() fiber.Handler {
 
 		if err != nil {
 			if errors.IsNotFound(err) {
-				return s.Warn(c, http.StatusNotFound, err)
+				return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestNotFound, err))
 			}
 
 			return s.Error(c, http.StatusBadGateway, err)
@@ -328,6 +554,115 @@ func (s TestkubeAPI) DeleteTestsHandler() fiber.Handler {
 	}
 }
 
+// PurgeTestJobsHandler deletes finished Kubernetes jobs of a test
+func (s TestkubeAPI) PurgeTestJobsHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		name := c.Params("id")
+		test, err := s.TestsClient.Get(name)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestNotFound, err))
+			}
+
+			return s.Error(c, http.StatusBadGateway, err)
+		}
+
+		if !isInProject(test.Labels, getProject(c)) {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestNotFound, fmt.Errorf("test %s not found", name)))
+		}
+
+		count, err := s.Executor.PurgeJobs(name)
+		if err != nil {
+			return s.Error(c, http.StatusBadGateway, fmt.Errorf("can't purge jobs for test %s: %w", name, err))
+		}
+
+		s.Logger(c.Context()).Infow("test jobs purged", "test", name, "count", count)
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// validateDataFile checks iteration data file is a single file
+func validateDataFile(dataFile *testkube.TestContent) error {
+	if dataFile == nil {
+		return nil
+	}
+
+	switch testkube.TestContentType(dataFile.Type_) {
+	case testkube.TestContentTypeString, testkube.TestContentTypeFileURI:
+		return nil
+	case testkube.TestContentTypeGitFile:
+		if dataFile.Repository == nil {
+			return fmt.Errorf("data file repository is not set")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported data file type %s, supported types are %s, %s and %s", dataFile.Type_,
+			testkube.TestContentTypeString, testkube.TestContentTypeFileURI, testkube.TestContentTypeGitFile)
+	}
+}
+
+// validateTestPlacement checks test placement and its conflicts with placement and job template of executor of test
+// type, executor missing at the moment is checked when test is executed
+func (s TestkubeAPI) validateTestPlacement(testType string, placement *testkube.Placement) error {
+	if placement == nil {
+		return nil
+	}
+
+	if err := placement.Validate(); err != nil {
+		return err
+	}
+
+	executor, err := s.getExecutorByType(testType)
+	if err != nil {
+		return nil
+	}
+
+	merged, err := testkube.MergePlacements(testkube.PlacementFromAnnotations(executor.Annotations), placement)
+	if err != nil {
+		return fmt.Errorf("test placement conflicts with executor %s placement: %w", executor.Name, err)
+	}
+
+	jobTemplate := executor.Spec.JobTemplate
+	if jobTemplate == "" {
+		jobTemplate = s.jobTemplates.Job
+	}
+
+	if err = jobs.ValidateTemplatePlacement(jobTemplate, merged); err != nil {
+		return fmt.Errorf("test placement conflicts with executor %s: %w", executor.Name, err)
+	}
+
+	return nil
+}
+
+// validateTestDependencies checks that test requires existing tests, tests requiring each other, also through other
+// tests, are rejected as their queued executions would wait for each other
+func (s TestkubeAPI) validateTestDependencies(name string, dependencies *testkube.TestDependencies) error {
+	if err := dependencies.Validate(); err != nil || dependencies == nil {
+		return err
+	}
+
+	for _, required := range dependencies.RequiresPassing {
+		if required == name {
+			return fmt.Errorf("test %s can't require itself", name)
+		}
+
+		_, err := s.TestsClient.Get(required)
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("required test %s not found", required)
+		}
+
+		if err != nil {
+			return fmt.Errorf("can't get required test %s: %w", required, err)
+		}
+	}
+
+	if cycle := s.findDependencyCycle(name, dependencies.RequiresPassing, []string{name}, map[string]bool{}); cycle != nil {
+		return fmt.Errorf("tests can't require each other: %s", strings.Join(cycle, " -> "))
+	}
+
+	return nil
+}
+
 func GetSecretsStringData(content *testkube.TestContent) map[string]string {
 	// create secrets for test
 	stringData := map[string]string{jobs.GitUsernameSecretName: "", jobs.GitTokenSecretName: ""}
@@ -338,3 +673,16 @@ func GetSecretsStringData(content *testkube.TestContent) map[string]string {
 
 	return stringData
 }
+
+// validateLogCollection checks log collection and that application logs can be collected from its namespace
+func (s TestkubeAPI) validateLogCollection(logCollection *testkube.LogCollection) error {
+	if err := logCollection.Validate(); err != nil {
+		return err
+	}
+
+	if logCollection != nil && !s.appLogsPolicy.IsAllowedNamespace(logCollection.Namespace, s.Namespace) {
+		return fmt.Errorf("application logs can't be collected from namespace %s, allowed namespaces are set in TESTKUBE_APP_LOGS_NAMESPACES", logCollection.Namespace)
+	}
+
+	return nil
+}
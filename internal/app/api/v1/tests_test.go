@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	testsv2 "github.com/kubeshop/testkube-operator/apis/tests/v2"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestTestList(t *testing.T) {
+	now := time.Now()
+	tests := func(names ...string) (out []testsv2.Test) {
+		for _, name := range names {
+			out = append(out, testsv2.Test{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		}
+		return out
+	}
+
+	latest := map[string]testkube.Execution{
+		"api":     {StartTime: now.Add(-time.Hour), ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed}},
+		"login":   {StartTime: now, ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusFailed}},
+		"search":  {StartTime: now.Add(-time.Minute), ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusTimeout}},
+		"pending": {StartTime: now.Add(-time.Minute)},
+	}
+
+	t.Run("filter by status", func(t *testing.T) {
+		statuses := testkube.ExecutionStatuses{testkube.FAILED_ExecutionStatus, testkube.TIMEOUT_ExecutionStatus}
+		filtered := filterTestsByLatestStatus(tests("api", "never-run", "login", "pending", "search"), latest, statuses.ToMap())
+		assert.Equal(t, []string{"login", "search"}, testNames(filtered))
+	})
+
+	t.Run("sort by name", func(t *testing.T) {
+		list := tests("search", "api", "login")
+		sortTests(list, sortByName, nil)
+		assert.Equal(t, []string{"api", "login", "search"}, testNames(list))
+	})
+
+	t.Run("sort by last run", func(t *testing.T) {
+		list := tests("never-run", "api", "search", "login", "pending")
+		sortTests(list, sortByLastRun, latest)
+		assert.Equal(t, []string{"login", "pending", "search", "api", "never-run"}, testNames(list))
+	})
+
+	t.Run("sort by status", func(t *testing.T) {
+		list := tests("never-run", "search", "api", "pending", "login")
+		sortTests(list, sortByStatus, latest)
+		assert.Equal(t, []string{"login", "api", "search", "never-run", "pending"}, testNames(list))
+	})
+
+	t.Run("page", func(t *testing.T) {
+		list := tests("a", "b", "c", "d", "e")
+		assert.Equal(t, []string{"a", "b", "c", "d", "e"}, testNames(pageTests(list, 0, 0)))
+		assert.Equal(t, []string{"c", "d"}, testNames(pageTests(list, 1, 2)))
+		assert.Equal(t, []string{"e"}, testNames(pageTests(list, 2, 2)))
+		assert.Empty(t, pageTests(list, 3, 2))
+	})
+}
+
+func TestMatchesTestType(t *testing.T) {
+	assert.True(t, matchesTestType("k6/script", "", false))
+	assert.True(t, matchesTestType("k6/script", "k6", false))
+	assert.True(t, matchesTestType("k6", "k6", false))
+	assert.False(t, matchesTestType("postman/collection", "k6", false))
+
+	assert.True(t, matchesTestType("k6", "k6", true))
+	assert.False(t, matchesTestType("k6/script", "k6", true))
+	assert.True(t, matchesTestType("k6/script", "", true))
+}
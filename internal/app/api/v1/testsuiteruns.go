@@ -0,0 +1,535 @@
+package v1
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	executorv1 "github.com/kubeshop/testkube-operator/apis/executor/v1"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/problem"
+	"github.com/kubeshop/testkube/pkg/server"
+	"github.com/kubeshop/testkube/pkg/slacknotifier"
+	"github.com/kubeshop/testkube/pkg/types"
+	"github.com/kubeshop/testkube/pkg/workerpool"
+)
+
+const (
+	// defaultSuiteStepsConcurrency is a default number of test suite steps running at once in API server
+	defaultSuiteStepsConcurrency = 50
+
+	// abortLookupAttempts and abortLookupInterval bound waiting for step execution to be stored before aborting it
+	abortLookupAttempts = 10
+	abortLookupInterval = 500 * time.Millisecond
+
+	// testSuiteWatchPollInterval is an interval of checking test suite execution changes
+	// when it isn't running in this API server
+	testSuiteWatchPollInterval = 2 * time.Second
+
+	// rerunFromFailed reruns test suite execution from its first failed step
+	rerunFromFailed = "failed"
+)
+
+type suiteStepsConfig struct {
+	// Concurrency is a number of test suite steps running at once, further steps wait for free worker
+	Concurrency int `default:"50"`
+	// ApprovalsURI is a Testkube API URI reachable by approvers, it's used in decision URIs of approval requests
+	ApprovalsURI string
+}
+
+// suiteRunsState keeps cancel functions of test suite executions running in this API server,
+// it's shared between API copies as handlers have value receivers
+type suiteRunsState struct {
+	mutex   sync.Mutex
+	cancels map[string]context.CancelFunc
+	// updates are closed and replaced when test suite execution is updated in storage
+	updates map[string]chan struct{}
+	// steps is a worker pool of test suite steps executing tests
+	steps workerpool.Pool[testkube.Test, testkube.ExecutionRequest, testkube.Execution]
+	// apiURI is a Testkube API URI used in approval requests
+	apiURI string
+}
+
+func newSuiteRunsState(config suiteStepsConfig) *suiteRunsState {
+	if config.Concurrency <= 0 {
+		config.Concurrency = defaultSuiteStepsConcurrency
+	}
+
+	return &suiteRunsState{
+		cancels: map[string]context.CancelFunc{},
+		updates: map[string]chan struct{}{},
+		steps:   workerpool.NewPool[testkube.Test, testkube.ExecutionRequest, testkube.Execution](config.Concurrency),
+		apiURI:  config.ApprovalsURI,
+	}
+}
+
+// start returns context of test suite execution cancelled by abort, done must be called when execution ends
+func (s *suiteRunsState) start(id string) (ctx context.Context, done func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if s == nil {
+		return ctx, cancel
+	}
+
+	s.mutex.Lock()
+	s.cancels[id] = cancel
+	s.updates[id] = make(chan struct{})
+	s.mutex.Unlock()
+
+	return ctx, func() {
+		s.mutex.Lock()
+		delete(s.cancels, id)
+		if updates, ok := s.updates[id]; ok {
+			close(updates)
+			delete(s.updates, id)
+		}
+		s.mutex.Unlock()
+		cancel()
+	}
+}
+
+// updated returns channel closed on next update of test suite execution running in this API server,
+// nil channel is returned for other executions
+func (s *suiteRunsState) updated(id string) <-chan struct{} {
+	if s == nil {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.updates[id]
+}
+
+// notify notifies watchers of test suite execution about its update
+func (s *suiteRunsState) notify(id string) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if updates, ok := s.updates[id]; ok {
+		close(updates)
+		s.updates[id] = make(chan struct{})
+	}
+}
+
+// approvalsURI returns Testkube API URI used in approval requests
+func (s *suiteRunsState) approvalsURI() string {
+	if s == nil {
+		return ""
+	}
+
+	return s.apiURI
+}
+
+// abort cancels test suite execution running in this API server
+func (s *suiteRunsState) abort(id string) bool {
+	if s == nil {
+		return false
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	cancel, ok := s.cancels[id]
+	if ok {
+		cancel()
+	}
+
+	return ok
+}
+
+// executeSuiteStepTest executes test of test suite step in steps worker pool, test waiting for free worker
+// isn't executed when suite is aborted and running test is aborted in executor, step timeout starts when test
+// gets a worker, so waiting for free worker isn't counted
+func (s TestkubeAPI) executeSuiteStepTest(ctx context.Context, test testkube.Test, request testkube.ExecutionRequest,
+	timeout time.Duration) (execution testkube.Execution, timedOut bool, err error) {
+	execFn := withStepTimeout(timeout, s.executeAbortableTest, &timedOut)
+	if s.suiteRuns == nil {
+		execution, err = execFn(ctx, test, request)
+		return execution, timedOut, err
+	}
+
+	response := s.suiteRuns.steps.Execute(ctx, workerpool.Request[testkube.Test, testkube.ExecutionRequest, testkube.Execution]{
+		Object:  test,
+		Options: request,
+		ExecFn:  execFn,
+	})
+	return response.Result, timedOut, response.Err
+}
+
+// withStepTimeout returns execFn cancelled after timeout, timedOut is set when test was interrupted by timeout
+// and not by test suite abort, no timeout is set for zero timeout
+func withStepTimeout(timeout time.Duration, execFn workerpool.ExecuteFn[testkube.Test, testkube.ExecutionRequest, testkube.Execution],
+	timedOut *bool) workerpool.ExecuteFn[testkube.Test, testkube.ExecutionRequest, testkube.Execution] {
+	if timeout <= 0 {
+		return execFn
+	}
+
+	return func(ctx context.Context, test testkube.Test, request testkube.ExecutionRequest) (testkube.Execution, error) {
+		timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		execution, err := execFn(timeoutCtx, test, request)
+		*timedOut = ctx.Err() == nil && timeoutCtx.Err() == context.DeadlineExceeded
+		return execution, err
+	}
+}
+
+// executeAbortableTest executes test with storage calls not bound to context, so aborted execution is still stored,
+// execution is aborted in executor when context is cancelled before it finishes
+func (s TestkubeAPI) executeAbortableTest(ctx context.Context, test testkube.Test, request testkube.ExecutionRequest) (
+	testkube.Execution, error) {
+	finished := make(chan struct{})
+	aborted := make(chan struct{})
+	go func() {
+		defer close(aborted)
+		select {
+		case <-finished:
+		case <-ctx.Done():
+			s.abortStepExecution(request.Name, test.Name, finished)
+		}
+	}()
+
+	stepCtx := server.WithRequestID(context.WithValue(context.Background(), abortKey{}, ctx), server.RequestID(ctx))
+	execution, err := s.executeTest(stepCtx, test, request)
+	close(finished)
+	<-aborted
+
+	return execution, err
+}
+
+// abortKey is a context key of test suite execution context, test interrupted by its cancellation is stored as aborted
+type abortKey struct{}
+
+// isAborted checks if test execution was interrupted by test suite abort
+func isAborted(ctx context.Context) bool {
+	abortCtx, ok := ctx.Value(abortKey{}).(context.Context)
+	return ok && abortCtx.Err() != nil
+}
+
+// abortStepExecution aborts step execution in executor, execution may not be stored yet when suite is aborted
+func (s TestkubeAPI) abortStepExecution(name, testName string, finished <-chan struct{}) {
+	for attempt := 0; attempt < abortLookupAttempts; attempt++ {
+		execution, err := s.ExecutionResults.GetByNameAndTest(context.Background(), name, testName)
+		if err == nil && execution.ExecutionResult != nil && execution.ExecutionResult.Status != nil &&
+			execution.ExecutionResult.IsRunning() {
+			if err = s.Executor.Abort(execution.Id); err != nil {
+				s.Log.Errorw("aborting test suite step execution error", "executionId", execution.Id, "error", err)
+			}
+			return
+		}
+
+		select {
+		case <-finished:
+			return
+		case <-time.After(abortLookupInterval):
+		}
+	}
+}
+
+// AbortTestSuiteExecutionHandler aborts test suite execution, running step is aborted in executor
+// and queued steps are skipped
+func (s TestkubeAPI) AbortTestSuiteExecutionHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+		id := c.Params("executionID")
+
+		execution, err := s.TestExecutionResults.Get(ctx, id)
+		if err == mongo.ErrNoDocuments {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteExecutionNotFound, fmt.Errorf("test suite execution %s not found", id)))
+		}
+
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't get test suite execution %s: %w", id, err))
+		}
+
+		if project := getProject(c); project != "" && execution.Project != project {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteExecutionNotFound, fmt.Errorf("test suite execution %s not found", id)))
+		}
+
+		if execution.Status != nil && execution.IsCompleted() {
+			return s.Warn(c, http.StatusConflict, fmt.Errorf("test suite execution %s is already %s", id, *execution.Status))
+		}
+
+		accepted, err := s.abortTestSuiteExecution(ctx, execution)
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		if accepted {
+			c.Status(http.StatusAccepted)
+		}
+
+		return nil
+	}
+}
+
+// RerunTestSuiteExecutionHandler creates test suite execution rerunning first failed step of finished test suite
+// execution and all subsequent steps, results and output variables of previous steps are carried over
+func (s TestkubeAPI) RerunTestSuiteExecutionHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := context.Background()
+		id := c.Params("executionID")
+
+		if from := c.Query("from", rerunFromFailed); from != rerunFromFailed {
+			return s.Warn(c, http.StatusBadRequest, fmt.Errorf("can't rerun test suite execution from %s, only %s is supported", from, rerunFromFailed))
+		}
+
+		execution, err := s.TestExecutionResults.Get(ctx, id)
+		if err == mongo.ErrNoDocuments {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteExecutionNotFound, fmt.Errorf("test suite execution %s not found", id)))
+		}
+
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't get test suite execution %s: %w", id, err))
+		}
+
+		if project := getProject(c); project != "" && execution.Project != project {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteExecutionNotFound, fmt.Errorf("test suite execution %s not found", id)))
+		}
+
+		if execution.Status == nil || !execution.IsCompleted() {
+			return s.Warn(c, http.StatusConflict, problem.WithCode(problem.CodeNotFinished, fmt.Errorf("test suite execution %s isn't finished yet", id)))
+		}
+
+		from := execution.FailedStepIndex()
+		if from < 0 {
+			return s.Warn(c, http.StatusConflict, fmt.Errorf("test suite execution %s has no failed steps", id))
+		}
+
+		s.Logger(c.Context()).Infow("rerunning test suite execution", "executionId", id, "step", from)
+		rerun, err := s.runTestSuiteExecution(ctx, testkube.NewRerunTestSuiteExecution(execution, from), testkube.TestSuiteExecutionRequest{}, from)
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't rerun test suite execution %s: %w", id, err))
+		}
+
+		c.Status(http.StatusCreated)
+		return c.JSON(rerun)
+	}
+}
+
+// abortTestSuiteExecution aborts test suite execution, true is returned when it's running in this API server
+// which aborts its running step, skips queued steps and stores aborted status asynchronously
+func (s TestkubeAPI) abortTestSuiteExecution(ctx context.Context, execution testkube.TestSuiteExecution) (accepted bool, err error) {
+	if s.suiteRuns.abort(execution.Id) {
+		return true, nil
+	}
+
+	// execution started by other or previous API server is aborted in storage and skips its steps when it sees it
+	for _, executionID := range abortTestSuiteSteps(&execution) {
+		if err = s.Executor.Abort(executionID); err != nil {
+			s.Log.Errorw("aborting test suite step execution error", "executionId", executionID, "error", err)
+		}
+	}
+
+	for _, step := range execution.StepResults {
+		if !step.IsAborted() || step.Execution.Id == "" {
+			continue
+		}
+
+		if err = s.ExecutionResults.UpdateResult(ctx, step.Execution.Id, *step.Execution.ExecutionResult); err != nil {
+			return false, fmt.Errorf("can't update test execution %s result: %w", step.Execution.Id, err)
+		}
+
+		if err = s.notifyEvents(testkube.WebhookTypeEndTest, *step.Execution); err != nil {
+			s.Log.Infow("Notify events", "error", err)
+		}
+	}
+
+	execution.EndTime = time.Now()
+	duration := execution.CalculateDuration()
+	execution.Duration = duration.String()
+	if err = s.TestExecutionResults.Update(ctx, execution); err != nil {
+		return false, fmt.Errorf("can't update test suite execution %s: %w", execution.Id, err)
+	}
+
+	if err = s.TestExecutionResults.EndExecution(ctx, execution.Id, execution.EndTime, duration); err != nil {
+		return false, fmt.Errorf("can't end test suite execution %s: %w", execution.Id, err)
+	}
+
+	// replica running the execution sees it aborted in storage and leaves end event to abort
+	s.notifyTestSuiteEnd(execution)
+	return false, nil
+}
+
+// notifyTestSuiteEnd sends finished test suite execution to webhooks subscribed to end-testsuite event and to Slack,
+// webhook filters of test executions don't apply to it
+func (s TestkubeAPI) notifyTestSuiteEnd(execution testkube.TestSuiteExecution) {
+	settings := s.getServerSettings(context.Background())
+	execution = s.redactTestSuiteExecution(context.Background(), execution)
+
+	if settings.WebhookNotifications && s.WebhooksClient != nil {
+		webhookList, err := s.WebhooksClient.GetByEvent(testkube.WebhookTypeEndTestSuite.String())
+		if err != nil {
+			s.Log.Warnw("getting test suite webhooks error", "error", err)
+			webhookList = &executorv1.WebhookList{}
+		}
+
+		for _, wh := range webhookList.Items {
+			event, err := s.newWebhookEvent(wh, testkube.WebhookTypeEndTestSuite)
+			if err != nil {
+				s.Log.Warnw("skipping webhook with invalid signing or connection options", "webhook", wh.Name, "error", err)
+				continue
+			}
+
+			event.TestSuiteExecution = &execution
+			s.EventsEmitter.Notify(event)
+		}
+	}
+
+	if settings.SlackNotifications {
+		if err := slacknotifier.SendTestSuiteEvent(execution); err != nil {
+			s.Log.Warnw("notify slack test suite end failed", "error", err)
+		}
+	}
+}
+
+// abortTestSuiteSteps aborts test suite execution with its running steps and skips queued steps,
+// execution IDs of aborted test steps are returned
+func abortTestSuiteSteps(execution *testkube.TestSuiteExecution) (executionIDs []string) {
+	for i := range execution.StepResults {
+		step := &execution.StepResults[i]
+		if step.Execution == nil || step.Execution.ExecutionResult == nil || step.Execution.ExecutionResult.Status == nil {
+			step.Skip()
+			continue
+		}
+
+		switch *step.Execution.ExecutionResult.Status {
+		case testkube.QUEUED_ExecutionStatus:
+			step.Skip()
+		case testkube.RUNNING_ExecutionStatus:
+			step.Abort()
+			if step.Execution.Id != "" {
+				executionIDs = append(executionIDs, step.Execution.Id)
+			}
+		}
+	}
+
+	execution.Status = testkube.TestSuiteExecutionStatusAborted
+	return executionIDs
+}
+
+// isTestSuiteExecutionAborted checks if test suite execution was aborted in storage, e.g. by other API server
+func (s TestkubeAPI) isTestSuiteExecutionAborted(id string) bool {
+	if s.TestExecutionResults == nil {
+		return false
+	}
+
+	execution, err := s.TestExecutionResults.Get(context.Background(), id)
+	return err == nil && execution.Status != nil && execution.IsAborted()
+}
+
+// updateTestSuiteExecution updates test suite execution in storage and notifies its watchers
+func (s TestkubeAPI) updateTestSuiteExecution(ctx context.Context, execution testkube.TestSuiteExecution) error {
+	err := s.TestExecutionResults.Update(ctx, execution)
+	s.suiteRuns.notify(execution.Id)
+	return err
+}
+
+// WatchTestSuiteExecutionHandler streams test suite execution snapshots, each sent when status of execution
+// or any of its steps changes, stream ends when execution is completed
+func (s TestkubeAPI) WatchTestSuiteExecutionHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Params("executionID")
+
+		execution, err := s.TestExecutionResults.Get(c.Context(), id)
+		if err == mongo.ErrNoDocuments {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteExecutionNotFound, fmt.Errorf("test suite execution %s not found", id)))
+		}
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		if project := getProject(c); project != "" && execution.Project != project {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteExecutionNotFound, fmt.Errorf("test suite execution %s not found", id)))
+		}
+
+		ctx := c.Context()
+
+		ctx.SetContentType("text/event-stream")
+		ctx.Response.Header.Set("Cache-Control", "no-cache")
+		ctx.Response.Header.Set("Connection", "keep-alive")
+		ctx.Response.Header.Set("Transfer-Encoding", "chunked")
+
+		ctx.SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+			enc := json.NewEncoder(w)
+			send := func(execution testkube.TestSuiteExecution) error {
+				fmt.Fprintf(w, "data: ")
+				if err := enc.Encode(execution); err != nil {
+					return err
+				}
+				// enc.Encode adds \n and we need \n\n after `data: {}` chunk
+				fmt.Fprintf(w, "\n")
+				return w.Flush()
+			}
+
+			streamCtx, cancel := s.streamContext(ctx)
+			defer cancel()
+			if err := s.watchTestSuiteExecution(streamCtx, id, send); err != nil {
+				s.Log.Infow("watching test suite execution", "executionID", id, "error", err)
+			}
+		}))
+
+		return nil
+	}
+}
+
+// watchTestSuiteExecution sends test suite execution whenever its status or status of any step changes until ctx is
+// done, executions running in this API server are sent on update, other executions are checked periodically
+func (s TestkubeAPI) watchTestSuiteExecution(ctx context.Context, id string, send func(execution testkube.TestSuiteExecution) error) error {
+	var sent string
+	for {
+		// channel is taken before reading execution so no update is missed
+		updated := s.suiteRuns.updated(id)
+		execution, err := s.TestExecutionResults.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if statuses := testSuiteExecutionStatuses(execution); statuses != sent {
+			execution.Duration = types.FormatDuration(execution.Duration)
+			if err = send(execution); err != nil {
+				return err
+			}
+			sent = statuses
+		}
+
+		if execution.Status != nil && execution.IsCompleted() {
+			return nil
+		}
+
+		select {
+		case <-updated:
+		case <-time.After(testSuiteWatchPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// testSuiteExecutionStatuses returns statuses of test suite execution and its steps
+func testSuiteExecutionStatuses(execution testkube.TestSuiteExecution) string {
+	var statuses strings.Builder
+	if execution.Status != nil {
+		statuses.WriteString(string(*execution.Status))
+	}
+
+	for _, step := range execution.StepResults {
+		statuses.WriteString(",")
+		if step.Execution != nil && step.Execution.ExecutionResult != nil && step.Execution.ExecutionResult.Status != nil {
+			statuses.WriteString(string(*step.Execution.ExecutionResult.Status))
+		}
+	}
+
+	return statuses.String()
+}
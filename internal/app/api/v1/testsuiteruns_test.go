@@ -0,0 +1,171 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/server"
+	"github.com/kubeshop/testkube/pkg/workerpool"
+)
+
+func TestSuiteRunsState(t *testing.T) {
+	runs := newSuiteRunsState(suiteStepsConfig{})
+
+	ctx, done := runs.start("1")
+	assert.False(t, runs.abort("2"))
+	assert.NoError(t, ctx.Err())
+
+	assert.True(t, runs.abort("1"))
+	assert.Error(t, ctx.Err())
+
+	done()
+	assert.False(t, runs.abort("1"), "finished execution can't be aborted")
+}
+
+func TestSuiteRunsStateUpdates(t *testing.T) {
+	runs := newSuiteRunsState(suiteStepsConfig{})
+	assert.Nil(t, runs.updated("1"), "not running execution isn't notified")
+
+	_, done := runs.start("1")
+	updated := runs.updated("1")
+	runs.notify("2")
+	select {
+	case <-updated:
+		t.Fatal("execution wasn't updated")
+	default:
+	}
+
+	runs.notify("1")
+	<-updated
+	next := runs.updated("1")
+
+	done()
+	<-next
+	assert.Nil(t, runs.updated("1"))
+}
+
+func TestTestSuiteExecutionStatuses(t *testing.T) {
+	execution := testkube.TestSuiteExecution{
+		Status: testkube.TestSuiteExecutionStatusRunning,
+		StepResults: []testkube.TestSuiteStepExecutionResult{
+			{Execution: &testkube.Execution{ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed}}},
+			{Execution: &testkube.Execution{ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusRunning}}},
+			{},
+		},
+	}
+	assert.Equal(t, "running,passed,running,", testSuiteExecutionStatuses(execution))
+
+	execution.StepResults[1].Execution.ExecutionResult.Output = "changed output"
+	assert.Equal(t, "running,passed,running,", testSuiteExecutionStatuses(execution), "only status changes are sent")
+}
+
+func TestExecuteAbortedTestStep(t *testing.T) {
+	s := TestkubeAPI{HTTPServer: server.NewServer(server.Config{}), suiteRuns: newSuiteRunsState(suiteStepsConfig{})}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	steps := []testkube.TestSuiteStep{
+		{Execute: &testkube.TestSuiteStepExecuteTest{Name: "api"}},
+		{Execute: &testkube.TestSuiteStepExecuteTest{Name: "api"}, Timeout: 60, Retries: 3},
+		{Delay: &testkube.TestSuiteStepDelay{Duration: 60000}},
+	}
+
+	for i := range steps {
+		result := testkube.NewTestStepQueuedResult(&steps[i])
+		s.executeTestStep(ctx, testkube.TestSuiteExecution{}, testkube.TestSuiteExecutionRequest{}, nil, &result)
+		assert.True(t, result.IsAborted(), steps[i].FullName())
+		assert.False(t, result.TimedOut, steps[i].FullName())
+		assert.LessOrEqual(t, result.Attempts, int32(1), "aborted step isn't retried")
+	}
+}
+
+func TestStepTimeoutStartsWithWorker(t *testing.T) {
+	runs := newSuiteRunsState(suiteStepsConfig{Concurrency: 1})
+	type request = workerpool.Request[testkube.Test, testkube.ExecutionRequest, testkube.Execution]
+
+	release := make(chan struct{})
+	busy := make(chan struct{})
+	go runs.steps.Execute(context.Background(), request{ExecFn: func(ctx context.Context, test testkube.Test, options testkube.ExecutionRequest) (testkube.Execution, error) {
+		close(busy)
+		<-release
+		return testkube.Execution{}, nil
+	}})
+	<-busy
+	time.AfterFunc(100*time.Millisecond, func() { close(release) })
+
+	var timedOut bool
+	response := runs.steps.Execute(context.Background(), request{ExecFn: withStepTimeout(50*time.Millisecond,
+		func(ctx context.Context, test testkube.Test, options testkube.ExecutionRequest) (testkube.Execution, error) {
+			return testkube.Execution{}, ctx.Err()
+		}, &timedOut)})
+	assert.NoError(t, response.Err, "waiting for free worker isn't counted")
+	assert.False(t, timedOut)
+
+	response = runs.steps.Execute(context.Background(), request{ExecFn: withStepTimeout(10*time.Millisecond,
+		func(ctx context.Context, test testkube.Test, options testkube.ExecutionRequest) (testkube.Execution, error) {
+			<-ctx.Done()
+			return testkube.Execution{}, ctx.Err()
+		}, &timedOut)})
+	assert.Error(t, response.Err)
+	assert.True(t, timedOut)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := withStepTimeout(time.Minute, func(ctx context.Context, test testkube.Test, options testkube.ExecutionRequest) (testkube.Execution, error) {
+		return testkube.Execution{}, ctx.Err()
+	}, &timedOut)(ctx, testkube.Test{}, testkube.ExecutionRequest{})
+	assert.Error(t, err)
+	assert.False(t, timedOut, "aborted step isn't timed out")
+}
+
+func TestAbortTestSuiteSteps(t *testing.T) {
+	step := func(status *testkube.ExecutionStatus, id string) testkube.TestSuiteStepExecutionResult {
+		return testkube.TestSuiteStepExecutionResult{
+			Execution: &testkube.Execution{Id: id, ExecutionResult: &testkube.ExecutionResult{Status: status}},
+		}
+	}
+
+	execution := testkube.TestSuiteExecution{
+		Status: testkube.TestSuiteExecutionStatusRunning,
+		StepResults: []testkube.TestSuiteStepExecutionResult{
+			step(testkube.ExecutionStatusPassed, "1"),
+			step(testkube.ExecutionStatusRunning, "2"),
+			step(testkube.ExecutionStatusQueued, ""),
+			{},
+		},
+	}
+
+	assert.Equal(t, []string{"2"}, abortTestSuiteSteps(&execution))
+	assert.True(t, execution.IsAborted())
+	assert.True(t, execution.StepResults[0].Execution.ExecutionResult.IsPassed())
+	assert.True(t, execution.StepResults[1].IsAborted())
+	assert.True(t, execution.StepResults[2].Execution.ExecutionResult.IsSkipped())
+	assert.True(t, execution.StepResults[3].Execution.ExecutionResult.IsSkipped())
+}
+
+func TestStepConditionContext(t *testing.T) {
+	result := func(status *testkube.ExecutionStatus) testkube.TestSuiteStepExecutionResult {
+		return testkube.TestSuiteStepExecutionResult{Execution: &testkube.Execution{ExecutionResult: &testkube.ExecutionResult{Status: status}}}
+	}
+
+	execution := testkube.TestSuiteExecution{
+		Params: map[string]string{"ENV": "staging", "USERS": "10"},
+		StepResults: []testkube.TestSuiteStepExecutionResult{
+			result(testkube.ExecutionStatusFailed),
+			result(testkube.ExecutionStatusSkipped),
+			result(testkube.ExecutionStatusQueued),
+		},
+	}
+	request := testkube.TestSuiteExecutionRequest{Params: map[string]string{"ENV": "prod"}}
+
+	ctx := stepConditionContext(execution, request, map[string]string{"userId": "123"}, true, 2)
+	assert.Equal(t, map[string]string{"ENV": "prod", "USERS": "10", "userId": "123"}, ctx.Params)
+	assert.True(t, ctx.Failed)
+	assert.Equal(t, "failed", ctx.PreviousStatus, "steps which weren't executed are ignored")
+
+	assert.Empty(t, stepConditionContext(execution, request, nil, false, 0).PreviousStatus)
+}
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -10,21 +11,28 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
 	"go.mongodb.org/mongo-driver/mongo"
 	"k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	testsuitesv1 "github.com/kubeshop/testkube-operator/apis/testsuite/v1"
 	"github.com/kubeshop/testkube/internal/pkg/api/datefilter"
 	"github.com/kubeshop/testkube/internal/pkg/api/repository/testresult"
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/condition"
 	"github.com/kubeshop/testkube/pkg/cronjob"
+	"github.com/kubeshop/testkube/pkg/executor/output"
 	testsuitesmapper "github.com/kubeshop/testkube/pkg/mapper/testsuites"
+	"github.com/kubeshop/testkube/pkg/problem"
 	"github.com/kubeshop/testkube/pkg/rand"
+	"github.com/kubeshop/testkube/pkg/server"
 	"github.com/kubeshop/testkube/pkg/types"
 	"github.com/kubeshop/testkube/pkg/workerpool"
 )
 
+// testSuiteLogsPollInterval is an interval of checking if next test suite step has started
+const testSuiteLogsPollInterval = time.Second
+
 // GetTestSuiteHandler for getting test object
 func (s TestkubeAPI) CreateTestSuiteHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -34,10 +42,20 @@ func (s TestkubeAPI) CreateTestSuiteHandler() fiber.Handler {
 			return s.Error(c, http.StatusBadRequest, err)
 		}
 
-		testSuite := mapTestSuiteUpsertRequestToTestCRD(request)
+		if err = testkube.ValidateTestSuiteSteps(request.Before, request.Steps, request.After); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = s.validateExecutionTriggers(getProject(c), testkube.ExecutionTriggerSource(testkube.ExecutionTriggerTypeTestSuite, request.Name),
+			request.Triggers); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		request.Labels = withProjectLabel(request.Labels, getProject(c))
+		testSuite := testsuitesmapper.MapTestSuiteUpsertRequestToTestCRD(request)
 		testSuite.Namespace = s.Namespace
 
-		s.Log.Infow("creating test suite", "testSuite", testSuite)
+		s.Logger(c.Context()).Infow("creating test suite", "testSuite", testSuite)
 
 		created, err := s.TestsSuitesClient.Create(&testSuite)
 		if err != nil {
@@ -58,12 +76,34 @@ func (s TestkubeAPI) UpdateTestSuiteHandler() fiber.Handler {
 			return s.Error(c, http.StatusBadRequest, err)
 		}
 
+		if err = testkube.ValidateTestSuiteSteps(request.Before, request.Steps, request.After); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if err = s.validateExecutionTriggers(getProject(c), testkube.ExecutionTriggerSource(testkube.ExecutionTriggerTypeTestSuite, request.Name),
+			request.Triggers); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
 		// we need to get resource first and load its metadata.ResourceVersion
 		testSuite, err := s.TestsSuitesClient.Get(request.Name)
 		if err != nil {
 			return s.Error(c, http.StatusBadGateway, err)
 		}
 
+		project := getProject(c)
+		if !isInProject(testSuite.Labels, project) {
+			return s.Error(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteNotFound, fmt.Errorf("test suite %s not found", request.Name)))
+		}
+
+		// enabled state is kept, if it's not set in request
+		if request.Enabled == nil {
+			enabled := !testkube.IsDisabled(testSuite.Labels)
+			request.Enabled = &enabled
+		}
+
+		request.Labels = testkube.WithEnabledLabel(withProjectLabel(request.Labels, project), request.Enabled)
+
 		// delete cron job, if schedule is cleaned
 		if testSuite.Spec.Schedule != "" {
 			cronJob, err := s.CronJobClient.Get(cronjob.GetMetadataName(request.Name, testSuiteResourceURI))
@@ -77,6 +117,8 @@ func (s TestkubeAPI) UpdateTestSuiteHandler() fiber.Handler {
 						return s.Error(c, http.StatusBadGateway, err)
 					}
 				} else {
+					suspend := !*request.Enabled
+					cronJob.Spec.Suspend = &suspend
 					if err = s.CronJobClient.UpdateLabels(cronJob, testSuite.Labels, request.Labels); err != nil {
 						return s.Error(c, http.StatusBadGateway, err)
 					}
@@ -85,9 +127,20 @@ func (s TestkubeAPI) UpdateTestSuiteHandler() fiber.Handler {
 		}
 
 		// map TestSuite but load spec only to not override metadata.ResourceVersion
-		testSuiteSpec := mapTestSuiteUpsertRequestToTestCRD(request)
+		testSuiteSpec := testsuitesmapper.MapTestSuiteUpsertRequestToTestCRD(request)
 		testSuite.Spec = testSuiteSpec.Spec
 		testSuite.Labels = request.Labels
+		for _, annotation := range append([]string{testkube.StepParamsAnnotation, testkube.StepOptionsAnnotation, testkube.TriggersAnnotation},
+			testkube.OwnershipAnnotations...) {
+			if value, ok := testSuiteSpec.Annotations[annotation]; ok {
+				if testSuite.Annotations == nil {
+					testSuite.Annotations = map[string]string{}
+				}
+				testSuite.Annotations[annotation] = value
+			} else {
+				delete(testSuite.Annotations, annotation)
+			}
+		}
 		testSuite, err = s.TestsSuitesClient.Update(testSuite)
 		if err != nil {
 			return s.Error(c, http.StatusBadGateway, err)
@@ -104,12 +157,16 @@ func (s TestkubeAPI) GetTestSuiteHandler() fiber.Handler {
 		crTestSuite, err := s.TestsSuitesClient.Get(name)
 		if err != nil {
 			if errors.IsNotFound(err) {
-				return s.Warn(c, http.StatusNotFound, err)
+				return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteNotFound, err))
 			}
 
 			return s.Error(c, http.StatusBadGateway, err)
 		}
 
+		if !isInProject(crTestSuite.Labels, getProject(c)) {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteNotFound, fmt.Errorf("test suite %s not found", name)))
+		}
+
 		testSuite := testsuitesmapper.MapCRToAPI(*crTestSuite)
 
 		return c.JSON(testSuite)
@@ -123,12 +180,16 @@ func (s TestkubeAPI) GetTestSuiteWithExecutionHandler() fiber.Handler {
 		crTestSuite, err := s.TestsSuitesClient.Get(name)
 		if err != nil {
 			if errors.IsNotFound(err) {
-				return s.Warn(c, http.StatusNotFound, err)
+				return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteNotFound, err))
 			}
 
 			return s.Error(c, http.StatusBadGateway, err)
 		}
 
+		if !isInProject(crTestSuite.Labels, getProject(c)) {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteNotFound, fmt.Errorf("test suite %s not found", name)))
+		}
+
 		ctx := c.Context()
 		execution, err := s.TestExecutionResults.GetLatestByTest(ctx, name)
 		if err != nil && err != mongo.ErrNoDocuments {
@@ -151,10 +212,25 @@ func (s TestkubeAPI) GetTestSuiteWithExecutionHandler() fiber.Handler {
 func (s TestkubeAPI) DeleteTestSuiteHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		name := c.Params("id")
+		if project := getProject(c); project != "" {
+			testSuite, err := s.TestsSuitesClient.Get(name)
+			if err != nil {
+				if errors.IsNotFound(err) {
+					return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteNotFound, err))
+				}
+
+				return s.Error(c, http.StatusBadGateway, err)
+			}
+
+			if !isInProject(testSuite.Labels, project) {
+				return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteNotFound, fmt.Errorf("test suite %s not found", name)))
+			}
+		}
+
 		err := s.TestsSuitesClient.Delete(name)
 		if err != nil {
 			if errors.IsNotFound(err) {
-				return s.Warn(c, http.StatusNotFound, err)
+				return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteNotFound, err))
 			}
 
 			return s.Error(c, http.StatusBadGateway, err)
@@ -175,7 +251,7 @@ func (s TestkubeAPI) DeleteTestSuiteHandler() fiber.Handler {
 func (s TestkubeAPI) DeleteTestSuitesHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var err error
-		selector := c.Query("selector")
+		selector := projectSelector(c.Query("selector"), getProject(c))
 		if selector == "" {
 			err = s.TestsSuitesClient.DeleteAll()
 		} else {
@@ -184,7 +260,7 @@ func (s TestkubeAPI) DeleteTestSuitesHandler() fiber.Handler {
 
 		if err != nil {
 			if errors.IsNotFound(err) {
-				return s.Warn(c, http.StatusNotFound, err)
+				return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteNotFound, err))
 			}
 
 			return s.Error(c, http.StatusBadGateway, err)
@@ -202,7 +278,7 @@ func (s TestkubeAPI) DeleteTestSuitesHandler() fiber.Handler {
 }
 
 func (s TestkubeAPI) getFilteredTestSuitesList(c *fiber.Ctx) (*testsuitesv1.TestSuiteList, error) {
-	crTestSuites, err := s.TestsSuitesClient.List(c.Query("selector"))
+	crTestSuites, err := s.TestsSuitesClient.List(projectSelector(c.Query("selector"), getProject(c)))
 	if err != nil {
 		return nil, err
 	}
@@ -217,6 +293,16 @@ func (s TestkubeAPI) getFilteredTestSuitesList(c *fiber.Ctx) (*testsuitesv1.Test
 		}
 	}
 
+	if owner := c.Query("owner"); owner != "" {
+		items := crTestSuites.Items[:0]
+		for _, testSuite := range crTestSuites.Items {
+			if testkube.OwnershipFromAnnotations(testSuite.Annotations).IsOwnedBy(owner) {
+				items = append(items, testSuite)
+			}
+		}
+		crTestSuites.Items = items
+	}
+
 	return crTestSuites, nil
 }
 
@@ -297,7 +383,8 @@ func (s TestkubeAPI) ListTestSuiteWithExecutionsHandler() fiber.Handler {
 
 func (s TestkubeAPI) ExecuteTestSuitesHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		ctx := context.Background()
+		// test suites run in background after response is sent, so only request id is taken from request context
+		ctx := server.WithRequestID(context.Background(), server.RequestID(c.Context()))
 
 		var request testkube.TestSuiteExecutionRequest
 		err := c.BodyParser(&request)
@@ -305,22 +392,37 @@ func (s TestkubeAPI) ExecuteTestSuitesHandler() fiber.Handler {
 			return s.Error(c, http.StatusBadRequest, fmt.Errorf("test execution request body invalid: %w", err))
 		}
 
+		request.RunningContext = s.withRequester(c, request.RunningContext)
+		settings := s.getServerSettings(ctx)
 		name := c.Params("id")
-		namespace := c.Query("namespace", "testkube")
-		selector := c.Query("selector")
-		s.Log.Debugw("getting test suite", "name", name, "selector", selector)
+		namespace := c.Query("namespace", settings.DefaultNamespace)
+		project := getProject(c)
+		selector := projectSelector(c.Query("selector"), project)
+		s.Logger(c.Context()).Debugw("getting test suite", "name", name, "selector", selector)
 
 		var testSuites []testsuitesv1.TestSuite
 		if name != "" {
 			testSuite, err := s.TestsSuitesClient.Get(name)
 			if err != nil {
 				if errors.IsNotFound(err) {
-					return s.Warn(c, http.StatusNotFound, err)
+					return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteNotFound, err))
 				}
 
 				return s.Error(c, http.StatusBadGateway, err)
 			}
 
+			if !isInProject(testSuite.Labels, project) {
+				return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteNotFound, fmt.Errorf("test suite %s not found", name)))
+			}
+
+			if testkube.IsDisabled(testSuite.Labels) && c.Query("force") != "true" {
+				return s.Warn(c, http.StatusConflict, problem.WithCode(problem.CodeDisabled, fmt.Errorf("test suite %s is disabled, use force to run it anyway", name)))
+			}
+
+			if err = testsuitesmapper.ValidateStepOptions(*testSuite); err != nil {
+				return s.Error(c, http.StatusUnprocessableEntity, err)
+			}
+
 			testSuites = append(testSuites, *testSuite)
 		} else {
 			testSuiteList, err := s.TestsSuitesClient.List(selector)
@@ -328,7 +430,17 @@ func (s TestkubeAPI) ExecuteTestSuitesHandler() fiber.Handler {
 				return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't get test suites: %w", err))
 			}
 
+			// disabled test suites and test suites which steps can't be read are skipped by bulk runs
 			for _, item := range testSuiteList.Items {
+				if testkube.IsDisabled(item.Labels) {
+					s.Logger(c.Context()).Debugw("skipping disabled test suite", "testSuite", item.Name)
+					continue
+				}
+
+				if err = testsuitesmapper.ValidateStepOptions(item); err != nil {
+					s.Logger(c.Context()).Errorw("skipping test suite with invalid step options", "testSuite", item.Name, "error", err)
+					continue
+				}
 				testSuites = append(testSuites, item)
 			}
 		}
@@ -336,6 +448,14 @@ func (s TestkubeAPI) ExecuteTestSuitesHandler() fiber.Handler {
 		var results []testkube.TestSuiteExecution
 		var work []testsuitesv1.TestSuite
 		for _, testSuite := range testSuites {
+			// scheduled runs in maintenance windows are stored as skipped, so planned downtime doesn't raise alerts
+			if c.Query("callback") != "" {
+				if window := testkube.ActiveMaintenanceWindow(time.Now(), settings.MaintenanceWindows); window != nil {
+					results = append(results, s.skipTestSuite(ctx, testsuitesmapper.MapCRToAPI(testSuite), request, *window))
+					continue
+				}
+			}
+
 			if testSuite.Spec.Schedule == "" || c.Query("callback") != "" {
 				work = append(work, testSuite)
 				continue
@@ -351,6 +471,7 @@ func (s TestkubeAPI) ExecuteTestSuitesHandler() fiber.Handler {
 				Resource: testSuiteResourceURI,
 				Data:     string(data),
 				Labels:   testSuite.Labels,
+				Suspend:  testkube.IsDisabled(testSuite.Labels),
 			}
 			if err = s.CronJobClient.Apply(testSuite.Name, cronjob.GetMetadataName(testSuite.Name, testSuiteResourceURI), options); err != nil {
 				return s.Error(c, http.StatusInternalServerError, fmt.Errorf("can't create scheduled test suite: %w", err))
@@ -360,7 +481,7 @@ func (s TestkubeAPI) ExecuteTestSuitesHandler() fiber.Handler {
 		}
 
 		if len(work) != 0 {
-			concurrencyLevel, err := strconv.Atoi(c.Query("concurrency", defaultConcurrencyLevel))
+			concurrencyLevel, err := strconv.Atoi(c.Query("concurrency", strconv.Itoa(int(settings.DefaultConcurrency))))
 			if err != nil {
 				return s.Error(c, http.StatusBadRequest, fmt.Errorf("can't detect concurrency level: %w", err))
 			}
@@ -375,7 +496,7 @@ func (s TestkubeAPI) ExecuteTestSuitesHandler() fiber.Handler {
 			}
 		}
 
-		s.Log.Debugw("executing test", "name", name, "selector", selector)
+		s.Logger(c.Context()).Debugw("executing test", "name", name, "selector", selector)
 		if name != "" && len(results) != 0 {
 			if results[0].IsFailed() {
 				return s.Error(c, http.StatusInternalServerError, fmt.Errorf("Test suite failed %v", name))
@@ -411,7 +532,12 @@ func (s TestkubeAPI) ListTestSuiteExecutionsHandler() fiber.Handler {
 		if err != nil {
 			return s.Error(c, http.StatusBadRequest, err)
 		}
-		allExecutionsTotals, err := s.TestExecutionResults.GetExecutionsTotals(ctx)
+		var allFilters []testresult.Filter
+		if project := getProject(c); project != "" {
+			allFilters = append(allFilters, testresult.NewExecutionsFilter().WithProject(project))
+		}
+
+		allExecutionsTotals, err := s.TestExecutionResults.GetExecutionsTotals(ctx, allFilters...)
 		if err != nil {
 			return s.Error(c, http.StatusBadRequest, err)
 		}
@@ -439,24 +565,193 @@ func (s TestkubeAPI) GetTestSuiteExecutionHandler() fiber.Handler {
 			return s.Error(c, http.StatusBadRequest, err)
 		}
 
+		if project := getProject(c); project != "" && execution.Project != project {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteExecutionNotFound, fmt.Errorf("test suite execution %s not found", id)))
+		}
+
 		execution.Duration = types.FormatDuration(execution.Duration)
+		execution = s.redactTestSuiteExecution(ctx, execution)
 
 		return c.JSON(execution)
 	}
 }
 
+// TestSuiteExecutionLogsHandler streams logs of test suite execution steps prefixed with step name,
+// running steps logs are streamed live, finished steps logs are replayed from stored output
+func (s TestkubeAPI) TestSuiteExecutionLogsHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Params("executionID")
+
+		execution, err := s.TestExecutionResults.Get(c.Context(), id)
+		if err == mongo.ErrNoDocuments {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteExecutionNotFound, fmt.Errorf("test suite execution %s not found", id)))
+		}
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		if project := getProject(c); project != "" && execution.Project != project {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestSuiteExecutionNotFound, fmt.Errorf("test suite execution %s not found", id)))
+		}
+
+		ctx := c.Context()
+
+		ctx.SetContentType("text/event-stream")
+		ctx.Response.Header.Set("Cache-Control", "no-cache")
+		ctx.Response.Header.Set("Connection", "keep-alive")
+		ctx.Response.Header.Set("Transfer-Encoding", "chunked")
+
+		ctx.SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+			enc := json.NewEncoder(w)
+			send := func(out output.Output) error {
+				fmt.Fprintf(w, "data: ")
+				if err := enc.Encode(out); err != nil {
+					return err
+				}
+				// enc.Encode adds \n and we need \n\n after `data: {}` chunk
+				fmt.Fprintf(w, "\n")
+				return w.Flush()
+			}
+
+			streamCtx, cancel := s.streamContext(ctx)
+			defer cancel()
+			if err := s.streamTestSuiteExecutionLogs(streamCtx, id, send); err != nil {
+				s.Log.Infow("streaming test suite execution logs", "executionID", id, "error", err)
+			}
+		}))
+
+		return nil
+	}
+}
+
+// streamTestSuiteExecutionLogs sends logs of consecutive test suite execution steps, waiting for steps to start until
+// ctx is done
+func (s TestkubeAPI) streamTestSuiteExecutionLogs(ctx context.Context, id string, send func(out output.Output) error) error {
+	for i := 0; ; {
+		execution, err := s.TestExecutionResults.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if i >= len(execution.StepResults) {
+			return nil
+		}
+
+		stepResult := execution.StepResults[i]
+		// only test execution steps have logs
+		if stepResult.Step == nil || stepResult.Step.Type() != testkube.TestSuiteStepTypeExecuteTest {
+			i++
+			continue
+		}
+
+		var stepExecution testkube.Execution
+		if stepResult.Execution != nil && stepResult.Execution.Name != "" {
+			stepExecution, err = s.ExecutionResults.GetByNameAndTest(ctx, stepResult.Execution.Name, stepResult.Execution.TestName)
+			if err != nil && err != mongo.ErrNoDocuments {
+				return err
+			}
+		}
+
+		// step execution is not created yet or the step was never started
+		if stepExecution.Id == "" {
+			if execution.IsCompleted() || (stepResult.Execution != nil && stepResult.Execution.ExecutionResult != nil &&
+				stepResult.Execution.ExecutionResult.Status != nil && stepResult.Execution.ExecutionResult.IsCompleted()) {
+				i++
+				continue
+			}
+
+			select {
+			case <-time.After(testSuiteLogsPollInterval):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			continue
+		}
+
+		stepName := stepResult.Step.FullName()
+		if err = send(output.NewOutputEvent(fmt.Sprintf("step %s started, execution %s", stepName, stepExecution.Id))); err != nil {
+			return err
+		}
+
+		if err = s.streamStepLogs(stepName, stepExecution, send); err != nil {
+			return err
+		}
+
+		i++
+	}
+}
+
+// streamStepLogs sends single step execution logs with step name prefix
+func (s TestkubeAPI) streamStepLogs(stepName string, execution testkube.Execution, send func(out output.Output) error) error {
+	prefix := "[" + stepName + "] "
+
+	if execution.ExecutionResult != nil && execution.ExecutionResult.Status != nil && execution.ExecutionResult.IsCompleted() {
+		s.loadOutput(&execution)
+		for _, line := range strings.Split(strings.TrimRight(execution.ExecutionResult.Output, "\n"), "\n") {
+			if err := send(output.NewOutputLine([]byte(prefix + line))); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	logs, err := s.Executor.Logs(execution.Id)
+	if err != nil {
+		return send(output.NewOutputError(fmt.Errorf("%sgetting logs error: %w", prefix, err)))
+	}
+
+	redactor := s.getRedactor(context.Background(), execution)
+	for out := range logs {
+		// step results are not passed as they would stop clients listening on the stream
+		if out.Type_ == output.TypeResult {
+			continue
+		}
+
+		out = redactor.Output(out)
+		out.Content = prefix + out.Content
+		if err := send(out); err != nil {
+			// drain logs channel so executor can finish tailing
+			for range logs {
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s TestkubeAPI) executeTestSuite(ctx context.Context, testSuite testkube.TestSuite, request testkube.TestSuiteExecutionRequest) (
 	testsuiteExecution testkube.TestSuiteExecution, err error) {
 	s.Log.Debugw("Got test to execute", "test", testSuite)
 
 	testsuiteExecution = testkube.NewStartedTestSuiteExecution(testSuite, request)
-	err = s.TestExecutionResults.Insert(ctx, testsuiteExecution)
+	return s.runTestSuiteExecution(ctx, testsuiteExecution, request, 0)
+}
+
+// runTestSuiteExecution stores started test suite execution and runs its steps in background, steps before
+// from index are carried over from rerun test suite execution and only pass their output variables to next steps
+func (s TestkubeAPI) runTestSuiteExecution(ctx context.Context, testsuiteExecution testkube.TestSuiteExecution,
+	request testkube.TestSuiteExecutionRequest, from int) (testkube.TestSuiteExecution, error) {
+	var testSuiteName string
+	if testsuiteExecution.TestSuite != nil {
+		testSuiteName = testsuiteExecution.TestSuite.Name
+	}
+
+	testsuiteExecution.RequestId = server.RequestID(ctx)
+	err := s.TestExecutionResults.Insert(ctx, testsuiteExecution)
 	if err != nil {
-		s.Log.Infow("Inserting test execution", "error", err)
+		s.Logger(ctx).Infow("Inserting test execution", "error", err)
 	}
 
+	// test suite execution is cancelled by abort, storage calls aren't bound to its context
+	runCtx, done := s.suiteRuns.start(testsuiteExecution.Id)
+	runCtx = server.WithRequestID(runCtx, testsuiteExecution.RequestId)
 	go func(testsuiteExecution testkube.TestSuiteExecution, request testkube.TestSuiteExecutionRequest) {
+		defer done()
 
+		// execution aborted in storage by other API server gets its end event from abort
+		abortedInStorage := false
 		defer func(testExecution *testkube.TestSuiteExecution) {
 			duration := testExecution.CalculateDuration()
 			testExecution.EndTime = time.Now()
@@ -466,52 +761,135 @@ func (s TestkubeAPI) executeTestSuite(ctx context.Context, testSuite testkube.Te
 			if err != nil {
 				s.Log.Errorw("error setting end time", "error", err.Error())
 			}
+
+			if !abortedInStorage {
+				s.notifyTestSuiteEnd(*testExecution)
+			}
 		}(&testsuiteExecution)
 
-		hasFailedSteps := false
+		hasFailedSteps, aborted, stopped := false, false, false
+		// output variables emitted by steps are passed as params to next steps
+		variables := map[string]string{}
 		for i := range testsuiteExecution.StepResults {
+			if i < from {
+				if execution := testsuiteExecution.StepResults[i].Execution; execution != nil && execution.ExecutionResult != nil {
+					for name, value := range execution.ExecutionResult.OutputVariables {
+						variables[name] = value
+					}
+				}
+				continue
+			}
+
+			// queued steps of aborted test suite are skipped, it can be aborted by other API server too
+			if !aborted && runCtx.Err() == nil && s.isTestSuiteExecutionAborted(testsuiteExecution.Id) {
+				abortedInStorage = true
+			}
+
+			if aborted = aborted || runCtx.Err() != nil || abortedInStorage; aborted {
+				testsuiteExecution.StepResults[i].Skip()
+				continue
+			}
+
+			step := testsuiteExecution.StepResults[i].Step
+			// only conditional steps, e.g. cleanup on failure, are executed after test suite was stopped by failed step
+			if stopped && (step == nil || step.Condition == "") {
+				continue
+			}
+
+			if step != nil && step.Condition != "" {
+				run, err := condition.Evaluate(step.Condition, stepConditionContext(testsuiteExecution, request, variables, hasFailedSteps, i))
+				if err != nil {
+					testsuiteExecution.StepResults[i].Err(fmt.Errorf("invalid step condition: %w", err))
+					hasFailedSteps = true
+					continue
+				}
+
+				if !run {
+					s.Log.Debugw("skipping test suite step", "step", step.FullName(), "condition", step.Condition)
+					testsuiteExecution.StepResults[i].Skip()
+					continue
+				}
+			}
+
+			// set step execution name upfront so step logs can be found while the step is running
+			if step != nil && step.Type() == testkube.TestSuiteStepTypeExecuteTest {
+				testsuiteExecution.StepResults[i].Execution.Name = fmt.Sprintf("%s-%s-%s", testSuiteName, step.Execute.Name, rand.String(5))
+				testsuiteExecution.StepResults[i].Execution.TestName = step.Execute.Name
+			}
 
 			// start execution of given step
 			testsuiteExecution.StepResults[i].Execution.ExecutionResult.InProgress()
-			err = s.TestExecutionResults.Update(ctx, testsuiteExecution)
+			err = s.updateTestSuiteExecution(ctx, testsuiteExecution)
 			if err != nil {
 				s.Log.Infow("Updating test execution", "error", err)
 			}
 
-			s.executeTestStep(ctx, testsuiteExecution, request, &testsuiteExecution.StepResults[i])
+			if step != nil && step.Type() == testkube.TestSuiteStepTypeApproval {
+				s.waitForStepApproval(runCtx, testsuiteExecution, i)
+			} else {
+				s.executeTestStep(runCtx, testsuiteExecution, request, variables, &testsuiteExecution.StepResults[i])
+			}
+			if execution := testsuiteExecution.StepResults[i].Execution; execution != nil && execution.ExecutionResult != nil {
+				for name, value := range execution.ExecutionResult.OutputVariables {
+					variables[name] = value
+				}
+			}
 
-			err := s.TestExecutionResults.Update(ctx, testsuiteExecution)
+			err := s.updateTestSuiteExecution(ctx, testsuiteExecution)
 			if err != nil {
 				hasFailedSteps = true
 				s.Log.Errorw("saving test suite execution results error", "error", err)
 				continue
 			}
 
+			if testsuiteExecution.StepResults[i].IsAborted() {
+				aborted = true
+				continue
+			}
+
 			if testsuiteExecution.StepResults[i].IsFailed() {
+				// quarantined tests still run but their failures don't fail test suite
+				if execution := testsuiteExecution.StepResults[i].Execution; execution != nil && testkube.IsQuarantined(execution.Labels) {
+					s.Log.Infow("ignoring quarantined test failure", "test", execution.TestName, "executionId", execution.Id)
+					continue
+				}
+
+				if testsuiteExecution.StepResults[i].Step.ContinueOnError {
+					s.Log.Infow("ignoring test suite step failure", "step", testsuiteExecution.StepResults[i].Step.FullName())
+					continue
+				}
+
 				hasFailedSteps = true
 				if testsuiteExecution.StepResults[i].Step.StopTestOnFailure {
-					break
+					stopped = true
 				}
 			}
 		}
 
 		testsuiteExecution.Status = testkube.TestSuiteExecutionStatusPassed
-		if hasFailedSteps {
+		switch {
+		case aborted:
+			testsuiteExecution.Status = testkube.TestSuiteExecutionStatusAborted
+		case hasFailedSteps:
 			testsuiteExecution.Status = testkube.TestSuiteExecutionStatusFailed
 		}
 
-		err := s.TestExecutionResults.Update(ctx, testsuiteExecution)
+		err := s.updateTestSuiteExecution(ctx, testsuiteExecution)
 		if err != nil {
 			s.Log.Errorw("saving final test suite execution result error", "error", err)
 		}
 
+		if testsuiteExecution.IsPassed() {
+			s.triggerTestSuiteExecutions(testsuiteExecution, variables)
+		}
+
 	}(testsuiteExecution, request)
 
 	return testsuiteExecution, nil
 }
 
 func (s TestkubeAPI) executeTestStep(ctx context.Context, testsuiteExecution testkube.TestSuiteExecution,
-	request testkube.TestSuiteExecutionRequest, result *testkube.TestSuiteStepExecutionResult) {
+	request testkube.TestSuiteExecutionRequest, variables map[string]string, result *testkube.TestSuiteStepExecutionResult) {
 
 	var testSuiteName string
 	if testsuiteExecution.TestSuite != nil {
@@ -526,33 +904,124 @@ func (s TestkubeAPI) executeTestStep(ctx context.Context, testsuiteExecution tes
 
 	case testkube.TestSuiteStepTypeExecuteTest:
 		executeTestStep := step.Execute
+		name := fmt.Sprintf("%s-%s-%s", testSuiteName, executeTestStep.Name, rand.String(5))
+		if result.Execution != nil && result.Execution.Name != "" {
+			name = result.Execution.Name
+		}
+
+		params := mergeStepParams(testsuiteExecution.Params, executeTestStep.Params, variables, request.Params)
 		request := testkube.ExecutionRequest{
-			Name:       fmt.Sprintf("%s-%s-%s", testSuiteName, executeTestStep.Name, rand.String(5)),
+			Name:       name,
 			Namespace:  executeTestStep.Namespace,
-			Params:     testsuiteExecution.Params,
+			Params:     params,
 			Sync:       true,
 			HttpProxy:  request.HttpProxy,
 			HttpsProxy: request.HttpsProxy,
+			// test suite steps are grouped by test suite execution
+			Labels:         map[string]string{testkube.ExecutionGroupLabel: testsuiteExecution.Id},
+			RunningContext: testsuiteExecution.RunningContext,
 		}
 
-		l.Debug("executing test", "params", testsuiteExecution.Params)
-		execution, err := s.executeTest(ctx, testkube.Test{Name: executeTestStep.Name}, request)
-		if err != nil {
-			result.Err(err)
-			return
+		for attempt := int32(1); ; attempt++ {
+			result.Attempts = attempt
+			result.TimedOut = false
+			l.Debug("executing test", "params", params, "attempt", attempt)
+			s.executeTestStepAttempt(ctx, step, request, result)
+
+			// aborted steps aren't retried
+			if ctx.Err() != nil || result.IsAborted() || !result.IsFailed() || attempt > step.Retries {
+				break
+			}
+
+			l.Infow("retrying failed test suite step", "attempt", attempt, "retries", step.Retries)
+			request.Name = fmt.Sprintf("%s-%s-%s", testSuiteName, executeTestStep.Name, rand.String(5))
+			result.Execution = testkube.NewQueuedExecution()
+			result.Execution.Name = request.Name
+			result.Execution.TestName = executeTestStep.Name
 		}
-		result.Execution = &execution
 
 	case testkube.TestSuiteStepTypeDelay:
 		l.Debug("delaying execution")
-		time.Sleep(time.Millisecond * time.Duration(step.Delay.Duration))
-		result.Execution.ExecutionResult.Success()
+		select {
+		case <-time.After(time.Millisecond * time.Duration(step.Delay.Duration)):
+			result.Execution.ExecutionResult.Success()
+		case <-ctx.Done():
+			result.Abort()
+		}
 
 	default:
 		result.Err(fmt.Errorf("can't find handler for execution step type: '%v'", step.Type()))
 	}
 }
 
+// executeTestStepAttempt executes test of step once, test running longer than step timeout is aborted and step fails
+func (s TestkubeAPI) executeTestStepAttempt(ctx context.Context, step *testkube.TestSuiteStep,
+	request testkube.ExecutionRequest, result *testkube.TestSuiteStepExecutionResult) {
+	execution, timedOut, err := s.executeSuiteStepTest(ctx, testkube.Test{Name: step.Execute.Name}, request,
+		time.Duration(step.Timeout)*time.Second)
+	timeoutErr := fmt.Errorf("step timed out after %ds", step.Timeout)
+	if err != nil {
+		switch {
+		case timedOut:
+			result.Err(timeoutErr)
+			result.TimedOut = true
+		// step waiting for free worker isn't executed when test suite is aborted
+		case ctx.Err() != nil:
+			result.Abort()
+		default:
+			result.Err(err)
+		}
+		return
+	}
+
+	// test aborted by step timeout is stored as failed
+	if timedOut && execution.ExecutionResult != nil && execution.ExecutionResult.Status != nil && execution.ExecutionResult.IsAborted() {
+		execution.ExecutionResult.Err(timeoutErr)
+		if err = s.ExecutionResults.UpdateResult(context.Background(), execution.Id, *execution.ExecutionResult); err != nil {
+			s.Log.Errorw("saving timed out test suite step result error", "executionId", execution.Id, "error", err)
+		}
+		result.TimedOut = true
+	}
+
+	result.Execution = &execution
+}
+
+// stepConditionContext returns test suite execution state step condition is evaluated against
+func stepConditionContext(testsuiteExecution testkube.TestSuiteExecution, request testkube.TestSuiteExecutionRequest,
+	variables map[string]string, failed bool, index int) condition.Context {
+	ctx := condition.Context{
+		Params: mergeStepParams(testsuiteExecution.Params, nil, variables, request.Params),
+		Failed: failed,
+	}
+
+	// steps which weren't executed are ignored
+	for i := index - 1; i >= 0; i-- {
+		previous := testsuiteExecution.StepResults[i].Execution
+		if previous == nil || previous.ExecutionResult == nil || previous.ExecutionResult.Status == nil ||
+			previous.ExecutionResult.IsQueued() || previous.ExecutionResult.IsSkipped() {
+			continue
+		}
+
+		ctx.PreviousStatus = string(*previous.ExecutionResult.Status)
+		break
+	}
+
+	return ctx
+}
+
+// mergeStepParams returns test suite execution params overridden by step params and output variables of previous steps,
+// test suite execution request params have the highest priority
+func mergeStepParams(suiteParams, stepParams, variables, requestParams map[string]string) map[string]string {
+	params := make(map[string]string, len(suiteParams)+len(stepParams)+len(variables)+len(requestParams))
+	for _, source := range []map[string]string{suiteParams, stepParams, variables, requestParams} {
+		for k, v := range source {
+			params[k] = v
+		}
+	}
+
+	return params
+}
+
 func getExecutionsFilterFromRequest(c *fiber.Ctx) testresult.Filter {
 
 	filter := testresult.NewExecutionsFilter()
@@ -595,6 +1064,11 @@ func getExecutionsFilterFromRequest(c *fiber.Ctx) testresult.Filter {
 		filter = filter.WithSelector(selector)
 	}
 
+	project := getProject(c)
+	if project != "" {
+		filter = filter.WithProject(project)
+	}
+
 	return filter
 }
 
@@ -650,53 +1124,7 @@ func mapStepResultToExecutionSummary(r testkube.TestSuiteStepExecutionResult) te
 		TestName: testName,
 		Status:   status,
 		Type_:    stepType,
+		Attempts: r.Attempts,
+		TimedOut: r.TimedOut,
 	}
 }
-
-func mapTestSuiteUpsertRequestToTestCRD(request testkube.TestSuiteUpsertRequest) testsuitesv1.TestSuite {
-	return testsuitesv1.TestSuite{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      request.Name,
-			Namespace: request.Namespace,
-			Labels:    request.Labels,
-		},
-		Spec: testsuitesv1.TestSuiteSpec{
-			Repeats:     int(request.Repeats),
-			Description: request.Description,
-			Before:      mapTestStepsToCRD(request.Before),
-			Steps:       mapTestStepsToCRD(request.Steps),
-			After:       mapTestStepsToCRD(request.After),
-			Schedule:    request.Schedule,
-			Params:      request.Params,
-		},
-	}
-}
-
-func mapTestStepsToCRD(steps []testkube.TestSuiteStep) (out []testsuitesv1.TestSuiteStepSpec) {
-	for _, step := range steps {
-		out = append(out, mapTestStepToCRD(step))
-	}
-
-	return
-}
-
-func mapTestStepToCRD(step testkube.TestSuiteStep) (stepSpec testsuitesv1.TestSuiteStepSpec) {
-	switch step.Type() {
-
-	case testkube.TestSuiteStepTypeDelay:
-		stepSpec.Delay = &testsuitesv1.TestSuiteStepDelay{
-			Duration: step.Delay.Duration,
-		}
-
-	case testkube.TestSuiteStepTypeExecuteTest:
-		s := step.Execute
-		stepSpec.Execute = &testsuitesv1.TestSuiteStepExecute{
-			Namespace: s.Namespace,
-			Name:      s.Name,
-			// TODO move StopOnFailure level up in operator model to mimic this one
-			StopOnFailure: step.StopTestOnFailure,
-		}
-	}
-
-	return
-}
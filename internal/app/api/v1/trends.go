@@ -0,0 +1,81 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/problem"
+)
+
+const (
+	defaultTrendInterval = "day"
+	defaultTrendWindow   = "30d"
+	// maxTrendBuckets limits number of buckets, e.g. hourly trend of a year
+	maxTrendBuckets = 1000
+)
+
+// trendIntervals are supported trend bucket intervals
+var trendIntervals = map[string]time.Duration{
+	"hour": time.Hour,
+	"day":  24 * time.Hour,
+	"week": 7 * 24 * time.Hour,
+}
+
+// parseTrendOptions parses trend bucket interval and window
+func parseTrendOptions(intervalName, windowValue string) (interval, window time.Duration, err error) {
+	interval, ok := trendIntervals[intervalName]
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid interval %s, one of hour, day or week is supported", intervalName)
+	}
+
+	if window, err = parseReportPeriod(windowValue); err != nil {
+		return 0, 0, err
+	}
+
+	if window == 0 || window/interval > maxTrendBuckets {
+		return 0, 0, fmt.Errorf("window %s should have from 1 to %d %s buckets", windowValue, maxTrendBuckets, intervalName)
+	}
+
+	return interval, window, nil
+}
+
+// GetExecutionsTrendHandler returns time bucketed execution counts by status and duration percentiles of a test
+func (s TestkubeAPI) GetExecutionsTrendHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Params("id")
+		intervalName := c.Query("interval", defaultTrendInterval)
+		interval, window, err := parseTrendOptions(intervalName, c.Query("window", defaultTrendWindow))
+		if err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		if project := getProject(c); project != "" {
+			test, err := s.TestsClient.Get(id)
+			if err != nil && !errors.IsNotFound(err) {
+				return s.Error(c, http.StatusBadGateway, err)
+			}
+
+			if err != nil || !isInProject(test.Labels, project) {
+				return s.Error(c, http.StatusNotFound, problem.WithCode(problem.CodeTestNotFound, fmt.Errorf("test %s not found", id)))
+			}
+		}
+
+		since := time.Now().Add(-window)
+		buckets, err := s.ExecutionResults.GetTrends(c.Context(), id, since, interval)
+		if err != nil {
+			return s.Error(c, http.StatusInternalServerError, err)
+		}
+
+		return c.JSON(testkube.ExecutionsTrend{
+			TestName: id,
+			Interval: intervalName,
+			Since:    since,
+			Buckets:  buckets,
+		})
+	}
+}
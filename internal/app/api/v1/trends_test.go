@@ -0,0 +1,37 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTrendOptions(t *testing.T) {
+
+	t.Run("daily buckets of a month", func(t *testing.T) {
+		interval, window, err := parseTrendOptions("day", "30d")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 24*time.Hour, interval)
+		assert.Equal(t, 30*24*time.Hour, window)
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		_, _, err := parseTrendOptions("minute", "30d")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("too many buckets", func(t *testing.T) {
+		_, _, err := parseTrendOptions("hour", "365d")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("empty window", func(t *testing.T) {
+		_, _, err := parseTrendOptions("day", "0d")
+
+		assert.Error(t, err)
+	})
+}
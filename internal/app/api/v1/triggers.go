@@ -0,0 +1,248 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	testsmapper "github.com/kubeshop/testkube/pkg/mapper/tests"
+	testsuitesmapper "github.com/kubeshop/testkube/pkg/mapper/testsuites"
+	"github.com/kubeshop/testkube/pkg/server"
+)
+
+// maxTriggersChain is a number of chained triggered executions, longer chains aren't continued
+const maxTriggersChain = 10
+
+// getTriggerTarget returns labels and annotations of test or test suite trigger points to
+func (s TestkubeAPI) getTriggerTarget(trigger testkube.ExecutionTrigger) (labels, annotations map[string]string, err error) {
+	if trigger.Type_ == testkube.ExecutionTriggerTypeTestSuite {
+		testSuite, err := s.TestsSuitesClient.Get(trigger.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return testSuite.Labels, testSuite.Annotations, nil
+	}
+
+	test, err := s.TestsClient.Get(trigger.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return test.Labels, test.Annotations, nil
+}
+
+// validateExecutionTriggers checks that triggered tests and test suites exist in project, triggers leading back to
+// source test or test suite are rejected as its executions would trigger each other endlessly
+func (s TestkubeAPI) validateExecutionTriggers(project, source string, triggers []testkube.ExecutionTrigger) error {
+	if err := testkube.ValidateExecutionTriggers(triggers); err != nil {
+		return err
+	}
+
+	visited := map[string]bool{}
+	var walk func(chain []string, triggers []testkube.ExecutionTrigger) error
+	walk = func(chain []string, triggers []testkube.ExecutionTrigger) error {
+		for _, trigger := range triggers {
+			target := trigger.String()
+			if target == source {
+				return fmt.Errorf("triggers loop %s", strings.Join(append(chain, target), " -> "))
+			}
+
+			if visited[target] {
+				continue
+			}
+			visited[target] = true
+
+			// only triggers of source are required to exist, dangling triggers of other objects are ignored
+			labels, annotations, err := s.getTriggerTarget(trigger)
+			if errors.IsNotFound(err) || (err == nil && len(chain) == 1 && !isInProject(labels, project)) {
+				if len(chain) == 1 {
+					return fmt.Errorf("triggered %s not found", target)
+				}
+				continue
+			}
+
+			if err != nil {
+				return fmt.Errorf("can't get triggered %s: %w", target, err)
+			}
+
+			if err = walk(append(chain, target), testkube.ExecutionTriggersFromAnnotations(annotations)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return walk([]string{source}, triggers)
+}
+
+// triggerTestExecutions runs triggers of test in background when its execution passes, end events of async
+// executions are sent once executor is started, so they are watched until they complete, completed executions
+// which didn't pass are skipped without reading test, test is read from informer cache when it's enabled
+func (s TestkubeAPI) triggerTestExecutions(execution testkube.Execution) {
+	if execution.ExecutionResult == nil || execution.ExecutionResult.Status == nil ||
+		(execution.ExecutionResult.IsCompleted() && !execution.ExecutionResult.IsPassed()) {
+		return
+	}
+
+	go func() {
+		test, err := s.TestsClient.Get(execution.TestName)
+		if err != nil {
+			s.Log.Warnw("can't get test of execution triggers", "test", execution.TestName, "error", err)
+			return
+		}
+
+		triggers := testkube.ExecutionTriggersFromAnnotations(test.Annotations)
+		if len(triggers) == 0 {
+			return
+		}
+
+		ctx := server.WithRequestID(context.Background(), execution.RequestId)
+		if !execution.ExecutionResult.IsCompleted() {
+			// watch stops when execution completes or can't be read
+			for range s.Executor.Watch(execution.Id) {
+			}
+
+			completed, err := s.ExecutionResults.Get(ctx, execution.Id)
+			if err != nil {
+				s.Log.Warnw("can't get completed execution of triggers", "executionId", execution.Id, "error", err)
+				return
+			}
+			execution = completed
+		}
+
+		if execution.ExecutionResult == nil || execution.ExecutionResult.Status == nil || !execution.ExecutionResult.IsPassed() {
+			return
+		}
+
+		s.runExecutionTriggers(ctx, testkube.ExecutionTriggerSource(testkube.ExecutionTriggerTypeTest, test.Name),
+			triggers, execution.RunningContext, execution.ExecutionResult.OutputVariables)
+	}()
+}
+
+// triggerTestSuiteExecutions runs triggers of test suite which execution passed in background, output variables
+// of all steps are passed to triggered executions
+func (s TestkubeAPI) triggerTestSuiteExecutions(execution testkube.TestSuiteExecution, variables map[string]string) {
+	if execution.TestSuite == nil {
+		return
+	}
+
+	go func() {
+		testSuite, err := s.TestsSuitesClient.Get(execution.TestSuite.Name)
+		if err != nil {
+			s.Log.Warnw("can't get test suite of passed execution triggers", "testSuite", execution.TestSuite.Name, "error", err)
+			return
+		}
+
+		ctx := server.WithRequestID(context.Background(), execution.RequestId)
+		s.runExecutionTriggers(ctx, testkube.ExecutionTriggerSource(testkube.ExecutionTriggerTypeTestSuite, testSuite.Name),
+			testkube.ExecutionTriggersFromAnnotations(testSuite.Annotations), execution.RunningContext, variables)
+	}()
+}
+
+// runExecutionTriggers starts executions triggered by passed execution of source test or test suite, triggers
+// of tests and test suites already in the chain aren't run, so loops created outside of API stop
+func (s TestkubeAPI) runExecutionTriggers(ctx context.Context, source string, triggers []testkube.ExecutionTrigger,
+	runningContext *testkube.RunningContext, variables map[string]string) {
+	if len(triggers) == 0 {
+		return
+	}
+
+	l := s.Logger(ctx).With("source", source)
+	triggered := runningContext.WithTrigger(source)
+	for _, trigger := range chainTriggers(l, triggered.TriggeredBy, triggers) {
+		params := mergeParams(mergeParams(nil, variables), trigger.Params)
+		if err := s.runExecutionTrigger(ctx, trigger, params, triggered); err != nil {
+			l.Warnw("can't run triggered execution", "trigger", trigger.String(), "error", err)
+		}
+	}
+}
+
+// runExecutionTrigger starts execution of triggered test or test suite, disabled tests and test suites are skipped
+func (s TestkubeAPI) runExecutionTrigger(ctx context.Context, trigger testkube.ExecutionTrigger, params map[string]string,
+	runningContext *testkube.RunningContext) error {
+	l := s.Logger(ctx).With("trigger", trigger.String(), "triggeredBy", runningContext.TriggeredBy)
+	switch trigger.Type_ {
+	case testkube.ExecutionTriggerTypeTest:
+		test, err := s.TestsClient.Get(trigger.Name)
+		if err != nil {
+			return err
+		}
+
+		if testkube.IsDisabled(test.Labels) {
+			l.Infow("skipping disabled triggered test")
+			return nil
+		}
+
+		request := testkube.ExecutionRequest{Params: params, RunningContext: runningContext}
+		execution, err := s.executeTest(ctx, testsmapper.MapTestCRToAPI(*test), request)
+		if err != nil {
+			return err
+		}
+
+		l.Infow("triggered test executed", "executionId", execution.Id)
+
+	case testkube.ExecutionTriggerTypeTestSuite:
+		testSuite, err := s.TestsSuitesClient.Get(trigger.Name)
+		if err != nil {
+			return err
+		}
+
+		if testkube.IsDisabled(testSuite.Labels) {
+			l.Infow("skipping disabled triggered test suite")
+			return nil
+		}
+
+		if err = testsuitesmapper.ValidateStepOptions(*testSuite); err != nil {
+			return err
+		}
+
+		request := testkube.TestSuiteExecutionRequest{Params: params, RunningContext: runningContext}
+		execution, err := s.executeTestSuite(ctx, testsuitesmapper.MapCRToAPI(*testSuite), request)
+		if err != nil {
+			return err
+		}
+
+		l.Infow("triggered test suite executed", "executionId", execution.Id)
+
+	default:
+		return fmt.Errorf("unknown trigger type %s", trigger.Type_)
+	}
+
+	return nil
+}
+
+// chainTriggers returns triggers which can continue triggers chain, triggers of too long chains and triggers of
+// tests and test suites already in the chain are skipped
+func chainTriggers(l *zap.SugaredLogger, chain []string, triggers []testkube.ExecutionTrigger) (runnable []testkube.ExecutionTrigger) {
+	if len(chain) > maxTriggersChain {
+		l.Warnw("skipping triggers of too long triggers chain", "chain", chain)
+		return nil
+	}
+
+	for _, trigger := range triggers {
+		if isInTriggersChain(chain, trigger.String()) {
+			l.Warnw("skipping trigger loop", "trigger", trigger.String(), "chain", chain)
+			continue
+		}
+		runnable = append(runnable, trigger)
+	}
+
+	return runnable
+}
+
+// isInTriggersChain checks if test or test suite already triggered execution in the chain
+func isInTriggersChain(chain []string, target string) bool {
+	for _, source := range chain {
+		if source == target {
+			return true
+		}
+	}
+
+	return false
+}
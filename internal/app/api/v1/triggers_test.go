@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	testsv2 "github.com/kubeshop/testkube-operator/apis/tests/v2"
+	testsuitesv1 "github.com/kubeshop/testkube-operator/apis/testsuite/v1"
+	testsclientv2 "github.com/kubeshop/testkube-operator/client/tests/v2"
+	testsuitesclientv1 "github.com/kubeshop/testkube-operator/client/testsuites/v1"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/log"
+)
+
+// newTriggersAPI returns API with tests and test suites triggering objects in format type/name
+func newTriggersAPI(t *testing.T, tests, testSuites map[string][]string) TestkubeAPI {
+	scheme := runtime.NewScheme()
+	require.NoError(t, testsv2.AddToScheme(scheme))
+	require.NoError(t, testsuitesv1.AddToScheme(scheme))
+
+	annotations := func(targets []string) map[string]string {
+		var triggers []testkube.ExecutionTrigger
+		for _, target := range targets {
+			trigger, err := testkube.ParseExecutionTrigger(target)
+			require.NoError(t, err)
+			triggers = append(triggers, trigger)
+		}
+		return testkube.ExecutionTriggersAnnotations(triggers)
+	}
+
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for name, targets := range tests {
+		builder = builder.WithObjects(&testsv2.Test{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "testkube",
+			Annotations: annotations(targets)}})
+	}
+
+	for name, targets := range testSuites {
+		builder = builder.WithObjects(&testsuitesv1.TestSuite{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "testkube",
+			Annotations: annotations(targets)}})
+	}
+
+	client := builder.Build()
+	return TestkubeAPI{
+		TestsClient:       testsclientv2.NewClient(client, "testkube"),
+		TestsSuitesClient: testsuitesclientv1.NewClient(client, "testkube"),
+	}
+}
+
+func TestValidateExecutionTriggers(t *testing.T) {
+	s := newTriggersAPI(t, map[string][]string{
+		"smoke": nil,
+		"api":   {"testsuite/e2e"},
+		"db":    {"api", "dangling"},
+	}, map[string][]string{
+		"e2e":        {"smoke"},
+		"regression": {"db"},
+	})
+
+	tests := map[string]struct {
+		source   string
+		triggers []string
+		err      string
+	}{
+		"existing targets":       {source: "test/checkout", triggers: []string{"smoke", "testsuite/e2e"}},
+		"dangling nested target": {source: "test/checkout", triggers: []string{"db"}},
+		"missing target":         {source: "test/checkout", triggers: []string{"payments"}, err: "triggered test/payments not found"},
+		"direct loop":            {source: "testsuite/e2e", triggers: []string{"api"}, err: "triggers loop testsuite/e2e -> test/api -> testsuite/e2e"},
+		"transitive loop": {source: "test/smoke", triggers: []string{"testsuite/regression"},
+			err: "triggers loop test/smoke -> testsuite/regression -> test/db -> test/api -> testsuite/e2e -> test/smoke"},
+		"itself":    {source: "test/smoke", triggers: []string{"smoke"}, err: "triggers loop test/smoke -> test/smoke"},
+		"duplicate": {source: "test/checkout", triggers: []string{"smoke", "test/smoke"}, err: "duplicate trigger test/smoke"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var triggers []testkube.ExecutionTrigger
+			for _, target := range test.triggers {
+				trigger, err := testkube.ParseExecutionTrigger(target)
+				require.NoError(t, err)
+				triggers = append(triggers, trigger)
+			}
+
+			err := s.validateExecutionTriggers("", test.source, triggers)
+			if test.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), test.err)
+			}
+		})
+	}
+}
+
+func TestChainTriggers(t *testing.T) {
+	triggers := []testkube.ExecutionTrigger{
+		{Type_: testkube.ExecutionTriggerTypeTest, Name: "api"},
+		{Type_: testkube.ExecutionTriggerTypeTestSuite, Name: "e2e"},
+	}
+
+	longChain := make([]string, maxTriggersChain+1)
+	for i := range longChain {
+		longChain[i] = testkube.ExecutionTriggerSource(testkube.ExecutionTriggerTypeTest, string(rune('a'+i)))
+	}
+
+	tests := map[string]struct {
+		chain    []string
+		runnable []testkube.ExecutionTrigger
+	}{
+		"new targets":      {chain: []string{"test/smoke"}, runnable: triggers},
+		"target in chain":  {chain: []string{"testsuite/e2e", "test/smoke"}, runnable: triggers[:1]},
+		"all in chain":     {chain: []string{"test/api", "testsuite/e2e", "test/smoke"}},
+		"same name types":  {chain: []string{"testsuite/api", "test/smoke"}, runnable: triggers},
+		"too long chain":   {chain: longChain},
+		"max chain length": {chain: longChain[:maxTriggersChain], runnable: triggers},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.runnable, chainTriggers(log.DefaultLogger, test.chain, triggers))
+		})
+	}
+}
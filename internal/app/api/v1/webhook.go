@@ -1,13 +1,22 @@
 package v1
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gofiber/fiber/v2"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	executorv1 "github.com/kubeshop/testkube-operator/apis/executor/v1"
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	webhooksmapper "github.com/kubeshop/testkube/pkg/mapper/webhooks"
+	"github.com/kubeshop/testkube/pkg/problem"
+	"github.com/kubeshop/testkube/pkg/webhook"
 )
 
+// webhookSecretLabel is a testkube label value of webhook signing secrets
+const webhookSecretLabel = "webhooks-secrets"
+
 func (s TestkubeAPI) CreateWebhookHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var request testkube.WebhookCreateRequest
@@ -16,11 +25,34 @@ func (s TestkubeAPI) CreateWebhookHandler() fiber.Handler {
 			return s.Error(c, http.StatusBadRequest, err)
 		}
 
-		webhook := webhooksmapper.MapAPIToCRD(request)
-		webhook.Namespace = s.Namespace
+		if _, err := webhook.Matches(testkube.Webhook{Selector: request.Selector, Statuses: request.Statuses}, testkube.Execution{}); err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		crd := webhooksmapper.MapAPIToCRD(request)
+		crd.Namespace = s.Namespace
+
+		// signing secret is stored outside of webhook resource and referenced by annotation
+		if request.SigningSecret != "" {
+			secretName := getWebhookSigningSecretName(request.Name)
+			stringData := map[string]string{webhook.SigningSecretKey: request.SigningSecret}
+			if err = s.SecretClient.Create(secretName, map[string]string{"testkube": webhookSecretLabel}, stringData); err != nil {
+				return s.Error(c, http.StatusBadGateway, err)
+			}
+
+			if crd.Annotations == nil {
+				crd.Annotations = map[string]string{}
+			}
+			crd.Annotations[testkube.WebhookSigningSecretAnnotation] = secretName
+		}
 
-		created, err := s.WebhooksClient.Create(&webhook)
+		created, err := s.WebhooksClient.Create(&crd)
 		if err != nil {
+			if request.SigningSecret != "" {
+				if err := s.SecretClient.Delete(getWebhookSigningSecretName(request.Name)); err != nil {
+					s.Logger(c.Context()).Warnw("deleting webhook signing secret failed", "webhook", request.Name, "error", err)
+				}
+			}
 			return s.Error(c, http.StatusBadRequest, err)
 		}
 
@@ -50,6 +82,10 @@ func (s TestkubeAPI) GetWebhookHandler() fiber.Handler {
 		name := c.Params("name")
 
 		item, err := s.WebhooksClient.Get(name)
+		if errors.IsNotFound(err) {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeWebhookNotFound, err))
+		}
+
 		if err != nil {
 			return s.Error(c, http.StatusBadRequest, err)
 		}
@@ -64,10 +100,18 @@ func (s TestkubeAPI) DeleteWebhookHandler() fiber.Handler {
 		name := c.Params("name")
 
 		err := s.WebhooksClient.Delete(name)
+		if errors.IsNotFound(err) {
+			return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeWebhookNotFound, err))
+		}
+
 		if err != nil {
 			return s.Error(c, http.StatusBadRequest, err)
 		}
 
+		if err = s.deleteWebhookSigningSecret(name); err != nil {
+			return s.Error(c, http.StatusBadGateway, err)
+		}
+
 		c.Status(204)
 		return nil
 	}
@@ -75,12 +119,75 @@ func (s TestkubeAPI) DeleteWebhookHandler() fiber.Handler {
 
 func (s TestkubeAPI) DeleteWebhooksHandler() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		err := s.WebhooksClient.DeleteByLabels(c.Query("selector"))
+		selector := c.Query("selector")
+		list, err := s.WebhooksClient.List(selector)
+		if err != nil {
+			return s.Error(c, http.StatusBadRequest, err)
+		}
+
+		err = s.WebhooksClient.DeleteByLabels(selector)
 		if err != nil {
 			return s.Error(c, http.StatusBadRequest, err)
 		}
 
+		for _, item := range list.Items {
+			if err = s.deleteWebhookSigningSecret(item.Name); err != nil {
+				return s.Error(c, http.StatusBadGateway, err)
+			}
+		}
+
 		c.Status(http.StatusNoContent)
 		return nil
 	}
 }
+
+// newWebhookEvent returns event of webhook with its signing secret and HTTP client
+func (s TestkubeAPI) newWebhookEvent(wh executorv1.Webhook, eventType *testkube.WebhookEventType) (event testkube.WebhookEvent, err error) {
+	event = testkube.WebhookEvent{Uri: wh.Spec.Uri, Type_: eventType}
+	if event.SigningSecret, err = s.getWebhookSigningSecret(wh); err != nil {
+		return event, err
+	}
+
+	proxyURL := wh.Annotations[testkube.WebhookProxyAnnotation]
+	tlsSecret := wh.Annotations[testkube.WebhookTLSSecretAnnotation]
+	if proxyURL == "" && tlsSecret == "" {
+		return event, nil
+	}
+
+	event.HTTPClient, err = s.getHTTPClient(proxyURL, tlsSecret)
+	return event, err
+}
+
+// getWebhookSigningSecret returns payload signing secret of webhook, empty secret means payloads aren't signed
+func (s TestkubeAPI) getWebhookSigningSecret(wh executorv1.Webhook) (string, error) {
+	secretName := wh.Annotations[testkube.WebhookSigningSecretAnnotation]
+	if secretName == "" {
+		return "", nil
+	}
+
+	data, err := s.SecretClient.Get(secretName)
+	if err != nil {
+		return "", err
+	}
+
+	signingSecret, ok := data[webhook.SigningSecretKey]
+	if !ok || signingSecret == "" {
+		return "", fmt.Errorf("secret %s has no %s key", secretName, webhook.SigningSecretKey)
+	}
+
+	return signingSecret, nil
+}
+
+// deleteWebhookSigningSecret deletes signing secret created with webhook, referenced secrets created by users are kept
+func (s TestkubeAPI) deleteWebhookSigningSecret(name string) error {
+	if err := s.SecretClient.Delete(getWebhookSigningSecretName(name)); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// getWebhookSigningSecretName returns name of Secret with webhook signing secret
+func getWebhookSigningSecretName(name string) string {
+	return fmt.Sprintf("%s-webhook-signing", name)
+}
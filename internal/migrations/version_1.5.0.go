@@ -0,0 +1,96 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/testresult"
+	"github.com/kubeshop/testkube/pkg/migrator"
+)
+
+func NewVersion_1_5_0(db *mongo.Database) *Version_1_5_0 {
+	return &Version_1_5_0{
+		db: db,
+	}
+}
+
+// Version_1_5_0 migrates results database, server migrations are run on every start of matching API server version,
+// so the migration is idempotent
+type Version_1_5_0 struct {
+	db *mongo.Database
+}
+
+func (m *Version_1_5_0) Version() string {
+	return "1.5.0"
+}
+func (m *Version_1_5_0) Migrate() error {
+	if err := migrateLegacyStatuses(context.Background(), m.db); err != nil {
+		return err
+	}
+
+	return seedExecutionNumbers(context.Background(), m.db)
+}
+func (m *Version_1_5_0) Info() string {
+	return "Renaming legacy success, pending and error statuses to passed, running and failed and seeding execution number counters from stored executions"
+}
+
+func (m *Version_1_5_0) Type() migrator.MigrationType {
+	return migrator.MigrationTypeServer
+}
+
+// legacyStatuses are statuses stored before statuses were unified
+var legacyStatuses = map[string]string{
+	"success": "passed",
+	"pending": "running",
+	"error":   "failed",
+}
+
+func migrateLegacyStatuses(ctx context.Context, db *mongo.Database) error {
+	var modified int64
+	for legacy, status := range legacyStatuses {
+		executions, err := db.Collection(result.CollectionName).UpdateMany(ctx, bson.M{"executionresult.status": legacy},
+			bson.M{"$set": bson.M{"executionresult.status": status}})
+		if err != nil {
+			return err
+		}
+
+		testSuiteExecutions, err := db.Collection(testresult.CollectionName).UpdateMany(ctx, bson.M{"status": legacy},
+			bson.M{"$set": bson.M{"status": status}})
+		if err != nil {
+			return err
+		}
+
+		modified += executions.ModifiedCount + testSuiteExecutions.ModifiedCount
+	}
+
+	if modified == 0 {
+		return nil
+	}
+
+	// counters of legacy statuses are rebuilt on next totals read
+	_, err := db.Collection(result.CountersCollectionName).DeleteMany(ctx, bson.M{})
+	return err
+}
+
+// seedExecutionNumbers raises execution number counters to highest stored execution numbers, so executions stored
+// before counters were introduced don't share numbers with new ones
+func seedExecutionNumbers(ctx context.Context, db *mongo.Database) error {
+	cursor, err := db.Collection(result.CollectionName).Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: bson.M{"number": bson.M{"$gt": 0}}}},
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$testname"}, {Key: "number", Value: bson.D{{Key: "$max", Value: "$number"}}}}}},
+		{{Key: "$merge", Value: bson.D{
+			{Key: "into", Value: result.NumbersCollectionName},
+			{Key: "whenMatched", Value: bson.A{bson.D{{Key: "$set", Value: bson.D{
+				{Key: "number", Value: bson.D{{Key: "$max", Value: bson.A{"$number", "$$new.number"}}}},
+			}}}}},
+		}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	return cursor.Close(ctx)
+}
@@ -0,0 +1,52 @@
+//go:build integration
+
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/storage"
+)
+
+const (
+	mongoDns    = "mongodb://localhost:27017"
+	mongoDbName = "testkube-test"
+)
+
+func TestSeedExecutionNumbers(t *testing.T) {
+	assert := require.New(t)
+
+	db, err := storage.GetMongoDataBase(mongoDns, mongoDbName)
+	assert.NoError(err)
+
+	results := db.Collection(result.CollectionName)
+	numbers := db.Collection(result.NumbersCollectionName)
+	assert.NoError(results.Drop(context.Background()))
+	assert.NoError(numbers.Drop(context.Background()))
+
+	_, err = results.InsertMany(context.Background(), []interface{}{
+		bson.M{"id": "1", "testname": "api", "number": 3},
+		bson.M{"id": "2", "testname": "api", "number": 7},
+		bson.M{"id": "3", "testname": "ui", "number": 2},
+	})
+	assert.NoError(err)
+	_, err = numbers.InsertOne(context.Background(), bson.M{"_id": "ui", "number": 5})
+	assert.NoError(err)
+
+	assert.NoError(seedExecutionNumbers(context.Background(), db))
+	assert.NoError(seedExecutionNumbers(context.Background(), db), "migration is run again on next start")
+
+	repository := result.NewMongoRespository(db)
+	number, err := repository.GetNextExecutionNumber(context.Background(), "api")
+	assert.NoError(err)
+	assert.Equal(int32(8), number)
+
+	number, err = repository.GetNextExecutionNumber(context.Background(), "ui")
+	assert.NoError(err)
+	assert.Equal(int32(6), number, "higher counter is kept")
+}
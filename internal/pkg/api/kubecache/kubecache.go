@@ -0,0 +1,307 @@
+package kubecache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	executorv1 "github.com/kubeshop/testkube-operator/apis/executor/v1"
+	testsv2 "github.com/kubeshop/testkube-operator/apis/tests/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	// TestTypeField is an informer cache index of test types
+	TestTypeField = "spec.type"
+
+	// DefaultMaxStaleness is a default time objects written by client are read from API server
+	// when informer doesn't observe the write
+	DefaultMaxStaleness = 10 * time.Second
+)
+
+// Options are informer cache options
+type Options struct {
+	Namespace string
+	// Resync is a period of informer cache resync
+	Resync time.Duration
+	// SyncTimeout is a maximum time of waiting for initial cache sync
+	SyncTimeout time.Duration
+	// MaxStaleness is a maximum time written objects are read from API server, zero uses DefaultMaxStaleness
+	MaxStaleness time.Duration
+}
+
+// Client reads tests, executors and webhooks from informer cache and all other objects from API server,
+// writes go to API server and invalidate cached objects until informer observes them
+type Client struct {
+	client.Client
+	cache        cache.Cache
+	maxStaleness time.Duration
+	// cached objects with their field indexes
+	cached map[schema.GroupVersionKind]map[string]bool
+
+	mutex sync.Mutex
+	// invalidated objects with expiration, empty name invalidates all objects of kind
+	invalidated map[objectKey]time.Time
+}
+
+type objectKey struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+	name      string
+}
+
+// NewClient starts informer cache of tests, executors and webhooks running until ctx is done and returns client reading
+// from it, error is returned when cache isn't synced in time, e.g. without list and watch permissions
+func NewClient(ctx context.Context, config *rest.Config, c client.Client, options Options) (*Client, error) {
+	informers, err := cache.New(config, cache.Options{
+		Scheme:    c.Scheme(),
+		Mapper:    c.RESTMapper(),
+		Resync:    &options.Resync,
+		Namespace: options.Namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if options.MaxStaleness == 0 {
+		options.MaxStaleness = DefaultMaxStaleness
+	}
+
+	cached := newClient(c, informers, options.MaxStaleness)
+	err = cached.add(ctx, &testsv2.Test{}, map[string]client.IndexerFunc{
+		TestTypeField: func(object client.Object) []string {
+			if test, ok := object.(*testsv2.Test); ok && test.Spec.Type_ != "" {
+				return []string{test.Spec.Type_}
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range []client.Object{&executorv1.Executor{}, &executorv1.Webhook{}} {
+		if err = cached.add(ctx, obj, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	// informers run until ctx is done, they are stopped right away when cache isn't synced
+	ctx, stop := context.WithCancel(ctx)
+	synced := false
+	defer func() {
+		if !synced {
+			stop()
+		}
+	}()
+
+	go func() {
+		_ = informers.Start(ctx)
+	}()
+
+	syncCtx, cancel := context.WithTimeout(ctx, options.SyncTimeout)
+	defer cancel()
+	if !informers.WaitForCacheSync(syncCtx) {
+		return nil, fmt.Errorf("informer cache not synced in %s", options.SyncTimeout)
+	}
+
+	synced = true
+	return cached, nil
+}
+
+func newClient(c client.Client, informers cache.Cache, maxStaleness time.Duration) *Client {
+	return &Client{
+		Client:       c,
+		cache:        informers,
+		maxStaleness: maxStaleness,
+		cached:       map[schema.GroupVersionKind]map[string]bool{},
+		invalidated:  map[objectKey]time.Time{},
+	}
+}
+
+// Get gets object from cache when it's cached and not invalidated
+func (c *Client) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if gvk, ok := c.cachedKind(obj); ok && !c.isInvalidated(objectKey{gvk: gvk, namespace: key.Namespace, name: key.Name}) {
+		return c.cache.Get(ctx, key, obj)
+	}
+
+	return c.Client.Get(ctx, key, obj)
+}
+
+// List lists objects from cache when they are cached and none of them is invalidated,
+// field selectors are supported for indexed fields only, so they are always listed from cache
+func (c *Client) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if gvk, ok := c.cachedKind(list); ok {
+		options := (&client.ListOptions{}).ApplyOptions(opts)
+		if (options.FieldSelector != nil && !options.FieldSelector.Empty()) || !c.isKindInvalidated(gvk) {
+			return c.cache.List(ctx, list, opts...)
+		}
+	}
+
+	return c.Client.List(ctx, list, opts...)
+}
+
+// Create creates object and invalidates it in cache
+func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	err := c.Client.Create(ctx, obj, opts...)
+	c.invalidate(obj, err)
+	return err
+}
+
+// Update updates object and invalidates it in cache
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	err := c.Client.Update(ctx, obj, opts...)
+	c.invalidate(obj, err)
+	return err
+}
+
+// Patch patches object and invalidates it in cache
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	c.invalidate(obj, err)
+	return err
+}
+
+// Delete deletes object and invalidates it in cache
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	err := c.Client.Delete(ctx, obj, opts...)
+	c.invalidate(obj, err)
+	return err
+}
+
+// DeleteAllOf deletes objects and invalidates all objects of their kind in cache
+func (c *Client) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	err := c.Client.DeleteAllOf(ctx, obj, opts...)
+	if gvk, ok := c.cachedKind(obj); ok && err == nil {
+		c.mutex.Lock()
+		c.invalidated[objectKey{gvk: gvk}] = time.Now().Add(c.maxStaleness)
+		c.mutex.Unlock()
+	}
+
+	return err
+}
+
+// HasIndex returns true when client lists given objects from cache with field index
+func HasIndex(c client.Client, obj client.Object, field string) bool {
+	cached, ok := c.(*Client)
+	if !ok {
+		return false
+	}
+
+	gvk, ok := cached.cachedKind(obj)
+	return ok && cached.cached[gvk][field]
+}
+
+// add adds object informer with field indexes, it must be called before cache is started
+func (c *Client) add(ctx context.Context, obj client.Object, indexes map[string]client.IndexerFunc) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+
+	informer, err := c.cache.GetInformer(ctx, obj)
+	if err != nil {
+		return err
+	}
+
+	// writes observed by informer are served from cache again
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(object interface{}) { c.observe(gvk, object) },
+		UpdateFunc: func(_, object interface{}) { c.observe(gvk, object) },
+		DeleteFunc: func(object interface{}) { c.observe(gvk, object) },
+	})
+
+	c.cached[gvk] = map[string]bool{}
+	for field, indexer := range indexes {
+		if err = c.cache.IndexField(ctx, obj, field, indexer); err != nil {
+			return err
+		}
+		c.cached[gvk][field] = true
+	}
+
+	return nil
+}
+
+// cachedKind returns kind of cached object or list of objects
+func (c *Client) cachedKind(obj runtime.Object) (schema.GroupVersionKind, bool) {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return gvk, false
+	}
+
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	_, ok := c.cached[gvk]
+	return gvk, ok
+}
+
+func (c *Client) invalidate(obj client.Object, err error) {
+	gvk, ok := c.cachedKind(obj)
+	if !ok || err != nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.invalidated[objectKey{gvk: gvk, namespace: obj.GetNamespace(), name: obj.GetName()}] = time.Now().Add(c.maxStaleness)
+}
+
+func (c *Client) observe(gvk schema.GroupVersionKind, object interface{}) {
+	if tombstone, ok := object.(toolscache.DeletedFinalStateUnknown); ok {
+		object = tombstone.Obj
+	}
+
+	observed, ok := object.(client.Object)
+	if !ok {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.invalidated, objectKey{gvk: gvk, namespace: observed.GetNamespace(), name: observed.GetName()})
+}
+
+func (c *Client) isInvalidated(key objectKey) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	for _, k := range []objectKey{key, {gvk: key.gvk}} {
+		if expiration, ok := c.invalidated[k]; ok {
+			if now.Before(expiration) {
+				return true
+			}
+			delete(c.invalidated, k)
+		}
+	}
+
+	return false
+}
+
+func (c *Client) isKindInvalidated(gvk schema.GroupVersionKind) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	invalidated := false
+	for key, expiration := range c.invalidated {
+		if key.gvk != gvk {
+			continue
+		}
+
+		if now.Before(expiration) {
+			invalidated = true
+		} else {
+			delete(c.invalidated, key)
+		}
+	}
+
+	return invalidated
+}
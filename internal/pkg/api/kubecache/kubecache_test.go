@@ -0,0 +1,78 @@
+package kubecache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	executorv1 "github.com/kubeshop/testkube-operator/apis/executor/v1"
+	testsv2 "github.com/kubeshop/testkube-operator/apis/tests/v2"
+)
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, testsv2.AddToScheme(scheme))
+	require.NoError(t, executorv1.AddToScheme(scheme))
+
+	live := fake.NewClientBuilder().WithScheme(scheme).Build()
+	// fake informers don't fill objects, so cached reads return empty objects
+	informers := &informertest.FakeInformers{Scheme: scheme}
+	cached := newClient(live, informers, time.Minute)
+	require.NoError(t, cached.add(ctx, &testsv2.Test{}, map[string]client.IndexerFunc{TestTypeField: nil}))
+	require.NoError(t, cached.add(ctx, &executorv1.Webhook{}, nil))
+
+	t.Run("cached objects", func(t *testing.T) {
+		assert.True(t, HasIndex(cached, &testsv2.Test{}, TestTypeField))
+		assert.False(t, HasIndex(cached, &testsv2.Test{}, "spec.name"))
+		assert.False(t, HasIndex(cached, &executorv1.Webhook{}, TestTypeField))
+		assert.False(t, HasIndex(live, &testsv2.Test{}, TestTypeField))
+
+		_, ok := cached.cachedKind(&testsv2.TestList{})
+		assert.True(t, ok)
+		_, ok = cached.cachedKind(&executorv1.Executor{})
+		assert.False(t, ok)
+	})
+
+	t.Run("written objects are read from API server until observed", func(t *testing.T) {
+		test := &testsv2.Test{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "testkube"}}
+		require.NoError(t, cached.Create(ctx, test))
+
+		read := &testsv2.Test{}
+		require.NoError(t, cached.Get(ctx, client.ObjectKey{Namespace: "testkube", Name: "api"}, read))
+		assert.Equal(t, "api", read.Name)
+
+		list := &testsv2.TestList{}
+		require.NoError(t, cached.List(ctx, list, client.InNamespace("testkube")))
+		assert.Len(t, list.Items, 1)
+
+		informer, err := informers.FakeInformerFor(&testsv2.Test{})
+		require.NoError(t, err)
+		informer.Add(test)
+
+		read = &testsv2.Test{}
+		require.NoError(t, cached.Get(ctx, client.ObjectKey{Namespace: "testkube", Name: "api"}, read))
+		assert.Empty(t, read.Name)
+
+		list = &testsv2.TestList{}
+		require.NoError(t, cached.List(ctx, list, client.InNamespace("testkube")))
+		assert.Empty(t, list.Items)
+	})
+
+	t.Run("invalidation expires", func(t *testing.T) {
+		cached.maxStaleness = 0
+		require.NoError(t, cached.Create(ctx, &testsv2.Test{ObjectMeta: metav1.ObjectMeta{Name: "expired", Namespace: "testkube"}}))
+
+		read := &testsv2.Test{}
+		require.NoError(t, cached.Get(ctx, client.ObjectKey{Namespace: "testkube", Name: "expired"}, read))
+		assert.Empty(t, read.Name)
+	})
+}
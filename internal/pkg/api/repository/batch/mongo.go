@@ -0,0 +1,88 @@
+package batch
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/digest"
+	"github.com/kubeshop/testkube/pkg/mapper/executions"
+)
+
+const (
+	// DigestsCollectionName stores batches of end events sent in notification digests
+	DigestsCollectionName = "digests"
+	// HeldCollectionName stores batches of end events held in maintenance windows and quiet hours
+	HeldCollectionName = "heldnotifications"
+)
+
+var _ digest.Batches = (*MongoRepository)(nil)
+
+func NewMongoRepository(db *mongo.Database, collectionName string) *MongoRepository {
+	return &MongoRepository{
+		Coll: db.Collection(collectionName),
+	}
+}
+
+// MongoRepository stores batches shared by API server replicas, batch is removed by single replica which takes it,
+// so each digest is sent once and batches survive API server restarts
+type MongoRepository struct {
+	Coll *mongo.Collection
+}
+
+// document is a batch of destination, executions are stored as summaries sent in digests
+type document struct {
+	Destination string                      `bson:"_id"`
+	Start       time.Time                   `bson:"start"`
+	Until       time.Time                   `bson:"until,omitempty"`
+	Executions  []testkube.ExecutionSummary `bson:"executions"`
+}
+
+func (r *MongoRepository) Add(ctx context.Context, destination string, execution testkube.Execution, now time.Time) error {
+	return r.push(ctx, destination, execution, bson.M{"$setOnInsert": bson.M{"start": now}})
+}
+
+func (r *MongoRepository) Hold(ctx context.Context, destination string, execution testkube.Execution, now, until time.Time) error {
+	return r.push(ctx, destination, execution, bson.M{"$setOnInsert": bson.M{"start": now}, "$max": bson.M{"until": until}})
+}
+
+// push appends execution summary to destination batch, batch inserted by other replica meanwhile is updated on retry
+func (r *MongoRepository) push(ctx context.Context, destination string, execution testkube.Execution, update bson.M) error {
+	update["$push"] = bson.M{"executions": executions.MapToSummary([]testkube.Execution{execution})[0]}
+	_, err := r.Coll.UpdateOne(ctx, bson.M{"_id": destination}, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		_, err = r.Coll.UpdateOne(ctx, bson.M{"_id": destination}, update, options.Update().SetUpsert(true))
+	}
+
+	return err
+}
+
+func (r *MongoRepository) Release(ctx context.Context, now time.Time) (map[string]testkube.ExecutionsDigest, error) {
+	return r.take(ctx, bson.M{"until": bson.M{"$lte": now}}, now)
+}
+
+func (r *MongoRepository) Flush(ctx context.Context, window time.Duration, now time.Time) (map[string]testkube.ExecutionsDigest, error) {
+	return r.take(ctx, bson.M{"start": bson.M{"$lte": now.Add(-window)}}, now)
+}
+
+// take removes matching batches one by one and returns their digests, batches removed by other replicas are skipped
+func (r *MongoRepository) take(ctx context.Context, filter bson.M, now time.Time) (map[string]testkube.ExecutionsDigest, error) {
+	digests := map[string]testkube.ExecutionsDigest{}
+	for {
+		var batch document
+		err := r.Coll.FindOneAndDelete(ctx, filter).Decode(&batch)
+		if err == mongo.ErrNoDocuments {
+			return digests, nil
+		}
+
+		if err != nil {
+			return digests, err
+		}
+
+		digests[batch.Destination] = digest.NewDigest(batch.Executions, batch.Start, now)
+	}
+}
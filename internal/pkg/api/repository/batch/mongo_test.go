@@ -0,0 +1,73 @@
+//go:build integration
+
+package batch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/storage"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const (
+	mongoDns    = "mongodb://localhost:27017"
+	mongoDbName = "testkube-test"
+)
+
+func getRepository() (*MongoRepository, error) {
+	db, err := storage.GetMongoDataBase(mongoDns, mongoDbName)
+	repository := NewMongoRepository(db, DigestsCollectionName)
+	return repository, err
+}
+
+func TestStorage(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	passed := testkube.Execution{Id: "1", ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed}}
+	failed := testkube.Execution{Id: "2", ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusFailed}}
+
+	t.Run("batch is flushed once after window passes", func(t *testing.T) {
+		assert.NoError(repository.Add(ctx, "slack", passed, now))
+		assert.NoError(repository.Add(ctx, "slack", failed, now.Add(time.Minute)))
+
+		digests, err := repository.Flush(ctx, 15*time.Minute, now.Add(10*time.Minute))
+		assert.NoError(err)
+		assert.Empty(digests)
+
+		digests, err = repository.Flush(ctx, 15*time.Minute, now.Add(15*time.Minute))
+		assert.NoError(err)
+		assert.Len(digests["slack"].Executions, 2)
+		assert.Equal(int32(1), digests["slack"].Passed)
+		assert.Equal(int32(1), digests["slack"].Failed)
+		assert.True(now.Equal(digests["slack"].StartTime))
+
+		digests, err = repository.Flush(ctx, 0, now.Add(15*time.Minute))
+		assert.NoError(err)
+		assert.Empty(digests, "batch is taken by single replica")
+	})
+
+	t.Run("held batch is released after latest hold", func(t *testing.T) {
+		assert.NoError(repository.Hold(ctx, "webhook/example", failed, now, now.Add(2*time.Hour)))
+		assert.NoError(repository.Hold(ctx, "webhook/example", passed, now, now.Add(time.Hour)))
+
+		digests, err := repository.Release(ctx, now.Add(time.Hour))
+		assert.NoError(err)
+		assert.Empty(digests)
+
+		digests, err = repository.Release(ctx, now.Add(2*time.Hour))
+		assert.NoError(err)
+		assert.Len(digests["webhook/example"].Executions, 2)
+	})
+}
@@ -0,0 +1,25 @@
+package lease
+
+import (
+	"context"
+	"time"
+)
+
+// Lease is a named lock held by holder until it expires
+type Lease struct {
+	Name   string `bson:"_id"`
+	Holder string `bson:"holder"`
+	// CreateTime is time of the first lease acquire
+	CreateTime time.Time `bson:"createtime"`
+	RenewTime  time.Time `bson:"renewtime"`
+	ExpireTime time.Time `bson:"expiretime"`
+}
+
+type Repository interface {
+	// TryAcquire acquires or renews lease for holder, false is returned when lease is held by other holder
+	TryAcquire(ctx context.Context, name, holder string, ttl time.Duration) (acquired bool, err error)
+	// Release releases lease held by holder
+	Release(ctx context.Context, name, holder string) error
+	// ListActive lists not expired leases with name prefix
+	ListActive(ctx context.Context, prefix string) ([]Lease, error)
+}
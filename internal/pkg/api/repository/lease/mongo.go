@@ -0,0 +1,64 @@
+package lease
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const CollectionName = "leases"
+
+func NewMongoRespository(db *mongo.Database) *MongoRepository {
+	return &MongoRepository{
+		Coll: db.Collection(CollectionName),
+	}
+}
+
+type MongoRepository struct {
+	Coll *mongo.Collection
+}
+
+func (r *MongoRepository) TryAcquire(ctx context.Context, name, holder string, ttl time.Duration) (acquired bool, err error) {
+	now := time.Now()
+	// lease can be renewed by its holder or taken over when expired, other holders fail on duplicated id insert
+	filter := bson.M{"_id": name, "$or": bson.A{
+		bson.M{"holder": holder},
+		bson.M{"expiretime": bson.M{"$lt": now}},
+	}}
+	update := bson.M{
+		"$set":         bson.M{"holder": holder, "renewtime": now, "expiretime": now.Add(ttl)},
+		"$setOnInsert": bson.M{"createtime": now},
+	}
+
+	_, err = r.Coll.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (r *MongoRepository) Release(ctx context.Context, name, holder string) error {
+	_, err := r.Coll.DeleteOne(ctx, bson.M{"_id": name, "holder": holder})
+	return err
+}
+
+func (r *MongoRepository) ListActive(ctx context.Context, prefix string) (leases []Lease, err error) {
+	filter := bson.M{
+		"_id":        bson.M{"$regex": primitive.Regex{Pattern: "^" + regexp.QuoteMeta(prefix)}},
+		"expiretime": bson.M{"$gte": time.Now()},
+	}
+
+	cursor, err := r.Coll.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cursor.All(ctx, &leases)
+	return leases, err
+}
@@ -0,0 +1,78 @@
+//go:build integration
+
+package lease
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/storage"
+)
+
+const (
+	mongoDns    = "mongodb://localhost:27017"
+	mongoDbName = "testkube-test"
+)
+
+func getRepository() (*MongoRepository, error) {
+	db, err := storage.GetMongoDataBase(mongoDns, mongoDbName)
+	repository := NewMongoRespository(db)
+	return repository, err
+}
+
+func TestStorage(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+
+	t.Run("lease is held by single holder until expired", func(t *testing.T) {
+		acquired, err := repository.TryAcquire(context.Background(), "leader", "api-1", time.Second)
+		assert.NoError(err)
+		assert.True(acquired)
+
+		acquired, err = repository.TryAcquire(context.Background(), "leader", "api-2", time.Second)
+		assert.NoError(err)
+		assert.False(acquired)
+
+		acquired, err = repository.TryAcquire(context.Background(), "leader", "api-1", time.Second)
+		assert.NoError(err)
+		assert.True(acquired)
+
+		time.Sleep(1100 * time.Millisecond)
+		acquired, err = repository.TryAcquire(context.Background(), "leader", "api-2", time.Second)
+		assert.NoError(err)
+		assert.True(acquired)
+	})
+
+	t.Run("released lease can be acquired by other holder", func(t *testing.T) {
+		_, err := repository.TryAcquire(context.Background(), "lock", "api-1", time.Minute)
+		assert.NoError(err)
+
+		err = repository.Release(context.Background(), "lock", "api-1")
+		assert.NoError(err)
+
+		acquired, err := repository.TryAcquire(context.Background(), "lock", "api-2", time.Minute)
+		assert.NoError(err)
+		assert.True(acquired)
+	})
+
+	t.Run("active leases are listed by prefix", func(t *testing.T) {
+		_, err := repository.TryAcquire(context.Background(), "api-instance/1", "1", time.Minute)
+		assert.NoError(err)
+		_, err = repository.TryAcquire(context.Background(), "api-instance/2", "2", time.Millisecond)
+		assert.NoError(err)
+		time.Sleep(10 * time.Millisecond)
+
+		leases, err := repository.ListActive(context.Background(), "api-instance/")
+		assert.NoError(err)
+		assert.Len(leases, 1)
+		assert.Equal("1", leases[0].Holder)
+	})
+}
@@ -0,0 +1,217 @@
+package result
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const (
+	// CountersCollectionName is a collection of execution counts per test, start day and status
+	CountersCollectionName = "executioncounters"
+	// countersRebuildCollectionName is a collection counters are rebuilt into before replacing counters collection
+	countersRebuildCollectionName = "executioncounters_rebuild"
+	// countersReadyID is an id of document marking counters consistent with executions
+	countersReadyID = "ready"
+	day             = 24 * time.Hour
+)
+
+// counterKey is an id of counter document, field order matters as ids are matched as embedded documents
+type counterKey struct {
+	TestName string    `bson:"testname"`
+	Day      time.Time `bson:"day"`
+	Status   string    `bson:"status"`
+}
+
+func newCounterKey(execution testkube.Execution) *counterKey {
+	key := counterKey{TestName: execution.TestName, Day: execution.StartTime.UTC().Truncate(day)}
+	if execution.ExecutionResult != nil && execution.ExecutionResult.Status != nil {
+		key.Status = string(*execution.ExecutionResult.Status)
+	}
+
+	return &key
+}
+
+// counterProjection projects execution fields used in counter key
+var counterProjection = bson.M{"testname": 1, "starttime": 1, "executionresult.status": 1}
+
+// countersSupported checks if totals of filter can be read from counters, counters have no labels, types,
+// projects, environments and only day precision of start time
+func countersSupported(paging bool, filter ...Filter) bool {
+	if paging {
+		return false
+	}
+
+	for _, f := range filter {
+		if f.StartDateDefined() || f.EndDateDefined() || f.TextSearchDefined() || f.Selector() != "" ||
+			f.TypeDefined() || f.ProjectDefined() || f.GroupIdDefined() || f.ExecutorImageDigest() != "" ||
+			f.NodeName() != "" || f.KubernetesVersion() != "" || f.RunnerVersion() != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getCounterTotals reads totals from counters, false is returned when counters aren't consistent with executions
+func (r *MongoRepository) getCounterTotals(ctx context.Context, filter ...Filter) (totals testkube.ExecutionsTotals, ok bool, err error) {
+	count, err := r.CountersColl.CountDocuments(ctx, bson.M{"_id": countersReadyID})
+	if err != nil || count == 0 {
+		return totals, false, err
+	}
+
+	// ready marker has string id without test name
+	query := bson.M{"_id.testname": bson.M{"$exists": true}}
+	for _, f := range filter {
+		if f.TestNameDefined() {
+			query["_id.testname"] = f.TestName()
+		}
+
+		if f.StatusesDefined() {
+			query["_id.status"] = bson.M{"$in": f.Statuses()}
+		}
+	}
+
+	cursor, err := r.CountersColl.Aggregate(ctx, []bson.D{
+		{{Key: "$match", Value: query}},
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$_id.status"}, {Key: "count", Value: bson.D{{Key: "$sum", Value: "$count"}}}}}},
+	})
+	if err != nil {
+		return totals, false, err
+	}
+
+	var result []statusCount
+	if err = cursor.All(ctx, &result); err != nil {
+		return totals, false, err
+	}
+
+	return newExecutionsTotals(result), true, nil
+}
+
+// updateCounters moves execution from before counter to after counter, nil key means execution didn't exist
+// before or doesn't exist after, counters are invalidated when update fails
+func (r *MongoRepository) updateCounters(ctx context.Context, before, after *counterKey) {
+	if before != nil && after != nil && before.TestName == after.TestName && before.Day.Equal(after.Day) &&
+		before.Status == after.Status {
+		return
+	}
+
+	var models []mongo.WriteModel
+	if before != nil {
+		models = append(models, newCounterUpdate(*before, -1))
+	}
+
+	if after != nil {
+		models = append(models, newCounterUpdate(*after, 1))
+	}
+
+	if len(models) == 0 {
+		return
+	}
+
+	if _, err := r.CountersColl.BulkWrite(ctx, models); err != nil {
+		r.invalidateCounters(ctx)
+	}
+}
+
+func newCounterUpdate(key counterKey, delta int) mongo.WriteModel {
+	return mongo.NewUpdateOneModel().
+		SetFilter(bson.M{"_id": key}).
+		SetUpdate(bson.M{"$inc": bson.M{"count": delta}}).
+		SetUpsert(true)
+}
+
+// invalidateCounters makes totals fall back to aggregation until counters are rebuilt
+func (r *MongoRepository) invalidateCounters(ctx context.Context) {
+	_, _ = r.CountersColl.DeleteOne(ctx, bson.M{"_id": countersReadyID})
+}
+
+// RebuildCounters recalculates counters from executions into a temporary collection using $merge, which
+// requires MongoDB 4.2, and renames it to counters collection, so counters aren't cleared while rebuilding,
+// executions updated during rebuild can be counted inaccurately
+func (r *MongoRepository) RebuildCounters(ctx context.Context) error {
+	db := r.CountersColl.Database()
+	rebuilt := db.Collection(countersRebuildCollectionName)
+	if err := rebuilt.Drop(ctx); err != nil {
+		return err
+	}
+
+	cursor, err := r.Coll.Aggregate(ctx, []bson.D{
+		counterGroupStage(),
+		{{Key: "$merge", Value: bson.D{{Key: "into", Value: countersRebuildCollectionName}, {Key: "whenMatched", Value: "replace"}}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = cursor.Close(ctx); err != nil {
+		return err
+	}
+
+	if _, err = rebuilt.InsertOne(ctx, bson.M{"_id": countersReadyID}); err != nil {
+		return err
+	}
+
+	return db.Client().Database("admin").RunCommand(ctx, bson.D{
+		{Key: "renameCollection", Value: db.Name() + "." + countersRebuildCollectionName},
+		{Key: "to", Value: db.Name() + "." + CountersCollectionName},
+		{Key: "dropTarget", Value: true},
+	}).Err()
+}
+
+// counterCount is a number of executions with counter key
+type counterCount struct {
+	Key   counterKey `bson:"_id"`
+	Count int32      `bson:"count"`
+}
+
+// counterGroupStage groups executions by counter key, start day is calculated the same way as in newCounterKey
+func counterGroupStage() bson.D {
+	startMs := bson.D{{Key: "$toLong", Value: "$starttime"}}
+	return bson.D{{Key: "$group", Value: bson.D{
+		{Key: "_id", Value: bson.D{
+			{Key: "testname", Value: "$testname"},
+			{Key: "day", Value: bson.D{{Key: "$toDate", Value: bson.D{{Key: "$subtract", Value: bson.A{startMs,
+				bson.D{{Key: "$mod", Value: bson.A{startMs, day.Milliseconds()}}}}}}}}},
+			{Key: "status", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$executionresult.status", ""}}}},
+		}},
+		{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+	}}}
+}
+
+// rebuildCountersAsync rebuilds counters in background, only one rebuild runs at a time
+func (r *MongoRepository) rebuildCountersAsync() {
+	if !atomic.CompareAndSwapInt32(&r.rebuilding, 0, 1) {
+		return
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&r.rebuilding, 0)
+		// failed rebuild is retried on next totals miss
+		_ = r.RebuildCounters(context.Background())
+	}()
+}
+
+// findBefore returns counter key of execution before update, nil is returned when execution doesn't exist
+func findBefore(result *mongo.SingleResult) (*counterKey, error) {
+	var before testkube.Execution
+	err := result.Decode(&before)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newCounterKey(before), nil
+}
+
+func findOneAndUpdateOptions() *options.FindOneAndUpdateOptions {
+	return options.FindOneAndUpdate().SetProjection(counterProjection).SetReturnDocument(options.Before)
+}
@@ -0,0 +1,35 @@
+package result
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestCountersSupported(t *testing.T) {
+	assert.True(t, countersSupported(false))
+	assert.True(t, countersSupported(false, NewExecutionsFilter().WithTestName("test").WithStatus("passed")))
+	assert.False(t, countersSupported(true, NewExecutionsFilter()))
+	assert.False(t, countersSupported(false, NewExecutionsFilter().WithStartDate(time.Now())))
+	assert.False(t, countersSupported(false, NewExecutionsFilter().WithSelector("app=api")))
+	assert.False(t, countersSupported(false, NewExecutionsFilter().WithProject("team-a")))
+	assert.False(t, countersSupported(false, NewExecutionsFilter().WithGroupId("62f3a1b2c4d5e6f708192a3b")))
+}
+
+func TestNewCounterKey(t *testing.T) {
+	start := time.Date(2022, 8, 10, 23, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	key := newCounterKey(testkube.Execution{
+		TestName:        "test",
+		StartTime:       start,
+		ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed},
+	})
+
+	assert.Equal(t, "test", key.TestName)
+	assert.Equal(t, time.Date(2022, 8, 10, 0, 0, 0, 0, time.UTC), key.Day)
+	assert.Equal(t, "passed", key.Status)
+	assert.Equal(t, "", newCounterKey(testkube.Execution{}).Status)
+}
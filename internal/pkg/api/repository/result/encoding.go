@@ -0,0 +1,109 @@
+package result
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/compression"
+)
+
+// encodeExecution returns execution as it's stored, output is compressed before it's encrypted as encrypted values don't compress
+func (r *MongoRepository) encodeExecution(execution testkube.Execution) (testkube.Execution, error) {
+	if r.CompressOutput {
+		var err error
+		if execution, err = compression.CompressExecution(execution); err != nil {
+			return execution, err
+		}
+	}
+
+	return r.Encrypter.EncryptExecution(execution)
+}
+
+// encodeResult returns execution result as it's stored
+func (r *MongoRepository) encodeResult(result testkube.ExecutionResult) (testkube.ExecutionResult, error) {
+	if r.CompressOutput {
+		var err error
+		if result, err = compression.CompressResult(result); err != nil {
+			return result, err
+		}
+	}
+
+	return r.Encrypter.EncryptResult(result)
+}
+
+// decodeExecution returns stored execution with decrypted and decompressed values, outputs are decompressed
+// even when compression is disabled, so they stay readable
+func (r *MongoRepository) decodeExecution(execution testkube.Execution) (testkube.Execution, error) {
+	execution, err := r.Encrypter.DecryptExecution(execution)
+	if err != nil {
+		return execution, err
+	}
+
+	return compression.DecompressExecution(execution)
+}
+
+// decodeExecutions decodes stored executions in place
+func (r *MongoRepository) decodeExecutions(executions []testkube.Execution) (err error) {
+	if err = r.Encrypter.DecryptExecutions(executions); err != nil {
+		return err
+	}
+
+	return compression.DecompressExecutions(executions)
+}
+
+// CompressOutputs compresses outputs of executions stored before compression was enabled, outputs changed
+// in the meantime are skipped, number of compressed outputs is returned
+func (r *MongoRepository) CompressOutputs(ctx context.Context) (count int, err error) {
+	query := bson.M{"executionresult.output": bson.M{"$gt": "", "$not": primitive.Regex{Pattern: "^" + compression.Prefix}}}
+	cursor, err := r.Coll.Find(ctx, query, options.Find().SetProjection(bson.M{"id": 1, "executionresult.output": 1}))
+	if err != nil {
+		return count, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var execution testkube.Execution
+		if err = cursor.Decode(&execution); err != nil {
+			return count, err
+		}
+
+		stored := execution.ExecutionResult.Output
+		output, err := r.Encrypter.Decrypt(stored)
+		if err != nil {
+			return count, fmt.Errorf("can't decrypt output of execution %s: %w", execution.Id, err)
+		}
+
+		if compression.IsCompressed(output) {
+			continue
+		}
+
+		compressed, err := compression.Compress(output)
+		if err != nil {
+			return count, fmt.Errorf("can't compress output of execution %s: %w", execution.Id, err)
+		}
+
+		if compressed == output {
+			continue
+		}
+
+		encrypted, err := r.Encrypter.Encrypt(compressed)
+		if err != nil {
+			return count, fmt.Errorf("can't encrypt output of execution %s: %w", execution.Id, err)
+		}
+
+		result, err := r.Coll.UpdateOne(ctx, bson.M{"id": execution.Id, "executionresult.output": stored},
+			bson.M{"$set": bson.M{"executionresult.output": encrypted}})
+		if err != nil {
+			return count, err
+		}
+
+		count += int(result.ModifiedCount)
+	}
+
+	return count, cursor.Err()
+}
@@ -15,7 +15,15 @@ type filter struct {
 	pageSize   int
 	textSearch string
 	selector   string
+	project    string
 	objectType string
+	groupId    string
+	excluded   []string
+	// environment execution ran with
+	executorImageDigest string
+	nodeName            string
+	kubernetesVersion   string
+	runnerVersion       string
 }
 
 func NewExecutionsFilter() *filter {
@@ -67,10 +75,52 @@ func (f *filter) WithSelector(selector string) *filter {
 	return f
 }
 
+func (f *filter) WithProject(project string) *filter {
+	f.project = project
+	return f
+}
+
 func (f *filter) WithType(objectType string) *filter {
 	f.objectType = objectType
 	return f
 }
+
+// WithGroupId filters executions of execution group
+func (f *filter) WithGroupId(groupId string) *filter {
+	f.groupId = groupId
+	return f
+}
+
+// WithExecutorImageDigest filters executions run with executor image of given digest
+func (f *filter) WithExecutorImageDigest(digest string) *filter {
+	f.executorImageDigest = digest
+	return f
+}
+
+// WithNodeName filters executions which pods were scheduled to given node
+func (f *filter) WithNodeName(nodeName string) *filter {
+	f.nodeName = nodeName
+	return f
+}
+
+// WithKubernetesVersion filters executions run in cluster of given Kubernetes version
+func (f *filter) WithKubernetesVersion(version string) *filter {
+	f.kubernetesVersion = version
+	return f
+}
+
+// WithRunnerVersion filters executions run by given version of testing tool
+func (f *filter) WithRunnerVersion(version string) *filter {
+	f.runnerVersion = version
+	return f
+}
+
+// WithExcludedFields excludes fields from returned executions, see ExcludedFields
+func (f *filter) WithExcludedFields(fields []string) *filter {
+	f.excluded = fields
+	return f
+}
+
 func (f filter) TestName() string {
 	return f.testName
 }
@@ -127,6 +177,42 @@ func (f filter) Type() string {
 	return f.objectType
 }
 
+func (f filter) GroupIdDefined() bool {
+	return f.groupId != ""
+}
+
+func (f filter) GroupId() string {
+	return f.groupId
+}
+
 func (f filter) Selector() string {
 	return f.selector
 }
+
+func (f filter) ProjectDefined() bool {
+	return f.project != ""
+}
+
+func (f filter) Project() string {
+	return f.project
+}
+
+func (f filter) ExcludedFields() []string {
+	return f.excluded
+}
+
+func (f filter) ExecutorImageDigest() string {
+	return f.executorImageDigest
+}
+
+func (f filter) NodeName() string {
+	return f.nodeName
+}
+
+func (f filter) KubernetesVersion() string {
+	return f.kubernetesVersion
+}
+
+func (f filter) RunnerVersion() string {
+	return f.runnerVersion
+}
@@ -24,8 +24,17 @@ type Filter interface {
 	TextSearchDefined() bool
 	TextSearch() string
 	Selector() string
+	ProjectDefined() bool
+	Project() string
 	TypeDefined() bool
 	Type() string
+	GroupIdDefined() bool
+	GroupId() string
+	ExcludedFields() []string
+	ExecutorImageDigest() string
+	NodeName() string
+	KubernetesVersion() string
+	RunnerVersion() string
 }
 
 type Repository interface {
@@ -33,15 +42,27 @@ type Repository interface {
 	Get(ctx context.Context, id string) (testkube.Execution, error)
 	// GetByNameAndTest gets execution result by name
 	GetByNameAndTest(ctx context.Context, name, testName string) (testkube.Execution, error)
+	// GetByNumberAndTest gets execution result by execution number of a test
+	GetByNumberAndTest(ctx context.Context, number int32, testName string) (testkube.Execution, error)
+	// GetNextExecutionNumber gets next execution number of a test
+	GetNextExecutionNumber(ctx context.Context, testName string) (int32, error)
+	// EnsureExecutionNumber raises execution number counter of a test to at least given number, so restored numbers aren't reused
+	EnsureExecutionNumber(ctx context.Context, testName string, number int32) error
 	// GetLatestByTest gets latest execution result by test
 	GetLatestByTest(ctx context.Context, testName string) (testkube.Execution, error)
-	// GetLatestByTests gets latest execution results by test names
-	GetLatestByTests(ctx context.Context, testNames []string) (executions []testkube.Execution, err error)
+	// GetLatestByTestAndStatus gets latest execution result by test with given status
+	GetLatestByTestAndStatus(ctx context.Context, testName string, status testkube.ExecutionStatus) (testkube.Execution, error)
+	// GetLatestByTests gets latest execution results by test names without excluded fields
+	GetLatestByTests(ctx context.Context, testNames []string, excludedFields ...string) (executions []testkube.Execution, err error)
 	// GetExecutions gets executions using a filter, use filter with no data for all
 	GetExecutions(ctx context.Context, filter Filter) ([]testkube.Execution, error)
+	// GetTrends gets time bucketed execution counts by status and duration percentiles of a test started since given time
+	GetTrends(ctx context.Context, testName string, since time.Time, interval time.Duration) ([]testkube.ExecutionsTrendBucket, error)
+	// GetUsage gets requested resources usage of finished executions matching filter per test and per label
+	GetUsage(ctx context.Context, filter Filter) (Usage, error)
 	// GetExecutionTotals gets the statistics on number of executions using a filter, but without paging
 	GetExecutionTotals(ctx context.Context, paging bool, filter ...Filter) (result testkube.ExecutionsTotals, err error)
-	// Insert inserts new execution result
+	// Insert inserts new execution result, ErrDuplicateName is returned when execution name already exists for a test
 	Insert(ctx context.Context, result testkube.Execution) error
 	// Update updates execution result
 	Update(ctx context.Context, result testkube.Execution) error
@@ -51,6 +72,27 @@ type Repository interface {
 	StartExecution(ctx context.Context, id string, startTime time.Time) error
 	// EndExecution updates execution end time
 	EndExecution(ctx context.Context, id string, endTime time.Time, duration time.Duration) error
+	// StartArtifactScans stores pending artifact scans of execution which artifacts weren't scanned yet,
+	// false is returned when scans of execution were already started, token hash authenticates scan results reports
+	StartArtifactScans(ctx context.Context, id string, scans []testkube.ArtifactScanResult, tokenHash string) (bool, error)
+	// GetArtifactScanTokenHash returns hash of token authenticating artifact scan results reports of execution
+	GetArtifactScanTokenHash(ctx context.Context, id string) (string, error)
+	// UpdateArtifactScans updates artifact scan results of execution, nil scans are removed
+	UpdateArtifactScans(ctx context.Context, id string, scans []testkube.ArtifactScanResult) error
+	// UpdateEnvironment updates digest of executor image and environment execution ran with
+	UpdateEnvironment(ctx context.Context, id, digest string, environment testkube.ExecutionEnvironment) error
+	// UpdateTimings updates platform timings of execution
+	UpdateTimings(ctx context.Context, id string, timings testkube.ExecutionTimings) error
+	// UpdateContentChecksum updates checksum of resolved test content and whether it changed since previous execution
+	UpdateContentChecksum(ctx context.Context, id, checksum string, changed bool) error
+	// AddAttempt records preempted attempt of rescheduled execution
+	AddAttempt(ctx context.Context, id string, attempt testkube.ExecutionAttempt) error
+	// DeleteStartedBefore deletes executions started before given date
+	DeleteStartedBefore(ctx context.Context, date time.Time) error
+	// GetStartedBefore gets up to limit oldest executions started and restored from archive before given date
+	GetStartedBefore(ctx context.Context, date time.Time, limit int) ([]testkube.Execution, error)
+	// DeleteByIds deletes executions with given ids
+	DeleteByIds(ctx context.Context, ids []string) error
 	// GetLabels get all available labels
 	GetLabels(ctx context.Context) (labels map[string][]string, err error)
 }
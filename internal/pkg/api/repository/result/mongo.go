@@ -2,6 +2,7 @@ package result
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -12,75 +13,160 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/encryption"
 )
 
-const CollectionName = "results"
+const (
+	CollectionName = "results"
+	// NumbersCollectionName is a collection of last execution numbers per test
+	NumbersCollectionName = "executionnumbers"
+)
+
+// ErrDuplicateName is returned when execution with the same name already exists for a test
+var ErrDuplicateName = errors.New("test execution name already exists")
+
+// IsDuplicateNameError checks if execution wasn't inserted as its name already exists for a test
+func IsDuplicateNameError(err error) bool {
+	return errors.Is(err, ErrDuplicateName)
+}
 
 func NewMongoRespository(db *mongo.Database) *MongoRepository {
 	return &MongoRepository{
-		Coll: db.Collection(CollectionName),
+		Coll:         db.Collection(CollectionName),
+		NumbersColl:  db.Collection(NumbersCollectionName),
+		CountersColl: db.Collection(CountersCollectionName),
 	}
 }
 
 type MongoRepository struct {
-	Coll *mongo.Collection
+	Coll         *mongo.Collection
+	NumbersColl  *mongo.Collection
+	CountersColl *mongo.Collection
+	// Encrypter encrypts params, params file and output of stored executions, values are stored as they are when it's nil
+	Encrypter *encryption.Encrypter
+	// CompressOutput enables gzip compression of stored outputs
+	CompressOutput bool
+	// rebuilding is set while counters are rebuilt in background
+	rebuilding int32
+}
+
+// EnsureIndexes creates unique index of test execution names and indexes of execution lookups,
+// existing duplicated names fail index creation
+func (r *MongoRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.Coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "testname", Value: 1}, {Key: "name", Value: 1}},
+			Options: options.Index().SetName("testname_name_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "testname", Value: 1}, {Key: "number", Value: 1}},
+			Options: options.Index().SetName("testname_number"),
+		},
+		{
+			Keys:    bson.D{{Key: "testname", Value: 1}, {Key: "starttime", Value: -1}},
+			Options: options.Index().SetName("testname_starttime"),
+		},
+		{
+			Keys:    bson.D{{Key: "groupid", Value: 1}},
+			Options: options.Index().SetName("groupid").SetSparse(true),
+		},
+	})
+	return err
 }
 
 func (r *MongoRepository) Get(ctx context.Context, id string) (result testkube.Execution, err error) {
 	err = r.Coll.FindOne(ctx, bson.M{"id": id}).Decode(&result)
-	return
+	if err != nil {
+		return result, err
+	}
+
+	return r.decodeExecution(result)
 }
 
 func (r *MongoRepository) GetByNameAndTest(ctx context.Context, name, testName string) (result testkube.Execution, err error) {
 	err = r.Coll.FindOne(ctx, bson.M{"name": name, "testname": testName}).Decode(&result)
-	return
-}
+	if err != nil {
+		return result, err
+	}
 
-func (r *MongoRepository) GetLatestByTest(ctx context.Context, testName string) (result testkube.Execution, err error) {
-	findOptions := options.FindOne()
-	findOptions.SetSort(bson.D{{Key: "starttime", Value: -1}})
-	err = r.Coll.FindOne(ctx, bson.M{"testname": testName}, findOptions).Decode(&result)
-	return
+	return r.decodeExecution(result)
 }
 
-func (r *MongoRepository) GetLatestByTests(ctx context.Context, testNames []string) (executions []testkube.Execution, err error) {
-	var results []struct {
-		LatestID string `bson:"latest_id"`
+func (r *MongoRepository) GetByNumberAndTest(ctx context.Context, number int32, testName string) (result testkube.Execution, err error) {
+	err = r.Coll.FindOne(ctx, bson.M{"number": number, "testname": testName}).Decode(&result)
+	if err != nil {
+		return result, err
 	}
 
-	if len(testNames) == 0 {
-		return executions, nil
-	}
+	return r.decodeExecution(result)
+}
 
-	conditions := bson.A{}
-	for _, testName := range testNames {
-		conditions = append(conditions, bson.M{"testname": testName})
+// GetNextExecutionNumber atomically increments and returns execution number of a test, numbering starts from 1
+func (r *MongoRepository) GetNextExecutionNumber(ctx context.Context, testName string) (number int32, err error) {
+	var counter struct {
+		Number int32 `bson:"number"`
 	}
 
-	pipeline := []bson.D{{{Key: "$match", Value: bson.M{"$or": conditions}}}}
-	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.D{{Key: "starttime", Value: -1}}}})
-	pipeline = append(pipeline, bson.D{
-		{Key: "$group", Value: bson.D{{Key: "_id", Value: "$testname"}, {Key: "latest_id", Value: bson.D{{Key: "$first", Value: "$id"}}}}}})
+	err = r.NumbersColl.FindOneAndUpdate(
+		ctx,
+		bson.M{"_id": testName},
+		bson.M{"$inc": bson.M{"number": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
 
-	cursor, err := r.Coll.Aggregate(ctx, pipeline)
+	return counter.Number, err
+}
+
+// EnsureExecutionNumber raises execution number counter of a test to at least given number
+func (r *MongoRepository) EnsureExecutionNumber(ctx context.Context, testName string, number int32) error {
+	_, err := r.NumbersColl.UpdateOne(ctx, bson.M{"_id": testName}, bson.M{"$max": bson.M{"number": number}}, options.Update().SetUpsert(true))
+	return err
+}
+
+func (r *MongoRepository) GetLatestByTest(ctx context.Context, testName string) (result testkube.Execution, err error) {
+	findOptions := options.FindOne()
+	findOptions.SetSort(bson.D{{Key: "starttime", Value: -1}})
+	err = r.Coll.FindOne(ctx, bson.M{"testname": testName}, findOptions).Decode(&result)
 	if err != nil {
-		return nil, err
+		return result, err
 	}
-	err = cursor.All(ctx, &results)
+
+	return r.decodeExecution(result)
+}
+
+func (r *MongoRepository) GetLatestByTestAndStatus(ctx context.Context, testName string, status testkube.ExecutionStatus) (result testkube.Execution, err error) {
+	findOptions := options.FindOne()
+	findOptions.SetSort(bson.D{{Key: "starttime", Value: -1}})
+	err = r.Coll.FindOne(ctx, bson.M{"testname": testName, "executionresult.status": status}, findOptions).Decode(&result)
 	if err != nil {
-		return nil, err
+		return result, err
 	}
 
-	if len(results) == 0 {
+	return r.decodeExecution(result)
+}
+
+// GetLatestByTests gets latest executions of tests with single aggregation using testname_starttime index
+func (r *MongoRepository) GetLatestByTests(ctx context.Context, testNames []string, excludedFields ...string) (executions []testkube.Execution, err error) {
+	if len(testNames) == 0 {
 		return executions, nil
 	}
 
-	conditions = bson.A{}
-	for _, result := range results {
-		conditions = append(conditions, bson.M{"id": result.LatestID})
+	pipeline := []bson.D{
+		{{Key: "$match", Value: bson.M{"testname": bson.M{"$in": testNames}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "testname", Value: 1}, {Key: "starttime", Value: -1}}}},
+	}
+
+	// heavy fields are projected out before grouping, so they aren't kept in group memory
+	if projection := exclusionProjection(excludedFields); projection != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$project", Value: projection}})
 	}
 
-	cursor, err = r.Coll.Find(ctx, bson.M{"$or": conditions})
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$testname"}, {Key: "latest", Value: bson.D{{Key: "$first", Value: "$$ROOT"}}}}}},
+		bson.D{{Key: "$replaceRoot", Value: bson.D{{Key: "newRoot", Value: "$latest"}}}},
+	)
+
+	cursor, err := r.Coll.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +176,7 @@ func (r *MongoRepository) GetLatestByTests(ctx context.Context, testNames []stri
 		return nil, err
 	}
 
-	return executions, nil
+	return executions, r.decodeExecutions(executions)
 }
 
 func (r *MongoRepository) GetNewestExecutions(ctx context.Context, limit int) (result []testkube.Execution, err error) {
@@ -102,8 +188,11 @@ func (r *MongoRepository) GetNewestExecutions(ctx context.Context, limit int) (r
 	if err != nil {
 		return result, err
 	}
-	err = cursor.All(ctx, &result)
-	return
+	if err = cursor.All(ctx, &result); err != nil {
+		return result, err
+	}
+
+	return result, r.decodeExecutions(result)
 }
 
 func (r *MongoRepository) GetExecutions(ctx context.Context, filter Filter) (result []testkube.Execution, err error) {
@@ -114,17 +203,36 @@ func (r *MongoRepository) GetExecutions(ctx context.Context, filter Filter) (res
 	if err != nil {
 		return
 	}
-	err = cursor.All(ctx, &result)
+	if err = cursor.All(ctx, &result); err != nil {
+		return
+	}
 
-	return
+	return result, r.decodeExecutions(result)
+}
+
+type statusCount struct {
+	Status string `bson:"_id"`
+	Count  int32  `bson:"count"`
 }
 
+// GetExecutionTotals reads totals from pre-aggregated counters when filter allows it, totals are aggregated
+// from executions otherwise or when counters are being rebuilt
 func (r *MongoRepository) GetExecutionTotals(ctx context.Context, paging bool, filter ...Filter) (totals testkube.ExecutionsTotals, err error) {
-	var result []struct {
-		Status string `bson:"_id"`
-		Count  int32  `bson:"count"`
+	if countersSupported(paging, filter...) {
+		totals, ok, err := r.getCounterTotals(ctx, filter...)
+		if err == nil && ok {
+			return totals, nil
+		}
+
+		r.rebuildCountersAsync()
 	}
 
+	return r.aggregateExecutionTotals(ctx, paging, filter...)
+}
+
+func (r *MongoRepository) aggregateExecutionTotals(ctx context.Context, paging bool, filter ...Filter) (totals testkube.ExecutionsTotals, err error) {
+	var result []statusCount
+
 	query := bson.M{}
 	if len(filter) > 0 {
 		query, _ = composeQueryAndOpts(filter[0])
@@ -150,6 +258,10 @@ func (r *MongoRepository) GetExecutionTotals(ctx context.Context, paging bool, f
 		return totals, err
 	}
 
+	return newExecutionsTotals(result), nil
+}
+
+func newExecutionsTotals(result []statusCount) (totals testkube.ExecutionsTotals) {
 	var sum int32
 
 	// TODO: statuses are messy e.g. success==passed error==failed
@@ -168,7 +280,7 @@ func (r *MongoRepository) GetExecutionTotals(ctx context.Context, paging bool, f
 	}
 	totals.Results = sum
 
-	return
+	return totals
 }
 
 func (r *MongoRepository) GetLabels(ctx context.Context) (labels map[string][]string, err error) {
@@ -205,23 +317,95 @@ func (r *MongoRepository) GetLabels(ctx context.Context) (labels map[string][]st
 }
 
 func (r *MongoRepository) Insert(ctx context.Context, result testkube.Execution) (err error) {
-	_, err = r.Coll.InsertOne(ctx, result)
+	// restored executions keep their history
+	if len(result.StatusHistory) == 0 && result.ExecutionResult != nil && result.ExecutionResult.Status != nil {
+		result.StatusHistory = []testkube.ExecutionStatusTransition{testkube.NewExecutionStatusTransition(*result.ExecutionResult, time.Now())}
+	}
+
+	encoded, err := r.encodeExecution(result)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Coll.InsertOne(ctx, encoded)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDuplicateName
+	}
+
+	if err == nil {
+		r.updateCounters(ctx, nil, newCounterKey(result))
+	}
+
 	return
 }
 
 func (r *MongoRepository) Update(ctx context.Context, result testkube.Execution) (err error) {
-	_, err = r.Coll.ReplaceOne(ctx, bson.M{"id": result.Id}, result)
+	encoded, err := r.encodeExecution(result)
+	if err != nil {
+		return err
+	}
+
+	// execution is replaced in pipeline, so status history is extended in the same update
+	update := mongo.Pipeline{{{Key: "$replaceWith", Value: bson.M{"$mergeObjects": bson.A{
+		bson.M{"$literal": encoded},
+		bson.M{"_id": "$_id", "statushistory": statusHistoryExpression(result.ExecutionResult, time.Now())},
+	}}}}}
+	before, err := findBefore(r.Coll.FindOneAndUpdate(ctx, bson.M{"id": result.Id}, update, findOneAndUpdateOptions()))
+	if err == nil && before != nil {
+		r.updateCounters(ctx, before, newCounterKey(result))
+	}
+
 	return
 }
 
 func (r *MongoRepository) UpdateResult(ctx context.Context, id string, result testkube.ExecutionResult) (err error) {
-	_, err = r.Coll.UpdateOne(ctx, bson.M{"id": id}, bson.M{"$set": bson.M{"executionresult": result}})
+	encoded, err := r.encodeResult(result)
+	if err != nil {
+		return err
+	}
+
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"executionresult": bson.M{"$literal": encoded},
+		"statushistory":   statusHistoryExpression(&result, time.Now()),
+	}}}}
+	before, err := findBefore(r.Coll.FindOneAndUpdate(ctx, bson.M{"id": id}, update, findOneAndUpdateOptions()))
+	if err == nil && before != nil {
+		after := *before
+		after.Status = ""
+		if result.Status != nil {
+			after.Status = string(*result.Status)
+		}
+		r.updateCounters(ctx, before, &after)
+	}
+
 	return
 }
 
+// statusHistoryExpression returns update pipeline expression of status history, transition to result status is
+// appended when stored execution has different status, pipeline updates require MongoDB 4.2
+func statusHistoryExpression(result *testkube.ExecutionResult, now time.Time) interface{} {
+	if result == nil || result.Status == nil {
+		return "$statushistory"
+	}
+
+	transition := testkube.NewExecutionStatusTransition(*result, now)
+	return bson.M{"$cond": bson.A{
+		bson.M{"$eq": bson.A{"$executionresult.status", bson.M{"$literal": *result.Status}}},
+		"$statushistory",
+		bson.M{"$concatArrays": bson.A{bson.M{"$ifNull": bson.A{"$statushistory", bson.A{}}}, bson.A{bson.M{"$literal": transition}}}},
+	}}
+}
+
 // StartExecution updates execution start time
 func (r *MongoRepository) StartExecution(ctx context.Context, id string, startTime time.Time) (err error) {
-	_, err = r.Coll.UpdateOne(ctx, bson.M{"id": id}, bson.M{"$set": bson.M{"starttime": startTime}})
+	before, err := findBefore(r.Coll.FindOneAndUpdate(ctx, bson.M{"id": id}, bson.M{"$set": bson.M{"starttime": startTime}},
+		findOneAndUpdateOptions()))
+	if err == nil && before != nil {
+		after := *before
+		after.Day = startTime.UTC().Truncate(day)
+		r.updateCounters(ctx, before, &after)
+	}
+
 	return
 }
 
@@ -231,6 +415,133 @@ func (r *MongoRepository) EndExecution(ctx context.Context, id string, endTime t
 	return
 }
 
+// StartArtifactScans stores pending artifact scans of execution which artifacts weren't scanned yet, token hash
+// isn't part of execution model, so it's never returned with execution
+func (r *MongoRepository) StartArtifactScans(ctx context.Context, id string, scans []testkube.ArtifactScanResult, tokenHash string) (bool, error) {
+	result, err := r.Coll.UpdateOne(ctx, bson.M{"id": id, "artifactscans": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"artifactscans": scans, "artifactscantokenhash": tokenHash}})
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount == 1, nil
+}
+
+// GetArtifactScanTokenHash returns hash of token authenticating artifact scan results reports of execution
+func (r *MongoRepository) GetArtifactScanTokenHash(ctx context.Context, id string) (string, error) {
+	var result struct {
+		TokenHash string `bson:"artifactscantokenhash"`
+	}
+	err := r.Coll.FindOne(ctx, bson.M{"id": id}, options.FindOne().SetProjection(bson.M{"artifactscantokenhash": 1})).Decode(&result)
+	return result.TokenHash, err
+}
+
+// UpdateArtifactScans updates artifact scan results of execution
+func (r *MongoRepository) UpdateArtifactScans(ctx context.Context, id string, scans []testkube.ArtifactScanResult) (err error) {
+	update := bson.M{"$set": bson.M{"artifactscans": scans}}
+	if scans == nil {
+		update = bson.M{"$unset": bson.M{"artifactscans": ""}}
+	}
+
+	_, err = r.Coll.UpdateOne(ctx, bson.M{"id": id}, update)
+	return
+}
+
+// UpdateEnvironment updates digest of executor image and environment execution ran with, empty digest isn't stored
+func (r *MongoRepository) UpdateEnvironment(ctx context.Context, id, digest string, environment testkube.ExecutionEnvironment) (err error) {
+	fields := bson.M{"environment": environment}
+	if digest != "" {
+		fields["executorimagedigest"] = digest
+	}
+
+	_, err = r.Coll.UpdateOne(ctx, bson.M{"id": id}, bson.M{"$set": fields})
+	return
+}
+
+// UpdateTimings updates platform timings of execution
+func (r *MongoRepository) UpdateTimings(ctx context.Context, id string, timings testkube.ExecutionTimings) (err error) {
+	_, err = r.Coll.UpdateOne(ctx, bson.M{"id": id}, bson.M{"$set": bson.M{"timings": timings}})
+	return
+}
+
+// UpdateContentChecksum updates checksum of resolved test content and whether it changed since previous execution
+func (r *MongoRepository) UpdateContentChecksum(ctx context.Context, id, checksum string, changed bool) (err error) {
+	_, err = r.Coll.UpdateOne(ctx, bson.M{"id": id}, bson.M{"$set": bson.M{"contentchecksum": checksum, "contentchanged": changed}})
+	return
+}
+
+// AddAttempt records preempted attempt of rescheduled execution
+func (r *MongoRepository) AddAttempt(ctx context.Context, id string, attempt testkube.ExecutionAttempt) (err error) {
+	_, err = r.Coll.UpdateOne(ctx, bson.M{"id": id}, bson.M{"$push": bson.M{"attempts": attempt}})
+	return
+}
+
+func (r *MongoRepository) DeleteStartedBefore(ctx context.Context, date time.Time) (err error) {
+	return r.deleteExecutions(ctx, bson.M{"starttime": bson.M{"$lt": date}})
+}
+
+// GetStartedBefore gets executions started before given date, executions restored from archive are returned
+// when they were restored before given date too
+func (r *MongoRepository) GetStartedBefore(ctx context.Context, date time.Time, limit int) (result []testkube.Execution, err error) {
+	result = make([]testkube.Execution, 0)
+	query := bson.M{"$and": bson.A{
+		bson.M{"starttime": bson.M{"$lt": date}},
+		bson.M{"$or": bson.A{
+			bson.M{"restoredtime": bson.M{"$exists": false}},
+			bson.M{"restoredtime": bson.M{"$lt": date}},
+		}},
+	}}
+
+	cursor, err := r.Coll.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "starttime", Value: 1}}).SetLimit(int64(limit)))
+	if err != nil {
+		return
+	}
+	if err = cursor.All(ctx, &result); err != nil {
+		return
+	}
+
+	return result, r.decodeExecutions(result)
+}
+
+// DeleteByIds deletes executions with given ids
+func (r *MongoRepository) DeleteByIds(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return r.deleteExecutions(ctx, bson.M{"id": bson.M{"$in": ids}})
+}
+
+func (r *MongoRepository) deleteExecutions(ctx context.Context, query bson.M) (err error) {
+	// deleted executions are counted before deletion to decrement counters
+	var deleted []counterCount
+	cursor, err := r.Coll.Aggregate(ctx, []bson.D{{{Key: "$match", Value: query}}, counterGroupStage()})
+	if err == nil {
+		err = cursor.All(ctx, &deleted)
+	}
+
+	if err != nil {
+		r.invalidateCounters(ctx)
+	}
+
+	if _, err = r.Coll.DeleteMany(ctx, query); err != nil {
+		return err
+	}
+
+	var models []mongo.WriteModel
+	for _, d := range deleted {
+		models = append(models, newCounterUpdate(d.Key, -int(d.Count)))
+	}
+
+	if len(models) != 0 {
+		if _, err := r.CountersColl.BulkWrite(ctx, models); err != nil {
+			r.invalidateCounters(ctx)
+		}
+	}
+
+	return nil
+}
+
 func composeQueryAndOpts(filter Filter) (bson.M, *options.FindOptions) {
 	query := bson.M{}
 	conditions := bson.A{}
@@ -290,9 +601,36 @@ func composeQueryAndOpts(filter Filter) (bson.M, *options.FindOptions) {
 		conditions = append(conditions, bson.M{"testtype": filter.Type()})
 	}
 
+	if filter.ProjectDefined() {
+		conditions = append(conditions, bson.M{"project": filter.Project()})
+	}
+
+	if filter.GroupIdDefined() {
+		conditions = append(conditions, bson.M{"groupid": filter.GroupId()})
+	}
+
+	if filter.ExecutorImageDigest() != "" {
+		conditions = append(conditions, bson.M{"executorimagedigest": filter.ExecutorImageDigest()})
+	}
+
+	if filter.NodeName() != "" {
+		conditions = append(conditions, bson.M{"environment.nodename": filter.NodeName()})
+	}
+
+	if filter.KubernetesVersion() != "" {
+		conditions = append(conditions, bson.M{"environment.kubernetesversion": filter.KubernetesVersion()})
+	}
+
+	if filter.RunnerVersion() != "" {
+		conditions = append(conditions, bson.M{"executionresult.runnerversion": filter.RunnerVersion()})
+	}
+
 	opts.SetSkip(int64(filter.Page() * filter.PageSize()))
 	opts.SetLimit(int64(filter.PageSize()))
 	opts.SetSort(bson.D{{Key: "starttime", Value: -1}})
+	if projection := exclusionProjection(filter.ExcludedFields()); projection != nil {
+		opts.SetProjection(projection)
+	}
 
 	if len(conditions) > 0 {
 		query = bson.M{"$and": conditions}
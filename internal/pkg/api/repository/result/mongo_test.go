@@ -4,14 +4,21 @@ package result
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
 
 	"github.com/kubeshop/testkube/internal/pkg/api/datefilter"
 	"github.com/kubeshop/testkube/internal/pkg/api/repository/storage"
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/compression"
 	"github.com/kubeshop/testkube/pkg/rand"
 )
 
@@ -28,6 +35,8 @@ func TestStorage(t *testing.T) {
 
 	err = repository.Coll.Drop(context.TODO())
 	assert.NoError(err)
+	err = repository.CountersColl.Drop(context.TODO())
+	assert.NoError(err)
 
 	oneDayAgo := time.Now().Add(-24 * time.Hour)
 	twoDaysAgo := time.Now().Add(-48 * time.Hour)
@@ -85,6 +94,13 @@ func TestStorage(t *testing.T) {
 		assert.Equal(*executions[0].ExecutionResult.Status, testkube.FAILED_ExecutionStatus)
 	})
 
+	t.Run("latest execution with status should be the most recent one with that status", func(t *testing.T) {
+		execution, err := repository.GetLatestByTestAndStatus(context.Background(), defaultName, testkube.PASSED_ExecutionStatus)
+		assert.NoError(err)
+		assert.Equal(testkube.PASSED_ExecutionStatus, *execution.ExecutionResult.Status)
+		assert.True(execution.StartTime.After(oneDayAgo))
+	})
+
 	t.Run("filter with different statuses should return only executions with those statuses", func(t *testing.T) {
 
 		executions, err := repository.GetExecutions(context.Background(), NewExecutionsFilter().WithStatus(
@@ -273,6 +289,528 @@ func TestLabels(t *testing.T) {
 	})
 }
 
+func TestExecutionNameUniqueness(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+
+	err = repository.EnsureIndexes(context.Background())
+	assert.NoError(err)
+
+	t.Run("concurrent inserts of the same execution name should store only one execution", func(t *testing.T) {
+		const inserts = 10
+		errs := make(chan error, inserts)
+		var wg sync.WaitGroup
+		for i := 0; i < inserts; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- repository.Insert(context.Background(), testkube.Execution{Id: rand.Name(), TestName: "test", Name: "same-name"})
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		stored, duplicated := 0, 0
+		for err := range errs {
+			switch {
+			case err == nil:
+				stored++
+			case IsDuplicateNameError(err):
+				duplicated++
+			default:
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		assert.Equal(1, stored)
+		assert.Equal(inserts-1, duplicated)
+	})
+
+	t.Run("execution name can be reused by other test", func(t *testing.T) {
+		err := repository.Insert(context.Background(), testkube.Execution{Id: rand.Name(), TestName: "other-test", Name: "same-name"})
+		assert.NoError(err)
+	})
+}
+
+func TestExecutionNumbers(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+	err = repository.NumbersColl.Drop(context.TODO())
+	assert.NoError(err)
+
+	t.Run("concurrent executions of a test should get distinct numbers", func(t *testing.T) {
+		const executions = 10
+		numbers := make(chan int32, executions)
+		var wg sync.WaitGroup
+		for i := 0; i < executions; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				number, err := repository.GetNextExecutionNumber(context.Background(), "test")
+				assert.NoError(err)
+				numbers <- number
+			}()
+		}
+		wg.Wait()
+		close(numbers)
+
+		assigned := map[int32]bool{}
+		for number := range numbers {
+			assigned[number] = true
+		}
+
+		assert.Len(assigned, executions)
+		for i := int32(1); i <= executions; i++ {
+			assert.True(assigned[i])
+		}
+	})
+
+	t.Run("numbering should start from 1 for each test", func(t *testing.T) {
+		number, err := repository.GetNextExecutionNumber(context.Background(), "other-test")
+		assert.NoError(err)
+		assert.Equal(int32(1), number)
+	})
+
+	t.Run("execution should be found by number", func(t *testing.T) {
+		err := repository.Insert(context.Background(), testkube.Execution{Id: rand.Name(), TestName: "other-test", Name: "numbered", Number: 1})
+		assert.NoError(err)
+
+		execution, err := repository.GetByNumberAndTest(context.Background(), 1, "other-test")
+		assert.NoError(err)
+		assert.Equal("numbered", execution.Name)
+	})
+}
+
+func TestTrends(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+
+	today := bucketStart(time.Now(), 24*time.Hour)
+	yesterday := today.Add(-24 * time.Hour)
+	for _, execution := range []struct {
+		status   testkube.ExecutionStatus
+		start    time.Time
+		duration time.Duration
+	}{
+		{testkube.PASSED_ExecutionStatus, yesterday.Add(time.Hour), time.Second},
+		{testkube.PASSED_ExecutionStatus, yesterday.Add(2 * time.Hour), 3 * time.Second},
+		{testkube.FAILED_ExecutionStatus, yesterday.Add(3 * time.Hour), 2 * time.Second},
+		{testkube.PASSED_ExecutionStatus, today, time.Second},
+	} {
+		status := execution.status
+		err = repository.Insert(context.Background(), testkube.Execution{
+			Id:              rand.Name(),
+			TestName:        "trend-test",
+			Name:            rand.Name(),
+			StartTime:       execution.start,
+			EndTime:         execution.start.Add(execution.duration),
+			ExecutionResult: &testkube.ExecutionResult{Status: &status},
+		})
+		assert.NoError(err)
+	}
+
+	buckets, err := repository.GetTrends(context.Background(), "trend-test", yesterday, 24*time.Hour)
+	assert.NoError(err)
+	assert.Len(buckets, 2)
+
+	assert.Equal(yesterday, buckets[0].StartTime)
+	assert.Equal(int32(3), buckets[0].Total)
+	assert.Equal(int32(2), buckets[0].Passed)
+	assert.Equal(int32(1), buckets[0].Failed)
+	assert.Equal(int64(2000), buckets[0].DurationP50)
+	assert.Equal(int64(3000), buckets[0].DurationP99)
+	assert.Equal(int32(1), buckets[1].Total)
+}
+
+func TestGetUsage(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+
+	start := time.Now().Add(-2 * time.Hour)
+	for _, execution := range []struct {
+		test        string
+		status      testkube.ExecutionStatus
+		duration    time.Duration
+		environment *testkube.ExecutionEnvironment
+		labels      map[string]string
+	}{
+		{"api", testkube.PASSED_ExecutionStatus, time.Hour, &testkube.ExecutionEnvironment{CpuRequestCores: 2, MemoryRequestBytes: 1 << 30},
+			map[string]string{"team": "payments"}},
+		{"api", testkube.FAILED_ExecutionStatus, 30 * time.Minute, &testkube.ExecutionEnvironment{CpuRequestCores: 2},
+			map[string]string{"team": "payments"}},
+		{"ui", testkube.PASSED_ExecutionStatus, time.Hour, nil, nil},
+		{"ui", testkube.RUNNING_ExecutionStatus, 0, &testkube.ExecutionEnvironment{CpuRequestCores: 1}, nil},
+	} {
+		status := execution.status
+		err = repository.Insert(context.Background(), testkube.Execution{
+			Id:              rand.Name(),
+			TestName:        execution.test,
+			Name:            rand.Name(),
+			StartTime:       start,
+			EndTime:         start.Add(execution.duration),
+			Environment:     execution.environment,
+			Labels:          execution.labels,
+			ExecutionResult: &testkube.ExecutionResult{Status: &status},
+		})
+		assert.NoError(err)
+	}
+
+	usage, err := repository.GetUsage(context.Background(), NewExecutionsFilter().WithStartDate(start.Add(-time.Minute)))
+	assert.NoError(err)
+	sort.Slice(usage.Tests, func(i, j int) bool { return usage.Tests[i].Name < usage.Tests[j].Name })
+	assert.Len(usage.Tests, 2)
+	assert.Equal("api", usage.Tests[0].Name)
+	assert.Equal(int32(2), usage.Tests[0].Executions)
+	assert.InDelta(3, usage.Tests[0].CpuHours, 0.0001)
+	assert.InDelta(1, usage.Tests[0].MemoryGBHours, 0.0001)
+	assert.Equal("ui", usage.Tests[1].Name)
+	assert.Equal(int32(1), usage.Tests[1].Executions, "running executions aren't counted")
+	assert.Equal(int32(1), usage.Tests[1].UnpricedExecutions)
+
+	assert.Len(usage.Labels, 1)
+	assert.Equal("team=payments", usage.Labels[0].Name)
+	assert.Equal(int32(2), usage.Labels[0].Executions)
+}
+
+func TestExecutionCounters(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+	err = repository.CountersColl.Drop(context.TODO())
+	assert.NoError(err)
+
+	oneDayAgo := time.Now().Add(-24 * time.Hour)
+	assert.NoError(repository.insertExecutionResult("counted", testkube.PASSED_ExecutionStatus, oneDayAgo, nil))
+	assert.NoError(repository.RebuildCounters(context.Background()))
+
+	id := rand.Name()
+	assert.NoError(repository.Insert(context.Background(), testkube.Execution{Id: id, TestName: "counted", Name: id,
+		ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusQueued}}))
+	assert.NoError(repository.StartExecution(context.Background(), id, time.Now()))
+	assert.NoError(repository.UpdateResult(context.Background(), id, testkube.ExecutionResult{Status: testkube.ExecutionStatusFailed}))
+	assert.NoError(repository.insertExecutionResult("other", testkube.RUNNING_ExecutionStatus, time.Now(), nil))
+
+	filters := []*filter{
+		NewExecutionsFilter(),
+		NewExecutionsFilter().WithTestName("counted"),
+		NewExecutionsFilter().WithStatus(string(testkube.FAILED_ExecutionStatus)),
+	}
+
+	for _, filter := range filters {
+		counted, ok, err := repository.getCounterTotals(context.Background(), filter)
+		assert.NoError(err)
+		assert.True(ok)
+
+		aggregated, err := repository.aggregateExecutionTotals(context.Background(), false, filter)
+		assert.NoError(err)
+		assert.Equal(aggregated, counted)
+	}
+
+	assert.NoError(repository.DeleteStartedBefore(context.Background(), time.Now().Add(-time.Hour)))
+	totals, err := repository.GetExecutionTotals(context.Background(), false, NewExecutionsFilter().WithTestName("counted"))
+	assert.NoError(err)
+	assert.Equal(testkube.ExecutionsTotals{Results: 1, Failed: 1}, totals)
+
+	// rebuild replaces existing counters
+	assert.NoError(repository.RebuildCounters(context.Background()))
+	counted, ok, err := repository.getCounterTotals(context.Background(), NewExecutionsFilter())
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal(testkube.ExecutionsTotals{Results: 2, Failed: 1, Running: 1}, counted)
+}
+
+// BenchmarkExecutionTotals compares totals read from counters with aggregation, counters latency
+// should stay flat as number of executions grows
+func BenchmarkExecutionTotals(b *testing.B) {
+	repository, err := getRepository()
+	require.NoError(b, err)
+
+	for _, size := range []int{1000, 10000, 50000} {
+		require.NoError(b, repository.Coll.Drop(context.TODO()))
+		require.NoError(b, repository.CountersColl.Drop(context.TODO()))
+
+		documents := make([]interface{}, size)
+		for i := range documents {
+			status := testkube.PASSED_ExecutionStatus
+			if i%3 == 0 {
+				status = testkube.FAILED_ExecutionStatus
+			}
+			documents[i] = testkube.Execution{
+				Id:              rand.Name(),
+				TestName:        fmt.Sprintf("test-%d", i%100),
+				Name:            rand.Name(),
+				StartTime:       time.Now().Add(-time.Duration(i) * time.Minute),
+				ExecutionResult: &testkube.ExecutionResult{Status: &status},
+			}
+		}
+		_, err := repository.Coll.InsertMany(context.Background(), documents)
+		require.NoError(b, err)
+		require.NoError(b, repository.RebuildCounters(context.Background()))
+
+		b.Run(fmt.Sprintf("counters/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := repository.GetExecutionTotals(context.Background(), false, NewExecutionsFilter())
+				require.NoError(b, err)
+			}
+		})
+
+		b.Run(fmt.Sprintf("aggregation/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := repository.aggregateExecutionTotals(context.Background(), false, NewExecutionsFilter())
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+func TestGetLatestByTests(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+	assert.NoError(repository.EnsureIndexes(context.Background()))
+
+	now := time.Now()
+	assert.NoError(repository.insertExecutionResult("first", testkube.FAILED_ExecutionStatus, now.Add(-2*time.Hour), nil))
+	assert.NoError(repository.insertExecutionResult("first", testkube.PASSED_ExecutionStatus, now.Add(-time.Hour), nil))
+	assert.NoError(repository.insertExecutionResult("second", testkube.FAILED_ExecutionStatus, now, nil))
+	assert.NoError(repository.insertExecutionResult("other", testkube.PASSED_ExecutionStatus, now, nil))
+
+	executions, err := repository.GetLatestByTests(context.Background(), []string{"first", "second", "missing"}, SummaryExcludedFields()...)
+	assert.NoError(err)
+	assert.Len(executions, 2)
+
+	statuses := map[string]testkube.ExecutionStatus{}
+	for _, execution := range executions {
+		statuses[execution.TestName] = *execution.ExecutionResult.Status
+	}
+	assert.Equal(map[string]testkube.ExecutionStatus{"first": testkube.PASSED_ExecutionStatus, "second": testkube.FAILED_ExecutionStatus}, statuses)
+
+	executions, err = repository.GetLatestByTests(context.Background(), nil)
+	assert.NoError(err)
+	assert.Empty(executions)
+}
+
+func TestExecutionGroup(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+	assert.NoError(repository.EnsureIndexes(context.Background()))
+
+	for i, groupId := range []string{"group", "group", "other", ""} {
+		execution := testkube.NewExecutionWithID(fmt.Sprintf("execution-%d", i), "postman/collection", "api")
+		execution.Name = execution.Id
+		execution.GroupId = groupId
+		assert.NoError(repository.Insert(context.Background(), execution))
+	}
+
+	executions, err := repository.GetExecutions(context.Background(), NewExecutionsFilter().WithGroupId("group"))
+	assert.NoError(err)
+	assert.Len(executions, 2)
+	for _, execution := range executions {
+		assert.Equal("group", execution.GroupId)
+	}
+}
+
+func TestCompressOutputs(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+
+	output := strings.Repeat("cypress run\n", 1000)
+	execution := testkube.NewExecutionWithID("historic", "cypress/project", "e2e")
+	execution.ExecutionResult.Output = output
+	assert.NoError(repository.Insert(context.Background(), execution))
+
+	repository.CompressOutput = true
+	count, err := repository.CompressOutputs(context.Background())
+	assert.NoError(err)
+	assert.Equal(1, count)
+
+	var stored testkube.Execution
+	assert.NoError(repository.Coll.FindOne(context.Background(), bson.M{"id": "historic"}).Decode(&stored))
+	assert.True(compression.IsCompressed(stored.ExecutionResult.Output))
+
+	execution, err = repository.Get(context.Background(), "historic")
+	assert.NoError(err)
+	assert.Equal(output, execution.ExecutionResult.Output)
+
+	count, err = repository.CompressOutputs(context.Background())
+	assert.NoError(err)
+	assert.Equal(0, count)
+}
+
+func TestArchivableExecutions(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+
+	weekAgo := time.Now().Add(-7 * 24 * time.Hour)
+	for i, restoredTime := range []time.Time{{}, time.Now(), weekAgo.Add(time.Hour), {}} {
+		execution := testkube.NewExecutionWithID(fmt.Sprintf("execution-%d", i), "postman/collection", "api")
+		execution.Name = execution.Id
+		execution.StartTime = weekAgo.Add(-time.Duration(3-i) * time.Hour)
+		execution.RestoredTime = restoredTime
+		if i == 3 {
+			execution.StartTime = time.Now()
+		}
+		assert.NoError(repository.Insert(context.Background(), execution))
+	}
+
+	executions, err := repository.GetStartedBefore(context.Background(), time.Now().Add(-24*time.Hour), 10)
+	assert.NoError(err)
+	assert.Len(executions, 2)
+	assert.Equal("execution-0", executions[0].Id)
+	assert.Equal("execution-2", executions[1].Id)
+
+	assert.NoError(repository.DeleteByIds(context.Background(), []string{"execution-0", "execution-2"}))
+	executions, err = repository.GetExecutions(context.Background(), NewExecutionsFilter())
+	assert.NoError(err)
+	assert.Len(executions, 2)
+}
+
+func TestArtifactScans(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+
+	execution := testkube.NewExecutionWithID("execution-1", "postman/collection", "api")
+	assert.NoError(repository.Insert(context.Background(), execution))
+
+	scans := []testkube.ArtifactScanResult{{Name: "report.html", Status: testkube.ArtifactScanStatusPending}}
+	started, err := repository.StartArtifactScans(context.Background(), execution.Id, scans, "hash-1")
+	assert.NoError(err)
+	assert.True(started)
+
+	started, err = repository.StartArtifactScans(context.Background(), execution.Id, scans, "hash-2")
+	assert.NoError(err)
+	assert.False(started, "scans are started once")
+
+	tokenHash, err := repository.GetArtifactScanTokenHash(context.Background(), execution.Id)
+	assert.NoError(err)
+	assert.Equal("hash-1", tokenHash)
+
+	scans[0].Status = testkube.ArtifactScanStatusQuarantined
+	assert.NoError(repository.UpdateArtifactScans(context.Background(), execution.Id, scans))
+	execution, err = repository.Get(context.Background(), execution.Id)
+	assert.NoError(err)
+	assert.Equal(scans, execution.ArtifactScans)
+
+	assert.NoError(repository.UpdateArtifactScans(context.Background(), execution.Id, nil))
+	started, err = repository.StartArtifactScans(context.Background(), execution.Id, scans, "hash-3")
+	assert.NoError(err)
+	assert.True(started, "removed scans are started again")
+}
+
+func TestUpdateEnvironment(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+
+	execution := testkube.NewExecutionWithID("execution-1", "postman/collection", "api")
+	assert.NoError(repository.Insert(context.Background(), execution))
+
+	digest := "sha256:4bcff63911fcb4448bd4fdacec207030997caf25e9bea4045fa6c8c44de311d1"
+	environment := testkube.ExecutionEnvironment{NodeName: "node-1", KubernetesVersion: "v1.25.3"}
+	assert.NoError(repository.UpdateEnvironment(context.Background(), execution.Id, digest, environment))
+
+	execution, err = repository.Get(context.Background(), execution.Id)
+	assert.NoError(err)
+	assert.Equal(digest, execution.ExecutorImageDigest)
+	assert.Equal(&environment, execution.Environment)
+
+	executions, err := repository.GetExecutions(context.Background(), NewExecutionsFilter().WithNodeName("node-1").WithExecutorImageDigest(digest))
+	assert.NoError(err)
+	assert.Len(executions, 1)
+
+	executions, err = repository.GetExecutions(context.Background(), NewExecutionsFilter().WithKubernetesVersion("v1.24.0"))
+	assert.NoError(err)
+	assert.Len(executions, 0)
+}
+
+// BenchmarkGetLatestByTests gets latest executions of 5k tests in single aggregation
+func BenchmarkGetLatestByTests(b *testing.B) {
+	repository, err := getRepository()
+	require.NoError(b, err)
+	require.NoError(b, repository.Coll.Drop(context.TODO()))
+	require.NoError(b, repository.EnsureIndexes(context.Background()))
+
+	const tests = 5000
+	testNames := make([]string, tests)
+	for i := range testNames {
+		testNames[i] = fmt.Sprintf("test-%d", i)
+	}
+
+	documents := make([]interface{}, 0, tests*3)
+	for i := 0; i < tests*3; i++ {
+		status := testkube.PASSED_ExecutionStatus
+		documents = append(documents, testkube.Execution{
+			Id:              rand.Name(),
+			TestName:        testNames[i%tests],
+			Name:            rand.Name(),
+			Number:          int32(i/tests + 1),
+			StartTime:       time.Now().Add(-time.Duration(i) * time.Second),
+			ExecutionResult: &testkube.ExecutionResult{Status: &status},
+		})
+	}
+	_, err = repository.Coll.InsertMany(context.Background(), documents)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		executions, err := repository.GetLatestByTests(context.Background(), testNames, SummaryExcludedFields()...)
+		require.NoError(b, err)
+		require.Len(b, executions, tests)
+	}
+}
+
 func getRepository() (*MongoRepository, error) {
 	db, err := storage.GetMongoDataBase(mongoDns, mongoDbName)
 	repository := NewMongoRespository(db)
@@ -292,3 +830,91 @@ func (repository *MongoRepository) insertExecutionResult(testName string, execSt
 			Labels:          labels,
 		})
 }
+
+func TestUpdateTimings(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+
+	execution := testkube.NewExecutionWithID("execution-1", "postman/collection", "api")
+	assert.NoError(repository.Insert(context.Background(), execution))
+
+	timings := testkube.ExecutionTimings{QueueDuration: "2.5s", SchedulingDuration: "1.2s", ContentFetchDuration: "3s"}
+	assert.NoError(repository.UpdateTimings(context.Background(), execution.Id, timings))
+
+	execution, err = repository.Get(context.Background(), execution.Id)
+	assert.NoError(err)
+	assert.Equal(&timings, execution.Timings)
+}
+
+func TestAddAttempt(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+
+	execution := testkube.NewExecutionWithID("execution-1", "postman/collection", "api")
+	assert.NoError(repository.Insert(context.Background(), execution))
+
+	attempts := []testkube.ExecutionAttempt{
+		{Number: 1, PodName: "execution-1-abcde", NodeName: "spot-1", Reason: "PreemptionByScheduler", Time: time.Now().UTC().Truncate(time.Millisecond)},
+		{Number: 2, PodName: "execution-1-fghij", NodeName: "spot-2", Reason: "NodeShutdown", Time: time.Now().UTC().Truncate(time.Millisecond)},
+	}
+	for _, attempt := range attempts {
+		assert.NoError(repository.AddAttempt(context.Background(), execution.Id, attempt))
+	}
+
+	execution, err = repository.Get(context.Background(), execution.Id)
+	assert.NoError(err)
+	assert.Equal(attempts, execution.Attempts)
+}
+
+func TestStatusHistory(t *testing.T) {
+	assert := require.New(t)
+
+	repository, err := getRepository()
+	assert.NoError(err)
+
+	err = repository.Coll.Drop(context.TODO())
+	assert.NoError(err)
+
+	execution := testkube.NewExecutionWithID("execution-1", "postman/collection", "api")
+	execution.ExecutionResult = &testkube.ExecutionResult{Status: testkube.ExecutionStatusQueued}
+	assert.NoError(repository.Insert(context.Background(), execution))
+
+	assert.NoError(repository.UpdateResult(context.Background(), execution.Id, testkube.NewPendingExecutionResult()))
+	// unchanged status isn't recorded again
+	assert.NoError(repository.UpdateResult(context.Background(), execution.Id, testkube.NewPendingExecutionResult()))
+	assert.NoError(repository.UpdateResult(context.Background(), execution.Id, testkube.NewErrorExecutionResult(errors.New("newman exited with 1"))))
+
+	execution, err = repository.Get(context.Background(), execution.Id)
+	assert.NoError(err)
+	assert.Len(execution.StatusHistory, 3)
+
+	var statuses []testkube.ExecutionStatus
+	for _, transition := range execution.StatusHistory {
+		statuses = append(statuses, *transition.Status)
+	}
+	assert.Equal([]testkube.ExecutionStatus{testkube.QUEUED_ExecutionStatus, testkube.RUNNING_ExecutionStatus,
+		testkube.FAILED_ExecutionStatus}, statuses)
+	assert.Equal("newman exited with 1", execution.StatusHistory[2].Reason)
+	assert.False(execution.StatusHistory[2].Time.Before(execution.StatusHistory[0].Time))
+
+	// replaced execution keeps stored history even when it was read before the last transition
+	stale := execution
+	stale.StatusHistory = stale.StatusHistory[:1]
+	stale.ExecutionResult = &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed}
+	assert.NoError(repository.Update(context.Background(), stale))
+
+	execution, err = repository.Get(context.Background(), execution.Id)
+	assert.NoError(err)
+	assert.Len(execution.StatusHistory, 4)
+	assert.Equal(testkube.PASSED_ExecutionStatus, *execution.StatusHistory[3].Status)
+}
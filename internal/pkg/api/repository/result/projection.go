@@ -0,0 +1,71 @@
+package result
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// heavyFields are execution document fields not needed in summaries, grouped by values of include query param
+var heavyFields = map[string][]string{
+	"output":  {"executionresult.output"},
+	"steps":   {"executionresult.steps", "executionresult.iterations"},
+	"params":  {"envs", "args", "params", "paramsfile"},
+	"content": {"content", "datafile"},
+}
+
+// ExcludedFields returns heavy execution fields which aren't included, so they can be projected out of
+// documents, executions read without them must not be saved back
+func ExcludedFields(include []string) ([]string, error) {
+	included := map[string]bool{}
+	for _, name := range include {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		if _, ok := heavyFields[name]; !ok {
+			return nil, fmt.Errorf("invalid include %s, supported values are %s", name, strings.Join(includeNames(), ", "))
+		}
+		included[name] = true
+	}
+
+	var excluded []string
+	for _, name := range includeNames() {
+		if !included[name] {
+			excluded = append(excluded, heavyFields[name]...)
+		}
+	}
+
+	return excluded, nil
+}
+
+// SummaryExcludedFields returns all heavy execution fields
+func SummaryExcludedFields() []string {
+	excluded, _ := ExcludedFields(nil)
+	return excluded
+}
+
+func includeNames() []string {
+	names := make([]string, 0, len(heavyFields))
+	for name := range heavyFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// exclusionProjection returns projection without given fields, nil projection returns whole documents
+func exclusionProjection(excluded []string) bson.M {
+	if len(excluded) == 0 {
+		return nil
+	}
+
+	projection := bson.M{}
+	for _, field := range excluded {
+		projection[field] = 0
+	}
+	return projection
+}
@@ -0,0 +1,31 @@
+package result
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExcludedFields(t *testing.T) {
+
+	t.Run("all heavy fields are excluded by default", func(t *testing.T) {
+		excluded, err := ExcludedFields(nil)
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"content", "datafile", "executionresult.output", "envs", "args", "params", "paramsfile",
+			"executionresult.steps", "executionresult.iterations"}, excluded)
+	})
+
+	t.Run("included fields are kept", func(t *testing.T) {
+		excluded, err := ExcludedFields([]string{"output", " params"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"content", "datafile", "executionresult.steps", "executionresult.iterations"}, excluded)
+	})
+
+	t.Run("unknown include", func(t *testing.T) {
+		_, err := ExcludedFields([]string{"logs"})
+
+		assert.EqualError(t, err, "invalid include logs, supported values are content, output, params, steps")
+	})
+}
@@ -0,0 +1,130 @@
+package result
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// bucketOffset aligns buckets to Monday, as Unix epoch started on Thursday, hour and day buckets aren't affected
+const bucketOffset = 4 * 24 * time.Hour
+
+type trendGroup struct {
+	ID struct {
+		Bucket int64  `bson:"bucket"`
+		Status string `bson:"status"`
+	} `bson:"_id"`
+	Count     int32    `bson:"count"`
+	Durations []*int64 `bson:"durations"`
+}
+
+// GetTrends gets time bucketed execution counts by status and duration percentiles of a test,
+// buckets and counts are aggregated in Mongo, percentiles are calculated from bucket durations
+func (r *MongoRepository) GetTrends(ctx context.Context, testName string, since time.Time, interval time.Duration) ([]testkube.ExecutionsTrendBucket, error) {
+	intervalMs := interval.Milliseconds()
+	startMs := bson.D{{Key: "$toLong", Value: "$starttime"}}
+
+	pipeline := []bson.D{
+		{{Key: "$match", Value: bson.M{"testname": testName, "starttime": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "bucket", Value: bson.D{{Key: "$subtract", Value: bson.A{startMs, bson.D{{Key: "$mod", Value: bson.A{
+					bson.D{{Key: "$subtract", Value: bson.A{startMs, bucketOffset.Milliseconds()}}}, intervalMs}}}}}}},
+				{Key: "status", Value: "$executionresult.status"},
+			}},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+			// not finished executions have zero end time, their duration is null
+			{Key: "durations", Value: bson.D{{Key: "$push", Value: bson.D{{Key: "$cond", Value: bson.A{
+				bson.D{{Key: "$gt", Value: bson.A{"$endtime", "$starttime"}}},
+				bson.D{{Key: "$subtract", Value: bson.A{"$endtime", "$starttime"}}},
+				nil,
+			}}}}}},
+		}}},
+	}
+
+	cursor, err := r.Coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []trendGroup
+	if err = cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	return newTrendBuckets(groups, since, time.Now(), interval), nil
+}
+
+// newTrendBuckets merges per status groups into continuous buckets between since and now
+func newTrendBuckets(groups []trendGroup, since, now time.Time, interval time.Duration) []testkube.ExecutionsTrendBucket {
+	buckets := []testkube.ExecutionsTrendBucket{}
+	indexes := map[int64]int{}
+	for start := bucketStart(since, interval); !start.After(now); start = start.Add(interval) {
+		indexes[start.UnixMilli()] = len(buckets)
+		buckets = append(buckets, testkube.ExecutionsTrendBucket{StartTime: start})
+	}
+
+	durations := make([][]int64, len(buckets))
+	for _, group := range groups {
+		i, ok := indexes[group.ID.Bucket]
+		if !ok {
+			continue
+		}
+
+		bucket := &buckets[i]
+		bucket.Total += group.Count
+		switch testkube.ExecutionStatus(group.ID.Status) {
+		case testkube.PASSED_ExecutionStatus:
+			bucket.Passed += group.Count
+		case testkube.FAILED_ExecutionStatus:
+			bucket.Failed += group.Count
+		case testkube.TIMEOUT_ExecutionStatus:
+			bucket.Timeout += group.Count
+		case testkube.ABORTED_ExecutionStatus:
+			bucket.Aborted += group.Count
+		case testkube.RUNNING_ExecutionStatus:
+			bucket.Running += group.Count
+		case testkube.QUEUED_ExecutionStatus:
+			bucket.Queued += group.Count
+		}
+
+		for _, duration := range group.Durations {
+			if duration != nil {
+				durations[i] = append(durations[i], *duration)
+			}
+		}
+	}
+
+	for i, values := range durations {
+		sort.Slice(values, func(a, b int) bool { return values[a] < values[b] })
+		buckets[i].DurationP50 = percentile(values, 50)
+		buckets[i].DurationP90 = percentile(values, 90)
+		buckets[i].DurationP99 = percentile(values, 99)
+	}
+
+	return buckets
+}
+
+// bucketStart returns start of bucket containing given time, the same formula is used in Mongo pipeline
+func bucketStart(t time.Time, interval time.Duration) time.Time {
+	ms := t.UnixMilli()
+	return time.UnixMilli(ms - (ms-bucketOffset.Milliseconds())%interval.Milliseconds()).UTC()
+}
+
+// percentile returns nearest rank percentile of sorted values
+func percentile(sorted []int64, p int) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
@@ -0,0 +1,65 @@
+package result
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestBucketStart(t *testing.T) {
+	at := time.Date(2022, 8, 10, 15, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2022, 8, 10, 15, 0, 0, 0, time.UTC), bucketStart(at, time.Hour))
+	assert.Equal(t, time.Date(2022, 8, 10, 0, 0, 0, 0, time.UTC), bucketStart(at, 24*time.Hour))
+	// 2022-08-08 is Monday
+	assert.Equal(t, time.Date(2022, 8, 8, 0, 0, 0, 0, time.UTC), bucketStart(at, 7*24*time.Hour))
+}
+
+func TestPercentile(t *testing.T) {
+	values := []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	assert.Equal(t, int64(5), percentile(values, 50))
+	assert.Equal(t, int64(9), percentile(values, 90))
+	assert.Equal(t, int64(10), percentile(values, 99))
+	assert.Equal(t, int64(7), percentile([]int64{7}, 50))
+	assert.Equal(t, int64(0), percentile(nil, 50))
+}
+
+func TestNewTrendBuckets(t *testing.T) {
+	day := 24 * time.Hour
+	since := time.Date(2022, 8, 8, 12, 0, 0, 0, time.UTC)
+	now := time.Date(2022, 8, 10, 12, 0, 0, 0, time.UTC)
+
+	group := func(bucket time.Time, status testkube.ExecutionStatus, durations ...int64) trendGroup {
+		g := trendGroup{Count: int32(len(durations))}
+		g.ID.Bucket = bucket.UnixMilli()
+		g.ID.Status = string(status)
+		for i := range durations {
+			g.Durations = append(g.Durations, &durations[i])
+		}
+		return g
+	}
+
+	running := group(time.Date(2022, 8, 10, 0, 0, 0, 0, time.UTC), testkube.RUNNING_ExecutionStatus, 0)
+	running.Durations = []*int64{nil}
+
+	buckets := newTrendBuckets([]trendGroup{
+		group(time.Date(2022, 8, 8, 0, 0, 0, 0, time.UTC), testkube.PASSED_ExecutionStatus, 300, 100),
+		group(time.Date(2022, 8, 8, 0, 0, 0, 0, time.UTC), testkube.FAILED_ExecutionStatus, 200),
+		running,
+	}, since, now, day)
+
+	assert.Len(t, buckets, 3)
+	assert.Equal(t, time.Date(2022, 8, 8, 0, 0, 0, 0, time.UTC), buckets[0].StartTime)
+	assert.Equal(t, int32(3), buckets[0].Total)
+	assert.Equal(t, int32(2), buckets[0].Passed)
+	assert.Equal(t, int32(1), buckets[0].Failed)
+	assert.Equal(t, int64(200), buckets[0].DurationP50)
+	assert.Equal(t, int64(300), buckets[0].DurationP99)
+	assert.Equal(t, int32(0), buckets[1].Total)
+	assert.Equal(t, int32(1), buckets[2].Running)
+	assert.Equal(t, int64(0), buckets[2].DurationP50)
+}
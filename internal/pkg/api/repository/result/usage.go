@@ -0,0 +1,104 @@
+package result
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// bytesInGB converts requested memory bytes to GB
+const bytesInGB = 1 << 30
+
+// UsageRollup is requested resources usage of finished executions of test or label
+type UsageRollup struct {
+	// Name is test name or label as key=value
+	Name       string `bson:"_id"`
+	Executions int32  `bson:"executions"`
+	// UnpricedExecutions are executions without recorded resource requests or duration
+	UnpricedExecutions int32   `bson:"unpricedexecutions"`
+	CpuHours           float64 `bson:"cpuhours"`
+	MemoryGBHours      float64 `bson:"memorygbhours"`
+}
+
+// Usage is requested resources usage of finished executions per test and per execution label
+type Usage struct {
+	Tests  []UsageRollup `bson:"tests"`
+	Labels []UsageRollup `bson:"labels"`
+}
+
+// GetUsage gets requested CPU core hours and memory GB hours of finished executions matching filter per test
+// and per label, usage is aggregated in Mongo, so executions aren't loaded, paging of filter isn't used
+func (r *MongoRepository) GetUsage(ctx context.Context, filter Filter) (usage Usage, err error) {
+	query, _ := composeQueryAndOpts(filter)
+	completed := bson.M{"executionresult.status": bson.M{"$in": bson.A{testkube.PASSED_ExecutionStatus,
+		testkube.FAILED_ExecutionStatus, testkube.ABORTED_ExecutionStatus, testkube.TIMEOUT_ExecutionStatus}}}
+	hours := bson.D{{Key: "$divide", Value: bson.A{bson.D{{Key: "$subtract", Value: bson.A{"$endtime", "$starttime"}}}, 3600000}}}
+	// executions without duration or any numeric resource request aren't priced
+	priced := bson.D{{Key: "$and", Value: bson.A{
+		bson.D{{Key: "$gt", Value: bson.A{"$endtime", "$starttime"}}},
+		bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "$gt", Value: bson.A{"$environment.cpurequestcores", 0}}},
+			bson.D{{Key: "$gt", Value: bson.A{"$environment.memoryrequestbytes", 0}}},
+		}}},
+	}}}
+	rollup := func(id interface{}) bson.D {
+		return bson.D{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: id},
+			{Key: "executions", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "unpricedexecutions", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$cond", Value: bson.A{"$priced", 0, 1}}}}}},
+			{Key: "cpuhours", Value: bson.D{{Key: "$sum", Value: "$cpuhours"}}},
+			{Key: "memorygbhours", Value: bson.D{{Key: "$sum", Value: "$memorygbhours"}}},
+		}}}
+	}
+
+	match := completed
+	if len(query) > 0 {
+		match = bson.M{"$and": bson.A{query, completed}}
+	}
+
+	pipeline := []bson.D{
+		{{Key: "$match", Value: match}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "testname", Value: 1},
+			{Key: "labels", Value: bson.D{{Key: "$objectToArray", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$labels", bson.D{}}}}}}},
+			{Key: "priced", Value: priced},
+			{Key: "hours", Value: hours},
+			{Key: "cpu", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$environment.cpurequestcores", 0}}}},
+			{Key: "memory", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$environment.memoryrequestbytes", 0}}}},
+		}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "testname", Value: 1},
+			{Key: "labels", Value: 1},
+			{Key: "priced", Value: 1},
+			{Key: "cpuhours", Value: bson.D{{Key: "$cond", Value: bson.A{"$priced",
+				bson.D{{Key: "$multiply", Value: bson.A{"$cpu", "$hours"}}}, 0}}}},
+			{Key: "memorygbhours", Value: bson.D{{Key: "$cond", Value: bson.A{"$priced",
+				bson.D{{Key: "$divide", Value: bson.A{bson.D{{Key: "$multiply", Value: bson.A{"$memory", "$hours"}}}, bytesInGB}}}, 0}}}},
+		}}},
+		{{Key: "$facet", Value: bson.D{
+			{Key: "tests", Value: bson.A{rollup("$testname")}},
+			{Key: "labels", Value: bson.A{
+				bson.D{{Key: "$unwind", Value: "$labels"}},
+				rollup(bson.D{{Key: "$concat", Value: bson.A{"$labels.k", "=", "$labels.v"}}}),
+			}},
+		}}},
+	}
+
+	cursor, err := r.Coll.Aggregate(ctx, pipeline)
+	if err != nil {
+		return usage, err
+	}
+
+	var results []Usage
+	if err = cursor.All(ctx, &results); err != nil {
+		return usage, err
+	}
+
+	if len(results) > 0 {
+		usage = results[0]
+	}
+
+	return usage, nil
+}
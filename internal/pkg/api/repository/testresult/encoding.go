@@ -0,0 +1,109 @@
+package testresult
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/compression"
+)
+
+// encodeExecution returns test suite execution as it's stored, step outputs are compressed before they are encrypted
+func (r *MongoRepository) encodeExecution(execution testkube.TestSuiteExecution) (testkube.TestSuiteExecution, error) {
+	if r.CompressOutput {
+		var err error
+		if execution, err = compression.CompressTestSuiteExecution(execution); err != nil {
+			return execution, err
+		}
+	}
+
+	return r.Encrypter.EncryptTestSuiteExecution(execution)
+}
+
+// decodeExecution returns stored test suite execution with decrypted and decompressed values
+func (r *MongoRepository) decodeExecution(execution testkube.TestSuiteExecution) (testkube.TestSuiteExecution, error) {
+	execution, err := r.Encrypter.DecryptTestSuiteExecution(execution)
+	if err != nil {
+		return execution, err
+	}
+
+	return compression.DecompressTestSuiteExecution(execution)
+}
+
+// decodeExecutions decodes stored test suite executions in place
+func (r *MongoRepository) decodeExecutions(executions []testkube.TestSuiteExecution) (err error) {
+	if err = r.Encrypter.DecryptTestSuiteExecutions(executions); err != nil {
+		return err
+	}
+
+	return compression.DecompressTestSuiteExecutions(executions)
+}
+
+// CompressOutputs compresses step outputs of completed test suite executions stored before compression was enabled,
+// number of changed test suite executions is returned
+func (r *MongoRepository) CompressOutputs(ctx context.Context) (count int, err error) {
+	query := bson.M{
+		"status": bson.M{"$nin": []testkube.TestSuiteExecutionStatus{
+			testkube.QUEUED_TestSuiteExecutionStatus,
+			testkube.RUNNING_TestSuiteExecutionStatus,
+		}},
+		"stepresults": bson.M{"$elemMatch": bson.M{
+			"execution.executionresult.output": bson.M{"$gt": "", "$not": primitive.Regex{Pattern: "^" + compression.Prefix}},
+		}},
+	}
+
+	cursor, err := r.Coll.Find(ctx, query)
+	if err != nil {
+		return count, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var execution testkube.TestSuiteExecution
+		if err = cursor.Decode(&execution); err != nil {
+			return count, err
+		}
+
+		decrypted, err := r.Encrypter.DecryptTestSuiteExecution(execution)
+		if err != nil {
+			return count, fmt.Errorf("can't decrypt test suite execution %s: %w", execution.Id, err)
+		}
+
+		compressed, err := compression.CompressTestSuiteExecution(decrypted)
+		if err != nil {
+			return count, fmt.Errorf("can't compress test suite execution %s: %w", execution.Id, err)
+		}
+
+		if compressedOutputs(compressed) == compressedOutputs(decrypted) {
+			continue
+		}
+
+		encrypted, err := r.Encrypter.EncryptTestSuiteExecution(compressed)
+		if err != nil {
+			return count, fmt.Errorf("can't encrypt test suite execution %s: %w", execution.Id, err)
+		}
+
+		if _, err = r.Coll.ReplaceOne(ctx, bson.M{"id": execution.Id}, encrypted); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, cursor.Err()
+}
+
+// compressedOutputs returns number of compressed step outputs
+func compressedOutputs(execution testkube.TestSuiteExecution) (count int) {
+	for _, stepResult := range execution.StepResults {
+		if stepResult.Execution != nil && stepResult.Execution.ExecutionResult != nil &&
+			compression.IsCompressed(stepResult.Execution.ExecutionResult.Output) {
+			count++
+		}
+	}
+
+	return count
+}
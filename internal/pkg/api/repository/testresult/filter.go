@@ -15,6 +15,7 @@ type filter struct {
 	pageSize   int
 	textSearch string
 	selector   string
+	project    string
 }
 
 func NewExecutionsFilter() *filter {
@@ -66,6 +67,11 @@ func (f *filter) WithSelector(selector string) *filter {
 	return f
 }
 
+func (f *filter) WithProject(project string) *filter {
+	f.project = project
+	return f
+}
+
 func (f filter) Name() string {
 	return f.name
 }
@@ -117,3 +123,11 @@ func (f filter) TextSearch() string {
 func (f filter) Selector() string {
 	return f.selector
 }
+
+func (f filter) ProjectDefined() bool {
+	return f.project != ""
+}
+
+func (f filter) Project() string {
+	return f.project
+}
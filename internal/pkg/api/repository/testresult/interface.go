@@ -24,6 +24,8 @@ type Filter interface {
 	TextSearchDefined() bool
 	TextSearch() string
 	Selector() string
+	ProjectDefined() bool
+	Project() string
 }
 
 type Repository interface {
@@ -43,8 +45,12 @@ type Repository interface {
 	Insert(ctx context.Context, result testkube.TestSuiteExecution) error
 	// Update updates execution result
 	Update(ctx context.Context, result testkube.TestSuiteExecution) error
+	// SetStepApproval stores decision of approval step, ErrStepDecided is returned when step has decision already
+	SetStepApproval(ctx context.Context, id string, step int, decision testkube.TestSuiteStepApprovalDecision) error
 	// StartExecution updates execution start time
 	StartExecution(ctx context.Context, id string, startTime time.Time) error
 	// EndExecution updates execution end time
 	EndExecution(ctx context.Context, id string, endTime time.Time, duration time.Duration) error
+	// DeleteStartedBefore deletes executions started before given date
+	DeleteStartedBefore(ctx context.Context, date time.Time) error
 }
@@ -2,6 +2,8 @@ package testresult
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -11,10 +13,14 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/encryption"
 )
 
 const CollectionName = "testresults"
 
+// ErrStepDecided is returned when decision of approval step is stored concurrently
+var ErrStepDecided = errors.New("approval step is already decided")
+
 func NewMongoRespository(db *mongo.Database) *MongoRepository {
 	return &MongoRepository{
 		Coll: db.Collection(CollectionName),
@@ -23,23 +29,39 @@ func NewMongoRespository(db *mongo.Database) *MongoRepository {
 
 type MongoRepository struct {
 	Coll *mongo.Collection
+	// Encrypter encrypts params and step executions of stored test suite executions, values are stored as they are when it's nil
+	Encrypter *encryption.Encrypter
+	// CompressOutput enables gzip compression of stored step outputs
+	CompressOutput bool
 }
 
 func (r *MongoRepository) Get(ctx context.Context, id string) (result testkube.TestSuiteExecution, err error) {
 	err = r.Coll.FindOne(ctx, bson.M{"id": id}).Decode(&result)
-	return
+	if err != nil {
+		return result, err
+	}
+
+	return r.decodeExecution(result)
 }
 
 func (r *MongoRepository) GetByNameAndTest(ctx context.Context, name, testName string) (result testkube.TestSuiteExecution, err error) {
 	err = r.Coll.FindOne(ctx, bson.M{"name": name, "testsuite.name": testName}).Decode(&result)
-	return
+	if err != nil {
+		return result, err
+	}
+
+	return r.decodeExecution(result)
 }
 
 func (r *MongoRepository) GetLatestByTest(ctx context.Context, testName string) (result testkube.TestSuiteExecution, err error) {
 	findOptions := options.FindOne()
 	findOptions.SetSort(bson.D{{Key: "starttime", Value: -1}})
 	err = r.Coll.FindOne(ctx, bson.M{"testsuite.name": testName}, findOptions).Decode(&result)
-	return
+	if err != nil {
+		return result, err
+	}
+
+	return r.decodeExecution(result)
 }
 
 func (r *MongoRepository) GetLatestByTests(ctx context.Context, testNames []string) (executions []testkube.TestSuiteExecution, err error) {
@@ -89,7 +111,7 @@ func (r *MongoRepository) GetLatestByTests(ctx context.Context, testNames []stri
 		return nil, err
 	}
 
-	return executions, nil
+	return executions, r.decodeExecutions(executions)
 }
 
 func (r *MongoRepository) GetNewestExecutions(ctx context.Context, limit int) (result []testkube.TestSuiteExecution, err error) {
@@ -101,8 +123,11 @@ func (r *MongoRepository) GetNewestExecutions(ctx context.Context, limit int) (r
 	if err != nil {
 		return result, err
 	}
-	err = cursor.All(ctx, &result)
-	return
+	if err = cursor.All(ctx, &result); err != nil {
+		return result, err
+	}
+
+	return result, r.decodeExecutions(result)
 }
 
 func (r *MongoRepository) GetExecutionsTotals(ctx context.Context, filter ...Filter) (totals testkube.ExecutionsTotals, err error) {
@@ -162,20 +187,49 @@ func (r *MongoRepository) GetExecutions(ctx context.Context, filter Filter) (res
 	if err != nil {
 		return
 	}
-	err = cursor.All(ctx, &result)
-	return
+	if err = cursor.All(ctx, &result); err != nil {
+		return result, err
+	}
+
+	return result, r.decodeExecutions(result)
 }
 
 func (r *MongoRepository) Insert(ctx context.Context, result testkube.TestSuiteExecution) (err error) {
-	_, err = r.Coll.InsertOne(ctx, result)
+	encoded, err := r.encodeExecution(result)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Coll.InsertOne(ctx, encoded)
 	return
 }
 
 func (r *MongoRepository) Update(ctx context.Context, result testkube.TestSuiteExecution) (err error) {
-	_, err = r.Coll.ReplaceOne(ctx, bson.M{"id": result.Id}, result)
+	encoded, err := r.encodeExecution(result)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Coll.ReplaceOne(ctx, bson.M{"id": result.Id}, encoded)
 	return
 }
 
+// SetStepApproval stores decision of approval step only when step has no decision, so concurrent decisions can't
+// overwrite each other
+func (r *MongoRepository) SetStepApproval(ctx context.Context, id string, step int, decision testkube.TestSuiteStepApprovalDecision) error {
+	field := fmt.Sprintf("stepresults.%d.approval", step)
+	result, err := r.Coll.UpdateOne(ctx, bson.M{"id": id, field: nil}, bson.M{"$set": bson.M{field: decision}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return ErrStepDecided
+	}
+
+	return nil
+}
+
 // StartExecution updates execution start time
 func (r *MongoRepository) StartExecution(ctx context.Context, id string, startTime time.Time) (err error) {
 	_, err = r.Coll.UpdateOne(ctx, bson.M{"id": id}, bson.M{"$set": bson.M{"starttime": startTime}})
@@ -188,6 +242,11 @@ func (r *MongoRepository) EndExecution(ctx context.Context, id string, endTime t
 	return
 }
 
+func (r *MongoRepository) DeleteStartedBefore(ctx context.Context, date time.Time) (err error) {
+	_, err = r.Coll.DeleteMany(ctx, bson.M{"starttime": bson.M{"$lt": date}})
+	return
+}
+
 func composeQueryAndOpts(filter Filter) (bson.M, *options.FindOptions) {
 
 	query := bson.M{}
@@ -240,6 +299,10 @@ func composeQueryAndOpts(filter Filter) (bson.M, *options.FindOptions) {
 		}
 	}
 
+	if filter.ProjectDefined() {
+		query["project"] = filter.Project()
+	}
+
 	opts.SetSkip(int64(filter.Page() * filter.PageSize()))
 	opts.SetLimit(int64(filter.PageSize()))
 	opts.SetSort(bson.D{{Key: "starttime", Value: -1}})
@@ -4,3 +4,6 @@ var (
 	Version string
 	Commit  string
 )
+
+// SchemaVersion is a version of API schema served by API server
+const SchemaVersion = "1.0.0"
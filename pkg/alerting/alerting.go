@@ -0,0 +1,102 @@
+package alerting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// Alert is an incident of failing test, alerts with the same deduplication key are the same incident
+type Alert struct {
+	DedupKey string
+	Summary  string
+	Source   string
+	Details  map[string]string
+}
+
+// Alerter opens and resolves incidents in incident management system
+type Alerter interface {
+	// Trigger opens incident or updates already open incident with the same deduplication key
+	Trigger(alert Alert) error
+	// Resolve closes incident with given deduplication key
+	Resolve(dedupKey string) error
+}
+
+// DedupKey returns deduplication key of test incidents
+func DedupKey(execution testkube.Execution) string {
+	return "testkube/" + execution.TestNamespace + "/" + execution.TestName
+}
+
+// NewAlert returns alert of failing test execution
+func NewAlert(execution testkube.Execution, failures int) Alert {
+	details := map[string]string{
+		"test":        execution.TestName,
+		"namespace":   execution.TestNamespace,
+		"executionId": execution.Id,
+		"execution":   execution.Name,
+	}
+
+	if execution.ExecutionResult != nil && execution.ExecutionResult.ErrorMessage != "" {
+		details["error"] = execution.ExecutionResult.ErrorMessage
+	}
+
+	return Alert{
+		DedupKey: DedupKey(execution),
+		Summary:  fmt.Sprintf("Test %s failed %d times in a row", execution.TestName, failures),
+		Source:   "testkube",
+		Details:  details,
+	}
+}
+
+// ConsecutiveFailures counts failed executions from the newest one to first passed one,
+// executions are sorted from the newest, not finished and aborted executions are skipped
+func ConsecutiveFailures(executions []testkube.Execution) (failures int) {
+	for _, execution := range executions {
+		if execution.ExecutionResult == nil || execution.ExecutionResult.Status == nil {
+			continue
+		}
+
+		switch *execution.ExecutionResult.Status {
+		case testkube.FAILED_ExecutionStatus, testkube.TIMEOUT_ExecutionStatus:
+			failures++
+		case testkube.PASSED_ExecutionStatus:
+			return failures
+		}
+	}
+
+	return failures
+}
+
+func postJSON(client *http.Client, uri string, headers map[string]string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, uri, bytes.NewBuffer(data))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		message, _ := ioutil.ReadAll(response.Body)
+		return fmt.Errorf("request to %s failed with status %d: %s", uri, response.StatusCode, message)
+	}
+
+	return nil
+}
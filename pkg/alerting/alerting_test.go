@@ -0,0 +1,100 @@
+package alerting
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestConsecutiveFailures(t *testing.T) {
+	executions := []testkube.Execution{
+		execution(testkube.ExecutionStatusRunning),
+		execution(testkube.ExecutionStatusFailed),
+		execution(testkube.ExecutionStatusTimeout),
+		execution(testkube.ExecutionStatusAborted),
+		execution(testkube.ExecutionStatusFailed),
+		execution(testkube.ExecutionStatusPassed),
+		execution(testkube.ExecutionStatusFailed),
+	}
+
+	assert.Equal(t, 3, ConsecutiveFailures(executions))
+	assert.Equal(t, 0, ConsecutiveFailures(executions[5:6]))
+}
+
+func TestPagerDutyAlerter(t *testing.T) {
+	var events []pagerDutyEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event pagerDutyEvent
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		events = append(events, event)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	alerter := NewPagerDutyAlerter("key")
+	alerter.URI = server.URL
+
+	alert := NewAlert(testkube.Execution{TestName: "api", TestNamespace: "testkube"}, 3)
+	require.NoError(t, alerter.Trigger(alert))
+	require.NoError(t, alerter.Resolve(alert.DedupKey))
+
+	require.Len(t, events, 2)
+	assert.Equal(t, "trigger", events[0].EventAction)
+	assert.Equal(t, "testkube/testkube/api", events[0].DedupKey)
+	assert.Equal(t, "Test api failed 3 times in a row", events[0].Payload.Summary)
+	assert.Equal(t, "resolve", events[1].EventAction)
+	assert.Nil(t, events[1].Payload)
+}
+
+func TestOpsgenieAlerter(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GenieKey key", r.Header.Get("Authorization"))
+		paths = append(paths, r.URL.RequestURI())
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	alerter := NewOpsgenieAlerter("key")
+	alerter.URI = server.URL + "/v2/alerts"
+
+	require.NoError(t, alerter.Trigger(Alert{DedupKey: "testkube/testkube/api"}))
+	require.NoError(t, alerter.Resolve("testkube/testkube/api"))
+
+	assert.Equal(t, []string{"/v2/alerts", "/v2/alerts/testkube%2Ftestkube%2Fapi/close?identifierType=alias"}, paths)
+}
+
+func TestFailedRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	alerter := NewPagerDutyAlerter("key")
+	alerter.URI = server.URL
+
+	assert.Error(t, alerter.Resolve("key"))
+}
+
+func execution(status *testkube.ExecutionStatus) testkube.Execution {
+	return testkube.Execution{ExecutionResult: &testkube.ExecutionResult{Status: status}}
+}
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker()
+
+	assert.Equal(t, ActionResolve, tracker.Next("api", 0, 3), "unknown incident is resolved once")
+	assert.Equal(t, ActionNone, tracker.Next("api", 0, 3))
+	assert.Equal(t, ActionNone, tracker.Next("api", 2, 3))
+	assert.Equal(t, ActionTrigger, tracker.Next("api", 3, 3))
+	assert.Equal(t, ActionNone, tracker.Next("api", 4, 3))
+	assert.Equal(t, ActionNone, tracker.Next("api", 1, 3))
+	assert.Equal(t, ActionResolve, tracker.Next("api", 0, 3))
+	assert.Equal(t, ActionTrigger, tracker.Next("other", 5, 3), "unknown incident is triggered once")
+}
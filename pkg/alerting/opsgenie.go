@@ -0,0 +1,60 @@
+package alerting
+
+import (
+	"net/http"
+	"net/url"
+
+	thttp "github.com/kubeshop/testkube/pkg/http"
+)
+
+// OpsgenieAlertsURI is an Opsgenie Alert API endpoint
+const OpsgenieAlertsURI = "https://api.opsgenie.com/v2/alerts"
+
+// NewOpsgenieAlerter returns alerter creating Opsgenie alerts with given API integration key
+func NewOpsgenieAlerter(apiKey string) *OpsgenieAlerter {
+	return &OpsgenieAlerter{
+		URI:    OpsgenieAlertsURI,
+		APIKey: apiKey,
+		Client: thttp.NewClient(),
+	}
+}
+
+// OpsgenieAlerter creates and closes Opsgenie alerts, deduplication key is used as alert alias
+type OpsgenieAlerter struct {
+	URI    string
+	APIKey string
+	Client *http.Client
+}
+
+type opsgenieAlert struct {
+	Message  string            `json:"message"`
+	Alias    string            `json:"alias"`
+	Source   string            `json:"source"`
+	Priority string            `json:"priority"`
+	Details  map[string]string `json:"details,omitempty"`
+}
+
+type opsgenieClose struct {
+	Source string `json:"source"`
+}
+
+// Trigger creates Opsgenie alert, Opsgenie increases count of open alert with the same alias
+func (a *OpsgenieAlerter) Trigger(alert Alert) error {
+	return postJSON(a.Client, a.URI, a.headers(), opsgenieAlert{
+		Message:  alert.Summary,
+		Alias:    alert.DedupKey,
+		Source:   alert.Source,
+		Priority: "P1",
+		Details:  alert.Details,
+	})
+}
+
+// Resolve closes Opsgenie alert with given alias
+func (a *OpsgenieAlerter) Resolve(dedupKey string) error {
+	uri := a.URI + "/" + url.PathEscape(dedupKey) + "/close?identifierType=alias"
+	return postJSON(a.Client, uri, a.headers(), opsgenieClose{Source: "testkube"})
+}
+
+func (a *OpsgenieAlerter) headers() map[string]string {
+	return map[string]string{"Authorization": "GenieKey " + a.APIKey}
+}
@@ -0,0 +1,64 @@
+package alerting
+
+import (
+	"net/http"
+
+	thttp "github.com/kubeshop/testkube/pkg/http"
+)
+
+// PagerDutyEventsURI is a PagerDuty Events API v2 endpoint
+const PagerDutyEventsURI = "https://events.pagerduty.com/v2/enqueue"
+
+// NewPagerDutyAlerter returns alerter sending events to PagerDuty service integration with given routing key
+func NewPagerDutyAlerter(routingKey string) *PagerDutyAlerter {
+	return &PagerDutyAlerter{
+		URI:        PagerDutyEventsURI,
+		RoutingKey: routingKey,
+		Client:     thttp.NewClient(),
+	}
+}
+
+// PagerDutyAlerter opens and resolves PagerDuty incidents
+type PagerDutyAlerter struct {
+	URI        string
+	RoutingKey string
+	Client     *http.Client
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+// Trigger opens PagerDuty incident
+func (a *PagerDutyAlerter) Trigger(alert Alert) error {
+	return postJSON(a.Client, a.URI, nil, pagerDutyEvent{
+		RoutingKey:  a.RoutingKey,
+		EventAction: "trigger",
+		DedupKey:    alert.DedupKey,
+		Payload: &pagerDutyPayload{
+			Summary:       alert.Summary,
+			Source:        alert.Source,
+			Severity:      "critical",
+			CustomDetails: alert.Details,
+		},
+	})
+}
+
+// Resolve resolves PagerDuty incident
+func (a *PagerDutyAlerter) Resolve(dedupKey string) error {
+	return postJSON(a.Client, a.URI, nil, pagerDutyEvent{
+		RoutingKey:  a.RoutingKey,
+		EventAction: "resolve",
+		DedupKey:    dedupKey,
+	})
+}
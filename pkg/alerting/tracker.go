@@ -0,0 +1,42 @@
+package alerting
+
+import "sync"
+
+// Action is a change of test incident
+type Action int
+
+const (
+	ActionNone Action = iota
+	ActionTrigger
+	ActionResolve
+)
+
+// NewTracker returns tracker of open incidents
+func NewTracker() *Tracker {
+	return &Tracker{open: map[string]bool{}}
+}
+
+// Tracker remembers incidents state so alerts aren't repeated on every evaluation,
+// incidents of unknown state, e.g. after restart, are triggered or resolved once
+type Tracker struct {
+	mutex sync.Mutex
+	open  map[string]bool
+}
+
+// Next returns incident action for number of consecutive test failures
+func (t *Tracker) Next(dedupKey string, failures, threshold int) Action {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	open, known := t.open[dedupKey]
+	switch {
+	case failures >= threshold && !(known && open):
+		t.open[dedupKey] = true
+		return ActionTrigger
+	case failures == 0 && !(known && !open):
+		t.open[dedupKey] = false
+		return ActionResolve
+	}
+
+	return ActionNone
+}
@@ -2,16 +2,21 @@ package client
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
-	"time"
+	"strings"
 
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -43,6 +48,7 @@ func GetClientSet(overrideHost string) (clientset kubernetes.Interface, err erro
 		restcfg.Host = overrideHost
 	}
 
+	restcfg.WrapTransport = wrapProblemTransport
 	return kubernetes.NewForConfig(restcfg)
 }
 
@@ -54,10 +60,30 @@ func NewAPIClient(client kubernetes.Interface, config APIConfig) APIClient {
 	}
 }
 
+// NewURIAPIClient returns API client calling API server directly on given URI e.g. exposed with Ingress or LoadBalancer,
+// token is passed as a bearer token when set
+func NewURIAPIClient(uri, token string) (APIClient, error) {
+	restClient, err := rest.UnversionedRESTClientFor(&rest.Config{
+		Host:          uri,
+		BearerToken:   token,
+		WrapTransport: wrapProblemTransport,
+		ContentConfig: rest.ContentConfig{
+			NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		},
+	})
+	if err != nil {
+		return APIClient{}, err
+	}
+
+	return APIClient{restClient: restClient}, nil
+}
+
 // APIClient struct managing API Client dependencies
 type APIClient struct {
 	client kubernetes.Interface
 	config APIConfig
+	// restClient is used instead of kubernetes service proxy when set
+	restClient rest.Interface
 }
 
 // tests and executions -----------------------------------------------------------------------------
@@ -107,6 +133,62 @@ func (c APIClient) GetExecution(executionID string) (execution testkube.Executio
 	return c.getExecutionFromResponse(resp)
 }
 
+// GetExecutionDiff compares test execution with base execution, previous execution of the test is used when base is empty
+func (c APIClient) GetExecutionDiff(executionID, baseExecutionID string) (diff testkube.ExecutionDiff, err error) {
+	uri := c.getURI("/executions/%s/diff", executionID)
+
+	req := c.GetProxy("GET").Suffix(uri)
+	if baseExecutionID != "" {
+		req.Param("base", baseExecutionID)
+	}
+	resp := req.Do(context.Background())
+
+	if err := c.responseError(resp); err != nil {
+		return diff, fmt.Errorf("api/get-execution-diff returned error: %w", err)
+	}
+
+	bytes, err := resp.Raw()
+	if err != nil {
+		return diff, err
+	}
+
+	err = json.Unmarshal(bytes, &diff)
+	return diff, err
+}
+
+// GetExecutionGroup returns executions of execution group, e.g. of matrix executions, with aggregate status
+func (c APIClient) GetExecutionGroup(groupID string) (group testkube.ExecutionGroup, err error) {
+	uri := c.getURI("/execution-groups/%s", groupID)
+
+	req := c.GetProxy("GET").Suffix(uri)
+	resp := req.Do(context.Background())
+
+	if err := c.responseError(resp); err != nil {
+		return group, fmt.Errorf("api/get-execution-group returned error: %w", err)
+	}
+
+	bytes, err := resp.Raw()
+	if err != nil {
+		return group, err
+	}
+
+	err = json.Unmarshal(bytes, &group)
+	return group, err
+}
+
+// AbortExecutionGroup aborts queued and running executions of execution group
+func (c APIClient) AbortExecutionGroup(groupID string) error {
+	uri := c.getURI("/execution-groups/%s/abort", groupID)
+	req := c.GetProxy("POST").Suffix(uri)
+	resp := req.Do(context.Background())
+
+	if err := c.responseError(resp); err != nil {
+		return fmt.Errorf("api/abort-execution-group returned error: %w", err)
+	}
+
+	return nil
+}
+
 // ListExecutions list all executions for given test name
 func (c APIClient) ListExecutions(id string, limit int, selector string) (executions testkube.ExecutionsResult, err error) {
 
@@ -196,13 +278,18 @@ func (c APIClient) ExecuteTest(id, executionName string, options ExecuteTestOpti
 	uri := c.getURI("/tests/%s/executions", id)
 
 	request := testkube.ExecutionRequest{
-		Name:       executionName,
-		ParamsFile: options.ExecutionParamsFileContent,
-		Params:     options.ExecutionParams,
-		Args:       options.Args,
-		SecretEnvs: options.SecretEnvs,
-		HttpProxy:  options.HTTPProxy,
-		HttpsProxy: options.HTTPSProxy,
+		Name:                executionName,
+		ParamsFile:          options.ExecutionParamsFileContent,
+		Files:               options.Files,
+		Params:              options.ExecutionParams,
+		SecretParams:        options.SecretParams,
+		Args:                options.Args,
+		SecretEnvs:          options.SecretEnvs,
+		HttpProxy:           options.HTTPProxy,
+		HttpsProxy:          options.HTTPSProxy,
+		Labels:              options.ExecutionLabels,
+		RunningContext:      options.RunningContext,
+		ExecutorImageDigest: options.ExecutorImageDigest,
 	}
 
 	body, err := json.Marshal(request)
@@ -214,6 +301,10 @@ func (c APIClient) ExecuteTest(id, executionName string, options ExecuteTestOpti
 		Suffix(uri).
 		Body(body)
 
+	if options.Force {
+		req.Param("force", "true")
+	}
+
 	resp := req.Do(context.Background())
 
 	if err := c.responseError(resp); err != nil {
@@ -228,12 +319,17 @@ func (c APIClient) ExecuteTest(id, executionName string, options ExecuteTestOpti
 func (c APIClient) ExecuteTests(selector string, concurrencyLevel int, options ExecuteTestOptions) (executions []testkube.Execution, err error) {
 	uri := c.getURI("/executions")
 	request := testkube.ExecutionRequest{
-		ParamsFile: options.ExecutionParamsFileContent,
-		Params:     options.ExecutionParams,
-		Args:       options.Args,
-		SecretEnvs: options.SecretEnvs,
-		HttpProxy:  options.HTTPProxy,
-		HttpsProxy: options.HTTPSProxy,
+		ParamsFile:          options.ExecutionParamsFileContent,
+		Files:               options.Files,
+		Params:              options.ExecutionParams,
+		SecretParams:        options.SecretParams,
+		Args:                options.Args,
+		SecretEnvs:          options.SecretEnvs,
+		HttpProxy:           options.HTTPProxy,
+		HttpsProxy:          options.HTTPSProxy,
+		Labels:              options.ExecutionLabels,
+		RunningContext:      options.RunningContext,
+		ExecutorImageDigest: options.ExecutorImageDigest,
 	}
 
 	body, err := json.Marshal(request)
@@ -266,6 +362,9 @@ func (c APIClient) Logs(id string) (logs chan output.Output, err error) {
 		Suffix(uri).
 		SetHeader("Accept", "text/event-stream").
 		Stream(context.Background())
+	if err != nil {
+		return logs, err
+	}
 
 	go func() {
 		defer close(logs)
@@ -321,6 +420,25 @@ func (c APIClient) AbortExecution(testID, id string) error {
 	return c.makeDeleteRequest(uri, "", false)
 }
 
+// RerunExecution starts new execution replaying params, args, content commit and executor image of execution
+func (c APIClient) RerunExecution(executionID string) (execution testkube.Execution, err error) {
+	uri := c.getURI("/executions/%s/rerun", executionID)
+	req := c.GetProxy("POST").Suffix(uri)
+	resp := req.Do(context.Background())
+
+	if err := c.responseError(resp); err != nil {
+		return execution, fmt.Errorf("api/rerun-execution returned error: %w", err)
+	}
+
+	bytes, err := resp.Raw()
+	if err != nil {
+		return execution, err
+	}
+
+	err = json.Unmarshal(bytes, &execution)
+	return execution, err
+}
+
 // executor --------------------------------------------------------------------------------
 
 // CreateExecutor creates new Executor Custom Resource
@@ -472,7 +590,49 @@ func (c APIClient) GetServerInfo() (info testkube.ServerInfo, err error) {
 
 }
 
+func (c APIClient) GetServerHealth() (report testkube.HealthReport, err error) {
+	uri := c.getURI("/health")
+	req := c.GetProxy("GET").Suffix(uri)
+	resp := req.Do(context.Background())
+	if resp.Error() != nil {
+		return report, resp.Error()
+	}
+
+	bytes, err := resp.Raw()
+	if err != nil {
+		return report, err
+	}
+
+	err = json.Unmarshal(bytes, &report)
+
+	return
+}
+
+// ListQuotas returns quotas of API server settings with their current usage
+func (c APIClient) ListQuotas() (usages []testkube.QuotaUsage, err error) {
+	uri := c.getURI("/quotas")
+	req := c.GetProxy("GET").Suffix(uri)
+	resp := req.Do(context.Background())
+	if err := c.responseError(resp); err != nil {
+		return usages, fmt.Errorf("api/list-quotas returned error: %w", err)
+	}
+
+	bytes, err := resp.Raw()
+	if err != nil {
+		return usages, err
+	}
+
+	err = json.Unmarshal(bytes, &usages)
+
+	return
+}
+
 func (c APIClient) GetProxy(requestType string) *rest.Request {
+	if c.restClient != nil {
+		return c.restClient.Verb(requestType).
+			SetHeader("Content-Type", "application/json")
+	}
+
 	return c.client.CoreV1().RESTClient().Verb(requestType).
 		Namespace(c.config.Namespace).
 		Resource("services").
@@ -608,8 +768,8 @@ func (c APIClient) getProblemFromResponse(resp rest.Result) (problem.Problem, er
 	problemResponse := problem.Problem{}
 	err := json.Unmarshal(bytes, &problemResponse)
 
-	// add kubeAPI client error to details
-	if respErr != nil {
+	// add kubeAPI client error to details of responses without problem
+	if respErr != nil && problemResponse.Detail == "" {
 		problemResponse.Detail += ";\nresp error:" + respErr.Error()
 	}
 
@@ -627,7 +787,7 @@ func (c APIClient) responseError(resp rest.Result) error {
 			return fmt.Errorf("api server response: '%s'\nerror: %w", content, resp.Error())
 		}
 
-		return fmt.Errorf("api server problem: %s", pr.Detail)
+		return APIError{Problem: pr}
 	}
 
 	return nil
@@ -686,6 +846,103 @@ func (c APIClient) GetExecutionArtifacts(executionID string) (artifacts testkube
 
 }
 
+// GetPresignedExecutionArtifacts returns execution artifacts with presigned storage download URLs
+func (c APIClient) GetPresignedExecutionArtifacts(executionID string) (artifacts testkube.Artifacts, err error) {
+	uri := c.getURI("/executions/%s/artifacts", executionID)
+	req := c.GetProxy("GET").
+		Suffix(uri).
+		Param("presigned", "true")
+	resp := req.Do(context.Background())
+
+	if err := c.responseError(resp); err != nil {
+		return artifacts, fmt.Errorf("api/list-artifacts returned error: %w", err)
+	}
+
+	return c.getArtifactsFromResponse(resp)
+}
+
+// DownloadArtifact downloads artifact into destination preserving its path, presigned URL is preferred when set,
+// API is used as a fallback, downloaded file size and checksum are verified
+func (c APIClient) DownloadArtifact(executionID string, artifact testkube.Artifact, destination string) (path string, err error) {
+	path, err = artifactPath(destination, artifact.Name)
+	if err != nil {
+		return path, err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return path, err
+	}
+
+	if artifact.DownloadUrl != "" {
+		err = writeArtifact(path, artifact, func() (io.ReadCloser, error) {
+			return getFromURL(artifact.DownloadUrl)
+		})
+		if err == nil {
+			return path, nil
+		}
+	}
+
+	return path, writeArtifact(path, artifact, func() (io.ReadCloser, error) {
+		uri := c.getURI("/executions/%s/artifacts/%s", executionID, url.QueryEscape(artifact.Name))
+		return c.GetProxy("GET").
+			Suffix(uri).
+			SetHeader("Accept", "text/event-stream").
+			Stream(context.Background())
+	})
+}
+
+// artifactPath returns artifact local path, artifacts outside of destination are rejected
+func artifactPath(destination, name string) (string, error) {
+	path := filepath.Join(destination, filepath.FromSlash(name))
+	rel, err := filepath.Rel(destination, path)
+	if err != nil {
+		return path, err
+	}
+
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path, fmt.Errorf("artifact %s path is outside of destination directory", name)
+	}
+
+	return path, nil
+}
+
+// writeArtifact writes artifact content to path and verifies it
+func writeArtifact(path string, artifact testkube.Artifact, open func() (io.ReadCloser, error)) error {
+	reader, err := open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	hash, sha256Hash := md5.New(), sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, hash, sha256Hash), reader)
+	if err != nil {
+		return err
+	}
+
+	return artifact.Verify(size, hex.EncodeToString(hash.Sum(nil)), hex.EncodeToString(sha256Hash.Sum(nil)))
+}
+
+func getFromURL(uri string) (io.ReadCloser, error) {
+	resp, err := http.Get(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("downloading %s returned status %d", uri, resp.StatusCode)
+	}
+
+	return resp.Body, nil
+}
+
 func (c APIClient) DownloadFile(executionID, fileName, destination string) (artifact string, err error) {
 	uri := c.getURI("/executions/%s/artifacts/%s", executionID, url.QueryEscape(fileName))
 	req, err := c.GetProxy("GET").
@@ -872,10 +1129,11 @@ func (c APIClient) ExecuteTestSuite(id, executionName string, options ExecuteTes
 	uri := c.getURI("/test-suites/%s/executions", id)
 
 	executionRequest := testkube.TestSuiteExecutionRequest{
-		Name:       executionName,
-		Params:     options.ExecutionParams,
-		HttpProxy:  options.HTTPProxy,
-		HttpsProxy: options.HTTPSProxy,
+		Name:           executionName,
+		Params:         options.ExecutionParams,
+		HttpProxy:      options.HTTPProxy,
+		HttpsProxy:     options.HTTPSProxy,
+		RunningContext: options.RunningContext,
 	}
 
 	body, err := json.Marshal(executionRequest)
@@ -887,6 +1145,10 @@ func (c APIClient) ExecuteTestSuite(id, executionName string, options ExecuteTes
 		Suffix(uri).
 		Body(body)
 
+	if options.Force {
+		req.Param("force", "true")
+	}
+
 	resp := req.Do(context.Background())
 
 	if err := c.responseError(resp); err != nil {
@@ -902,9 +1164,10 @@ func (c APIClient) ExecuteTestSuites(selector string, concurrencyLevel int, opti
 	uri := c.getURI("/test-suite-executions")
 
 	executionRequest := testkube.TestSuiteExecutionRequest{
-		Params:     options.ExecutionParams,
-		HttpProxy:  options.HTTPProxy,
-		HttpsProxy: options.HTTPSProxy,
+		Params:         options.ExecutionParams,
+		HttpProxy:      options.HTTPProxy,
+		HttpsProxy:     options.HTTPSProxy,
+		RunningContext: options.RunningContext,
 	}
 
 	body, err := json.Marshal(executionRequest)
@@ -940,32 +1203,85 @@ func (c APIClient) GetTestSuiteExecution(executionID string) (execution testkube
 	return c.getTestSuiteExecutionFromResponse(resp)
 }
 
-// WatchTestSuiteExecution watches for changes in channels of test suite executions steps
+// AbortTestSuiteExecution aborts test suite execution by id
+func (c APIClient) AbortTestSuiteExecution(executionID string) error {
+	uri := c.getURI("/test-suite-executions/%s/abort", executionID)
+	req := c.GetProxy("POST").Suffix(uri)
+	resp := req.Do(context.Background())
+
+	if err := c.responseError(resp); err != nil {
+		return fmt.Errorf("api/abort-test-suite-execution returned error: %w", err)
+	}
+
+	return nil
+}
+
+// ApproveTestSuiteStep approves or rejects approval step of test suite execution
+func (c APIClient) ApproveTestSuiteStep(executionID string, step int, decision testkube.TestSuiteStepApprovalDecision) (
+	result testkube.TestSuiteStepExecutionResult, err error) {
+	uri := c.getURI("/test-suite-executions/%s/steps/%d/approve", executionID, step)
+	body, err := json.Marshal(decision)
+	if err != nil {
+		return result, err
+	}
+
+	req := c.GetProxy("POST").Suffix(uri).Body(body)
+	resp := req.Do(context.Background())
+
+	if err := c.responseError(resp); err != nil {
+		return result, fmt.Errorf("api/approve-test-suite-step returned error: %w", err)
+	}
+
+	bytes, err := resp.Raw()
+	if err != nil {
+		return result, err
+	}
+
+	err = json.Unmarshal(bytes, &result)
+	return result, err
+}
+
+// RerunTestSuiteExecution starts test suite execution rerunning failed and subsequent steps of test suite execution
+func (c APIClient) RerunTestSuiteExecution(executionID string) (execution testkube.TestSuiteExecution, err error) {
+	uri := c.getURI("/test-suite-executions/%s/rerun", executionID)
+	req := c.GetProxy("POST").Suffix(uri).Param("from", "failed")
+	resp := req.Do(context.Background())
+
+	if err := c.responseError(resp); err != nil {
+		return execution, fmt.Errorf("api/rerun-test-suite-execution returned error: %w", err)
+	}
+
+	bytes, err := resp.Raw()
+	if err != nil {
+		return execution, err
+	}
+
+	err = json.Unmarshal(bytes, &execution)
+	return execution, err
+}
+
+// WatchTestSuiteExecution watches for changes in channels of test suite executions steps,
+// execution is sent whenever its status or status of any step changes until it's completed
 func (c APIClient) WatchTestSuiteExecution(executionID string) (executionCh chan testkube.TestSuiteExecution, err error) {
 	executionCh = make(chan testkube.TestSuiteExecution)
+	uri := c.getURI("/test-suite-executions/%s/watch", executionID)
 
-	go func() {
-		execution, err := c.GetTestSuiteExecution(executionID)
-		if err != nil {
-			close(executionCh)
-			return
-		}
-		executionCh <- execution
-		for range time.NewTicker(time.Second).C {
-			execution, err = c.GetTestSuiteExecution(executionID)
-			if err != nil {
-				close(executionCh)
-				return
-			}
+	resp, err := c.GetProxy("GET").
+		Suffix(uri).
+		SetHeader("Accept", "text/event-stream").
+		Stream(context.Background())
+	if err != nil {
+		close(executionCh)
+		return executionCh, err
+	}
 
-			if execution.IsCompleted() {
-				close(executionCh)
-				return
-			}
+	go func() {
+		defer close(executionCh)
+		defer resp.Close()
 
-			executionCh <- execution
-		}
+		StreamToTestSuiteExecutionsChannel(resp, executionCh)
 	}()
+
 	return
 }
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"testing"
 
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
@@ -80,3 +81,54 @@ func TestDefaultDirectAPIClient(t *testing.T) {
 	})
 
 }
+
+func TestArtifactPath(t *testing.T) {
+	path, err := artifactPath("out", "screenshots/login.png")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("out", "screenshots", "login.png"), path)
+
+	_, err = artifactPath("out", "../../etc/passwd")
+	assert.Error(t, err)
+}
+
+func TestURIAPIClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/results/v1/tests/api-test":
+			w.Header().Add("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"name":"api-test","type":"postman/collection"}`)
+		case "/results/v1/executions/1/logs":
+			w.Header().Add("Content-Type", "text/event-stream")
+			fmt.Fprintf(w, "data: {\"type\":\"line\",\"content\":\"hello\"}\n\n")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewURIAPIClient(srv.URL+"/results", "secret-token")
+	assert.NoError(t, err)
+
+	t.Run("get test", func(t *testing.T) {
+		test, err := client.GetTest("api-test")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "postman/collection", test.Type_)
+	})
+
+	t.Run("stream logs", func(t *testing.T) {
+		logs, err := client.Logs("1")
+		assert.NoError(t, err)
+
+		var lines []string
+		for l := range logs {
+			lines = append(lines, l.Content)
+		}
+		assert.Equal(t, []string{"hello"}, lines)
+	})
+}
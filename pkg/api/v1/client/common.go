@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/kubeshop/testkube/pkg/executor/output"
 )
 
@@ -46,6 +47,37 @@ func StreamToLogsChannel(resp io.Reader, logs chan output.Output) {
 	}
 }
 
+// StreamToTestSuiteExecutionsChannel converts SSE stream of test suite executions to channel, completed execution is not sent
+func StreamToTestSuiteExecutionsChannel(resp io.Reader, executions chan testkube.TestSuiteExecution) {
+	scanner := bufio.NewScanner(resp)
+
+	// test suite executions with many steps can be long
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 16*1024*1024)
+
+	for scanner.Scan() {
+		chunk := trimDataChunk(scanner.Bytes())
+
+		// ignore lines which are not JSON objects
+		if len(chunk) < 2 || chunk[0] != '{' {
+			continue
+		}
+
+		execution := testkube.TestSuiteExecution{}
+		err := json.Unmarshal(chunk, &execution)
+		if err != nil {
+			fmt.Printf("Unmarshal chunk error: %+v, json:'%s' \n", err, chunk)
+			continue
+		}
+
+		if execution.Status != nil && execution.IsCompleted() {
+			return
+		}
+
+		executions <- execution
+	}
+}
+
 // trimDataChunk remove data: and newlines from incoming SSE data line
 func trimDataChunk(in []byte) []byte {
 	prefix := []byte("data: ")
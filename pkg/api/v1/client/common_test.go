@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/kubeshop/testkube/pkg/executor/output"
 	"github.com/stretchr/testify/assert"
 )
@@ -41,3 +42,22 @@ func TestStreamToLogsChannelNewErrorFormat(t *testing.T) {
 	result := <-log
 	assert.Equal(t, output.Output{Type_: "error", Content: "some message"}, result)
 }
+
+// TestStreamToTestSuiteExecutionsChannel sends test suite executions until completed execution
+func TestStreamToTestSuiteExecutionsChannel(t *testing.T) {
+	executions := make(chan testkube.TestSuiteExecution)
+	in := []byte("data: {\"id\": \"1\", \"status\": \"running\"}\n\n" +
+		"data: {\"id\": \"1\", \"status\": \"passed\"}\n\n" +
+		"data: {\"id\": \"1\", \"status\": \"running\"}\n\n")
+
+	go func() {
+		defer close(executions)
+		StreamToTestSuiteExecutionsChannel(bytes.NewBuffer(in), executions)
+	}()
+
+	var statuses []testkube.TestSuiteExecutionStatus
+	for execution := range executions {
+		statuses = append(statuses, *execution.Status)
+	}
+	assert.Equal(t, []testkube.TestSuiteExecutionStatus{testkube.RUNNING_TestSuiteExecutionStatus}, statuses)
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/kubeshop/testkube/pkg/problem"
+)
+
+// Exit codes of CLI commands failed with API server problems, code 1 is shared by failed executions and other errors,
+// codes 2 and 3 are used for timed out and aborted executions
+const (
+	ExitCodeNotFound        = 4
+	ExitCodeInvalidRequest  = 5
+	ExitCodeConflict        = 6
+	ExitCodeExecutorMissing = 7
+	ExitCodeAccessDenied    = 8
+	ExitCodeUnavailable     = 9
+	ExitCodeQuotaExceeded   = 10
+)
+
+// exitCodes are CLI exit codes of problem codes, other problems exit with 1
+var exitCodes = map[problem.Code]int{
+	problem.CodeNotFound:                   ExitCodeNotFound,
+	problem.CodeTestNotFound:               ExitCodeNotFound,
+	problem.CodeTestSuiteNotFound:          ExitCodeNotFound,
+	problem.CodeExecutionNotFound:          ExitCodeNotFound,
+	problem.CodeTestSuiteExecutionNotFound: ExitCodeNotFound,
+	problem.CodeExecutionGroupNotFound:     ExitCodeNotFound,
+	problem.CodeExecutorNotFound:           ExitCodeNotFound,
+	problem.CodeWebhookNotFound:            ExitCodeNotFound,
+	problem.CodeBadRequest:                 ExitCodeInvalidRequest,
+	problem.CodeInvalidRequest:             ExitCodeInvalidRequest,
+	problem.CodePayloadTooLarge:            ExitCodeInvalidRequest,
+	problem.CodeConflict:                   ExitCodeConflict,
+	problem.CodeDuplicateExecution:         ExitCodeConflict,
+	problem.CodeDisabled:                   ExitCodeConflict,
+	problem.CodeNotFinished:                ExitCodeConflict,
+	problem.CodeExecutorMissing:            ExitCodeExecutorMissing,
+	problem.CodeUnauthorized:               ExitCodeAccessDenied,
+	problem.CodeForbidden:                  ExitCodeAccessDenied,
+	problem.CodePolicyViolation:            ExitCodeAccessDenied,
+	problem.CodeRateLimited:                ExitCodeUnavailable,
+	problem.CodeUnavailable:                ExitCodeUnavailable,
+	problem.CodeQuotaExceeded:              ExitCodeQuotaExceeded,
+	problem.CodeArtifactQuotaExceeded:      ExitCodeQuotaExceeded,
+}
+
+// hints are friendly messages added to problem details
+var hints = map[problem.Code]string{
+	problem.CodeTestNotFound:               "list tests with kubectl testkube get tests",
+	problem.CodeTestSuiteNotFound:          "list test suites with kubectl testkube get testsuites",
+	problem.CodeExecutionNotFound:          "list executions with kubectl testkube get executions",
+	problem.CodeTestSuiteExecutionNotFound: "list test suite executions with kubectl testkube get testsuiteexecutions",
+	problem.CodeExecutorNotFound:           "list executors with kubectl testkube get executors",
+	problem.CodeExecutorMissing:            "no executor handles the test type, list executors with kubectl testkube get executors",
+	problem.CodeDuplicateExecution:         "execution names have to be unique per test, pass different --name",
+	problem.CodeDisabled:                   "pass --force to run it",
+	problem.CodeInvalidRequest:             "check command flags and definition files",
+	problem.CodeUnauthorized:               "check API token",
+	problem.CodeForbidden:                  "check API token and project",
+	problem.CodePolicyViolation:            "execution policies are listed in API server settings at /v1/config",
+	problem.CodeRateLimited:                "too many requests, try again later",
+	problem.CodeUnavailable:                "API server is not available, try again later",
+	problem.CodeQuotaExceeded:              "check quotas usage at /v1/quotas",
+	problem.CodeArtifactQuotaExceeded:      "delete old executions or raise the quota, usage is at /v1/quotas",
+}
+
+// APIError is a problem returned by API server
+type APIError struct {
+	Problem problem.Problem
+}
+
+func (e APIError) Error() string {
+	message := "api server problem: " + e.Problem.Detail
+	if hint, ok := hints[e.Code()]; ok {
+		message += " (" + hint + ")"
+	}
+
+	// request id is shown so problems can be reported with API server logs
+	if e.Problem.RequestId != "" {
+		message += ", request id: " + e.Problem.RequestId
+	}
+
+	return message
+}
+
+// Code returns problem code, code of response status is returned for API servers not sending codes
+func (e APIError) Code() problem.Code {
+	if e.Problem.Code != "" {
+		return e.Problem.Code
+	}
+
+	return problem.CodeOf(nil, e.Problem.Status)
+}
+
+// ExitCode returns CLI exit code of problem
+func (e APIError) ExitCode() int {
+	if code, ok := exitCodes[e.Code()]; ok {
+		return code
+	}
+
+	return 1
+}
+
+// problemTransport passes problem+json responses to kube rest client as JSON ones, rest client drops bodies
+// of error responses with content types it can't decode
+type problemTransport struct {
+	next http.RoundTripper
+}
+
+func (t problemTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err == nil && strings.HasPrefix(resp.Header.Get("Content-Type"), "application/problem+json") {
+		resp.Header.Set("Content-Type", "application/json")
+	}
+
+	return resp, err
+}
+
+func wrapProblemTransport(next http.RoundTripper) http.RoundTripper {
+	return problemTransport{next: next}
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/problem"
+)
+
+func TestAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusNotFound)
+		if r.URL.Path == "/v1/tests/legacy" {
+			fmt.Fprint(w, `{"type":"about:blank","title":"Not Found","status":404,"detail":"test legacy not found"}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"type":"about:blank","title":"Not Found","status":404,"detail":"test api not found","code":"test_not_found"}`)
+	}))
+	defer srv.Close()
+
+	client, err := NewURIAPIClient(srv.URL, "")
+	require.NoError(t, err)
+
+	_, err = client.GetTest("api")
+	var apiErr APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, problem.CodeTestNotFound, apiErr.Code())
+	assert.Equal(t, ExitCodeNotFound, apiErr.ExitCode())
+	assert.Contains(t, err.Error(), "test api not found (list tests with kubectl testkube get tests)")
+
+	_, err = client.GetTest("legacy")
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, problem.CodeNotFound, apiErr.Code(), "code of status is used for servers not sending codes")
+	assert.Equal(t, ExitCodeNotFound, apiErr.ExitCode())
+}
@@ -34,3 +34,8 @@ func GetClient(clientType ClientType, namespace string) (client Client, err erro
 
 	return client, err
 }
+
+// GetURIClient returns Testkube API client connecting to API server URI without kubernetes access
+func GetURIClient(uri, token string) (client Client, err error) {
+	return NewURIAPIClient(uri, token)
+}
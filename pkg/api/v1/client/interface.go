@@ -18,8 +18,12 @@ type HTTPClient interface {
 // Client is the Testkube API client abstraction
 type Client interface {
 	GetExecution(executionID string) (execution testkube.Execution, err error)
+	GetExecutionGroup(groupID string) (group testkube.ExecutionGroup, err error)
+	GetExecutionDiff(executionID, baseExecutionID string) (diff testkube.ExecutionDiff, err error)
+	AbortExecutionGroup(groupID string) error
 	ListExecutions(id string, limit int, selector string) (executions testkube.ExecutionsResult, err error)
 	AbortExecution(test string, id string) error
+	RerunExecution(executionID string) (execution testkube.Execution, err error)
 
 	GetTest(id string) (test testkube.Test, err error)
 	GetTestWithExecution(id string) (test testkube.TestWithExecution, err error)
@@ -47,6 +51,8 @@ type Client interface {
 
 	GetExecutionArtifacts(executionID string) (artifacts testkube.Artifacts, err error)
 	DownloadFile(executionID, fileName, destination string) (artifact string, err error)
+	GetPresignedExecutionArtifacts(executionID string) (artifacts testkube.Artifacts, err error)
+	DownloadArtifact(executionID string, artifact testkube.Artifact, destination string) (path string, err error)
 
 	CreateTestSuite(options UpsertTestSuiteOptions) (testSuite testkube.TestSuite, err error)
 	UpdateTestSuite(options UpsertTestSuiteOptions) (testSuite testkube.TestSuite, err error)
@@ -62,8 +68,13 @@ type Client interface {
 	GetTestSuiteExecution(executionID string) (execution testkube.TestSuiteExecution, err error)
 	ListTestSuiteExecutions(test string, limit int, selector string) (executions testkube.TestSuiteExecutionsResult, err error)
 	WatchTestSuiteExecution(executionID string) (execution chan testkube.TestSuiteExecution, err error)
+	AbortTestSuiteExecution(executionID string) error
+	ApproveTestSuiteStep(executionID string, step int, decision testkube.TestSuiteStepApprovalDecision) (result testkube.TestSuiteStepExecutionResult, err error)
+	RerunTestSuiteExecution(executionID string) (execution testkube.TestSuiteExecution, err error)
 
 	GetServerInfo() (info testkube.ServerInfo, err error)
+	GetServerHealth() (report testkube.HealthReport, err error)
+	ListQuotas() (usages []testkube.QuotaUsage, err error)
 }
 
 // UpsertTestSuiteOptions - mapping to OpenAPI schema for creating/changing testsuite
@@ -82,11 +93,17 @@ type CreateWebhookOptions testkube.WebhookCreateRequest
 // ExecuteTestOptions contains test run options
 type ExecuteTestOptions struct {
 	ExecutionParams            map[string]string
+	SecretParams               []string
 	ExecutionParamsFileContent string
+	Files                      []testkube.ExecutionFile
 	Args                       []string
 	SecretEnvs                 map[string]string
 	HTTPProxy                  string
 	HTTPSProxy                 string
+	ExecutionLabels            map[string]string
+	RunningContext             *testkube.RunningContext
+	Force                      bool
+	ExecutorImageDigest        string
 }
 
 // ExecuteTestSuiteOptions contains test suite run options
@@ -94,4 +111,6 @@ type ExecuteTestSuiteOptions struct {
 	ExecutionParams map[string]string
 	HTTPProxy       string
 	HTTPSProxy      string
+	Force           bool
+	RunningContext  *testkube.RunningContext
 }
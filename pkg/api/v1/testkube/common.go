@@ -5,6 +5,141 @@ import (
 	"strings"
 )
 
+// ProjectLabel is a label used to scope tests, test suites and their executions to a project
+const ProjectLabel = "testkube.io/project"
+
+// QuarantineLabel is a label marking test as quarantined, quarantined test failures don't fail test suites
+const QuarantineLabel = "testkube.io/quarantined"
+
+// IsQuarantined checks if resource labels mark it as quarantined
+func IsQuarantined(labels map[string]string) bool {
+	return labels[QuarantineLabel] == "true"
+}
+
+// DisabledLabel is a label marking test or test suite as disabled, disabled resources are skipped by selector runs,
+// their cron jobs are suspended and manual runs have to be forced
+const DisabledLabel = "testkube.io/disabled"
+
+// IsDisabled checks if resource labels mark it as disabled
+func IsDisabled(labels map[string]string) bool {
+	return labels[DisabledLabel] == "true"
+}
+
+// WithEnabledLabel returns labels with disabled label set or removed by enabled state, labels are kept when state isn't set
+func WithEnabledLabel(labels map[string]string, enabled *bool) map[string]string {
+	if enabled == nil {
+		return labels
+	}
+
+	result := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		result[k] = v
+	}
+
+	if *enabled {
+		delete(result, DisabledLabel)
+	} else {
+		result[DisabledLabel] = "true"
+	}
+
+	return result
+}
+
+const (
+	// PerfGateThresholdLabel is a label with allowed metrics regression in percent versus baseline, gate is disabled when not set
+	PerfGateThresholdLabel = "testkube.io/perf-gate-threshold"
+	// PerfGateBaselineLabel is a label with number of last successful executions used as baseline
+	PerfGateBaselineLabel = "testkube.io/perf-gate-baseline"
+)
+
+// StepParamsAnnotation is a test suite annotation storing per step params, as test suite step spec has no params field
+const StepParamsAnnotation = "testkube.io/step-params"
+
+// StepOptionsAnnotation is a test suite annotation storing per step timeout, retries, error handling and condition, as test suite
+// step spec has no such fields
+const StepOptionsAnnotation = "testkube.io/step-options"
+
+// SecretMountsAnnotation is a test annotation storing secret mounts, as test spec has no secret mounts field
+const SecretMountsAnnotation = "testkube.io/secret-mounts"
+
+// DataFileAnnotation is a test annotation storing iteration data file content, as test spec has no data file field
+const DataFileAnnotation = "testkube.io/data-file"
+
+// SecretParamsAnnotation is a test annotation storing names of secret params, as test spec has no secret params field
+const SecretParamsAnnotation = "testkube.io/secret-params"
+
+// MaintenanceWindowsAnnotation is a test annotation storing maintenance windows, as test spec has no maintenance windows field
+const MaintenanceWindowsAnnotation = "testkube.io/maintenance-windows"
+
+// PlacementAnnotation is a test and executor annotation storing scheduling constraints, as their specs have no placement field
+const PlacementAnnotation = "testkube.io/placement"
+
+// LogCollectionAnnotation is a test annotation storing application pods logs collection, as test spec has no log collection field
+const LogCollectionAnnotation = "testkube.io/log-collection"
+
+// PrechecksAnnotation is a test annotation storing readiness prechecks, as test spec has no prechecks field
+const PrechecksAnnotation = "testkube.io/prechecks"
+
+// DependenciesAnnotation is a test annotation storing tests required to pass, as test spec has no dependencies field
+const DependenciesAnnotation = "testkube.io/dependencies"
+
+// NewmanOptionsAnnotation is a test annotation storing newman options, as test spec has no newman options field
+const NewmanOptionsAnnotation = "testkube.io/newman-options"
+
+// TriggersAnnotation is a test and test suite annotation storing executions triggered on success, as specs have no triggers field
+const TriggersAnnotation = "testkube.io/triggers"
+
+// ContentUriOptionsAnnotation is a test annotation storing file URI content options, as test content spec has no options field
+const ContentUriOptionsAnnotation = "testkube.io/content-uri-options"
+
+// WebhookSelectorAnnotation is a webhook annotation storing label selector of notified tests, as webhook spec has no selector field
+const WebhookSelectorAnnotation = "testkube.io/webhook-selector"
+
+// WebhookStatusesAnnotation is a webhook annotation storing comma separated statuses of notified executions
+const WebhookStatusesAnnotation = "testkube.io/webhook-statuses"
+
+// WebhookSigningSecretAnnotation is a webhook annotation storing name of Secret with payload signing secret
+const WebhookSigningSecretAnnotation = "testkube.io/webhook-signing-secret"
+
+// WebhookProxyAnnotation is a webhook annotation storing URL of proxy used to call webhook
+const WebhookProxyAnnotation = "testkube.io/webhook-proxy"
+
+// WebhookTLSSecretAnnotation is a webhook annotation storing name of Secret with CA bundle and mTLS client certificate
+const WebhookTLSSecretAnnotation = "testkube.io/webhook-tls-secret"
+
+const (
+	// OwnerAnnotation is a test and test suite annotation storing owner, as specs have no ownership fields
+	OwnerAnnotation = "testkube.io/owner"
+	// TeamAnnotation is a test and test suite annotation storing owning team
+	TeamAnnotation = "testkube.io/team"
+	// ContactAnnotation is a test and test suite annotation storing contact of owners
+	ContactAnnotation = "testkube.io/contact"
+)
+
+// OwnershipAnnotations are annotations storing ownership fields
+var OwnershipAnnotations = []string{OwnerAnnotation, TeamAnnotation, ContactAnnotation}
+
+// RegistryMirrorLabel is an executor label, "disabled" value opts out executor from registry mirror
+const RegistryMirrorLabel = "testkube.io/registry-mirror"
+
+// IsRegistryMirrorDisabled checks if executor labels opt out from registry mirror
+func IsRegistryMirrorDisabled(labels map[string]string) bool {
+	return labels[RegistryMirrorLabel] == "disabled"
+}
+
+// ExecutionGroupLabel is an execution label with id of execution group, e.g. of matrix executions started by one request
+const ExecutionGroupLabel = "testkube.io/execution-group"
+
+// GetExecutionGroup returns execution group id from execution labels
+func GetExecutionGroup(labels map[string]string) string {
+	return labels[ExecutionGroupLabel]
+}
+
+// GetProject returns project name from resource labels
+func GetProject(labels map[string]string) string {
+	return labels[ProjectLabel]
+}
+
 func LabelsToString(labelsMap map[string]string) string {
 	labels := []string{}
 	for k, v := range labelsMap {
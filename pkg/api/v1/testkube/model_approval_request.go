@@ -0,0 +1,30 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// test suite approval step waiting for decision
+type ApprovalRequest struct {
+	// test suite execution id
+	TestSuiteExecutionId string `json:"testSuiteExecutionId"`
+	// test suite execution name
+	TestSuiteExecutionName string `json:"testSuiteExecutionName,omitempty"`
+	// test suite name
+	TestSuiteName string `json:"testSuiteName,omitempty"`
+	// index of approval step in test suite execution step results
+	Step int32 `json:"step"`
+	// message shown to approvers
+	Message string `json:"message,omitempty"`
+	// approval timeout in seconds
+	Timeout int32 `json:"timeout,omitempty"`
+	// URI decision is posted to
+	Uri string `json:"uri,omitempty"`
+	// test suite execution labels
+	Labels map[string]string `json:"labels,omitempty"`
+}
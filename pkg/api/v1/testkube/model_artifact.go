@@ -15,4 +15,13 @@ type Artifact struct {
 	Name string `json:"name,omitempty"`
 	// file size in bytes
 	Size int32 `json:"size,omitempty"`
+	// file MD5 checksum, empty when storage doesn't provide it
+	Checksum string `json:"checksum,omitempty"`
+	// file SHA-256 checksum computed when artifact was uploaded, empty when storage doesn't provide it
+	Sha256 string `json:"sha256,omitempty"`
+	// presigned storage URL for downloading file directly
+	DownloadUrl string              `json:"downloadUrl,omitempty"`
+	ScanStatus  *ArtifactScanStatus `json:"scanStatus,omitempty"`
+	// scanner findings of flagged or quarantined artifact
+	ScanDetails string `json:"scanDetails,omitempty"`
 }
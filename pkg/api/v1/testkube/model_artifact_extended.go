@@ -1,19 +1,58 @@
 package testkube
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 )
 
 type Artifacts []Artifact
 
+// Table returns artifacts table, scan column is added when artifacts were scanned
 func (artifacts Artifacts) Table() (header []string, output [][]string) {
+	scanned := false
+	for _, e := range artifacts {
+		scanned = scanned || e.ScanStatus != nil
+	}
+
 	header = []string{"Name", "Size (KB)"}
+	if scanned {
+		header = append(header, "Scan")
+	}
+
 	for _, e := range artifacts {
-		output = append(output, []string{
+		row := []string{
 			e.Name,
 			strconv.FormatInt(int64(e.Size), 10),
-		})
+		}
+
+		if scanned {
+			status := ""
+			if e.ScanStatus != nil {
+				status = string(*e.ScanStatus)
+			}
+			row = append(row, status)
+		}
+
+		output = append(output, row)
 	}
 
 	return
 }
+
+// Verify checks downloaded file size, MD5 and SHA-256 checksums, checksums are not checked when artifact doesn't have them
+func (a Artifact) Verify(size int64, checksum, sha256 string) error {
+	if size != int64(a.Size) {
+		return fmt.Errorf("artifact %s size mismatch: expected %d bytes, got %d", a.Name, a.Size, size)
+	}
+
+	if a.Checksum != "" && !strings.EqualFold(a.Checksum, checksum) {
+		return fmt.Errorf("artifact %s checksum mismatch: expected %s, got %s", a.Name, a.Checksum, checksum)
+	}
+
+	if a.Sha256 != "" && !strings.EqualFold(a.Sha256, sha256) {
+		return fmt.Errorf("artifact %s SHA-256 checksum mismatch: expected %s, got %s", a.Name, a.Sha256, sha256)
+	}
+
+	return nil
+}
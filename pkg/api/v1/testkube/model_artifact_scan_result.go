@@ -0,0 +1,19 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// result of execution artifact scan
+type ArtifactScanResult struct {
+	// artifact file path
+	Name   string              `json:"name"`
+	Status *ArtifactScanStatus `json:"status"`
+	// scanner findings, e.g. names of detected secrets or viruses
+	Details string `json:"details,omitempty"`
+}
@@ -0,0 +1,21 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+type ArtifactScanStatus string
+
+// List of ArtifactScanStatus
+const (
+	PENDING_ArtifactScanStatus     ArtifactScanStatus = "pending"
+	CLEAN_ArtifactScanStatus       ArtifactScanStatus = "clean"
+	FLAGGED_ArtifactScanStatus     ArtifactScanStatus = "flagged"
+	QUARANTINED_ArtifactScanStatus ArtifactScanStatus = "quarantined"
+	FAILED_ArtifactScanStatus      ArtifactScanStatus = "failed"
+)
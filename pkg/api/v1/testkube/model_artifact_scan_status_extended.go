@@ -0,0 +1,29 @@
+package testkube
+
+import "fmt"
+
+func ArtifactScanStatusPtr(status ArtifactScanStatus) *ArtifactScanStatus {
+	return &status
+}
+
+var ArtifactScanStatusPending = ArtifactScanStatusPtr(PENDING_ArtifactScanStatus)
+var ArtifactScanStatusClean = ArtifactScanStatusPtr(CLEAN_ArtifactScanStatus)
+var ArtifactScanStatusFlagged = ArtifactScanStatusPtr(FLAGGED_ArtifactScanStatus)
+var ArtifactScanStatusQuarantined = ArtifactScanStatusPtr(QUARANTINED_ArtifactScanStatus)
+var ArtifactScanStatusFailed = ArtifactScanStatusPtr(FAILED_ArtifactScanStatus)
+
+// Validate checks that scan status is known
+func (s ArtifactScanStatus) Validate() error {
+	switch s {
+	case PENDING_ArtifactScanStatus, CLEAN_ArtifactScanStatus, FLAGGED_ArtifactScanStatus, QUARANTINED_ArtifactScanStatus,
+		FAILED_ArtifactScanStatus:
+		return nil
+	}
+
+	return fmt.Errorf("unknown artifact scan status %q", s)
+}
+
+// IsDownloadable checks if artifact with scan status can be downloaded, pending, quarantined and failed artifacts can't
+func (s *ArtifactScanStatus) IsDownloadable() bool {
+	return s == nil || *s == CLEAN_ArtifactScanStatus || *s == FLAGGED_ArtifactScanStatus
+}
@@ -0,0 +1,37 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+import (
+	"time"
+)
+
+// release evidence of test suite execution with parameters, results, operator identity and artifact checksums
+type ComplianceReport struct {
+	// test suite execution id
+	ExecutionId string `json:"executionId"`
+	// test suite execution name
+	ExecutionName string                    `json:"executionName"`
+	TestSuite     *ObjectRef                `json:"testSuite,omitempty"`
+	Status        *TestSuiteExecutionStatus `json:"status,omitempty"`
+	// execution params passed to test suite steps
+	Params map[string]string `json:"params,omitempty"`
+	// test suite execution start time
+	StartTime time.Time `json:"startTime,omitempty"`
+	// test suite execution end time
+	EndTime time.Time `json:"endTime,omitempty"`
+	// test suite execution duration
+	Duration       string          `json:"duration,omitempty"`
+	RunningContext *RunningContext `json:"runningContext,omitempty"`
+	// results of test suite steps in order
+	Steps []ComplianceReportStep `json:"steps"`
+	// report generation time, it's signed together with report
+	GeneratedAt time.Time `json:"generatedAt"`
+}
@@ -0,0 +1,45 @@
+package testkube
+
+import "time"
+
+// NewComplianceReport returns compliance report of test suite execution, step artifacts aren't set
+func NewComplianceReport(execution TestSuiteExecution, generatedAt time.Time) ComplianceReport {
+	report := ComplianceReport{
+		ExecutionId:    execution.Id,
+		ExecutionName:  execution.Name,
+		TestSuite:      execution.TestSuite,
+		Status:         execution.Status,
+		Params:         execution.Params,
+		StartTime:      execution.StartTime,
+		EndTime:        execution.EndTime,
+		Duration:       execution.Duration,
+		RunningContext: execution.RunningContext,
+		Steps:          []ComplianceReportStep{},
+		GeneratedAt:    generatedAt,
+	}
+
+	for _, result := range execution.StepResults {
+		step := ComplianceReportStep{Name: "unknown"}
+		if result.Step != nil {
+			step.Name = result.Step.FullName()
+		}
+
+		if result.Execution != nil {
+			step.Duration = result.Execution.Duration
+			if result.Execution.ExecutionResult != nil {
+				step.Status = result.Execution.ExecutionResult.Status
+				step.ErrorMessage = result.Execution.ExecutionResult.ErrorMessage
+			}
+
+			// delay steps aren't executed by executors
+			if result.Step != nil && result.Step.Type() == TestSuiteStepTypeExecuteTest {
+				step.ExecutionId = result.Execution.Id
+				step.Params = result.Execution.Params
+			}
+		}
+
+		report.Steps = append(report.Steps, step)
+	}
+
+	return report
+}
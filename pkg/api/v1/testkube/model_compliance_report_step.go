@@ -0,0 +1,27 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// result of test suite step in compliance report
+type ComplianceReportStep struct {
+	// step name
+	Name string `json:"name"`
+	// test execution id, empty for delay steps
+	ExecutionId string           `json:"executionId,omitempty"`
+	Status      *ExecutionStatus `json:"status,omitempty"`
+	// test execution duration
+	Duration string `json:"duration,omitempty"`
+	// test execution params
+	Params map[string]string `json:"params,omitempty"`
+	// error message of failed test execution
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// test execution artifacts with sizes and checksums
+	Artifacts []Artifact `json:"artifacts,omitempty"`
+}
@@ -13,4 +13,6 @@ package testkube
 type Config struct {
 	Id        string `json:"id"`
 	ClusterId string `json:"clusterId"`
+	// API server settings, defaults are used when not set
+	Settings *ServerSettings `json:"settings,omitempty"`
 }
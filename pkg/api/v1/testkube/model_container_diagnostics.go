@@ -0,0 +1,30 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// executor container status
+type ContainerDiagnostics struct {
+	// container name
+	Name string `json:"name"`
+	// container image
+	Image string `json:"image"`
+	// container state waiting, running or terminated
+	State string `json:"state"`
+	// state reason e.g. ImagePullBackOff or OOMKilled
+	Reason string `json:"reason,omitempty"`
+	// state message
+	Message string `json:"message,omitempty"`
+	// exit code of terminated container
+	ExitCode int32 `json:"exitCode,omitempty"`
+	// container restarts count
+	RestartCount int32 `json:"restartCount"`
+	// is container ready
+	Ready bool `json:"ready"`
+}
@@ -0,0 +1,28 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// options of fetching file URI test content
+type ContentUriOptions struct {
+	// name of kubernetes secret with file URI credentials
+	SecretName string `json:"secretName,omitempty"`
+	// secret key with basic auth username
+	UsernameKey string `json:"usernameKey,omitempty"`
+	// secret key with basic auth password
+	PasswordKey string `json:"passwordKey,omitempty"`
+	// HTTP header names with secret keys of their values
+	HeaderKeys map[string]string `json:"headerKeys,omitempty"`
+	// number of retries of failed requests, retries are delayed with exponential backoff
+	Retries int32 `json:"retries,omitempty"`
+	// maximal content size in bytes
+	MaxSize int64 `json:"maxSize,omitempty"`
+	// expected hex encoded SHA-256 checksum of content
+	Sha256 string `json:"sha256,omitempty"`
+}
@@ -0,0 +1,51 @@
+package testkube
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// MaxContentUriRetries is a maximal number of retries of failed file URI content requests
+const MaxContentUriRetries = 10
+
+// HasCredentials checks if file URI credentials are read from secret
+func (o *ContentUriOptions) HasCredentials() bool {
+	return o != nil && (o.UsernameKey != "" || o.PasswordKey != "" || len(o.HeaderKeys) > 0)
+}
+
+// ValidateContentUriOptions checks that file URI options are set only for file URI content and credentials
+// are read from secret
+func ValidateContentUriOptions(content *TestContent) error {
+	if content == nil || content.UriOptions == nil {
+		return nil
+	}
+
+	options := content.UriOptions
+	if TestContentType(content.Type_) != TestContentTypeFileURI {
+		return fmt.Errorf("uri options are supported only for %s content", TestContentTypeFileURI)
+	}
+
+	if options.HasCredentials() && options.SecretName == "" {
+		return fmt.Errorf("uri options secret name is required for credentials")
+	}
+
+	if (options.UsernameKey == "") != (options.PasswordKey == "") {
+		return fmt.Errorf("uri options basic auth requires both username and password keys")
+	}
+
+	if options.Retries < 0 || options.Retries > MaxContentUriRetries {
+		return fmt.Errorf("uri options retries must be between 0 and %d", MaxContentUriRetries)
+	}
+
+	if options.MaxSize < 0 {
+		return fmt.Errorf("uri options max size can't be negative")
+	}
+
+	if options.Sha256 != "" {
+		if checksum, err := hex.DecodeString(options.Sha256); err != nil || len(checksum) != 32 {
+			return fmt.Errorf("uri options sha256 must be hex encoded SHA-256 checksum")
+		}
+	}
+
+	return nil
+}
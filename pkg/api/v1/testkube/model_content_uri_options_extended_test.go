@@ -0,0 +1,33 @@
+package testkube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateContentUriOptions(t *testing.T) {
+	content := func(contentType string, options ContentUriOptions) *TestContent {
+		return &TestContent{Type_: contentType, Uri: "https://example.com/collection.json", UriOptions: &options}
+	}
+	fileURI := string(TestContentTypeFileURI)
+
+	assert.NoError(t, ValidateContentUriOptions(nil))
+	assert.NoError(t, ValidateContentUriOptions(&TestContent{Type_: fileURI}))
+	assert.NoError(t, ValidateContentUriOptions(content(fileURI, ContentUriOptions{
+		SecretName:  "credentials",
+		UsernameKey: "username",
+		PasswordKey: "password",
+		HeaderKeys:  map[string]string{"X-Api-Key": "key"},
+		Retries:     3,
+		MaxSize:     1024,
+		Sha256:      "4aa812d043cd10f9a49b836b07da2ca9b13f6c1a1a95f3bdc0916a7f7b7b148d",
+	})))
+
+	assert.Error(t, ValidateContentUriOptions(content(string(TestContentTypeString), ContentUriOptions{Retries: 1})))
+	assert.Error(t, ValidateContentUriOptions(content(fileURI, ContentUriOptions{UsernameKey: "username", PasswordKey: "password"})))
+	assert.Error(t, ValidateContentUriOptions(content(fileURI, ContentUriOptions{SecretName: "credentials", UsernameKey: "username"})))
+	assert.Error(t, ValidateContentUriOptions(content(fileURI, ContentUriOptions{Retries: MaxContentUriRetries + 1})))
+	assert.Error(t, ValidateContentUriOptions(content(fileURI, ContentUriOptions{MaxSize: -1})))
+	assert.Error(t, ValidateContentUriOptions(content(fileURI, ContentUriOptions{Sha256: "abc"})))
+}
@@ -0,0 +1,20 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// rates of requested resources execution costs are estimated with, zero rates estimate zero costs
+type CostRates struct {
+	// cost of requested CPU core per hour
+	CpuHour float64 `json:"cpuHour,omitempty"`
+	// cost of requested GB of memory per hour
+	MemoryGBHour float64 `json:"memoryGBHour,omitempty"`
+	// currency of rates
+	Currency string `json:"currency,omitempty"`
+}
@@ -0,0 +1,26 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// estimated cost of executions of test or label
+type CostRollup struct {
+	// test name or label as key=value
+	Name string `json:"name"`
+	// number of finished executions
+	Executions int32 `json:"executions"`
+	// number of executions without recorded resource requests or duration, they aren't part of the cost
+	UnpricedExecutions int32 `json:"unpricedExecutions,omitempty"`
+	// requested CPU core hours
+	CpuHours float64 `json:"cpuHours,omitempty"`
+	// requested memory GB hours
+	MemoryGBHours float64 `json:"memoryGBHours,omitempty"`
+	// estimated cost
+	Cost float64 `json:"cost"`
+}
@@ -0,0 +1,32 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+import (
+	"time"
+)
+
+// estimated costs of test executions
+type CostsReport struct {
+	// label selector used for executions selection
+	Selector string `json:"selector,omitempty"`
+	// report start time, executions started since then are taken into account
+	Since time.Time `json:"since"`
+	// report generation time
+	GeneratedAt time.Time `json:"generatedAt"`
+	// rates costs were estimated with
+	Rates *CostRates `json:"rates,omitempty"`
+	// estimated cost of all executions
+	Cost float64 `json:"cost"`
+	// costs per test sorted from the most expensive
+	Tests []CostRollup `json:"tests"`
+	// costs per execution label sorted from the most expensive
+	Labels []CostRollup `json:"labels"`
+}
@@ -0,0 +1,32 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+import (
+	"time"
+)
+
+// failed executions with the same normalized error message
+type ErrorCluster struct {
+	// normalized error message with ids, timestamps and numbers replaced by placeholders
+	Signature string `json:"signature"`
+	// error message of the newest execution
+	Message string `json:"message,omitempty"`
+	// number of failed executions
+	Count int32 `json:"count"`
+	// names of tests with the error
+	Tests []string `json:"tests,omitempty"`
+	// start time of the oldest execution
+	FirstSeen time.Time `json:"firstSeen,omitempty"`
+	// start time of the newest execution
+	LastSeen time.Time `json:"lastSeen,omitempty"`
+	// the newest executions with the error
+	Examples []ExecutionSummary `json:"examples,omitempty"`
+}
@@ -0,0 +1,28 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+import (
+	"time"
+)
+
+// top error signatures of failed executions
+type ErrorsReport struct {
+	// label selector used for executions selection
+	Selector string `json:"selector,omitempty"`
+	// report start time, executions started since then are taken into account
+	Since time.Time `json:"since"`
+	// report generation time
+	GeneratedAt time.Time `json:"generatedAt"`
+	// number of analyzed failed executions
+	Executions int32 `json:"executions"`
+	// error clusters sorted from the most frequent
+	Errors []ErrorCluster `json:"errors"`
+}
@@ -25,15 +25,28 @@ type Execution struct {
 	TestType string `json:"testType,omitempty"`
 	// execution name
 	Name string `json:"name,omitempty"`
+	// execution number of a test
+	Number int32 `json:"number,omitempty"`
 	// environment variables passed to executor
 	Envs map[string]string `json:"envs,omitempty"`
 	// additional arguments/flags passed to executor binary
 	Args []string `json:"args,omitempty"`
 	// execution params passed to executor converted to vars for usage in tests
 	Params map[string]string `json:"params,omitempty"`
+	// names of params with secret values redacted in logs, results and notifications
+	SecretParams []string `json:"secretParams,omitempty"`
 	// params file content - need to be in format for particular executor (e.g. postman envs file)
-	ParamsFile string       `json:"paramsFile,omitempty"`
-	Content    *TestContent `json:"content,omitempty"`
+	ParamsFile string `json:"paramsFile,omitempty"`
+	// additional files mounted into executor container
+	Files   []ExecutionFile `json:"files,omitempty"`
+	Content *TestContent    `json:"content,omitempty"`
+	// checksum of resolved test content, e.g. SHA-256 of string content, git commit SHA or file URI ETag
+	ContentChecksum string `json:"contentChecksum,omitempty"`
+	// whether test content checksum changed since previous execution of the test
+	ContentChanged bool `json:"contentChanged,omitempty"`
+	// iteration data file, runner runs one iteration per data row
+	DataFile *TestContent   `json:"dataFile,omitempty"`
+	Newman   *NewmanOptions `json:"newman,omitempty"`
 	// test start time
 	StartTime time.Time `json:"startTime,omitempty"`
 	// test end time
@@ -41,6 +54,32 @@ type Execution struct {
 	// test duration
 	Duration        string           `json:"duration,omitempty"`
 	ExecutionResult *ExecutionResult `json:"executionResult,omitempty"`
+	// project the execution is scoped to
+	Project string `json:"project,omitempty"`
+	// id of execution group the execution belongs to, e.g. of matrix executions
+	GroupId        string          `json:"groupId,omitempty"`
+	RunningContext *RunningContext `json:"runningContext,omitempty"`
 	// execution labels
 	Labels map[string]string `json:"labels,omitempty"`
+	// time execution was restored from archive, restored executions are archived again after archive period since restore
+	RestoredTime time.Time `json:"restoredTime,omitempty"`
+	// owner, team and contact of executed test
+	Ownership *Ownership `json:"ownership,omitempty"`
+	// scan results of execution artifacts, artifacts are downloadable once scanned
+	ArtifactScans []ArtifactScanResult `json:"artifactScans,omitempty"`
+	// executor image the execution ran with
+	ExecutorImage string `json:"executorImage,omitempty"`
+	// digest of executor image the execution ran with, e.g. sha256:4bcff639...
+	ExecutorImageDigest string                `json:"executorImageDigest,omitempty"`
+	Environment         *ExecutionEnvironment `json:"environment,omitempty"`
+	// id of execution rerun by this execution
+	RerunOf string `json:"rerunOf,omitempty"`
+	// id of API request which started the execution, passed in X-Request-ID header
+	RequestId string `json:"requestId,omitempty"`
+	// attempts of execution which executor pods were preempted, execution was rescheduled after each of them
+	Attempts []ExecutionAttempt `json:"attempts,omitempty"`
+	// timeline of execution status transitions
+	StatusHistory []ExecutionStatusTransition `json:"statusHistory,omitempty"`
+	// platform timings of execution, measured when execution finished
+	Timings *ExecutionTimings `json:"timings,omitempty"`
 }
@@ -0,0 +1,28 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+import (
+	"time"
+)
+
+// execution attempt which executor pod was preempted
+type ExecutionAttempt struct {
+	// attempt number starting from 1
+	Number int32 `json:"number"`
+	// name of preempted executor pod
+	PodName string `json:"podName,omitempty"`
+	// node the preempted pod ran on
+	NodeName string `json:"nodeName,omitempty"`
+	// preemption reason, e.g. PreemptionByScheduler, NodeShutdown or pod deleted from drained node
+	Reason string `json:"reason"`
+	// time preemption was detected
+	Time time.Time `json:"time,omitempty"`
+}
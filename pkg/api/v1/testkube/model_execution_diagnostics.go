@@ -0,0 +1,26 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// execution job and pods diagnostics
+type ExecutionDiagnostics struct {
+	// job name
+	JobName string `json:"jobName"`
+	// number of active job pods
+	Active int32 `json:"active,omitempty"`
+	// number of succeeded job pods
+	Succeeded int32 `json:"succeeded,omitempty"`
+	// number of failed job pods
+	Failed int32 `json:"failed,omitempty"`
+	// job pods
+	Pods []PodDiagnostics `json:"pods"`
+	// recent kubernetes events of job and its pods
+	Events []KubernetesEvent `json:"events"`
+}
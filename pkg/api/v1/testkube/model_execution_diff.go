@@ -0,0 +1,30 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// differences between test execution and base execution of the same test
+type ExecutionDiff struct {
+	// execution id
+	ExecutionId string `json:"executionId"`
+	// base execution id, previous execution of the test by default
+	BaseExecutionId string `json:"baseExecutionId"`
+	// checksum of execution test content
+	ContentChecksum string `json:"contentChecksum,omitempty"`
+	// checksum of base execution test content
+	BaseContentChecksum string `json:"baseContentChecksum,omitempty"`
+	// whether test content differs between executions
+	ContentChanged bool             `json:"contentChanged"`
+	Status         *ExecutionStatus `json:"status,omitempty"`
+	BaseStatus     *ExecutionStatus `json:"baseStatus,omitempty"`
+	// whether execution status differs between executions
+	StatusChanged bool `json:"statusChanged"`
+	// names of execution params added, removed or changed since base execution
+	ChangedParams []string `json:"changedParams,omitempty"`
+}
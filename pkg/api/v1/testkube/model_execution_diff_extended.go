@@ -0,0 +1,40 @@
+package testkube
+
+import "sort"
+
+// NewExecutionDiff compares test execution with base execution
+func NewExecutionDiff(execution, base Execution) ExecutionDiff {
+	diff := ExecutionDiff{
+		ExecutionId:         execution.Id,
+		BaseExecutionId:     base.Id,
+		ContentChecksum:     execution.ContentChecksum,
+		BaseContentChecksum: base.ContentChecksum,
+		ContentChanged:      execution.ContentChangedSince(base),
+	}
+
+	if execution.ExecutionResult != nil {
+		diff.Status = execution.ExecutionResult.Status
+	}
+
+	if base.ExecutionResult != nil {
+		diff.BaseStatus = base.ExecutionResult.Status
+	}
+
+	diff.StatusChanged = (diff.Status == nil) != (diff.BaseStatus == nil) ||
+		(diff.Status != nil && diff.BaseStatus != nil && *diff.Status != *diff.BaseStatus)
+
+	for name, value := range execution.Params {
+		if baseValue, ok := base.Params[name]; !ok || baseValue != value {
+			diff.ChangedParams = append(diff.ChangedParams, name)
+		}
+	}
+
+	for name := range base.Params {
+		if _, ok := execution.Params[name]; !ok {
+			diff.ChangedParams = append(diff.ChangedParams, name)
+		}
+	}
+
+	sort.Strings(diff.ChangedParams)
+	return diff
+}
@@ -0,0 +1,35 @@
+package testkube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExecutionDiff(t *testing.T) {
+	base := Execution{
+		Id:              "1",
+		ContentChecksum: "sha256:1",
+		Params:          map[string]string{"env": "dev", "user": "admin", "debug": "true"},
+		ExecutionResult: &ExecutionResult{Status: ExecutionStatusPassed},
+	}
+	execution := Execution{
+		Id:              "2",
+		ContentChecksum: "sha256:2",
+		Params:          map[string]string{"env": "prod", "user": "admin", "region": "eu"},
+		ExecutionResult: &ExecutionResult{Status: ExecutionStatusFailed},
+	}
+
+	diff := NewExecutionDiff(execution, base)
+
+	assert.Equal(t, "2", diff.ExecutionId)
+	assert.Equal(t, "1", diff.BaseExecutionId)
+	assert.True(t, diff.ContentChanged)
+	assert.True(t, diff.StatusChanged)
+	assert.Equal(t, []string{"debug", "env", "region"}, diff.ChangedParams)
+
+	diff = NewExecutionDiff(base, base)
+	assert.False(t, diff.ContentChanged)
+	assert.False(t, diff.StatusChanged)
+	assert.Empty(t, diff.ChangedParams)
+}
@@ -0,0 +1,26 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// environment execution pod ran in
+type ExecutionEnvironment struct {
+	// name of node execution pod was scheduled to
+	NodeName string `json:"nodeName,omitempty"`
+	// version of Kubernetes cluster execution ran in, e.g. v1.25.3
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// CPU requested by execution pod containers
+	CpuRequest string `json:"cpuRequest,omitempty"`
+	// memory requested by execution pod containers
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+	// CPU cores requested by execution pod containers, execution costs are aggregated from it
+	CpuRequestCores float64 `json:"cpuRequestCores,omitempty"`
+	// memory bytes requested by execution pod containers, execution costs are aggregated from it
+	MemoryRequestBytes int64 `json:"memoryRequestBytes,omitempty"`
+}
@@ -124,3 +124,9 @@ func (e Execution) IsFailed() bool {
 
 	return *e.ExecutionResult.Status == FAILED_ExecutionStatus
 }
+
+// ContentChangedSince checks if test content checksum differs from previous execution, content of executions
+// without checksum is treated as unchanged
+func (e Execution) ContentChangedSince(previous Execution) bool {
+	return e.ContentChecksum != "" && previous.ContentChecksum != "" && e.ContentChecksum != previous.ContentChecksum
+}
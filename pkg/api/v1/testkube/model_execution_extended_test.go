@@ -0,0 +1,16 @@
+package testkube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutionContentChangedSince(t *testing.T) {
+	execution := Execution{ContentChecksum: "git:2"}
+
+	assert.True(t, execution.ContentChangedSince(Execution{ContentChecksum: "git:1"}))
+	assert.False(t, execution.ContentChangedSince(Execution{ContentChecksum: "git:2"}))
+	assert.False(t, execution.ContentChangedSince(Execution{}), "previous execution without checksum")
+	assert.False(t, Execution{}.ContentChangedSince(execution), "execution without checksum")
+}
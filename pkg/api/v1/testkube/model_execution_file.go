@@ -0,0 +1,24 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// file mounted into executor container at run time, its content is inline or read from secret or config map key
+type ExecutionFile struct {
+	// file path inside executor container
+	Path string `json:"path"`
+	// inline file content
+	Content string `json:"content,omitempty"`
+	// kubernetes secret name with file content
+	SecretName string `json:"secretName,omitempty"`
+	// kubernetes config map name with file content
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// secret or config map key with file content
+	Key string `json:"key,omitempty"`
+}
@@ -0,0 +1,56 @@
+package testkube
+
+import (
+	"fmt"
+	"path"
+)
+
+// MaxInlineExecutionFilesSize is a maximal size of inline execution files content, they're stored in single secret
+const MaxInlineExecutionFilesSize = 1 << 20
+
+// IsInline checks if file content is passed inline
+func (f ExecutionFile) IsInline() bool {
+	return f.SecretName == "" && f.ConfigMapName == ""
+}
+
+// ValidateExecutionFiles checks that files have unique absolute paths and single content source
+func ValidateExecutionFiles(files []ExecutionFile) error {
+	paths := map[string]struct{}{}
+	size := 0
+	for _, file := range files {
+		if !path.IsAbs(file.Path) {
+			return fmt.Errorf("execution file path %q must be absolute", file.Path)
+		}
+
+		if _, ok := paths[path.Clean(file.Path)]; ok {
+			return fmt.Errorf("execution file path %s is duplicated", file.Path)
+		}
+		paths[path.Clean(file.Path)] = struct{}{}
+
+		if file.SecretName != "" && file.ConfigMapName != "" {
+			return fmt.Errorf("execution file %s must have either secret or config map source", file.Path)
+		}
+
+		if file.IsInline() {
+			if file.Key != "" {
+				return fmt.Errorf("execution file %s key requires secret or config map source", file.Path)
+			}
+			size += len(file.Content)
+			continue
+		}
+
+		if file.Content != "" {
+			return fmt.Errorf("execution file %s must have either inline content or secret or config map source", file.Path)
+		}
+
+		if file.Key == "" {
+			return fmt.Errorf("execution file %s key is required for secret and config map source", file.Path)
+		}
+	}
+
+	if size > MaxInlineExecutionFilesSize {
+		return fmt.Errorf("inline execution files size %d bytes exceeds %d bytes limit", size, MaxInlineExecutionFilesSize)
+	}
+
+	return nil
+}
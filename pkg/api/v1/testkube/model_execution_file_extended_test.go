@@ -0,0 +1,23 @@
+package testkube
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateExecutionFiles(t *testing.T) {
+	assert.NoError(t, ValidateExecutionFiles([]ExecutionFile{
+		{Path: "/etc/app/config.yaml", Content: "debug: true"},
+		{Path: "/etc/certs/ca.crt", SecretName: "tls", Key: "ca.crt"},
+		{Path: "/data/empty.txt"},
+	}))
+
+	assert.Error(t, ValidateExecutionFiles([]ExecutionFile{{Path: "config.yaml"}}), "relative path")
+	assert.Error(t, ValidateExecutionFiles([]ExecutionFile{{Path: "/a"}, {Path: "/b/../a"}}), "duplicated path")
+	assert.Error(t, ValidateExecutionFiles([]ExecutionFile{{Path: "/a", SecretName: "tls"}}), "missing key")
+	assert.Error(t, ValidateExecutionFiles([]ExecutionFile{{Path: "/a", SecretName: "tls", ConfigMapName: "data", Key: "a"}}), "two sources")
+	assert.Error(t, ValidateExecutionFiles([]ExecutionFile{{Path: "/a", Content: "a", SecretName: "tls", Key: "a"}}), "inline and secret content")
+	assert.Error(t, ValidateExecutionFiles([]ExecutionFile{{Path: "/a", Content: strings.Repeat("a", MaxInlineExecutionFilesSize+1)}}), "too large")
+}
@@ -0,0 +1,30 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+import (
+	"time"
+)
+
+// executions started together, e.g. matrix executions, with aggregate status
+type ExecutionGroup struct {
+	// execution group id
+	Id     string            `json:"id"`
+	Status *ExecutionStatus  `json:"status"`
+	Totals *ExecutionsTotals `json:"totals"`
+	// start time of the first execution
+	StartTime time.Time `json:"startTime,omitempty"`
+	// end time of the last execution, set when all executions are completed
+	EndTime time.Time `json:"endTime,omitempty"`
+	// time from start of the first execution to end of the last one, or to now while group is running
+	Duration string `json:"duration,omitempty"`
+	// executions of the group
+	Executions []ExecutionSummary `json:"executions"`
+}
@@ -0,0 +1,66 @@
+package testkube
+
+import (
+	"time"
+)
+
+// NewExecutionGroup returns execution group with aggregate status and duration of executions, group is running
+// until all executions are completed and it's passed only when all completed executions passed
+func NewExecutionGroup(id string, executions []ExecutionSummary) ExecutionGroup {
+	group := ExecutionGroup{
+		Id:         id,
+		Status:     ExecutionStatusPassed,
+		Totals:     &ExecutionsTotals{},
+		Executions: executions,
+	}
+
+	var running, failed bool
+	for _, execution := range executions {
+		group.Totals.Results++
+		if !execution.StartTime.IsZero() && (group.StartTime.IsZero() || execution.StartTime.Before(group.StartTime)) {
+			group.StartTime = execution.StartTime
+		}
+
+		if execution.EndTime.After(group.EndTime) {
+			group.EndTime = execution.EndTime
+		}
+
+		status := QUEUED_ExecutionStatus
+		if execution.Status != nil {
+			status = *execution.Status
+		}
+
+		switch status {
+		case QUEUED_ExecutionStatus:
+			group.Totals.Queued++
+			running = true
+		case RUNNING_ExecutionStatus:
+			group.Totals.Running++
+			running = true
+		case PASSED_ExecutionStatus:
+			group.Totals.Passed++
+		case SKIPPED_ExecutionStatus:
+		default:
+			group.Totals.Failed++
+			failed = true
+		}
+	}
+
+	switch {
+	case running:
+		group.Status = ExecutionStatusRunning
+		group.EndTime = time.Time{}
+	case failed:
+		group.Status = ExecutionStatusFailed
+	}
+
+	if !group.StartTime.IsZero() {
+		end := group.EndTime
+		if end.IsZero() {
+			end = time.Now()
+		}
+		group.Duration = end.Sub(group.StartTime).String()
+	}
+
+	return group
+}
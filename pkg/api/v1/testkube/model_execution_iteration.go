@@ -0,0 +1,23 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// result of test iteration run for data file row
+type ExecutionIteration struct {
+	// iteration number, starting from 1
+	Number int32 `json:"number"`
+	// data file row the iteration was run with
+	Data   map[string]string `json:"data,omitempty"`
+	Status *ExecutionStatus  `json:"status"`
+	// error message when iteration failed
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// iteration steps
+	Steps []ExecutionStepResult `json:"steps,omitempty"`
+}
@@ -0,0 +1,12 @@
+package testkube
+
+// NewExecutionIteration returns result of iteration run for data file row, iterations are numbered from 1
+func NewExecutionIteration(number int, data map[string]string, result ExecutionResult) ExecutionIteration {
+	return ExecutionIteration{
+		Number:       int32(number),
+		Data:         data,
+		Status:       result.Status,
+		ErrorMessage: result.ErrorMessage,
+		Steps:        result.Steps,
+	}
+}
@@ -0,0 +1,22 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// execution gating policy, test executions matching deny condition are rejected with policy message
+type ExecutionPolicy struct {
+	// policy name included in policy violations
+	Name string `json:"name"`
+	// condition rejecting execution, it can reference namespace, test, type, requester, labels.<name>, time.hour, time.weekday, running and running.test
+	Deny string `json:"deny"`
+	// policy violation message returned for rejected executions
+	Message string `json:"message,omitempty"`
+	// IANA timezone of time variables, UTC is used when not set
+	Timezone string `json:"timezone,omitempty"`
+}
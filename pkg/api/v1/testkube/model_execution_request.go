@@ -17,10 +17,16 @@ type ExecutionRequest struct {
 	Namespace string `json:"namespace,omitempty"`
 	// params file content - need to be in format for particular executor (e.g. postman envs file)
 	ParamsFile string `json:"paramsFile,omitempty"`
+	// additional files mounted into executor container
+	Files []ExecutionFile `json:"files,omitempty"`
 	// execution params passed to executor
 	Params map[string]string `json:"params,omitempty"`
+	// names of params with secret values merged with test secret params, the values are redacted in logs, results and notifications
+	SecretParams []string `json:"secretParams,omitempty"`
 	// additional executor binary arguments
 	Args []string `json:"args,omitempty"`
+	// params matrix, execution is started for each combination of param values with values merged into params
+	Matrix map[string][]string `json:"matrix,omitempty"`
 	// execution params passed to executor from secrets
 	SecretEnvs map[string]string `json:"secretEnvs,omitempty"`
 	// whether to start execution sync or async
@@ -29,4 +35,10 @@ type ExecutionRequest struct {
 	HttpProxy string `json:"httpProxy,omitempty"`
 	// https proxy for executor containers
 	HttpsProxy string `json:"httpsProxy,omitempty"`
+	// execution labels merged with test labels, passed to execution and executor job
+	Labels         map[string]string `json:"labels,omitempty"`
+	RunningContext *RunningContext   `json:"runningContext,omitempty"`
+	// executor image digest the execution is pinned to, e.g. sha256:4bcff639..., executor image is used with the digest
+	ExecutorImageDigest string         `json:"executorImageDigest,omitempty"`
+	Newman              *NewmanOptions `json:"newman,omitempty"`
 }
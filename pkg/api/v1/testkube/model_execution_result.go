@@ -16,8 +16,20 @@ type ExecutionResult struct {
 	Output string `json:"output,omitempty"`
 	// output type depends of reporter used in partucular tool
 	OutputType string `json:"outputType,omitempty"`
+	// object storing whole output when it's over inline size limit, output keeps its end then
+	OutputObject *StorageObject `json:"outputObject,omitempty"`
 	// error message when status is error, separate to output as output can be partial in case of error
 	ErrorMessage string `json:"errorMessage,omitempty"`
+	// error type when executor pod was terminated by kubernetes e.g. OOMKilled, Evicted or Preempted, PrecheckFailed or DependencyFailed when test prechecks or dependencies were not met, InvalidResult when runner result does not match its schema
+	ErrorType string `json:"errorType,omitempty"`
 	// execution steps (for collection of requests)
 	Steps []ExecutionStepResult `json:"steps,omitempty"`
+	// iterations run for rows of test data file
+	Iterations []ExecutionIteration `json:"iterations,omitempty"`
+	// named output variables emitted by runner (e.g. created resource ID), passed as params to next test suite steps
+	OutputVariables map[string]string `json:"outputVariables,omitempty"`
+	// key performance metrics reported by perf-oriented runners (e.g. p95_latency, error_rate), lower values are better
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+	// version of testing tool runner ran, e.g. newman 5.3.2
+	RunnerVersion string `json:"runnerVersion,omitempty"`
 }
@@ -1,5 +1,20 @@
 package testkube
 
+const (
+	// ErrorTypeOOMKilled is set when executor container was killed for exceeding memory limit
+	ErrorTypeOOMKilled = "OOMKilled"
+	// ErrorTypeEvicted is set when executor pod was evicted from node
+	ErrorTypeEvicted = "Evicted"
+	// ErrorTypePreempted is set when executor pod was preempted more times than execution can be rescheduled
+	ErrorTypePreempted = "Preempted"
+	// ErrorTypePrecheckFailed is set when execution was skipped as test prechecks weren't met within timeout
+	ErrorTypePrecheckFailed = "PrecheckFailed"
+	// ErrorTypeDependencyFailed is set when execution was skipped as tests it requires weren't passing
+	ErrorTypeDependencyFailed = "DependencyFailed"
+	// ErrorTypeInvalidResult is set when result emitted by runner doesn't match runner result schema
+	ErrorTypeInvalidResult = "InvalidResult"
+)
+
 func NewPendingExecutionResult() ExecutionResult {
 	return ExecutionResult{
 		Status: StatusPtr(RUNNING_ExecutionStatus),
@@ -26,7 +41,7 @@ func (e *ExecutionResult) Error() {
 }
 
 func (e *ExecutionResult) IsCompleted() bool {
-	return e.IsPassed() || e.IsFailed()
+	return e.IsPassed() || e.IsFailed() || e.IsAborted() || e.IsTimeout()
 }
 
 func (e *ExecutionResult) IsRunning() bool {
@@ -45,6 +60,18 @@ func (e *ExecutionResult) IsFailed() bool {
 	return *e.Status == FAILED_ExecutionStatus
 }
 
+func (e *ExecutionResult) IsAborted() bool {
+	return *e.Status == ABORTED_ExecutionStatus
+}
+
+func (e *ExecutionResult) IsSkipped() bool {
+	return *e.Status == SKIPPED_ExecutionStatus
+}
+
+func (e *ExecutionResult) IsTimeout() bool {
+	return *e.Status == TIMEOUT_ExecutionStatus
+}
+
 func (e *ExecutionResult) Err(err error) ExecutionResult {
 	e.Status = ExecutionStatusFailed
 	e.ErrorMessage = err.Error()
@@ -1,27 +0,0 @@
-/*
- * testkube
- *
- * Efficient testing of k8s applications mandates a k8s native approach to test mgmt/definition/execution - testkube provides a “quality control plane” that natively integrates testing activities into k8s development and operational workflows
- *
- * API version: 0.0.5
- * Contact: api@testkube.io
- * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
- */
-package testkube
-
-import (
-	"time"
-)
-
-// execution result data
-type ExecutionResultStep struct {
-	// step name
-	Name string `json:"name,omitempty"`
-	// test execution start time
-	StartTime time.Time `json:"startTime,omitempty"`
-	// test execution start time
-	EndTime time.Time `json:"endTime,omitempty"`
-	// execution step status
-	Status     string                        `json:"status,omitempty"`
-	Assertions *ExecutionResultStepAssertion `json:"assertions,omitempty"`
-}
@@ -1,17 +0,0 @@
-/*
- * testkube
- *
- * Efficient testing of k8s applications mandates a k8s native approach to test mgmt/definition/execution - testkube provides a “quality control plane” that natively integrates testing activities into k8s development and operational workflows
- *
- * API version: 0.0.5
- * Contact: api@testkube.io
- * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
- */
-package testkube
-
-// execution result data
-type ExecutionResultStepAssertion struct {
-	Name         string `json:"name,omitempty"`
-	Status       string `json:"status,omitempty"`
-	ErrorMessage string `json:"errorMessage,omitempty"`
-}
@@ -17,4 +17,7 @@ const (
 	RUNNING_ExecutionStatus ExecutionStatus = "running"
 	PASSED_ExecutionStatus  ExecutionStatus = "passed"
 	FAILED_ExecutionStatus  ExecutionStatus = "failed"
+	ABORTED_ExecutionStatus ExecutionStatus = "aborted"
+	TIMEOUT_ExecutionStatus ExecutionStatus = "timeout"
+	SKIPPED_ExecutionStatus ExecutionStatus = "skipped"
 )
@@ -13,6 +13,9 @@ var ExecutionStatusFailed = StatusPtr(FAILED_ExecutionStatus)
 var ExecutionStatusPassed = StatusPtr(PASSED_ExecutionStatus)
 var ExecutionStatusQueued = StatusPtr(QUEUED_ExecutionStatus)
 var ExecutionStatusRunning = StatusPtr(RUNNING_ExecutionStatus)
+var ExecutionStatusAborted = StatusPtr(ABORTED_ExecutionStatus)
+var ExecutionStatusTimeout = StatusPtr(TIMEOUT_ExecutionStatus)
+var ExecutionStatusSkipped = StatusPtr(SKIPPED_ExecutionStatus)
 
 // ExecutionStatuses is an array of ExecutionStatus
 type ExecutionStatuses []ExecutionStatus
@@ -34,6 +37,8 @@ func ParseExecutionStatusList(source, separator string) (statusList ExecutionSta
 		PASSED_ExecutionStatus:  {},
 		QUEUED_ExecutionStatus:  {},
 		RUNNING_ExecutionStatus: {},
+		ABORTED_ExecutionStatus: {},
+		TIMEOUT_ExecutionStatus: {},
 	}
 
 	if source == "" {
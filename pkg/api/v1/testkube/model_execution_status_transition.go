@@ -0,0 +1,23 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+import (
+	"time"
+)
+
+// execution status transition
+type ExecutionStatusTransition struct {
+	Status *ExecutionStatus `json:"status"`
+	// time execution status changed
+	Time time.Time `json:"time"`
+	// reason of status change, e.g. error message of failed execution
+	Reason string `json:"reason,omitempty"`
+}
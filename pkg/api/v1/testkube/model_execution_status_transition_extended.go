@@ -0,0 +1,38 @@
+package testkube
+
+import (
+	"strings"
+	"time"
+)
+
+// maxTransitionReasonLength is a length reasons of status transitions are truncated to, full error stays in result
+const maxTransitionReasonLength = 200
+
+// NewExecutionStatusTransition returns transition to result status, first line of error message is its reason
+func NewExecutionStatusTransition(result ExecutionResult, t time.Time) ExecutionStatusTransition {
+	reason, _, _ := strings.Cut(strings.TrimSpace(result.ErrorMessage), "\n")
+	if len(reason) > maxTransitionReasonLength {
+		reason = reason[:maxTransitionReasonLength] + "..."
+	}
+
+	return ExecutionStatusTransition{Status: result.Status, Time: t, Reason: reason}
+}
+
+// StatusDuration returns time execution spent in status according to its status history, last not completed
+// status lasts until now
+func (e Execution) StatusDuration(status ExecutionStatus) (duration time.Duration) {
+	for i, transition := range e.StatusHistory {
+		if transition.Status == nil || *transition.Status != status {
+			continue
+		}
+
+		switch {
+		case i+1 < len(e.StatusHistory):
+			duration += e.StatusHistory[i+1].Time.Sub(transition.Time)
+		case status == QUEUED_ExecutionStatus || status == RUNNING_ExecutionStatus:
+			duration += time.Since(transition.Time)
+		}
+	}
+
+	return duration
+}
@@ -0,0 +1,37 @@
+package testkube
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExecutionStatusTransition(t *testing.T) {
+	now := time.Now()
+	result := NewErrorExecutionResult(errors.New("newman exited with 1\nfull output"))
+	assert.Equal(t, ExecutionStatusTransition{Status: StatusPtr(FAILED_ExecutionStatus), Time: now, Reason: "newman exited with 1"},
+		NewExecutionStatusTransition(result, now))
+
+	result = NewErrorExecutionResult(errors.New(strings.Repeat("a", 300)))
+	assert.Len(t, NewExecutionStatusTransition(result, now).Reason, maxTransitionReasonLength+3)
+}
+
+func TestStatusDuration(t *testing.T) {
+	start := time.Date(2022, 6, 1, 8, 0, 0, 0, time.UTC)
+	execution := Execution{StatusHistory: []ExecutionStatusTransition{
+		{Status: StatusPtr(QUEUED_ExecutionStatus), Time: start},
+		{Status: StatusPtr(RUNNING_ExecutionStatus), Time: start.Add(time.Minute)},
+		{Status: StatusPtr(FAILED_ExecutionStatus), Time: start.Add(3 * time.Minute)},
+	}}
+
+	assert.Equal(t, time.Minute, execution.StatusDuration(QUEUED_ExecutionStatus))
+	assert.Equal(t, 2*time.Minute, execution.StatusDuration(RUNNING_ExecutionStatus))
+	assert.Zero(t, execution.StatusDuration(FAILED_ExecutionStatus))
+	assert.Zero(t, execution.StatusDuration(PASSED_ExecutionStatus))
+
+	execution.StatusHistory = execution.StatusHistory[:2]
+	assert.Greater(t, execution.StatusDuration(RUNNING_ExecutionStatus), 2*time.Minute, "running status lasts until now")
+}
@@ -19,6 +19,8 @@ type ExecutionSummary struct {
 	Id string `json:"id"`
 	// execution name
 	Name string `json:"name"`
+	// execution number of a test
+	Number int32 `json:"number,omitempty"`
 	// name of the test
 	TestName string `json:"testName"`
 	// name of the test
@@ -33,5 +35,10 @@ type ExecutionSummary struct {
 	// calculated test duration
 	Duration string `json:"duration,omitempty"`
 	// execution labels
-	Labels map[string]string `json:"labels,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	RunningContext *RunningContext   `json:"runningContext,omitempty"`
+	// checksum of resolved test content
+	ContentChecksum string `json:"contentChecksum,omitempty"`
+	// whether test content checksum changed since previous execution of the test
+	ContentChanged bool `json:"contentChanged,omitempty"`
 }
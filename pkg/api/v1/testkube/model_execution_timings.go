@@ -0,0 +1,20 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// execution platform timings, they tell time spent outside of test run
+type ExecutionTimings struct {
+	// time execution spent in queue before executor job was created
+	QueueDuration string `json:"queueDuration,omitempty"`
+	// time executor pod waited to be scheduled to node
+	SchedulingDuration string `json:"schedulingDuration,omitempty"`
+	// time executor init container of executor pod fetched test content
+	ContentFetchDuration string `json:"contentFetchDuration,omitempty"`
+}
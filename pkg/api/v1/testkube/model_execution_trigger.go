@@ -0,0 +1,20 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// test or test suite executed when execution of test or test suite with the trigger passes
+type ExecutionTrigger struct {
+	// type of triggered object, test or testsuite
+	Type_ string `json:"type"`
+	// name of triggered test or test suite
+	Name string `json:"name"`
+	// params passed to triggered execution, they override output variables of passed execution
+	Params map[string]string `json:"params,omitempty"`
+}
@@ -0,0 +1,94 @@
+package testkube
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// ExecutionTriggerTypeTest triggers test execution
+	ExecutionTriggerTypeTest = "test"
+	// ExecutionTriggerTypeTestSuite triggers test suite execution
+	ExecutionTriggerTypeTestSuite = "testsuite"
+)
+
+// ExecutionTriggerSource returns name of test or test suite in triggers chain, e.g. testsuite/smoke
+func ExecutionTriggerSource(triggerType, name string) string {
+	return triggerType + "/" + name
+}
+
+// String returns triggered test or test suite in triggers chain format
+func (t ExecutionTrigger) String() string {
+	return ExecutionTriggerSource(t.Type_, t.Name)
+}
+
+// Validate checks that trigger points to named test or test suite
+func (t ExecutionTrigger) Validate() error {
+	if t.Type_ != ExecutionTriggerTypeTest && t.Type_ != ExecutionTriggerTypeTestSuite {
+		return fmt.Errorf("trigger type should be %s or %s", ExecutionTriggerTypeTest, ExecutionTriggerTypeTestSuite)
+	}
+
+	if t.Name == "" {
+		return fmt.Errorf("triggered %s name can't be empty", t.Type_)
+	}
+
+	return nil
+}
+
+// ValidateExecutionTriggers checks that triggers are valid and don't trigger the same test or test suite twice
+func ValidateExecutionTriggers(triggers []ExecutionTrigger) error {
+	triggered := map[string]bool{}
+	for _, trigger := range triggers {
+		if err := trigger.Validate(); err != nil {
+			return err
+		}
+
+		if triggered[trigger.String()] {
+			return fmt.Errorf("duplicate trigger %s", trigger)
+		}
+		triggered[trigger.String()] = true
+	}
+
+	return nil
+}
+
+// ParseExecutionTrigger parses trigger in format [type/]name, triggered type is test when not set
+func ParseExecutionTrigger(value string) (ExecutionTrigger, error) {
+	trigger := ExecutionTrigger{Type_: ExecutionTriggerTypeTest, Name: value}
+	for _, triggerType := range []string{ExecutionTriggerTypeTest, ExecutionTriggerTypeTestSuite} {
+		if strings.HasPrefix(value, triggerType+"/") {
+			trigger = ExecutionTrigger{Type_: triggerType, Name: strings.TrimPrefix(value, triggerType+"/")}
+		}
+	}
+
+	return trigger, trigger.Validate()
+}
+
+// ExecutionTriggersFromAnnotations returns triggers stored in test or test suite annotations
+func ExecutionTriggersFromAnnotations(annotations map[string]string) (triggers []ExecutionTrigger) {
+	data := annotations[TriggersAnnotation]
+	if data == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &triggers); err != nil {
+		return nil
+	}
+
+	return triggers
+}
+
+// ExecutionTriggersAnnotations returns test or test suite annotations storing triggers
+func ExecutionTriggersAnnotations(triggers []ExecutionTrigger) map[string]string {
+	if len(triggers) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(triggers)
+	if err != nil {
+		return nil
+	}
+
+	return map[string]string{TriggersAnnotation: string(data)}
+}
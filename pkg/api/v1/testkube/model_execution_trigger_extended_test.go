@@ -0,0 +1,45 @@
+package testkube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExecutionTrigger(t *testing.T) {
+	trigger, err := ParseExecutionTrigger("testsuite/e2e")
+	require.NoError(t, err)
+	assert.Equal(t, ExecutionTrigger{Type_: ExecutionTriggerTypeTestSuite, Name: "e2e"}, trigger)
+	assert.Equal(t, "testsuite/e2e", trigger.String())
+
+	trigger, err = ParseExecutionTrigger("smoke")
+	require.NoError(t, err)
+	assert.Equal(t, ExecutionTrigger{Type_: ExecutionTriggerTypeTest, Name: "smoke"}, trigger)
+
+	_, err = ParseExecutionTrigger("test/")
+	assert.Error(t, err)
+}
+
+func TestValidateExecutionTriggers(t *testing.T) {
+	assert.NoError(t, ValidateExecutionTriggers([]ExecutionTrigger{{Type_: "test", Name: "smoke"}, {Type_: "testsuite", Name: "smoke"}}))
+
+	for name, triggers := range map[string][]ExecutionTrigger{
+		"unknown type":      {{Type_: "job", Name: "smoke"}},
+		"empty name":        {{Type_: "test"}},
+		"duplicate trigger": {{Type_: "test", Name: "smoke"}, {Type_: "test", Name: "smoke"}},
+	} {
+		assert.Error(t, ValidateExecutionTriggers(triggers), name)
+	}
+}
+
+func TestRunningContextWithTrigger(t *testing.T) {
+	runningContext := &RunningContext{Provider: "github-actions", TriggeredBy: []string{"test/api"}}
+	triggered := runningContext.WithTrigger("testsuite/smoke")
+
+	assert.Equal(t, &RunningContext{Provider: "github-actions", TriggeredBy: []string{"test/api", "testsuite/smoke"}}, triggered)
+	assert.Equal(t, []string{"test/api"}, runningContext.TriggeredBy, "running context of passed execution isn't changed")
+	assert.Equal(t, "github-actions triggered by test/api -> testsuite/smoke", triggered.String())
+
+	assert.Equal(t, &RunningContext{TriggeredBy: []string{"test/api"}}, (*RunningContext)(nil).WithTrigger("test/api"))
+}
@@ -0,0 +1,28 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+import (
+	"time"
+)
+
+// summary of executions finished in digest window
+type ExecutionsDigest struct {
+	// digest window start time
+	StartTime time.Time `json:"startTime,omitempty"`
+	// digest window end time
+	EndTime time.Time `json:"endTime,omitempty"`
+	// number of passed executions
+	Passed int32 `json:"passed,omitempty"`
+	// number of failed and timed out executions
+	Failed int32 `json:"failed,omitempty"`
+	// executions finished in digest window
+	Executions []ExecutionSummary `json:"executions,omitempty"`
+}
@@ -14,4 +14,6 @@ type ExecutionsResult struct {
 	Totals   *ExecutionsTotals  `json:"totals"`
 	Filtered *ExecutionsTotals  `json:"filtered,omitempty"`
 	Results  []ExecutionSummary `json:"results"`
+	// page of archived executions matching filter, returned when archived executions are requested
+	Archived []ExecutionSummary `json:"archived,omitempty"`
 }
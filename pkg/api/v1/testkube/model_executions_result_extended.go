@@ -1,19 +1,32 @@
 package testkube
 
+import "strconv"
+
 func (result ExecutionsResult) Table() (header []string, output [][]string) {
-	header = []string{"ID", "Name", "Type", "Status", "Labels"}
+	header = []string{"ID", "Name", "Number", "Type", "Status", "Labels", "Context", "Content"}
 
 	for _, e := range result.Results {
 		var status string
 		if e.Status != nil {
 			status = string(*e.Status)
 		}
+		var number string
+		if e.Number > 0 {
+			number = "#" + strconv.Itoa(int(e.Number))
+		}
+		var content string
+		if e.ContentChanged {
+			content = "changed"
+		}
 		output = append(output, []string{
 			e.Id,
 			e.TestName,
+			number,
 			e.TestType,
 			status,
 			LabelsToString(e.Labels),
+			e.RunningContext.String(),
+			content,
 		})
 	}
 
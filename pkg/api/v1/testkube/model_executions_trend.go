@@ -0,0 +1,25 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+import (
+	"time"
+)
+
+// time bucketed test executions statistics
+type ExecutionsTrend struct {
+	TestName string `json:"testName"`
+	// bucket interval e.g. hour, day or week
+	Interval string `json:"interval"`
+	// trend start time, executions started since then are taken into account
+	Since time.Time `json:"since"`
+	// buckets sorted from the oldest, buckets without executions are included
+	Buckets []ExecutionsTrendBucket `json:"buckets"`
+}
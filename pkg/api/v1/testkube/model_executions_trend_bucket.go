@@ -0,0 +1,33 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+import (
+	"time"
+)
+
+// executions started in bucket interval
+type ExecutionsTrendBucket struct {
+	// bucket start time
+	StartTime time.Time `json:"startTime"`
+	Total     int32     `json:"total"`
+	Passed    int32     `json:"passed"`
+	Failed    int32     `json:"failed"`
+	Timeout   int32     `json:"timeout"`
+	Aborted   int32     `json:"aborted"`
+	Running   int32     `json:"running"`
+	Queued    int32     `json:"queued"`
+	// median duration of finished executions in milliseconds
+	DurationP50 int64 `json:"durationP50,omitempty"`
+	// 90th percentile duration of finished executions in milliseconds
+	DurationP90 int64 `json:"durationP90,omitempty"`
+	// 99th percentile duration of finished executions in milliseconds
+	DurationP99 int64 `json:"durationP99,omitempty"`
+}
@@ -22,5 +22,6 @@ type Executor struct {
 	// Job template to launch executor
 	JobTemplate string `json:"jobTemplate,omitempty"`
 	// executor labels
-	Labels map[string]string `json:"labels,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Placement *Placement        `json:"placement,omitempty"`
 }
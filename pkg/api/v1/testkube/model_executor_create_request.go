@@ -26,5 +26,6 @@ type ExecutorCreateRequest struct {
 	// Job template to launch executor
 	JobTemplate string `json:"jobTemplate,omitempty"`
 	// executor labels
-	Labels map[string]string `json:"labels,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Placement *Placement        `json:"placement,omitempty"`
 }
@@ -13,7 +13,11 @@ package testkube
 type ExecutorOutput struct {
 	// One of possible output types
 	Type_ string `json:"type"`
-	// Message/event data passed from executor (like log lines etc)
+	// Output variable name, for variable output type
+	Name string `json:"name,omitempty"`
+	// Message/event data passed from executor (like log lines etc), output variable value for variable output type
 	Content string           `json:"content,omitempty"`
 	Result  *ExecutionResult `json:"result,omitempty"`
+	// version of runner result schema, for result output type, v1 when empty
+	Version string `json:"version,omitempty"`
 }
@@ -0,0 +1,20 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// features enabled in API server
+type Features struct {
+	// is artifacts storage configured
+	ArtifactStorage bool `json:"artifactStorage"`
+	// enabled execution events notifiers
+	Notifiers []string `json:"notifiers,omitempty"`
+	// authentication mode of API
+	AuthMode string `json:"authMode,omitempty"`
+}
@@ -0,0 +1,22 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// API server dependency check result
+type HealthCheck struct {
+	// checked dependency name
+	Name string `json:"name"`
+	// check status ok or failed
+	Status string `json:"status"`
+	// check duration
+	Latency string `json:"latency"`
+	// check error
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,22 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// API server dependencies health report
+type HealthReport struct {
+	// overall status, failed when any of checks failed
+	Status string `json:"status"`
+	// dependency checks
+	Checks []HealthCheck `json:"checks"`
+	// number of events waiting in events emitter queue
+	EventsQueueDepth int32 `json:"eventsQueueDepth"`
+	// events emitter queue capacity
+	EventsQueueCapacity int32 `json:"eventsQueueCapacity"`
+}
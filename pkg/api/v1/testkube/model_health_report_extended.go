@@ -0,0 +1,11 @@
+package testkube
+
+const (
+	HealthStatusOK     = "ok"
+	HealthStatusFailed = "failed"
+)
+
+// IsHealthy checks if all dependencies are healthy
+func (r HealthReport) IsHealthy() bool {
+	return r.Status == HealthStatusOK
+}
@@ -0,0 +1,30 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+import (
+	"time"
+)
+
+// kubernetes event
+type KubernetesEvent struct {
+	// event type Normal or Warning
+	Type_ string `json:"type"`
+	// event reason
+	Reason string `json:"reason"`
+	// event message
+	Message string `json:"message"`
+	// kind and name of involved object
+	Object string `json:"object"`
+	// number of event occurrences
+	Count int32 `json:"count,omitempty"`
+	// last event occurrence time
+	LastTimestamp time.Time `json:"lastTimestamp,omitempty"`
+}
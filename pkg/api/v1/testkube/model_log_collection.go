@@ -0,0 +1,20 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// logs of application pods collected during execution window and stored as app-logs.txt execution artifact
+type LogCollection struct {
+	// label selector of application pods
+	Selector string `json:"selector"`
+	// namespace of application pods, Testkube namespace by default
+	Namespace string `json:"namespace,omitempty"`
+	// names of containers logs are collected from, all containers by default
+	Containers []string `json:"containers,omitempty"`
+}
@@ -0,0 +1,71 @@
+package testkube
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// AppLogsArtifact is a name of execution artifact with collected application pods logs
+const AppLogsArtifact = "app-logs.txt"
+
+// Validate checks that log collection selects application pods by valid non empty label selector
+func (l *LogCollection) Validate() error {
+	if l == nil {
+		return nil
+	}
+
+	if l.Selector == "" {
+		return fmt.Errorf("log collection selector can't be empty")
+	}
+
+	if _, err := labels.Parse(l.Selector); err != nil {
+		return fmt.Errorf("invalid log collection selector %q: %w", l.Selector, err)
+	}
+
+	return nil
+}
+
+// IsCollectedContainer checks if logs of container are collected
+func (l LogCollection) IsCollectedContainer(name string) bool {
+	if len(l.Containers) == 0 {
+		return true
+	}
+
+	for _, container := range l.Containers {
+		if container == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LogCollectionFromAnnotations returns log collection stored in test annotations
+func LogCollectionFromAnnotations(annotations map[string]string) (logCollection *LogCollection) {
+	data := annotations[LogCollectionAnnotation]
+	if data == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &logCollection); err != nil {
+		return nil
+	}
+
+	return logCollection
+}
+
+// LogCollectionAnnotations returns test annotations storing log collection
+func LogCollectionAnnotations(logCollection *LogCollection) map[string]string {
+	if logCollection == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(logCollection)
+	if err != nil {
+		return nil
+	}
+
+	return map[string]string{LogCollectionAnnotation: string(data)}
+}
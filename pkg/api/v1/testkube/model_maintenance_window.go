@@ -0,0 +1,30 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+import (
+	"time"
+)
+
+// window of planned downtime when scheduled executions are skipped, set by cron schedule and duration or by calendar range
+type MaintenanceWindow struct {
+	// window name included in skipped executions
+	Name string `json:"name,omitempty"`
+	// cron expression of window starts, evaluated in window timezone
+	Schedule string `json:"schedule,omitempty"`
+	// IANA timezone of schedule, UTC is used when not set
+	Timezone string `json:"timezone,omitempty"`
+	// window length after every schedule start
+	Duration string `json:"duration,omitempty"`
+	// start time of calendar range window
+	Start time.Time `json:"start,omitempty"`
+	// end time of calendar range window
+	End time.Time `json:"end,omitempty"`
+}
@@ -0,0 +1,193 @@
+package testkube
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron"
+)
+
+// MaintenanceReason is an error message of scheduled executions skipped in maintenance window
+const MaintenanceReason = "maintenance window"
+
+var maintenanceScheduleParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// Validate checks that window is set either by schedule and duration or by start and end time
+func (w MaintenanceWindow) Validate() error {
+	if w.Schedule != "" {
+		if !w.Start.IsZero() || !w.End.IsZero() {
+			return fmt.Errorf("maintenance window can't have both schedule and calendar range")
+		}
+
+		if _, err := maintenanceScheduleParser.Parse(w.Schedule); err != nil {
+			return fmt.Errorf("invalid maintenance window schedule %q: %w", w.Schedule, err)
+		}
+
+		if _, err := time.LoadLocation(w.Timezone); err != nil {
+			return fmt.Errorf("invalid maintenance window timezone %q: %w", w.Timezone, err)
+		}
+
+		duration, err := time.ParseDuration(w.Duration)
+		if err != nil {
+			return fmt.Errorf("invalid maintenance window duration %q: %w", w.Duration, err)
+		}
+
+		if duration <= 0 {
+			return fmt.Errorf("maintenance window duration should be positive")
+		}
+
+		return nil
+	}
+
+	if w.Start.IsZero() || w.End.IsZero() {
+		return fmt.Errorf("maintenance window needs schedule and duration or start and end time")
+	}
+
+	if !w.End.After(w.Start) {
+		return fmt.Errorf("maintenance window end should be after start")
+	}
+
+	return nil
+}
+
+// IsActive checks if time is in maintenance window, invalid windows are never active
+func (w MaintenanceWindow) IsActive(t time.Time) bool {
+	_, active := w.ActiveUntil(t)
+	return active
+}
+
+// ActiveUntil returns end of window occurrence active at time, invalid windows are never active
+func (w MaintenanceWindow) ActiveUntil(t time.Time) (time.Time, bool) {
+	if w.Schedule == "" {
+		return w.End, !w.Start.IsZero() && !t.Before(w.Start) && t.Before(w.End)
+	}
+
+	schedule, err := maintenanceScheduleParser.Parse(w.Schedule)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	duration, err := time.ParseDuration(w.Duration)
+	if err != nil || duration <= 0 {
+		return time.Time{}, false
+	}
+
+	location, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	// window is active when it started less than duration ago
+	start := schedule.Next(t.In(location).Add(-duration))
+	return start.Add(duration), !start.After(t)
+}
+
+// Reason returns error message of executions skipped in window
+func (w MaintenanceWindow) Reason() string {
+	if w.Name == "" {
+		return MaintenanceReason
+	}
+
+	return fmt.Sprintf("%s %s", MaintenanceReason, w.Name)
+}
+
+// String returns window in form accepted by ParseMaintenanceWindow
+func (w MaintenanceWindow) String() string {
+	if w.Schedule != "" {
+		return w.Schedule + " " + w.Duration
+	}
+
+	return w.Start.Format(time.RFC3339) + "/" + w.End.Format(time.RFC3339)
+}
+
+// ActiveMaintenanceWindow returns first window active at time, nil is returned when no window is active
+func ActiveMaintenanceWindow(t time.Time, windows ...[]MaintenanceWindow) *MaintenanceWindow {
+	for _, items := range windows {
+		for i := range items {
+			if items[i].IsActive(t) {
+				return &items[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// MaintenanceWindowsEnd returns latest end of windows active at time, zero time is returned when no window is active
+func MaintenanceWindowsEnd(t time.Time, windows ...[]MaintenanceWindow) (end time.Time) {
+	for _, items := range windows {
+		for _, window := range items {
+			if until, active := window.ActiveUntil(t); active && until.After(end) {
+				end = until
+			}
+		}
+	}
+
+	return end
+}
+
+// ValidateMaintenanceWindows checks all maintenance windows
+func ValidateMaintenanceWindows(windows []MaintenanceWindow) error {
+	for _, window := range windows {
+		if err := window.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParseMaintenanceWindow parses maintenance window in "<cron schedule> <duration>" form, e.g. "0 2 * * 6 4h",
+// or calendar range in "<RFC3339 start>/<RFC3339 end>" form
+func ParseMaintenanceWindow(value string) (window MaintenanceWindow, err error) {
+	if start, end, ok := strings.Cut(value, "/"); ok {
+		if window.Start, err = time.Parse(time.RFC3339, strings.TrimSpace(start)); err != nil {
+			return window, fmt.Errorf("invalid maintenance window start: %w", err)
+		}
+
+		if window.End, err = time.Parse(time.RFC3339, strings.TrimSpace(end)); err != nil {
+			return window, fmt.Errorf("invalid maintenance window end: %w", err)
+		}
+
+		return window, window.Validate()
+	}
+
+	fields := strings.Fields(value)
+	if len(fields) < 2 {
+		return window, fmt.Errorf("maintenance window %q should be cron schedule with duration or calendar range", value)
+	}
+
+	window.Schedule = strings.Join(fields[:len(fields)-1], " ")
+	window.Duration = fields[len(fields)-1]
+	return window, window.Validate()
+}
+
+// MaintenanceWindowsFromAnnotations returns maintenance windows stored in test annotations
+func MaintenanceWindowsFromAnnotations(annotations map[string]string) (windows []MaintenanceWindow) {
+	data := annotations[MaintenanceWindowsAnnotation]
+	if data == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &windows); err != nil {
+		return nil
+	}
+
+	return windows
+}
+
+// MaintenanceWindowsAnnotations returns test annotations storing maintenance windows
+func MaintenanceWindowsAnnotations(windows []MaintenanceWindow) map[string]string {
+	if len(windows) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(windows)
+	if err != nil {
+		return nil
+	}
+
+	return map[string]string{MaintenanceWindowsAnnotation: string(data)}
+}
@@ -0,0 +1,71 @@
+package testkube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceWindowIsActive(t *testing.T) {
+	saturday := time.Date(2022, 7, 16, 3, 0, 0, 0, time.UTC)
+
+	t.Run("cron window", func(t *testing.T) {
+		window := MaintenanceWindow{Schedule: "0 2 * * 6", Duration: "2h"}
+
+		assert.True(t, window.IsActive(saturday))
+		assert.True(t, window.IsActive(saturday.Add(-time.Hour)), "window start is included")
+		assert.False(t, window.IsActive(saturday.Add(time.Hour)), "window end isn't included")
+		assert.False(t, window.IsActive(saturday.Add(24*time.Hour)))
+	})
+
+	t.Run("cron window in timezone", func(t *testing.T) {
+		window := MaintenanceWindow{Schedule: "0 4 * * 6", Duration: "2h", Timezone: "Europe/Warsaw"}
+
+		assert.NoError(t, window.Validate())
+		assert.True(t, window.IsActive(saturday), "04:00 CEST is 02:00 UTC")
+		assert.False(t, window.IsActive(saturday.Add(time.Hour)))
+	})
+
+	t.Run("quiet hours end", func(t *testing.T) {
+		nights := []MaintenanceWindow{{Schedule: "0 22 * * *", Duration: "9h"}}
+		weekend := []MaintenanceWindow{{Schedule: "0 0 * * 6", Duration: "48h"}}
+
+		assert.Equal(t, time.Date(2022, 7, 16, 7, 0, 0, 0, time.UTC), MaintenanceWindowsEnd(saturday, nights).UTC())
+		assert.Equal(t, time.Date(2022, 7, 18, 0, 0, 0, 0, time.UTC), MaintenanceWindowsEnd(saturday, nights, weekend).UTC())
+		assert.True(t, MaintenanceWindowsEnd(saturday.Add(5*time.Hour), nights).IsZero())
+	})
+
+	t.Run("calendar window", func(t *testing.T) {
+		window := MaintenanceWindow{Start: saturday, End: saturday.Add(time.Hour)}
+
+		assert.True(t, window.IsActive(saturday))
+		assert.False(t, window.IsActive(saturday.Add(time.Hour)))
+		assert.False(t, window.IsActive(saturday.Add(-time.Minute)))
+	})
+
+	t.Run("first active window", func(t *testing.T) {
+		global := []MaintenanceWindow{{Name: "upgrade", Start: saturday.Add(time.Hour), End: saturday.Add(2 * time.Hour)}}
+		test := []MaintenanceWindow{{Name: "backup", Schedule: "0 2 * * 6", Duration: "2h"}}
+
+		assert.Equal(t, "backup", ActiveMaintenanceWindow(saturday, global, test).Name)
+		assert.Nil(t, ActiveMaintenanceWindow(saturday.Add(3*time.Hour), global, test))
+	})
+}
+
+func TestParseMaintenanceWindow(t *testing.T) {
+	window, err := ParseMaintenanceWindow("0 2 * * 6 4h")
+	assert.NoError(t, err)
+	assert.Equal(t, MaintenanceWindow{Schedule: "0 2 * * 6", Duration: "4h"}, window)
+	assert.Equal(t, "0 2 * * 6 4h", window.String())
+
+	window, err = ParseMaintenanceWindow("2022-07-16T02:00:00Z/2022-07-16T06:00:00Z")
+	assert.NoError(t, err)
+	assert.Equal(t, 4*time.Hour, window.End.Sub(window.Start))
+	assert.Equal(t, "2022-07-16T02:00:00Z/2022-07-16T06:00:00Z", window.String())
+
+	for _, value := range []string{"0 2 * * 6", "0 2 * * 6 -1h", "61 2 * * 6 1h", "2022-07-16T06:00:00Z/2022-07-16T02:00:00Z"} {
+		_, err = ParseMaintenanceWindow(value)
+		assert.Error(t, err, value)
+	}
+}
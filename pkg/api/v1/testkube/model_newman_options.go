@@ -0,0 +1,27 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// newman options of postman collection tests
+type NewmanOptions struct {
+	EnvironmentFile *TestContent `json:"environmentFile,omitempty"`
+	GlobalsFile     *TestContent `json:"globalsFile,omitempty"`
+	// names or IDs of collection folders or requests to run, all collection items are run when empty
+	Folders []string `json:"folders,omitempty"`
+	// number of collection iterations, newman runs 1 iteration when not set
+	Iterations int32 `json:"iterations,omitempty"`
+	// whether to stop run on first test failure or request error, execution request overrides test value when set
+	Bail *bool `json:"bail,omitempty"`
+	// timeout of collection requests, e.g. 30s, requests don't time out when not set
+	TimeoutRequest string `json:"timeoutRequest,omitempty"`
+	// whether to disable SSL verification of requests, execution request overrides test value when set
+	Insecure      *bool                `json:"insecure,omitempty"`
+	SslClientCert *NewmanSslClientCert `json:"sslClientCert,omitempty"`
+}
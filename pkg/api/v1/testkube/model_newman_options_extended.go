@@ -0,0 +1,309 @@
+package testkube
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// newman params override newman options, they aren't passed to postman environment
+const (
+	// NewmanFolderParam is an execution param with comma separated collection folders to run
+	NewmanFolderParam = "newman.folder"
+	// NewmanIterationsParam is an execution param with number of collection iterations
+	NewmanIterationsParam = "newman.iterations"
+	// NewmanBailParam is an execution param stopping run on first failure when true
+	NewmanBailParam = "newman.bail"
+	// NewmanTimeoutRequestParam is an execution param with timeout of collection requests, e.g. 30s
+	NewmanTimeoutRequestParam = "newman.timeout-request"
+	// NewmanInsecureParam is an execution param disabling SSL verification of requests when true
+	NewmanInsecureParam = "newman.insecure"
+)
+
+// NewmanSslClientPassphraseParam is a secret execution param with SSL client private key passphrase, newman reads
+// the passphrase only from its command line, so the param makes it redacted in logged newman command and output
+const NewmanSslClientPassphraseParam = "newman.ssl-client-passphrase"
+
+// NewmanParams are execution params overriding newman options
+var NewmanParams = []string{NewmanFolderParam, NewmanIterationsParam, NewmanBailParam, NewmanTimeoutRequestParam, NewmanInsecureParam}
+
+const (
+	// NewmanSslClientCertPath, NewmanSslClientKeyPath and NewmanSslClientPassphrasePath are paths SSL client
+	// certificate secret keys are mounted to in executor container
+	NewmanSslClientCertPath       = "/etc/testkube/newman/ssl-client-cert.pem"
+	NewmanSslClientKeyPath        = "/etc/testkube/newman/ssl-client-key.pem"
+	NewmanSslClientPassphrasePath = "/etc/testkube/newman/ssl-client-passphrase"
+)
+
+// NewmanInlineFilesLimitBytes is a limit of inline environment and globals files, options are stored in test
+// annotation and annotations of an object can't exceed 256KiB in total
+const NewmanInlineFilesLimitBytes = 128 * 1024
+
+// Validate checks that environment and globals files are single files
+func (o *NewmanOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	inline := 0
+	for _, file := range []*TestContent{o.EnvironmentFile, o.GlobalsFile} {
+		if file != nil && TestContentType(file.Type_) == TestContentTypeString {
+			inline += len(file.Data)
+		}
+	}
+
+	if inline > NewmanInlineFilesLimitBytes {
+		return fmt.Errorf("inline newman environment and globals files have %d bytes, they can't exceed %d bytes as they're "+
+			"stored in test annotation, use %s or %s files instead", inline, NewmanInlineFilesLimitBytes, TestContentTypeFileURI, TestContentTypeGitFile)
+	}
+
+	if err := validateNewmanFile("environment", o.EnvironmentFile); err != nil {
+		return err
+	}
+
+	if err := validateNewmanFile("globals", o.GlobalsFile); err != nil {
+		return err
+	}
+
+	for _, folder := range o.Folders {
+		if strings.TrimSpace(folder) == "" {
+			return fmt.Errorf("newman folder can't be empty")
+		}
+	}
+
+	if o.Iterations < 0 {
+		return fmt.Errorf("newman iterations should be positive")
+	}
+
+	if o.TimeoutRequest != "" {
+		timeout, err := time.ParseDuration(o.TimeoutRequest)
+		if err != nil {
+			return fmt.Errorf("invalid newman request timeout %q: %w", o.TimeoutRequest, err)
+		}
+
+		if timeout <= 0 {
+			return fmt.Errorf("newman request timeout should be positive")
+		}
+	}
+
+	if o.SslClientCert != nil && o.SslClientCert.SecretName == "" {
+		return fmt.Errorf("newman SSL client certificate secret name can't be empty")
+	}
+
+	return nil
+}
+
+// SecretMounts returns secret mounts of newman options secret keys mounted into executor container
+func (o *NewmanOptions) SecretMounts() []SecretMount {
+	if o == nil {
+		return nil
+	}
+
+	return o.SslClientCert.SecretMounts()
+}
+
+// SecretMounts returns secret mounts of SSL client certificate secret keys
+func (c *NewmanSslClientCert) SecretMounts() []SecretMount {
+	if c == nil {
+		return nil
+	}
+
+	certKey, keyKey := c.CertKey, c.KeyKey
+	if certKey == "" {
+		certKey = "tls.crt"
+	}
+
+	if keyKey == "" {
+		keyKey = "tls.key"
+	}
+
+	mounts := []SecretMount{
+		{SecretName: c.SecretName, Key: certKey, MountPath: NewmanSslClientCertPath},
+		{SecretName: c.SecretName, Key: keyKey, MountPath: NewmanSslClientKeyPath},
+	}
+	if c.PassphraseKey != "" {
+		mounts = append(mounts, SecretMount{SecretName: c.SecretName, Key: c.PassphraseKey, MountPath: NewmanSslClientPassphrasePath})
+	}
+
+	return mounts
+}
+
+func validateNewmanFile(name string, file *TestContent) error {
+	if file == nil {
+		return nil
+	}
+
+	switch TestContentType(file.Type_) {
+	case TestContentTypeString, TestContentTypeFileURI:
+		return nil
+	case TestContentTypeGitFile:
+		if file.Repository == nil {
+			return fmt.Errorf("newman %s file repository is not set", name)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported newman %s file type %s, supported types are %s, %s and %s", name, file.Type_,
+			TestContentTypeString, TestContentTypeFileURI, TestContentTypeGitFile)
+	}
+}
+
+// MergeNewmanOptions returns test newman options with options set in execution request applied on top of them
+func MergeNewmanOptions(options, override *NewmanOptions) *NewmanOptions {
+	if override == nil {
+		return options
+	}
+
+	if options == nil {
+		return override
+	}
+
+	merged := *options
+	if override.EnvironmentFile != nil {
+		merged.EnvironmentFile = override.EnvironmentFile
+	}
+
+	if override.GlobalsFile != nil {
+		merged.GlobalsFile = override.GlobalsFile
+	}
+
+	if len(override.Folders) > 0 {
+		merged.Folders = override.Folders
+	}
+
+	if override.Iterations != 0 {
+		merged.Iterations = override.Iterations
+	}
+
+	if override.TimeoutRequest != "" {
+		merged.TimeoutRequest = override.TimeoutRequest
+	}
+
+	if override.SslClientCert != nil {
+		merged.SslClientCert = override.SslClientCert
+	}
+
+	if override.Bail != nil {
+		merged.Bail = override.Bail
+	}
+
+	if override.Insecure != nil {
+		merged.Insecure = override.Insecure
+	}
+
+	return &merged
+}
+
+// NewmanOptionsFromParams returns newman options with newman params applied, remaining params are returned
+// without newman params, so they aren't passed to postman environment
+func NewmanOptionsFromParams(options *NewmanOptions, params map[string]string) (*NewmanOptions, map[string]string, error) {
+	remaining := make(map[string]string, len(params))
+	values := map[string]string{}
+	for k, v := range params {
+		if isNewmanParam(k) {
+			values[k] = strings.TrimSpace(v)
+			continue
+		}
+		remaining[k] = v
+	}
+
+	if len(values) == 0 {
+		return options, params, options.Validate()
+	}
+
+	merged := NewmanOptions{}
+	if options != nil {
+		merged = *options
+	}
+
+	if folder, ok := values[NewmanFolderParam]; ok {
+		merged.Folders = nil
+		for _, name := range strings.Split(folder, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				merged.Folders = append(merged.Folders, name)
+			}
+		}
+	}
+
+	if value, ok := values[NewmanIterationsParam]; ok {
+		iterations, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || iterations < 1 {
+			return nil, nil, fmt.Errorf("invalid %s param %q, it should be positive number", NewmanIterationsParam, value)
+		}
+		merged.Iterations = int32(iterations)
+	}
+
+	if value, ok := values[NewmanTimeoutRequestParam]; ok {
+		merged.TimeoutRequest = value
+	}
+
+	for name, flag := range map[string]**bool{NewmanBailParam: &merged.Bail, NewmanInsecureParam: &merged.Insecure} {
+		value, ok := values[name]
+		if !ok {
+			continue
+		}
+
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid %s param %q, it should be true or false", name, value)
+		}
+		*flag = &enabled
+	}
+
+	return &merged, remaining, merged.Validate()
+}
+
+func isNewmanParam(name string) bool {
+	for _, param := range NewmanParams {
+		if name == param {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewmanOptionsFromAnnotations returns newman options stored in test annotations
+func NewmanOptionsFromAnnotations(annotations map[string]string) (options *NewmanOptions) {
+	data := annotations[NewmanOptionsAnnotation]
+	if data == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &options); err != nil {
+		return nil
+	}
+
+	return options
+}
+
+// NewmanOptionsAnnotations returns test annotations storing newman options, git credentials aren't stored
+// in annotations, so files of private repositories are fetched with test git credentials
+func NewmanOptionsAnnotations(options *NewmanOptions) map[string]string {
+	if options == nil {
+		return nil
+	}
+
+	stored := *options
+	stored.EnvironmentFile = withoutGitCredentials(stored.EnvironmentFile)
+	stored.GlobalsFile = withoutGitCredentials(stored.GlobalsFile)
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return nil
+	}
+
+	return map[string]string{NewmanOptionsAnnotation: string(data)}
+}
+
+func withoutGitCredentials(file *TestContent) *TestContent {
+	if file == nil || file.Repository == nil {
+		return file
+	}
+
+	stored := *file
+	repository := *stored.Repository
+	repository.Username, repository.Token = "", ""
+	stored.Repository = &repository
+	return &stored
+}
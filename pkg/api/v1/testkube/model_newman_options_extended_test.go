@@ -0,0 +1,105 @@
+package testkube
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewmanOptionsValidate(t *testing.T) {
+	assert.NoError(t, (*NewmanOptions)(nil).Validate())
+	assert.NoError(t, (&NewmanOptions{EnvironmentFile: NewStringTestContent("{}"), Folders: []string{"Users"}}).Validate())
+	assert.Contains(t, (&NewmanOptions{GlobalsFile: &TestContent{Type_: "git-dir"}}).Validate().Error(), "unsupported newman globals file type")
+	assert.Contains(t, (&NewmanOptions{EnvironmentFile: &TestContent{Type_: "git-file"}}).Validate().Error(), "repository is not set")
+	assert.Contains(t, (&NewmanOptions{Folders: []string{" "}}).Validate().Error(), "folder can't be empty")
+	assert.Contains(t, (&NewmanOptions{Iterations: -1}).Validate().Error(), "iterations should be positive")
+	assert.Contains(t, (&NewmanOptions{TimeoutRequest: "30"}).Validate().Error(), "invalid newman request timeout")
+	assert.Contains(t, (&NewmanOptions{TimeoutRequest: "-1s"}).Validate().Error(), "request timeout should be positive")
+	assert.Contains(t, (&NewmanOptions{SslClientCert: &NewmanSslClientCert{}}).Validate().Error(), "secret name can't be empty")
+
+	half := strings.Repeat("a", NewmanInlineFilesLimitBytes/2)
+	assert.NoError(t, (&NewmanOptions{EnvironmentFile: NewStringTestContent(half), GlobalsFile: NewStringTestContent(half)}).Validate())
+	assert.Contains(t, (&NewmanOptions{EnvironmentFile: NewStringTestContent(half), GlobalsFile: NewStringTestContent(half + "a")}).Validate().Error(),
+		"use file-uri or git-file files instead")
+}
+
+func TestNewmanOptionsFromParams(t *testing.T) {
+	test := &NewmanOptions{EnvironmentFile: NewStringTestContent("{}"), Folders: []string{"Users"}}
+
+	t.Run("request options override test options", func(t *testing.T) {
+		options := MergeNewmanOptions(test, &NewmanOptions{Folders: []string{"Orders"}})
+		assert.Equal(t, test.EnvironmentFile, options.EnvironmentFile)
+		assert.Equal(t, []string{"Orders"}, options.Folders)
+		assert.Equal(t, []string{"Users"}, test.Folders)
+	})
+
+	t.Run("folder param overrides folders", func(t *testing.T) {
+		options, params, err := NewmanOptionsFromParams(test, map[string]string{NewmanFolderParam: "Orders, Payments", "user": "admin"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Orders", "Payments"}, options.Folders)
+		assert.Equal(t, test.EnvironmentFile, options.EnvironmentFile)
+		assert.Equal(t, map[string]string{"user": "admin"}, params)
+	})
+
+	t.Run("params without newman params", func(t *testing.T) {
+		params := map[string]string{"user": "admin"}
+		options, remaining, err := NewmanOptionsFromParams(nil, params)
+		assert.NoError(t, err)
+		assert.Nil(t, options)
+		assert.Equal(t, params, remaining)
+	})
+
+	enabled, disabled := true, false
+
+	t.Run("request options override test run options", func(t *testing.T) {
+		options := MergeNewmanOptions(&NewmanOptions{Bail: &enabled, Insecure: &enabled}, &NewmanOptions{Bail: &disabled})
+		assert.Equal(t, &NewmanOptions{Bail: &disabled, Insecure: &enabled}, options)
+	})
+
+	t.Run("run option params", func(t *testing.T) {
+		options, params, err := NewmanOptionsFromParams(&NewmanOptions{Bail: &enabled}, map[string]string{
+			NewmanIterationsParam:     "5",
+			NewmanBailParam:           "false",
+			NewmanTimeoutRequestParam: "30s",
+			NewmanInsecureParam:       "true",
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, params)
+		assert.Equal(t, &NewmanOptions{Iterations: 5, Bail: &disabled, TimeoutRequest: "30s", Insecure: &enabled}, options)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		for param, value := range map[string]string{
+			NewmanIterationsParam:     "0",
+			NewmanBailParam:           "sometimes",
+			NewmanTimeoutRequestParam: "5",
+		} {
+			_, _, err := NewmanOptionsFromParams(nil, map[string]string{param: value})
+			assert.Error(t, err, param)
+		}
+	})
+}
+
+func TestNewmanSslClientCertSecretMounts(t *testing.T) {
+	assert.Nil(t, (*NewmanOptions)(nil).SecretMounts())
+	assert.Equal(t, []SecretMount{
+		{SecretName: "api-client-cert", Key: "tls.crt", MountPath: NewmanSslClientCertPath},
+		{SecretName: "api-client-cert", Key: "tls.key", MountPath: NewmanSslClientKeyPath},
+		{SecretName: "api-client-cert", Key: "passphrase", MountPath: NewmanSslClientPassphrasePath},
+	}, (&NewmanOptions{SslClientCert: &NewmanSslClientCert{SecretName: "api-client-cert", PassphraseKey: "passphrase"}}).SecretMounts())
+}
+
+func TestNewmanOptionsAnnotations(t *testing.T) {
+	options := &NewmanOptions{
+		EnvironmentFile: &TestContent{Type_: "git-file", Repository: &Repository{Uri: "https://github.com/kubeshop/testkube", Path: "env.json", Token: "secret"}},
+		Folders:         []string{"Users"},
+	}
+
+	stored := NewmanOptionsFromAnnotations(NewmanOptionsAnnotations(options))
+	assert.Equal(t, options.Folders, stored.Folders)
+	assert.Equal(t, "env.json", stored.EnvironmentFile.Repository.Path)
+	assert.Empty(t, stored.EnvironmentFile.Repository.Token)
+	assert.Equal(t, "secret", options.EnvironmentFile.Repository.Token)
+	assert.Nil(t, NewmanOptionsFromAnnotations(nil))
+}
@@ -0,0 +1,22 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// SSL client certificate of collection requests read from kubernetes secret
+type NewmanSslClientCert struct {
+	// kubernetes secret name
+	SecretName string `json:"secretName"`
+	// secret key with PEM client certificate, tls.crt when not set
+	CertKey string `json:"certKey,omitempty"`
+	// secret key with PEM client certificate private key, tls.key when not set
+	KeyKey string `json:"keyKey,omitempty"`
+	// secret key with private key passphrase
+	PassphraseKey string `json:"passphraseKey,omitempty"`
+}
@@ -0,0 +1,20 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// people responsible for test or test suite, included in failure notifications
+type Ownership struct {
+	// owner name or handle
+	Owner string `json:"owner,omitempty"`
+	// team owning test or test suite
+	Team string `json:"team,omitempty"`
+	// contact of owners, e.g. Slack channel, email or on-call rotation
+	Contact string `json:"contact,omitempty"`
+}
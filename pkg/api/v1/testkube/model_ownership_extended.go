@@ -0,0 +1,58 @@
+package testkube
+
+import "strings"
+
+// OwnershipFromAnnotations returns ownership stored in resource annotations, nil is returned when no field is set
+func OwnershipFromAnnotations(annotations map[string]string) *Ownership {
+	ownership := Ownership{
+		Owner:   annotations[OwnerAnnotation],
+		Team:    annotations[TeamAnnotation],
+		Contact: annotations[ContactAnnotation],
+	}
+
+	if ownership.IsEmpty() {
+		return nil
+	}
+
+	return &ownership
+}
+
+// Annotations returns resource annotations of set ownership fields
+func (o *Ownership) Annotations() map[string]string {
+	if o.IsEmpty() {
+		return nil
+	}
+
+	annotations := map[string]string{}
+	for annotation, value := range map[string]string{OwnerAnnotation: o.Owner, TeamAnnotation: o.Team, ContactAnnotation: o.Contact} {
+		if value != "" {
+			annotations[annotation] = value
+		}
+	}
+
+	return annotations
+}
+
+// IsEmpty checks if no ownership field is set
+func (o *Ownership) IsEmpty() bool {
+	return o == nil || (o.Owner == "" && o.Team == "" && o.Contact == "")
+}
+
+// IsOwnedBy checks if owner or team matches name, names are compared case insensitively
+func (o *Ownership) IsOwnedBy(name string) bool {
+	return o != nil && name != "" && (strings.EqualFold(o.Owner, name) || strings.EqualFold(o.Team, name))
+}
+
+// String returns owner with team, e.g. for list tables
+func (o *Ownership) String() string {
+	switch {
+	case o == nil:
+		return ""
+	case o.Owner != "" && o.Team != "":
+		return o.Owner + " (" + o.Team + ")"
+	case o.Owner != "":
+		return o.Owner
+	default:
+		return o.Team
+	}
+}
@@ -0,0 +1,22 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// scheduling constraints of execution pods, executor and test constraints are merged into job template
+type Placement struct {
+	// node labels execution pods have to be scheduled on
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// required node affinity, nodes have to match all requirements
+	NodeAffinity []PlacementRequirement `json:"nodeAffinity,omitempty"`
+	// taints of nodes execution pods tolerate
+	Tolerations []PlacementToleration `json:"tolerations,omitempty"`
+	// spread of pods of test executions across topology domains, e.g. zones
+	TopologySpread []PlacementTopologySpread `json:"topologySpread,omitempty"`
+}
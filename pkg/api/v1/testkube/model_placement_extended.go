@@ -0,0 +1,248 @@
+package testkube
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Validate checks placement constraints and that nodes can match both node selector and node affinity,
+// nil placement is valid
+func (p *Placement) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	for _, requirement := range p.NodeAffinity {
+		if requirement.Key == "" {
+			return fmt.Errorf("placement node affinity key can't be empty")
+		}
+
+		switch requirement.Operator {
+		case "In", "NotIn":
+			if len(requirement.Values) == 0 {
+				return fmt.Errorf("placement node affinity %s %s needs values", requirement.Key, requirement.Operator)
+			}
+		case "Exists", "DoesNotExist":
+			if len(requirement.Values) != 0 {
+				return fmt.Errorf("placement node affinity %s %s can't have values", requirement.Key, requirement.Operator)
+			}
+		case "Gt", "Lt":
+			if len(requirement.Values) != 1 {
+				return fmt.Errorf("placement node affinity %s %s needs single value", requirement.Key, requirement.Operator)
+			}
+
+			if _, err := strconv.Atoi(requirement.Values[0]); err != nil {
+				return fmt.Errorf("placement node affinity %s %s value should be integer", requirement.Key, requirement.Operator)
+			}
+		default:
+			return fmt.Errorf("invalid placement node affinity operator %q", requirement.Operator)
+		}
+	}
+
+	for _, toleration := range p.Tolerations {
+		switch toleration.Operator {
+		case "", "Equal":
+			if toleration.Key == "" {
+				return fmt.Errorf("placement toleration key can't be empty for Equal operator")
+			}
+		case "Exists":
+			if toleration.Value != "" {
+				return fmt.Errorf("placement toleration %s can't have value for Exists operator", toleration.Key)
+			}
+		default:
+			return fmt.Errorf("invalid placement toleration operator %q", toleration.Operator)
+		}
+
+		switch toleration.Effect {
+		case "", "NoSchedule", "PreferNoSchedule", "NoExecute":
+		default:
+			return fmt.Errorf("invalid placement toleration effect %q", toleration.Effect)
+		}
+	}
+
+	for _, spread := range p.TopologySpread {
+		if spread.TopologyKey == "" {
+			return fmt.Errorf("placement topology spread key can't be empty")
+		}
+
+		if spread.MaxSkew < 0 {
+			return fmt.Errorf("placement topology spread %s max skew can't be negative", spread.TopologyKey)
+		}
+
+		switch spread.WhenUnsatisfiable {
+		case "", "DoNotSchedule", "ScheduleAnyway":
+		default:
+			return fmt.Errorf("invalid placement topology spread %s when unsatisfiable %q", spread.TopologyKey, spread.WhenUnsatisfiable)
+		}
+	}
+
+	return p.checkConflicts()
+}
+
+// labelConstraint is a constraint of node label placement requires
+type labelConstraint struct {
+	required bool
+	absent   bool
+	// allowed values, nil allows any value
+	allowed map[string]bool
+	denied  map[string]bool
+}
+
+func (c *labelConstraint) allow(values ...string) {
+	allowed := map[string]bool{}
+	for _, value := range values {
+		if c.allowed == nil || c.allowed[value] {
+			allowed[value] = true
+		}
+	}
+	c.allowed = allowed
+	c.required = true
+}
+
+func (c *labelConstraint) satisfiable() bool {
+	if c.required && c.absent {
+		return false
+	}
+
+	if c.allowed == nil {
+		return true
+	}
+
+	for value := range c.allowed {
+		if !c.denied[value] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkConflicts checks that node selector and node affinity requirements can be matched by the same node,
+// numeric requirements aren't checked
+func (p *Placement) checkConflicts() error {
+	constraints := map[string]*labelConstraint{}
+	constraint := func(key string) *labelConstraint {
+		if constraints[key] == nil {
+			constraints[key] = &labelConstraint{denied: map[string]bool{}}
+		}
+		return constraints[key]
+	}
+
+	for key, value := range p.NodeSelector {
+		constraint(key).allow(value)
+	}
+
+	for _, requirement := range p.NodeAffinity {
+		c := constraint(requirement.Key)
+		switch requirement.Operator {
+		case "In":
+			c.allow(requirement.Values...)
+		case "NotIn":
+			for _, value := range requirement.Values {
+				c.denied[value] = true
+			}
+		case "Exists", "Gt", "Lt":
+			c.required = true
+		case "DoesNotExist":
+			c.absent = true
+		}
+	}
+
+	keys := make([]string, 0, len(constraints))
+	for key := range constraints {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !constraints[key].satisfiable() {
+			return fmt.Errorf("conflicting placement constraints of node label %s, no node can match them", key)
+		}
+	}
+
+	return nil
+}
+
+// MergePlacements merges placements in order, e.g. executor and test placement, node affinity requirements and
+// tolerations are combined and later topology spread of the same key replaces earlier one, error is returned for
+// conflicting constraints
+func MergePlacements(placements ...*Placement) (*Placement, error) {
+	var merged *Placement
+	for _, placement := range placements {
+		if placement == nil {
+			continue
+		}
+
+		if merged == nil {
+			merged = &Placement{}
+		}
+
+		for key, value := range placement.NodeSelector {
+			if current, ok := merged.NodeSelector[key]; ok && current != value {
+				return nil, fmt.Errorf("conflicting placement node selector %s: %s and %s", key, current, value)
+			}
+
+			if merged.NodeSelector == nil {
+				merged.NodeSelector = map[string]string{}
+			}
+			merged.NodeSelector[key] = value
+		}
+
+		merged.NodeAffinity = append(merged.NodeAffinity, placement.NodeAffinity...)
+		merged.Tolerations = append(merged.Tolerations, placement.Tolerations...)
+
+		for _, spread := range placement.TopologySpread {
+			if i := topologySpreadIndex(merged.TopologySpread, spread.TopologyKey); i >= 0 {
+				merged.TopologySpread[i] = spread
+			} else {
+				merged.TopologySpread = append(merged.TopologySpread, spread)
+			}
+		}
+	}
+
+	if merged == nil {
+		return nil, nil
+	}
+
+	return merged, merged.checkConflicts()
+}
+
+func topologySpreadIndex(spreads []PlacementTopologySpread, topologyKey string) int {
+	for i := range spreads {
+		if spreads[i].TopologyKey == topologyKey {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// PlacementFromAnnotations returns placement stored in test or executor annotations
+func PlacementFromAnnotations(annotations map[string]string) (placement *Placement) {
+	data := annotations[PlacementAnnotation]
+	if data == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &placement); err != nil {
+		return nil
+	}
+
+	return placement
+}
+
+// PlacementAnnotations returns test or executor annotations storing placement
+func PlacementAnnotations(placement *Placement) map[string]string {
+	if placement == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(placement)
+	if err != nil {
+		return nil
+	}
+
+	return map[string]string{PlacementAnnotation: string(data)}
+}
@@ -0,0 +1,87 @@
+package testkube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlacementValidate(t *testing.T) {
+	assert.NoError(t, (*Placement)(nil).Validate())
+	assert.NoError(t, (&Placement{
+		NodeSelector: map[string]string{"nvidia.com/gpu.present": "true"},
+		NodeAffinity: []PlacementRequirement{
+			{Key: "topology.kubernetes.io/zone", Operator: "In", Values: []string{"europe-west1-b", "europe-west1-c"}},
+			{Key: "topology.kubernetes.io/zone", Operator: "NotIn", Values: []string{"europe-west1-c"}},
+			{Key: "gpu-count", Operator: "Gt", Values: []string{"1"}},
+		},
+		Tolerations:    []PlacementToleration{{Key: "nvidia.com/gpu", Operator: "Exists", Effect: "NoSchedule"}},
+		TopologySpread: []PlacementTopologySpread{{TopologyKey: "topology.kubernetes.io/zone", MaxSkew: 1}},
+	}).Validate())
+
+	for name, placement := range map[string]Placement{
+		"invalid operator":     {NodeAffinity: []PlacementRequirement{{Key: "zone", Operator: "Equals", Values: []string{"a"}}}},
+		"missing values":       {NodeAffinity: []PlacementRequirement{{Key: "zone", Operator: "In"}}},
+		"non numeric Gt":       {NodeAffinity: []PlacementRequirement{{Key: "gpu-count", Operator: "Gt", Values: []string{"many"}}}},
+		"toleration value":     {Tolerations: []PlacementToleration{{Key: "gpu", Operator: "Exists", Value: "true"}}},
+		"toleration effect":    {Tolerations: []PlacementToleration{{Key: "gpu", Value: "true", Effect: "NoRun"}}},
+		"topology without key": {TopologySpread: []PlacementTopologySpread{{MaxSkew: 1}}},
+		"disjoint values":      {NodeAffinity: []PlacementRequirement{{Key: "zone", Operator: "In", Values: []string{"a"}}, {Key: "zone", Operator: "In", Values: []string{"b"}}}},
+		"selector not in":      {NodeSelector: map[string]string{"zone": "a"}, NodeAffinity: []PlacementRequirement{{Key: "zone", Operator: "NotIn", Values: []string{"a"}}}},
+		"selector not exists":  {NodeSelector: map[string]string{"zone": "a"}, NodeAffinity: []PlacementRequirement{{Key: "zone", Operator: "DoesNotExist"}}},
+	} {
+		assert.Error(t, placement.Validate(), name)
+	}
+}
+
+func TestMergePlacements(t *testing.T) {
+	executor := &Placement{
+		NodeSelector:   map[string]string{"pool": "tests"},
+		Tolerations:    []PlacementToleration{{Key: "dedicated", Value: "tests", Effect: "NoSchedule"}},
+		TopologySpread: []PlacementTopologySpread{{TopologyKey: "topology.kubernetes.io/zone", MaxSkew: 2}},
+	}
+	test := &Placement{
+		NodeSelector:   map[string]string{"nvidia.com/gpu.present": "true"},
+		NodeAffinity:   []PlacementRequirement{{Key: "topology.kubernetes.io/region", Operator: "In", Values: []string{"europe-west1"}}},
+		TopologySpread: []PlacementTopologySpread{{TopologyKey: "topology.kubernetes.io/zone", MaxSkew: 1}},
+	}
+
+	t.Run("constraints are merged", func(t *testing.T) {
+		merged, err := MergePlacements(executor, nil, test)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"pool": "tests", "nvidia.com/gpu.present": "true"}, merged.NodeSelector)
+		assert.Equal(t, test.NodeAffinity, merged.NodeAffinity)
+		assert.Equal(t, executor.Tolerations, merged.Tolerations)
+		assert.Equal(t, test.TopologySpread, merged.TopologySpread, "test topology spread overrides executor one")
+		assert.Len(t, executor.NodeSelector, 1, "merged placements aren't changed")
+	})
+
+	t.Run("conflicting node selectors", func(t *testing.T) {
+		_, err := MergePlacements(executor, &Placement{NodeSelector: map[string]string{"pool": "gpu"}})
+
+		assert.EqualError(t, err, "conflicting placement node selector pool: tests and gpu")
+	})
+
+	t.Run("conflicting node affinity", func(t *testing.T) {
+		_, err := MergePlacements(executor, &Placement{NodeAffinity: []PlacementRequirement{{Key: "pool", Operator: "In", Values: []string{"gpu"}}}})
+
+		assert.EqualError(t, err, "conflicting placement constraints of node label pool, no node can match them")
+	})
+
+	t.Run("no placement", func(t *testing.T) {
+		merged, err := MergePlacements(nil, nil)
+
+		assert.NoError(t, err)
+		assert.Nil(t, merged)
+	})
+}
+
+func TestPlacementAnnotations(t *testing.T) {
+	placement := &Placement{NodeSelector: map[string]string{"pool": "tests"}}
+
+	assert.Equal(t, placement, PlacementFromAnnotations(PlacementAnnotations(placement)))
+	assert.Nil(t, PlacementAnnotations(nil))
+	assert.Nil(t, PlacementFromAnnotations(map[string]string{PlacementAnnotation: "{"}))
+}
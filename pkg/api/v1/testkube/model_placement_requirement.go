@@ -0,0 +1,19 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// node label requirement
+type PlacementRequirement struct {
+	// node label key
+	Key      string `json:"key"`
+	Operator string `json:"operator"`
+	// label values, required by In, NotIn, Gt and Lt operators
+	Values []string `json:"values,omitempty"`
+}
@@ -0,0 +1,21 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// node taint toleration
+type PlacementToleration struct {
+	// taint key, empty key with Exists operator tolerates all taints
+	Key      string `json:"key,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	// taint value
+	Value string `json:"value,omitempty"`
+	// taint effect, empty effect tolerates all effects
+	Effect string `json:"effect,omitempty"`
+}
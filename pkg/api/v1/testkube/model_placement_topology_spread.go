@@ -0,0 +1,19 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// spread of pods of test executions across topology domains
+type PlacementTopologySpread struct {
+	// node label key of topology domains
+	TopologyKey string `json:"topologyKey"`
+	// max difference of pod numbers between domains
+	MaxSkew           int32  `json:"maxSkew,omitempty"`
+	WhenUnsatisfiable string `json:"whenUnsatisfiable,omitempty"`
+}
@@ -0,0 +1,26 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// executor pod diagnostics
+type PodDiagnostics struct {
+	// pod name
+	Name string `json:"name"`
+	// pod phase
+	Phase string `json:"phase"`
+	// pod status reason e.g. Evicted
+	Reason string `json:"reason,omitempty"`
+	// pod status message
+	Message string `json:"message,omitempty"`
+	// init containers statuses
+	InitContainers []ContainerDiagnostics `json:"initContainers,omitempty"`
+	// containers statuses
+	Containers []ContainerDiagnostics `json:"containers,omitempty"`
+}
@@ -0,0 +1,22 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// readiness check of kubernetes resource or HTTP endpoint
+type Precheck struct {
+	// kubernetes deployment, statefulset, daemonset or pod which has to be ready, in kind/name form
+	Resource string `json:"resource,omitempty"`
+	// namespace of resource, Testkube namespace by default
+	Namespace string `json:"namespace,omitempty"`
+	// URL of HTTP probe
+	Url string `json:"url,omitempty"`
+	// status HTTP probe has to respond with, any 2xx status by default
+	ExpectedStatus int32 `json:"expectedStatus,omitempty"`
+}
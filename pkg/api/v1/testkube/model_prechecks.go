@@ -0,0 +1,19 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// readiness checks of services test depends on evaluated before executor job is launched, executions are skipped
+// when checks aren't met within timeout
+type Prechecks struct {
+	// time checks have to be met within, e.g. 2m
+	Timeout string `json:"timeout,omitempty"`
+	// checks which all have to be met
+	Checks []Precheck `json:"checks"`
+}
@@ -0,0 +1,148 @@
+package testkube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultPrechecksTimeout is a time prechecks have to be met within when timeout isn't set
+const DefaultPrechecksTimeout = time.Minute
+
+// PrecheckKinds are kinds of kubernetes resources prechecks wait for
+var PrecheckKinds = []string{"deployment", "statefulset", "daemonset", "pod"}
+
+// Validate checks that prechecks have valid timeout and each check is either resource or HTTP probe
+func (p *Prechecks) Validate() error {
+	if p == nil {
+		return nil
+	}
+
+	if p.Timeout != "" {
+		timeout, err := time.ParseDuration(p.Timeout)
+		if err != nil {
+			return fmt.Errorf("invalid prechecks timeout %q: %w", p.Timeout, err)
+		}
+
+		if timeout <= 0 {
+			return fmt.Errorf("prechecks timeout should be positive")
+		}
+	}
+
+	if len(p.Checks) == 0 {
+		return fmt.Errorf("prechecks need at least one check")
+	}
+
+	for _, check := range p.Checks {
+		if err := check.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetTimeout returns time prechecks have to be met within
+func (p Prechecks) GetTimeout() time.Duration {
+	if timeout, err := time.ParseDuration(p.Timeout); err == nil && timeout > 0 {
+		return timeout
+	}
+
+	return DefaultPrechecksTimeout
+}
+
+// Validate checks that precheck is either resource of supported kind or HTTP probe
+func (c Precheck) Validate() error {
+	if (c.Resource == "") == (c.Url == "") {
+		return fmt.Errorf("precheck needs either resource or url")
+	}
+
+	if c.Url != "" {
+		u, err := url.Parse(c.Url)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid precheck url %q", c.Url)
+		}
+
+		if c.ExpectedStatus != 0 && (c.ExpectedStatus < 100 || c.ExpectedStatus > 599) {
+			return fmt.Errorf("invalid precheck %s expected status %d", c.Url, c.ExpectedStatus)
+		}
+
+		return nil
+	}
+
+	kind, name := c.KindName()
+	if name == "" {
+		return fmt.Errorf("precheck resource %q should be in kind/name form", c.Resource)
+	}
+
+	for _, supported := range PrecheckKinds {
+		if kind == supported {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("precheck resource %s kind should be one of %s", c.Resource, strings.Join(PrecheckKinds, ", "))
+}
+
+// KindName returns lower case kind and name of precheck resource
+func (c Precheck) KindName() (kind, name string) {
+	kind, name, _ = strings.Cut(c.Resource, "/")
+	return strings.ToLower(kind), name
+}
+
+// String returns checked resource with namespace or probe URL
+func (c Precheck) String() string {
+	if c.Url != "" {
+		return c.Url
+	}
+
+	if c.Namespace != "" {
+		return c.Namespace + "/" + c.Resource
+	}
+
+	return c.Resource
+}
+
+// ParsePrecheck parses precheck of HTTP probe URL or resource in [namespace/]kind/name form
+func ParsePrecheck(value string) (Precheck, error) {
+	var check Precheck
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		check.Url = value
+	} else if parts := strings.Split(value, "/"); len(parts) == 3 {
+		check.Namespace, check.Resource = parts[0], parts[1]+"/"+parts[2]
+	} else {
+		check.Resource = value
+	}
+
+	return check, check.Validate()
+}
+
+// PrechecksFromAnnotations returns prechecks stored in test annotations
+func PrechecksFromAnnotations(annotations map[string]string) (prechecks *Prechecks) {
+	data := annotations[PrechecksAnnotation]
+	if data == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &prechecks); err != nil {
+		return nil
+	}
+
+	return prechecks
+}
+
+// PrechecksAnnotations returns test annotations storing prechecks
+func PrechecksAnnotations(prechecks *Prechecks) map[string]string {
+	if prechecks == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(prechecks)
+	if err != nil {
+		return nil
+	}
+
+	return map[string]string{PrechecksAnnotation: string(data)}
+}
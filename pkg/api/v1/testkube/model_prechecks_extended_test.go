@@ -0,0 +1,37 @@
+package testkube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePrecheck(t *testing.T) {
+	for value, expected := range map[string]Precheck{
+		"deployment/backend":                     {Resource: "deployment/backend"},
+		"staging/statefulset/db":                 {Resource: "statefulset/db", Namespace: "staging"},
+		"http://backend.staging.svc:8080/health": {Url: "http://backend.staging.svc:8080/health"},
+	} {
+		check, err := ParsePrecheck(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, expected, check)
+	}
+
+	for _, value := range []string{"backend", "service/backend", "http://", "a/b/c/d"} {
+		_, err := ParsePrecheck(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestPrechecksValidate(t *testing.T) {
+	assert.NoError(t, (*Prechecks)(nil).Validate())
+	assert.NoError(t, (&Prechecks{Timeout: "2m", Checks: []Precheck{{Url: "https://example.com", ExpectedStatus: 204}}}).Validate())
+	assert.Error(t, (&Prechecks{}).Validate(), "no checks")
+	assert.Error(t, (&Prechecks{Timeout: "-1m", Checks: []Precheck{{Resource: "pod/cache"}}}).Validate())
+	assert.Error(t, (&Prechecks{Checks: []Precheck{{Resource: "pod/cache", Url: "https://example.com"}}}).Validate())
+	assert.Error(t, (&Prechecks{Checks: []Precheck{{Url: "https://example.com", ExpectedStatus: 42}}}).Validate())
+
+	assert.Equal(t, 2*time.Minute, Prechecks{Timeout: "2m"}.GetTimeout())
+	assert.Equal(t, DefaultPrechecksTimeout, Prechecks{}.GetTimeout())
+}
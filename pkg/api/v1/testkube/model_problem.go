@@ -21,4 +21,8 @@ type Problem struct {
 	Detail string `json:"detail,omitempty"`
 	// A URI that identifies the specific occurrence of the problem. This URI may or may not yield further information if de-referenced.
 	Instance string `json:"instance,omitempty"`
+	// machine readable problem code, e.g. test_not_found, executor_missing or duplicate_execution
+	Code string `json:"code,omitempty"`
+	// id of request the problem was returned for, passed in X-Request-ID header
+	RequestId string `json:"requestId,omitempty"`
 }
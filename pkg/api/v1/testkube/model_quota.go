@@ -0,0 +1,26 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// execution and artifact storage limits of executions selected by labels or project, 0 disables limit
+type Quota struct {
+	// quota name
+	Name string `json:"name"`
+	// label selector of executions the quota applies to
+	Selector string `json:"selector,omitempty"`
+	// project of executions the quota applies to
+	Project string `json:"project,omitempty"`
+	// max number of executions started per UTC day
+	MaxExecutionsPerDay int32 `json:"maxExecutionsPerDay,omitempty"`
+	// max number of running executions
+	MaxConcurrentExecutions int32 `json:"maxConcurrentExecutions,omitempty"`
+	// max size of stored execution artifacts in GB
+	MaxArtifactsGB float64 `json:"maxArtifactsGB,omitempty"`
+}
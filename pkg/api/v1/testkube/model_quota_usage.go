@@ -0,0 +1,23 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// quota with its current usage
+type QuotaUsage struct {
+	Quota *Quota `json:"quota,omitempty"`
+	// number of executions started today (UTC)
+	ExecutionsToday int32 `json:"executionsToday,omitempty"`
+	// number of running executions
+	ConcurrentExecutions int32 `json:"concurrentExecutions,omitempty"`
+	// size of stored execution artifacts in GB, it's refreshed periodically
+	ArtifactsGB float64 `json:"artifactsGB,omitempty"`
+	// reached limits, new executions are rejected
+	Exceeded []string `json:"exceeded,omitempty"`
+}
@@ -17,6 +17,8 @@ type Repository struct {
 	Uri string `json:"uri"`
 	// branch/tag name for checkout
 	Branch string `json:"branch"`
+	// commit SHA to checkout instead of branch head, e.g. when execution is rerun
+	Commit string `json:"commit,omitempty"`
 	// if needed we can checkout particular path (dir or file) in case of BIG/mono repositories
 	Path string `json:"path,omitempty"`
 	// git auth username for private repositories
@@ -0,0 +1,22 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// result of backup restore
+type RestoreResult struct {
+	// numbers of created resources by kind
+	Created map[string]int32 `json:"created,omitempty"`
+	// numbers of updated resources by kind
+	Updated map[string]int32 `json:"updated,omitempty"`
+	// numbers of skipped existing resources by kind
+	Skipped map[string]int32 `json:"skipped,omitempty"`
+	// errors of resources which weren't restored
+	Errors []string `json:"errors,omitempty"`
+}
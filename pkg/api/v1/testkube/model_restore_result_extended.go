@@ -0,0 +1,17 @@
+package testkube
+
+import "fmt"
+
+// NewRestoreResult returns empty restore result
+func NewRestoreResult() RestoreResult {
+	return RestoreResult{
+		Created: map[string]int32{},
+		Updated: map[string]int32{},
+		Skipped: map[string]int32{},
+	}
+}
+
+// AddError adds error of resource which wasn't restored
+func (r *RestoreResult) AddError(kind, name string, err error) {
+	r.Errors = append(r.Errors, fmt.Sprintf("%s %s: %s", kind, name, err))
+}
@@ -0,0 +1,26 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// running context describing source which triggered execution (e.g. CI pipeline)
+type RunningContext struct {
+	// CI provider name e.g. github-actions, gitlab-ci, jenkins
+	Provider string `json:"provider,omitempty"`
+	// URL of pipeline run which triggered execution
+	PipelineUrl string `json:"pipelineUrl,omitempty"`
+	// commit sha execution was triggered for
+	Commit string `json:"commit,omitempty"`
+	// user or service account which triggered execution
+	Actor string `json:"actor,omitempty"`
+	// user authenticated by trusted auth layer, set by server and evaluated by execution policies
+	Requester string `json:"requester,omitempty"`
+	// chain of tests and test suites which passed executions triggered execution, e.g. testsuite/smoke, set by server
+	TriggeredBy []string `json:"triggeredBy,omitempty"`
+}
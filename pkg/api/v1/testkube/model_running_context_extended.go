@@ -0,0 +1,51 @@
+package testkube
+
+import (
+	"strings"
+)
+
+// IsEmpty checks if any running context field is set
+func (r *RunningContext) IsEmpty() bool {
+	return r == nil || (r.Provider == "" && r.PipelineUrl == "" && r.Commit == "" && r.Actor == "" && len(r.TriggeredBy) == 0)
+}
+
+// String returns human readable running context representation
+func (r *RunningContext) String() string {
+	if r.IsEmpty() {
+		return ""
+	}
+
+	var parts []string
+	if r.Provider != "" {
+		parts = append(parts, r.Provider)
+	}
+
+	if r.Commit != "" {
+		parts = append(parts, "commit "+r.Commit)
+	}
+
+	if r.Actor != "" {
+		parts = append(parts, "by "+r.Actor)
+	}
+
+	if len(r.TriggeredBy) != 0 {
+		parts = append(parts, "triggered by "+strings.Join(r.TriggeredBy, " -> "))
+	}
+
+	if r.PipelineUrl != "" {
+		parts = append(parts, "("+r.PipelineUrl+")")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// WithTrigger returns copy of running context of execution triggered by passed execution of source test or test suite
+func (r *RunningContext) WithTrigger(source string) *RunningContext {
+	triggered := RunningContext{}
+	if r != nil {
+		triggered = *r
+	}
+	triggered.TriggeredBy = append(append([]string{}, triggered.TriggeredBy...), source)
+
+	return &triggered
+}
@@ -0,0 +1,20 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// secret key mounted as a file into executor container
+type SecretMount struct {
+	// kubernetes secret name
+	SecretName string `json:"secretName"`
+	// secret key
+	Key string `json:"key"`
+	// file path inside executor container
+	MountPath string `json:"mountPath"`
+}
@@ -15,4 +15,10 @@ type ServerInfo struct {
 	Version string `json:"version"`
 	// build commit
 	Commit string `json:"commit,omitempty"`
+	// API schema version
+	SchemaVersion string `json:"schemaVersion,omitempty"`
+	// enabled features
+	Features *Features `json:"features,omitempty"`
+	// test types supported by registered executors
+	ExecutorTypes []string `json:"executorTypes,omitempty"`
 }
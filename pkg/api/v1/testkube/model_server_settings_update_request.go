@@ -0,0 +1,40 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// API server settings update request, only set fields are updated
+type ServerSettingsUpdateRequest struct {
+	// default concurrency level for multiple tests and test suites execution
+	DefaultConcurrency *int32 `json:"defaultConcurrency,omitempty"`
+	// number of days test and test suite executions are kept, 0 keeps executions forever
+	ExecutionsRetentionDays *int32 `json:"executionsRetentionDays,omitempty"`
+	// number of days after which test executions are moved to archive in object storage, 0 disables archiving
+	ArchiveAfterDays *int32 `json:"archiveAfterDays,omitempty"`
+	// are webhook notifications enabled
+	WebhookNotifications *bool `json:"webhookNotifications,omitempty"`
+	// are slack notifications enabled
+	SlackNotifications *bool `json:"slackNotifications,omitempty"`
+	// minutes of batching execution notifications into a digest, 0 sends notification per execution
+	NotificationsDigestMinutes *int32 `json:"notificationsDigestMinutes,omitempty"`
+	// default namespace of executions requested without namespace
+	DefaultNamespace *string `json:"defaultNamespace,omitempty"`
+	// regex patterns redacted in logs, results and notifications in addition to secret params and default patterns
+	RedactionPatterns *[]string `json:"redactionPatterns,omitempty"`
+	// windows of planned downtime when scheduled executions of all tests and test suites are skipped
+	MaintenanceWindows *[]MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// recurring windows, e.g. nights and weekends, when execution notifications and incidents are held until window ends
+	QuietHours *[]MaintenanceWindow `json:"quietHours,omitempty"`
+	// policies evaluated before test executions start, executions matching deny condition of any policy are rejected
+	ExecutionPolicies *[]ExecutionPolicy `json:"executionPolicies,omitempty"`
+	// execution and artifact storage quotas of teams selected by labels or projects
+	Quotas *[]Quota `json:"quotas,omitempty"`
+	// rates execution costs of costs report are estimated with
+	CostRates *CostRates `json:"costRates,omitempty"`
+}
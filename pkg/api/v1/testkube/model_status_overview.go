@@ -0,0 +1,36 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+import (
+	"time"
+)
+
+// aggregated status of tests and executions for dashboard homepage
+type StatusOverview struct {
+	// number of tests
+	Tests int32 `json:"tests"`
+	// numbers of tests by status of their latest execution
+	TestsByLatestStatus map[string]int32 `json:"testsByLatestStatus"`
+	// number of tests without executions
+	TestsWithoutExecutions int32 `json:"testsWithoutExecutions"`
+	// number of running executions
+	RunningExecutions int32 `json:"runningExecutions"`
+	// number of queued executions
+	QueuedExecutions int32 `json:"queuedExecutions"`
+	// number of executions started in the last 24 hours
+	ExecutionsLast24h int32 `json:"executionsLast24h"`
+	// ratio of passed to passed and failed executions started in the last 24 hours, from 0 to 1
+	PassRateLast24h float64 `json:"passRateLast24h"`
+	// newest failed and timed out executions
+	RecentFailures []ExecutionSummary `json:"recentFailures"`
+	// overview generation time
+	GeneratedAt time.Time `json:"generatedAt"`
+}
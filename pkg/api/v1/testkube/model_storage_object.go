@@ -0,0 +1,22 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// object in object storage
+type StorageObject struct {
+	// bucket name
+	Bucket string `json:"bucket"`
+	// object name
+	Name string `json:"name"`
+	// object size in bytes
+	Size int64 `json:"size,omitempty"`
+	// object content encoding, gzip for compressed objects
+	ContentEncoding string `json:"contentEncoding,omitempty"`
+}
@@ -3,12 +3,13 @@ package testkube
 type Tests []Test
 
 func (t Tests) Table() (header []string, output [][]string) {
-	header = []string{"Name", "Type", "Created", "Labels", "Schedule"}
+	header = []string{"Name", "Type", "Created", "Owner", "Labels", "Schedule"}
 	for _, e := range t {
 		output = append(output, []string{
 			e.Name,
 			e.Type_,
 			e.Created.String(),
+			e.Ownership.String(),
 			LabelsToString(e.Labels),
 			e.Schedule,
 		})
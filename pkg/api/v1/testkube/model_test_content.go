@@ -15,6 +15,9 @@ type TestContent struct {
 	Repository *Repository `json:"repository,omitempty"`
 	// test content data as string
 	Data string `json:"data,omitempty"`
+	// test content files by relative paths, materialized into directory in executor
+	Files map[string]string `json:"files,omitempty"`
 	// test content
-	Uri string `json:"uri,omitempty"`
+	Uri        string             `json:"uri,omitempty"`
+	UriOptions *ContentUriOptions `json:"uriOptions,omitempty"`
 }
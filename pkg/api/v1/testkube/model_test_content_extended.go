@@ -1,7 +1,11 @@
 // content could be fetched as file or dir (many files, e.g. Cypress project) in executor
 package testkube
 
-import "fmt"
+import (
+	"fmt"
+	"path"
+	"strings"
+)
 
 type TestContentType string
 
@@ -10,10 +14,14 @@ const (
 	TestContentTypeFileURI TestContentType = "file-uri"
 	TestContentTypeGitFile TestContentType = "git-file"
 	TestContentTypeGitDir  TestContentType = "git-dir"
+	TestContentTypeFiles   TestContentType = "files"
 )
 
+// MaxContentFilesSize is a maximal size of inline content files, they're stored in test custom resource
+const MaxContentFilesSize = 512 * 1024
+
 var ErrTestContentTypeNotFile = fmt.Errorf("unsupported content type use one of: file-uri, git-file, string")
-var ErrTestContentTypeNotDir = fmt.Errorf("unsupported content type use one of: git-dir")
+var ErrTestContentTypeNotDir = fmt.Errorf("unsupported content type use one of: git-dir, files")
 
 func NewStringTestContent(str string) *TestContent {
 	return &TestContent{
@@ -24,7 +32,8 @@ func NewStringTestContent(str string) *TestContent {
 
 // IsDir - for content fetched as dir
 func (c *TestContent) IsDir() bool {
-	return TestContentType(c.Type_) == TestContentTypeGitDir
+	return TestContentType(c.Type_) == TestContentTypeGitDir ||
+		TestContentType(c.Type_) == TestContentTypeFiles
 
 }
 
@@ -34,3 +43,43 @@ func (c *TestContent) IsFile() bool {
 		TestContentType(c.Type_) == TestContentTypeFileURI ||
 		TestContentType(c.Type_) == TestContentTypeString
 }
+
+// ValidateContentFiles checks that inline content files are set only for files content, have relative paths
+// inside content directory and fit size limit
+func ValidateContentFiles(content *TestContent) error {
+	if content == nil {
+		return nil
+	}
+
+	if TestContentType(content.Type_) != TestContentTypeFiles {
+		if len(content.Files) > 0 {
+			return fmt.Errorf("content files are supported only for %s content", TestContentTypeFiles)
+		}
+		return nil
+	}
+
+	if len(content.Files) == 0 {
+		return fmt.Errorf("%s content has no files", TestContentTypeFiles)
+	}
+
+	paths := map[string]struct{}{}
+	size := 0
+	for name, data := range content.Files {
+		clean := path.Clean(name)
+		if name == "" || path.IsAbs(name) || clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+			return fmt.Errorf("content file path %q must be relative path inside content directory", name)
+		}
+
+		if _, ok := paths[clean]; ok {
+			return fmt.Errorf("content file path %s is duplicated", name)
+		}
+		paths[clean] = struct{}{}
+		size += len(data)
+	}
+
+	if size > MaxContentFilesSize {
+		return fmt.Errorf("content files have %d bytes, exceeding limit of %d bytes", size, MaxContentFilesSize)
+	}
+
+	return nil
+}
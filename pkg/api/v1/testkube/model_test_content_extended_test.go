@@ -0,0 +1,25 @@
+package testkube
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateContentFiles(t *testing.T) {
+	files := func(files map[string]string) *TestContent {
+		return &TestContent{Type_: string(TestContentTypeFiles), Files: files}
+	}
+
+	assert.NoError(t, ValidateContentFiles(nil))
+	assert.NoError(t, ValidateContentFiles(NewStringTestContent("test")))
+	assert.NoError(t, ValidateContentFiles(files(map[string]string{"script.js": "1", "lib/helpers.js": "2"})))
+
+	assert.Error(t, ValidateContentFiles(files(nil)))
+	assert.Error(t, ValidateContentFiles(files(map[string]string{"/etc/script.js": "1"})))
+	assert.Error(t, ValidateContentFiles(files(map[string]string{"../script.js": "1"})))
+	assert.Error(t, ValidateContentFiles(files(map[string]string{"lib/../script.js": "1", "script.js": "2"})))
+	assert.Error(t, ValidateContentFiles(files(map[string]string{"script.js": strings.Repeat("x", MaxContentFilesSize+1)})))
+	assert.Error(t, ValidateContentFiles(&TestContent{Type_: string(TestContentTypeString), Files: map[string]string{"script.js": "1"}}))
+}
@@ -0,0 +1,22 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// tests which latest executions have to pass before test is executed
+type TestDependencies struct {
+	// names of tests which latest completed executions within freshness window have to be passing
+	RequiresPassing []string `json:"requiresPassing"`
+	// age of latest executions of required tests, e.g. 24h
+	Freshness string `json:"freshness,omitempty"`
+	// what happens with blocked executions, skip stores them as skipped, queue waits until required tests pass
+	OnBlocked string `json:"onBlocked,omitempty"`
+	// time queued executions wait for required tests to pass before they are skipped, e.g. 1h
+	QueueTimeout string `json:"queueTimeout,omitempty"`
+}
@@ -0,0 +1,108 @@
+package testkube
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	// DependenciesOnBlockedSkip stores blocked executions as skipped
+	DependenciesOnBlockedSkip = "skip"
+	// DependenciesOnBlockedQueue keeps blocked executions waiting until required tests pass
+	DependenciesOnBlockedQueue = "queue"
+
+	// DefaultDependenciesFreshness is an age of latest executions of required tests when freshness isn't set
+	DefaultDependenciesFreshness = 24 * time.Hour
+	// DefaultDependenciesQueueTimeout is a time queued executions wait when queue timeout isn't set
+	DefaultDependenciesQueueTimeout = time.Hour
+)
+
+// Validate checks that dependencies require tests and have valid durations and blocked executions handling
+func (d *TestDependencies) Validate() error {
+	if d == nil {
+		return nil
+	}
+
+	if len(d.RequiresPassing) == 0 {
+		return fmt.Errorf("dependencies need at least one required test")
+	}
+
+	for _, name := range d.RequiresPassing {
+		if name == "" {
+			return fmt.Errorf("required test name can't be empty")
+		}
+	}
+
+	for field, value := range map[string]string{"freshness": d.Freshness, "queue timeout": d.QueueTimeout} {
+		if value == "" {
+			continue
+		}
+
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid dependencies %s %q: %w", field, value, err)
+		}
+
+		if duration <= 0 {
+			return fmt.Errorf("dependencies %s should be positive", field)
+		}
+	}
+
+	if d.OnBlocked != "" && d.OnBlocked != DependenciesOnBlockedSkip && d.OnBlocked != DependenciesOnBlockedQueue {
+		return fmt.Errorf("dependencies onBlocked should be %s or %s", DependenciesOnBlockedSkip, DependenciesOnBlockedQueue)
+	}
+
+	return nil
+}
+
+// GetFreshness returns age of latest executions of required tests
+func (d TestDependencies) GetFreshness() time.Duration {
+	if freshness, err := time.ParseDuration(d.Freshness); err == nil && freshness > 0 {
+		return freshness
+	}
+
+	return DefaultDependenciesFreshness
+}
+
+// GetQueueTimeout returns time queued executions wait for required tests to pass
+func (d TestDependencies) GetQueueTimeout() time.Duration {
+	if timeout, err := time.ParseDuration(d.QueueTimeout); err == nil && timeout > 0 {
+		return timeout
+	}
+
+	return DefaultDependenciesQueueTimeout
+}
+
+// IsQueued checks if blocked executions wait for required tests to pass
+func (d TestDependencies) IsQueued() bool {
+	return d.OnBlocked == DependenciesOnBlockedQueue
+}
+
+// DependenciesFromAnnotations returns dependencies stored in test annotations
+func DependenciesFromAnnotations(annotations map[string]string) (dependencies *TestDependencies) {
+	data := annotations[DependenciesAnnotation]
+	if data == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &dependencies); err != nil {
+		return nil
+	}
+
+	return dependencies
+}
+
+// DependenciesAnnotations returns test annotations storing dependencies
+func DependenciesAnnotations(dependencies *TestDependencies) map[string]string {
+	if dependencies == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(dependencies)
+	if err != nil {
+		return nil
+	}
+
+	return map[string]string{DependenciesAnnotation: string(data)}
+}
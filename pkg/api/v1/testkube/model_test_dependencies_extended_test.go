@@ -0,0 +1,26 @@
+package testkube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestDependenciesValidate(t *testing.T) {
+	assert.NoError(t, (*TestDependencies)(nil).Validate())
+	assert.NoError(t, (&TestDependencies{RequiresPassing: []string{"smoke"}, Freshness: "6h", OnBlocked: "queue", QueueTimeout: "30m"}).Validate())
+
+	for name, dependencies := range map[string]TestDependencies{
+		"no required tests":      {},
+		"empty test name":        {RequiresPassing: []string{""}},
+		"invalid freshness":      {RequiresPassing: []string{"smoke"}, Freshness: "day"},
+		"negative timeout":       {RequiresPassing: []string{"smoke"}, QueueTimeout: "-1h"},
+		"unknown blocked action": {RequiresPassing: []string{"smoke"}, OnBlocked: "wait"},
+	} {
+		assert.Error(t, dependencies.Validate(), name)
+	}
+
+	assert.Equal(t, DefaultDependenciesFreshness, TestDependencies{}.GetFreshness())
+	assert.Equal(t, 30*time.Minute, TestDependencies{QueueTimeout: "30m"}.GetQueueTimeout())
+}
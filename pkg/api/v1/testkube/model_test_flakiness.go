@@ -0,0 +1,22 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// test flakiness calculated over recent executions
+type TestFlakiness struct {
+	// test name
+	TestName string `json:"testName"`
+	// rate of passed/failed status changes between consecutive executions, 0 - stable, 1 - alternates on every execution
+	Flakiness float64 `json:"flakiness"`
+	// test is quarantined, its failures don't fail test suites
+	Quarantined bool `json:"quarantined"`
+	// recent executions used as flakiness evidence
+	Executions []ExecutionSummary `json:"executions,omitempty"`
+}
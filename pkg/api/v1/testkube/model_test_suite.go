@@ -26,4 +26,10 @@ type TestSuite struct {
 	Repeats  int32  `json:"repeats,omitempty"`
 	// default test suite params can be overriden by execution params
 	Params map[string]string `json:"params,omitempty"`
+	// owner, team and contact of people responsible for the test suite
+	Ownership *Ownership `json:"ownership,omitempty"`
+	// disabled test suites are skipped by selector runs, their cron jobs are suspended and manual runs have to be forced
+	Enabled *bool `json:"enabled,omitempty"`
+	// tests and test suites executed when test suite execution passes
+	Triggers []ExecutionTrigger `json:"triggers,omitempty"`
 }
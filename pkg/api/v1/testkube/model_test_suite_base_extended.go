@@ -7,12 +7,13 @@ import (
 type TestSuites []TestSuite
 
 func (tests TestSuites) Table() (header []string, output [][]string) {
-	header = []string{"Name", "Description", "Steps", "Labels", "Schedule"}
+	header = []string{"Name", "Description", "Steps", "Owner", "Labels", "Schedule"}
 	for _, e := range tests {
 		output = append(output, []string{
 			e.Name,
 			e.Description,
 			fmt.Sprintf("%d", len(e.Steps)),
+			e.Ownership.String(),
 			LabelsToString(e.Labels),
 			e.Schedule,
 		})
@@ -33,6 +33,13 @@ type TestSuiteExecution struct {
 	Duration string `json:"duration,omitempty"`
 	// steps execution restults
 	StepResults []TestSuiteStepExecutionResult `json:"stepResults,omitempty"`
+	// project the test suite execution is scoped to
+	Project string `json:"project,omitempty"`
 	// test suite execution labels
-	Labels map[string]string `json:"labels,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	RunningContext *RunningContext   `json:"runningContext,omitempty"`
+	// id of test suite execution rerun by this execution
+	RerunOf string `json:"rerunOf,omitempty"`
+	// id of API request which started the test suite execution, passed in X-Request-ID header
+	RequestId string `json:"requestId,omitempty"`
 }
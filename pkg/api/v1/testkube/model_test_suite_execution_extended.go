@@ -27,9 +27,15 @@ func NewStartedTestSuiteExecution(testSuite TestSuite, request TestSuiteExecutio
 		Params:    testSuite.Params,
 		TestSuite: testSuite.GetObjectRef(),
 		Labels:    testSuite.Labels,
+		Project:   GetProject(testSuite.Labels),
+		// operator or pipeline which started test suite is passed to its steps
+		RunningContext: request.RunningContext,
 	}
 
 	// override params from request
+	if testExecution.Params == nil && len(request.Params) > 0 {
+		testExecution.Params = map[string]string{}
+	}
 	for k, v := range request.Params {
 		testExecution.Params[k] = v
 	}
@@ -45,8 +51,59 @@ func NewStartedTestSuiteExecution(testSuite TestSuite, request TestSuiteExecutio
 	return testExecution
 }
 
+// NewRerunTestSuiteExecution returns started test suite execution rerunning steps of execution from given step,
+// results of previous steps are carried over
+func NewRerunTestSuiteExecution(execution TestSuiteExecution, from int) TestSuiteExecution {
+	name := execution.Name
+	if execution.TestSuite != nil {
+		name = execution.TestSuite.Name
+	}
+
+	rerun := TestSuiteExecution{
+		Id:             primitive.NewObjectID().Hex(),
+		StartTime:      time.Now(),
+		Name:           fmt.Sprintf("%s.%s", name, rand.Name()),
+		Status:         TestSuiteExecutionStatusRunning,
+		Envs:           execution.Envs,
+		Params:         execution.Params,
+		TestSuite:      execution.TestSuite,
+		Labels:         execution.Labels,
+		Project:        execution.Project,
+		RunningContext: execution.RunningContext,
+		RerunOf:        execution.Id,
+	}
+
+	for i, result := range execution.StepResults {
+		if i < from {
+			result.CarriedOver = true
+			rerun.StepResults = append(rerun.StepResults, result)
+			continue
+		}
+
+		rerun.StepResults = append(rerun.StepResults, NewTestStepQueuedResult(result.Step))
+	}
+
+	return rerun
+}
+
+// FailedStepIndex returns index of first step which neither passed nor was skipped, -1 when there is no such step
+func (e TestSuiteExecution) FailedStepIndex() int {
+	for i, result := range e.StepResults {
+		if result.Execution == nil || result.Execution.ExecutionResult == nil || result.Execution.ExecutionResult.Status == nil {
+			return i
+		}
+
+		if !result.Execution.ExecutionResult.IsPassed() && !result.Execution.ExecutionResult.IsSkipped() {
+			return i
+		}
+	}
+
+	return -1
+}
+
 func (e TestSuiteExecution) IsCompleted() bool {
-	return *e.Status == *TestSuiteExecutionStatusFailed || *e.Status == *TestSuiteExecutionStatusPassed
+	return *e.Status == *TestSuiteExecutionStatusFailed || *e.Status == *TestSuiteExecutionStatusPassed ||
+		*e.Status == *TestSuiteExecutionStatusAborted
 }
 
 func (e *TestSuiteExecution) CalculateDuration() time.Duration {
@@ -66,7 +123,7 @@ func (e *TestSuiteExecution) CalculateDuration() time.Duration {
 }
 
 func (e TestSuiteExecution) Table() (header []string, output [][]string) {
-	header = []string{"Status", "Step", "ID", "Error"}
+	header = []string{"Status", "Step", "ID", "Attempts", "Error"}
 	output = make([][]string, 0)
 
 	for _, sr := range e.StepResults {
@@ -75,6 +132,10 @@ func (e TestSuiteExecution) Table() (header []string, output [][]string) {
 			status = string(*sr.Execution.ExecutionResult.Status)
 		}
 
+		if sr.CarriedOver {
+			status += " (carried over)"
+		}
+
 		switch sr.Step.Type() {
 		case TestSuiteStepTypeExecuteTest:
 			var id, errorMessage string
@@ -82,10 +143,21 @@ func (e TestSuiteExecution) Table() (header []string, output [][]string) {
 				errorMessage = sr.Execution.ExecutionResult.ErrorMessage
 				id = sr.Execution.Id
 			}
-			row := []string{status, sr.Step.FullName(), id, errorMessage}
+			var attempts string
+			if sr.Attempts > 0 {
+				attempts = fmt.Sprintf("%d", sr.Attempts)
+			}
+			row := []string{status, sr.Step.FullName(), id, attempts, errorMessage}
 			output = append(output, row)
 		case TestSuiteStepTypeDelay:
-			row := []string{status, sr.Step.FullName(), "", ""}
+			row := []string{status, sr.Step.FullName(), "", "", ""}
+			output = append(output, row)
+		case TestSuiteStepTypeApproval:
+			var decision string
+			if sr.Approval != nil {
+				decision = sr.Approval.String()
+			}
+			row := []string{status, sr.Step.FullName(), "", "", decision}
 			output = append(output, row)
 		}
 	}
@@ -108,3 +180,7 @@ func (e *TestSuiteExecution) IsPassed() bool {
 func (e *TestSuiteExecution) IsFailed() bool {
 	return *e.Status == FAILED_TestSuiteExecutionStatus
 }
+
+func (e *TestSuiteExecution) IsAborted() bool {
+	return *e.Status == ABORTED_TestSuiteExecutionStatus
+}
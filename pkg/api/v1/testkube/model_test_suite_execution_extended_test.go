@@ -0,0 +1,51 @@
+package testkube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRerunTestSuiteExecution(t *testing.T) {
+	result := func(status *ExecutionStatus, outputs map[string]string) TestSuiteStepExecutionResult {
+		return TestSuiteStepExecutionResult{
+			Step:      &TestSuiteStep{Execute: &TestSuiteStepExecuteTest{Name: "api"}},
+			Execution: &Execution{Id: "step", ExecutionResult: &ExecutionResult{Status: status, OutputVariables: outputs}},
+		}
+	}
+
+	execution := TestSuiteExecution{
+		Id:        "1",
+		Status:    TestSuiteExecutionStatusFailed,
+		TestSuite: &ObjectRef{Name: "e2e", Namespace: "testkube"},
+		Params:    map[string]string{"ENV": "prod"},
+		StepResults: []TestSuiteStepExecutionResult{
+			result(ExecutionStatusPassed, map[string]string{"userId": "123"}),
+			result(ExecutionStatusSkipped, nil),
+			result(ExecutionStatusFailed, nil),
+			result(ExecutionStatusQueued, nil),
+		},
+	}
+
+	from := execution.FailedStepIndex()
+	assert.Equal(t, 2, from)
+
+	rerun := NewRerunTestSuiteExecution(execution, from)
+	assert.NotEqual(t, execution.Id, rerun.Id)
+	assert.Equal(t, "1", rerun.RerunOf)
+	assert.True(t, rerun.IsRunning())
+	assert.Equal(t, execution.Params, rerun.Params)
+	assert.Equal(t, execution.TestSuite, rerun.TestSuite)
+	assert.Len(t, rerun.StepResults, 4)
+
+	for i, step := range rerun.StepResults {
+		assert.Equal(t, i < from, step.CarriedOver, i)
+		assert.Equal(t, execution.StepResults[i].Step, step.Step, i)
+	}
+	assert.Equal(t, map[string]string{"userId": "123"}, rerun.StepResults[0].Execution.ExecutionResult.OutputVariables)
+	assert.True(t, rerun.StepResults[2].Execution.ExecutionResult.IsQueued())
+	assert.True(t, rerun.StepResults[3].Execution.ExecutionResult.IsQueued())
+
+	execution.StepResults = execution.StepResults[:2]
+	assert.Equal(t, -1, execution.FailedStepIndex(), "passed and skipped steps aren't rerun")
+}
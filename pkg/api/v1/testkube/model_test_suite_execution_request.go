@@ -20,5 +20,6 @@ type TestSuiteExecutionRequest struct {
 	// http proxy for executor containers
 	HttpProxy string `json:"httpProxy,omitempty"`
 	// https proxy for executor containers
-	HttpsProxy string `json:"httpsProxy,omitempty"`
+	HttpsProxy     string          `json:"httpsProxy,omitempty"`
+	RunningContext *RunningContext `json:"runningContext,omitempty"`
 }
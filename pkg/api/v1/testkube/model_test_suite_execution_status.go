@@ -17,4 +17,6 @@ const (
 	RUNNING_TestSuiteExecutionStatus TestSuiteExecutionStatus = "running"
 	PASSED_TestSuiteExecutionStatus  TestSuiteExecutionStatus = "passed"
 	FAILED_TestSuiteExecutionStatus  TestSuiteExecutionStatus = "failed"
+	ABORTED_TestSuiteExecutionStatus TestSuiteExecutionStatus = "aborted"
+	SKIPPED_TestSuiteExecutionStatus TestSuiteExecutionStatus = "skipped"
 )
@@ -13,6 +13,8 @@ var TestSuiteExecutionStatusFailed = TestSuiteExecutionStatusPtr(FAILED_TestSuit
 var TestSuiteExecutionStatusPassed = TestSuiteExecutionStatusPtr(PASSED_TestSuiteExecutionStatus)
 var TestSuiteExecutionStatusQueued = TestSuiteExecutionStatusPtr(QUEUED_TestSuiteExecutionStatus)
 var TestSuiteExecutionStatusRunning = TestSuiteExecutionStatusPtr(RUNNING_TestSuiteExecutionStatus)
+var TestSuiteExecutionStatusAborted = TestSuiteExecutionStatusPtr(ABORTED_TestSuiteExecutionStatus)
+var TestSuiteExecutionStatusSkipped = TestSuiteExecutionStatusPtr(SKIPPED_TestSuiteExecutionStatus)
 
 // TestSuiteExecutionStatuses is an array of TestSuiteExecutionStatus
 type TestSuiteExecutionStatuses []TestSuiteExecutionStatus
@@ -34,6 +36,8 @@ func ParseTestSuiteExecutionStatusList(source, separator string) (statusList Tes
 		PASSED_TestSuiteExecutionStatus:  {},
 		QUEUED_TestSuiteExecutionStatus:  {},
 		RUNNING_TestSuiteExecutionStatus: {},
+		ABORTED_TestSuiteExecutionStatus: {},
+		SKIPPED_TestSuiteExecutionStatus: {},
 	}
 
 	if source == "" {
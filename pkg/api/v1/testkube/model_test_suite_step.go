@@ -10,7 +10,16 @@
 package testkube
 
 type TestSuiteStep struct {
-	StopTestOnFailure bool                      `json:"stopTestOnFailure"`
-	Execute           *TestSuiteStepExecuteTest `json:"execute,omitempty"`
-	Delay             *TestSuiteStepDelay       `json:"delay,omitempty"`
+	StopTestOnFailure bool `json:"stopTestOnFailure"`
+	// step timeout in seconds, test execution exceeding it is aborted and step fails
+	Timeout int32 `json:"timeout,omitempty"`
+	// number of retries of failed step
+	Retries int32 `json:"retries,omitempty"`
+	// failed step doesn't fail test suite execution and doesn't stop it
+	ContinueOnError bool `json:"continueOnError,omitempty"`
+	// condition step is executed on, e.g. failure() or params.ENV == "prod", steps without condition are executed until step with stop on failure fails
+	Condition string                    `json:"condition,omitempty"`
+	Execute   *TestSuiteStepExecuteTest `json:"execute,omitempty"`
+	Delay     *TestSuiteStepDelay       `json:"delay,omitempty"`
+	Approval  *TestSuiteStepApproval    `json:"approval,omitempty"`
 }
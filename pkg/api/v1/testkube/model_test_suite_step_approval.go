@@ -0,0 +1,17 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+type TestSuiteStepApproval struct {
+	// message shown to approvers
+	Message string `json:"message,omitempty"`
+	// approval timeout in seconds, test suite execution is aborted when step isn't approved in time
+	Timeout int32 `json:"timeout,omitempty"`
+}
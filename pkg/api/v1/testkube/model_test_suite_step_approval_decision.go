@@ -0,0 +1,26 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+import (
+	"time"
+)
+
+// approval step decision
+type TestSuiteStepApprovalDecision struct {
+	// step is approved and test suite execution continues, rejected step aborts test suite execution
+	Approved bool `json:"approved"`
+	// identity of approver
+	Approver string `json:"approver"`
+	// decision comment
+	Comment string `json:"comment,omitempty"`
+	// decision time
+	DecisionTime time.Time `json:"decisionTime,omitempty"`
+}
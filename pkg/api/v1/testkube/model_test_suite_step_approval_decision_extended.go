@@ -0,0 +1,17 @@
+package testkube
+
+import "fmt"
+
+// String returns decision with approver, e.g. approved by jane
+func (d TestSuiteStepApprovalDecision) String() string {
+	decision := "rejected"
+	if d.Approved {
+		decision = "approved"
+	}
+
+	if d.Approver == "" {
+		return decision
+	}
+
+	return fmt.Sprintf("%s by %s", decision, d.Approver)
+}
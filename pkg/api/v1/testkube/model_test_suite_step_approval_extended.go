@@ -0,0 +1,5 @@
+package testkube
+
+func (s TestSuiteStepApproval) FullName() string {
+	return "approval"
+}
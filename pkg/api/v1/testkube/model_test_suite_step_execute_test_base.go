@@ -14,4 +14,6 @@ type TestSuiteStepExecuteTest struct {
 	Namespace string `json:"namespace,omitempty"`
 	// object name
 	Name string `json:"name"`
+	// step params override test suite params and are overridden by test suite execution request params
+	Params map[string]string `json:"params,omitempty"`
 }
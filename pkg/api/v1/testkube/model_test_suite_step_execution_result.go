@@ -14,4 +14,11 @@ type TestSuiteStepExecutionResult struct {
 	Step      *TestSuiteStep `json:"step,omitempty"`
 	Test      *ObjectRef     `json:"test,omitempty"`
 	Execution *Execution     `json:"execution,omitempty"`
+	// number of step executions, including retries
+	Attempts int32 `json:"attempts,omitempty"`
+	// whether last step execution exceeded step timeout
+	TimedOut bool                           `json:"timedOut,omitempty"`
+	Approval *TestSuiteStepApprovalDecision `json:"approval,omitempty"`
+	// whether step result was carried over from rerun test suite execution
+	CarriedOver bool `json:"carriedOver,omitempty"`
 }
@@ -24,3 +24,30 @@ func (r *TestSuiteStepExecutionResult) IsFailed() bool {
 
 	return true
 }
+
+// Abort marks step execution aborted
+func (r *TestSuiteStepExecutionResult) Abort() {
+	r.setStatus(ExecutionStatusAborted)
+}
+
+// Skip marks step skipped, skipped steps aren't executed
+func (r *TestSuiteStepExecutionResult) Skip() {
+	r.setStatus(ExecutionStatusSkipped)
+}
+
+func (r *TestSuiteStepExecutionResult) setStatus(status *ExecutionStatus) {
+	if r.Execution == nil {
+		r.Execution = NewQueuedExecution()
+	}
+
+	if r.Execution.ExecutionResult == nil {
+		r.Execution.ExecutionResult = &ExecutionResult{}
+	}
+
+	r.Execution.ExecutionResult.Status = status
+}
+
+func (r *TestSuiteStepExecutionResult) IsAborted() bool {
+	return r.Execution != nil && r.Execution.ExecutionResult != nil && r.Execution.ExecutionResult.Status != nil &&
+		r.Execution.ExecutionResult.IsAborted()
+}
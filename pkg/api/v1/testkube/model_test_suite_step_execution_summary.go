@@ -18,4 +18,8 @@ type TestSuiteStepExecutionSummary struct {
 	TestName string             `json:"testName,omitempty"`
 	Status   *ExecutionStatus   `json:"status"`
 	Type_    *TestSuiteStepType `json:"type,omitempty"`
+	// number of step executions, including retries
+	Attempts int32 `json:"attempts,omitempty"`
+	// whether last step execution exceeded step timeout
+	TimedOut bool `json:"timedOut,omitempty"`
 }
@@ -1,5 +1,11 @@
 package testkube
 
+import (
+	"fmt"
+
+	"github.com/kubeshop/testkube/pkg/condition"
+)
+
 func (s TestSuiteStep) Type() *TestSuiteStepType {
 	if s.Execute != nil {
 		return TestSuiteStepTypeExecuteTest
@@ -7,6 +13,9 @@ func (s TestSuiteStep) Type() *TestSuiteStepType {
 	if s.Delay != nil {
 		return TestSuiteStepTypeDelay
 	}
+	if s.Approval != nil {
+		return TestSuiteStepTypeApproval
+	}
 	return nil
 }
 
@@ -16,7 +25,44 @@ func (s TestSuiteStep) FullName() string {
 		return s.Delay.FullName()
 	case TestSuiteStepTypeExecuteTest:
 		return s.Execute.FullName()
+	case TestSuiteStepTypeApproval:
+		return s.Approval.FullName()
 	default:
 		return "unknown"
 	}
 }
+
+// MaxTestSuiteStepRetries is a maximal number of retries of failed test suite step
+const MaxTestSuiteStepRetries = 10
+
+// ValidateTestSuiteSteps checks that step timeouts and retries are set only for test steps and are in allowed range
+// and step conditions are valid
+func ValidateTestSuiteSteps(steps ...[]TestSuiteStep) error {
+	for _, group := range steps {
+		for _, step := range group {
+			if step.Timeout < 0 {
+				return fmt.Errorf("step %s timeout can't be negative", step.FullName())
+			}
+
+			if step.Retries < 0 || step.Retries > MaxTestSuiteStepRetries {
+				return fmt.Errorf("step %s retries must be between 0 and %d", step.FullName(), MaxTestSuiteStepRetries)
+			}
+
+			if (step.Timeout > 0 || step.Retries > 0) && step.Type() != TestSuiteStepTypeExecuteTest {
+				return fmt.Errorf("step %s timeout and retries are supported only for test steps", step.FullName())
+			}
+
+			if step.Approval != nil && step.Approval.Timeout < 0 {
+				return fmt.Errorf("step %s approval timeout can't be negative", step.FullName())
+			}
+
+			if step.Condition != "" {
+				if _, err := condition.Parse(step.Condition); err != nil {
+					return fmt.Errorf("step %s has invalid condition: %w", step.FullName(), err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
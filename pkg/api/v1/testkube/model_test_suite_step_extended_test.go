@@ -0,0 +1,23 @@
+package testkube
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTestSuiteSteps(t *testing.T) {
+	test := TestSuiteStep{Execute: &TestSuiteStepExecuteTest{Name: "api"}, Timeout: 60, Retries: 2}
+	delay := TestSuiteStep{Delay: &TestSuiteStepDelay{Duration: 1000}, ContinueOnError: true}
+	assert.NoError(t, ValidateTestSuiteSteps([]TestSuiteStep{delay}, []TestSuiteStep{test}, nil))
+	assert.NoError(t, ValidateTestSuiteSteps([]TestSuiteStep{{Execute: test.Execute, Condition: `failure()`}}))
+
+	for _, step := range []TestSuiteStep{
+		{Execute: test.Execute, Timeout: -1},
+		{Execute: test.Execute, Retries: MaxTestSuiteStepRetries + 1},
+		{Delay: delay.Delay, Retries: 1},
+		{Execute: test.Execute, Condition: `failure() &&`},
+	} {
+		assert.Error(t, ValidateTestSuiteSteps([]TestSuiteStep{step}))
+	}
+}
@@ -15,4 +15,5 @@ type TestSuiteStepType string
 const (
 	EXECUTE_TEST_TestSuiteStepType TestSuiteStepType = "executeTest"
 	DELAY_TestSuiteStepType        TestSuiteStepType = "delay"
+	APPROVAL_TestSuiteStepType     TestSuiteStepType = "approval"
 )
@@ -7,4 +7,5 @@ func TestSuiteStepTypePtr(stepType TestSuiteStepType) *TestSuiteStepType {
 var (
 	TestSuiteStepTypeExecuteTest = TestSuiteStepTypePtr(EXECUTE_TEST_TestSuiteStepType)
 	TestSuiteStepTypeDelay       = TestSuiteStepTypePtr(DELAY_TestSuiteStepType)
+	TestSuiteStepTypeApproval    = TestSuiteStepTypePtr(APPROVAL_TestSuiteStepType)
 )
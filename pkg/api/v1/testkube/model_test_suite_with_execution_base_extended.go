@@ -7,7 +7,7 @@ import (
 type TestSuiteWithExecutions []TestSuiteWithExecution
 
 func (testSutes TestSuiteWithExecutions) Table() (header []string, output [][]string) {
-	header = []string{"Name", "Description", "Steps", "Labels", "Schedule", "Status", "Execution id"}
+	header = []string{"Name", "Description", "Steps", "Owner", "Labels", "Schedule", "Status", "Execution id"}
 	for _, e := range testSutes {
 		if e.TestSuite == nil {
 			continue
@@ -26,6 +26,7 @@ func (testSutes TestSuiteWithExecutions) Table() (header []string, output [][]st
 			e.TestSuite.Name,
 			e.TestSuite.Description,
 			fmt.Sprintf("%d", len(e.TestSuite.Steps)),
+			e.TestSuite.Ownership.String(),
 			LabelsToString(e.TestSuite.Labels),
 			e.TestSuite.Schedule,
 			status,
@@ -0,0 +1,30 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+// test executions summary
+type TestSummary struct {
+	// test name
+	TestName string `json:"testName"`
+	// number of finished executions
+	Executions int32 `json:"executions"`
+	// number of passed executions
+	Passed int32 `json:"passed"`
+	// number of failed executions
+	Failed int32 `json:"failed"`
+	// passed to finished executions ratio
+	PassRate float64 `json:"passRate"`
+	// mean execution duration
+	MeanDuration string `json:"meanDuration,omitempty"`
+	// number of failed executions in a row since last passed one
+	CurrentFailureStreak int32 `json:"currentFailureStreak"`
+	// the longest number of failed executions in a row
+	LongestFailureStreak int32 `json:"longestFailureStreak"`
+}
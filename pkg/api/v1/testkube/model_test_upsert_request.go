@@ -29,4 +29,23 @@ type TestUpsertRequest struct {
 	Schedule string `json:"schedule,omitempty"`
 	// default test params can be overriden by execution params or by test suite params
 	Params map[string]string `json:"params,omitempty"`
+	// secret keys mounted as files into executor container
+	SecretMounts []SecretMount `json:"secretMounts,omitempty"`
+	// iteration data file, test is run once for each data row
+	DataFile *TestContent `json:"dataFile,omitempty"`
+	// names of params with secret values, the values are redacted in logs, results and notifications
+	SecretParams []string `json:"secretParams,omitempty"`
+	// owner, team and contact of people responsible for the test
+	Ownership *Ownership `json:"ownership,omitempty"`
+	// disabled tests are skipped by selector runs, their cron jobs are suspended and manual runs have to be forced
+	Enabled *bool `json:"enabled,omitempty"`
+	// windows of planned downtime when scheduled executions of the test are skipped
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	Placement          *Placement          `json:"placement,omitempty"`
+	LogCollection      *LogCollection      `json:"logCollection,omitempty"`
+	Prechecks          *Prechecks          `json:"prechecks,omitempty"`
+	Dependencies       *TestDependencies   `json:"dependencies,omitempty"`
+	Newman             *NewmanOptions      `json:"newman,omitempty"`
+	// tests and test suites executed when test execution passes
+	Triggers []ExecutionTrigger `json:"triggers,omitempty"`
 }
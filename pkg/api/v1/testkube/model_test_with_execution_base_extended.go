@@ -3,7 +3,7 @@ package testkube
 type TestWithExecutions []TestWithExecution
 
 func (t TestWithExecutions) Table() (header []string, output [][]string) {
-	header = []string{"Name", "Type", "Created", "Labels", "Schedule", "Status", "Execution id"}
+	header = []string{"Name", "Type", "Created", "Owner", "Labels", "Schedule", "Status", "Execution id"}
 	for _, e := range t {
 		if e.Test == nil {
 			continue
@@ -22,6 +22,7 @@ func (t TestWithExecutions) Table() (header []string, output [][]string) {
 			e.Test.Name,
 			e.Test.Type_,
 			e.Test.Created.String(),
+			e.Test.Ownership.String(),
 			LabelsToString(e.Test.Labels),
 			e.Test.Schedule,
 			status,
@@ -0,0 +1,26 @@
+/*
+ * Testkube API
+ *
+ * Testkube provides a Kubernetes-native framework for test definition, execution and results
+ *
+ * API version: 1.0.0
+ * Contact: testkube@kubeshop.io
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package testkube
+
+import (
+	"time"
+)
+
+// aggregated report of tests executions
+type TestsSummaryReport struct {
+	// label selector used for tests selection
+	Selector string `json:"selector,omitempty"`
+	// report start time, executions started since then are taken into account
+	Since time.Time `json:"since"`
+	// report generation time
+	GeneratedAt time.Time `json:"generatedAt"`
+	// per test summaries
+	Tests []TestSummary `json:"tests"`
+}
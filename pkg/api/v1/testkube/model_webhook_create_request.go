@@ -15,6 +15,16 @@ type WebhookCreateRequest struct {
 	Namespace string             `json:"namespace,omitempty"`
 	Uri       string             `json:"uri"`
 	Events    []WebhookEventType `json:"events,omitempty"`
+	// label selector of tests which executions are notified, all tests are notified when empty
+	Selector string `json:"selector,omitempty"`
+	// statuses of notified executions, executions with any status are notified when empty
+	Statuses []ExecutionStatus `json:"statuses,omitempty"`
+	// URL of proxy used to call webhook, proxy environment variables are used when empty
+	ProxyURL string `json:"proxyURL,omitempty"`
+	// name of Secret with ca.crt CA bundle and tls.crt and tls.key mTLS client certificate used to call webhook
+	TLSSecret string `json:"tlsSecret,omitempty"`
+	// secret used to sign webhook payloads, stored in Kubernetes Secret
+	SigningSecret string `json:"signingSecret,omitempty"`
 	// executor labels
 	Labels map[string]string `json:"labels,omitempty"`
 }
@@ -9,9 +9,19 @@
  */
 package testkube
 
+import "net/http"
+
 // CRD based executor data
 type WebhookEvent struct {
 	Uri       string            `json:"uri,omitempty"`
 	Type_     *WebhookEventType `json:"type"`
 	Execution *Execution        `json:"execution,omitempty"`
+	Digest    *ExecutionsDigest `json:"digest,omitempty"`
+	Approval  *ApprovalRequest  `json:"approval,omitempty"`
+	// finished test suite execution of end-testsuite event
+	TestSuiteExecution *TestSuiteExecution `json:"testSuiteExecution,omitempty"`
+	// secret used to sign event payload, never sent to webhook
+	SigningSecret string `json:"-"`
+	// client with webhook proxy and TLS options, emitter client is used when empty
+	HTTPClient *http.Client `json:"-"`
 }
@@ -13,6 +13,9 @@ type WebhookEventType string
 
 // List of WebhookEventType
 const (
-	START_TEST_WebhookEventType WebhookEventType = "start-test"
-	END_TEST_WebhookEventType   WebhookEventType = "end-test"
+	START_TEST_WebhookEventType        WebhookEventType = "start-test"
+	END_TEST_WebhookEventType          WebhookEventType = "end-test"
+	DIGEST_WebhookEventType            WebhookEventType = "digest"
+	APPROVAL_REQUIRED_WebhookEventType WebhookEventType = "approval-required"
+	END_TESTSUITE_WebhookEventType     WebhookEventType = "end-testsuite"
 )
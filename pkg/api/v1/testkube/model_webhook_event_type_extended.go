@@ -9,6 +9,9 @@ func WebhookTypePtr(t WebhookEventType) *WebhookEventType {
 }
 
 var (
-	WebhookTypeStartTest = WebhookTypePtr(START_TEST_WebhookEventType)
-	WebhookTypeEndTest   = WebhookTypePtr(END_TEST_WebhookEventType)
+	WebhookTypeStartTest        = WebhookTypePtr(START_TEST_WebhookEventType)
+	WebhookTypeEndTest          = WebhookTypePtr(END_TEST_WebhookEventType)
+	WebhookTypeDigest           = WebhookTypePtr(DIGEST_WebhookEventType)
+	WebhookTypeApprovalRequired = WebhookTypePtr(APPROVAL_REQUIRED_WebhookEventType)
+	WebhookTypeEndTestSuite     = WebhookTypePtr(END_TESTSUITE_WebhookEventType)
 )
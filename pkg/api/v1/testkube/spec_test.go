@@ -0,0 +1,198 @@
+package testkube
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	apiv1 "github.com/kubeshop/testkube/api/v1"
+	"github.com/kubeshop/testkube/pkg/openapi"
+)
+
+const generatedHeader = "Generated by: Swagger Codegen"
+
+// TestModelsMatchSpec checks that generated models have the same properties and types as OpenAPI schemas,
+// models have to be regenerated with make openapi-generate-model-testkube when the spec changes
+func TestModelsMatchSpec(t *testing.T) {
+	spec, err := openapi.Load(apiv1.Spec)
+	require.NoError(t, err)
+
+	files, err := filepath.Glob("model_*.go")
+	require.NoError(t, err)
+
+	types := map[string]ast.Expr{}
+	structs := map[string]*ast.StructType{}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		source, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		parsed, err := parser.ParseFile(token.NewFileSet(), file, source, 0)
+		require.NoError(t, err)
+
+		generated := strings.Contains(string(source), generatedHeader)
+		for _, decl := range parsed.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, s := range gen.Specs {
+				typeSpec := s.(*ast.TypeSpec)
+				types[typeSpec.Name.Name] = typeSpec.Type
+				if structType, ok := typeSpec.Type.(*ast.StructType); ok && generated {
+					structs[typeSpec.Name.Name] = structType
+				}
+			}
+		}
+	}
+
+	var problems []string
+	for name, structType := range structs {
+		schema := spec.Schema(name)
+		if schema == nil {
+			problems = append(problems, name+": schema is missing in spec")
+			continue
+		}
+
+		properties, err := spec.Properties(schema)
+		require.NoError(t, err)
+
+		fields := map[string]string{}
+		omitted := map[string]bool{}
+		for _, field := range structType.Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+
+			tag, err := strconv.Unquote(field.Tag.Value)
+			require.NoError(t, err)
+
+			options := strings.Split(reflect.StructTag(tag).Get("json"), ",")
+			if options[0] == "" || options[0] == "-" {
+				continue
+			}
+
+			fields[options[0]] = kind(types, field.Type)
+			omitted[options[0]] = len(options) > 1 && options[1] == "omitempty"
+		}
+
+		for property, propertySchema := range properties {
+			fieldKind, ok := fields[property]
+			if !ok {
+				problems = append(problems, name+"."+property+": field is missing in model")
+				continue
+			}
+
+			schemaKind, err := schemaKind(spec, propertySchema)
+			require.NoError(t, err)
+
+			if fieldKind != "" && schemaKind != "" && fieldKind != schemaKind {
+				problems = append(problems, name+"."+property+": model type "+fieldKind+" doesn't match spec type "+schemaKind)
+			}
+		}
+
+		for _, property := range requiredProperties(spec, schema) {
+			if _, ok := properties[property]; !ok {
+				problems = append(problems, name+"."+property+": required property is missing in spec")
+			} else if omitted[property] {
+				problems = append(problems, name+"."+property+": required property is omitted when empty in model")
+			}
+		}
+
+		for field := range fields {
+			if _, ok := properties[field]; !ok {
+				problems = append(problems, name+"."+field+": property is missing in spec")
+			}
+		}
+	}
+
+	sort.Strings(problems)
+	require.Empty(t, problems)
+}
+
+func requiredProperties(spec *openapi.Spec, schema *openapi.Schema) []string {
+	schema, err := spec.Resolve(schema)
+	if err != nil || schema == nil {
+		return nil
+	}
+
+	required := append([]string{}, schema.Required...)
+	for _, subschema := range schema.AllOf {
+		required = append(required, requiredProperties(spec, subschema)...)
+	}
+
+	return required
+}
+
+// kind returns JSON schema type of Go type, empty kind is returned for types that can't be checked
+func kind(types map[string]ast.Expr, expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return kind(types, t.X)
+	case *ast.ArrayType:
+		return "array"
+	case *ast.MapType:
+		return "object"
+	case *ast.StructType:
+		return "object"
+	case *ast.InterfaceType:
+		return ""
+	case *ast.SelectorExpr:
+		if t.Sel.Name == "Time" {
+			return "string"
+		}
+
+		return ""
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return "string"
+		case "bool":
+			return "boolean"
+		case "int", "int32", "int64":
+			return "integer"
+		case "float32", "float64":
+			return "number"
+		}
+
+		if underlying, ok := types[t.Name]; ok {
+			return kind(types, underlying)
+		}
+	}
+
+	return ""
+}
+
+func schemaKind(spec *openapi.Spec, schema *openapi.Schema) (string, error) {
+	schema, err := spec.Resolve(schema)
+	if err != nil || schema == nil {
+		return "", err
+	}
+
+	if schema.Type != "" {
+		return schema.Type, nil
+	}
+
+	if len(schema.AllOf) == 1 && len(schema.Properties) == 0 {
+		return schemaKind(spec, schema.AllOf[0])
+	}
+
+	if len(schema.AllOf) > 0 || len(schema.Properties) > 0 {
+		return "object", nil
+	}
+
+	return "", nil
+}
@@ -0,0 +1,173 @@
+package artifactscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/k8sclient"
+)
+
+const (
+	// ExecutionIDEnvVarName is a name of environment variable with execution id passed to scan job
+	ExecutionIDEnvVarName = "TESTKUBE_EXECUTION_ID"
+	// ArtifactsEnvVarName is a name of environment variable with JSON list of artifact names and download URLs
+	ArtifactsEnvVarName = "TESTKUBE_ARTIFACTS"
+	// ReportURLEnvVarName is a name of environment variable with URL scan results are PUT to
+	ReportURLEnvVarName = "TESTKUBE_SCAN_REPORT_URL"
+	// ReportTokenEnvVarName is a name of environment variable with bearer token of scan results report
+	ReportTokenEnvVarName = "TESTKUBE_SCAN_REPORT_TOKEN"
+
+	// jobNameSuffix is appended to execution id in scan job name
+	jobNameSuffix = "-artifact-scan"
+	// jobTTLSecondsAfterFinished is a time finished scan jobs are kept for
+	jobTTLSecondsAfterFinished int32 = 3600
+	// jobStartTimeout is a time scan job pod can wait for scheduling on top of job deadline
+	jobStartTimeout = 5 * time.Minute
+	// jobPollInterval is an interval of scan job status checks
+	jobPollInterval = 5 * time.Second
+)
+
+// JobOptions configures scan job, e.g. running trufflehog or clamav over downloaded artifacts
+type JobOptions struct {
+	Image          string
+	Command        []string
+	Namespace      string
+	ServiceAccount string
+	// ReportURL is base API URL, results are reported to <ReportURL>/executions/<id>/artifacts/scans
+	ReportURL string
+	// Deadline is a time after which scan job is terminated
+	Deadline time.Duration
+}
+
+// JobScanner scans artifacts in kubernetes job, job reports scan results back to API
+type JobScanner struct {
+	ClientSet kubernetes.Interface
+	Options   JobOptions
+}
+
+// NewJobScanner creates scanner launching scan jobs in cluster
+func NewJobScanner(options JobOptions) (*JobScanner, error) {
+	clientSet, err := k8sclient.ConnectToK8s()
+	if err != nil {
+		return nil, err
+	}
+
+	return &JobScanner{ClientSet: clientSet, Options: options}, nil
+}
+
+// Scan launches scan job, all artifacts are pending until job reports their results
+func (s *JobScanner) Scan(ctx context.Context, execution testkube.Execution, artifacts []testkube.Artifact, token string) ([]testkube.ArtifactScanResult, error) {
+	job, err := NewJobSpec(execution.Id, artifacts, token, s.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.ClientSet.BatchV1().Jobs(s.Options.Namespace).Create(ctx, job, metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("can't create artifact scan job: %w", err)
+	}
+
+	return nil, nil
+}
+
+// Wait waits until scan job succeeds or fails, jobs without deadline are waited for until context is done
+func (s *JobScanner) Wait(ctx context.Context, executionID string) error {
+	if s.Options.Deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Options.Deadline+jobStartTimeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+	for {
+		job, err := s.ClientSet.BatchV1().Jobs(s.Options.Namespace).Get(ctx, executionID+jobNameSuffix, metav1.GetOptions{})
+		if err != nil && ctx.Err() == nil {
+			return fmt.Errorf("can't get artifact scan job: %w", err)
+		}
+
+		if err == nil {
+			if job.Status.Succeeded > 0 {
+				return nil
+			}
+
+			for _, condition := range job.Status.Conditions {
+				if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+					return fmt.Errorf("artifact scan job failed: %s %s", condition.Reason, condition.Message)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("artifact scan job didn't finish: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// jobArtifact is artifact passed to scan job
+type jobArtifact struct {
+	Name        string `json:"name"`
+	DownloadUrl string `json:"downloadUrl"`
+}
+
+// NewJobSpec returns scan job of execution artifacts, token authenticates scan results report
+func NewJobSpec(executionID string, artifacts []testkube.Artifact, token string, options JobOptions) (*batchv1.Job, error) {
+	list := make([]jobArtifact, len(artifacts))
+	for i, artifact := range artifacts {
+		list[i] = jobArtifact{Name: artifact.Name, DownloadUrl: artifact.DownloadUrl}
+	}
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := jobTTLSecondsAfterFinished
+	var backoffLimit int32
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      executionID + jobNameSuffix,
+			Namespace: options.Namespace,
+			Labels:    map[string]string{"executionId": executionID},
+		},
+		Spec: batchv1.JobSpec{
+			TTLSecondsAfterFinished: &ttl,
+			BackoffLimit:            &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: options.ServiceAccount,
+					Containers: []corev1.Container{
+						{
+							Name:    "scanner",
+							Image:   options.Image,
+							Command: options.Command,
+							Env: []corev1.EnvVar{
+								{Name: ExecutionIDEnvVarName, Value: executionID},
+								{Name: ArtifactsEnvVarName, Value: string(data)},
+								{Name: ReportURLEnvVarName, Value: fmt.Sprintf("%s/executions/%s/artifacts/scans", options.ReportURL, executionID)},
+								{Name: ReportTokenEnvVarName, Value: token},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if options.Deadline > 0 {
+		deadline := int64(options.Deadline.Seconds())
+		job.Spec.ActiveDeadlineSeconds = &deadline
+	}
+
+	return job, nil
+}
@@ -0,0 +1,64 @@
+package artifactscan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestNewJobSpec(t *testing.T) {
+	job, err := NewJobSpec("1", []testkube.Artifact{{Name: "report.html", DownloadUrl: "http://minio/report.html"}}, "token",
+		JobOptions{Image: "trufflehog", Namespace: "testkube", ReportURL: "http://testkube-api-server:8088/v1", Deadline: 10 * time.Minute})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1-artifact-scan", job.Name)
+	assert.Equal(t, int64(600), *job.Spec.ActiveDeadlineSeconds)
+
+	container := job.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "trufflehog", container.Image)
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: ArtifactsEnvVarName, Value: `[{"name":"report.html","downloadUrl":"http://minio/report.html"}]`})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: ReportURLEnvVarName, Value: "http://testkube-api-server:8088/v1/executions/1/artifacts/scans"})
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: ReportTokenEnvVarName, Value: "token"})
+}
+
+func TestJobScannerScan(t *testing.T) {
+	scanner := JobScanner{ClientSet: fake.NewSimpleClientset(), Options: JobOptions{Image: "clamav", Namespace: "testkube"}}
+
+	results, err := scanner.Scan(context.Background(), testkube.Execution{Id: "1"}, []testkube.Artifact{{Name: "report.html"}}, "token")
+	assert.NoError(t, err)
+	assert.Empty(t, results, "artifacts are pending until job reports results")
+
+	_, err = scanner.ClientSet.BatchV1().Jobs("testkube").Get(context.Background(), "1-artifact-scan", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+func TestJobScannerWait(t *testing.T) {
+	newScanner := func(status batchv1.JobStatus) JobScanner {
+		job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "1-artifact-scan", Namespace: "testkube"}, Status: status}
+		return JobScanner{ClientSet: fake.NewSimpleClientset(job), Options: JobOptions{Namespace: "testkube"}}
+	}
+
+	scanner := newScanner(batchv1.JobStatus{Succeeded: 1})
+	assert.NoError(t, scanner.Wait(context.Background(), "1"))
+
+	scanner = newScanner(batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+		{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "DeadlineExceeded"},
+	}})
+	err := scanner.Wait(context.Background(), "1")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "DeadlineExceeded")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	scanner = newScanner(batchv1.JobStatus{Active: 1})
+	assert.Error(t, scanner.Wait(ctx, "1"), "running job times out")
+}
@@ -0,0 +1,16 @@
+package artifactscan
+
+import (
+	"context"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// Scanner is a post-processing hook scanning scraped execution artifacts before they're exposed for download
+type Scanner interface {
+	// Scan starts scanning of artifacts with presigned download URLs, artifacts without returned result
+	// stay pending until their results are reported back to API with token
+	Scan(ctx context.Context, execution testkube.Execution, artifacts []testkube.Artifact, token string) ([]testkube.ArtifactScanResult, error)
+	// Wait waits until started scan of execution finishes, error is returned when scan failed or timed out
+	Wait(ctx context.Context, executionID string) error
+}
@@ -0,0 +1,175 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	executorv1 "github.com/kubeshop/testkube-operator/apis/executor/v1"
+	testsv2 "github.com/kubeshop/testkube-operator/apis/tests/v2"
+	testsuitesv1 "github.com/kubeshop/testkube-operator/apis/testsuite/v1"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const (
+	// FormatVersion is a version of archive layout, archives of other versions can't be restored
+	FormatVersion = "v1"
+
+	metadataFile            = "metadata.json"
+	testsFile               = "tests.json"
+	testSuitesFile          = "testsuites.json"
+	executorsFile           = "executors.json"
+	webhooksFile            = "webhooks.json"
+	executionsFile          = "executions.json"
+	testSuiteExecutionsFile = "testsuiteexecutions.json"
+)
+
+// ErrMissingMetadata is returned when archive isn't Testkube backup
+var ErrMissingMetadata = errors.New("backup archive has no metadata")
+
+// ErrTooLarge is returned when decompressed archive is bigger than read limit
+var ErrTooLarge = errors.New("backup archive is too large")
+
+// Metadata describes backup archive
+type Metadata struct {
+	// FormatVersion is a version of archive layout
+	FormatVersion string `json:"formatVersion"`
+	// ServerVersion is a version of API server which created the backup
+	ServerVersion string `json:"serverVersion,omitempty"`
+	// CreatedAt is a time of backup creation
+	CreatedAt time.Time `json:"createdAt"`
+	// Executions is set when execution metadata are included
+	Executions bool `json:"executions"`
+}
+
+// Backup is a backup of Testkube resource definitions and execution metadata
+type Backup struct {
+	Metadata            Metadata
+	Tests               []testsv2.Test
+	TestSuites          []testsuitesv1.TestSuite
+	Executors           []executorv1.Executor
+	Webhooks            []executorv1.Webhook
+	Executions          []testkube.Execution
+	TestSuiteExecutions []testkube.TestSuiteExecution
+}
+
+type archiveFile struct {
+	name  string
+	value interface{}
+}
+
+// Write writes backup as gzip compressed tar archive with JSON file of each resource kind
+func Write(w io.Writer, backup Backup) error {
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	files := []archiveFile{
+		{metadataFile, backup.Metadata},
+		{testsFile, backup.Tests},
+		{testSuitesFile, backup.TestSuites},
+		{executorsFile, backup.Executors},
+		{webhooksFile, backup.Webhooks},
+	}
+
+	if backup.Metadata.Executions {
+		files = append(files, archiveFile{executionsFile, backup.Executions}, archiveFile{testSuiteExecutionsFile, backup.TestSuiteExecutions})
+	}
+
+	for _, file := range files {
+		data, err := json.Marshal(file.value)
+		if err != nil {
+			return fmt.Errorf("can't encode %s: %w", file.name, err)
+		}
+
+		header := &tar.Header{Name: file.name, Mode: 0644, Size: int64(len(data)), ModTime: backup.Metadata.CreatedAt}
+		if err = tarWriter.WriteHeader(header); err != nil {
+			return fmt.Errorf("can't write %s: %w", file.name, err)
+		}
+
+		if _, err = tarWriter.Write(data); err != nil {
+			return fmt.Errorf("can't write %s: %w", file.name, err)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+
+	return gzipWriter.Close()
+}
+
+// Read reads backup archive of at most maxSize decompressed bytes, unknown files are skipped
+func Read(r io.Reader, maxSize int64) (backup Backup, err error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return backup, fmt.Errorf("can't decompress backup archive: %w", err)
+	}
+	defer gzipReader.Close()
+
+	values := map[string]interface{}{
+		metadataFile:            &backup.Metadata,
+		testsFile:               &backup.Tests,
+		testSuitesFile:          &backup.TestSuites,
+		executorsFile:           &backup.Executors,
+		webhooksFile:            &backup.Webhooks,
+		executionsFile:          &backup.Executions,
+		testSuiteExecutionsFile: &backup.TestSuiteExecutions,
+	}
+
+	hasMetadata := false
+	// small archive can decompress to huge files, so decompressed size is limited too
+	tarReader := tar.NewReader(&limitedReader{reader: gzipReader, maxSize: maxSize})
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return backup, fmt.Errorf("can't read backup archive: %w", err)
+		}
+
+		value, ok := values[header.Name]
+		if !ok {
+			continue
+		}
+
+		if err = json.NewDecoder(tarReader).Decode(value); err != nil {
+			return backup, fmt.Errorf("can't decode %s: %w", header.Name, err)
+		}
+
+		hasMetadata = hasMetadata || header.Name == metadataFile
+	}
+
+	if !hasMetadata {
+		return backup, ErrMissingMetadata
+	}
+
+	if backup.Metadata.FormatVersion != FormatVersion {
+		return backup, fmt.Errorf("unsupported backup format version %s, supported version is %s", backup.Metadata.FormatVersion, FormatVersion)
+	}
+
+	return backup, nil
+}
+
+// limitedReader returns ErrTooLarge when more than maxSize bytes are read
+type limitedReader struct {
+	reader  io.Reader
+	maxSize int64
+	read    int64
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	if r.read > r.maxSize {
+		return n, ErrTooLarge
+	}
+
+	return n, err
+}
@@ -0,0 +1,91 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	executorv1 "github.com/kubeshop/testkube-operator/apis/executor/v1"
+	testsv2 "github.com/kubeshop/testkube-operator/apis/tests/v2"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const maxSize = 1 << 20
+
+func TestWriteRead(t *testing.T) {
+	backup := Backup{
+		Metadata: Metadata{FormatVersion: FormatVersion, ServerVersion: "1.0.0", CreatedAt: time.Date(2022, 8, 1, 0, 0, 0, 0, time.UTC), Executions: true},
+		Tests: []testsv2.Test{{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Labels: map[string]string{"app": "api"}},
+			Spec:       testsv2.TestSpec{Type_: "postman/collection"},
+		}},
+		Webhooks:   []executorv1.Webhook{{ObjectMeta: metav1.ObjectMeta{Name: "slack"}}},
+		Executions: []testkube.Execution{{Id: "1", TestName: "api", Number: 3}},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, Write(&buffer, backup))
+
+	read, err := Read(&buffer, maxSize)
+	require.NoError(t, err)
+	assert.Equal(t, backup.Metadata, read.Metadata)
+	assert.Equal(t, backup.Tests, read.Tests)
+	assert.Equal(t, backup.Webhooks, read.Webhooks)
+	assert.Equal(t, backup.Executions, read.Executions)
+	assert.Empty(t, read.TestSuites)
+
+	t.Run("executions aren't written when not included", func(t *testing.T) {
+		backup.Metadata.Executions = false
+
+		var buffer bytes.Buffer
+		require.NoError(t, Write(&buffer, backup))
+
+		read, err := Read(&buffer, maxSize)
+		require.NoError(t, err)
+		assert.Empty(t, read.Executions)
+	})
+
+	t.Run("other format version", func(t *testing.T) {
+		backup.Metadata.FormatVersion = "v0"
+
+		var buffer bytes.Buffer
+		require.NoError(t, Write(&buffer, backup))
+
+		_, err := Read(&buffer, maxSize)
+		assert.Error(t, err)
+	})
+
+	t.Run("decompressed archive over limit", func(t *testing.T) {
+		backup.Metadata.FormatVersion = FormatVersion
+
+		var buffer bytes.Buffer
+		require.NoError(t, Write(&buffer, backup))
+
+		_, err := Read(&buffer, 100)
+		assert.ErrorIs(t, err, ErrTooLarge)
+	})
+}
+
+func TestReadWithoutMetadata(t *testing.T) {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: testsFile, Mode: 0644, Size: 2}))
+	_, err := tarWriter.Write([]byte("[]"))
+	require.NoError(t, err)
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+
+	_, err = Read(&buffer, maxSize)
+	assert.ErrorIs(t, err, ErrMissingMetadata)
+
+	_, err = Read(bytes.NewBufferString("not an archive"), maxSize)
+	assert.Error(t, err)
+}
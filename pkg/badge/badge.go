@@ -0,0 +1,104 @@
+package badge
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const (
+	ColorPassing = "#4c1"
+	ColorFailing = "#e05d44"
+	ColorUnknown = "#9f9f9f"
+
+	// charWidth is an approximate width of 11px Verdana character used for badge width calculation
+	charWidth = 7
+	padding   = 10
+)
+
+// Badge is a status badge with label on the left and message on the right side
+type Badge struct {
+	Label   string
+	Message string
+	Color   string
+}
+
+// ForExecution returns badge of test latest execution, nil execution renders unknown badge
+func ForExecution(label string, execution *testkube.Execution, now time.Time) Badge {
+	badge := Badge{Label: label, Message: "unknown", Color: ColorUnknown}
+	if execution == nil || execution.ExecutionResult == nil || execution.ExecutionResult.Status == nil {
+		return badge
+	}
+
+	switch *execution.ExecutionResult.Status {
+	case testkube.PASSED_ExecutionStatus:
+		badge.Message, badge.Color = "passing", ColorPassing
+	case testkube.FAILED_ExecutionStatus, testkube.TIMEOUT_ExecutionStatus:
+		badge.Message, badge.Color = "failing", ColorFailing
+	}
+
+	runTime := execution.EndTime
+	if runTime.IsZero() {
+		runTime = execution.StartTime
+	}
+
+	if !runTime.IsZero() {
+		badge.Message += " | " + Ago(now.Sub(runTime))
+	}
+
+	return badge
+}
+
+// Ago formats duration since last run in short human readable form
+func Ago(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+var svgTemplate = template.Must(template.New("badge").Parse(
+	`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="20" role="img" aria-label="{{.Label}}: {{.Message}}">` +
+		`<title>{{.Label}}: {{.Message}}</title>` +
+		`<rect width="{{.LabelWidth}}" height="20" fill="#555"/>` +
+		`<rect x="{{.LabelWidth}}" width="{{.MessageWidth}}" height="20" fill="{{.Color}}"/>` +
+		`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` +
+		`<text x="{{.LabelX}}" y="14">{{.Label}}</text>` +
+		`<text x="{{.MessageX}}" y="14">{{.Message}}</text>` +
+		`</g></svg>`))
+
+// SVG renders badge as SVG image
+func (b Badge) SVG() ([]byte, error) {
+	labelWidth := len(b.Label)*charWidth + padding
+	messageWidth := len(b.Message)*charWidth + padding
+
+	var buf bytes.Buffer
+	err := svgTemplate.Execute(&buf, map[string]interface{}{
+		"Label":        escape(b.Label),
+		"Message":      escape(b.Message),
+		"Color":        escape(b.Color),
+		"Width":        labelWidth + messageWidth,
+		"LabelWidth":   labelWidth,
+		"MessageWidth": messageWidth,
+		"LabelX":       labelWidth / 2,
+		"MessageX":     labelWidth + messageWidth/2,
+	})
+
+	return buf.Bytes(), err
+}
+
+func escape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,47 @@
+package badge
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestForExecution(t *testing.T) {
+	now := time.Date(2022, 8, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("passed execution renders passing badge with last run time", func(t *testing.T) {
+		badge := ForExecution("test", &testkube.Execution{
+			EndTime:         now.Add(-3 * time.Hour),
+			ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed},
+		}, now)
+
+		assert.Equal(t, Badge{Label: "test", Message: "passing | 3h ago", Color: ColorPassing}, badge)
+	})
+
+	t.Run("timed out execution renders failing badge", func(t *testing.T) {
+		badge := ForExecution("test", &testkube.Execution{
+			StartTime:       now.Add(-30 * time.Minute),
+			ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusTimeout},
+		}, now)
+
+		assert.Equal(t, Badge{Label: "test", Message: "failing | 30m ago", Color: ColorFailing}, badge)
+	})
+
+	t.Run("missing execution renders unknown badge", func(t *testing.T) {
+		badge := ForExecution("test", nil, now)
+
+		assert.Equal(t, Badge{Label: "test", Message: "unknown", Color: ColorUnknown}, badge)
+	})
+}
+
+func TestSVG(t *testing.T) {
+	svg, err := Badge{Label: "a<b", Message: "passing", Color: ColorPassing}.SVG()
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(svg), "a&lt;b")
+	assert.Contains(t, string(svg), `fill="#4c1"`)
+	assert.NotContains(t, string(svg), "a<b")
+}
@@ -0,0 +1,72 @@
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// Prefix marks compressed values, values without it are stored as they are
+	Prefix = "gzip:v1:"
+	// minSize is a size of smallest compressed value, gzip header and base64 encoding outweigh savings of shorter ones
+	minSize = 512
+)
+
+// IsCompressed checks if value was compressed
+func IsCompressed(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Compress returns gzip compressed base64 encoded value, short, already compressed and badly compressible values
+// are returned as they are
+func Compress(value string) (string, error) {
+	if len(value) < minSize || IsCompressed(value) {
+		return value, nil
+	}
+
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write([]byte(value)); err != nil {
+		return "", fmt.Errorf("can't compress value: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("can't compress value: %w", err)
+	}
+
+	compressed := Prefix + base64.RawStdEncoding.EncodeToString(buffer.Bytes())
+	if len(compressed) >= len(value) {
+		return value, nil
+	}
+
+	return compressed, nil
+}
+
+// Decompress returns decompressed value, values which weren't compressed are returned as they are
+func Decompress(value string) (string, error) {
+	if !IsCompressed(value) {
+		return value, nil
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(value, Prefix))
+	if err != nil {
+		return "", fmt.Errorf("can't decode compressed value: %w", err)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("can't decompress value: %w", err)
+	}
+	defer reader.Close()
+
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("can't decompress value: %w", err)
+	}
+
+	return string(decompressed), nil
+}
@@ -0,0 +1,67 @@
+package compression
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestCompress(t *testing.T) {
+	output := strings.Repeat("Running test spec.cy.js\n", 100)
+
+	compressed, err := Compress(output)
+	require.NoError(t, err)
+	assert.True(t, IsCompressed(compressed))
+	assert.Less(t, len(compressed), len(output))
+
+	again, err := Compress(compressed)
+	require.NoError(t, err)
+	assert.Equal(t, compressed, again, "compressed value isn't compressed twice")
+
+	decompressed, err := Decompress(compressed)
+	require.NoError(t, err)
+	assert.Equal(t, output, decompressed)
+
+	short, err := Compress("passed")
+	require.NoError(t, err)
+	assert.Equal(t, "passed", short)
+
+	plain, err := Decompress("stored before compression")
+	require.NoError(t, err)
+	assert.Equal(t, "stored before compression", plain)
+
+	_, err = Decompress(Prefix + "not gzip")
+	assert.Error(t, err)
+}
+
+func TestCompressExecution(t *testing.T) {
+	execution := testkube.Execution{
+		Id:              "1",
+		ExecutionResult: &testkube.ExecutionResult{Output: strings.Repeat("a", 1000)},
+	}
+
+	compressed, err := CompressExecution(execution)
+	require.NoError(t, err)
+	assert.True(t, IsCompressed(compressed.ExecutionResult.Output))
+	assert.False(t, IsCompressed(execution.ExecutionResult.Output), "passed execution isn't changed")
+
+	decompressed, err := DecompressExecution(compressed)
+	require.NoError(t, err)
+	assert.Equal(t, execution, decompressed)
+
+	suiteExecution := testkube.TestSuiteExecution{
+		StepResults: []testkube.TestSuiteStepExecutionResult{{Execution: &execution}, {}},
+	}
+
+	compressedSuite, err := CompressTestSuiteExecution(suiteExecution)
+	require.NoError(t, err)
+	assert.True(t, IsCompressed(compressedSuite.StepResults[0].Execution.ExecutionResult.Output))
+
+	decompressedSuite, err := DecompressTestSuiteExecution(compressedSuite)
+	require.NoError(t, err)
+	assert.Equal(t, suiteExecution, decompressedSuite)
+}
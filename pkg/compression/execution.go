@@ -0,0 +1,90 @@
+package compression
+
+import (
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// CompressExecution returns execution with compressed output, execution result is copied, so passed execution isn't changed
+func CompressExecution(execution testkube.Execution) (testkube.Execution, error) {
+	return mapExecution(execution, Compress)
+}
+
+// DecompressExecution returns execution with decompressed output
+func DecompressExecution(execution testkube.Execution) (testkube.Execution, error) {
+	return mapExecution(execution, Decompress)
+}
+
+// CompressResult returns execution result with compressed output
+func CompressResult(result testkube.ExecutionResult) (testkube.ExecutionResult, error) {
+	var err error
+	result.Output, err = Compress(result.Output)
+	return result, err
+}
+
+// DecompressExecutions decompresses executions in place
+func DecompressExecutions(executions []testkube.Execution) (err error) {
+	for i := range executions {
+		if executions[i], err = DecompressExecution(executions[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CompressTestSuiteExecution returns test suite execution with compressed step execution outputs
+func CompressTestSuiteExecution(execution testkube.TestSuiteExecution) (testkube.TestSuiteExecution, error) {
+	return mapTestSuiteExecution(execution, Compress)
+}
+
+// DecompressTestSuiteExecution returns test suite execution with decompressed step execution outputs
+func DecompressTestSuiteExecution(execution testkube.TestSuiteExecution) (testkube.TestSuiteExecution, error) {
+	return mapTestSuiteExecution(execution, Decompress)
+}
+
+// DecompressTestSuiteExecutions decompresses test suite executions in place
+func DecompressTestSuiteExecutions(executions []testkube.TestSuiteExecution) (err error) {
+	for i := range executions {
+		if executions[i], err = DecompressTestSuiteExecution(executions[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func mapExecution(execution testkube.Execution, fn func(string) (string, error)) (testkube.Execution, error) {
+	if execution.ExecutionResult == nil || execution.ExecutionResult.Output == "" {
+		return execution, nil
+	}
+
+	result := *execution.ExecutionResult
+	var err error
+	if result.Output, err = fn(result.Output); err != nil {
+		return execution, err
+	}
+	execution.ExecutionResult = &result
+
+	return execution, nil
+}
+
+func mapTestSuiteExecution(execution testkube.TestSuiteExecution, fn func(string) (string, error)) (testkube.TestSuiteExecution, error) {
+	if execution.StepResults == nil {
+		return execution, nil
+	}
+
+	stepResults := make([]testkube.TestSuiteStepExecutionResult, len(execution.StepResults))
+	for i, stepResult := range execution.StepResults {
+		if stepResult.Execution != nil {
+			stepExecution, err := mapExecution(*stepResult.Execution, fn)
+			if err != nil {
+				return execution, err
+			}
+			stepResult.Execution = &stepExecution
+		}
+		stepResults[i] = stepResult
+	}
+	execution.StepResults = stepResults
+
+	return execution, nil
+}
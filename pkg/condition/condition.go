@@ -0,0 +1,393 @@
+package condition
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	paramsPrefix       = "params."
+	previousStatusName = "previous.status"
+	functionAlways     = "always"
+	functionSuccess    = "success"
+	functionFailure    = "failure"
+	operatorEqual      = "=="
+	operatorNotEqual   = "!="
+	operatorLessEqual  = "<="
+	operatorMoreEqual  = ">="
+	operatorLess       = "<"
+	operatorMore       = ">"
+	operatorAnd        = "&&"
+	operatorOr         = "||"
+	operatorNot        = "!"
+	parenthesisOpen    = "("
+	parenthesisClose   = ")"
+	falseValue         = "false"
+	trueValue          = "true"
+)
+
+// Context is a state of test suite execution conditions are evaluated against
+type Context struct {
+	// Params are test suite execution params merged with output variables of previous steps
+	Params map[string]string
+	// Failed is set when any of previous steps failed
+	Failed bool
+	// PreviousStatus is execution status of previous executed step, empty for first step
+	PreviousStatus string
+	// Variables are values of variables allowed by ParseWith, e.g. of execution policies
+	Variables map[string]string
+}
+
+// Expression is a parsed condition
+type Expression struct {
+	root       node
+	references []string
+}
+
+// References returns variables referenced by condition in order of their occurrence
+func (e Expression) References() []string {
+	return e.references
+}
+
+// ReferencesVariable checks if condition references variable
+func (e Expression) ReferencesVariable(name string) bool {
+	for _, reference := range e.references {
+		if reference == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Evaluate evaluates condition against test suite execution state
+func (e Expression) Evaluate(ctx Context) bool {
+	return e.root.eval(ctx)
+}
+
+// Parse parses condition, e.g. `failure() || params.ENV == "prod"`
+func Parse(condition string) (expression Expression, err error) {
+	return ParseWith(condition)
+}
+
+// ParseWith parses condition which can reference given variables besides step condition ones, variables ending
+// with dot are prefixes, e.g. `labels.` allows `labels.team`
+func ParseWith(condition string, variables ...string) (expression Expression, err error) {
+	tokens, err := tokenize(condition)
+	if err != nil {
+		return expression, err
+	}
+
+	if len(tokens) == 0 {
+		return expression, fmt.Errorf("condition is empty")
+	}
+
+	p := &parser{tokens: tokens, variables: variables}
+	root, err := p.parseOr()
+	if err != nil {
+		return expression, err
+	}
+
+	if !p.done() {
+		return expression, fmt.Errorf("unexpected %q in condition", p.peek().text)
+	}
+
+	return Expression{root: root, references: p.references}, nil
+}
+
+// Evaluate parses and evaluates condition
+func Evaluate(condition string, ctx Context) (bool, error) {
+	expression, err := Parse(condition)
+	if err != nil {
+		return false, err
+	}
+
+	return expression.Evaluate(ctx), nil
+}
+
+type node interface {
+	eval(ctx Context) bool
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n orNode) eval(ctx Context) bool {
+	return n.left.eval(ctx) || n.right.eval(ctx)
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n andNode) eval(ctx Context) bool {
+	return n.left.eval(ctx) && n.right.eval(ctx)
+}
+
+type notNode struct {
+	node node
+}
+
+func (n notNode) eval(ctx Context) bool {
+	return !n.node.eval(ctx)
+}
+
+type functionNode struct {
+	name string
+}
+
+func (n functionNode) eval(ctx Context) bool {
+	switch n.name {
+	case functionSuccess:
+		return !ctx.Failed
+	case functionFailure:
+		return ctx.Failed
+	default:
+		return true
+	}
+}
+
+type compareNode struct {
+	operator    string
+	left, right operand
+}
+
+func (n compareNode) eval(ctx Context) bool {
+	left, right := n.left.value(ctx), n.right.value(ctx)
+	switch n.operator {
+	case operatorEqual:
+		return left == right
+	case operatorNotEqual:
+		return left != right
+	}
+
+	// values which aren't numbers aren't ordered
+	l, err := strconv.Atoi(left)
+	if err != nil {
+		return false
+	}
+
+	r, err := strconv.Atoi(right)
+	if err != nil {
+		return false
+	}
+
+	switch n.operator {
+	case operatorLess:
+		return l < r
+	case operatorLessEqual:
+		return l <= r
+	case operatorMore:
+		return l > r
+	default:
+		return l >= r
+	}
+}
+
+// valueNode is an operand used as a boolean, empty and false values are false
+type valueNode struct {
+	operand operand
+}
+
+func (n valueNode) eval(ctx Context) bool {
+	value := n.operand.value(ctx)
+	return value != "" && value != falseValue
+}
+
+type operand struct {
+	literal   string
+	reference string
+}
+
+func (o operand) value(ctx Context) string {
+	switch {
+	case o.reference == previousStatusName:
+		return ctx.PreviousStatus
+	case strings.HasPrefix(o.reference, paramsPrefix):
+		return ctx.Params[strings.TrimPrefix(o.reference, paramsPrefix)]
+	case o.reference != "":
+		return ctx.Variables[o.reference]
+	default:
+		return o.literal
+	}
+}
+
+type parser struct {
+	tokens     []token
+	position   int
+	variables  []string
+	references []string
+}
+
+// isVariable checks if name is one of variables allowed by ParseWith
+func (p *parser) isVariable(name string) bool {
+	for _, variable := range p.variables {
+		if strings.HasSuffix(variable, ".") {
+			if strings.HasPrefix(name, variable) && len(name) > len(variable) {
+				return true
+			}
+		} else if name == variable {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *parser) done() bool {
+	return p.position >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{}
+	}
+
+	return p.tokens[p.position]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	p.position++
+	return t
+}
+
+func (p *parser) expect(text string) error {
+	if t := p.next(); t.kind != tokenOperator || t.text != text {
+		return fmt.Errorf("expected %q in condition", text)
+	}
+
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is(operatorOr) {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is(operatorAnd) {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.peek().is(operatorNot) {
+		p.next()
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{node: n}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.peek()
+	switch {
+	case t.is(parenthesisOpen):
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return n, p.expect(parenthesisClose)
+	case t.kind == tokenIdentifier && p.position+1 < len(p.tokens) && p.tokens[p.position+1].is(parenthesisOpen):
+		p.position += 2
+		switch t.text {
+		case functionAlways, functionSuccess, functionFailure:
+		default:
+			return nil, fmt.Errorf("unknown function %s() in condition", t.text)
+		}
+		return functionNode{name: t.text}, p.expect(parenthesisClose)
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if op := p.peek(); isComparison(op) {
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return compareNode{operator: op.text, left: left, right: right}, nil
+	}
+
+	return valueNode{operand: left}, nil
+}
+
+func (p *parser) parseOperand() (operand, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		return operand{literal: t.text}, nil
+	case tokenIdentifier:
+		switch {
+		case t.text == trueValue || t.text == falseValue:
+			return operand{literal: t.text}, nil
+		case isNumber(t.text):
+			return operand{literal: t.text}, nil
+		case t.text == previousStatusName, strings.HasPrefix(t.text, paramsPrefix) && len(t.text) > len(paramsPrefix),
+			p.isVariable(t.text):
+			p.references = append(p.references, t.text)
+			return operand{reference: t.text}, nil
+		}
+		return operand{}, fmt.Errorf("unknown variable %s in condition", t.text)
+	case tokenOperator:
+		return operand{}, fmt.Errorf("unexpected %q in condition", t.text)
+	default:
+		return operand{}, fmt.Errorf("unexpected end of condition")
+	}
+}
+
+func isComparison(t token) bool {
+	for _, operator := range []string{operatorEqual, operatorNotEqual, operatorLessEqual, operatorMoreEqual, operatorLess, operatorMore} {
+		if t.is(operator) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNumber(text string) bool {
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return text != ""
+}
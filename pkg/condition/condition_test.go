@@ -0,0 +1,100 @@
+package condition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	ctx := Context{
+		Params:         map[string]string{"ENV": "prod", "USERS": "10", "DEBUG": "false"},
+		Failed:         true,
+		PreviousStatus: "failed",
+	}
+
+	conditions := map[string]bool{
+		`always()`:                                    true,
+		`success()`:                                   false,
+		`failure()`:                                   true,
+		`!failure()`:                                  false,
+		`params.ENV == "prod"`:                        true,
+		`params.ENV != 'prod'`:                        false,
+		`params.USERS == 10`:                          true,
+		`params.MISSING == ""`:                        true,
+		`params.DEBUG`:                                false,
+		`params.ENV`:                                  true,
+		`previous.status == "failed"`:                 true,
+		`success() || params.ENV == "prod"`:           true,
+		`failure() && params.ENV == "staging"`:        false,
+		`!(failure() && params.ENV == "staging")`:     true,
+		`success() && false || true`:                  true,
+		`success() && (false || true)`:                false,
+		`failure() && previous.status != "aborted"`:   true,
+		`params.ENV == "prod" && params.DEBUG != "x"`: true,
+	}
+
+	for condition, expected := range conditions {
+		result, err := Evaluate(condition, ctx)
+		assert.NoError(t, err, condition)
+		assert.Equal(t, expected, result, condition)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, condition := range []string{
+		``,
+		`unknown()`,
+		`status == "failed"`,
+		`params.`,
+		`params.ENV ==`,
+		`params.ENV == "prod`,
+		`(failure()`,
+		`failure() failure()`,
+		`params.ENV = "prod"`,
+		`failure() &&`,
+	} {
+		_, err := Parse(condition)
+		assert.Error(t, err, condition)
+	}
+}
+
+func TestParseWith(t *testing.T) {
+	ctx := Context{
+		Params:    map[string]string{"ENV": "prod"},
+		Variables: map[string]string{"namespace": "testkube", "labels.team": "payments", "running": "12", "time.hour": "9"},
+	}
+
+	conditions := map[string]bool{
+		`namespace == "testkube"`:                     true,
+		`labels.team == "payments" && running >= 10`:  true,
+		`labels.missing == ""`:                        true,
+		`running > 12`:                                false,
+		`running <= 12 && running < 13`:               true,
+		`time.hour < 8 || time.hour >= 18`:            false,
+		`namespace > 1`:                               false,
+		`params.ENV == "prod" && namespace != "prod"`: true,
+	}
+
+	for condition, expected := range conditions {
+		expression, err := ParseWith(condition, "namespace", "running", "time.hour", "labels.")
+		assert.NoError(t, err, condition)
+		assert.Equal(t, expected, expression.Evaluate(ctx), condition)
+	}
+
+	for _, condition := range []string{`labels. == "x"`, `requester == "bot"`, `running >`} {
+		_, err := ParseWith(condition, "running", "labels.")
+		assert.Error(t, err, condition)
+	}
+
+	_, err := Parse(`namespace == "testkube"`)
+	assert.Error(t, err, "step conditions can't reference policy variables")
+}
+
+func TestExpressionReferences(t *testing.T) {
+	expression, err := ParseWith(`running.test > 2 || (previous.status == "failed" && params.ENV == "running")`, "running", "running.test")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"running.test", "previous.status", "params.ENV"}, expression.References())
+	assert.True(t, expression.ReferencesVariable("running.test"))
+	assert.False(t, expression.ReferencesVariable("running"), "string literals aren't references")
+}
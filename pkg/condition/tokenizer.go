@@ -0,0 +1,72 @@
+package condition
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenIdentifier
+	tokenString
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func (t token) is(operator string) bool {
+	return t.kind == tokenOperator && t.text == operator
+}
+
+// operators are matched in order, so longer operators are before their prefixes
+var operators = []string{operatorEqual, operatorNotEqual, operatorLessEqual, operatorMoreEqual, operatorLess, operatorMore,
+	operatorAnd, operatorOr, operatorNot, parenthesisOpen, parenthesisClose}
+
+// tokenize splits condition to identifiers, quoted strings and operators
+func tokenize(condition string) (tokens []token, err error) {
+	for i := 0; i < len(condition); {
+		c := condition[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(condition[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string in condition")
+			}
+			tokens = append(tokens, token{kind: tokenString, text: condition[i+1 : i+1+end]})
+			i += end + 2
+		case isIdentifierChar(c):
+			start := i
+			for i < len(condition) && isIdentifierChar(condition[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, text: condition[start:i]})
+		default:
+			operator := ""
+			for _, o := range operators {
+				if strings.HasPrefix(condition[i:], o) {
+					operator = o
+					break
+				}
+			}
+
+			if operator == "" {
+				return nil, fmt.Errorf("unexpected character %q in condition", c)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: operator})
+			i += len(operator)
+		}
+	}
+
+	return tokens, nil
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '-' || c == '.' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
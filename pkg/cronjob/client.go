@@ -32,6 +32,7 @@ type CronJobOptions struct {
 	Resource string
 	Data     string
 	Labels   map[string]string
+	Suspend  bool
 }
 
 type templateParameters struct {
@@ -45,6 +46,7 @@ type templateParameters struct {
 	CronJobTemplate string
 	Data            string
 	Labels          map[string]string
+	Suspend         bool
 }
 
 // NewClient is a method to create new cron job client
@@ -93,6 +95,7 @@ func (c *Client) Apply(id, name string, options CronJobOptions) error {
 		CronJobTemplate: c.cronJobTemplate,
 		Data:            options.Data,
 		Labels:          options.Labels,
+		Suspend:         options.Suspend,
 	}
 
 	cronJobSpec, err := NewApplySpec(c.Log, parameters)
@@ -183,6 +186,11 @@ func NewApplySpec(log *zap.SugaredLogger, parameters templateParameters) (*batch
 		cronJob.Labels[key] = value
 	}
 
+	// cron jobs of disabled tests and test suites are suspended
+	if cronJob.Spec != nil {
+		cronJob.Spec.WithSuspend(parameters.Suspend)
+	}
+
 	return &cronJob, nil
 }
 
@@ -0,0 +1,127 @@
+package digest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/mapper/executions"
+)
+
+// Batches batch finished executions per notification destination, e.g. webhook or slack, until their digests are sent
+type Batches interface {
+	// Add adds execution to destination batch, batch window starts with first added execution
+	Add(ctx context.Context, destination string, execution testkube.Execution, now time.Time) error
+	// Hold adds execution to destination batch held until given time, later time extends hold of the batch
+	Hold(ctx context.Context, destination string, execution testkube.Execution, now, until time.Time) error
+	// Release removes and returns digests of batches held until given time or earlier
+	Release(ctx context.Context, now time.Time) (map[string]testkube.ExecutionsDigest, error)
+	// Flush removes and returns digests of batches started at least window ago, zero window flushes all batches
+	Flush(ctx context.Context, window time.Duration, now time.Time) (map[string]testkube.ExecutionsDigest, error)
+}
+
+// Collector batches finished executions per notification destination in memory, batches are lost on restart
+type Collector struct {
+	mutex   sync.Mutex
+	batches map[string]*batch
+}
+
+type batch struct {
+	start      time.Time
+	until      time.Time
+	executions []testkube.Execution
+}
+
+// NewCollector returns new digest collector
+func NewCollector() *Collector {
+	return &Collector{batches: map[string]*batch{}}
+}
+
+// Add adds execution to destination batch, batch window starts with first added execution
+func (c *Collector) Add(ctx context.Context, destination string, execution testkube.Execution, now time.Time) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	b, ok := c.batches[destination]
+	if !ok {
+		b = &batch{start: now}
+		c.batches[destination] = b
+	}
+
+	b.executions = append(b.executions, execution)
+	return nil
+}
+
+// Hold adds execution to destination batch held until given time, later time extends hold of the batch
+func (c *Collector) Hold(ctx context.Context, destination string, execution testkube.Execution, now, until time.Time) error {
+	_ = c.Add(ctx, destination, execution, now)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if b := c.batches[destination]; until.After(b.until) {
+		b.until = until
+	}
+	return nil
+}
+
+// Release removes and returns digests of batches held until given time or earlier
+func (c *Collector) Release(ctx context.Context, now time.Time) (map[string]testkube.ExecutionsDigest, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	digests := map[string]testkube.ExecutionsDigest{}
+	for destination, b := range c.batches {
+		if b.until.After(now) {
+			continue
+		}
+
+		digests[destination] = NewDigest(executions.MapToSummary(b.executions), b.start, now)
+		delete(c.batches, destination)
+	}
+
+	return digests, nil
+}
+
+// Flush removes and returns digests of batches started at least window ago, zero window flushes all batches
+func (c *Collector) Flush(ctx context.Context, window time.Duration, now time.Time) (map[string]testkube.ExecutionsDigest, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	digests := map[string]testkube.ExecutionsDigest{}
+	for destination, b := range c.batches {
+		if now.Sub(b.start) < window {
+			continue
+		}
+
+		digests[destination] = NewDigest(executions.MapToSummary(b.executions), b.start, now)
+		delete(c.batches, destination)
+	}
+
+	return digests, nil
+}
+
+// NewDigest summarizes executions finished between start and end
+func NewDigest(list []testkube.ExecutionSummary, start, end time.Time) testkube.ExecutionsDigest {
+	digest := testkube.ExecutionsDigest{
+		StartTime:  start,
+		EndTime:    end,
+		Executions: list,
+	}
+
+	for _, execution := range list {
+		if execution.Status == nil {
+			continue
+		}
+
+		switch *execution.Status {
+		case testkube.PASSED_ExecutionStatus:
+			digest.Passed++
+		case testkube.FAILED_ExecutionStatus, testkube.TIMEOUT_ExecutionStatus:
+			digest.Failed++
+		}
+	}
+
+	return digest
+}
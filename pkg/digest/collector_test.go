@@ -0,0 +1,72 @@
+package digest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestCollector(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 8, 10, 12, 0, 0, 0, time.UTC)
+	window := 15 * time.Minute
+
+	t.Run("batches are flushed after window passes", func(t *testing.T) {
+		collector := NewCollector()
+		collector.Add(ctx, "slack", execution("1", testkube.ExecutionStatusPassed), now)
+		collector.Add(ctx, "slack", execution("2", testkube.ExecutionStatusFailed), now.Add(5*time.Minute))
+		collector.Add(ctx, "webhook", execution("3", testkube.ExecutionStatusTimeout), now.Add(10*time.Minute))
+
+		digests, err := collector.Flush(ctx, window, now.Add(10*time.Minute))
+		require.NoError(t, err)
+		assert.Empty(t, digests)
+
+		digests, err = collector.Flush(ctx, window, now.Add(window))
+		require.NoError(t, err)
+		assert.Len(t, digests, 1)
+		assert.Equal(t, int32(1), digests["slack"].Passed)
+		assert.Equal(t, int32(1), digests["slack"].Failed)
+		assert.Len(t, digests["slack"].Executions, 2)
+		assert.Equal(t, now, digests["slack"].StartTime)
+
+		digests, err = collector.Flush(ctx, 0, now.Add(window))
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), digests["webhook"].Failed)
+
+		digests, err = collector.Flush(ctx, 0, now.Add(window))
+		require.NoError(t, err)
+		assert.Empty(t, digests)
+	})
+}
+
+func TestCollectorHold(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2022, 8, 10, 3, 0, 0, 0, time.UTC)
+	collector := NewCollector()
+	collector.Hold(ctx, "slack", execution("1", testkube.ExecutionStatusFailed), now, now.Add(4*time.Hour))
+	collector.Hold(ctx, "slack", execution("2", testkube.ExecutionStatusFailed), now.Add(time.Hour), now.Add(2*time.Hour))
+	collector.Hold(ctx, "webhook", execution("3", testkube.ExecutionStatusPassed), now, now.Add(time.Hour))
+
+	digests, err := collector.Release(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, digests, 1)
+	assert.Equal(t, int32(1), digests["webhook"].Passed)
+
+	digests, err = collector.Release(ctx, now.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, digests, "batch is held until latest hold")
+
+	digests, err = collector.Release(ctx, now.Add(4*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), digests["slack"].Failed)
+	assert.Equal(t, now, digests["slack"].StartTime)
+}
+
+func execution(id string, status *testkube.ExecutionStatus) testkube.Execution {
+	return testkube.Execution{Id: id, ExecutionResult: &testkube.ExecutionResult{Status: status}}
+}
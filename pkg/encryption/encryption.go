@@ -0,0 +1,233 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// prefix marks encrypted values, values without it were stored before encryption was enabled
+	prefix = "enc:v1:"
+	// markerPrefix is a common prefix of encrypted and escaped values
+	markerPrefix = "enc:"
+	// escapePrefix marks plain values starting with marker prefix stored when encryption is disabled, so they
+	// aren't decrypted
+	escapePrefix = "enc:plain:"
+	// dataKeySize is a size of AES-256 data key generated for each value
+	dataKeySize = 32
+)
+
+// KeyWrapper encrypts and decrypts data keys with key encryption key, it can be implemented with KMS client
+type KeyWrapper interface {
+	// WrapKey encrypts data key
+	WrapKey(key []byte) ([]byte, error)
+	// UnwrapKey decrypts data key
+	UnwrapKey(wrapped []byte) ([]byte, error)
+}
+
+// NewLocalKeyWrapper returns key wrapper using AES-GCM with local key encryption key e.g. from Kubernetes secret
+func NewLocalKeyWrapper(key []byte) (KeyWrapper, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key encryption key: %w", err)
+	}
+
+	return localKeyWrapper{aead: aead}, nil
+}
+
+type localKeyWrapper struct {
+	aead cipher.AEAD
+}
+
+func (w localKeyWrapper) WrapKey(key []byte) ([]byte, error) {
+	return seal(w.aead, key)
+}
+
+func (w localKeyWrapper) UnwrapKey(wrapped []byte) ([]byte, error) {
+	return open(w.aead, wrapped)
+}
+
+// ParseKey parses base64 encoded AES key, 32 bytes long raw keys are accepted too, raw keys can be valid base64
+// strings, so value is decoded only when it decodes to key of right size
+func ParseKey(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	if key, err := base64.StdEncoding.DecodeString(value); err == nil && len(key) == dataKeySize {
+		return key, nil
+	}
+
+	if len(value) == dataKeySize {
+		return []byte(value), nil
+	}
+
+	return nil, fmt.Errorf("key is neither base64 encoded nor %d bytes long", dataKeySize)
+}
+
+// NewEncrypter returns envelope encrypter, each value is encrypted with its own data key wrapped by key wrapper
+func NewEncrypter(wrapper KeyWrapper) *Encrypter {
+	return &Encrypter{wrapper: wrapper}
+}
+
+// Encrypter encrypts and decrypts stored values, nil encrypter keeps values as they are
+type Encrypter struct {
+	wrapper KeyWrapper
+}
+
+// Enabled checks if values are encrypted
+func (e *Encrypter) Enabled() bool {
+	return e != nil && e.wrapper != nil
+}
+
+// Encrypt encrypts value, empty values are returned as they are and values looking encrypted are encrypted too,
+// when encryption is disabled values starting with marker prefix are escaped, so they aren't decrypted
+func (e *Encrypter) Encrypt(value string) (string, error) {
+	if !e.Enabled() {
+		if strings.HasPrefix(value, markerPrefix) {
+			return escapePrefix + value, nil
+		}
+
+		return value, nil
+	}
+
+	if value == "" {
+		return value, nil
+	}
+
+	key := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", fmt.Errorf("generating data key: %w", err)
+	}
+
+	wrapped, err := e.wrapper.WrapKey(key)
+	if err != nil {
+		return "", fmt.Errorf("wrapping data key: %w", err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := seal(aead, []byte(value))
+	if err != nil {
+		return "", err
+	}
+
+	return prefix + base64.RawStdEncoding.EncodeToString(wrapped) + ":" + base64.RawStdEncoding.EncodeToString(data), nil
+}
+
+// Decrypt decrypts encrypted value, plain values are returned as they are and escaped values are unescaped
+func (e *Encrypter) Decrypt(value string) (string, error) {
+	if strings.HasPrefix(value, escapePrefix) {
+		return strings.TrimPrefix(value, escapePrefix), nil
+	}
+
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	if !e.Enabled() {
+		return "", fmt.Errorf("value is encrypted, but encryption key is not set")
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, prefix), ":")
+	if len(parts) != 2 {
+		return "", fmt.Errorf("invalid encrypted value format")
+	}
+
+	wrapped, err := base64.RawStdEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", fmt.Errorf("decoding data key: %w", err)
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("decoding value: %w", err)
+	}
+
+	key, err := e.wrapper.UnwrapKey(wrapped)
+	if err != nil {
+		return "", fmt.Errorf("unwrapping data key: %w", err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := open(aead, data)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
+
+// EncryptMap returns copy of values with encrypted values
+func (e *Encrypter) EncryptMap(values map[string]string) (map[string]string, error) {
+	return mapValues(values, e.Encrypt)
+}
+
+// DecryptMap returns copy of values with decrypted values
+func (e *Encrypter) DecryptMap(values map[string]string) (map[string]string, error) {
+	return mapValues(values, e.Decrypt)
+}
+
+// IsEncrypted checks if value was encrypted by encrypter
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+func mapValues(values map[string]string, fn func(string) (string, error)) (map[string]string, error) {
+	if values == nil {
+		return nil, nil
+	}
+
+	result := make(map[string]string, len(values))
+	for name, value := range values {
+		mapped, err := fn(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		result[name] = mapped
+	}
+
+	return result, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts data with random nonce prepended to ciphertext
+func seal(aead cipher.AEAD, data []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+
+	return aead.Seal(nonce, nonce, data, nil), nil
+}
+
+func open(aead cipher.AEAD, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("encrypted value is too short")
+	}
+
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting value: %w", err)
+	}
+
+	return plain, nil
+}
@@ -0,0 +1,145 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func newTestEncrypter(t *testing.T, key string) *Encrypter {
+	wrapper, err := NewLocalKeyWrapper([]byte(key))
+	require.NoError(t, err)
+	return NewEncrypter(wrapper)
+}
+
+func TestEncrypter(t *testing.T) {
+	encrypter := newTestEncrypter(t, strings.Repeat("k", 32))
+
+	encrypted, err := encrypter.Encrypt("password")
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(encrypted))
+	assert.NotContains(t, encrypted, "password")
+
+	again, err := encrypter.Encrypt("password")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, again, "each value has its own data key and nonce")
+
+	decrypted, err := encrypter.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "password", decrypted)
+
+	plain, err := encrypter.Decrypt("stored before encryption")
+	require.NoError(t, err)
+	assert.Equal(t, "stored before encryption", plain)
+
+	empty, err := encrypter.Encrypt("")
+	require.NoError(t, err)
+	assert.Equal(t, "", empty)
+
+	_, err = newTestEncrypter(t, strings.Repeat("x", 32)).Decrypt(encrypted)
+	assert.Error(t, err, "other key")
+
+	var disabled *Encrypter
+	value, err := disabled.Encrypt("password")
+	require.NoError(t, err)
+	assert.Equal(t, "password", value)
+
+	_, err = disabled.Decrypt(encrypted)
+	assert.Error(t, err, "missing key")
+}
+
+func TestEncryptPrefixedValues(t *testing.T) {
+	for name, encrypter := range map[string]*Encrypter{
+		"enabled":  newTestEncrypter(t, strings.Repeat("k", 32)),
+		"disabled": nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, value := range []string{"enc:v1:password", "enc:v1:a:b", "enc:plain:password"} {
+				stored, err := encrypter.Encrypt(value)
+				require.NoError(t, err)
+				assert.NotEqual(t, value, stored)
+
+				decrypted, err := encrypter.Decrypt(stored)
+				require.NoError(t, err)
+				assert.Equal(t, value, decrypted)
+			}
+		})
+	}
+
+	encrypter := newTestEncrypter(t, strings.Repeat("k", 32))
+	stored, err := encrypter.Encrypt("enc:v1:password")
+	require.NoError(t, err)
+	assert.NotContains(t, stored, "password", "values looking encrypted are encrypted too")
+
+	var disabled *Encrypter
+	execution, err := disabled.EncryptExecution(testkube.Execution{Params: map[string]string{"token": "enc:v1:password"}})
+	require.NoError(t, err)
+	execution, err = disabled.DecryptExecution(execution)
+	require.NoError(t, err)
+	assert.Equal(t, "enc:v1:password", execution.Params["token"])
+}
+
+func TestEncryptExecution(t *testing.T) {
+	encrypter := newTestEncrypter(t, strings.Repeat("k", 32))
+	execution := testkube.Execution{
+		Id:              "1",
+		Params:          map[string]string{"token": "secret"},
+		ParamsFile:      "token=secret",
+		Files:           []testkube.ExecutionFile{{Path: "/tls/key.pem", Content: "private key"}, {Path: "/tls/ca.pem", SecretName: "tls", Key: "ca.pem"}},
+		ExecutionResult: &testkube.ExecutionResult{Output: "logged in with secret"},
+	}
+
+	encrypted, err := encrypter.EncryptExecution(execution)
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(encrypted.Params["token"]))
+	assert.True(t, IsEncrypted(encrypted.ParamsFile))
+	assert.True(t, IsEncrypted(encrypted.ExecutionResult.Output))
+	assert.True(t, IsEncrypted(encrypted.Files[0].Content))
+	assert.Empty(t, encrypted.Files[1].Content)
+	assert.Equal(t, "private key", execution.Files[0].Content)
+	assert.Equal(t, "secret", execution.Params["token"], "passed execution isn't changed")
+	assert.Equal(t, "logged in with secret", execution.ExecutionResult.Output)
+
+	decrypted, err := encrypter.DecryptExecution(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, execution, decrypted)
+
+	suiteExecution := testkube.TestSuiteExecution{
+		Params:      map[string]string{"token": "secret"},
+		StepResults: []testkube.TestSuiteStepExecutionResult{{Execution: &execution}, {}},
+	}
+
+	encryptedSuite, err := encrypter.EncryptTestSuiteExecution(suiteExecution)
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(encryptedSuite.Params["token"]))
+	assert.True(t, IsEncrypted(encryptedSuite.StepResults[0].Execution.ParamsFile))
+	assert.Equal(t, "token=secret", execution.ParamsFile)
+
+	decryptedSuite, err := encrypter.DecryptTestSuiteExecution(encryptedSuite)
+	require.NoError(t, err)
+	assert.Equal(t, suiteExecution, decryptedSuite)
+}
+
+func TestParseKey(t *testing.T) {
+	key := []byte(strings.Repeat("k", 32))
+
+	parsed, err := ParseKey(base64.StdEncoding.EncodeToString(key) + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	// 32 characters long raw key is valid base64 of 24 bytes
+	parsed, err = ParseKey(string(key))
+	require.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	_, err = ParseKey(base64.StdEncoding.EncodeToString([]byte("short key")))
+	assert.Error(t, err)
+
+	_, err = ParseKey("not a key")
+	assert.Error(t, err)
+}
@@ -0,0 +1,151 @@
+package encryption
+
+import (
+	"strings"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// EncryptExecution returns execution with encrypted params, params file and output, execution params
+// and result are copied, so passed execution isn't changed
+func (e *Encrypter) EncryptExecution(execution testkube.Execution) (testkube.Execution, error) {
+	return e.mapExecution(execution, e.Encrypt)
+}
+
+// DecryptExecution returns execution with decrypted params, params file and output
+func (e *Encrypter) DecryptExecution(execution testkube.Execution) (testkube.Execution, error) {
+	return e.mapExecution(execution, e.Decrypt)
+}
+
+// EncryptResult returns execution result with encrypted output
+func (e *Encrypter) EncryptResult(result testkube.ExecutionResult) (testkube.ExecutionResult, error) {
+	var err error
+	result.Output, err = e.Encrypt(result.Output)
+	return result, err
+}
+
+// DecryptResult returns execution result with decrypted output
+func (e *Encrypter) DecryptResult(result testkube.ExecutionResult) (testkube.ExecutionResult, error) {
+	var err error
+	result.Output, err = e.Decrypt(result.Output)
+	return result, err
+}
+
+// DecryptExecutions decrypts executions in place
+func (e *Encrypter) DecryptExecutions(executions []testkube.Execution) (err error) {
+	for i := range executions {
+		if executions[i], err = e.DecryptExecution(executions[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EncryptTestSuiteExecution returns test suite execution with encrypted params and encrypted step executions
+func (e *Encrypter) EncryptTestSuiteExecution(execution testkube.TestSuiteExecution) (testkube.TestSuiteExecution, error) {
+	return e.mapTestSuiteExecution(execution, e.Encrypt)
+}
+
+// DecryptTestSuiteExecution returns test suite execution with decrypted params and decrypted step executions
+func (e *Encrypter) DecryptTestSuiteExecution(execution testkube.TestSuiteExecution) (testkube.TestSuiteExecution, error) {
+	return e.mapTestSuiteExecution(execution, e.Decrypt)
+}
+
+// DecryptTestSuiteExecutions decrypts test suite executions in place
+func (e *Encrypter) DecryptTestSuiteExecutions(executions []testkube.TestSuiteExecution) (err error) {
+	for i := range executions {
+		if executions[i], err = e.DecryptTestSuiteExecution(executions[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Encrypter) mapExecution(execution testkube.Execution, fn func(string) (string, error)) (testkube.Execution, error) {
+	if !e.Enabled() && !isExecutionEncrypted(execution) {
+		return execution, nil
+	}
+
+	var err error
+	if execution.Params, err = mapValues(execution.Params, fn); err != nil {
+		return execution, err
+	}
+
+	if execution.ParamsFile, err = fn(execution.ParamsFile); err != nil {
+		return execution, err
+	}
+
+	if execution.Files != nil {
+		files := make([]testkube.ExecutionFile, len(execution.Files))
+		for i, file := range execution.Files {
+			if file.Content, err = fn(file.Content); err != nil {
+				return execution, err
+			}
+			files[i] = file
+		}
+		execution.Files = files
+	}
+
+	if execution.ExecutionResult != nil {
+		result := *execution.ExecutionResult
+		if result.Output, err = fn(result.Output); err != nil {
+			return execution, err
+		}
+		execution.ExecutionResult = &result
+	}
+
+	return execution, nil
+}
+
+func (e *Encrypter) mapTestSuiteExecution(execution testkube.TestSuiteExecution, fn func(string) (string, error)) (testkube.TestSuiteExecution, error) {
+	var err error
+	if execution.Params, err = mapValues(execution.Params, fn); err != nil {
+		return execution, err
+	}
+
+	if execution.StepResults == nil {
+		return execution, nil
+	}
+
+	stepResults := make([]testkube.TestSuiteStepExecutionResult, len(execution.StepResults))
+	for i, stepResult := range execution.StepResults {
+		if stepResult.Execution != nil {
+			stepExecution, err := e.mapExecution(*stepResult.Execution, fn)
+			if err != nil {
+				return execution, err
+			}
+			stepResult.Execution = &stepExecution
+		}
+		stepResults[i] = stepResult
+	}
+	execution.StepResults = stepResults
+
+	return execution, nil
+}
+
+// isExecutionEncrypted checks if execution has encrypted or escaped values, or values which have to be escaped
+func isExecutionEncrypted(execution testkube.Execution) bool {
+	if isMarked(execution.ParamsFile) || (execution.ExecutionResult != nil && isMarked(execution.ExecutionResult.Output)) {
+		return true
+	}
+
+	for _, value := range execution.Params {
+		if isMarked(value) {
+			return true
+		}
+	}
+
+	for _, file := range execution.Files {
+		if isMarked(file.Content) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isMarked(value string) bool {
+	return strings.HasPrefix(value, markerPrefix)
+}
@@ -1,14 +1,11 @@
 package agent
 
 import (
-	"encoding/json"
-	"fmt"
-	"io/ioutil"
 	"os"
 
-	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/kubeshop/testkube/pkg/executor/output"
 	"github.com/kubeshop/testkube/pkg/executor/runner"
+	"github.com/kubeshop/testkube/pkg/executor/sdk"
 )
 
 // Run starts test runner, test runner can have 3 states
@@ -17,32 +14,16 @@ import (
 // - pod:failed,  test execution: failed - this one is unusual behaviour
 func Run(r runner.Runner, args []string) {
 
-	var test []byte
-	var err error
-
-	stat, _ := os.Stdin.Stat()
-	if (stat.Mode() & os.ModeCharDevice) == 0 {
-		test, err = ioutil.ReadAll(os.Stdin)
-		if err != nil {
-			output.PrintError(fmt.Errorf("can't read stind input: %w", err))
-			os.Exit(1)
-		}
-	} else if len(args) > 1 {
-		test = []byte(args[1])
-	} else {
-		output.PrintError(fmt.Errorf("missing input JSON argument or stdin input"))
-		os.Exit(1)
-	}
-
-	e := testkube.Execution{}
-
-	err = json.Unmarshal(test, &e)
+	e, err := sdk.ReadExecution(args, os.Stdin)
 	if err != nil {
 		output.PrintError(err)
 		os.Exit(1)
 	}
 
 	output.PrintEvent("running test", e.Id)
+	if e.RequestId != "" {
+		output.PrintEvent("started by API request", e.RequestId)
+	}
 
 	result, err := r.Run(e)
 	if err != nil {
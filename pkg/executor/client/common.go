@@ -19,8 +19,29 @@ type ExecuteOptions struct {
 	TestSpec     testsv2.TestSpec
 	ExecutorName string
 	ExecutorSpec executorv1.ExecutorSpec
-	Request      testkube.ExecutionRequest
-	Sync         bool
-	HasSecrets   bool
-	Labels       map[string]string
+	// ExecutorLabels are executor custom resource labels
+	ExecutorLabels map[string]string
+	Request        testkube.ExecutionRequest
+	Sync           bool
+	HasSecrets     bool
+	Labels         map[string]string
+	SecretMounts   []testkube.SecretMount
+	// DataFile is test iteration data file
+	DataFile *testkube.TestContent
+	// SecretParams are names of params with values redacted in execution logs and results
+	SecretParams []string
+	// Ownership is test owner, team and contact copied to execution
+	Ownership *testkube.Ownership
+	// ContentUriOptions are file URI test content fetching options
+	ContentUriOptions *testkube.ContentUriOptions
+	// Placement are merged executor and test scheduling constraints
+	Placement *testkube.Placement
+	// LogCollection selects application pods which logs are stored as execution artifact
+	LogCollection *testkube.LogCollection
+	// Prechecks are readiness checks evaluated before executor is called
+	Prechecks *testkube.Prechecks
+	// Dependencies are tests which latest executions have to pass before executor is called
+	Dependencies *testkube.TestDependencies
+	// Newman are newman options of postman collection tests
+	Newman *testkube.NewmanOptions
 }
@@ -34,6 +34,16 @@ type Executor interface {
 	Abort(id string) (err error)
 
 	Logs(id string) (logs chan output.Output, err error)
+
+	// PurgeJobs deletes finished jobs of given test and returns number of deleted jobs
+	PurgeJobs(testName string) (count int, err error)
+
+	// Diagnostics returns state of execution job, pods and recent kubernetes events
+	Diagnostics(id string) (diagnostics testkube.ExecutionDiagnostics, err error)
+
+	// Reconcile updates result of execution not watched by active API server instance anymore,
+	// returns false when execution is still running or watched
+	Reconcile(execution testkube.Execution, isInstanceActive func(instanceID string) bool) (reconciled bool, err error)
 }
 
 // HTTPClient interface for getting REST based requests
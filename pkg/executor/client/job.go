@@ -10,12 +10,14 @@ import (
 	"github.com/kubeshop/testkube/pkg/executor/output"
 	"github.com/kubeshop/testkube/pkg/jobs"
 	"github.com/kubeshop/testkube/pkg/log"
+	"github.com/kubeshop/testkube/pkg/storage"
 	"go.uber.org/zap"
 )
 
 // NewJobExecutor creates new job executor
-func NewJobExecutor(repo result.Repository, namespace, initImage, jobTemplate string) (client JobExecutor, err error) {
-	jobClient, err := jobs.NewJobClient(namespace, initImage, jobTemplate)
+func NewJobExecutor(repo result.Repository, namespace, initImage, jobTemplate, registryMirror, instanceID string, gcPolicy jobs.GCPolicy,
+	reschedulePolicy jobs.ReschedulePolicy, artifacts storage.Client, appLogsPolicy jobs.AppLogsPolicy) (client JobExecutor, err error) {
+	jobClient, err := jobs.NewJobClient(namespace, initImage, jobTemplate, registryMirror, instanceID, gcPolicy, reschedulePolicy, artifacts, appLogsPolicy)
 	if err != nil {
 		return client, fmt.Errorf("can't get k8s jobs client: %w", err)
 	}
@@ -116,16 +118,39 @@ func (c JobExecutor) Abort(id string) error {
 	return nil
 }
 
+// PurgeJobs deletes finished jobs of given test
+func (c JobExecutor) PurgeJobs(testName string) (count int, err error) {
+	return c.Client.PurgeFinishedJobs(context.Background(), testName)
+}
+
+// Diagnostics returns execution job diagnostics
+func (c JobExecutor) Diagnostics(id string) (diagnostics testkube.ExecutionDiagnostics, err error) {
+	return c.Client.GetJobDiagnostics(context.Background(), id)
+}
+
+// Reconcile updates result of execution which job is not watched anymore
+func (c JobExecutor) Reconcile(execution testkube.Execution, isInstanceActive func(instanceID string) bool) (reconciled bool, err error) {
+	return c.Client.ReconcileExecution(context.Background(), c.Repository, execution, isInstanceActive)
+}
+
 // getJobOptions compose JobOptions based on ExecuteOptions
 func getJobOptions(options ExecuteOptions) jobs.JobOptions {
 	return jobs.JobOptions{
-		Image:       options.ExecutorSpec.Image,
-		HasSecrets:  options.HasSecrets,
-		JobTemplate: options.ExecutorSpec.JobTemplate,
-		TestName:    options.TestName,
-		Namespace:   options.Namespace,
-		SecretEnvs:  options.Request.SecretEnvs,
-		HTTPProxy:   options.Request.HttpProxy,
-		HTTPSProxy:  options.Request.HttpsProxy,
+		Image:             options.ExecutorSpec.Image,
+		HasSecrets:        options.HasSecrets,
+		JobTemplate:       options.ExecutorSpec.JobTemplate,
+		TestName:          options.TestName,
+		Namespace:         options.Namespace,
+		SecretEnvs:        options.Request.SecretEnvs,
+		HTTPProxy:         options.Request.HttpProxy,
+		HTTPSProxy:        options.Request.HttpsProxy,
+		Labels:            options.Labels,
+		SecretMounts:      options.SecretMounts,
+		Files:             options.Request.Files,
+		ContentUriOptions: options.ContentUriOptions,
+		Placement:         options.Placement,
+		LogCollection:     options.LogCollection,
+		// executors can opt out from registry mirror e.g. when using images from internal registry
+		DisableRegistryMirror: testkube.IsRegistryMirrorDisabled(options.ExecutorLabels),
 	}
 }
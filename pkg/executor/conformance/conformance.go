@@ -0,0 +1,322 @@
+// Package conformance verifies that executors follow executor protocol, candidate executor is run against canned
+// inputs and its output, result and error semantics are checked, e.g. before executor is registered
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/output"
+)
+
+// defaultTimeout is a timeout of single conformance case run
+const defaultTimeout = 2 * time.Minute
+
+// Options are conformance options of executor
+type Options struct {
+	// Type is a test type executor handles, e.g. postman/collection
+	Type string
+	// Content is string content of passing test
+	Content string
+	// FailingContent is string content of failing test, failing test case is skipped when empty
+	FailingContent string
+	// Params are execution params of tests
+	Params map[string]string
+	// Timeout is a timeout of every case, 2 minutes are used when empty
+	Timeout time.Duration
+	// Artifacts are expected to be uploaded by passing test, upload to artifacts storage is checked when set
+	Artifacts bool
+}
+
+// Report is a report of executor run
+type Report struct {
+	Output
+	// Outputs are JSON outputs written by executor
+	Outputs []output.Output
+	// Result is result of last result output, nil when executor didn't write any
+	Result *testkube.ExecutionResult
+	// Artifacts are bucket/object names uploaded to stubbed artifacts storage
+	Artifacts []string
+}
+
+// Case is conformance case, its check returns error when executor doesn't conform
+type Case struct {
+	Name  string
+	Input func(dataDir string) Input
+	Check func(report Report) error
+	// Storage runs case with stubbed artifacts storage, its endpoint and credentials are passed in RUNNER_ env vars
+	Storage bool
+}
+
+// CaseResult is a result of conformance case
+type CaseResult struct {
+	Name string
+	Err  error
+}
+
+// Cases returns conformance cases of executor options
+func Cases(options Options) []Case {
+	cases := []Case{
+		{
+			Name:  "passing test",
+			Input: options.input(testkube.NewStringTestContent(options.Content), nil),
+			Check: func(report Report) error {
+				return expectResult(report, testkube.PASSED_ExecutionStatus)
+			},
+		},
+		{
+			Name:  "invalid content",
+			Input: options.input(&testkube.TestContent{Type_: "unknown", Data: options.Content}, nil),
+			Check: expectFailure,
+		},
+		{
+			Name:  "missing input",
+			Input: func(dataDir string) Input { return Input{DataDir: dataDir} },
+			Check: func(report Report) error {
+				if report.ExitCode == 0 {
+					return fmt.Errorf("executor run without execution should exit with non-zero code")
+				}
+
+				return expectError(report)
+			},
+		},
+		{
+			Name:    "artifacts upload",
+			Storage: true,
+			Input:   options.input(testkube.NewStringTestContent(options.Content), map[string]string{"RUNNER_SCRAPPERENABLED": "true"}),
+			Check: func(report Report) error {
+				if err := expectResult(report, testkube.PASSED_ExecutionStatus); err != nil {
+					return err
+				}
+
+				if options.Artifacts && len(report.Artifacts) == 0 {
+					return fmt.Errorf("executor didn't upload artifacts to storage")
+				}
+
+				return nil
+			},
+		},
+		{
+			// artifacts upload failures shouldn't hide test result
+			Name: "unavailable artifacts storage",
+			Input: options.input(testkube.NewStringTestContent(options.Content), map[string]string{
+				"RUNNER_SCRAPPERENABLED": "true",
+				"RUNNER_ENDPOINT":        "127.0.0.1:1",
+			}),
+			Check: func(report Report) error {
+				if report.Result == nil {
+					return fmt.Errorf("executor should write test result when artifacts can't be uploaded")
+				}
+
+				return nil
+			},
+		},
+	}
+
+	if options.FailingContent != "" {
+		cases = append(cases, Case{
+			Name:  "failing test",
+			Input: options.input(testkube.NewStringTestContent(options.FailingContent), nil),
+			Check: func(report Report) error {
+				if err := expectResult(report, testkube.FAILED_ExecutionStatus); err != nil {
+					return err
+				}
+
+				if report.Result.ErrorMessage == "" {
+					return fmt.Errorf("failed result should have error message")
+				}
+
+				return nil
+			},
+		})
+	}
+
+	return cases
+}
+
+// Verify runs conformance cases with runner
+func Verify(ctx context.Context, runner Runner, options Options) []CaseResult {
+	results := make([]CaseResult, 0)
+	for _, c := range Cases(options) {
+		results = append(results, CaseResult{Name: c.Name, Err: RunCase(ctx, runner, c, options.Timeout)})
+	}
+
+	return results
+}
+
+// Test runs conformance cases as subtests of go test, e.g. with CommandRunner of executor binary
+func Test(t *testing.T, runner Runner, options Options) {
+	for _, c := range Cases(options) {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			if err := RunCase(context.Background(), runner, c, options.Timeout); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// RunCase runs case in temporary data directory and checks output protocol and case expectations
+func RunCase(ctx context.Context, runner Runner, c Case, timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	dataDir, err := os.MkdirTemp("", "executor-conformance")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dataDir)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	input := c.Input(dataDir)
+	var storage *storageStub
+	if c.Storage {
+		if storage, err = newStorageStub(); err != nil {
+			return err
+		}
+		defer storage.close()
+
+		env := storage.env()
+		for name, value := range input.Env {
+			env[name] = value
+		}
+		input.Env = env
+	}
+
+	out, err := runner.Run(ctx, input)
+	if err != nil {
+		return fmt.Errorf("running executor: %w", err)
+	}
+
+	report, err := NewReport(out)
+	if err != nil {
+		return err
+	}
+
+	if storage != nil {
+		report.Artifacts = storage.uploaded()
+	}
+
+	return c.Check(report)
+}
+
+// NewReport parses executor output, lines which don't follow output protocol are returned as errors,
+// non JSON lines e.g. of testing tool libraries are ignored the same way as by job executor
+func NewReport(out Output) (Report, error) {
+	report := Report{Output: out}
+	for i, line := range strings.Split(string(out.Stdout), "\n") {
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+
+		if report.Result != nil {
+			return report, fmt.Errorf("line %d: result should be the last output", i+1)
+		}
+
+		var entry output.Output
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return report, fmt.Errorf("line %d: invalid output JSON: %w", i+1, err)
+		}
+
+		switch entry.Type_ {
+		case output.TypeLogLine, output.TypeLogEvent, output.TypeError:
+		case output.TypeVariable:
+			if entry.Name == "" {
+				return report, fmt.Errorf("line %d: variable output without name", i+1)
+			}
+		case output.TypeResult:
+			if err := validateResult([]byte(line)); err != nil {
+				return report, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			report.Result = entry.Result
+		default:
+			return report, fmt.Errorf("line %d: unknown output type %q", i+1, entry.Type_)
+		}
+
+		report.Outputs = append(report.Outputs, entry)
+	}
+
+	return report, nil
+}
+
+func validateResult(line []byte) error {
+	var entry struct {
+		Version string          `json:"version"`
+		Result  json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return err
+	}
+
+	if len(entry.Result) == 0 || string(entry.Result) == "null" {
+		return fmt.Errorf("result output without result")
+	}
+
+	violations, err := output.ValidateResult(entry.Version, entry.Result)
+	if err != nil {
+		return err
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("result doesn't match result schema: %s", strings.Join(violations, "; "))
+	}
+
+	return nil
+}
+
+func (o Options) input(content *testkube.TestContent, env map[string]string) func(dataDir string) Input {
+	return func(dataDir string) Input {
+		execution := testkube.NewExecutionWithID(fmt.Sprintf("conformance-%d", time.Now().UnixNano()), o.Type, "conformance")
+		execution.Content = content
+		execution.Params = o.Params
+		return Input{Execution: &execution, DataDir: dataDir, Env: env}
+	}
+}
+
+func expectResult(report Report, status testkube.ExecutionStatus) error {
+	if report.ExitCode != 0 {
+		return fmt.Errorf("executor should exit with zero code when test completes, got %d", report.ExitCode)
+	}
+
+	if report.Result == nil {
+		return fmt.Errorf("executor didn't write result")
+	}
+
+	if *report.Result.Status != status {
+		return fmt.Errorf("expected %s result, got %s: %s", status, *report.Result.Status, report.Result.ErrorMessage)
+	}
+
+	return nil
+}
+
+// expectFailure checks that executor reports error or failed result of test which can't run
+func expectFailure(report Report) error {
+	if report.Result != nil && report.Result.IsPassed() {
+		return fmt.Errorf("executor passed test which can't be run")
+	}
+
+	return expectError(report)
+}
+
+func expectError(report Report) error {
+	if report.Result != nil && report.Result.IsFailed() {
+		return nil
+	}
+
+	for _, entry := range report.Outputs {
+		if entry.Type_ == output.TypeError {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("executor should write error output or failed result")
+}
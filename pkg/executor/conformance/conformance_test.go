@@ -0,0 +1,128 @@
+package conformance
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/storage/minio"
+)
+
+// conformingScript is an executor passing tests containing "pass" and failing other ones
+const conformingScript = `
+if [ -z "$1" ]; then
+  echo '{"type":"error","content":"missing input JSON argument"}'
+  exit 1
+fi
+echo '{"type":"event","content":"running test"}'
+case "$1" in
+  *'"type":"string"'*'pass'*) echo '{"type":"result","result":{"status":"passed","output":"ok"}}' ;;
+  *'"type":"string"'*) echo '{"type":"result","result":{"status":"failed","errorMessage":"assertion failed"}}' ;;
+  *) echo '{"type":"error","content":"unhandled content type"}'; exit 1 ;;
+esac
+`
+
+func TestVerify(t *testing.T) {
+	options := Options{Type: "shell/test", Content: "pass", FailingContent: "fail"}
+
+	t.Run("conforming executor", func(t *testing.T) {
+		runner := CommandRunner{Command: "sh", Args: []string{"-c", conformingScript, "sh"}}
+		for _, result := range Verify(context.Background(), runner, options) {
+			assert.NoError(t, result.Err, result.Name)
+		}
+	})
+
+	t.Run("executor passing everything", func(t *testing.T) {
+		runner := CommandRunner{Command: "sh", Args: []string{"-c", `echo '{"type":"result","result":{"status":"success"}}'`, "sh"}}
+		results := Verify(context.Background(), runner, options)
+		require.Len(t, results, 6)
+		for _, result := range results {
+			require.Error(t, result.Err, result.Name)
+		}
+		assert.Contains(t, results[0].Err.Error(), "value success is not one of")
+	})
+}
+
+// uploadingRunner uploads artifact of execution to storage from env vars and reports passed result
+type uploadingRunner struct{}
+
+func (r uploadingRunner) Run(ctx context.Context, input Input) (Output, error) {
+	if input.Execution == nil || input.Env["RUNNER_SCRAPPERENABLED"] != "true" {
+		return Output{Stdout: []byte(`{"type":"result","result":{"status":"passed"}}`)}, nil
+	}
+
+	client := minio.NewClient(input.Env["RUNNER_ENDPOINT"], input.Env["RUNNER_ACCESSKEYID"], input.Env["RUNNER_SECRETACCESSKEY"], "", "", false)
+	if err := client.Connect(); err != nil {
+		return Output{}, err
+	}
+
+	if err := client.UploadFile(input.Execution.Id, "report.xml", strings.NewReader("<testsuites/>"), 13); err != nil {
+		return Output{Stdout: []byte(`{"type":"error","content":"` + err.Error() + `"}`)}, nil
+	}
+
+	return Output{Stdout: []byte(`{"type":"result","result":{"status":"passed"}}`)}, nil
+}
+
+func TestArtifactsUpload(t *testing.T) {
+	var upload Case
+	for _, c := range Cases(Options{Type: "shell/test", Content: "pass", Artifacts: true}) {
+		if c.Storage {
+			upload = c
+		}
+	}
+	require.Equal(t, "artifacts upload", upload.Name)
+
+	assert.NoError(t, RunCase(context.Background(), uploadingRunner{}, upload, 0))
+
+	runner := CommandRunner{Command: "sh", Args: []string{"-c", conformingScript, "sh"}}
+	err := RunCase(context.Background(), runner, upload, 0)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "didn't upload artifacts")
+	}
+}
+
+func TestStorageStub(t *testing.T) {
+	stub, err := newStorageStub()
+	require.NoError(t, err)
+	defer stub.close()
+
+	env := stub.env()
+	client := minio.NewClient(env["RUNNER_ENDPOINT"], env["RUNNER_ACCESSKEYID"], env["RUNNER_SECRETACCESSKEY"], "", "", false)
+	require.NoError(t, client.Connect())
+	require.NoError(t, client.UploadFile("conformance-1", "report.xml", strings.NewReader("<testsuites/>"), 13))
+	assert.Equal(t, []string{"conformance-1/report.xml"}, stub.uploaded())
+}
+
+func TestNewReport(t *testing.T) {
+	for name, test := range map[string]struct {
+		stdout string
+		err    string
+	}{
+		"valid output": {stdout: "newman run\n" +
+			`{"type":"line","content":"GET /health"}` + "\n" +
+			`{"type":"variable","name":"userId","content":"123"}` + "\n" +
+			`{"type":"result","version":"v1","result":{"status":"passed"}}`},
+		"invalid JSON":        {stdout: `{"type":"line"`, err: "line 1: invalid output JSON"},
+		"unknown output type": {stdout: `{"type":"progress","content":"50%"}`, err: `line 1: unknown output type "progress"`},
+		"unnamed variable":    {stdout: `{"type":"variable","content":"123"}`, err: "line 1: variable output without name"},
+		"result without status": {stdout: `{"type":"result","result":{"output":"ok"}}`,
+			err: "line 1: result doesn't match result schema: result: missing required property status"},
+		"output after result": {stdout: `{"type":"result","result":{"status":"passed"}}` + "\n" + `{"type":"line","content":"done"}`,
+			err: "line 2: result should be the last output"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			report, err := NewReport(Output{Stdout: []byte(test.stdout)})
+			if test.err == "" {
+				require.NoError(t, err)
+				assert.NotNil(t, report.Result)
+				return
+			}
+
+			require.Error(t, err)
+			assert.True(t, strings.HasPrefix(err.Error(), test.err), err.Error())
+		})
+	}
+}
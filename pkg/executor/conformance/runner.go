@@ -0,0 +1,127 @@
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// containerDataDir is a data directory of executor container, it's the same as in executor jobs
+const containerDataDir = "/data"
+
+// Input is an input of executor run
+type Input struct {
+	// Execution is passed to executor as first argument, nil execution runs executor without input
+	Execution *testkube.Execution
+	// DataDir is a directory executor fetches test content to
+	DataDir string
+	// Env are RUNNER_ env vars passed to executor on top of data directory
+	Env map[string]string
+}
+
+// Output is an output of executor run
+type Output struct {
+	Stdout   []byte
+	ExitCode int
+}
+
+// Runner runs candidate executor
+type Runner interface {
+	Run(ctx context.Context, input Input) (Output, error)
+}
+
+// DockerRunner runs executor image with docker, data directory is mounted the same way as in executor jobs
+type DockerRunner struct {
+	Image string
+	// Docker is a docker binary, docker on PATH is used when empty
+	Docker string
+}
+
+func (r DockerRunner) Run(ctx context.Context, input Input) (Output, error) {
+	docker := r.Docker
+	if docker == "" {
+		docker = "docker"
+	}
+
+	env := withEnv(input.Env, "RUNNER_DATADIR", containerDataDir)
+	args := []string{"run", "--rm", "-v", input.DataDir + ":" + containerDataDir}
+	// executor container reaches stubbed artifacts storage of host through host gateway
+	if endpoint := env["RUNNER_ENDPOINT"]; strings.HasPrefix(endpoint, "127.0.0.1:") {
+		env["RUNNER_ENDPOINT"] = "host.docker.internal" + strings.TrimPrefix(endpoint, "127.0.0.1")
+		args = append(args, "--add-host", "host.docker.internal:host-gateway")
+	}
+	for _, name := range sortedKeys(env) {
+		args = append(args, "-e", name+"="+env[name])
+	}
+	args = append(args, r.Image)
+
+	return run(ctx, input, exec.CommandContext(ctx, docker), args)
+}
+
+// CommandRunner runs local executor binary, e.g. built in go test with go build
+type CommandRunner struct {
+	Command string
+	Args    []string
+}
+
+func (r CommandRunner) Run(ctx context.Context, input Input) (Output, error) {
+	cmd := exec.CommandContext(ctx, r.Command)
+	cmd.Env = os.Environ()
+	env := withEnv(input.Env, "RUNNER_DATADIR", input.DataDir)
+	for _, name := range sortedKeys(env) {
+		cmd.Env = append(cmd.Env, name+"="+env[name])
+	}
+
+	return run(ctx, input, cmd, r.Args)
+}
+
+// run runs command with args and execution JSON argument, non zero exit codes aren't errors
+func run(ctx context.Context, input Input, cmd *exec.Cmd, args []string) (Output, error) {
+	if input.Execution != nil {
+		data, err := json.Marshal(input.Execution)
+		if err != nil {
+			return Output{}, err
+		}
+		args = append(args, string(data))
+	}
+
+	cmd.Args = append(cmd.Args, args...)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return Output{}, ctx.Err()
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return Output{Stdout: stdout.Bytes(), ExitCode: exitErr.ExitCode()}, nil
+	}
+
+	return Output{Stdout: stdout.Bytes()}, err
+}
+
+func withEnv(env map[string]string, name, value string) map[string]string {
+	merged := map[string]string{name: value}
+	for k, v := range env {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,76 @@
+package conformance
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// storageStub is S3 compatible artifacts storage accepting all uploads, names of uploaded objects are recorded
+type storageStub struct {
+	server  *httptest.Server
+	mutex   sync.Mutex
+	objects []string
+}
+
+// newStorageStub starts storage stub, it listens on all interfaces, so executor containers can reach it too
+func newStorageStub() (*storageStub, error) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return nil, fmt.Errorf("starting artifacts storage stub: %w", err)
+	}
+
+	stub := &storageStub{}
+	stub.server = &httptest.Server{Listener: listener, Config: &http.Server{Handler: http.HandlerFunc(stub.handle)}}
+	stub.server.Start()
+	return stub, nil
+}
+
+// endpoint returns host:port of storage stub
+func (s *storageStub) endpoint() string {
+	return fmt.Sprintf("127.0.0.1:%d", s.server.Listener.Addr().(*net.TCPAddr).Port)
+}
+
+// env returns RUNNER_ env vars of artifacts storage pointing to stub
+func (s *storageStub) env() map[string]string {
+	return map[string]string{
+		"RUNNER_ENDPOINT":        s.endpoint(),
+		"RUNNER_ACCESSKEYID":     "conformance",
+		"RUNNER_SECRETACCESSKEY": "conformance",
+		"RUNNER_SSL":             "false",
+	}
+}
+
+// uploaded returns bucket/object names of uploaded objects
+func (s *storageStub) uploaded() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string(nil), s.objects...)
+}
+
+func (s *storageStub) close() {
+	s.server.Close()
+}
+
+// handle answers bucket location requests and accepts all other requests, path style requests are used for IP endpoints
+func (s *storageStub) handle(w http.ResponseWriter, r *http.Request) {
+	_, _ = io.Copy(io.Discard, r.Body)
+	if r.Method == http.MethodGet && r.URL.Query().Has("location") {
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LocationConstraint>`)
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		if bucket, object, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/"); ok && object != "" {
+			s.mutex.Lock()
+			s.objects = append(s.objects, bucket+"/"+object)
+			s.mutex.Unlock()
+		}
+		w.Header().Set("ETag", `"d41d8cd98f00b204e9800998ecf8427e"`)
+	}
+}
@@ -0,0 +1,110 @@
+package content
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	nethttp "net/http"
+	"sort"
+	"strings"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/git"
+	"github.com/kubeshop/testkube/pkg/http"
+)
+
+const (
+	// ChecksumPrefixSha256 prefixes SHA-256 hash of string content
+	ChecksumPrefixSha256 = "sha256:"
+	// ChecksumPrefixETag prefixes ETag of file URI content
+	ChecksumPrefixETag = "etag:"
+	// ChecksumPrefixGit prefixes commit SHA of git content
+	ChecksumPrefixGit = "git:"
+)
+
+// Checksum returns checksum of resolved content without fetching it, empty checksum is returned when content
+// version can't be determined, like for file URI served without ETag
+func (f Fetcher) Checksum(content *testkube.TestContent) (checksum string, err error) {
+	if content == nil {
+		return "", nil
+	}
+
+	switch testkube.TestContentType(content.Type_) {
+	case testkube.TestContentTypeString:
+		hash := sha256.Sum256([]byte(content.Data))
+		return ChecksumPrefixSha256 + hex.EncodeToString(hash[:]), nil
+	case testkube.TestContentTypeFiles:
+		return filesChecksum(content.Files), nil
+	case testkube.TestContentTypeFileURI:
+		return f.uriChecksum(content.Uri, content.UriOptions)
+	case testkube.TestContentTypeGitFile, testkube.TestContentTypeGitDir:
+		if content.Repository == nil {
+			return "", fmt.Errorf("checksum - empty repository")
+		}
+
+		if content.Repository.Commit != "" {
+			return ChecksumPrefixGit + content.Repository.Commit, nil
+		}
+
+		uri, err := f.gitURI(content.Repository)
+		if err != nil {
+			return "", err
+		}
+
+		commit, err := git.RemoteCommit(uri, content.Repository.Branch)
+		if err != nil {
+			return "", err
+		}
+
+		return ChecksumPrefixGit + commit, nil
+	default:
+		return "", nil
+	}
+}
+
+// filesChecksum returns SHA-256 hash of inline content files in path order
+func filesChecksum(files map[string]string) string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	for _, name := range names {
+		// lengths are written to keep boundaries between paths and contents
+		fmt.Fprintf(hash, "%d:%s%d:%s", len(name), name, len(files[name]), files[name])
+	}
+
+	return ChecksumPrefixSha256 + hex.EncodeToString(hash.Sum(nil))
+}
+
+// uriChecksum returns ETag of file URI read with HEAD request, request has the same credentials as fetching
+func (f Fetcher) uriChecksum(uri string, options *testkube.ContentUriOptions) (checksum string, err error) {
+	if options == nil {
+		options = &testkube.ContentUriOptions{}
+	}
+
+	req, err := nethttp.NewRequest(nethttp.MethodHead, uri, nil)
+	if err != nil {
+		return "", err
+	}
+
+	f.setUriCredentials(req, options)
+	resp, err := http.NewClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("can't read file URI headers, status code: %d", resp.StatusCode)
+	}
+
+	etag := strings.Trim(strings.TrimPrefix(resp.Header.Get("ETag"), "W/"), `"`)
+	if etag == "" {
+		return "", nil
+	}
+
+	return ChecksumPrefixETag + etag, nil
+}
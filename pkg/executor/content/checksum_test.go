@@ -0,0 +1,94 @@
+package content
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestFetcherChecksum(t *testing.T) {
+	f := NewFetcher("")
+
+	t.Run("string content is hashed", func(t *testing.T) {
+		checksum, err := f.Checksum(&testkube.TestContent{Type_: string(testkube.TestContentTypeString), Data: "test"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", checksum)
+	})
+
+	t.Run("file uri content uses ETag", func(t *testing.T) {
+		etag := `W/"v2"`
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodHead, r.Method)
+			if etag != "" {
+				w.Header().Set("ETag", etag)
+			}
+		}))
+		defer server.Close()
+
+		checksum, err := f.Checksum(&testkube.TestContent{Type_: string(testkube.TestContentTypeFileURI), Uri: server.URL})
+		assert.NoError(t, err)
+		assert.Equal(t, "etag:v2", checksum)
+
+		etag = ""
+		checksum, err = f.Checksum(&testkube.TestContent{Type_: string(testkube.TestContentTypeFileURI), Uri: server.URL})
+		assert.NoError(t, err)
+		assert.Empty(t, checksum)
+	})
+
+	t.Run("file uri credentials are sent", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, _ := r.BasicAuth()
+			if username != "user" || password != "secret" || r.Header.Get("X-Api-Key") != "key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("ETag", `"v3"`)
+		}))
+		defer server.Close()
+
+		content := &testkube.TestContent{Type_: string(testkube.TestContentTypeFileURI), Uri: server.URL, UriOptions: &testkube.ContentUriOptions{
+			SecretName:  "credentials",
+			UsernameKey: "username",
+			PasswordKey: "password",
+			HeaderKeys:  map[string]string{"X-Api-Key": "api-key"},
+		}}
+
+		_, err := f.Checksum(content)
+		assert.Error(t, err)
+
+		checksum, err := f.WithUriCredentials(UriCredentials{Username: "user", Password: "secret", Headers: map[string]string{"X-Api-Key": "key"}}).
+			Checksum(content)
+		assert.NoError(t, err)
+		assert.Equal(t, "etag:v3", checksum)
+	})
+
+	t.Run("pinned git commit is used", func(t *testing.T) {
+		checksum, err := f.Checksum(&testkube.TestContent{
+			Type_:      string(testkube.TestContentTypeGitDir),
+			Repository: &testkube.Repository{Type_: "git", Uri: "https://github.com/kubeshop/testkube", Branch: "main", Commit: "9d1b2f3"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "git:9d1b2f3", checksum)
+	})
+
+	t.Run("empty content has no checksum", func(t *testing.T) {
+		checksum, err := f.Checksum(nil)
+
+		assert.NoError(t, err)
+		assert.Empty(t, checksum)
+	})
+}
+
+func TestFilesChecksum(t *testing.T) {
+	checksum := filesChecksum(map[string]string{"script.js": "import './lib.js'", "lib.js": "export default 1"})
+
+	assert.Equal(t, checksum, filesChecksum(map[string]string{"lib.js": "export default 1", "script.js": "import './lib.js'"}))
+	assert.NotEqual(t, checksum, filesChecksum(map[string]string{"script.js": "import './lib.js'", "lib.js": "export default 2"}))
+	assert.NotEqual(t, filesChecksum(map[string]string{"ab": "c"}), filesChecksum(map[string]string{"a": "bc"}))
+}
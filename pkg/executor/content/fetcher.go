@@ -11,18 +11,22 @@ import (
 
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/kubeshop/testkube/pkg/git"
-	"github.com/kubeshop/testkube/pkg/http"
 )
 
-// NewFetcher returns new file/dir fetcher based on given directory path
+// NewFetcher returns new file/dir fetcher based on given directory path, file URI content is cached
+// in directory set in RUNNER_CONTENT_CACHE_DIR env var
 func NewFetcher(path string) Fetcher {
 	return Fetcher{
-		path: path,
+		path:     path,
+		cacheDir: os.Getenv(CacheDirEnvVarName),
 	}
 }
 
 type Fetcher struct {
-	path string
+	path     string
+	cacheDir string
+	// uriCredentials are sent with file URI requests instead of credentials from env vars set by job
+	uriCredentials *UriCredentials
 }
 
 func (f Fetcher) Fetch(content *testkube.TestContent) (path string, err error) {
@@ -31,13 +35,15 @@ func (f Fetcher) Fetch(content *testkube.TestContent) (path string, err error) {
 	}
 	switch testkube.TestContentType(content.Type_) {
 	case testkube.TestContentTypeFileURI:
-		return f.FetchURI(content.Uri)
+		return f.FetchURIWithOptions(content.Uri, content.UriOptions)
 	case testkube.TestContentTypeString:
 		return f.FetchString(content.Data)
 	case testkube.TestContentTypeGitFile:
 		return f.FetchGitFile(content.Repository)
 	case testkube.TestContentTypeGitDir:
 		return f.FetchGitDir(content.Repository)
+	case testkube.TestContentTypeFiles:
+		return f.FetchFiles(content.Files)
 	default:
 		return path, fmt.Errorf("unhandled content type: '%s'", content.Type_)
 	}
@@ -48,16 +54,9 @@ func (f Fetcher) FetchString(str string) (path string, err error) {
 	return f.saveTempFile(strings.NewReader(str))
 }
 
-//FetchURI stores uri as local file
+// FetchURI stores uri as local file
 func (f Fetcher) FetchURI(uri string) (path string, err error) {
-	client := http.NewClient()
-	resp, err := client.Get(uri)
-	if err != nil {
-		return path, err
-	}
-	defer resp.Body.Close()
-
-	return f.saveTempFile(resp.Body)
+	return f.FetchURIWithOptions(uri, nil)
 }
 
 // FetchGitDir returns path to locally checked out git repo with partial path
@@ -67,6 +66,11 @@ func (f Fetcher) FetchGitDir(repo *testkube.Repository) (path string, err error)
 		return path, err
 	}
 
+	// pinned commit is checked out instead of branch head
+	if repo.Commit != "" {
+		return git.CheckoutCommit(uri, repo.Path, repo.Commit, f.path)
+	}
+
 	// if path not set make full repo checkout
 	if repo.Path == "" {
 		return git.Checkout(uri, repo.Branch, f.path)
@@ -82,7 +86,12 @@ func (f Fetcher) FetchGitFile(repo *testkube.Repository) (path string, err error
 		return path, err
 	}
 
-	repoPath, err := git.Checkout(uri, repo.Branch, f.path)
+	var repoPath string
+	if repo.Commit != "" {
+		repoPath, err = git.CheckoutCommit(uri, "", repo.Commit, f.path)
+	} else {
+		repoPath, err = git.Checkout(uri, repo.Branch, f.path)
+	}
 	if err != nil {
 		return path, err
 	}
@@ -91,6 +100,35 @@ func (f Fetcher) FetchGitFile(repo *testkube.Repository) (path string, err error
 	return filepath.Join(repoPath, repo.Path), nil
 }
 
+// FetchFiles returns path to local directory with inline content files saved in their relative paths
+func (f Fetcher) FetchFiles(files map[string]string) (path string, err error) {
+	dir := f.path
+	if dir == "" {
+		dir, err = ioutil.TempDir("", "content-files")
+		if err != nil {
+			return "", err
+		}
+	}
+
+	root := filepath.Join(dir, "files")
+	for name, data := range files {
+		filePath := filepath.Join(root, filepath.FromSlash(name))
+		if !strings.HasPrefix(filePath, root+string(filepath.Separator)) {
+			return "", fmt.Errorf("content file path %s is outside of content directory", name)
+		}
+
+		if err = os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return "", err
+		}
+
+		if err = os.WriteFile(filePath, []byte(data), 0644); err != nil {
+			return "", err
+		}
+	}
+
+	return root + "/", nil
+}
+
 // gitUri merge creds with git uri
 func (f Fetcher) gitURI(repo *testkube.Repository) (uri string, err error) {
 	if repo.Username != "" && repo.Token != "" {
@@ -0,0 +1,25 @@
+package content
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestFetchFiles(t *testing.T) {
+	f := NewFetcher(t.TempDir())
+
+	path, err := f.Fetch(&testkube.TestContent{
+		Type_: string(testkube.TestContentTypeFiles),
+		Files: map[string]string{"script.js": "import lib from './lib/helpers.js'", "lib/helpers.js": "export default 1"},
+	})
+	assert.NoError(t, err)
+	assertFileContent(t, filepath.Join(path, "script.js"), "import lib from './lib/helpers.js'")
+	assertFileContent(t, filepath.Join(path, "lib", "helpers.js"), "export default 1")
+
+	_, err = f.FetchFiles(map[string]string{"../outside.js": "1"})
+	assert.Error(t, err)
+}
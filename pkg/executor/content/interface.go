@@ -8,6 +8,7 @@ type ContentFetcher interface {
 	URIFetcher
 	GitDirFetcher
 	GitFileFetcher
+	FilesFetcher
 
 	Fetch(content *testkube.TestContent) (path string, err error)
 }
@@ -31,3 +32,8 @@ type GitDirFetcher interface {
 type GitFileFetcher interface {
 	FetchGitFile(repo *testkube.Repository) (path string, err error)
 }
+
+// FilesFetcher interface for fetching inline content files to local directory
+type FilesFetcher interface {
+	FetchFiles(files map[string]string) (path string, err error)
+}
@@ -0,0 +1,263 @@
+package content
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	thttp "github.com/kubeshop/testkube/pkg/http"
+)
+
+const (
+	// UriUsernameEnvVarName is file URI basic auth username env var set from secret by job
+	UriUsernameEnvVarName = "RUNNER_CONTENT_URI_USERNAME"
+	// UriPasswordEnvVarName is file URI basic auth password env var set from secret by job
+	UriPasswordEnvVarName = "RUNNER_CONTENT_URI_PASSWORD"
+	// UriHeaderEnvVarPrefix prefixes env vars with file URI header values set from secret by job
+	UriHeaderEnvVarPrefix = "RUNNER_CONTENT_URI_HEADER_"
+	// CacheDirEnvVarName is a directory file URI content is cached in by ETag, e.g. on persistent volume
+	CacheDirEnvVarName = "RUNNER_CONTENT_CACHE_DIR"
+)
+
+// uriRetryBackoff is a delay before first retry of failed file URI request, it doubles with every retry
+var uriRetryBackoff = time.Second
+
+var envVarNameInvalidChars = regexp.MustCompile("[^A-Z0-9_]")
+
+// UriHeaderEnvVarName returns name of env var with file URI header value
+func UriHeaderEnvVarName(header string) string {
+	return UriHeaderEnvVarPrefix + envVarNameInvalidChars.ReplaceAllString(strings.ToUpper(header), "_")
+}
+
+// UriCredentials are file URI basic auth credentials and header values read from secret of content URI options
+type UriCredentials struct {
+	Username string
+	Password string
+	// Headers are header values by header name
+	Headers map[string]string
+}
+
+// WithUriCredentials returns fetcher sending credentials with file URI requests, e.g. for API server which reads
+// them from secret instead of env vars set by job
+func (f Fetcher) WithUriCredentials(credentials UriCredentials) Fetcher {
+	f.uriCredentials = &credentials
+	return f
+}
+
+// setUriCredentials sets basic auth and headers of file URI request, credentials are read from env vars set by job
+// when fetcher has none
+func (f Fetcher) setUriCredentials(req *http.Request, options *testkube.ContentUriOptions) {
+	credentials := f.uriCredentials
+	if credentials == nil {
+		credentials = &UriCredentials{
+			Username: os.Getenv(UriUsernameEnvVarName),
+			Password: os.Getenv(UriPasswordEnvVarName),
+			Headers:  map[string]string{},
+		}
+		for header := range options.HeaderKeys {
+			credentials.Headers[header] = os.Getenv(UriHeaderEnvVarName(header))
+		}
+	}
+
+	if options.UsernameKey != "" {
+		req.SetBasicAuth(credentials.Username, credentials.Password)
+	}
+
+	for header := range options.HeaderKeys {
+		req.Header.Set(header, credentials.Headers[header])
+	}
+}
+
+// retryableError is an error of file URI request which is retried
+type retryableError struct {
+	err error
+}
+
+func (e retryableError) Error() string {
+	return e.err.Error()
+}
+
+// FetchURIWithOptions stores uri as local file, credentials are read from env vars set by job, failed requests are
+// retried with exponential backoff and content is cached by ETag when cache directory is set
+func (f Fetcher) FetchURIWithOptions(uri string, options *testkube.ContentUriOptions) (path string, err error) {
+	if options == nil {
+		options = &testkube.ContentUriOptions{}
+	}
+
+	backoff := uriRetryBackoff
+	for attempt := 0; ; attempt++ {
+		path, err = f.fetchURI(uri, options)
+		var retryable retryableError
+		if err == nil || !errors.As(err, &retryable) || attempt >= int(options.Retries) {
+			return path, err
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// fetchURI makes single request of file URI content
+func (f Fetcher) fetchURI(uri string, options *testkube.ContentUriOptions) (path string, err error) {
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return "", err
+	}
+
+	f.setUriCredentials(req, options)
+	cache := f.uriCache(uri)
+	if etag := cache.etag(); etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := thttp.NewClient().Do(req)
+	if err != nil {
+		return "", retryableError{err: err}
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cache.dir != "":
+		cached, err := os.Open(cache.dataPath())
+		if err != nil {
+			return "", err
+		}
+		defer cached.Close()
+
+		return f.saveURIContent(cached, options)
+	case resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests:
+		return "", retryableError{err: fmt.Errorf("fetching %s failed with status code: %d", uri, resp.StatusCode)}
+	case resp.StatusCode >= http.StatusBadRequest:
+		return "", fmt.Errorf("fetching %s failed with status code: %d", uri, resp.StatusCode)
+	}
+
+	if options.MaxSize > 0 && resp.ContentLength > options.MaxSize {
+		return "", fmt.Errorf("content of %s has %d bytes, exceeding max size of %d bytes", uri, resp.ContentLength, options.MaxSize)
+	}
+
+	path, err = f.saveURIContent(resp.Body, options)
+	if err != nil {
+		return "", err
+	}
+
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		// caching failure doesn't fail fetching, content is downloaded again next time
+		_ = cache.store(etag, path)
+	}
+
+	return path, nil
+}
+
+// saveURIContent saves content to temp file, content size and checksum are verified
+func (f Fetcher) saveURIContent(reader io.Reader, options *testkube.ContentUriOptions) (path string, err error) {
+	if options.MaxSize > 0 {
+		// one more byte is read to detect exceeded size
+		reader = io.LimitReader(reader, options.MaxSize+1)
+	}
+
+	hash := sha256.New()
+	counter := &countingWriter{}
+	path, err = f.saveTempFile(io.TeeReader(reader, io.MultiWriter(hash, counter)))
+	if err != nil {
+		return "", err
+	}
+
+	if options.MaxSize > 0 && counter.count > options.MaxSize {
+		return "", fmt.Errorf("content exceeds max size of %d bytes", options.MaxSize)
+	}
+
+	if options.Sha256 != "" {
+		if checksum := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(checksum, options.Sha256) {
+			return "", fmt.Errorf("content checksum mismatch, expected sha256 %s, got %s", options.Sha256, checksum)
+		}
+	}
+
+	return path, nil
+}
+
+// countingWriter counts written bytes
+type countingWriter struct {
+	count int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.count += int64(len(p))
+	return len(p), nil
+}
+
+// uriCache is a cache entry of file URI content stored with its ETag
+type uriCache struct {
+	dir string
+	key string
+}
+
+// uriCache returns cache entry of uri, entry without directory is disabled
+func (f Fetcher) uriCache(uri string) uriCache {
+	key := sha256.Sum256([]byte(uri))
+	return uriCache{dir: f.cacheDir, key: hex.EncodeToString(key[:])}
+}
+
+func (c uriCache) dataPath() string {
+	return filepath.Join(c.dir, c.key)
+}
+
+func (c uriCache) etagPath() string {
+	return filepath.Join(c.dir, c.key+".etag")
+}
+
+// etag returns ETag of cached content, empty ETag is returned when content isn't cached
+func (c uriCache) etag() string {
+	if c.dir == "" {
+		return ""
+	}
+
+	if _, err := os.Stat(c.dataPath()); err != nil {
+		return ""
+	}
+
+	etag, err := os.ReadFile(c.etagPath())
+	if err != nil {
+		return ""
+	}
+
+	return string(etag)
+}
+
+// store copies fetched content to cache, ETag is written last so partially stored content isn't used
+func (c uriCache) store(etag, path string) error {
+	if c.dir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return err
+	}
+
+	source, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	_ = os.Remove(c.etagPath())
+	target, err := os.Create(c.dataPath())
+	if err != nil {
+		return err
+	}
+	defer target.Close()
+
+	if _, err = io.Copy(target, source); err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.etagPath(), []byte(etag), 0644)
+}
@@ -0,0 +1,126 @@
+package content
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const uriContent = `{"some":"json"}`
+
+// uriContentSha256 is SHA-256 checksum of uriContent
+const uriContentSha256 = "4aa812d043cd10f9a49b836b07da2ca9b13f6c1a1a95f3bdc0916a7f7b7b148d"
+
+func TestFetchURIWithOptions(t *testing.T) {
+	uriRetryBackoff = time.Millisecond
+
+	t.Run("credentials are read from env vars", func(t *testing.T) {
+		t.Setenv(UriUsernameEnvVarName, "user")
+		t.Setenv(UriPasswordEnvVarName, "pass")
+		t.Setenv(UriHeaderEnvVarName("X-Api-Key"), "key")
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			username, password, ok := r.BasicAuth()
+			if !ok || username != "user" || password != "pass" || r.Header.Get("X-Api-Key") != "key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, uriContent)
+		}))
+		defer server.Close()
+
+		path, err := NewFetcher(t.TempDir()).FetchURIWithOptions(server.URL, &testkube.ContentUriOptions{
+			SecretName:  "credentials",
+			UsernameKey: "username",
+			PasswordKey: "password",
+			HeaderKeys:  map[string]string{"X-Api-Key": "key"},
+		})
+		assert.NoError(t, err)
+		assertFileContent(t, path, uriContent)
+
+		_, err = NewFetcher(t.TempDir()).FetchURI(server.URL)
+		assert.Error(t, err, "unauthorized request isn't retried")
+	})
+
+	t.Run("failed requests are retried", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, uriContent)
+		}))
+		defer server.Close()
+
+		_, err := NewFetcher(t.TempDir()).FetchURIWithOptions(server.URL, &testkube.ContentUriOptions{Retries: 1})
+		assert.Error(t, err)
+		assert.Equal(t, 2, requests)
+
+		path, err := NewFetcher(t.TempDir()).FetchURIWithOptions(server.URL, &testkube.ContentUriOptions{Retries: 1})
+		assert.NoError(t, err)
+		assertFileContent(t, path, uriContent)
+	})
+
+	t.Run("size and checksum are verified", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, uriContent)
+		}))
+		defer server.Close()
+
+		_, err := NewFetcher(t.TempDir()).FetchURIWithOptions(server.URL, &testkube.ContentUriOptions{MaxSize: 5})
+		assert.Error(t, err)
+
+		_, err = NewFetcher(t.TempDir()).FetchURIWithOptions(server.URL, &testkube.ContentUriOptions{Sha256: "00"})
+		assert.Error(t, err)
+
+		path, err := NewFetcher(t.TempDir()).FetchURIWithOptions(server.URL, &testkube.ContentUriOptions{
+			MaxSize: int64(len(uriContent)),
+			Sha256:  uriContentSha256,
+		})
+		assert.NoError(t, err)
+		assertFileContent(t, path, uriContent)
+	})
+
+	t.Run("content is cached by ETag", func(t *testing.T) {
+		downloads := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			downloads++
+			fmt.Fprint(w, uriContent)
+		}))
+		defer server.Close()
+
+		t.Setenv(CacheDirEnvVarName, t.TempDir())
+		for i := 0; i < 2; i++ {
+			path, err := NewFetcher(t.TempDir()).FetchURI(server.URL)
+			assert.NoError(t, err)
+			assertFileContent(t, path, uriContent)
+		}
+
+		assert.Equal(t, 1, downloads)
+	})
+}
+
+func TestUriHeaderEnvVarName(t *testing.T) {
+	assert.Equal(t, "RUNNER_CONTENT_URI_HEADER_X_API_KEY", UriHeaderEnvVarName("X-Api-Key"))
+	assert.Equal(t, "RUNNER_CONTENT_URI_HEADER_AUTHORIZATION", UriHeaderEnvVarName("Authorization"))
+}
+
+func assertFileContent(t *testing.T, path, content string) {
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, content, string(data))
+}
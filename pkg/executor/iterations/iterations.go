@@ -0,0 +1,171 @@
+package iterations
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/content"
+)
+
+const (
+	// gitUsernameEnvVarName and gitTokenEnvVarName are test git credentials passed to executor container by job
+	gitUsernameEnvVarName = "RUNNER_GITUSERNAME"
+	gitTokenEnvVarName    = "RUNNER_GITTOKEN"
+)
+
+// Fetch fetches iteration data file to directory, git data files without credentials are fetched
+// with test git credentials, so data files can be kept in private test repository
+func Fetch(dataFile *testkube.TestContent, dir string) (path string, err error) {
+	if dataFile == nil {
+		return "", fmt.Errorf("data file is not set")
+	}
+
+	fetcher := content.NewFetcher(dir)
+	switch testkube.TestContentType(dataFile.Type_) {
+	case testkube.TestContentTypeString, testkube.TestContentTypeFileURI:
+		return fetcher.Fetch(dataFile)
+	case testkube.TestContentTypeGitFile:
+		if dataFile.Repository == nil {
+			return "", fmt.Errorf("data file repository is not set")
+		}
+
+		repository := *dataFile.Repository
+		if repository.Username == "" && repository.Token == "" {
+			repository.Username = os.Getenv(gitUsernameEnvVarName)
+			repository.Token = os.Getenv(gitTokenEnvVarName)
+		}
+		return fetcher.FetchGitFile(&repository)
+	default:
+		return "", fmt.Errorf("unsupported data file type: '%s'", dataFile.Type_)
+	}
+}
+
+// ReadRows reads data rows from CSV file with header row or from JSON file with array of objects,
+// JSON values which aren't strings are kept in their JSON form
+func ReadRows(path string) (rows []map[string]string, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	if isJSON(reader) {
+		return readJSONRows(reader)
+	}
+
+	return readCSVRows(reader)
+}
+
+// Run runs iteration for each data row, returned result has iteration results with their rows and steps,
+// steps prefixed with iteration number and outputs of all iterations, it's failed when any iteration failed
+func Run(rows []map[string]string, run func(row map[string]string) (testkube.ExecutionResult, error)) testkube.ExecutionResult {
+	result := testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed}
+	var output strings.Builder
+	var failed []string
+	for i, row := range rows {
+		number := i + 1
+		iterationResult, err := run(row)
+		if err != nil {
+			iterationResult.Err(err)
+		}
+
+		iteration := testkube.NewExecutionIteration(number, row, iterationResult)
+		result.Iterations = append(result.Iterations, iteration)
+		if iteration.Status == nil || *iteration.Status != testkube.PASSED_ExecutionStatus {
+			failed = append(failed, fmt.Sprint(number))
+		}
+
+		for _, step := range iteration.Steps {
+			step.Name = fmt.Sprintf("iteration %d: %s", number, step.Name)
+			result.Steps = append(result.Steps, step)
+		}
+
+		if result.OutputType == "" {
+			result.OutputType = iterationResult.OutputType
+		}
+		fmt.Fprintf(&output, "iteration %d\n%s\n", number, iterationResult.Output)
+
+		for name, value := range iterationResult.OutputVariables {
+			if result.OutputVariables == nil {
+				result.OutputVariables = map[string]string{}
+			}
+			result.OutputVariables[name] = value
+		}
+	}
+
+	result.Output = output.String()
+	if len(failed) != 0 {
+		result.Status = testkube.ExecutionStatusFailed
+		result.ErrorMessage = fmt.Sprintf("iterations %s of %d failed", strings.Join(failed, ", "), len(rows))
+	}
+
+	return result
+}
+
+func isJSON(reader *bufio.Reader) bool {
+	for i := 1; ; i++ {
+		peek, err := reader.Peek(i)
+		if len(peek) < i {
+			return false
+		}
+
+		if c := peek[i-1]; c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			return c == '['
+		}
+
+		if err != nil {
+			return false
+		}
+	}
+}
+
+func readJSONRows(reader io.Reader) (rows []map[string]string, err error) {
+	var items []map[string]json.RawMessage
+	if err = json.NewDecoder(reader).Decode(&items); err != nil {
+		return nil, fmt.Errorf("data file is not JSON array of objects: %w", err)
+	}
+
+	for _, item := range items {
+		row := make(map[string]string, len(item))
+		for name, value := range item {
+			var text string
+			if err = json.Unmarshal(value, &text); err != nil {
+				text = string(bytes.TrimSpace(value))
+			}
+			row[name] = text
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func readCSVRows(reader io.Reader) (rows []map[string]string, err error) {
+	records, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("data file is not valid CSV: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, name := range header {
+			row[name] = record[i]
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,92 @@
+package iterations
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestReadRows(t *testing.T) {
+	t.Run("CSV file", func(t *testing.T) {
+		rows, err := ReadRows(writeFile(t, "user,password\nadmin,secret\nguest,\"a,b\"\n"))
+		require.NoError(t, err)
+		assert.Equal(t, []map[string]string{
+			{"user": "admin", "password": "secret"},
+			{"user": "guest", "password": "a,b"},
+		}, rows)
+	})
+
+	t.Run("JSON file", func(t *testing.T) {
+		rows, err := ReadRows(writeFile(t, "\n  [{\"user\": \"admin\", \"age\": 42, \"tags\": [\"a\"]}]"))
+		require.NoError(t, err)
+		assert.Equal(t, []map[string]string{{"user": "admin", "age": "42", "tags": `["a"]`}}, rows)
+	})
+
+	t.Run("invalid JSON file", func(t *testing.T) {
+		_, err := ReadRows(writeFile(t, `["admin"]`))
+		assert.Error(t, err)
+	})
+}
+
+func TestRun(t *testing.T) {
+	rows := []map[string]string{{"user": "admin"}, {"user": "guest"}, {"user": "bot"}}
+
+	result := Run(rows, func(row map[string]string) (testkube.ExecutionResult, error) {
+		if row["user"] == "bot" {
+			return testkube.ExecutionResult{}, errors.New("runner failed")
+		}
+
+		status := testkube.PASSED_ExecutionStatus
+		if row["user"] == "guest" {
+			status = testkube.FAILED_ExecutionStatus
+		}
+
+		return testkube.ExecutionResult{
+			Status:          testkube.StatusPtr(status),
+			Output:          row["user"],
+			OutputType:      "text/plain",
+			OutputVariables: map[string]string{row["user"]: "1"},
+			Steps:           []testkube.ExecutionStepResult{{Name: "login", Status: string(status)}},
+		}, nil
+	})
+
+	assert.Equal(t, testkube.FAILED_ExecutionStatus, *result.Status)
+	assert.Equal(t, "iterations 2, 3 of 3 failed", result.ErrorMessage)
+	assert.Equal(t, "iteration 1\nadmin\niteration 2\nguest\niteration 3\n\n", result.Output)
+	assert.Equal(t, "text/plain", result.OutputType)
+	assert.Equal(t, map[string]string{"admin": "1", "guest": "1"}, result.OutputVariables)
+
+	require.Len(t, result.Iterations, 3)
+	assert.Equal(t, int32(1), result.Iterations[0].Number)
+	assert.Equal(t, rows[1], result.Iterations[1].Data)
+	assert.Equal(t, "runner failed", result.Iterations[2].ErrorMessage)
+
+	require.Len(t, result.Steps, 2)
+	assert.Equal(t, "iteration 1: login", result.Steps[0].Name)
+	assert.Equal(t, "iteration 2: login", result.Steps[1].Name)
+}
+
+func TestFetch(t *testing.T) {
+	dir := t.TempDir()
+	path, err := Fetch(&testkube.TestContent{Type_: string(testkube.TestContentTypeString), Data: "user\nadmin\n"}, dir)
+	require.NoError(t, err)
+
+	rows, err := ReadRows(path)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{{"user": "admin"}}, rows)
+
+	_, err = Fetch(&testkube.TestContent{Type_: string(testkube.TestContentTypeGitFile)}, dir)
+	assert.Error(t, err)
+}
+
+func writeFile(t *testing.T, data string) string {
+	path := filepath.Join(t.TempDir(), "data")
+	require.NoError(t, os.WriteFile(path, []byte(data), 0644))
+	return path
+}
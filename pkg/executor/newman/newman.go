@@ -0,0 +1,87 @@
+// Package newman maps newman options of execution to newman run arguments, the postman collection runner of
+// kubeshop/testkube-executor-postman appends Args of execution newman options to its newman run arguments, the
+// executor isn't part of this repository, so options are ignored by executor images built before it
+package newman
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/iterations"
+)
+
+// Args fetches environment and globals files of options to directory and returns newman run arguments,
+// every file is fetched to its own subdirectory so it doesn't overwrite test content, newman defaults are
+// used for options which aren't set
+func Args(options *testkube.NewmanOptions, dir string) (args []string, err error) {
+	if options == nil {
+		return nil, nil
+	}
+
+	files := []struct {
+		name string
+		flag string
+		file *testkube.TestContent
+	}{
+		{name: "environment", flag: "-e", file: options.EnvironmentFile},
+		{name: "globals", flag: "-g", file: options.GlobalsFile},
+	}
+	for _, f := range files {
+		if f.file == nil {
+			continue
+		}
+
+		fileDir := filepath.Join(dir, "newman-"+f.name)
+		if err = os.MkdirAll(fileDir, 0755); err != nil {
+			return nil, err
+		}
+
+		path, err := iterations.Fetch(f.file, fileDir)
+		if err != nil {
+			return nil, fmt.Errorf("can't fetch newman %s file: %w", f.name, err)
+		}
+		args = append(args, f.flag, path)
+	}
+
+	for _, folder := range options.Folders {
+		args = append(args, "--folder", folder)
+	}
+
+	if options.Iterations > 0 {
+		args = append(args, "-n", strconv.Itoa(int(options.Iterations)))
+	}
+
+	if options.Bail != nil && *options.Bail {
+		args = append(args, "--bail")
+	}
+
+	if options.TimeoutRequest != "" {
+		timeout, err := time.ParseDuration(options.TimeoutRequest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid newman request timeout %q: %w", options.TimeoutRequest, err)
+		}
+		args = append(args, "--timeout-request", strconv.FormatInt(timeout.Milliseconds(), 10))
+	}
+
+	if options.Insecure != nil && *options.Insecure {
+		args = append(args, "--insecure")
+	}
+
+	if cert := options.SslClientCert; cert != nil {
+		args = append(args, "--ssl-client-cert", testkube.NewmanSslClientCertPath, "--ssl-client-key", testkube.NewmanSslClientKeyPath)
+		if cert.PassphraseKey != "" {
+			passphrase, err := os.ReadFile(testkube.NewmanSslClientPassphrasePath)
+			if err != nil {
+				return nil, fmt.Errorf("can't read newman SSL client passphrase: %w", err)
+			}
+			args = append(args, "--ssl-client-passphrase", strings.TrimSpace(string(passphrase)))
+		}
+	}
+
+	return args, nil
+}
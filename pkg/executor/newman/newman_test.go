@@ -0,0 +1,60 @@
+package newman
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestArgs(t *testing.T) {
+	t.Run("no options", func(t *testing.T) {
+		args, err := Args(nil, t.TempDir())
+		require.NoError(t, err)
+		assert.Empty(t, args)
+	})
+
+	t.Run("files and folders", func(t *testing.T) {
+		args, err := Args(&testkube.NewmanOptions{
+			EnvironmentFile: testkube.NewStringTestContent(`{"name":"staging"}`),
+			GlobalsFile:     testkube.NewStringTestContent(`{"name":"globals"}`),
+			Folders:         []string{"Users", "Orders"},
+		}, t.TempDir())
+		require.NoError(t, err)
+		require.Len(t, args, 8)
+		assert.Equal(t, "-e", args[0])
+		assert.Equal(t, "-g", args[2])
+		assert.Equal(t, []string{"--folder", "Users", "--folder", "Orders"}, args[4:])
+
+		environment, err := os.ReadFile(args[1])
+		require.NoError(t, err)
+		assert.Equal(t, `{"name":"staging"}`, string(environment))
+
+		globals, err := os.ReadFile(args[3])
+		require.NoError(t, err)
+		assert.Equal(t, `{"name":"globals"}`, string(globals))
+	})
+
+	t.Run("run options", func(t *testing.T) {
+		enabled := true
+		args, err := Args(&testkube.NewmanOptions{
+			Iterations:     3,
+			Bail:           &enabled,
+			TimeoutRequest: "1m30s",
+			Insecure:       &enabled,
+			SslClientCert:  &testkube.NewmanSslClientCert{SecretName: "api-client-cert"},
+		}, t.TempDir())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"-n", "3", "--bail", "--timeout-request", "90000", "--insecure",
+			"--ssl-client-cert", testkube.NewmanSslClientCertPath, "--ssl-client-key", testkube.NewmanSslClientKeyPath}, args)
+	})
+
+	t.Run("unsupported file type", func(t *testing.T) {
+		_, err := Args(&testkube.NewmanOptions{EnvironmentFile: &testkube.TestContent{Type_: "git-dir"}}, t.TempDir())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "can't fetch newman environment file")
+	})
+}
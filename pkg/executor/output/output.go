@@ -11,6 +11,7 @@ const TypeLogEvent = "event"
 const TypeLogLine = "line"
 const TypeError = "error"
 const TypeResult = "result"
+const TypeVariable = "variable"
 
 // NewOutputEvent returns new Output struct of type event
 func NewOutputEvent(message string) Output {
@@ -39,8 +40,18 @@ func NewOutputError(err error) Output {
 // NewOutputResult returns new Output struct of type result - should be last line in stream as it'll stop listening
 func NewOutputResult(result testkube.ExecutionResult) Output {
 	return Output{
-		Type_:  TypeResult,
-		Result: &result,
+		Type_:   TypeResult,
+		Result:  &result,
+		Version: ResultSchemaV1,
+	}
+}
+
+// NewOutputVariable returns new Output struct of type variable
+func NewOutputVariable(name, value string) Output {
+	return Output{
+		Type_:   TypeVariable,
+		Name:    name,
+		Content: value,
 	}
 }
 
@@ -52,6 +63,8 @@ func (out Output) String() string {
 	switch out.Type_ {
 	case TypeError, TypeLogLine, TypeLogEvent:
 		return out.Content
+	case TypeVariable:
+		return out.Name + "=" + out.Content
 	case TypeResult:
 		b, _ := json.Marshal(out.Result)
 		return string(b)
@@ -78,6 +91,12 @@ func PrintResult(result testkube.ExecutionResult) {
 	fmt.Printf("%s\n", out)
 }
 
+// PrintVariable - prints output variable as output json
+func PrintVariable(name, value string) {
+	out, _ := json.Marshal(NewOutputVariable(name, value))
+	fmt.Printf("%s\n", out)
+}
+
 // PrintEvent - prints event as output json
 func PrintEvent(message string, obj ...interface{}) {
 	out, _ := json.Marshal(NewOutputEvent(fmt.Sprintf("%s %v", message, obj)))
@@ -20,10 +20,26 @@ func GetExecutionResult(b []byte) (is bool, result testkube.ExecutionResult) {
 	return err == nil, result
 }
 
+// getRawResult returns raw result and schema version of result output line
+func getRawResult(b []byte) (raw json.RawMessage, version string, ok bool) {
+	var out struct {
+		Type_   string          `json:"type"`
+		Version string          `json:"version"`
+		Result  json.RawMessage `json:"result"`
+	}
+
+	if err := json.Unmarshal(b, &out); err != nil || out.Type_ != TypeResult || len(out.Result) == 0 || string(out.Result) == "null" {
+		return nil, "", false
+	}
+
+	return out.Result, out.Version, true
+}
+
 // ParseRunnerOutput try to parse possible runner output which is some bunch
 // of json stream like
 // {"type": "line", "message": "runner execution started  ------------"}
 // {"type": "line", "message": "GET /results"}
+// {"type": "variable", "name": "userId", "content": "123"}
 // {"type": "result", "result": {"id": "2323", "output": "-----"}}
 func ParseRunnerOutput(b []byte) (result testkube.ExecutionResult, logs []string, err error) {
 	scanner := bufio.NewScanner(bytes.NewReader(b))
@@ -36,6 +52,7 @@ func ParseRunnerOutput(b []byte) (result testkube.ExecutionResult, logs []string
 	// but there could be some buffers or go routines used so go through whole
 	// array too
 	result.Status = testkube.ExecutionStatusFailed
+	variables := map[string]string{}
 	for scanner.Scan() {
 		b := scanner.Bytes()
 
@@ -43,6 +60,19 @@ func ParseRunnerOutput(b []byte) (result testkube.ExecutionResult, logs []string
 			// empty or non json line
 			continue
 		}
+		// results are validated raw, as values not matching schema can be lost or zeroed when decoded
+		if raw, version, ok := getRawResult(b); ok {
+			violations, err := ValidateResult(version, raw)
+			if err != nil {
+				violations = []string{err.Error()}
+			}
+
+			if len(violations) > 0 {
+				result = NewInvalidResult(version, raw, violations)
+				continue
+			}
+		}
+
 		log, err := GetLogEntry(scanner.Bytes())
 		if err != nil {
 			// try to read in case of some lines which we couldn't parse
@@ -63,9 +93,27 @@ func ParseRunnerOutput(b []byte) (result testkube.ExecutionResult, logs []string
 
 		case TypeLogEvent, TypeLogLine:
 			logs = append(logs, log.Content)
+
+		case TypeVariable:
+			if log.Name != "" {
+				variables[log.Name] = log.Content
+			}
 		}
 
 	}
 
+	// variables set directly in result have priority over ones emitted as separate outputs
+	if len(variables) > 0 {
+		if result.OutputVariables == nil {
+			result.OutputVariables = map[string]string{}
+		}
+
+		for name, value := range variables {
+			if _, ok := result.OutputVariables[name]; !ok {
+				result.OutputVariables[name] = value
+			}
+		}
+	}
+
 	return result, logs, scanner.Err()
 }
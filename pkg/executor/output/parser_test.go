@@ -62,3 +62,38 @@ func TestParseRunnerOutput(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, testkube.ExecutionStatusFailed, result.Status)
 }
+
+func TestParseRunnerOutputVariables(t *testing.T) {
+	output := []byte(`{"type":"line","content":"creating user"}
+{"type":"variable","name":"userId","content":"123"}
+{"type":"variable","name":"token","content":"from-line"}
+{"type":"result","result":{"status":"passed","outputVariables":{"token":"from-result"}}}
+`)
+
+	result, logs, err := ParseRunnerOutput(output)
+
+	assert.NoError(t, err)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, testkube.ExecutionStatusPassed, result.Status)
+	assert.Equal(t, map[string]string{"userId": "123", "token": "from-result"}, result.OutputVariables)
+}
+
+func TestParseRunnerOutputInvalidResult(t *testing.T) {
+	output := []byte(`{"type":"line","content":"running tests"}
+{"type":"result","result":{"status":"ok","steps":[{"name":"Health"}],"metrics":{"p95_latency":"120ms"}}}
+`)
+
+	result, _, err := ParseRunnerOutput(output)
+
+	assert.NoError(t, err)
+	assert.Equal(t, testkube.ExecutionStatusFailed, result.Status)
+	assert.Equal(t, testkube.ErrorTypeInvalidResult, result.ErrorType)
+	assert.Equal(t, "runner result doesn't match result schema v1: result.metrics.p95_latency: expected number, got string; "+
+		"result.status: value ok is not one of [queued running passed failed aborted timeout skipped]; "+
+		"result.steps[0]: missing required property status", result.ErrorMessage)
+	assert.Equal(t, `{"status":"ok","steps":[{"name":"Health"}],"metrics":{"p95_latency":"120ms"}}`, result.Output)
+
+	result, _, err = ParseRunnerOutput([]byte(`{"type":"result","version":"v2","result":{"status":"passed"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "runner result doesn't match result schema v2: unsupported result schema version v2", result.ErrorMessage)
+}
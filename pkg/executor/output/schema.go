@@ -0,0 +1,203 @@
+package output
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const (
+	// ResultSchemaV1 is a version of runner result schema used by results without version
+	ResultSchemaV1 = "v1"
+	// maxSchemaViolations is a number of schema violations reported in execution error
+	maxSchemaViolations = 10
+)
+
+//go:embed schemas/result.v1.json
+var resultSchemaV1 []byte
+
+// resultSchemas are JSON Schemas of runner results by version
+var resultSchemas = map[string]*jsonSchema{
+	ResultSchemaV1: mustParseSchema(resultSchemaV1),
+}
+
+// ResultSchema returns JSON Schema document of runner result version
+func ResultSchema(version string) ([]byte, error) {
+	if version == ResultSchemaV1 {
+		return resultSchemaV1, nil
+	}
+
+	return nil, fmt.Errorf("unsupported result schema version %s", version)
+}
+
+// jsonSchema is a subset of JSON Schema draft 7 keywords used in runner result schemas
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 string                 `json:"type"`
+	Enum                 []interface{}          `json:"enum"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	Minimum              *float64               `json:"minimum"`
+	Definitions          map[string]*jsonSchema `json:"definitions"`
+}
+
+func mustParseSchema(data []byte) *jsonSchema {
+	var schema jsonSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		panic(fmt.Sprintf("invalid runner result schema: %s", err))
+	}
+
+	return &schema
+}
+
+// ValidateResult validates raw runner result against JSON Schema of its version, violations are returned
+// with JSON paths of invalid values
+func ValidateResult(version string, raw json.RawMessage) ([]string, error) {
+	if version == "" {
+		version = ResultSchemaV1
+	}
+
+	schema, ok := resultSchemas[version]
+	if !ok {
+		return nil, fmt.Errorf("unsupported result schema version %s", version)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid result JSON: %w", err)
+	}
+
+	return schema.validate(schema, "result", value), nil
+}
+
+// NewInvalidResult returns failed result of runner result violating its schema, raw result is attached as output
+func NewInvalidResult(version string, raw json.RawMessage, violations []string) testkube.ExecutionResult {
+	if version == "" {
+		version = ResultSchemaV1
+	}
+
+	message := strings.Join(violations, "; ")
+	if len(violations) > maxSchemaViolations {
+		message = fmt.Sprintf("%s and %d more", strings.Join(violations[:maxSchemaViolations], "; "), len(violations)-maxSchemaViolations)
+	}
+
+	result := testkube.NewErrorExecutionResult(fmt.Errorf("runner result doesn't match result schema %s: %s", version, message))
+	result.ErrorType = testkube.ErrorTypeInvalidResult
+	result.Output = string(raw)
+	result.OutputType = "application/json"
+	return result
+}
+
+func (s *jsonSchema) validate(root *jsonSchema, path string, value interface{}) (violations []string) {
+	if s.Ref != "" {
+		definition, ok := root.Definitions[strings.TrimPrefix(s.Ref, "#/definitions/")]
+		if !ok {
+			return []string{fmt.Sprintf("%s: unknown schema reference %s", path, s.Ref)}
+		}
+
+		return definition.validate(root, path, value)
+	}
+
+	if s.Type != "" && !isSchemaType(s.Type, value) {
+		return []string{fmt.Sprintf("%s: expected %s, got %s", path, s.Type, schemaTypeOf(value))}
+	}
+
+	if len(s.Enum) > 0 && !isEnumValue(s.Enum, value) {
+		violations = append(violations, fmt.Sprintf("%s: value %v is not one of %v", path, value, s.Enum))
+	}
+
+	switch v := value.(type) {
+	case json.Number:
+		if number, _ := v.Float64(); s.Minimum != nil && number < *s.Minimum {
+			violations = append(violations, fmt.Sprintf("%s: value %v is less than %v", path, v, *s.Minimum))
+		}
+
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				violations = append(violations, s.Items.validate(root, fmt.Sprintf("%s[%d]", path, i), item)...)
+			}
+		}
+
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				violations = append(violations, fmt.Sprintf("%s: missing required property %s", path, name))
+			}
+		}
+
+		// properties are validated in order, so violations are reported the same way every time
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			property, ok := s.Properties[name]
+			if !ok {
+				property = s.AdditionalProperties
+			}
+
+			if property != nil {
+				violations = append(violations, property.validate(root, path+"."+name, v[name])...)
+			}
+		}
+	}
+
+	return violations
+}
+
+func isSchemaType(schemaType string, value interface{}) bool {
+	if schemaType == "integer" {
+		number, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+
+		f, err := number.Float64()
+		return err == nil && f == math.Trunc(f)
+	}
+
+	return schemaTypeOf(value) == schemaType || (schemaType == "number" && schemaTypeOf(value) == "integer")
+}
+
+func schemaTypeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func isEnumValue(enum []interface{}, value interface{}) bool {
+	for _, item := range enum {
+		if fmt.Sprint(item) == fmt.Sprint(value) {
+			return true
+		}
+	}
+
+	return false
+}
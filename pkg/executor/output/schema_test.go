@@ -0,0 +1,37 @@
+package output
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateResult(t *testing.T) {
+	for name, test := range map[string]struct {
+		result     string
+		violations []string
+	}{
+		"valid result":             {result: `{"status":"passed","iterations":[{"number":1,"status":"passed","data":{"user":"jane"}}],"metrics":{"error_rate":0.01}}`},
+		"unknown fields":           {result: `{"status":"passed","id":"2323"}`},
+		"missing status":           {result: `{"output":"done"}`, violations: []string{"result: missing required property status"}},
+		"not an object":            {result: `"passed"`, violations: []string{"result: expected object, got string"}},
+		"fractional iteration":     {result: `{"status":"passed","iterations":[{"number":1.5,"status":"passed"}]}`, violations: []string{"result.iterations[0].number: expected integer, got number"}},
+		"iteration number below 1": {result: `{"status":"passed","iterations":[{"number":0,"status":"passed"}]}`, violations: []string{"result.iterations[0].number: value 0 is less than 1"}},
+		"variable value":           {result: `{"status":"passed","outputVariables":{"userId":123}}`, violations: []string{"result.outputVariables.userId: expected string, got integer"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			violations, err := ValidateResult("", json.RawMessage(test.result))
+			require.NoError(t, err)
+			assert.Equal(t, test.violations, violations)
+		})
+	}
+
+	_, err := ValidateResult("v2", json.RawMessage(`{"status":"passed"}`))
+	assert.Error(t, err)
+
+	schema, err := ResultSchema(ResultSchemaV1)
+	require.NoError(t, err)
+	assert.True(t, json.Valid(schema))
+}
@@ -42,3 +42,15 @@ func (s MinioScraper) Scrape(id string, directories []string) error {
 
 	return client.ScrapeArtefacts(id, directories...)
 }
+
+// ScrapeProject gets artifacts from pod based on execution ID, project and directories list, artifacts of project
+// executions are stored in project bucket
+func (s MinioScraper) ScrapeProject(id, project string, directories []string) error {
+	client := minio.NewClient(s.Endpoint, s.AccessKeyID, s.SecretAccessKey, s.Location, s.Token, s.Ssl) // create storage client
+	err := client.Connect()
+	if err != nil {
+		return fmt.Errorf("error occured creating minio client: %w", err)
+	}
+
+	return client.ScrapeProjectArtefacts(id, project, directories...)
+}
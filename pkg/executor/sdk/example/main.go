@@ -0,0 +1,78 @@
+// Example executor checks that URL passed in string test content responds with expected status code, it's built
+// with executor SDK:
+//
+//	kubectl testkube create executor --name http-status-executor --types http/status --image <example image>
+//	kubectl testkube create test --name api-health --type http/status --file url.txt --param status=204
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/sdk"
+)
+
+// client limits waiting for slow URLs, so executor reports failed test before job deadline
+var client = &http.Client{Timeout: 30 * time.Second}
+
+func main() {
+	sdk.Main(run)
+}
+
+func run(e *sdk.Executor) (testkube.ExecutionResult, error) {
+	data, err := os.ReadFile(e.ContentPath)
+	if err != nil {
+		return testkube.ExecutionResult{}, fmt.Errorf("test content should be file with URL: %w", err)
+	}
+
+	expected := http.StatusOK
+	if status, ok := e.Execution.Params["status"]; ok {
+		if expected, err = strconv.Atoi(status); err != nil {
+			return testkube.ExecutionResult{}, fmt.Errorf("invalid status param %s", status)
+		}
+	}
+
+	url := strings.TrimSpace(string(data))
+	e.Log("GET %s", url)
+	response, err := client.Get(url)
+	if err != nil {
+		return testkube.NewErrorExecutionResult(err), nil
+	}
+	defer response.Body.Close()
+
+	e.Variable("statusCode", strconv.Itoa(response.StatusCode))
+	// response is kept as execution artifact
+	dir := filepath.Join(e.Params.DataDir, "artifacts")
+	if err = saveResponse(dir, response); err == nil {
+		err = e.UploadArtifacts(dir)
+	}
+	if err != nil {
+		e.Event("can't upload response", err.Error())
+	}
+
+	if response.StatusCode != expected {
+		return testkube.NewErrorExecutionResult(fmt.Errorf("got status %s, expected %d", response.Status, expected)), nil
+	}
+
+	return testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed, Output: "got status " + response.Status}, nil
+}
+
+func saveResponse(dir string, response *http.Response) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(filepath.Join(dir, "response.txt"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return response.Write(f)
+}
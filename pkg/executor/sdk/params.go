@@ -0,0 +1,29 @@
+package sdk
+
+import (
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Params are executor params passed by job in RUNNER_ prefixed env vars
+type Params struct {
+	// Endpoint, AccessKeyID, SecretAccessKey, Location, Token and Ssl configure artifacts storage
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	Location        string
+	Token           string
+	Ssl             bool
+	// ScrapperEnabled enables uploading of artifacts
+	ScrapperEnabled bool
+	// DataDir is a directory test content is fetched to
+	DataDir string `default:"/data"`
+	// GitUsername and GitToken are git credentials of test, used for repositories without credentials
+	GitUsername string
+	GitToken    string
+}
+
+// LoadParams loads executor params from env vars
+func LoadParams() (params Params, err error) {
+	err = envconfig.Process("runner", &params)
+	return params, err
+}
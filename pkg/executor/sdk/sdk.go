@@ -0,0 +1,221 @@
+// Package sdk helps to build custom executors, it reads execution passed by job, fetches test content, writes output
+// protocol and uploads artifacts, so executor implements only running of the testing tool
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/content"
+	"github.com/kubeshop/testkube/pkg/executor/iterations"
+	"github.com/kubeshop/testkube/pkg/executor/output"
+	"github.com/kubeshop/testkube/pkg/executor/scraper"
+	"github.com/kubeshop/testkube/pkg/process"
+)
+
+// RunFunc runs test of execution which content was fetched to executor ContentPath
+type RunFunc func(e *Executor) (testkube.ExecutionResult, error)
+
+// Executor is a test execution run by custom executor
+type Executor struct {
+	Execution testkube.Execution
+	Params    Params
+	// ContentPath is a path test content was fetched to, it's empty for executions without content
+	ContentPath string
+	// Row is a data row of running iteration, it's nil for executions without data file
+	Row map[string]string
+
+	encoder *json.Encoder
+	out     io.Writer
+}
+
+// Main runs executor process, execution is read from stdin or first argument and output is written to stdout
+func Main(run RunFunc) {
+	e, err := New(os.Args, os.Stdin, os.Stdout)
+	if err != nil {
+		output.PrintError(err)
+		os.Exit(1)
+	}
+
+	os.Exit(e.Execute(run))
+}
+
+// New returns executor of execution passed in stdin or first argument, output protocol is written to out
+func New(args []string, stdin io.Reader, out io.Writer) (*Executor, error) {
+	execution, err := ReadExecution(args, stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := LoadParams()
+	if err != nil {
+		return nil, fmt.Errorf("can't load executor params: %w", err)
+	}
+
+	return &Executor{Execution: execution, Params: params, encoder: json.NewEncoder(out), out: out}, nil
+}
+
+// ReadExecution reads execution JSON from piped stdin or first argument
+func ReadExecution(args []string, stdin io.Reader) (execution testkube.Execution, err error) {
+	var data []byte
+	if f, ok := stdin.(*os.File); ok {
+		if stat, err := f.Stat(); err != nil || (stat.Mode()&os.ModeCharDevice) != 0 {
+			stdin = nil
+		}
+	}
+
+	if stdin != nil {
+		if data, err = ioutil.ReadAll(stdin); err != nil {
+			return execution, fmt.Errorf("can't read stdin input: %w", err)
+		}
+	}
+
+	if len(data) == 0 && len(args) > 1 {
+		data = []byte(args[1])
+	}
+
+	if len(data) == 0 {
+		return execution, fmt.Errorf("missing input JSON argument or stdin input")
+	}
+
+	err = json.Unmarshal(data, &execution)
+	return execution, err
+}
+
+// Execute fetches test content, runs test and writes its result, exit code of executor process is returned,
+// test is run once for each row of data file of data-driven executions
+func (e *Executor) Execute(run RunFunc) int {
+	e.Event("running test", e.Execution.Id)
+	path, err := e.FetchContent()
+	if err != nil {
+		e.Error(fmt.Errorf("can't fetch test content: %w", err))
+		return 1
+	}
+	e.ContentPath = path
+
+	var result testkube.ExecutionResult
+	if e.Execution.DataFile != nil {
+		rows, err := e.FetchDataRows()
+		if err != nil {
+			e.Error(fmt.Errorf("can't read iterations data file: %w", err))
+			return 1
+		}
+
+		result = iterations.Run(rows, func(row map[string]string) (testkube.ExecutionResult, error) {
+			e.Row = row
+			return run(e)
+		})
+		e.Row = nil
+	} else if result, err = run(e); err != nil {
+		e.Error(err)
+		return 1
+	}
+
+	if err = e.Result(result); err != nil {
+		e.Error(err)
+		return 1
+	}
+
+	return 0
+}
+
+// FetchContent fetches test content to data directory, git repositories without credentials are fetched with
+// test git credentials, empty path is returned for executions without content
+func (e *Executor) FetchContent() (string, error) {
+	if e.Execution.Content == nil {
+		return "", nil
+	}
+
+	testContent := *e.Execution.Content
+	if repository := testContent.Repository; repository != nil && repository.Username == "" && repository.Token == "" {
+		withCredentials := *repository
+		withCredentials.Username = e.Params.GitUsername
+		withCredentials.Token = e.Params.GitToken
+		testContent.Repository = &withCredentials
+	}
+
+	return content.NewFetcher(e.Params.DataDir).Fetch(&testContent)
+}
+
+// FetchDataRows fetches data file of data-driven execution to data directory and reads its rows
+func (e *Executor) FetchDataRows() ([]map[string]string, error) {
+	dir := filepath.Join(e.Params.DataDir, "data-file")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	path, err := iterations.Fetch(e.Execution.DataFile, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return iterations.ReadRows(path)
+}
+
+// Log writes log line output
+func (e *Executor) Log(format string, args ...interface{}) {
+	e.write(output.NewOutputLine([]byte(fmt.Sprintf(format, args...))))
+}
+
+// Event writes event output, e.g. executor lifecycle events, message and objects are separated with spaces
+func (e *Executor) Event(message string, obj ...interface{}) {
+	e.write(output.NewOutputEvent(strings.TrimSuffix(fmt.Sprintln(append([]interface{}{message}, obj...)...), "\n")))
+}
+
+// Variable writes output variable, variables are passed as params to next test suite steps
+func (e *Executor) Variable(name, value string) {
+	e.write(output.NewOutputVariable(name, value))
+}
+
+// Error writes error output, it fails execution with error not related to the test itself
+func (e *Executor) Error(err error) {
+	e.write(output.NewOutputError(err))
+}
+
+// Result validates result against runner result schema and writes it, it should be the last output
+func (e *Executor) Result(result testkube.ExecutionResult) error {
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	violations, err := output.ValidateResult(output.ResultSchemaV1, raw)
+	if err != nil {
+		return err
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("result doesn't match result schema %s: %s", output.ResultSchemaV1, strings.Join(violations, "; "))
+	}
+
+	e.write(output.NewOutputResult(result))
+	return nil
+}
+
+// Command runs command in directory, its output is written as log lines and returned
+func (e *Executor) Command(dir, command string, args ...string) ([]byte, error) {
+	return process.LoggedExecuteInDir(dir, output.NewJSONWrapWriter(e.out), command, args...)
+}
+
+// UploadArtifacts uploads files in directories to artifacts storage of execution, nothing is uploaded when
+// artifacts scrapping isn't enabled
+func (e *Executor) UploadArtifacts(directories ...string) error {
+	if !e.Params.ScrapperEnabled {
+		return nil
+	}
+
+	s := scraper.NewMinioScraper(e.Params.Endpoint, e.Params.AccessKeyID, e.Params.SecretAccessKey, e.Params.Location,
+		e.Params.Token, e.Params.Ssl)
+	return s.ScrapeProject(e.Execution.Id, e.Execution.Project, directories)
+}
+
+func (e *Executor) write(out output.Output) {
+	// output is written to stdout of executor pod, there is nowhere to report its errors
+	_ = e.encoder.Encode(out)
+}
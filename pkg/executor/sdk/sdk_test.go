@@ -0,0 +1,113 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/output"
+)
+
+func newTestExecutor(t *testing.T, execution testkube.Execution) (*Executor, *bytes.Buffer) {
+	t.Setenv("RUNNER_DATADIR", t.TempDir())
+	t.Setenv("RUNNER_GITUSERNAME", "jane")
+
+	data, err := json.Marshal(execution)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	e, err := New([]string{"executor"}, bytes.NewReader(data), &out)
+	require.NoError(t, err)
+	return e, &out
+}
+
+func TestReadExecution(t *testing.T) {
+	execution, err := ReadExecution([]string{"executor", `{"id":"example-id"}`}, strings.NewReader(""))
+	require.NoError(t, err)
+	assert.Equal(t, "example-id", execution.Id)
+
+	_, err = ReadExecution([]string{"executor"}, strings.NewReader(""))
+	assert.Error(t, err)
+}
+
+func TestExecute(t *testing.T) {
+	execution := testkube.NewExecutionWithID("example-id", "http/status", "api-health")
+	execution.Content = testkube.NewStringTestContent("https://example.com/health")
+	e, out := newTestExecutor(t, execution)
+	assert.Equal(t, "jane", e.Params.GitUsername)
+
+	code := e.Execute(func(e *Executor) (testkube.ExecutionResult, error) {
+		data, err := os.ReadFile(e.ContentPath)
+		if err != nil {
+			return testkube.ExecutionResult{}, err
+		}
+
+		e.Log("GET %s", data)
+		e.Variable("statusCode", "200")
+		return testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed, Output: "got status 200 OK"}, nil
+	})
+	assert.Equal(t, 0, code)
+
+	result, logs, err := output.ParseRunnerOutput(out.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, testkube.ExecutionStatusPassed, result.Status)
+	assert.Equal(t, map[string]string{"statusCode": "200"}, result.OutputVariables)
+	assert.Equal(t, []string{"running test example-id", "GET https://example.com/health"}, logs)
+}
+
+func TestExecuteIterations(t *testing.T) {
+	execution := testkube.NewExecutionWithID("example-id", "http/status", "api-health")
+	execution.DataFile = testkube.NewStringTestContent("user,status\njane,200\njohn,401\n")
+	e, out := newTestExecutor(t, execution)
+
+	var users []string
+	code := e.Execute(func(e *Executor) (testkube.ExecutionResult, error) {
+		users = append(users, e.Row["user"])
+		if e.Row["status"] != "200" {
+			return testkube.ExecutionResult{Status: testkube.ExecutionStatusFailed, Output: "got status " + e.Row["status"]}, nil
+		}
+
+		return testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed, Output: "got status 200"}, nil
+	})
+	assert.Equal(t, 0, code)
+	assert.Equal(t, []string{"jane", "john"}, users)
+
+	result, _, err := output.ParseRunnerOutput(out.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, testkube.ExecutionStatusFailed, result.Status)
+	assert.Equal(t, "iterations 2 of 2 failed", result.ErrorMessage)
+	assert.Len(t, result.Iterations, 2)
+}
+
+func TestExecuteErrors(t *testing.T) {
+	e, out := newTestExecutor(t, testkube.NewExecutionWithID("example-id", "http/status", "api-health"))
+	code := e.Execute(func(e *Executor) (testkube.ExecutionResult, error) {
+		return testkube.ExecutionResult{}, errors.New("test content should be file with URL")
+	})
+	assert.Equal(t, 1, code)
+
+	result, _, err := output.ParseRunnerOutput(out.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "test content should be file with URL", result.ErrorMessage)
+
+	// results without status are rejected before they are written
+	assert.EqualError(t, e.Result(testkube.ExecutionResult{Output: "done"}),
+		"result doesn't match result schema v1: result.status: expected string, got null")
+}
+
+func TestEvent(t *testing.T) {
+	e, out := newTestExecutor(t, testkube.NewExecutionWithID("example-id", "http/status", "api-health"))
+	e.Event("can't upload response")
+	e.Event("retrying", 2, "of", 3)
+
+	_, logs, err := output.ParseRunnerOutput(out.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"can't upload response", "retrying 2 of 3"}, logs)
+}
@@ -0,0 +1,105 @@
+package failures
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/mapper/executions"
+)
+
+// maxSignatureLength is a maximal length of error signature, longer messages are truncated
+const maxSignatureLength = 200
+
+// replacements are applied in order, so more specific patterns go first
+var replacements = []struct {
+	pattern     *regexp.Regexp
+	placeholder string
+}{
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`), "<time>"},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<uuid>"},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8,}\b`), "<id>"},
+	{regexp.MustCompile(`\b\d{1,3}(\.\d{1,3}){3}(:\d+)?\b`), "<ip>"},
+	{regexp.MustCompile(`\b\d+(\.\d+)?(ns|us|µs|ms|s|m|h)\b`), "<duration>"},
+	{regexp.MustCompile(`\d+`), "<n>"},
+}
+
+var whitespace = regexp.MustCompile(`\s+`)
+
+// Normalize returns error signature with ids, timestamps, addresses and numbers replaced by placeholders,
+// only the first non empty line of message is used
+func Normalize(message string) string {
+	line := ""
+	for _, l := range strings.Split(message, "\n") {
+		if line = strings.TrimSpace(l); line != "" {
+			break
+		}
+	}
+
+	for _, r := range replacements {
+		line = r.pattern.ReplaceAllString(line, r.placeholder)
+	}
+
+	line = whitespace.ReplaceAllString(line, " ")
+	if len(line) > maxSignatureLength {
+		line = line[:maxSignatureLength] + "..."
+	}
+
+	return line
+}
+
+// Cluster groups failed executions by error signature, clusters are sorted by count and last occurrence,
+// executions are expected to be sorted from the newest and executions without error message are skipped
+func Cluster(list []testkube.Execution, examples int) []testkube.ErrorCluster {
+	clusters := []testkube.ErrorCluster{}
+	indexes := map[string]int{}
+	tests := map[string]map[string]bool{}
+
+	for _, execution := range list {
+		if execution.ExecutionResult == nil || execution.ExecutionResult.ErrorMessage == "" {
+			continue
+		}
+
+		signature := Normalize(execution.ExecutionResult.ErrorMessage)
+		i, ok := indexes[signature]
+		if !ok {
+			i = len(clusters)
+			indexes[signature] = i
+			tests[signature] = map[string]bool{}
+			clusters = append(clusters, testkube.ErrorCluster{
+				Signature: signature,
+				Message:   execution.ExecutionResult.ErrorMessage,
+				FirstSeen: execution.StartTime,
+				LastSeen:  execution.StartTime,
+			})
+		}
+
+		cluster := &clusters[i]
+		cluster.Count++
+		if execution.StartTime.Before(cluster.FirstSeen) {
+			cluster.FirstSeen = execution.StartTime
+		}
+		if execution.StartTime.After(cluster.LastSeen) {
+			cluster.LastSeen = execution.StartTime
+		}
+
+		if !tests[signature][execution.TestName] {
+			tests[signature][execution.TestName] = true
+			cluster.Tests = append(cluster.Tests, execution.TestName)
+		}
+
+		if len(cluster.Examples) < examples {
+			cluster.Examples = append(cluster.Examples, executions.MapToSummary([]testkube.Execution{execution})...)
+		}
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if clusters[i].Count != clusters[j].Count {
+			return clusters[i].Count > clusters[j].Count
+		}
+		return clusters[i].LastSeen.After(clusters[j].LastSeen)
+	})
+
+	return clusters
+}
@@ -0,0 +1,59 @@
+package failures
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"dial tcp 10.0.12.7:5432: connect: connection refused":                  "dial tcp <ip>: connect: connection refused",
+		"execution 62f3a1b2c4d5e6f708192a3b failed at 2022-08-10T12:01:02.123Z": "execution <id> failed at <time>",
+		"job 8c5c3b4e-1d2f-4a5b-9c8d-7e6f5a4b3c2d timed out after 30.5s":        "job <uuid> timed out after <duration>",
+		"expected status 200, got 503":                                          "expected status <n>, got <n>",
+		"\n  assertion   failed\nstack trace line 1":                            "assertion failed",
+	}
+
+	for message, signature := range tests {
+		assert.Equal(t, signature, Normalize(message))
+	}
+}
+
+func TestCluster(t *testing.T) {
+	now := time.Now()
+	execution := func(id, testName, message string, age time.Duration) testkube.Execution {
+		return testkube.Execution{
+			Id:        id,
+			TestName:  testName,
+			StartTime: now.Add(-age),
+			ExecutionResult: &testkube.ExecutionResult{
+				Status:       testkube.ExecutionStatusFailed,
+				ErrorMessage: message,
+			},
+		}
+	}
+
+	// newest first
+	clusters := Cluster([]testkube.Execution{
+		execution("1", "api", "expected status 200, got 500", time.Minute),
+		execution("2", "db", "dial tcp 10.0.0.1:5432: connection refused", 2*time.Minute),
+		execution("3", "api", "dial tcp 10.0.0.2:5432: connection refused", 3*time.Minute),
+		execution("4", "ui", "dial tcp 10.0.0.1:5432: connection refused", 4*time.Minute),
+		execution("5", "ui", "", 5*time.Minute),
+	}, 2)
+
+	assert.Len(t, clusters, 2)
+	assert.Equal(t, "dial tcp <ip>: connection refused", clusters[0].Signature)
+	assert.Equal(t, "dial tcp 10.0.0.1:5432: connection refused", clusters[0].Message)
+	assert.Equal(t, int32(3), clusters[0].Count)
+	assert.Equal(t, []string{"db", "api", "ui"}, clusters[0].Tests)
+	assert.Equal(t, now.Add(-4*time.Minute), clusters[0].FirstSeen)
+	assert.Equal(t, now.Add(-2*time.Minute), clusters[0].LastSeen)
+	assert.Len(t, clusters[0].Examples, 2)
+	assert.Equal(t, "2", clusters[0].Examples[0].Id)
+	assert.Equal(t, int32(1), clusters[1].Count)
+}
@@ -0,0 +1,112 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const (
+	ContentType = "application/atom+xml"
+	atomNS      = "http://www.w3.org/2005/Atom"
+)
+
+// Feed is an Atom feed document
+type Feed struct {
+	XMLName xml.Name `xml:"feed"`
+	NS      string   `xml:"xmlns,attr"`
+	ID      string   `xml:"id"`
+	Title   string   `xml:"title"`
+	Updated string   `xml:"updated"`
+	Link    []Link   `xml:"link"`
+	Entries []Entry  `xml:"entry"`
+}
+
+// Entry is an Atom feed entry
+type Entry struct {
+	ID      string `xml:"id"`
+	Title   string `xml:"title"`
+	Updated string `xml:"updated"`
+	Summary string `xml:"summary,omitempty"`
+	Link    []Link `xml:"link"`
+}
+
+// Link is an Atom link
+type Link struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// NewExecutionsFeed creates feed of executions with links to execution details, logs and artifacts,
+// apiURL is an absolute URL of API v1 routes, feedURL is an absolute URL of the feed itself
+func NewExecutionsFeed(title, apiURL, feedURL string, executions []testkube.Execution, now time.Time) Feed {
+	feed := Feed{
+		NS:      atomNS,
+		ID:      feedURL,
+		Title:   title,
+		Updated: formatTime(now),
+		Link:    []Link{{Href: feedURL, Rel: "self", Type: ContentType}},
+	}
+
+	for _, execution := range executions {
+		feed.Entries = append(feed.Entries, newExecutionEntry(apiURL, execution))
+	}
+
+	if len(executions) > 0 {
+		feed.Updated = feed.Entries[0].Updated
+	}
+
+	return feed
+}
+
+func newExecutionEntry(apiURL string, execution testkube.Execution) Entry {
+	executionURL := apiURL + "/executions/" + execution.Id
+
+	var status testkube.ExecutionStatus
+	var summary string
+	if execution.ExecutionResult != nil {
+		if execution.ExecutionResult.Status != nil {
+			status = *execution.ExecutionResult.Status
+		}
+		summary = execution.ExecutionResult.ErrorMessage
+	}
+
+	title := fmt.Sprintf("%s %s %s", execution.TestName, execution.Name, status)
+	if execution.Number > 0 {
+		title = fmt.Sprintf("%s #%d %s", execution.TestName, execution.Number, status)
+	}
+
+	updated := execution.EndTime
+	if updated.IsZero() {
+		updated = execution.StartTime
+	}
+
+	return Entry{
+		ID:      "urn:testkube:execution:" + execution.Id,
+		Title:   title,
+		Updated: formatTime(updated),
+		Summary: summary,
+		Link: []Link{
+			{Href: executionURL, Rel: "alternate", Type: "application/json"},
+			{Href: executionURL + "/logs", Rel: "related"},
+			{Href: executionURL + "/artifacts", Rel: "related", Type: "application/json"},
+		},
+	}
+}
+
+// Marshal renders feed as XML document
+func (f Feed) Marshal() ([]byte, error) {
+	out, err := xml.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), out...), nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
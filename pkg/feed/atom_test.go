@@ -0,0 +1,45 @@
+package feed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestNewExecutionsFeed(t *testing.T) {
+	now := time.Date(2022, 8, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("executions are mapped to entries with links", func(t *testing.T) {
+		feed := NewExecutionsFeed("failed executions", "http://api/v1", "http://api/v1/executions/feed", []testkube.Execution{{
+			Id:       "exec-1",
+			TestName: "test",
+			Number:   42,
+			EndTime:  now.Add(-time.Hour),
+			ExecutionResult: &testkube.ExecutionResult{
+				Status:       testkube.ExecutionStatusFailed,
+				ErrorMessage: "assertion failed",
+			},
+		}}, now)
+
+		assert.Len(t, feed.Entries, 1)
+		entry := feed.Entries[0]
+		assert.Equal(t, "test #42 failed", entry.Title)
+		assert.Equal(t, "assertion failed", entry.Summary)
+		assert.Equal(t, "2022-08-10T11:00:00Z", entry.Updated)
+		assert.Equal(t, entry.Updated, feed.Updated)
+		assert.Equal(t, "http://api/v1/executions/exec-1/logs", entry.Link[1].Href)
+	})
+
+	t.Run("empty feed is updated now", func(t *testing.T) {
+		feed := NewExecutionsFeed("failed executions", "http://api/v1", "http://api/v1/executions/feed", nil, now)
+
+		out, err := feed.Marshal()
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(out), `<feed xmlns="http://www.w3.org/2005/Atom">`)
+		assert.Contains(t, string(out), "<updated>2022-08-10T12:00:00Z</updated>")
+	})
+}
@@ -71,3 +71,42 @@ func PartialCheckout(uri, path, branch, dir string) (outputDir string, err error
 
 	return tmpDir + "/repo/" + path, nil
 }
+
+// CheckoutCommit will checkout given commit from Git repository, only given directory is checked out when path is set
+func CheckoutCommit(uri, path, commit, dir string) (outputDir string, err error) {
+	tmpDir := dir
+	if tmpDir == "" {
+		tmpDir, err = ioutil.TempDir("", "git-commit-checkout")
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if _, err = process.ExecuteInDir(tmpDir, "git", "init", "repo"); err != nil {
+		return "", err
+	}
+
+	repoDir := tmpDir + "/repo"
+	if _, err = process.ExecuteInDir(repoDir, "git", "remote", "add", "origin", uri); err != nil {
+		return "", err
+	}
+
+	fetchArgs := []string{"fetch", "--depth", "1"}
+	if path != "" {
+		if _, err = process.ExecuteInDir(repoDir, "git", "sparse-checkout", "set", path); err != nil {
+			return "", err
+		}
+		fetchArgs = append(fetchArgs, "--filter", "blob:none")
+	}
+
+	// commits are fetched directly, git servers like GitHub and GitLab allow fetching reachable commits
+	if _, err = process.ExecuteInDir(repoDir, "git", append(fetchArgs, "origin", commit)...); err != nil {
+		return "", err
+	}
+
+	if _, err = process.ExecuteInDir(repoDir, "git", "checkout", "FETCH_HEAD"); err != nil {
+		return "", err
+	}
+
+	return repoDir + "/" + path, nil
+}
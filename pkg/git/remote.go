@@ -0,0 +1,104 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	thttp "github.com/kubeshop/testkube/pkg/http"
+)
+
+// RemoteCommit returns commit SHA of branch or tag in remote repository, refs are read from smart HTTP
+// advertisement, so repository isn't cloned and git binary isn't needed
+func RemoteCommit(uri, branch string) (commit string, err error) {
+	refsURL, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+
+	if refsURL.Scheme != "http" && refsURL.Scheme != "https" {
+		return "", fmt.Errorf("resolving commits is supported only for http and https repositories, got: %s", refsURL.Scheme)
+	}
+
+	refsURL.Path = strings.TrimSuffix(refsURL.Path, "/") + "/info/refs"
+	refsURL.RawQuery = "service=git-upload-pack"
+
+	resp, err := thttp.NewClient().Get(refsURL.String())
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("can't read refs of repository, status code: %d", resp.StatusCode)
+	}
+
+	refs, err := ParseRefs(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return ResolveRef(refs, branch)
+}
+
+// ParseRefs parses pkt-line encoded refs advertisement to map of ref names and commit SHAs
+func ParseRefs(reader io.Reader) (refs map[string]string, err error) {
+	refs = map[string]string{}
+	buffered := bufio.NewReader(reader)
+	for {
+		header := make([]byte, 4)
+		if _, err = io.ReadFull(buffered, header); err == io.EOF {
+			return refs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("can't read refs pkt-line: %w", err)
+		}
+
+		length, err := strconv.ParseUint(string(header), 16, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid refs pkt-line length %q: %w", header, err)
+		}
+
+		// flush packet separates service announcement and refs
+		if length < 4 {
+			continue
+		}
+
+		line := make([]byte, length-4)
+		if _, err = io.ReadFull(buffered, line); err != nil {
+			return nil, fmt.Errorf("can't read refs pkt-line: %w", err)
+		}
+
+		text := strings.TrimSuffix(string(line), "\n")
+		if strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		// first ref is followed by capabilities
+		text, _, _ = strings.Cut(text, "\x00")
+		if sha, name, ok := strings.Cut(text, " "); ok {
+			refs[name] = sha
+		}
+	}
+}
+
+// ResolveRef returns commit SHA of branch or tag, annotated tags are resolved to tagged commits and default
+// branch is used when branch isn't set
+func ResolveRef(refs map[string]string, branch string) (commit string, err error) {
+	candidates := []string{"HEAD"}
+	if branch != "" {
+		candidates = []string{"refs/heads/" + branch, "refs/tags/" + branch + "^{}", "refs/tags/" + branch, branch}
+	}
+
+	for _, candidate := range candidates {
+		if sha, ok := refs[candidate]; ok {
+			return sha, nil
+		}
+	}
+
+	return "", fmt.Errorf("ref %s not found in repository", branch)
+}
@@ -0,0 +1,81 @@
+package git
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	mainCommit   = "1111111111111111111111111111111111111111"
+	tagObject    = "2222222222222222222222222222222222222222"
+	taggedCommit = "3333333333333333333333333333333333333333"
+)
+
+func pktLine(text string) string {
+	return fmt.Sprintf("%04x%s", len(text)+4, text)
+}
+
+func refsAdvertisement() string {
+	return pktLine("# service=git-upload-pack\n") + "0000" +
+		pktLine(mainCommit+" HEAD\x00multi_ack symref=HEAD:refs/heads/main\n") +
+		pktLine(mainCommit+" refs/heads/main\n") +
+		pktLine(tagObject+" refs/tags/v1.0.0\n") +
+		pktLine(taggedCommit+" refs/tags/v1.0.0^{}\n") +
+		"0000"
+}
+
+func TestParseRefs(t *testing.T) {
+	refs, err := ParseRefs(strings.NewReader(refsAdvertisement()))
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"HEAD":                mainCommit,
+		"refs/heads/main":     mainCommit,
+		"refs/tags/v1.0.0":    tagObject,
+		"refs/tags/v1.0.0^{}": taggedCommit,
+	}, refs)
+
+	_, err = ParseRefs(strings.NewReader("zzzz"))
+	assert.Error(t, err)
+}
+
+func TestResolveRef(t *testing.T) {
+	refs, err := ParseRefs(strings.NewReader(refsAdvertisement()))
+	assert.NoError(t, err)
+
+	commit, err := ResolveRef(refs, "main")
+	assert.NoError(t, err)
+	assert.Equal(t, mainCommit, commit)
+
+	commit, err = ResolveRef(refs, "")
+	assert.NoError(t, err)
+	assert.Equal(t, mainCommit, commit)
+
+	commit, err = ResolveRef(refs, "v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, taggedCommit, commit, "annotated tag is resolved to commit")
+
+	_, err = ResolveRef(refs, "develop")
+	assert.Error(t, err)
+}
+
+func TestRemoteCommit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/org/repo.git/info/refs", r.URL.Path)
+		assert.Equal(t, "git-upload-pack", r.URL.Query().Get("service"))
+		fmt.Fprint(w, refsAdvertisement())
+	}))
+	defer server.Close()
+
+	commit, err := RemoteCommit(server.URL+"/org/repo.git", "main")
+	assert.NoError(t, err)
+	assert.Equal(t, mainCommit, commit)
+
+	_, err = RemoteCommit("git@github.com:org/repo.git", "main")
+	assert.Error(t, err)
+}
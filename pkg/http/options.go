@@ -0,0 +1,135 @@
+package http
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+const (
+	// CACertKey is a key of PEM encoded CA bundle in TLS Secret data
+	CACertKey = "ca.crt"
+	// ClientCertKey is a key of PEM encoded client certificate in TLS Secret data
+	ClientCertKey = "tls.crt"
+	// ClientKeyKey is a key of PEM encoded client private key in TLS Secret data
+	ClientKeyKey = "tls.key"
+)
+
+// ClientOptions are outgoing connection options of HTTP client
+type ClientOptions struct {
+	// ProxyURL is an URL of proxy, proxy environment variables are used when empty
+	ProxyURL string
+	// CACert is a PEM encoded bundle of CAs trusted in addition to system CAs
+	CACert []byte
+	// ClientCert is a PEM encoded mTLS client certificate
+	ClientCert []byte
+	// ClientKey is a PEM encoded mTLS client private key
+	ClientKey []byte
+}
+
+// NewClientOptions returns client options with TLS material from Secret data with ca.crt, tls.crt and tls.key keys
+func NewClientOptions(proxyURL string, secretData map[string]string) ClientOptions {
+	options := ClientOptions{ProxyURL: proxyURL}
+	if value, ok := secretData[CACertKey]; ok {
+		options.CACert = []byte(value)
+	}
+
+	if value, ok := secretData[ClientCertKey]; ok {
+		options.ClientCert = []byte(value)
+	}
+
+	if value, ok := secretData[ClientKeyKey]; ok {
+		options.ClientKey = []byte(value)
+	}
+
+	return options
+}
+
+// NewClientWithOptions returns new client with configured timeouts, proxy and TLS options
+func NewClientWithOptions(options ClientOptions) (*http.Client, error) {
+	client := NewClient()
+	transport := client.Transport.(*http.Transport)
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if options.ProxyURL != "" {
+		proxyURL, err := url.Parse(options.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if len(options.CACert) == 0 && len(options.ClientCert) == 0 && len(options.ClientKey) == 0 {
+		return client, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(options.CACert) != 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(options.CACert) {
+			return nil, errors.New("no valid certificates in CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(options.ClientCert) != 0 || len(options.ClientKey) != 0 {
+		certificate, err := tls.X509KeyPair(options.ClientCert, options.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return client, nil
+}
+
+// ClientCache reuses clients with the same options, so connections of clients are pooled
+type ClientCache struct {
+	mutex   sync.Mutex
+	clients map[string]*http.Client
+}
+
+// NewClientCache returns new client cache
+func NewClientCache() *ClientCache {
+	return &ClientCache{clients: map[string]*http.Client{}}
+}
+
+// Get returns cached client with given options or creates new one
+func (c *ClientCache) Get(options ClientOptions) (*http.Client, error) {
+	key := options.key()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if client, ok := c.clients[key]; ok {
+		return client, nil
+	}
+
+	client, err := NewClientWithOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	c.clients[key] = client
+	return client, nil
+}
+
+func (o ClientOptions) key() string {
+	hash := sha256.New()
+	for _, value := range [][]byte{[]byte(o.ProxyURL), o.CACert, o.ClientCert, o.ClientKey} {
+		hash.Write([]byte(fmt.Sprintf("%d:", len(value))))
+		hash.Write(value)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
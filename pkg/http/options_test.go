@@ -0,0 +1,65 @@
+package http
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClientWithOptions(t *testing.T) {
+
+	t.Run("trusts custom CA bundle", func(t *testing.T) {
+		// given
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+		// when
+		client, err := NewClientWithOptions(ClientOptions{CACert: caCert})
+		require.NoError(t, err)
+		response, err := client.Get(server.URL)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, ClientTimeout, client.Timeout)
+	})
+
+	t.Run("uses proxy URL", func(t *testing.T) {
+		// given / when
+		client, err := NewClientWithOptions(ClientOptions{ProxyURL: "http://proxy.example.com:3128"})
+		require.NoError(t, err)
+		request, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+		proxy, err := client.Transport.(*http.Transport).Proxy(request)
+
+		// then
+		require.NoError(t, err)
+		assert.Equal(t, "proxy.example.com:3128", proxy.Host)
+	})
+
+	t.Run("rejects invalid TLS material", func(t *testing.T) {
+		_, err := NewClientWithOptions(ClientOptions{CACert: []byte("invalid")})
+		assert.Error(t, err)
+
+		_, err = NewClientWithOptions(ClientOptions{ClientCert: []byte("invalid"), ClientKey: []byte("invalid")})
+		assert.Error(t, err)
+	})
+}
+
+func TestClientCache(t *testing.T) {
+	cache := NewClientCache()
+
+	first, err := cache.Get(NewClientOptions("http://proxy:3128", nil))
+	require.NoError(t, err)
+	second, err := cache.Get(ClientOptions{ProxyURL: "http://proxy:3128"})
+	require.NoError(t, err)
+	other, err := cache.Get(ClientOptions{})
+	require.NoError(t, err)
+
+	assert.Same(t, first, second)
+	assert.NotSame(t, first, other)
+}
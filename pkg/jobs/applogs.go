@@ -0,0 +1,212 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/storage"
+)
+
+const (
+	// appLogsLimitBytes is a maximum size of logs collected from single application container
+	appLogsLimitBytes int64 = 10 << 20
+	// appLogsTotalLimitBytes is a maximum size of logs collected from all application containers of execution
+	appLogsTotalLimitBytes int64 = 50 << 20
+)
+
+// AppLogsPolicy limits namespaces application logs are collected from, as API server service account can read
+// pods logs there
+type AppLogsPolicy struct {
+	// Namespaces are namespaces other than Testkube namespace application logs can be collected from
+	Namespaces []string `envconfig:"NAMESPACES"`
+}
+
+// IsAllowedNamespace checks if application logs can be collected from namespace, Testkube namespace is always allowed
+func (p AppLogsPolicy) IsAllowedNamespace(namespace, testkubeNamespace string) bool {
+	if namespace == "" || namespace == testkubeNamespace {
+		return true
+	}
+
+	for _, allowed := range p.Namespaces {
+		if allowed == namespace {
+			return true
+		}
+	}
+
+	return false
+}
+
+// appLogsSource is an application container logs are collected from
+type appLogsSource struct {
+	Pod       string
+	Container string
+}
+
+// getAppLogsSources returns containers of application pods logs are collected from, sorted by pod name
+func getAppLogsSources(pods []corev1.Pod, collection testkube.LogCollection) (sources []appLogsSource) {
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			if collection.IsCollectedContainer(container.Name) {
+				sources = append(sources, appLogsSource{Pod: pod.Name, Container: container.Name})
+			}
+		}
+	}
+
+	return sources
+}
+
+// collectAppLogs stores logs written by application pods since execution start as execution artifact, logs which
+// can't be read are noted in the artifact so partial logs are still stored
+func (c *JobClient) collectAppLogs(ctx context.Context, execution testkube.Execution, collection *testkube.LogCollection) {
+	if collection == nil || c.artifacts == nil {
+		return
+	}
+
+	l := c.Log.With("executionId", execution.Id, "selector", collection.Selector)
+	namespace := collection.Namespace
+	if namespace == "" {
+		namespace = c.Namespace
+	}
+
+	// log collection CRs can be edited directly so namespace is checked also when logs are collected
+	if !c.appLogsPolicy.IsAllowedNamespace(namespace, c.Namespace) {
+		l.Warnw("application logs namespace isn't allowed", "namespace", namespace)
+		return
+	}
+
+	pods, err := c.ClientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: collection.Selector})
+	if err != nil {
+		l.Errorw("listing application pods for log collection", "error", err)
+		return
+	}
+
+	sources := getAppLogsSources(pods.Items, *collection)
+	if len(sources) == 0 {
+		l.Infow("no application pods to collect logs from", "namespace", namespace)
+		return
+	}
+
+	// logs are streamed to temporary file, so they aren't buffered in memory
+	file, err := os.CreateTemp("", "app-logs-*.txt")
+	if err != nil {
+		l.Errorw("creating application logs file", "error", err)
+		return
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	size, err := c.writeAppLogs(ctx, file, namespace, sources, metav1.NewTime(execution.StartTime))
+	if err != nil {
+		l.Errorw("writing collected application logs", "error", err)
+		return
+	}
+
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		l.Errorw("reading collected application logs", "error", err)
+		return
+	}
+
+	if err = storage.NewArtifacts(c.artifacts, execution.Id, execution.Project).Upload(testkube.AppLogsArtifact, file, size); err != nil {
+		l.Errorw("uploading collected application logs", "error", err)
+		return
+	}
+
+	l.Infow("application logs collected", "containers", len(sources), "size", size)
+}
+
+// writeAppLogs streams logs of application containers to writer until total logs limit is reached, and returns
+// number of written bytes
+func (c *JobClient) writeAppLogs(ctx context.Context, writer io.Writer, namespace string, sources []appLogsSource,
+	since metav1.Time) (int64, error) {
+	w := &countingWriter{Writer: writer}
+	for _, source := range sources {
+		remaining := appLogsTotalLimitBytes - w.Count
+		if remaining <= 0 {
+			_, err := fmt.Fprintf(w, "==> logs truncated, total limit of %d bytes reached <==\n", appLogsTotalLimitBytes)
+			return w.Count, err
+		}
+
+		limit := appLogsLimitBytes
+		if remaining < limit {
+			limit = remaining
+		}
+
+		if _, err := fmt.Fprintf(w, "==> %s/%s/%s <==\n", namespace, source.Pod, source.Container); err != nil {
+			return w.Count, err
+		}
+
+		if err := c.writeContainerLogs(ctx, w, namespace, source, since, limit); err != nil {
+			return w.Count, err
+		}
+	}
+
+	return w.Count, nil
+}
+
+// writeContainerLogs streams logs of application container to writer, logs which can't be read are noted instead
+func (c *JobClient) writeContainerLogs(ctx context.Context, w *countingWriter, namespace string, source appLogsSource,
+	since metav1.Time, limit int64) error {
+	stream, err := c.ClientSet.CoreV1().Pods(namespace).GetLogs(source.Pod, &corev1.PodLogOptions{
+		Container:  source.Container,
+		SinceTime:  &since,
+		Timestamps: true,
+		LimitBytes: &limit,
+	}).Stream(ctx)
+	if err != nil {
+		_, err = fmt.Fprintf(w, "can't get logs: %s\n\n", err)
+		return err
+	}
+	defer stream.Close()
+
+	start := w.Count
+	if _, err = io.Copy(w, io.LimitReader(stream, limit)); err != nil {
+		if w.Err != nil {
+			return w.Err
+		}
+
+		if _, err = fmt.Fprintf(w, "\ncan't read logs: %s\n", err); err != nil {
+			return err
+		}
+	}
+
+	if w.Count > start && w.Last != '\n' {
+		if _, err = w.Write([]byte{'\n'}); err != nil {
+			return err
+		}
+	}
+
+	_, err = w.Write([]byte{'\n'})
+	return err
+}
+
+// countingWriter counts written bytes and remembers last written byte and write error
+type countingWriter struct {
+	io.Writer
+	Count int64
+	Last  byte
+	Err   error
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.Count += int64(n)
+	if n > 0 {
+		w.Last = p[n-1]
+	}
+	if err != nil {
+		w.Err = err
+	}
+
+	return n, err
+}
@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestGetAppLogsSources(t *testing.T) {
+	pod := func(name string, containers ...string) corev1.Pod {
+		pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		for _, container := range containers {
+			pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: container})
+		}
+		return pod
+	}
+	pods := []corev1.Pod{pod("backend-2", "api", "istio-proxy"), pod("backend-1", "api", "istio-proxy")}
+
+	t.Run("all containers", func(t *testing.T) {
+		assert.Equal(t, []appLogsSource{
+			{Pod: "backend-1", Container: "api"},
+			{Pod: "backend-1", Container: "istio-proxy"},
+			{Pod: "backend-2", Container: "api"},
+			{Pod: "backend-2", Container: "istio-proxy"},
+		}, getAppLogsSources(pods, testkube.LogCollection{Selector: "app=backend"}))
+	})
+
+	t.Run("selected containers", func(t *testing.T) {
+		assert.Equal(t, []appLogsSource{
+			{Pod: "backend-1", Container: "api"},
+			{Pod: "backend-2", Container: "api"},
+		}, getAppLogsSources(pods, testkube.LogCollection{Selector: "app=backend", Containers: []string{"api"}}))
+	})
+}
+
+func TestAppLogsPolicyIsAllowedNamespace(t *testing.T) {
+	policy := AppLogsPolicy{Namespaces: []string{"staging"}}
+
+	assert.True(t, policy.IsAllowedNamespace("", "testkube"), "Testkube namespace by default")
+	assert.True(t, policy.IsAllowedNamespace("testkube", "testkube"))
+	assert.True(t, policy.IsAllowedNamespace("staging", "testkube"))
+	assert.False(t, policy.IsAllowedNamespace("kube-system", "testkube"))
+	assert.False(t, AppLogsPolicy{}.IsAllowedNamespace("staging", "testkube"))
+}
+
+func TestCountingWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	w := &countingWriter{Writer: &buffer}
+
+	_, err := w.Write([]byte("line 1\nline"))
+	assert.NoError(t, err)
+	_, err = w.Write([]byte(" 2"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(13), w.Count)
+	assert.Equal(t, byte('2'), w.Last)
+	assert.Equal(t, "line 1\nline 2", buffer.String())
+}
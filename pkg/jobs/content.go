@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/content"
+)
+
+// NewContentUriEnvVars returns env vars with file URI content credentials read from secret, values aren't
+// passed in execution JSON
+func NewContentUriEnvVars(options *testkube.ContentUriOptions) (envVars []corev1.EnvVar) {
+	if !options.HasCredentials() {
+		return nil
+	}
+
+	secretEnvVar := func(name, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: options.SecretName},
+					Key:                  key,
+				},
+			},
+		}
+	}
+
+	if options.UsernameKey != "" {
+		envVars = append(envVars, secretEnvVar(content.UriUsernameEnvVarName, options.UsernameKey))
+	}
+
+	if options.PasswordKey != "" {
+		envVars = append(envVars, secretEnvVar(content.UriPasswordEnvVarName, options.PasswordKey))
+	}
+
+	headers := make([]string, 0, len(options.HeaderKeys))
+	for header := range options.HeaderKeys {
+		headers = append(headers, header)
+	}
+	sort.Strings(headers)
+
+	for _, header := range headers {
+		envVars = append(envVars, secretEnvVar(content.UriHeaderEnvVarName(header), options.HeaderKeys[header]))
+	}
+
+	return envVars
+}
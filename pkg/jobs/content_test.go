@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestNewContentUriEnvVars(t *testing.T) {
+	assert.Empty(t, NewContentUriEnvVars(nil))
+	assert.Empty(t, NewContentUriEnvVars(&testkube.ContentUriOptions{Retries: 3}))
+
+	envVars := NewContentUriEnvVars(&testkube.ContentUriOptions{
+		SecretName:  "credentials",
+		UsernameKey: "username",
+		PasswordKey: "password",
+		HeaderKeys:  map[string]string{"X-Api-Key": "api-key", "Authorization": "token"},
+	})
+
+	var names, keys []string
+	for _, envVar := range envVars {
+		assert.Empty(t, envVar.Value)
+		assert.Equal(t, "credentials", envVar.ValueFrom.SecretKeyRef.Name)
+		names = append(names, envVar.Name)
+		keys = append(keys, envVar.ValueFrom.SecretKeyRef.Key)
+	}
+
+	assert.Equal(t, []string{"RUNNER_CONTENT_URI_USERNAME", "RUNNER_CONTENT_URI_PASSWORD",
+		"RUNNER_CONTENT_URI_HEADER_AUTHORIZATION", "RUNNER_CONTENT_URI_HEADER_X_API_KEY"}, names)
+	assert.Equal(t, []string{"username", "password", "token", "api-key"}, keys)
+}
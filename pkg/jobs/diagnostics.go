@@ -0,0 +1,138 @@
+package jobs
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// maxDiagnosticsEvents is a number of the most recent events returned in diagnostics
+const maxDiagnosticsEvents = 20
+
+// GetJobDiagnostics returns job, pods and events state of given job
+func (c *JobClient) GetJobDiagnostics(ctx context.Context, jobName string) (diagnostics testkube.ExecutionDiagnostics, err error) {
+	job, err := c.ClientSet.BatchV1().Jobs(c.Namespace).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		return diagnostics, err
+	}
+
+	diagnostics = testkube.ExecutionDiagnostics{
+		JobName:   job.Name,
+		Active:    job.Status.Active,
+		Succeeded: job.Status.Succeeded,
+		Failed:    job.Status.Failed,
+		Pods:      []testkube.PodDiagnostics{},
+		Events:    []testkube.KubernetesEvent{},
+	}
+
+	pods, err := c.ClientSet.CoreV1().Pods(c.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "job-name=" + jobName})
+	if err != nil {
+		return diagnostics, err
+	}
+
+	objectNames := []string{job.Name}
+	for _, pod := range pods.Items {
+		diagnostics.Pods = append(diagnostics.Pods, MapPodToDiagnostics(pod))
+		objectNames = append(objectNames, pod.Name)
+	}
+
+	var events []corev1.Event
+	for _, name := range objectNames {
+		list, err := c.ClientSet.CoreV1().Events(c.Namespace).List(ctx, metav1.ListOptions{FieldSelector: "involvedObject.name=" + name})
+		if err != nil {
+			return diagnostics, err
+		}
+
+		events = append(events, list.Items...)
+	}
+
+	diagnostics.Events = MapEventsToDiagnostics(events, maxDiagnosticsEvents)
+	return diagnostics, nil
+}
+
+// MapPodToDiagnostics maps pod status to pod diagnostics
+func MapPodToDiagnostics(pod corev1.Pod) testkube.PodDiagnostics {
+	return testkube.PodDiagnostics{
+		Name:           pod.Name,
+		Phase:          string(pod.Status.Phase),
+		Reason:         pod.Status.Reason,
+		Message:        pod.Status.Message,
+		InitContainers: mapContainerStatuses(pod.Spec.InitContainers, pod.Status.InitContainerStatuses),
+		Containers:     mapContainerStatuses(pod.Spec.Containers, pod.Status.ContainerStatuses),
+	}
+}
+
+func mapContainerStatuses(containers []corev1.Container, statuses []corev1.ContainerStatus) (diagnostics []testkube.ContainerDiagnostics) {
+	images := map[string]string{}
+	for _, container := range containers {
+		images[container.Name] = container.Image
+	}
+
+	for _, status := range statuses {
+		container := testkube.ContainerDiagnostics{
+			Name:         status.Name,
+			Image:        images[status.Name],
+			RestartCount: status.RestartCount,
+			Ready:        status.Ready,
+		}
+
+		switch {
+		case status.State.Waiting != nil:
+			container.State = "waiting"
+			container.Reason = status.State.Waiting.Reason
+			container.Message = status.State.Waiting.Message
+		case status.State.Running != nil:
+			container.State = "running"
+		case status.State.Terminated != nil:
+			container.State = "terminated"
+			container.Reason = status.State.Terminated.Reason
+			container.Message = status.State.Terminated.Message
+			container.ExitCode = status.State.Terminated.ExitCode
+		}
+
+		diagnostics = append(diagnostics, container)
+	}
+
+	return diagnostics
+}
+
+// MapEventsToDiagnostics returns limit of the most recent events
+func MapEventsToDiagnostics(events []corev1.Event, limit int) []testkube.KubernetesEvent {
+	sort.Slice(events, func(i, j int) bool {
+		return eventTime(events[j]).Before(eventTime(events[i]))
+	})
+
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	diagnostics := []testkube.KubernetesEvent{}
+	for _, event := range events {
+		diagnostics = append(diagnostics, testkube.KubernetesEvent{
+			Type_:         event.Type,
+			Reason:        event.Reason,
+			Message:       event.Message,
+			Object:        event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+			Count:         event.Count,
+			LastTimestamp: eventTime(event),
+		})
+	}
+
+	return diagnostics
+}
+
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
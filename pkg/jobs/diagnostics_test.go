@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestMapPodToDiagnostics(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "execution-pod"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init", Image: "kubeshop/testkube-executor-init:0.7.10"}},
+			Containers:     []corev1.Container{{Name: "executor", Image: "kubeshop/testkube-postman-executor:0.0.1"}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			InitContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "init",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+			}},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "executor",
+				RestartCount: 2,
+				State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"}},
+			}},
+		},
+	}
+
+	assert.Equal(t, testkube.PodDiagnostics{
+		Name:  "execution-pod",
+		Phase: "Pending",
+		InitContainers: []testkube.ContainerDiagnostics{{
+			Name:     "init",
+			Image:    "kubeshop/testkube-executor-init:0.7.10",
+			State:    "terminated",
+			Reason:   "OOMKilled",
+			ExitCode: 137,
+		}},
+		Containers: []testkube.ContainerDiagnostics{{
+			Name:         "executor",
+			Image:        "kubeshop/testkube-postman-executor:0.0.1",
+			State:        "waiting",
+			Reason:       "ImagePullBackOff",
+			Message:      "Back-off pulling image",
+			RestartCount: 2,
+		}},
+	}, MapPodToDiagnostics(pod))
+}
+
+func TestMapEventsToDiagnostics(t *testing.T) {
+	now := time.Now()
+	newEvent := func(reason string, age time.Duration) corev1.Event {
+		return corev1.Event{
+			Reason:         reason,
+			LastTimestamp:  metav1.NewTime(now.Add(-age)),
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "execution-pod"},
+		}
+	}
+
+	events := MapEventsToDiagnostics([]corev1.Event{
+		newEvent("Scheduled", 3*time.Minute),
+		newEvent("Failed", time.Minute),
+		newEvent("Pulling", 2*time.Minute),
+	}, 2)
+
+	assert.Len(t, events, 2)
+	assert.Equal(t, "Failed", events[0].Reason)
+	assert.Equal(t, "Pulling", events[1].Reason)
+	assert.Equal(t, "Pod/execution-pod", events[0].Object)
+}
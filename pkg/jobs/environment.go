@@ -0,0 +1,109 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// saveEnvironment records digest of executor image and environment the execution pod ran with, including resource
+// requests execution costs are estimated from
+func (c *JobClient) saveEnvironment(ctx context.Context, repo result.Repository, executionID, podName string) {
+	pod, err := c.ClientSet.CoreV1().Pods(c.Namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		c.Log.Errorw("getting executor pod environment", "executionId", executionID, "error", err)
+		return
+	}
+
+	environment := testkube.ExecutionEnvironment{NodeName: pod.Spec.NodeName}
+	setRequests(&environment, podRequests(*pod))
+	if version, err := c.serverVersion.get(c.fetchServerVersion); err != nil {
+		c.Log.Warnw("getting kubernetes version", "executionId", executionID, "error", err)
+	} else {
+		environment.KubernetesVersion = version
+	}
+
+	if err = repo.UpdateEnvironment(ctx, executionID, ImageDigest(*pod), environment); err != nil {
+		c.Log.Errorw("saving execution environment", "executionId", executionID, "error", err)
+	}
+}
+
+func (c *JobClient) fetchServerVersion() (string, error) {
+	version, err := c.ClientSet.Discovery().ServerVersion()
+	if err != nil {
+		return "", err
+	}
+
+	return version.GitVersion, nil
+}
+
+// serverVersionTTL is a time Kubernetes version is cached for, version changes only with cluster upgrades
+const serverVersionTTL = 10 * time.Minute
+
+// versionCache caches Kubernetes version, so it isn't requested for every execution
+type versionCache struct {
+	mutex     sync.Mutex
+	version   string
+	fetchedAt time.Time
+}
+
+// get returns cached version, version is fetched when it's not cached or it's older than TTL, nil cache doesn't cache
+func (v *versionCache) get(fetch func() (string, error)) (string, error) {
+	if v == nil {
+		return fetch()
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if v.version != "" && time.Since(v.fetchedAt) < serverVersionTTL {
+		return v.version, nil
+	}
+
+	version, err := fetch()
+	if err != nil {
+		return "", err
+	}
+
+	v.version, v.fetchedAt = version, time.Now()
+	return version, nil
+}
+
+// podRequests returns resources requested by pod, init containers run before containers, so the larger of their
+// max and containers sum is requested
+func podRequests(pod corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			sum := requests[name]
+			sum.Add(quantity)
+			requests[name] = sum
+		}
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+
+	return requests
+}
+
+// setRequests records CPU and memory requests in environment as quantities and numbers, unset requests aren't recorded
+func setRequests(environment *testkube.ExecutionEnvironment, requests corev1.ResourceList) {
+	if cpu, ok := requests[corev1.ResourceCPU]; ok && !cpu.IsZero() {
+		environment.CpuRequest, environment.CpuRequestCores = cpu.String(), cpu.AsApproximateFloat64()
+	}
+
+	if memory, ok := requests[corev1.ResourceMemory]; ok && !memory.IsZero() {
+		environment.MemoryRequest, environment.MemoryRequestBytes = memory.String(), memory.Value()
+	}
+}
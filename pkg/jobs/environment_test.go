@@ -0,0 +1,74 @@
+package jobs
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestPodRequests(t *testing.T) {
+	container := func(cpu, memory string) corev1.Container {
+		return corev1.Container{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}}}
+	}
+	environment := func(pod corev1.Pod) (environment testkube.ExecutionEnvironment) {
+		setRequests(&environment, podRequests(pod))
+		return environment
+	}
+
+	t.Run("containers are summed", func(t *testing.T) {
+		assert.Equal(t, testkube.ExecutionEnvironment{CpuRequest: "750m", MemoryRequest: "512Mi", CpuRequestCores: 0.75,
+			MemoryRequestBytes: 512 << 20}, environment(corev1.Pod{Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{container("100m", "64Mi")},
+			Containers:     []corev1.Container{container("500m", "256Mi"), container("250m", "256Mi")},
+		}}))
+	})
+
+	t.Run("larger init container request is used", func(t *testing.T) {
+		assert.Equal(t, testkube.ExecutionEnvironment{CpuRequest: "2", MemoryRequest: "256Mi", CpuRequestCores: 2,
+			MemoryRequestBytes: 256 << 20}, environment(corev1.Pod{Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{container("2", "64Mi")},
+			Containers:     []corev1.Container{container("500m", "256Mi")},
+		}}))
+	})
+
+	t.Run("unset requests", func(t *testing.T) {
+		assert.Empty(t, environment(corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{}}}}))
+	})
+}
+
+func TestVersionCache(t *testing.T) {
+	fetches := 0
+	fetch := func() (string, error) {
+		fetches++
+		return "v1.24.3", nil
+	}
+
+	cache := &versionCache{}
+	for i := 0; i < 3; i++ {
+		version, err := cache.get(fetch)
+		assert.NoError(t, err)
+		assert.Equal(t, "v1.24.3", version)
+	}
+	assert.Equal(t, 1, fetches)
+
+	cache.fetchedAt = time.Now().Add(-serverVersionTTL)
+	_, err := cache.get(fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fetches, "expired version is fetched again")
+
+	_, err = (&versionCache{}).get(func() (string, error) { return "", errors.New("unauthorized") })
+	assert.Error(t, err)
+
+	_, err = (*versionCache)(nil).get(fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, fetches, "nil cache doesn't cache")
+}
@@ -0,0 +1,142 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// inlineFilesVolumeName is a name of volume with inline execution files
+const inlineFilesVolumeName = "execution-files"
+
+// FilesSecretName returns name of secret with inline execution files, secret is deleted together with execution job
+func FilesSecretName(executionID string) string {
+	return executionID + "-files"
+}
+
+// NewExecutionFileVolumes returns volumes and volume mounts exposing execution files in declared paths, inline files
+// are read from execution files secret
+func NewExecutionFileVolumes(executionID string, files []testkube.ExecutionFile) (volumes []corev1.Volume, volumeMounts []corev1.VolumeMount) {
+	var inlineItems []corev1.KeyToPath
+	for i, file := range files {
+		key := fileKey(i, file)
+		name := inlineFilesVolumeName
+		switch {
+		case file.SecretName != "":
+			name = fmt.Sprintf("execution-file-%d", i)
+			volumes = append(volumes, corev1.Volume{
+				Name: name,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: file.SecretName,
+						Items:      []corev1.KeyToPath{{Key: key, Path: key}},
+					},
+				},
+			})
+		case file.ConfigMapName != "":
+			name = fmt.Sprintf("execution-file-%d", i)
+			volumes = append(volumes, corev1.Volume{
+				Name: name,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: file.ConfigMapName},
+						Items:                []corev1.KeyToPath{{Key: key, Path: key}},
+					},
+				},
+			})
+		default:
+			inlineItems = append(inlineItems, corev1.KeyToPath{Key: key, Path: key})
+		}
+
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: file.Path,
+			SubPath:   key,
+			ReadOnly:  true,
+		})
+	}
+
+	if len(inlineItems) > 0 {
+		volumes = append(volumes, corev1.Volume{
+			Name: inlineFilesVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: FilesSecretName(executionID),
+					Items:      inlineItems,
+				},
+			},
+		})
+	}
+
+	return volumes, volumeMounts
+}
+
+// NewFilesSecret returns secret with inline execution files owned by execution job, nil is returned when there are
+// no inline files
+func NewFilesSecret(job *batchv1.Job, files []testkube.ExecutionFile) *corev1.Secret {
+	data := map[string][]byte{}
+	for i, file := range files {
+		if file.IsInline() {
+			data[fileKey(i, file)] = []byte(file.Content)
+		}
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      FilesSecretName(job.Name),
+			Namespace: job.Namespace,
+			Labels:    map[string]string{JobNameLabel: job.Name},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "batch/v1",
+				Kind:       "Job",
+				Name:       job.Name,
+				UID:        job.UID,
+			}},
+		},
+		Data: data,
+	}
+}
+
+// createFilesSecret creates secret with inline execution files of created job, pod waits for secret volume
+// until the secret is created, so the job is deleted when the secret can't be created
+func (c *JobClient) createFilesSecret(ctx context.Context, job *batchv1.Job, files []testkube.ExecutionFile) error {
+	secret := NewFilesSecret(job, files)
+	if secret == nil {
+		return nil
+	}
+
+	if _, err := c.ClientSet.CoreV1().Secrets(c.Namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		propagation := metav1.DeletePropagationBackground
+		if err := c.ClientSet.BatchV1().Jobs(c.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{PropagationPolicy: &propagation}); err != nil {
+			c.Log.Errorw("deleting job without execution files secret", "job", job.Name, "error", err)
+		}
+		return fmt.Errorf("execution files secret create error: %w", err)
+	}
+
+	return nil
+}
+
+// marshalExecution returns execution passed to executor, execution files are mounted so their content is left out
+func marshalExecution(execution testkube.Execution) ([]byte, error) {
+	execution.Files = nil
+	return json.Marshal(execution)
+}
+
+// fileKey returns key of execution file content, inline files are stored under indexed keys
+func fileKey(i int, file testkube.ExecutionFile) string {
+	if file.IsInline() {
+		return fmt.Sprintf("file-%d", i)
+	}
+
+	return file.Key
+}
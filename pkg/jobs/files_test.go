@@ -0,0 +1,54 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+var executionFiles = []testkube.ExecutionFile{
+	{Path: "/etc/app/config.yaml", Content: "debug: true"},
+	{Path: "/etc/certs/ca.crt", SecretName: "tls", Key: "ca.crt"},
+	{Path: "/data/users.csv", ConfigMapName: "data", Key: "users.csv"},
+}
+
+func TestNewExecutionFileVolumes(t *testing.T) {
+	volumes, volumeMounts := NewExecutionFileVolumes("1", executionFiles)
+
+	assert.Equal(t, []corev1.Volume{
+		{Name: "execution-file-1", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+			SecretName: "tls",
+			Items:      []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}},
+		}}},
+		{Name: "execution-file-2", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "data"},
+			Items:                []corev1.KeyToPath{{Key: "users.csv", Path: "users.csv"}},
+		}}},
+		{Name: "execution-files", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+			SecretName: "1-files",
+			Items:      []corev1.KeyToPath{{Key: "file-0", Path: "file-0"}},
+		}}},
+	}, volumes)
+
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "execution-files", MountPath: "/etc/app/config.yaml", SubPath: "file-0", ReadOnly: true},
+		{Name: "execution-file-1", MountPath: "/etc/certs/ca.crt", SubPath: "ca.crt", ReadOnly: true},
+		{Name: "execution-file-2", MountPath: "/data/users.csv", SubPath: "users.csv", ReadOnly: true},
+	}, volumeMounts)
+}
+
+func TestNewFilesSecret(t *testing.T) {
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "1", Namespace: "testkube", UID: "uid"}}
+
+	secret := NewFilesSecret(job, executionFiles)
+	assert.Equal(t, "1-files", secret.Name)
+	assert.Equal(t, map[string][]byte{"file-0": []byte("debug: true")}, secret.Data)
+	assert.Equal(t, "uid", string(secret.OwnerReferences[0].UID), "secret is deleted with job")
+
+	assert.Nil(t, NewFilesSecret(job, executionFiles[1:]), "files without inline content don't need secret")
+}
@@ -0,0 +1,119 @@
+package jobs
+
+import (
+	"context"
+	"sort"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestNameLabel is a label set on jobs and their pods with name of executed test
+const TestNameLabel = "testkube.io/test-name"
+
+// GCPolicy is a finished jobs garbage collection policy
+type GCPolicy struct {
+	// TTLSecondsAfterFinished is set on jobs not having it set in job template, finished jobs are kept forever when not set
+	TTLSecondsAfterFinished *int32 `envconfig:"TTL_SECONDS_AFTER_FINISHED"`
+	// KeepLastFailed is a number of last failed jobs kept for a test, older ones are deleted when new job is created, 0 keeps all
+	KeepLastFailed int `envconfig:"KEEP_LAST_FAILED"`
+}
+
+// IsJobFinished checks if job has completed or failed
+func IsJobFinished(job batchv1.Job) bool {
+	return IsJobFailed(job) || hasJobCondition(job, batchv1.JobComplete)
+}
+
+// IsJobFailed checks if job has failed
+func IsJobFailed(job batchv1.Job) bool {
+	return hasJobCondition(job, batchv1.JobFailed)
+}
+
+func hasJobCondition(job batchv1.Job, conditionType batchv1.JobConditionType) bool {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetFailedJobsOverLimit returns failed jobs older than last keep ones
+func GetFailedJobsOverLimit(jobs []batchv1.Job, keep int) (overLimit []batchv1.Job) {
+	var failed []batchv1.Job
+	for _, job := range jobs {
+		if IsJobFailed(job) {
+			failed = append(failed, job)
+		}
+	}
+
+	if len(failed) <= keep {
+		return nil
+	}
+
+	// the newest first
+	sort.Slice(failed, func(i, j int) bool {
+		return failed[j].CreationTimestamp.Before(&failed[i].CreationTimestamp)
+	})
+
+	return failed[keep:]
+}
+
+// CleanFailedJobs deletes failed test jobs over the policy limit
+func (c *JobClient) CleanFailedJobs(ctx context.Context, testName string) error {
+	if c.gcPolicy.KeepLastFailed <= 0 {
+		return nil
+	}
+
+	jobs, err := c.listTestJobs(ctx, testName)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range GetFailedJobsOverLimit(jobs, c.gcPolicy.KeepLastFailed) {
+		if err = c.deleteJob(ctx, job.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PurgeFinishedJobs deletes all finished jobs of given test and returns number of deleted jobs
+func (c *JobClient) PurgeFinishedJobs(ctx context.Context, testName string) (count int, err error) {
+	jobs, err := c.listTestJobs(ctx, testName)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range jobs {
+		if !IsJobFinished(job) {
+			continue
+		}
+
+		if err = c.deleteJob(ctx, job.Name); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+func (c *JobClient) listTestJobs(ctx context.Context, testName string) ([]batchv1.Job, error) {
+	list, err := c.ClientSet.BatchV1().Jobs(c.Namespace).List(ctx, metav1.ListOptions{LabelSelector: TestNameLabel + "=" + testName})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+func (c *JobClient) deleteJob(ctx context.Context, name string) error {
+	bg := metav1.DeletePropagationBackground
+	return c.ClientSet.BatchV1().Jobs(c.Namespace).Delete(ctx, name, metav1.DeleteOptions{
+		PropagationPolicy: &bg,
+	})
+}
@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetFailedJobsOverLimit(t *testing.T) {
+	now := time.Now()
+	newJob := func(name string, age time.Duration, conditionType batchv1.JobConditionType) batchv1.Job {
+		return batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(now.Add(-age))},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	jobs := []batchv1.Job{
+		newJob("failed-old", 3*time.Hour, batchv1.JobFailed),
+		newJob("failed-new", time.Hour, batchv1.JobFailed),
+		newJob("complete", 4*time.Hour, batchv1.JobComplete),
+		newJob("failed-mid", 2*time.Hour, batchv1.JobFailed),
+	}
+
+	t.Run("older failed jobs are over limit", func(t *testing.T) {
+		overLimit := GetFailedJobsOverLimit(jobs, 1)
+
+		assert.Len(t, overLimit, 2)
+		assert.Equal(t, "failed-mid", overLimit[0].Name)
+		assert.Equal(t, "failed-old", overLimit[1].Name)
+	})
+
+	t.Run("no jobs over limit", func(t *testing.T) {
+		assert.Empty(t, GetFailedJobsOverLimit(jobs, 3))
+	})
+}
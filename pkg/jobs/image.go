@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+var imageDigestRegex = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// ValidateImageDigest checks that image digest is sha256 manifest digest
+func ValidateImageDigest(digest string) error {
+	if !imageDigestRegex.MatchString(digest) {
+		return fmt.Errorf("image digest %q must be in sha256:<64 hex characters> format", digest)
+	}
+
+	return nil
+}
+
+// ImageWithDigest returns image reference pinned to given digest, digest already set in image is replaced
+func ImageWithDigest(image, digest string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+
+	return image + "@" + digest
+}
+
+// ImageDigest returns digest of image the executor container of pod runs, it's empty until image is pulled
+// or when image isn't pulled from registry
+func ImageDigest(pod corev1.Pod) string {
+	if len(pod.Spec.Containers) == 0 {
+		return ""
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != pod.Spec.Containers[0].Name {
+			continue
+		}
+
+		// image ID is e.g. docker-pullable://kubeshop/testkube-postman-executor@sha256:...
+		if i := strings.LastIndex(status.ImageID, "@"); i >= 0 {
+			if digest := status.ImageID[i+1:]; ValidateImageDigest(digest) == nil {
+				return digest
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,47 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const testImageDigest = "sha256:4bcff63911fcb4448bd4fdacec207030997caf25e9bea4045fa6c8c44de311d1"
+
+func TestImageDigest(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "init"}},
+			Containers:     []corev1.Container{{Name: "executor"}, {Name: "sidecar"}},
+		},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{{Name: "init", ImageID: "docker-pullable://kubeshop/testkube-executor-init@sha256:" + testImageDigest[7:]}},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "sidecar", ImageID: "docker.io/library/envoy@sha256:0000000000000000000000000000000000000000000000000000000000000000"},
+				{Name: "executor", ImageID: "docker-pullable://kubeshop/testkube-postman-executor@" + testImageDigest},
+			},
+		},
+	}
+
+	assert.Equal(t, testImageDigest, ImageDigest(pod))
+
+	pod.Status.ContainerStatuses[1].ImageID = "sha256:" + testImageDigest[7:]
+	assert.Empty(t, ImageDigest(pod), "local image ID isn't registry digest")
+
+	assert.Empty(t, ImageDigest(corev1.Pod{}))
+}
+
+func TestImageWithDigest(t *testing.T) {
+	assert.Equal(t, "kubeshop/testkube-postman-executor:1.0.0@"+testImageDigest,
+		ImageWithDigest("kubeshop/testkube-postman-executor:1.0.0", testImageDigest))
+	assert.Equal(t, "ghcr.io/org/executor@"+testImageDigest,
+		ImageWithDigest("ghcr.io/org/executor@sha256:0000000000000000000000000000000000000000000000000000000000000000", testImageDigest))
+}
+
+func TestValidateImageDigest(t *testing.T) {
+	assert.NoError(t, ValidateImageDigest(testImageDigest))
+	assert.Error(t, ValidateImageDigest("sha256:abc"))
+	assert.Error(t, ValidateImageDigest("latest"))
+	assert.Error(t, ValidateImageDigest(""))
+}
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -28,6 +27,7 @@ import (
 	"github.com/kubeshop/testkube/pkg/k8sclient"
 	"github.com/kubeshop/testkube/pkg/log"
 	"github.com/kubeshop/testkube/pkg/secret"
+	"github.com/kubeshop/testkube/pkg/storage"
 )
 
 const (
@@ -47,13 +47,25 @@ const (
 
 // JobClient data struct for managing running jobs
 type JobClient struct {
-	ClientSet   *kubernetes.Clientset
-	Repository  result.Repository
-	Namespace   string
-	Cmd         string
-	Log         *zap.SugaredLogger
-	initImage   string
-	jobTemplate string
+	ClientSet      *kubernetes.Clientset
+	Repository     result.Repository
+	Namespace      string
+	Cmd            string
+	Log            *zap.SugaredLogger
+	initImage      string
+	jobTemplate    string
+	registryMirror string
+	gcPolicy       GCPolicy
+	// reschedulePolicy limits rescheduling of executions which pods were preempted
+	reschedulePolicy ReschedulePolicy
+	// artifacts is a storage of execution artifacts, collected application logs aren't stored when it's not set
+	artifacts storage.Client
+	// appLogsPolicy limits namespaces application logs are collected from
+	appLogsPolicy AppLogsPolicy
+	// instanceID identifies API server instance watching launched jobs
+	instanceID string
+	// serverVersion caches Kubernetes version recorded in execution environment
+	serverVersion *versionCache
 }
 
 // JobOptions is for configuring JobOptions
@@ -69,21 +81,50 @@ type JobOptions struct {
 	SecretEnvs  map[string]string
 	HTTPProxy   string
 	HTTPSProxy  string
+	Labels      map[string]string
+	// SecretMounts are secret keys mounted as files into executor container
+	SecretMounts []testkube.SecretMount
+	// Files are execution files mounted into executor container
+	Files []testkube.ExecutionFile
+	// ContentUriOptions are file URI test content options, their credentials are passed from secret as env vars
+	ContentUriOptions *testkube.ContentUriOptions
+	// RegistryMirror is a registry all job images are rewritten to
+	RegistryMirror string
+	// DisableRegistryMirror opts out executor from registry mirror
+	DisableRegistryMirror bool
+	// TTLSecondsAfterFinished is set on job when job template doesn't set it
+	TTLSecondsAfterFinished *int32
+	// InstanceID is an API server instance watching the job
+	InstanceID string
+	// RequestID is an id of API request which started the execution
+	RequestID string
+	// Placement are merged executor and test scheduling constraints
+	Placement *testkube.Placement
+	// LogCollection selects application pods which logs are stored as execution artifact
+	LogCollection *testkube.LogCollection
 }
 
 // NewJobClient returns new JobClient instance
-func NewJobClient(namespace, initImage, jobTemplate string) (*JobClient, error) {
+func NewJobClient(namespace, initImage, jobTemplate, registryMirror, instanceID string, gcPolicy GCPolicy,
+	reschedulePolicy ReschedulePolicy, artifacts storage.Client, appLogsPolicy AppLogsPolicy) (*JobClient, error) {
 	clientSet, err := k8sclient.ConnectToK8s()
 	if err != nil {
 		return nil, err
 	}
 
 	return &JobClient{
-		ClientSet:   clientSet,
-		Namespace:   namespace,
-		Log:         log.DefaultLogger,
-		initImage:   initImage,
-		jobTemplate: jobTemplate,
+		ClientSet:        clientSet,
+		Namespace:        namespace,
+		Log:              log.DefaultLogger,
+		initImage:        initImage,
+		jobTemplate:      jobTemplate,
+		registryMirror:   registryMirror,
+		gcPolicy:         gcPolicy,
+		instanceID:       instanceID,
+		reschedulePolicy: reschedulePolicy,
+		artifacts:        artifacts,
+		appLogsPolicy:    appLogsPolicy,
+		serverVersion:    &versionCache{},
 	}, nil
 }
 
@@ -98,7 +139,7 @@ func (c *JobClient) LaunchK8sJobSync(repo result.Repository, execution testkube.
 	podsClient := c.ClientSet.CoreV1().Pods(c.Namespace)
 	ctx := context.Background()
 
-	jsn, err := json.Marshal(execution)
+	jsn, err := marshalExecution(execution)
 	if err != nil {
 		return result.Err(err), err
 	}
@@ -111,17 +152,31 @@ func (c *JobClient) LaunchK8sJobSync(repo result.Repository, execution testkube.
 	if options.JobTemplate == "" {
 		options.JobTemplate = c.jobTemplate
 	}
+	if !options.DisableRegistryMirror {
+		options.RegistryMirror = c.registryMirror
+	}
+	options.TTLSecondsAfterFinished = c.gcPolicy.TTLSecondsAfterFinished
+	options.InstanceID = c.instanceID
+	options.RequestID = execution.RequestId
+
+	if err = c.CleanFailedJobs(ctx, execution.TestName); err != nil {
+		c.Log.Errorw("cleaning failed test jobs", "test", execution.TestName, "error", err)
+	}
 
 	jobSpec, err := NewJobSpec(c.Log, options)
 	if err != nil {
 		return result.Err(err), err
 	}
 
-	_, err = jobs.Create(ctx, jobSpec, metav1.CreateOptions{})
+	job, err := jobs.Create(ctx, jobSpec, metav1.CreateOptions{})
 	if err != nil {
 		return result.Err(err), err
 	}
 
+	if err = c.createFilesSecret(ctx, job, options.Files); err != nil {
+		return result.Err(err), err
+	}
+
 	pods, err := c.GetJobPods(podsClient, execution.Id, 1, 10)
 	if err != nil {
 		return result.Err(err), err
@@ -129,7 +184,7 @@ func (c *JobClient) LaunchK8sJobSync(repo result.Repository, execution testkube.
 
 	// get job pod and
 	for _, pod := range pods.Items {
-		if pod.Status.Phase != corev1.PodRunning && pod.Labels["job-name"] == execution.Id {
+		if pod.Status.Phase != corev1.PodRunning && pod.Labels[JobNameLabel] == execution.Id {
 			l := c.Log.With("pod", pod.Name, "namespace", pod.Namespace, "func", "LaunchK8sJobSync")
 
 			// save stop time
@@ -141,19 +196,32 @@ func (c *JobClient) LaunchK8sJobSync(repo result.Repository, execution testkube.
 				}
 			}()
 
-			// wait for complete
-			l.Debug("poll immediate waiting for pod to succeed")
-			if err := wait.PollImmediate(pollInterval, pollTimeout, IsPodReady(c.ClientSet, pod.Name, c.Namespace)); err != nil {
-				// continue on poll err and try to get logs later
-				l.Errorw("waiting for pod complete error", "error", err)
+			// sync results are saved when execution completes, running status ends its time in queue
+			if err = c.saveResult(ctx, repo, execution.Id, result); err != nil {
+				l.Infow("Update result", "error", err)
 			}
+
+			// wait for complete, preempted pods are rescheduled
+			l.Debug("poll immediate waiting for pod to succeed")
+			podName, preemption := c.waitForPod(ctx, repo, execution, options, pod.Name)
 			l.Debug("poll immediate end")
+			c.saveEnvironment(ctx, repo, execution.Id, podName)
+			c.saveTimings(ctx, repo, execution, podName)
+			c.collectAppLogs(ctx, execution, options.LogCollection)
+
+			if preemption != nil {
+				result = preemptedResult(*preemption)
+				if err = c.saveResult(ctx, repo, execution.Id, result); err != nil {
+					l.Infow("Update result", "error", err)
+				}
+				return result, nil
+			}
 
 			var logs []byte
-			logs, err = c.GetPodLogs(pod.Name)
+			logs, err = c.GetPodLogs(podName)
 			if err != nil {
 				l.Errorw("get pod logs error", "error", err)
-				err = repo.UpdateResult(ctx, execution.Id, result.Err(err))
+				err = c.saveResult(ctx, repo, execution.Id, c.applyPodTermination(ctx, execution, podName, result.Err(err)))
 				if err != nil {
 					l.Infow("Update result", "error", err)
 				}
@@ -164,15 +232,17 @@ func (c *JobClient) LaunchK8sJobSync(repo result.Repository, execution testkube.
 			result, _, err := output.ParseRunnerOutput(logs)
 			if err != nil {
 				l.Errorw("parse ouput error", "error", err)
-				err = repo.UpdateResult(ctx, execution.Id, result.Err(err))
+				err = c.saveResult(ctx, repo, execution.Id, c.applyPodTermination(ctx, execution, podName, result.Err(err)))
 				if err != nil {
 					l.Infow("End execution", "error", err)
 				}
 				return result, err
 			}
 
+			result = c.applyPodTermination(ctx, execution, podName, result)
+			result = c.applyPerfGate(ctx, repo, execution, result)
 			l.Infow("execution completed saving result", "executionId", execution.Id, "status", result.Status)
-			err = repo.UpdateResult(ctx, execution.Id, result)
+			err = c.saveResult(ctx, repo, execution.Id, result)
 			if err != nil {
 				l.Infow("End execution", "error", err)
 			}
@@ -196,7 +266,7 @@ func (c *JobClient) LaunchK8sJob(repo result.Repository, execution testkube.Exec
 	// init result
 	result = testkube.NewPendingExecutionResult()
 
-	jsn, err := json.Marshal(execution)
+	jsn, err := marshalExecution(execution)
 	if err != nil {
 		return result.Err(err), err
 	}
@@ -209,6 +279,16 @@ func (c *JobClient) LaunchK8sJob(repo result.Repository, execution testkube.Exec
 	if options.JobTemplate == "" {
 		options.JobTemplate = c.jobTemplate
 	}
+	if !options.DisableRegistryMirror {
+		options.RegistryMirror = c.registryMirror
+	}
+	options.TTLSecondsAfterFinished = c.gcPolicy.TTLSecondsAfterFinished
+	options.InstanceID = c.instanceID
+	options.RequestID = execution.RequestId
+
+	if err = c.CleanFailedJobs(ctx, execution.TestName); err != nil {
+		c.Log.Errorw("cleaning failed test jobs", "test", execution.TestName, "error", err)
+	}
 
 	jobSpec, err := NewJobSpec(c.Log, options)
 
@@ -216,11 +296,15 @@ func (c *JobClient) LaunchK8sJob(repo result.Repository, execution testkube.Exec
 		return result.Err(err), fmt.Errorf("new job spec error: %w", err)
 	}
 
-	_, err = jobs.Create(ctx, jobSpec, metav1.CreateOptions{})
+	job, err := jobs.Create(ctx, jobSpec, metav1.CreateOptions{})
 	if err != nil {
 		return result.Err(err), fmt.Errorf("job create error: %w", err)
 	}
 
+	if err = c.createFilesSecret(ctx, job, options.Files); err != nil {
+		return result.Err(err), err
+	}
+
 	pods, err := c.GetJobPods(podsClient, execution.Id, 1, 10)
 	if err != nil {
 		return result.Err(err), fmt.Errorf("get job pods error: %w", err)
@@ -228,7 +312,8 @@ func (c *JobClient) LaunchK8sJob(repo result.Repository, execution testkube.Exec
 
 	// get job pod and
 	for _, pod := range pods.Items {
-		if pod.Status.Phase != corev1.PodRunning && pod.Labels["job-name"] == execution.Id {
+		pod := pod
+		if pod.Status.Phase != corev1.PodRunning && pod.Labels[JobNameLabel] == execution.Id {
 			// async wait for complete status or error
 			go func() {
 				l := c.Log.With("executionID", execution.Id, "func", "LaunchK8sJob")
@@ -242,19 +327,26 @@ func (c *JobClient) LaunchK8sJob(repo result.Repository, execution testkube.Exec
 					}
 				}()
 
-				// wait for complete
+				// wait for complete, preempted pods are rescheduled
 				l.Debug("poll immediate waiting for pod to succeed")
-				if err := wait.PollImmediate(pollInterval, pollTimeout, IsPodReady(c.ClientSet, pod.Name, c.Namespace)); err != nil {
-					// continue on poll err and try to get logs later
-					l.Errorw("poll immediate error", "error", err)
-				}
+				podName, preemption := c.waitForPod(ctx, repo, execution, options, pod.Name)
 				l.Debug("poll immediate end")
+				c.saveEnvironment(ctx, repo, execution.Id, podName)
+				c.saveTimings(ctx, repo, execution, podName)
+				c.collectAppLogs(ctx, execution, options.LogCollection)
+
+				if preemption != nil {
+					if err = c.saveResult(ctx, repo, execution.Id, preemptedResult(*preemption)); err != nil {
+						l.Infow("Update result", "error", err)
+					}
+					return
+				}
 
 				var logs []byte
-				logs, err = c.GetPodLogs(pod.Name)
+				logs, err = c.GetPodLogs(podName)
 				if err != nil {
 					l.Errorw("get pod logs error", "error", err)
-					err = repo.UpdateResult(ctx, execution.Id, result.Err(err))
+					err = c.saveResult(ctx, repo, execution.Id, c.applyPodTermination(ctx, execution, podName, result.Err(err)))
 					if err != nil {
 						l.Infow("End execution", "error", err)
 					}
@@ -265,15 +357,17 @@ func (c *JobClient) LaunchK8sJob(repo result.Repository, execution testkube.Exec
 				result, _, err := output.ParseRunnerOutput(logs)
 				if err != nil {
 					l.Errorw("parse ouput error", "error", err)
-					err = repo.UpdateResult(ctx, execution.Id, result.Err(err))
+					err = c.saveResult(ctx, repo, execution.Id, c.applyPodTermination(ctx, execution, podName, result.Err(err)))
 					if err != nil {
 						l.Infow("End execution", "error", err)
 					}
 					return
 				}
 
+				result = c.applyPodTermination(ctx, execution, podName, result)
+				result = c.applyPerfGate(ctx, repo, execution, result)
 				l.Infow("execution completed saving result", "status", result.Status)
-				err = repo.UpdateResult(ctx, execution.Id, result)
+				err = c.saveResult(ctx, repo, execution.Id, result)
 				if err != nil {
 					l.Infow("End execution", "error", err)
 				}
@@ -284,9 +378,26 @@ func (c *JobClient) LaunchK8sJob(repo result.Repository, execution testkube.Exec
 	return testkube.NewPendingExecutionResult(), nil
 }
 
+// saveResult updates execution result unless execution was aborted in the meantime
+func (c *JobClient) saveResult(ctx context.Context, repo result.Repository, id string, executionResult testkube.ExecutionResult) error {
+	if isExecutionAborted(ctx, repo, id) {
+		c.Log.Infow("execution was aborted, skipping result update", "executionId", id)
+		return nil
+	}
+
+	return repo.UpdateResult(ctx, id, executionResult)
+}
+
+// isExecutionAborted checks if stored execution was aborted
+func isExecutionAborted(ctx context.Context, repo result.Repository, id string) bool {
+	execution, err := repo.Get(ctx, id)
+	return err == nil && execution.ExecutionResult != nil && execution.ExecutionResult.Status != nil &&
+		execution.ExecutionResult.IsAborted()
+}
+
 // GetJobPods returns job pods
 func (c *JobClient) GetJobPods(podsClient tcorev1.PodInterface, jobName string, retryNr, retryCount int) (*corev1.PodList, error) {
-	pods, err := podsClient.List(context.TODO(), metav1.ListOptions{LabelSelector: "job-name=" + jobName})
+	pods, err := podsClient.List(context.TODO(), metav1.ListOptions{LabelSelector: JobNameLabel + "=" + jobName})
 	if err != nil {
 		return nil, err
 	}
@@ -313,7 +424,7 @@ func (c *JobClient) TailJobLogs(id string, logs chan []byte) (err error) {
 	}
 
 	for _, pod := range pods.Items {
-		if pod.Labels["job-name"] == id {
+		if pod.Labels[JobNameLabel] == id {
 
 			l := c.Log.With("podNamespace", pod.Namespace, "podName", pod.Name, "podStatus", pod.Status)
 
@@ -564,6 +675,8 @@ func NewJobSpec(log *zap.SugaredLogger, options JobOptions) (*batchv1.Job, error
 		}...)
 	}
 
+	secretEnvVars = append(secretEnvVars, NewContentUriEnvVars(options.ContentUriOptions)...)
+
 	tmpl, err := template.New("job").Parse(options.JobTemplate)
 	if err != nil {
 		return nil, fmt.Errorf("creating job spec from options.JobTemplate error: %w", err)
@@ -583,6 +696,57 @@ func NewJobSpec(log *zap.SugaredLogger, options JobOptions) (*batchv1.Job, error
 		return nil, fmt.Errorf("decoding job spec error: %w", err)
 	}
 
+	if job.Labels == nil {
+		job.Labels = map[string]string{}
+	}
+
+	if job.Spec.Template.Labels == nil {
+		job.Spec.Template.Labels = map[string]string{}
+	}
+
+	// execution labels are set on job and its pods to allow selecting them in kubernetes, labels of job template
+	// and job controller aren't overridden as pods are looked up by them
+	for key, value := range options.Labels {
+		if key == JobNameLabel || key == controllerUIDLabel {
+			continue
+		}
+
+		if _, ok := job.Labels[key]; !ok {
+			job.Labels[key] = value
+		}
+
+		if _, ok := job.Spec.Template.Labels[key]; !ok {
+			job.Spec.Template.Labels[key] = value
+		}
+	}
+
+	if options.TestName != "" {
+		job.Labels[TestNameLabel] = options.TestName
+		job.Spec.Template.Labels[TestNameLabel] = options.TestName
+	}
+
+	if options.InstanceID != "" {
+		job.Labels[InstanceLabel] = options.InstanceID
+	}
+
+	// request ids don't fit label values, so they are annotations
+	if options.RequestID != "" {
+		if job.Annotations == nil {
+			job.Annotations = map[string]string{}
+		}
+
+		if job.Spec.Template.Annotations == nil {
+			job.Spec.Template.Annotations = map[string]string{}
+		}
+
+		job.Annotations[RequestIDAnnotation] = options.RequestID
+		job.Spec.Template.Annotations[RequestIDAnnotation] = options.RequestID
+	}
+
+	if job.Spec.TTLSecondsAfterFinished == nil {
+		job.Spec.TTLSecondsAfterFinished = options.TTLSecondsAfterFinished
+	}
+
 	env := append(envVars, secretEnvVars...)
 	if options.HTTPProxy != "" {
 		env = append(env, corev1.EnvVar{Name: "HTTP_PROXY", Value: options.HTTPProxy})
@@ -600,9 +764,83 @@ func NewJobSpec(log *zap.SugaredLogger, options JobOptions) (*batchv1.Job, error
 		job.Spec.Template.Spec.Containers[i].Env = append(job.Spec.Template.Spec.Containers[i].Env, env...)
 	}
 
+	if options.RegistryMirror != "" {
+		for i := range job.Spec.Template.Spec.InitContainers {
+			job.Spec.Template.Spec.InitContainers[i].Image = RewriteImageRegistry(job.Spec.Template.Spec.InitContainers[i].Image, options.RegistryMirror)
+		}
+
+		for i := range job.Spec.Template.Spec.Containers {
+			job.Spec.Template.Spec.Containers[i].Image = RewriteImageRegistry(job.Spec.Template.Spec.Containers[i].Image, options.RegistryMirror)
+		}
+	}
+
+	if err := applyPlacement(&job.Spec.Template.Spec, options.Placement, options.TestName); err != nil {
+		return nil, err
+	}
+
+	volumes, volumeMounts := NewSecretMountVolumes(options.SecretMounts)
+	fileVolumes, fileVolumeMounts := NewExecutionFileVolumes(options.Name, options.Files)
+	volumes = append(volumes, fileVolumes...)
+	volumeMounts = append(volumeMounts, fileVolumeMounts...)
+	job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes, volumes...)
+	for i := range job.Spec.Template.Spec.Containers {
+		job.Spec.Template.Spec.Containers[i].VolumeMounts = append(job.Spec.Template.Spec.Containers[i].VolumeMounts, volumeMounts...)
+	}
+
 	return &job, nil
 }
 
+// RewriteImageRegistry replaces image registry with given mirror, docker hub official images are prefixed with library
+func RewriteImageRegistry(image, mirror string) string {
+	mirror = strings.TrimSuffix(mirror, "/")
+	if image == "" || mirror == "" || strings.HasPrefix(image, mirror+"/") {
+		return image
+	}
+
+	parts := strings.SplitN(image, "/", 2)
+	switch {
+	case len(parts) == 1:
+		// docker hub official image e.g. nginx:latest
+		return mirror + "/library/" + image
+	case strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost":
+		// image with registry host e.g. ghcr.io/org/image
+		return mirror + "/" + parts[1]
+	default:
+		// docker hub image e.g. kubeshop/testkube-executor
+		return mirror + "/" + image
+	}
+}
+
+// NewSecretMountVolumes returns volumes and volume mounts exposing secret keys as files in given paths
+func NewSecretMountVolumes(secretMounts []testkube.SecretMount) (volumes []corev1.Volume, volumeMounts []corev1.VolumeMount) {
+	for i, secretMount := range secretMounts {
+		name := fmt.Sprintf("secret-mount-%d", i)
+		volumes = append(volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secretMount.SecretName,
+					Items: []corev1.KeyToPath{
+						{
+							Key:  secretMount.Key,
+							Path: secretMount.Key,
+						},
+					},
+				},
+			},
+		})
+
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: secretMount.MountPath,
+			SubPath:   secretMount.Key,
+			ReadOnly:  true,
+		})
+	}
+
+	return volumes, volumeMounts
+}
+
 var envVars = []corev1.EnvVar{
 	{
 		Name:  "DEBUG",
@@ -0,0 +1,100 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestNewSecretMountVolumes(t *testing.T) {
+	volumes, volumeMounts := NewSecretMountVolumes([]testkube.SecretMount{
+		{SecretName: "gcp-credentials", Key: "service-account.json", MountPath: "/credentials/sa.json"},
+	})
+
+	assert.Equal(t, []corev1.Volume{
+		{
+			Name: "secret-mount-0",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: "gcp-credentials",
+					Items:      []corev1.KeyToPath{{Key: "service-account.json", Path: "service-account.json"}},
+				},
+			},
+		},
+	}, volumes)
+
+	assert.Equal(t, []corev1.VolumeMount{
+		{
+			Name:      "secret-mount-0",
+			MountPath: "/credentials/sa.json",
+			SubPath:   "service-account.json",
+			ReadOnly:  true,
+		},
+	}, volumeMounts)
+}
+
+func TestRewriteImageRegistry(t *testing.T) {
+	mirror := "mirror.internal:5000/"
+
+	tests := map[string]string{
+		"nginx:1.21":                          "mirror.internal:5000/library/nginx:1.21",
+		"kubeshop/testkube-executor:latest":   "mirror.internal:5000/kubeshop/testkube-executor:latest",
+		"ghcr.io/kubeshop/executor:1.0.0":     "mirror.internal:5000/kubeshop/executor:1.0.0",
+		"localhost/executor":                  "mirror.internal:5000/executor",
+		"mirror.internal:5000/kubeshop/image": "mirror.internal:5000/kubeshop/image",
+	}
+
+	for image, expected := range tests {
+		assert.Equal(t, expected, RewriteImageRegistry(image, mirror), image)
+	}
+
+	assert.Equal(t, "nginx", RewriteImageRegistry("nginx", ""))
+}
+
+func TestNewJobSpecLabels(t *testing.T) {
+	jobTemplate := `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: "{{ .Name }}"
+  labels:
+    executor: postman-executor
+spec:
+  template:
+    metadata:
+      labels:
+        executor: postman-executor
+    spec:
+      containers:
+        - name: "{{ .Name }}"
+          image: "{{ .Image }}"
+`
+
+	job, err := NewJobSpec(zap.NewNop().Sugar(), JobOptions{
+		Name:        "execution-1",
+		Image:       "kubeshop/testkube-postman-executor",
+		JobTemplate: jobTemplate,
+		TestName:    "api",
+		Labels: map[string]string{
+			"app":                 "backend",
+			ExecutorLabel:         "other-executor",
+			JobNameLabel:          "other-execution",
+			TestNameLabel:         "other-test",
+			testkube.ProjectLabel: "team-a",
+		},
+	})
+	require.NoError(t, err)
+
+	for _, labels := range []map[string]string{job.Labels, job.Spec.Template.Labels} {
+		assert.Equal(t, map[string]string{
+			"app":                 "backend",
+			ExecutorLabel:         "postman-executor",
+			TestNameLabel:         "api",
+			testkube.ProjectLabel: "team-a",
+		}, labels)
+	}
+}
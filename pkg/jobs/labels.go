@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const (
+	// JobNameLabel is a label set by job controller on job pods, pods of execution are looked up by it
+	JobNameLabel = "job-name"
+	// controllerUIDLabel is a label set by job controller on job and its pods
+	controllerUIDLabel = "controller-uid"
+	// ExecutorLabel is a label of executor jobs set by job templates
+	ExecutorLabel = "executor"
+)
+
+// reservedLabels are labels set by server, job templates or job controller, pod lookups and project scoping
+// depend on them, so they can't be set by execution requests
+var reservedLabels = map[string]struct{}{
+	JobNameLabel:                 {},
+	controllerUIDLabel:           {},
+	ExecutorLabel:                {},
+	TestNameLabel:                {},
+	InstanceLabel:                {},
+	testkube.ProjectLabel:        {},
+	testkube.ExecutionGroupLabel: {},
+}
+
+// isReservedLabel checks if label is reserved
+func isReservedLabel(key string) bool {
+	_, ok := reservedLabels[key]
+	return ok
+}
+
+// ValidateLabels checks that execution request labels are valid kubernetes labels and aren't reserved
+func ValidateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, ", "))
+		}
+
+		if errs := validation.IsValidLabelValue(value); len(errs) != 0 {
+			return fmt.Errorf("invalid value of label %q: %s", key, strings.Join(errs, ", "))
+		}
+
+		if isReservedLabel(key) {
+			return fmt.Errorf("label %q is reserved", key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestValidateLabels(t *testing.T) {
+	assert.NoError(t, ValidateLabels(nil))
+	assert.NoError(t, ValidateLabels(map[string]string{"app": "backend", "example.com/team": "payments", "empty": ""}))
+
+	for _, labels := range []map[string]string{
+		{"invalid key": "value"},
+		{"app": "invalid value"},
+		{"app": "value-longer-than-63-characters-is-not-valid-kubernetes-label-value"},
+		{JobNameLabel: "other-execution"},
+		{ExecutorLabel: "other-executor"},
+		{testkube.ProjectLabel: "other-project"},
+		{TestNameLabel: "other-test"},
+	} {
+		assert.Error(t, ValidateLabels(labels), labels)
+	}
+}
@@ -0,0 +1,100 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const defaultPerfGateBaseline = 5
+
+// PerfGate is a performance regression gate configuration
+type PerfGate struct {
+	// Threshold is allowed metric regression in percent versus baseline
+	Threshold float64
+	// Baseline is number of last successful executions used as baseline
+	Baseline int
+}
+
+// GetPerfGate returns performance regression gate configured with test labels, nil when gate is disabled
+func GetPerfGate(labels map[string]string) *PerfGate {
+	threshold, err := strconv.ParseFloat(labels[testkube.PerfGateThresholdLabel], 64)
+	if err != nil || threshold < 0 {
+		return nil
+	}
+
+	baseline, err := strconv.Atoi(labels[testkube.PerfGateBaselineLabel])
+	if err != nil || baseline <= 0 {
+		baseline = defaultPerfGateBaseline
+	}
+
+	return &PerfGate{Threshold: threshold, Baseline: baseline}
+}
+
+// FindRegressions compares metrics with baseline executions average and returns regressed metrics descriptions
+func (g PerfGate) FindRegressions(metrics map[string]float64, baseline []testkube.Execution) (regressions []string) {
+	names := make([]string, 0, len(metrics))
+	for name := range metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var sum float64
+		var count int
+		for _, execution := range baseline {
+			if execution.ExecutionResult == nil {
+				continue
+			}
+
+			if value, ok := execution.ExecutionResult.Metrics[name]; ok {
+				sum += value
+				count++
+			}
+		}
+
+		if count == 0 || sum <= 0 {
+			continue
+		}
+
+		average := sum / float64(count)
+		change := (metrics[name] - average) / average * 100
+		if change > g.Threshold {
+			regressions = append(regressions, fmt.Sprintf("%s regressed by %.2f%% (%g vs baseline %g)", name, change, metrics[name], average))
+		}
+	}
+
+	return regressions
+}
+
+// applyPerfGate fails passed execution result when its metrics regress versus baseline of last successful executions
+func (c *JobClient) applyPerfGate(ctx context.Context, repo result.Repository, execution testkube.Execution, executionResult testkube.ExecutionResult) testkube.ExecutionResult {
+	gate := GetPerfGate(execution.Labels)
+	if gate == nil || executionResult.Status == nil || !executionResult.IsPassed() || len(executionResult.Metrics) == 0 {
+		return executionResult
+	}
+
+	filter := result.NewExecutionsFilter().
+		WithTestName(execution.TestName).
+		WithStatus(string(testkube.PASSED_ExecutionStatus)).
+		WithPageSize(gate.Baseline)
+
+	baseline, err := repo.GetExecutions(ctx, filter)
+	if err != nil {
+		c.Log.Errorw("getting performance baseline executions", "executionId", execution.Id, "error", err)
+		return executionResult
+	}
+
+	regressions := gate.FindRegressions(executionResult.Metrics, baseline)
+	if len(regressions) == 0 {
+		return executionResult
+	}
+
+	c.Log.Infow("performance regression detected", "executionId", execution.Id, "regressions", regressions)
+	return executionResult.Err(fmt.Errorf("performance regression: %s", strings.Join(regressions, ", ")))
+}
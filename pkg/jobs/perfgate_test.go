@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestGetPerfGate(t *testing.T) {
+
+	t.Run("disabled without threshold", func(t *testing.T) {
+		assert.Nil(t, GetPerfGate(map[string]string{testkube.PerfGateBaselineLabel: "3"}))
+	})
+
+	t.Run("default baseline", func(t *testing.T) {
+		gate := GetPerfGate(map[string]string{testkube.PerfGateThresholdLabel: "10"})
+
+		assert.Equal(t, &PerfGate{Threshold: 10, Baseline: defaultPerfGateBaseline}, gate)
+	})
+
+	t.Run("custom baseline", func(t *testing.T) {
+		gate := GetPerfGate(map[string]string{testkube.PerfGateThresholdLabel: "10", testkube.PerfGateBaselineLabel: "3"})
+
+		assert.Equal(t, &PerfGate{Threshold: 10, Baseline: 3}, gate)
+	})
+}
+
+func TestPerfGate_FindRegressions(t *testing.T) {
+	baseline := []testkube.Execution{
+		{ExecutionResult: &testkube.ExecutionResult{Metrics: map[string]float64{"p95_latency": 100, "error_rate": 0.01}}},
+		{ExecutionResult: &testkube.ExecutionResult{Metrics: map[string]float64{"p95_latency": 120}}},
+		{ExecutionResult: &testkube.ExecutionResult{}},
+	}
+
+	gate := PerfGate{Threshold: 10, Baseline: 3}
+
+	t.Run("metrics within threshold", func(t *testing.T) {
+		regressions := gate.FindRegressions(map[string]float64{"p95_latency": 120, "error_rate": 0.011}, baseline)
+
+		assert.Empty(t, regressions)
+	})
+
+	t.Run("regressed metrics", func(t *testing.T) {
+		regressions := gate.FindRegressions(map[string]float64{"p95_latency": 150, "error_rate": 0.02, "p99_latency": 500}, baseline)
+
+		assert.Equal(t, []string{
+			"error_rate regressed by 100.00% (0.02 vs baseline 0.01)",
+			"p95_latency regressed by 36.36% (150 vs baseline 110)",
+		}, regressions)
+	})
+}
@@ -0,0 +1,143 @@
+package jobs
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"go.uber.org/zap"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// ValidateTemplatePlacement checks that placement doesn't conflict with node selector and required node affinity of
+// job template, template is rendered with empty job options and templates which can't be rendered without execution
+// are checked when job is created
+func ValidateTemplatePlacement(jobTemplate string, placement *testkube.Placement) error {
+	if jobTemplate == "" || placement == nil {
+		return nil
+	}
+
+	job, err := NewJobSpec(zap.NewNop().Sugar(), JobOptions{JobTemplate: jobTemplate})
+	if err != nil {
+		return nil
+	}
+
+	return checkTemplatePlacement(job.Spec.Template.Spec, placement)
+}
+
+// checkTemplatePlacement checks that nodes can match placement together with node selector and one of required node
+// selector terms of job template pod spec, terms are ORed, so placement conflicts only when it conflicts with all of them
+func checkTemplatePlacement(spec corev1.PodSpec, placement *testkube.Placement) error {
+	if placement == nil {
+		return nil
+	}
+
+	terms := []corev1.NodeSelectorTerm{{}}
+	if spec.Affinity != nil && spec.Affinity.NodeAffinity != nil {
+		if required := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil &&
+			len(required.NodeSelectorTerms) > 0 {
+			terms = required.NodeSelectorTerms
+		}
+	}
+
+	var err error
+	for _, term := range terms {
+		template := &testkube.Placement{NodeSelector: spec.NodeSelector}
+		for _, expression := range term.MatchExpressions {
+			template.NodeAffinity = append(template.NodeAffinity, testkube.PlacementRequirement{
+				Key:      expression.Key,
+				Operator: string(expression.Operator),
+				Values:   expression.Values,
+			})
+		}
+
+		if _, err = testkube.MergePlacements(template, placement); err == nil {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("job template conflicts with placement: %w", err)
+}
+
+// applyPlacement merges placement into pod spec of job template, node selector of template is kept and placement
+// node affinity requirements are added to every required template node selector term, pods of test executions are
+// spread by their test name label
+func applyPlacement(spec *corev1.PodSpec, placement *testkube.Placement, testName string) error {
+	if placement == nil {
+		return nil
+	}
+
+	// template node selector and node affinity conflicts are validated the same way as executor and test placement ones
+	if err := checkTemplatePlacement(*spec, placement); err != nil {
+		return err
+	}
+
+	for key, value := range placement.NodeSelector {
+		if spec.NodeSelector == nil {
+			spec.NodeSelector = map[string]string{}
+		}
+		spec.NodeSelector[key] = value
+	}
+
+	if len(placement.NodeAffinity) > 0 {
+		var requirements []corev1.NodeSelectorRequirement
+		for _, requirement := range placement.NodeAffinity {
+			requirements = append(requirements, corev1.NodeSelectorRequirement{
+				Key:      requirement.Key,
+				Operator: corev1.NodeSelectorOperator(requirement.Operator),
+				Values:   requirement.Values,
+			})
+		}
+
+		if spec.Affinity == nil {
+			spec.Affinity = &corev1.Affinity{}
+		}
+
+		if spec.Affinity.NodeAffinity == nil {
+			spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+		}
+
+		required := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+		if required == nil || len(required.NodeSelectorTerms) == 0 {
+			required = &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{}}}
+			spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = required
+		}
+
+		// terms are ORed, so requirements have to be part of each of them
+		for i := range required.NodeSelectorTerms {
+			required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, requirements...)
+		}
+	}
+
+	for _, toleration := range placement.Tolerations {
+		spec.Tolerations = append(spec.Tolerations, corev1.Toleration{
+			Key:      toleration.Key,
+			Operator: corev1.TolerationOperator(toleration.Operator),
+			Value:    toleration.Value,
+			Effect:   corev1.TaintEffect(toleration.Effect),
+		})
+	}
+
+	for _, spread := range placement.TopologySpread {
+		constraint := corev1.TopologySpreadConstraint{
+			MaxSkew:           spread.MaxSkew,
+			TopologyKey:       spread.TopologyKey,
+			WhenUnsatisfiable: corev1.UnsatisfiableConstraintAction(spread.WhenUnsatisfiable),
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{TestNameLabel: testName}},
+		}
+
+		if constraint.MaxSkew == 0 {
+			constraint.MaxSkew = 1
+		}
+
+		if constraint.WhenUnsatisfiable == "" {
+			constraint.WhenUnsatisfiable = corev1.DoNotSchedule
+		}
+
+		spec.TopologySpreadConstraints = append(spec.TopologySpreadConstraints, constraint)
+	}
+
+	return nil
+}
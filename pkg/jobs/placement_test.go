@@ -0,0 +1,111 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestApplyPlacement(t *testing.T) {
+	placement := &testkube.Placement{
+		NodeSelector:   map[string]string{"nvidia.com/gpu.present": "true"},
+		NodeAffinity:   []testkube.PlacementRequirement{{Key: "topology.kubernetes.io/zone", Operator: "In", Values: []string{"europe-west1-b"}}},
+		Tolerations:    []testkube.PlacementToleration{{Key: "nvidia.com/gpu", Operator: "Exists", Effect: "NoSchedule"}},
+		TopologySpread: []testkube.PlacementTopologySpread{{TopologyKey: "kubernetes.io/hostname"}},
+	}
+
+	t.Run("placement is merged into job template", func(t *testing.T) {
+		spec := corev1.PodSpec{
+			NodeSelector: map[string]string{"pool": "tests"},
+			Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "arch", Operator: corev1.NodeSelectorOpIn, Values: []string{"amd64"}}}},
+					{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "arch", Operator: corev1.NodeSelectorOpIn, Values: []string{"arm64"}}}},
+				}},
+			}},
+		}
+
+		require.NoError(t, applyPlacement(&spec, placement, "gpu-test"))
+
+		assert.Equal(t, map[string]string{"pool": "tests", "nvidia.com/gpu.present": "true"}, spec.NodeSelector)
+		for _, term := range spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			assert.Len(t, term.MatchExpressions, 2)
+			assert.Equal(t, "topology.kubernetes.io/zone", term.MatchExpressions[1].Key)
+		}
+
+		assert.Equal(t, []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}},
+			spec.Tolerations)
+		require.Len(t, spec.TopologySpreadConstraints, 1)
+		assert.Equal(t, int32(1), spec.TopologySpreadConstraints[0].MaxSkew)
+		assert.Equal(t, corev1.DoNotSchedule, spec.TopologySpreadConstraints[0].WhenUnsatisfiable)
+		assert.Equal(t, "gpu-test", spec.TopologySpreadConstraints[0].LabelSelector.MatchLabels[TestNameLabel])
+	})
+
+	t.Run("node affinity is added to template without affinity", func(t *testing.T) {
+		var spec corev1.PodSpec
+		require.NoError(t, applyPlacement(&spec, placement, "gpu-test"))
+
+		terms := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		require.Len(t, terms, 1)
+		assert.Len(t, terms[0].MatchExpressions, 1)
+	})
+
+	t.Run("template node selector conflicts", func(t *testing.T) {
+		spec := corev1.PodSpec{NodeSelector: map[string]string{"nvidia.com/gpu.present": "false"}}
+
+		assert.Error(t, applyPlacement(&spec, placement, "gpu-test"))
+	})
+
+	t.Run("template node affinity conflicts with all terms", func(t *testing.T) {
+		zone := func(zone string) corev1.NodeSelectorTerm {
+			return corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: "topology.kubernetes.io/zone", Operator: corev1.NodeSelectorOpIn, Values: []string{zone}},
+			}}
+		}
+		spec := corev1.PodSpec{Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				zone("us-east1-b"), zone("us-east1-c"),
+			}},
+		}}}
+
+		err := applyPlacement(&spec, placement, "gpu-test")
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "topology.kubernetes.io/zone")
+		}
+
+		// placement can be matched with one of terms
+		spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[1] = zone("europe-west1-b")
+		assert.NoError(t, applyPlacement(&spec, placement, "gpu-test"))
+	})
+}
+
+func TestValidateTemplatePlacement(t *testing.T) {
+	jobTemplate := `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: "{{ .Name }}"
+spec:
+  template:
+    spec:
+      nodeSelector:
+        pool: tests
+      containers:
+        - name: "{{ .Name }}"
+          image: "{{ .Image }}"
+`
+
+	assert.NoError(t, ValidateTemplatePlacement(jobTemplate, nil))
+	assert.NoError(t, ValidateTemplatePlacement(jobTemplate, &testkube.Placement{NodeSelector: map[string]string{"gpu": "true"}}))
+	assert.NoError(t, ValidateTemplatePlacement("", &testkube.Placement{NodeSelector: map[string]string{"pool": "gpu"}}))
+
+	err := ValidateTemplatePlacement(jobTemplate, &testkube.Placement{NodeAffinity: []testkube.PlacementRequirement{
+		{Key: "pool", Operator: "NotIn", Values: []string{"tests"}},
+	}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "job template conflicts with placement")
+	}
+}
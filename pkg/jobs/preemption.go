@@ -0,0 +1,189 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const (
+	// disruptionTargetCondition is a pod condition set by kubernetes on pods deleted due to node disruptions
+	disruptionTargetCondition = "DisruptionTarget"
+	// podDeletedReason is a preemption reason of pods deleted while their job is still running
+	podDeletedReason = "PodDeleted"
+)
+
+// podPreemptionReasons are pod status reasons set by kubelet on pods of shut down or lost nodes
+var podPreemptionReasons = map[string]bool{
+	"Shutdown":     true,
+	"NodeShutdown": true,
+	"Terminated":   true,
+	"NodeLost":     true,
+}
+
+var reschedulesCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "testkube_executions_reschedules_count",
+	Help: "The total number of test executions rescheduled after executor pod was preempted",
+}, []string{"name", "reason"})
+
+// ReschedulePolicy is a policy of rescheduling executions which executor pods were preempted, e.g. on spot nodes
+type ReschedulePolicy struct {
+	// MaxReschedules is a number of times execution is rescheduled, 0 fails preempted executions
+	MaxReschedules int `envconfig:"MAX_RESCHEDULES" default:"2"`
+}
+
+// DetectPodPreemption returns reason of pod preempted by node disruption, empty string when pod wasn't preempted
+func DetectPodPreemption(pod corev1.Pod) string {
+	if pod.Status.Phase == corev1.PodSucceeded {
+		return ""
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == disruptionTargetCondition && condition.Status == corev1.ConditionTrue {
+			return condition.Reason
+		}
+	}
+
+	if podPreemptionReasons[pod.Status.Reason] {
+		return pod.Status.Reason
+	}
+
+	if pod.DeletionTimestamp != nil {
+		return podDeletedReason
+	}
+
+	return ""
+}
+
+// getPodPreemption returns attempt of preempted executor pod, pods of deleted jobs (e.g. aborted) aren't preempted
+func (c *JobClient) getPodPreemption(ctx context.Context, jobName, podName string) (testkube.ExecutionAttempt, bool) {
+	attempt := testkube.ExecutionAttempt{PodName: podName, Time: time.Now()}
+	pod, err := c.ClientSet.CoreV1().Pods(c.Namespace).Get(ctx, podName, metav1.GetOptions{})
+	switch {
+	case k8serrors.IsNotFound(err):
+		attempt.Reason = podDeletedReason
+	case err != nil:
+		c.Log.Errorw("getting executor pod status", "pod", podName, "error", err)
+		return attempt, false
+	default:
+		attempt.NodeName = pod.Spec.NodeName
+		attempt.Reason = DetectPodPreemption(*pod)
+	}
+
+	if attempt.Reason == "" {
+		return attempt, false
+	}
+
+	job, err := c.ClientSet.BatchV1().Jobs(c.Namespace).Get(ctx, jobName, metav1.GetOptions{})
+	if err != nil || job.DeletionTimestamp != nil {
+		return attempt, false
+	}
+
+	return attempt, true
+}
+
+// waitForPod waits for executor pod to finish, job is relaunched when pod was preempted until reschedules limit
+// is reached, name of last pod and attempt of preempted pod which wasn't rescheduled are returned
+func (c *JobClient) waitForPod(ctx context.Context, repo result.Repository, execution testkube.Execution,
+	options JobOptions, podName string) (string, *testkube.ExecutionAttempt) {
+	l := c.Log.With("executionId", execution.Id)
+	for number := 1; ; number++ {
+		if err := wait.PollImmediate(pollInterval, pollTimeout, IsPodReady(c.ClientSet, podName, c.Namespace)); err != nil {
+			// continue on poll err and try to get logs later
+			l.Errorw("waiting for pod complete error", "error", err)
+		}
+
+		attempt, preempted := c.getPodPreemption(ctx, execution.Id, podName)
+		if !preempted {
+			return podName, nil
+		}
+
+		attempt.Number = int32(number)
+		if err := repo.AddAttempt(ctx, execution.Id, attempt); err != nil {
+			l.Errorw("saving execution attempt", "error", err)
+		}
+
+		if number > c.reschedulePolicy.MaxReschedules || isExecutionAborted(ctx, repo, execution.Id) {
+			return podName, &attempt
+		}
+
+		l.Infow("executor pod preempted, rescheduling execution", "pod", podName, "node", attempt.NodeName,
+			"reason", attempt.Reason, "attempt", number)
+		reschedulesCount.With(map[string]string{
+			"name":   execution.TestName,
+			"reason": attempt.Reason,
+		}).Inc()
+
+		newPodName, err := c.relaunchJob(ctx, options)
+		if err != nil {
+			l.Errorw("rescheduling execution", "error", err)
+			return podName, &attempt
+		}
+		podName = newPodName
+	}
+}
+
+// relaunchJob deletes job of preempted pod and creates it again, name of new job pod is returned
+func (c *JobClient) relaunchJob(ctx context.Context, options JobOptions) (string, error) {
+	jobs := c.ClientSet.BatchV1().Jobs(c.Namespace)
+	foreground := metav1.DeletePropagationForeground
+	err := jobs.Delete(ctx, options.Name, metav1.DeleteOptions{PropagationPolicy: &foreground})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return "", fmt.Errorf("can't delete job of preempted pod: %w", err)
+	}
+
+	err = wait.PollImmediate(pollInterval, pollTimeout, func() (bool, error) {
+		_, err := jobs.Get(ctx, options.Name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return "", fmt.Errorf("waiting for job of preempted pod deletion: %w", err)
+	}
+
+	jobSpec, err := NewJobSpec(c.Log, options)
+	if err != nil {
+		return "", err
+	}
+
+	job, err := jobs.Create(ctx, jobSpec, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("job create error: %w", err)
+	}
+
+	if err = c.createFilesSecret(ctx, job, options.Files); err != nil {
+		return "", err
+	}
+
+	pods, err := c.GetJobPods(c.ClientSet.CoreV1().Pods(c.Namespace), options.Name, 1, 10)
+	if err != nil {
+		return "", fmt.Errorf("get job pods error: %w", err)
+	}
+
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("job %s has no pods", options.Name)
+	}
+
+	return pods.Items[0].Name, nil
+}
+
+// preemptedResult returns failed result of execution which preempted executor pod wasn't rescheduled
+func preemptedResult(attempt testkube.ExecutionAttempt) testkube.ExecutionResult {
+	result := testkube.NewErrorExecutionResult(fmt.Errorf("executor pod %s was preempted (%s), execution was rescheduled %d times. "+
+		"Hint: raise TESTKUBE_JOB_MAX_RESCHEDULES or run the test on on-demand nodes", attempt.PodName, attempt.Reason,
+		attempt.Number-1))
+	result.ErrorType = testkube.ErrorTypePreempted
+	return result
+}
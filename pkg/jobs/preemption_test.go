@@ -0,0 +1,54 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestDetectPodPreemption(t *testing.T) {
+	deleted := metav1.Now()
+	tests := map[string]struct {
+		pod    corev1.Pod
+		reason string
+	}{
+		"disruption target": {
+			pod: corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed, Conditions: []corev1.PodCondition{
+				{Type: disruptionTargetCondition, Status: corev1.ConditionTrue, Reason: "TerminationByKubelet"},
+			}}},
+			reason: "TerminationByKubelet",
+		},
+		"node shutdown": {
+			pod:    corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Shutdown"}},
+			reason: "Shutdown",
+		},
+		"deleted pod": {
+			pod:    corev1.Pod{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &deleted}, Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+			reason: podDeletedReason,
+		},
+		"succeeded pod": {
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &deleted}, Status: corev1.PodStatus{Phase: corev1.PodSucceeded}},
+		},
+		"failed pod": {
+			pod: corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.reason, DetectPodPreemption(test.pod))
+		})
+	}
+}
+
+func TestPreemptedResult(t *testing.T) {
+	result := preemptedResult(testkube.ExecutionAttempt{Number: 3, PodName: "example-pod", Reason: "Shutdown"})
+
+	assert.True(t, result.IsFailed())
+	assert.Equal(t, testkube.ErrorTypePreempted, result.ErrorType)
+	assert.Contains(t, result.ErrorMessage, "example-pod was preempted (Shutdown), execution was rescheduled 2 times")
+}
@@ -0,0 +1,96 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/output"
+)
+
+const (
+	// InstanceLabel is a job label with API server instance watching the job
+	InstanceLabel = "testkube.io/api-instance"
+	// RequestIDAnnotation is a job and pod annotation with id of API request which started the execution
+	RequestIDAnnotation = "testkube.io/request-id"
+)
+
+// ReconcileExecution updates result of execution which job isn't watched anymore, e.g. after API server restart,
+// false is returned when execution job is still running or is watched by active API server instance
+func (c *JobClient) ReconcileExecution(ctx context.Context, repo result.Repository, execution testkube.Execution,
+	isInstanceActive func(instanceID string) bool) (reconciled bool, err error) {
+	job, err := c.ClientSet.BatchV1().Jobs(c.Namespace).Get(ctx, execution.Id, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return false, fmt.Errorf("getting execution job: %w", err)
+	}
+
+	endTime := time.Now()
+	var executionResult testkube.ExecutionResult
+	switch {
+	case err != nil:
+		executionResult = testkube.ExecutionResult{
+			Status:       testkube.ExecutionStatusAborted,
+			ErrorMessage: fmt.Sprintf("execution job %s not found, it was deleted or not created before API server restart", execution.Id),
+		}
+	case !IsJobFinished(*job) || IsJobWatched(*job, isInstanceActive):
+		return false, nil
+	default:
+		if job.Status.CompletionTime != nil {
+			endTime = job.Status.CompletionTime.Time
+		}
+		executionResult = c.recoverJobResult(ctx, repo, execution)
+	}
+
+	c.Log.Infow("reconciling orphaned execution", "executionId", execution.Id, "status", executionResult.Status)
+	if err = c.saveResult(ctx, repo, execution.Id, executionResult); err != nil {
+		return false, fmt.Errorf("saving execution result: %w", err)
+	}
+
+	execution.EndTime = endTime
+	if err = repo.EndExecution(ctx, execution.Id, execution.EndTime, execution.CalculateDuration()); err != nil {
+		return false, fmt.Errorf("saving execution end time: %w", err)
+	}
+
+	return true, nil
+}
+
+// recoverJobResult reads execution result from logs of finished job pod
+func (c *JobClient) recoverJobResult(ctx context.Context, repo result.Repository, execution testkube.Execution) testkube.ExecutionResult {
+	executionResult := testkube.NewPendingExecutionResult()
+	pods, err := c.ClientSet.CoreV1().Pods(c.Namespace).List(ctx, metav1.ListOptions{LabelSelector: "job-name=" + execution.Id})
+	if err != nil {
+		return executionResult.Err(fmt.Errorf("can't recover result of execution job %s: %w", execution.Id, err))
+	}
+
+	if len(pods.Items) == 0 {
+		return executionResult.Err(fmt.Errorf("can't recover result of execution job %s: job pods not found", execution.Id))
+	}
+
+	podName := pods.Items[len(pods.Items)-1].Name
+	logs, err := c.GetPodLogs(podName)
+	if err != nil {
+		return c.applyPodTermination(ctx, execution, podName,
+			executionResult.Err(fmt.Errorf("can't recover result of execution job %s: %w", execution.Id, err)))
+	}
+
+	executionResult, _, err = output.ParseRunnerOutput(logs)
+	if err != nil {
+		return c.applyPodTermination(ctx, execution, podName,
+			executionResult.Err(fmt.Errorf("can't recover result of execution job %s: %w", execution.Id, err)))
+	}
+
+	executionResult = c.applyPodTermination(ctx, execution, podName, executionResult)
+	return c.applyPerfGate(ctx, repo, execution, executionResult)
+}
+
+// IsJobWatched checks if API server instance which launched the job is still active
+func IsJobWatched(job batchv1.Job, isInstanceActive func(instanceID string) bool) bool {
+	instanceID, ok := job.Labels[InstanceLabel]
+	return ok && isInstanceActive(instanceID)
+}
@@ -0,0 +1,31 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsJobWatched(t *testing.T) {
+	isInstanceActive := func(instanceID string) bool {
+		return instanceID == "api-1"
+	}
+
+	tests := map[string]struct {
+		labels  map[string]string
+		watched bool
+	}{
+		"active instance":   {labels: map[string]string{InstanceLabel: "api-1"}, watched: true},
+		"inactive instance": {labels: map[string]string{InstanceLabel: "api-2"}, watched: false},
+		"no instance label": {labels: map[string]string{TestNameLabel: "test"}, watched: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			job := batchv1.Job{ObjectMeta: metav1.ObjectMeta{Labels: tt.labels}}
+			assert.Equal(t, tt.watched, IsJobWatched(job, isInstanceActive))
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const podEvictedReason = "Evicted"
+
+var podTerminationsCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "testkube_executions_pod_terminations_count",
+	Help: "The total number of test executions with executor pod terminated by kubernetes",
+}, []string{"name", "error_type"})
+
+// PodTermination is a reason of executor pod terminated by kubernetes
+type PodTermination struct {
+	ErrorType string
+	Message   string
+}
+
+// DetectPodTermination returns reason of pod killed or evicted by kubernetes, nil when pod wasn't terminated
+func DetectPodTermination(pod corev1.Pod) *PodTermination {
+	if pod.Status.Reason == podEvictedReason {
+		return &PodTermination{
+			ErrorType: testkube.ErrorTypeEvicted,
+			Message: fmt.Sprintf("executor pod %s was evicted: %s. Hint: increase memory and ephemeral-storage requests in executor job template",
+				pod.Name, pod.Status.Message),
+		}
+	}
+
+	var statuses []corev1.ContainerStatus
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		for _, state := range []corev1.ContainerState{status.State, status.LastTerminationState} {
+			if state.Terminated != nil && state.Terminated.Reason == testkube.ErrorTypeOOMKilled {
+				return &PodTermination{
+					ErrorType: testkube.ErrorTypeOOMKilled,
+					Message: fmt.Sprintf("executor container %s was OOMKilled. Hint: increase container memory limit in executor job template",
+						status.Name),
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyPodTermination fails execution result when executor pod was killed or evicted
+func (c *JobClient) applyPodTermination(ctx context.Context, execution testkube.Execution, podName string, executionResult testkube.ExecutionResult) testkube.ExecutionResult {
+	pod, err := c.ClientSet.CoreV1().Pods(c.Namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		c.Log.Errorw("getting executor pod status", "executionId", execution.Id, "error", err)
+		return executionResult
+	}
+
+	termination := DetectPodTermination(*pod)
+	if termination == nil {
+		return executionResult
+	}
+
+	podTerminationsCount.With(map[string]string{
+		"name":       execution.TestName,
+		"error_type": termination.ErrorType,
+	}).Inc()
+
+	c.Log.Infow("executor pod terminated", "executionId", execution.Id, "errorType", termination.ErrorType)
+	executionResult.ErrorType = termination.ErrorType
+	return executionResult.Err(errors.New(termination.Message))
+}
@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestDetectPodTermination(t *testing.T) {
+	t.Run("evicted pod", func(t *testing.T) {
+		termination := DetectPodTermination(corev1.Pod{Status: corev1.PodStatus{Reason: "Evicted", Message: "The node was low on resource: memory."}})
+
+		assert.NotNil(t, termination)
+		assert.Equal(t, testkube.ErrorTypeEvicted, termination.ErrorType)
+		assert.Contains(t, termination.Message, "The node was low on resource: memory.")
+	})
+
+	t.Run("OOMKilled container", func(t *testing.T) {
+		termination := DetectPodTermination(corev1.Pod{Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "executor",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+			}},
+		}})
+
+		assert.NotNil(t, termination)
+		assert.Equal(t, testkube.ErrorTypeOOMKilled, termination.ErrorType)
+		assert.Contains(t, termination.Message, "executor")
+	})
+
+	t.Run("failed container", func(t *testing.T) {
+		assert.Nil(t, DetectPodTermination(corev1.Pod{Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "executor",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+			}},
+		}}))
+	})
+}
@@ -0,0 +1,105 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubeshop/testkube/internal/pkg/api/repository/result"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// timingBuckets are histogram buckets of platform timings from 0.25s to about 17 minutes
+var timingBuckets = prometheus.ExponentialBuckets(0.25, 2, 13)
+
+var queueDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "testkube_executions_queue_duration_seconds",
+	Help:    "The time test executions spent in queue before executor job was created",
+	Buckets: timingBuckets,
+}, []string{"name"})
+
+var schedulingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "testkube_executions_pod_scheduling_duration_seconds",
+	Help:    "The time executor pods of test executions waited to be scheduled to node",
+	Buckets: timingBuckets,
+}, []string{"name"})
+
+var contentFetchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "testkube_executions_content_fetch_duration_seconds",
+	Help:    "The time executor init containers of executor pods fetched test content",
+	Buckets: timingBuckets,
+}, []string{"name"})
+
+// PodTimings returns time pod waited to be scheduled and time its executor init container of initImage fetched test
+// content, other init containers of job template are left out, zero durations are returned for missing conditions and
+// executor init container which didn't finish
+func PodTimings(pod corev1.Pod, initImage string) (scheduling, contentFetch time.Duration) {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionTrue &&
+			condition.LastTransitionTime.After(pod.CreationTimestamp.Time) {
+			scheduling = condition.LastTransitionTime.Sub(pod.CreationTimestamp.Time)
+		}
+	}
+
+	var initContainer string
+	for _, container := range pod.Spec.InitContainers {
+		if container.Image == initImage {
+			initContainer = container.Name
+			break
+		}
+	}
+
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.Name != initContainer {
+			continue
+		}
+
+		if terminated := status.State.Terminated; terminated != nil && terminated.FinishedAt.After(terminated.StartedAt.Time) {
+			contentFetch = terminated.FinishedAt.Sub(terminated.StartedAt.Time)
+		}
+	}
+
+	return scheduling, contentFetch
+}
+
+// saveTimings records time execution spent in queue according to its status history and timings of its last executor
+// pod, they are observed in metrics too
+func (c *JobClient) saveTimings(ctx context.Context, repo result.Repository, execution testkube.Execution, podName string) {
+	l := c.Log.With("executionId", execution.Id)
+	labels := map[string]string{"name": execution.TestName}
+	var timings testkube.ExecutionTimings
+	if stored, err := repo.Get(ctx, execution.Id); err != nil {
+		l.Errorw("getting execution status history", "error", err)
+	} else if queued := stored.StatusDuration(testkube.QUEUED_ExecutionStatus); queued > 0 {
+		timings.QueueDuration = queued.String()
+		queueDuration.With(labels).Observe(queued.Seconds())
+	}
+
+	pod, err := c.ClientSet.CoreV1().Pods(c.Namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		l.Errorw("getting executor pod timings", "error", err)
+	} else {
+		scheduling, contentFetch := PodTimings(*pod, c.initImage)
+		if scheduling > 0 {
+			timings.SchedulingDuration = scheduling.String()
+			schedulingDuration.With(labels).Observe(scheduling.Seconds())
+		}
+
+		if contentFetch > 0 {
+			timings.ContentFetchDuration = contentFetch.String()
+			contentFetchDuration.With(labels).Observe(contentFetch.Seconds())
+		}
+	}
+
+	if timings == (testkube.ExecutionTimings{}) {
+		return
+	}
+
+	if err = repo.UpdateTimings(ctx, execution.Id, timings); err != nil {
+		l.Errorw("saving execution timings", "error", err)
+	}
+}
@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodTimings(t *testing.T) {
+	created := time.Date(2022, 6, 1, 8, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) metav1.Time { return metav1.NewTime(created.Add(d)) }
+
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: at(0)},
+		Spec: corev1.PodSpec{InitContainers: []corev1.Container{
+			{Name: "setup", Image: "busybox"},
+			{Name: "init", Image: "kubeshop/testkube-executor-init:0.7.10"},
+		}},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodInitialized, Status: corev1.ConditionTrue, LastTransitionTime: at(10 * time.Second)},
+				{Type: corev1.PodScheduled, Status: corev1.ConditionTrue, LastTransitionTime: at(2 * time.Second)},
+			},
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{Name: "setup", State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{StartedAt: at(3 * time.Second), FinishedAt: at(5 * time.Second)},
+				}},
+				{Name: "init", State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{StartedAt: at(5 * time.Second), FinishedAt: at(8 * time.Second)},
+				}},
+			},
+		},
+	}
+
+	scheduling, contentFetch := PodTimings(pod, "kubeshop/testkube-executor-init:0.7.10")
+	assert.Equal(t, 2*time.Second, scheduling)
+	assert.Equal(t, 3*time.Second, contentFetch)
+
+	// pod waiting for node with running init container
+	pod.Status.Conditions[1].Status = corev1.ConditionFalse
+	pod.Status.InitContainerStatuses[1].State = corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: at(5 * time.Second)}}
+	scheduling, contentFetch = PodTimings(pod, "kubeshop/testkube-executor-init:0.7.10")
+	assert.Zero(t, scheduling)
+	assert.Zero(t, contentFetch)
+
+	// job template without executor init container
+	_, contentFetch = PodTimings(pod, "kubeshop/testkube-executor-init:0.7.11")
+	assert.Zero(t, contentFetch)
+}
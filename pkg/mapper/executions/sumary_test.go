@@ -23,6 +23,7 @@ func TestMapToSummary(t *testing.T) {
 		assert.Equal(t, result[i].Status, executions[i].ExecutionResult.Status)
 		assert.Equal(t, result[i].StartTime, executions[i].StartTime)
 		assert.Equal(t, result[i].EndTime, executions[i].EndTime)
+		assert.Equal(t, result[i].Labels, executions[i].Labels)
 	}
 }
 
@@ -7,13 +7,18 @@ func MapToSummary(executions []testkube.Execution) []testkube.ExecutionSummary {
 	result := make([]testkube.ExecutionSummary, len(executions))
 	for i, s := range executions {
 		result[i] = testkube.ExecutionSummary{
-			Id:        s.Id,
-			Name:      s.Name,
-			TestName:  s.TestName,
-			TestType:  s.TestType,
-			Status:    s.ExecutionResult.Status,
-			StartTime: s.StartTime,
-			EndTime:   s.EndTime,
+			Id:              s.Id,
+			Name:            s.Name,
+			Number:          s.Number,
+			TestName:        s.TestName,
+			TestType:        s.TestType,
+			Status:          s.ExecutionResult.Status,
+			StartTime:       s.StartTime,
+			EndTime:         s.EndTime,
+			Duration:        s.Duration,
+			Labels:          s.Labels,
+			ContentChecksum: s.ContentChecksum,
+			ContentChanged:  s.ContentChanged,
 		}
 	}
 
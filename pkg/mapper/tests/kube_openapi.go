@@ -1,6 +1,8 @@
 package tests
 
 import (
+	"encoding/json"
+
 	testsv2 "github.com/kubeshop/testkube-operator/apis/tests/v2"
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 )
@@ -19,24 +21,115 @@ func MapTestListKubeToAPI(crTests testsv2.TestList) (tests []testkube.Test) {
 func MapTestCRToAPI(crTest testsv2.Test) (test testkube.Test) {
 	test.Name = crTest.Name
 	test.Content = MapTestContentFromSpec(crTest.Spec.Content)
+	test.Content.UriOptions = MapContentUriOptionsFromAnnotations(crTest.Annotations)
 	test.Created = crTest.CreationTimestamp.Time
 	test.Type_ = crTest.Spec.Type_
 	test.Labels = crTest.Labels
 	test.Params = crTest.Spec.Params
 	test.Schedule = crTest.Spec.Schedule
+	test.SecretMounts = MapSecretMountsFromAnnotations(crTest.Annotations)
+	test.DataFile = MapDataFileFromAnnotations(crTest.Annotations)
+	test.SecretParams = MapSecretParamsFromAnnotations(crTest.Annotations)
+	test.Ownership = testkube.OwnershipFromAnnotations(crTest.Annotations)
+	test.MaintenanceWindows = testkube.MaintenanceWindowsFromAnnotations(crTest.Annotations)
+	test.Placement = testkube.PlacementFromAnnotations(crTest.Annotations)
+	test.LogCollection = testkube.LogCollectionFromAnnotations(crTest.Annotations)
+	test.Prechecks = testkube.PrechecksFromAnnotations(crTest.Annotations)
+	test.Dependencies = testkube.DependenciesFromAnnotations(crTest.Annotations)
+	test.Newman = testkube.NewmanOptionsFromAnnotations(crTest.Annotations)
+	test.Triggers = testkube.ExecutionTriggersFromAnnotations(crTest.Annotations)
+	enabled := !testkube.IsDisabled(crTest.Labels)
+	test.Enabled = &enabled
 	return
 }
 
+// MapDataFileFromAnnotations maps test CRD annotations to OpenAPI spec iteration data file
+func MapDataFileFromAnnotations(annotations map[string]string) (dataFile *testkube.TestContent) {
+	data := annotations[testkube.DataFileAnnotation]
+	if data == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &dataFile); err != nil {
+		return nil
+	}
+
+	return dataFile
+}
+
+// MapSecretMountsFromAnnotations maps test CRD annotations to OpenAPI spec secret mounts
+func MapSecretMountsFromAnnotations(annotations map[string]string) (secretMounts []testkube.SecretMount) {
+	data := annotations[testkube.SecretMountsAnnotation]
+	if data == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &secretMounts); err != nil {
+		return nil
+	}
+
+	return secretMounts
+}
+
+// MapSecretParamsFromAnnotations maps test CRD annotations to OpenAPI spec secret param names
+func MapSecretParamsFromAnnotations(annotations map[string]string) (secretParams []string) {
+	data := annotations[testkube.SecretParamsAnnotation]
+	if data == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &secretParams); err != nil {
+		return nil
+	}
+
+	return secretParams
+}
+
+// MapContentUriOptionsFromAnnotations maps test CRD annotations to OpenAPI spec file URI content options
+func MapContentUriOptionsFromAnnotations(annotations map[string]string) (options *testkube.ContentUriOptions) {
+	data := annotations[testkube.ContentUriOptionsAnnotation]
+	if data == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &options); err != nil {
+		return nil
+	}
+
+	return options
+}
+
+// mapRepositoryFromSpec maps CRD repository to OpenAPI spec Repository
+func mapRepositoryFromSpec(repository *testsv2.Repository) *testkube.Repository {
+	if repository == nil {
+		return nil
+	}
+
+	return &testkube.Repository{
+		Type_:    repository.Type_,
+		Uri:      repository.Uri,
+		Branch:   repository.Branch,
+		Path:     repository.Path,
+		Username: repository.Username,
+		Token:    repository.Token,
+	}
+}
+
 // MapTestContentFromSpec maps CRD to OpenAPI spec TestContent
 func MapTestContentFromSpec(specContent *testsv2.TestContent) *testkube.TestContent {
 	content := &testkube.TestContent{
-		Type_: specContent.Type_,
-		// assuming same data structure - there is task about syncing them automatically
-		// https://github.com/kubeshop/testkube/issues/723
-		Repository: (*testkube.Repository)(specContent.Repository),
+		Type_:      specContent.Type_,
+		Repository: mapRepositoryFromSpec(specContent.Repository),
 		Data:       specContent.Data,
 		Uri:        specContent.Uri,
 	}
 
+	// inline files are stored in data of files content
+	if testkube.TestContentType(content.Type_) == testkube.TestContentTypeFiles {
+		if err := json.Unmarshal([]byte(specContent.Data), &content.Files); err == nil {
+			content.Data = ""
+		}
+	}
+
 	return content
 }
@@ -1,6 +1,8 @@
 package tests
 
 import (
+	"encoding/json"
+
 	testsv2 "github.com/kubeshop/testkube-operator/apis/tests/v2"
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -8,12 +10,27 @@ import (
 
 // MapToSpec maps TestUpsertRequest to Test CRD spec
 func MapToSpec(request testkube.TestUpsertRequest) *testsv2.Test {
+	annotations := mergeAnnotations(
+		MapSecretMountsToAnnotations(request.SecretMounts),
+		MapDataFileToAnnotations(request.DataFile),
+		MapSecretParamsToAnnotations(request.SecretParams),
+		MapContentUriOptionsToAnnotations(request.Content),
+		request.Ownership.Annotations(),
+		testkube.MaintenanceWindowsAnnotations(request.MaintenanceWindows),
+		testkube.PlacementAnnotations(request.Placement),
+		testkube.LogCollectionAnnotations(request.LogCollection),
+		testkube.PrechecksAnnotations(request.Prechecks),
+		testkube.DependenciesAnnotations(request.Dependencies),
+		testkube.NewmanOptionsAnnotations(request.Newman),
+		testkube.ExecutionTriggersAnnotations(request.Triggers),
+	)
 
 	test := &testsv2.Test{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      request.Name,
-			Namespace: request.Namespace,
-			Labels:    request.Labels,
+			Name:        request.Name,
+			Namespace:   request.Namespace,
+			Labels:      testkube.WithEnabledLabel(request.Labels, request.Enabled),
+			Annotations: annotations,
 		},
 		Spec: testsv2.TestSpec{
 			Type_:    request.Type_,
@@ -27,17 +44,120 @@ func MapToSpec(request testkube.TestUpsertRequest) *testsv2.Test {
 
 }
 
+// MapSecretMountsToAnnotations maps OpenAPI spec secret mounts to test CRD annotations
+func MapSecretMountsToAnnotations(secretMounts []testkube.SecretMount) map[string]string {
+	if len(secretMounts) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(secretMounts)
+	if err != nil {
+		return nil
+	}
+
+	return map[string]string{testkube.SecretMountsAnnotation: string(data)}
+}
+
+// MapDataFileToAnnotations maps OpenAPI spec iteration data file to test CRD annotations, git credentials
+// aren't stored in annotations, so private data file repositories use test git credentials
+func MapDataFileToAnnotations(dataFile *testkube.TestContent) map[string]string {
+	if dataFile == nil {
+		return nil
+	}
+
+	stored := *dataFile
+	if stored.Repository != nil {
+		repository := *stored.Repository
+		repository.Username, repository.Token = "", ""
+		stored.Repository = &repository
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return nil
+	}
+
+	return map[string]string{testkube.DataFileAnnotation: string(data)}
+}
+
+// MapSecretParamsToAnnotations maps OpenAPI spec secret param names to test CRD annotations
+func MapSecretParamsToAnnotations(secretParams []string) map[string]string {
+	if len(secretParams) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(secretParams)
+	if err != nil {
+		return nil
+	}
+
+	return map[string]string{testkube.SecretParamsAnnotation: string(data)}
+}
+
+// MapContentUriOptionsToAnnotations maps OpenAPI spec file URI content options to test CRD annotations
+func MapContentUriOptionsToAnnotations(content *testkube.TestContent) map[string]string {
+	if content == nil || content.UriOptions == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(content.UriOptions)
+	if err != nil {
+		return nil
+	}
+
+	return map[string]string{testkube.ContentUriOptionsAnnotation: string(data)}
+}
+
+func mergeAnnotations(annotations ...map[string]string) map[string]string {
+	var result map[string]string
+	for _, items := range annotations {
+		for k, v := range items {
+			if result == nil {
+				result = map[string]string{}
+			}
+			result[k] = v
+		}
+	}
+
+	return result
+}
+
 // MapContentToSpecContent maps TestContent OpenAPI spec to TestContent CRD spec
 func MapContentToSpecContent(content *testkube.TestContent) (specContent *testsv2.TestContent) {
 	if content == nil {
 		return
 	}
 
+	data := content.Data
+	// test content spec has no files field, so inline files are stored in data
+	if testkube.TestContentType(content.Type_) == testkube.TestContentTypeFiles {
+		files, err := json.Marshal(content.Files)
+		if err != nil {
+			return nil
+		}
+		data = string(files)
+	}
+
 	return &testsv2.TestContent{
-		// assuming same data structure
-		Repository: (*testsv2.Repository)(content.Repository),
-		Data:       content.Data,
+		Repository: mapRepositoryToSpec(content.Repository),
+		Data:       data,
 		Uri:        content.Uri,
 		Type_:      content.Type_,
 	}
 }
+
+// mapRepositoryToSpec maps OpenAPI spec Repository to CRD repository, pinned commit is execution only
+func mapRepositoryToSpec(repository *testkube.Repository) *testsv2.Repository {
+	if repository == nil {
+		return nil
+	}
+
+	return &testsv2.Repository{
+		Type_:    repository.Type_,
+		Uri:      repository.Uri,
+		Branch:   repository.Branch,
+		Path:     repository.Path,
+		Username: repository.Username,
+		Token:    repository.Token,
+	}
+}
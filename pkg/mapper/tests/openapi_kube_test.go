@@ -0,0 +1,17 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestMapContentFiles(t *testing.T) {
+	content := &testkube.TestContent{Type_: string(testkube.TestContentTypeFiles), Files: map[string]string{"script.js": "1", "lib/helpers.js": "2"}}
+
+	spec := MapContentToSpecContent(content)
+	assert.Equal(t, `{"lib/helpers.js":"2","script.js":"1"}`, spec.Data)
+	assert.Equal(t, content, MapTestContentFromSpec(spec))
+}
@@ -36,6 +36,12 @@ func MapCRToAPI(cr testsuitesv1.TestSuite) (test testkube.TestSuite) {
 	test.Labels = cr.Labels
 	test.Schedule = cr.Spec.Schedule
 	test.Params = cr.Spec.Params
+	setStepsParams(&test, cr.Annotations[testkube.StepParamsAnnotation])
+	setStepsOptions(&test, cr.Annotations[testkube.StepOptionsAnnotation])
+	test.Ownership = testkube.OwnershipFromAnnotations(cr.Annotations)
+	test.Triggers = testkube.ExecutionTriggersFromAnnotations(cr.Annotations)
+	enabled := !testkube.IsDisabled(cr.Labels)
+	test.Enabled = &enabled
 
 	return
 }
@@ -53,6 +59,12 @@ func mapCRStepToAPI(crstep testsuitesv1.TestSuiteStepSpec) (teststep testkube.Te
 			},
 		}
 
+	// approval options are set from step options annotation, approval without them waits for decision forever
+	case crstep.Delay != nil && crstep.Delay.Duration == approvalStepDelay:
+		teststep = testkube.TestSuiteStep{
+			Approval: &testkube.TestSuiteStepApproval{},
+		}
+
 	case crstep.Delay != nil:
 		teststep = testkube.TestSuiteStep{
 			Delay: &testkube.TestSuiteStepDelay{
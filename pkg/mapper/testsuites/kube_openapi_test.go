@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	testsuitesv1 "github.com/kubeshop/testkube-operator/apis/testsuite/v1"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestMapTestSuiteListKubeToAPI(t *testing.T) {
@@ -46,3 +48,183 @@ func TestMapTestSuiteListKubeToAPI(t *testing.T) {
 	assert.Equal(t, 1, len(openAPITest.Before))
 	assert.Equal(t, 1, len(openAPITest.After))
 }
+
+func TestMapStepParams(t *testing.T) {
+	steps := []testkube.TestSuiteStep{
+		{Delay: &testkube.TestSuiteStepDelay{Duration: 1000}},
+		{Execute: &testkube.TestSuiteStepExecuteTest{Name: "some-test-name", Params: map[string]string{"users": "3"}}},
+	}
+
+	annotation := MapStepParamsToAnnotation(nil, steps, nil)
+	assert.Equal(t, `{"steps":{"some-test-name":{"users":"3"}}}`, annotation)
+
+	openAPITest := MapCRToAPI(
+		testsuitesv1.TestSuite{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{testkube.StepParamsAnnotation: annotation},
+			},
+			Spec: testsuitesv1.TestSuiteSpec{
+				Steps: []testsuitesv1.TestSuiteStepSpec{
+					{Delay: &testsuitesv1.TestSuiteStepDelay{Duration: 1000}},
+					{Execute: &testsuitesv1.TestSuiteStepExecute{Name: "some-test-name"}},
+				},
+			},
+		},
+	)
+
+	assert.Equal(t, map[string]string{"users": "3"}, openAPITest.Steps[1].Execute.Params)
+	assert.Empty(t, MapStepParamsToAnnotation(nil, openAPITest.Steps[:1], nil))
+}
+
+func TestMapStepParamsReorderedSteps(t *testing.T) {
+	annotation := MapStepParamsToAnnotation(nil, []testkube.TestSuiteStep{
+		{Execute: &testkube.TestSuiteStepExecuteTest{Name: "api", Params: map[string]string{"users": "3"}}},
+		{Execute: &testkube.TestSuiteStepExecuteTest{Name: "ui"}},
+		{Execute: &testkube.TestSuiteStepExecuteTest{Name: "api", Params: map[string]string{"users": "100"}}},
+	}, nil)
+
+	// steps are reordered and step is inserted in custom resource
+	openAPITest := MapCRToAPI(
+		testsuitesv1.TestSuite{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{testkube.StepParamsAnnotation: annotation},
+			},
+			Spec: testsuitesv1.TestSuiteSpec{
+				Steps: []testsuitesv1.TestSuiteStepSpec{
+					{Delay: &testsuitesv1.TestSuiteStepDelay{Duration: 1000}},
+					{Execute: &testsuitesv1.TestSuiteStepExecute{Name: "ui"}},
+					{Execute: &testsuitesv1.TestSuiteStepExecute{Name: "smoke"}},
+					{Execute: &testsuitesv1.TestSuiteStepExecute{Name: "api"}},
+					{Execute: &testsuitesv1.TestSuiteStepExecute{Name: "api"}},
+				},
+			},
+		},
+	)
+
+	assert.Empty(t, openAPITest.Steps[1].Execute.Params)
+	assert.Empty(t, openAPITest.Steps[2].Execute.Params)
+	assert.Equal(t, map[string]string{"users": "3"}, openAPITest.Steps[3].Execute.Params)
+	assert.Equal(t, map[string]string{"users": "100"}, openAPITest.Steps[4].Execute.Params)
+}
+
+func TestMapIndexedStepParams(t *testing.T) {
+	openAPITest := MapCRToAPI(
+		testsuitesv1.TestSuite{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{testkube.StepParamsAnnotation: `{"steps":[null,{"users":"3"}]}`},
+			},
+			Spec: testsuitesv1.TestSuiteSpec{
+				Steps: []testsuitesv1.TestSuiteStepSpec{
+					{Delay: &testsuitesv1.TestSuiteStepDelay{Duration: 1000}},
+					{Execute: &testsuitesv1.TestSuiteStepExecute{Name: "some-test-name"}},
+				},
+			},
+		},
+	)
+
+	assert.Equal(t, map[string]string{"users": "3"}, openAPITest.Steps[1].Execute.Params, "annotations of older versions are read")
+}
+
+func TestMapStepOptions(t *testing.T) {
+	steps := []testkube.TestSuiteStep{
+		{Delay: &testkube.TestSuiteStepDelay{Duration: 1000}},
+		{Execute: &testkube.TestSuiteStepExecuteTest{Name: "some-test-name"}, Timeout: 60, Retries: 2, ContinueOnError: true, Condition: "failure()"},
+	}
+
+	annotation := MapStepOptionsToAnnotation(nil, steps, nil)
+	assert.Equal(t, `{"steps":[{},{"timeout":60,"retries":2,"continueOnError":true,"condition":"failure()"}]}`, annotation)
+
+	openAPITest := MapCRToAPI(
+		testsuitesv1.TestSuite{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{testkube.StepOptionsAnnotation: annotation},
+			},
+			Spec: testsuitesv1.TestSuiteSpec{
+				Steps: []testsuitesv1.TestSuiteStepSpec{
+					{Delay: &testsuitesv1.TestSuiteStepDelay{Duration: 1000}},
+					{Execute: &testsuitesv1.TestSuiteStepExecute{Name: "some-test-name"}},
+				},
+			},
+		},
+	)
+
+	assert.Equal(t, int32(60), openAPITest.Steps[1].Timeout)
+	assert.Equal(t, int32(2), openAPITest.Steps[1].Retries)
+	assert.True(t, openAPITest.Steps[1].ContinueOnError)
+	assert.Equal(t, "failure()", openAPITest.Steps[1].Condition)
+	assert.Empty(t, MapStepOptionsToAnnotation(nil, openAPITest.Steps[:1], nil))
+}
+
+func TestMapApprovalStep(t *testing.T) {
+	request := testkube.TestSuiteUpsertRequest{
+		Name: "release",
+		Steps: []testkube.TestSuiteStep{
+			{Execute: &testkube.TestSuiteStepExecuteTest{Name: "some-test-name"}},
+			{Approval: &testkube.TestSuiteStepApproval{Message: "Deploy?", Timeout: 600}},
+		},
+	}
+
+	cr := MapTestSuiteUpsertRequestToTestCRD(request)
+	assert.NotNil(t, cr.Spec.Steps[1].Delay, "approval step is stored as delay")
+
+	openAPITest := MapCRToAPI(cr)
+	assert.Nil(t, openAPITest.Steps[1].Delay)
+	assert.Equal(t, &testkube.TestSuiteStepApproval{Message: "Deploy?", Timeout: 600}, openAPITest.Steps[1].Approval)
+	assert.Equal(t, testkube.TestSuiteStepTypeApproval, openAPITest.Steps[1].Type())
+}
+
+func TestMapApprovalStepWithoutOptions(t *testing.T) {
+	cr := MapTestSuiteUpsertRequestToTestCRD(testkube.TestSuiteUpsertRequest{
+		Name:  "release",
+		Steps: []testkube.TestSuiteStep{{Approval: &testkube.TestSuiteStepApproval{Message: "Deploy?"}}},
+	})
+	delete(cr.Annotations, testkube.StepOptionsAnnotation)
+
+	openAPITest := MapCRToAPI(cr)
+	assert.Equal(t, testkube.TestSuiteStepTypeApproval, openAPITest.Steps[0].Type(), "approval step isn't run as delay")
+}
+
+func TestValidateStepOptions(t *testing.T) {
+	cr := MapTestSuiteUpsertRequestToTestCRD(testkube.TestSuiteUpsertRequest{
+		Name:  "release",
+		Steps: []testkube.TestSuiteStep{{Approval: &testkube.TestSuiteStepApproval{Message: "Deploy?"}}},
+	})
+	assert.NoError(t, ValidateStepOptions(cr))
+	assert.NoError(t, ValidateStepOptions(testsuitesv1.TestSuite{}))
+
+	cr.Annotations[testkube.StepOptionsAnnotation] = `{"steps":[{"approval":`
+	err := ValidateStepOptions(cr)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid testkube.io/step-options annotation of test suite release")
+	}
+}
+
+func TestMapOwnership(t *testing.T) {
+	ownership := &testkube.Ownership{Owner: "jane", Team: "payments"}
+	cr := MapTestSuiteUpsertRequestToTestCRD(testkube.TestSuiteUpsertRequest{Name: "smoke", Ownership: ownership})
+
+	assert.Equal(t, map[string]string{testkube.OwnerAnnotation: "jane", testkube.TeamAnnotation: "payments"}, cr.Annotations)
+	assert.Equal(t, ownership, MapCRToAPI(cr).Ownership)
+	assert.Nil(t, MapCRToAPI(testsuitesv1.TestSuite{}).Ownership)
+}
+
+func TestMapEnabled(t *testing.T) {
+	disabled := false
+	cr := MapTestSuiteUpsertRequestToTestCRD(testkube.TestSuiteUpsertRequest{Name: "smoke", Labels: map[string]string{"team": "payments"}, Enabled: &disabled})
+
+	assert.Equal(t, map[string]string{"team": "payments", testkube.DisabledLabel: "true"}, cr.Labels)
+	assert.Equal(t, &disabled, MapCRToAPI(cr).Enabled)
+
+	enabled := true
+	cr = MapTestSuiteUpsertRequestToTestCRD(testkube.TestSuiteUpsertRequest{Name: "smoke", Labels: cr.Labels, Enabled: &enabled})
+	assert.Equal(t, map[string]string{"team": "payments"}, cr.Labels)
+	assert.Equal(t, &enabled, MapCRToAPI(cr).Enabled)
+}
+
+func TestMapTriggers(t *testing.T) {
+	triggers := []testkube.ExecutionTrigger{{Type_: testkube.ExecutionTriggerTypeTestSuite, Name: "e2e", Params: map[string]string{"env": "staging"}}}
+	cr := MapTestSuiteUpsertRequestToTestCRD(testkube.TestSuiteUpsertRequest{Name: "smoke", Triggers: triggers})
+
+	assert.Equal(t, `[{"type":"testsuite","name":"e2e","params":{"env":"staging"}}]`, cr.Annotations[testkube.TriggersAnnotation])
+	assert.Equal(t, triggers, MapCRToAPI(cr).Triggers)
+}
@@ -0,0 +1,84 @@
+package testsuites
+
+import (
+	testsuitesv1 "github.com/kubeshop/testkube-operator/apis/testsuite/v1"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MapTestSuiteUpsertRequestToTestCRD maps TestSuiteUpsertRequest to TestSuite CRD
+func MapTestSuiteUpsertRequestToTestCRD(request testkube.TestSuiteUpsertRequest) testsuitesv1.TestSuite {
+	annotations := request.Ownership.Annotations()
+	if stepParams := MapStepParamsToAnnotation(request.Before, request.Steps, request.After); stepParams != "" {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[testkube.StepParamsAnnotation] = stepParams
+	}
+
+	if stepOptions := MapStepOptionsToAnnotation(request.Before, request.Steps, request.After); stepOptions != "" {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[testkube.StepOptionsAnnotation] = stepOptions
+	}
+
+	for k, v := range testkube.ExecutionTriggersAnnotations(request.Triggers) {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[k] = v
+	}
+
+	return testsuitesv1.TestSuite{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        request.Name,
+			Namespace:   request.Namespace,
+			Labels:      testkube.WithEnabledLabel(request.Labels, request.Enabled),
+			Annotations: annotations,
+		},
+		Spec: testsuitesv1.TestSuiteSpec{
+			Repeats:     int(request.Repeats),
+			Description: request.Description,
+			Before:      mapTestStepsToCRD(request.Before),
+			Steps:       mapTestStepsToCRD(request.Steps),
+			After:       mapTestStepsToCRD(request.After),
+			Schedule:    request.Schedule,
+			Params:      request.Params,
+		},
+	}
+}
+
+func mapTestStepsToCRD(steps []testkube.TestSuiteStep) (out []testsuitesv1.TestSuiteStepSpec) {
+	for _, step := range steps {
+		out = append(out, mapTestStepToCRD(step))
+	}
+
+	return
+}
+
+func mapTestStepToCRD(step testkube.TestSuiteStep) (stepSpec testsuitesv1.TestSuiteStepSpec) {
+	switch step.Type() {
+
+	case testkube.TestSuiteStepTypeDelay:
+		stepSpec.Delay = &testsuitesv1.TestSuiteStepDelay{
+			Duration: step.Delay.Duration,
+		}
+
+	// approval step is stored as no-op delay with step options annotation, as test suite step spec has no approval field,
+	// delay duration marks it as approval step, so it isn't run as delay when annotation is lost
+	case testkube.TestSuiteStepTypeApproval:
+		stepSpec.Delay = &testsuitesv1.TestSuiteStepDelay{Duration: approvalStepDelay}
+
+	case testkube.TestSuiteStepTypeExecuteTest:
+		s := step.Execute
+		stepSpec.Execute = &testsuitesv1.TestSuiteStepExecute{
+			Namespace: s.Namespace,
+			Name:      s.Name,
+			// TODO move StopOnFailure level up in operator model to mimic this one
+			StopOnFailure: step.StopTestOnFailure,
+		}
+	}
+
+	return
+}
@@ -0,0 +1,115 @@
+package testsuites
+
+import (
+	"encoding/json"
+	"fmt"
+
+	testsuitesv1 "github.com/kubeshop/testkube-operator/apis/testsuite/v1"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// approvalStepDelay is a delay duration of approval steps stored in test suite spec
+const approvalStepDelay int32 = -1
+
+// stepOptions are step timeout, retries, error handling, condition and approval stored in test suite annotation
+type stepOptions struct {
+	Timeout         int32                           `json:"timeout,omitempty"`
+	Retries         int32                           `json:"retries,omitempty"`
+	ContinueOnError bool                            `json:"continueOnError,omitempty"`
+	Condition       string                          `json:"condition,omitempty"`
+	Approval        *testkube.TestSuiteStepApproval `json:"approval,omitempty"`
+}
+
+// stepsOptions are per step options, indexes match test suite steps
+type stepsOptions struct {
+	Before []stepOptions `json:"before,omitempty"`
+	Steps  []stepOptions `json:"steps,omitempty"`
+	After  []stepOptions `json:"after,omitempty"`
+}
+
+// MapStepOptionsToAnnotation maps test suite steps options to annotation value, returns empty string when no step has options
+func MapStepOptionsToAnnotation(before, steps, after []testkube.TestSuiteStep) string {
+	options := stepsOptions{
+		Before: getStepsOptions(before),
+		Steps:  getStepsOptions(steps),
+		After:  getStepsOptions(after),
+	}
+
+	if options.Before == nil && options.Steps == nil && options.After == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(options)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+// ValidateStepOptions checks that test suite step options annotation can be read, test suites which step options
+// can't be read aren't executed, as their steps would run without conditions and approvals
+func ValidateStepOptions(cr testsuitesv1.TestSuite) error {
+	var options stepsOptions
+	if annotation := cr.Annotations[testkube.StepOptionsAnnotation]; annotation != "" {
+		if err := json.Unmarshal([]byte(annotation), &options); err != nil {
+			return fmt.Errorf("invalid %s annotation of test suite %s: %w", testkube.StepOptionsAnnotation, cr.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// setStepsOptions sets options of test suite steps from annotation value, invalid annotation is rejected by
+// ValidateStepOptions before test suite is executed
+func setStepsOptions(test *testkube.TestSuite, annotation string) {
+	if annotation == "" {
+		return
+	}
+
+	var options stepsOptions
+	if err := json.Unmarshal([]byte(annotation), &options); err != nil {
+		return
+	}
+
+	setStepOptions(test.Before, options.Before)
+	setStepOptions(test.Steps, options.Steps)
+	setStepOptions(test.After, options.After)
+}
+
+func getStepsOptions(steps []testkube.TestSuiteStep) []stepOptions {
+	var options []stepOptions
+	hasOptions := false
+	for _, step := range steps {
+		option := stepOptions{
+			Timeout:         step.Timeout,
+			Retries:         step.Retries,
+			ContinueOnError: step.ContinueOnError,
+			Condition:       step.Condition,
+			Approval:        step.Approval,
+		}
+		hasOptions = hasOptions || option != stepOptions{}
+		options = append(options, option)
+	}
+
+	if !hasOptions {
+		return nil
+	}
+
+	return options
+}
+
+func setStepOptions(steps []testkube.TestSuiteStep, options []stepOptions) {
+	for i := range steps {
+		if i < len(options) {
+			steps[i].Timeout = options[i].Timeout
+			steps[i].Retries = options[i].Retries
+			steps[i].ContinueOnError = options[i].ContinueOnError
+			steps[i].Condition = options[i].Condition
+			if options[i].Approval != nil {
+				steps[i].Approval = options[i].Approval
+				steps[i].Delay = nil
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package testsuites
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// stepParams are per step params stored in test suite annotation keyed by step keys, so params stay with their
+// steps when steps are inserted, removed or reordered
+type stepParams struct {
+	Before map[string]map[string]string `json:"before,omitempty"`
+	Steps  map[string]map[string]string `json:"steps,omitempty"`
+	After  map[string]map[string]string `json:"after,omitempty"`
+}
+
+// indexedStepParams are per step params stored by older versions, indexes match test suite steps
+type indexedStepParams struct {
+	Before []map[string]string `json:"before,omitempty"`
+	Steps  []map[string]string `json:"steps,omitempty"`
+	After  []map[string]string `json:"after,omitempty"`
+}
+
+// MapStepParamsToAnnotation maps test suite steps params to annotation value, returns empty string when no step has params
+func MapStepParamsToAnnotation(before, steps, after []testkube.TestSuiteStep) string {
+	params := stepParams{
+		Before: getStepsParams(before),
+		Steps:  getStepsParams(steps),
+		After:  getStepsParams(after),
+	}
+
+	if params.Before == nil && params.Steps == nil && params.After == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+// setStepsParams sets params of test suite steps from annotation value, annotations of older versions with params
+// matched by step index are read too
+func setStepsParams(test *testkube.TestSuite, annotation string) {
+	if annotation == "" {
+		return
+	}
+
+	var params stepParams
+	if err := json.Unmarshal([]byte(annotation), &params); err == nil {
+		setStepParams(test.Before, params.Before)
+		setStepParams(test.Steps, params.Steps)
+		setStepParams(test.After, params.After)
+		return
+	}
+
+	var indexed indexedStepParams
+	if err := json.Unmarshal([]byte(annotation), &indexed); err != nil {
+		return
+	}
+
+	setIndexedStepParams(test.Before, indexed.Before)
+	setIndexedStepParams(test.Steps, indexed.Steps)
+	setIndexedStepParams(test.After, indexed.After)
+}
+
+// getStepKeys returns keys of test execution steps, key is a test name and occurrence of the test in steps
+// for repeated tests, e.g. api, api#2
+func getStepKeys(steps []testkube.TestSuiteStep) []string {
+	keys := make([]string, len(steps))
+	occurrences := map[string]int{}
+	for i, step := range steps {
+		if step.Execute == nil {
+			continue
+		}
+
+		occurrences[step.Execute.Name]++
+		keys[i] = step.Execute.Name
+		if occurrence := occurrences[step.Execute.Name]; occurrence > 1 {
+			keys[i] = fmt.Sprintf("%s#%d", step.Execute.Name, occurrence)
+		}
+	}
+
+	return keys
+}
+
+func getStepsParams(steps []testkube.TestSuiteStep) map[string]map[string]string {
+	var params map[string]map[string]string
+	for i, key := range getStepKeys(steps) {
+		if key == "" || len(steps[i].Execute.Params) == 0 {
+			continue
+		}
+
+		if params == nil {
+			params = map[string]map[string]string{}
+		}
+		params[key] = steps[i].Execute.Params
+	}
+
+	return params
+}
+
+func setStepParams(steps []testkube.TestSuiteStep, params map[string]map[string]string) {
+	for i, key := range getStepKeys(steps) {
+		if stepParams, ok := params[key]; ok && key != "" {
+			steps[i].Execute.Params = stepParams
+		}
+	}
+}
+
+func setIndexedStepParams(steps []testkube.TestSuiteStep, params []map[string]string) {
+	for i := range steps {
+		if i < len(params) && steps[i].Execute != nil {
+			steps[i].Execute.Params = params[i]
+		}
+	}
+}
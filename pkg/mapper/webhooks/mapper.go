@@ -1,6 +1,8 @@
 package webhooks
 
 import (
+	"strings"
+
 	executorv1 "github.com/kubeshop/testkube-operator/apis/executor/v1"
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -13,6 +15,10 @@ func MapCRDToAPI(item executorv1.Webhook) testkube.Webhook {
 		Namespace: item.Namespace,
 		Uri:       item.Spec.Uri,
 		Events:    MapStringArrayToCRDEvents(item.Spec.Events),
+		Selector:  item.Annotations[testkube.WebhookSelectorAnnotation],
+		Statuses:  mapStatuses(item.Annotations[testkube.WebhookStatusesAnnotation]),
+		ProxyURL:  item.Annotations[testkube.WebhookProxyAnnotation],
+		TLSSecret: item.Annotations[testkube.WebhookTLSSecretAnnotation],
 		Labels:    item.Labels,
 	}
 }
@@ -27,7 +33,7 @@ func MapStringArrayToCRDEvents(items []string) (events []testkube.WebhookEventTy
 
 // MapAPIToCRD maps OpenAPI spec WebhookCreateRequest to CRD Webhook
 func MapAPIToCRD(request testkube.WebhookCreateRequest) executorv1.Webhook {
-	return executorv1.Webhook{
+	webhook := executorv1.Webhook{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      request.Name,
 			Namespace: request.Namespace,
@@ -38,6 +44,45 @@ func MapAPIToCRD(request testkube.WebhookCreateRequest) executorv1.Webhook {
 			Events: MapEventTypesToStringArray(request.Events),
 		},
 	}
+
+	// webhook spec has no filter and connection fields, so they are stored in annotations
+	annotations := map[string]string{}
+	if request.Selector != "" {
+		annotations[testkube.WebhookSelectorAnnotation] = request.Selector
+	}
+
+	if len(request.Statuses) != 0 {
+		statuses := make([]string, len(request.Statuses))
+		for i, status := range request.Statuses {
+			statuses[i] = string(status)
+		}
+		annotations[testkube.WebhookStatusesAnnotation] = strings.Join(statuses, ",")
+	}
+
+	if request.ProxyURL != "" {
+		annotations[testkube.WebhookProxyAnnotation] = request.ProxyURL
+	}
+
+	if request.TLSSecret != "" {
+		annotations[testkube.WebhookTLSSecretAnnotation] = request.TLSSecret
+	}
+
+	if len(annotations) != 0 {
+		webhook.Annotations = annotations
+	}
+
+	return webhook
+}
+
+func mapStatuses(annotation string) (statuses []testkube.ExecutionStatus) {
+	if annotation == "" {
+		return nil
+	}
+
+	for _, status := range strings.Split(annotation, ",") {
+		statuses = append(statuses, testkube.ExecutionStatus(strings.TrimSpace(status)))
+	}
+	return
 }
 
 // MapEventTypesToStringArray maps OpenAPI spec list of WebhookEventType to string array
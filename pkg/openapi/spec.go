@@ -0,0 +1,251 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	schemaRefPrefix    = "#/components/schemas/"
+	parameterRefPrefix = "#/components/parameters/"
+)
+
+// Spec is a subset of OpenAPI 3 document used for serving and request validation
+type Spec struct {
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+
+	document []byte
+}
+
+// Components are reusable schemas and parameters of OpenAPI document
+type Components struct {
+	Schemas    map[string]*Schema    `json:"schemas"`
+	Parameters map[string]*Parameter `json:"parameters"`
+}
+
+// PathItem are operations of single path
+type PathItem struct {
+	Parameters []*Parameter `json:"parameters"`
+	Get        *Operation   `json:"get"`
+	Post       *Operation   `json:"post"`
+	Put        *Operation   `json:"put"`
+	Patch      *Operation   `json:"patch"`
+	Delete     *Operation   `json:"delete"`
+}
+
+// Operation is API operation of path and method
+type Operation struct {
+	OperationID string       `json:"operationId"`
+	Parameters  []*Parameter `json:"parameters"`
+	RequestBody *RequestBody `json:"requestBody"`
+}
+
+// Parameter is path, query or header parameter of operation
+type Parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody is request body of operation by content type
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// MediaType is schema of content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a subset of JSON schema used by OpenAPI document
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Enum                 []interface{}      `json:"enum"`
+	Nullable             bool               `json:"nullable"`
+	Required             []string           `json:"required"`
+	Properties           map[string]*Schema `json:"properties"`
+	AdditionalProperties *Schema            `json:"additionalProperties"`
+	Items                *Schema            `json:"items"`
+	AllOf                []*Schema          `json:"allOf"`
+}
+
+// Load parses YAML or JSON OpenAPI document
+func Load(data []byte) (*Spec, error) {
+	document, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing openapi document: %w", err)
+	}
+
+	spec := &Spec{document: document}
+	if err = json.Unmarshal(document, spec); err != nil {
+		return nil, fmt.Errorf("decoding openapi document: %w", err)
+	}
+
+	return spec, nil
+}
+
+// JSON returns OpenAPI document in JSON format
+func (s *Spec) JSON() []byte {
+	return s.document
+}
+
+// Schema returns component schema by name, nil is returned for unknown schema
+func (s *Spec) Schema(name string) *Schema {
+	return s.Components.Schemas[name]
+}
+
+// Resolve returns schema referenced by schema $ref
+func (s *Spec) Resolve(schema *Schema) (*Schema, error) {
+	for schema != nil && schema.Ref != "" {
+		resolved, ok := s.Components.Schemas[strings.TrimPrefix(schema.Ref, schemaRefPrefix)]
+		if !ok || !strings.HasPrefix(schema.Ref, schemaRefPrefix) {
+			return nil, fmt.Errorf("unknown schema reference %s", schema.Ref)
+		}
+
+		schema = resolved
+	}
+
+	return schema, nil
+}
+
+// Properties returns properties of schema including properties of all of its allOf schemas
+func (s *Spec) Properties(schema *Schema) (map[string]*Schema, error) {
+	schema, err := s.Resolve(schema)
+	if err != nil || schema == nil {
+		return nil, err
+	}
+
+	properties := map[string]*Schema{}
+	for _, subschema := range schema.AllOf {
+		subproperties, err := s.Properties(subschema)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, property := range subproperties {
+			properties[name] = property
+		}
+	}
+
+	for name, property := range schema.Properties {
+		properties[name] = property
+	}
+
+	return properties, nil
+}
+
+// Find returns operation and its parameters for method and path relative to API version prefix,
+// paths with literal segments are preferred over templated ones, nil is returned for unknown path
+func (s *Spec) Find(method, path string) (*Operation, []*Parameter, error) {
+	segments := splitPath(path)
+	var item *PathItem
+	bestScore := -1
+	for template, candidate := range s.Paths {
+		score, ok := matchPath(splitPath(template), segments)
+		if ok && score > bestScore {
+			candidate := candidate
+			item, bestScore = &candidate, score
+		}
+	}
+
+	if item == nil {
+		return nil, nil, nil
+	}
+
+	operation := item.operation(method)
+	if operation == nil {
+		return nil, nil, nil
+	}
+
+	parameters := map[string]*Parameter{}
+	var names []string
+	for _, parameter := range append(append([]*Parameter{}, item.Parameters...), operation.Parameters...) {
+		parameter, err := s.resolveParameter(parameter)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		key := parameter.In + ":" + parameter.Name
+		if _, ok := parameters[key]; !ok {
+			names = append(names, key)
+		}
+
+		parameters[key] = parameter
+	}
+
+	result := make([]*Parameter, 0, len(names))
+	for _, key := range names {
+		result = append(result, parameters[key])
+	}
+
+	return operation, result, nil
+}
+
+func (s *Spec) resolveParameter(parameter *Parameter) (*Parameter, error) {
+	if parameter.Ref == "" {
+		return parameter, nil
+	}
+
+	resolved, ok := s.Components.Parameters[strings.TrimPrefix(parameter.Ref, parameterRefPrefix)]
+	if !ok || !strings.HasPrefix(parameter.Ref, parameterRefPrefix) {
+		return nil, fmt.Errorf("unknown parameter reference %s", parameter.Ref)
+	}
+
+	return resolved, nil
+}
+
+func (p PathItem) operation(method string) *Operation {
+	switch method {
+	case http.MethodGet:
+		return p.Get
+	case http.MethodPost:
+		return p.Post
+	case http.MethodPut:
+		return p.Put
+	case http.MethodPatch:
+		return p.Patch
+	case http.MethodDelete:
+		return p.Delete
+	}
+
+	return nil
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// matchPath matches path segments to template segments, score is number of matched literal segments
+func matchPath(template, segments []string) (score int, ok bool) {
+	if len(template) != len(segments) {
+		return 0, false
+	}
+
+	for i, segment := range template {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if segments[i] == "" {
+				return 0, false
+			}
+
+			continue
+		}
+
+		if segment != segments[i] {
+			return 0, false
+		}
+
+		score++
+	}
+
+	return score, true
+}
@@ -0,0 +1,256 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// dateFormat is a format of date query parameters
+	dateFormat = "2006-01-02"
+	// jsonContentType is a content type of validated request bodies
+	jsonContentType = "application/json"
+)
+
+// ValidationError lists all problems of validated request
+type ValidationError []string
+
+func (e ValidationError) Error() string {
+	return "invalid request: " + strings.Join(e, "; ")
+}
+
+// ValidateQuery validates query parameters of operation, comma separated values of enum parameters
+// are validated separately
+func (s *Spec) ValidateQuery(parameters []*Parameter, query func(name string) string) error {
+	var problems ValidationError
+	for _, parameter := range parameters {
+		if parameter.In != "query" {
+			continue
+		}
+
+		value := query(parameter.Name)
+		if value == "" {
+			if parameter.Required {
+				problems = append(problems, fmt.Sprintf("query parameter %s is required", parameter.Name))
+			}
+
+			continue
+		}
+
+		schema, err := s.Resolve(parameter.Schema)
+		if err != nil {
+			return err
+		}
+
+		if problem := validateParameter(schema, value); problem != "" {
+			problems = append(problems, fmt.Sprintf("query parameter %s %s", parameter.Name, problem))
+		}
+	}
+
+	if len(problems) > 0 {
+		return problems
+	}
+
+	return nil
+}
+
+// ValidateBody validates JSON request body of operation, empty bodies and other content types aren't validated,
+// required properties aren't checked for partial updates
+func (s *Spec) ValidateBody(operation *Operation, contentType string, body []byte, partial bool) error {
+	if operation == nil || operation.RequestBody == nil || len(body) == 0 ||
+		!strings.HasPrefix(strings.ToLower(contentType), jsonContentType) {
+		return nil
+	}
+
+	media, ok := operation.RequestBody.Content[jsonContentType]
+	if !ok || media.Schema == nil {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return ValidationError{"body is not valid JSON: " + err.Error()}
+	}
+
+	v := validator{spec: s, partial: partial}
+	if err := v.validate("body", media.Schema, value); err != nil {
+		return err
+	}
+
+	if len(v.problems) > 0 {
+		return v.problems
+	}
+
+	return nil
+}
+
+func validateParameter(schema *Schema, value string) string {
+	if schema == nil {
+		return ""
+	}
+
+	switch schema.Type {
+	case "integer":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return "must be an integer"
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "must be a number"
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return "must be a boolean"
+		}
+	case "string":
+		if schema.Format == "date" {
+			if _, err := time.Parse(dateFormat, value); err != nil {
+				return "must be a date in yyyy-mm-dd format"
+			}
+		}
+	}
+
+	if len(schema.Enum) > 0 {
+		for _, item := range strings.Split(value, ",") {
+			if !inEnum(schema.Enum, item) {
+				return fmt.Sprintf("value %s must be one of %s", item, formatEnum(schema.Enum))
+			}
+		}
+	}
+
+	return ""
+}
+
+type validator struct {
+	spec     *Spec
+	partial  bool
+	problems ValidationError
+}
+
+func (v *validator) fail(path, format string, args ...interface{}) {
+	v.problems = append(v.problems, path+" "+fmt.Sprintf(format, args...))
+}
+
+// validate validates decoded JSON value against schema, null values are treated as absent ones and so are
+// empty enum strings which Go clients send for unset fields
+func (v *validator) validate(path string, schema *Schema, value interface{}) error {
+	schema, err := v.spec.Resolve(schema)
+	if err != nil || schema == nil || value == nil {
+		return err
+	}
+
+	for _, subschema := range schema.AllOf {
+		if err = v.validate(path, subschema, value); err != nil {
+			return err
+		}
+	}
+
+	switch schema.Type {
+	case "object":
+		if _, ok := value.(map[string]interface{}); !ok {
+			v.fail(path, "must be an object")
+			return nil
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			v.fail(path, "must be an array")
+			return nil
+		}
+
+		for i, item := range items {
+			if err = v.validate(fmt.Sprintf("%s[%d]", path, i), schema.Items, item); err != nil {
+				return err
+			}
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			v.fail(path, "must be a string")
+			return nil
+		}
+
+		if schema.Format == "date-time" {
+			if _, err = time.Parse(time.RFC3339, text); err != nil {
+				v.fail(path, "must be a date-time in RFC 3339 format")
+			}
+		}
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != math.Trunc(number) {
+			v.fail(path, "must be an integer")
+			return nil
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			v.fail(path, "must be a number")
+			return nil
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.fail(path, "must be a boolean")
+			return nil
+		}
+	}
+
+	if len(schema.Enum) > 0 && value != "" && !inEnum(schema.Enum, value) {
+		v.fail(path, "must be one of %s", formatEnum(schema.Enum))
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	if !v.partial {
+		for _, name := range schema.Required {
+			if object[name] == nil {
+				v.fail(path+"."+name, "is required")
+			}
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := schema.Properties[name]
+		if !ok {
+			property = schema.AdditionalProperties
+		}
+
+		if err = v.validate(path+"."+name, property, object[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, item := range enum {
+		if reflect.DeepEqual(item, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func formatEnum(enum []interface{}) string {
+	items := make([]string, len(enum))
+	for i, item := range enum {
+		items[i] = fmt.Sprint(item)
+	}
+
+	return strings.Join(items, ", ")
+}
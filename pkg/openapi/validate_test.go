@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv1 "github.com/kubeshop/testkube/api/v1"
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func loadSpec(t *testing.T) *Spec {
+	spec, err := Load(apiv1.Spec)
+	require.NoError(t, err)
+	return spec
+}
+
+func TestFind(t *testing.T) {
+	spec := loadSpec(t)
+
+	operation, _, err := spec.Find(http.MethodGet, "/executions/feed")
+	require.NoError(t, err)
+	require.NotNil(t, operation)
+	assert.Equal(t, "getExecutionsFeed", operation.OperationID)
+
+	operation, parameters, err := spec.Find(http.MethodGet, "/tests/api/executions")
+	require.NoError(t, err)
+	require.NotNil(t, operation)
+	assert.Equal(t, "listTestExecutions", operation.OperationID)
+	assert.NotEmpty(t, parameters)
+
+	operation, _, err = spec.Find(http.MethodGet, "/unknown")
+	require.NoError(t, err)
+	assert.Nil(t, operation)
+}
+
+func TestValidateQuery(t *testing.T) {
+	spec := loadSpec(t)
+	_, parameters, err := spec.Find(http.MethodGet, "/executions")
+	require.NoError(t, err)
+
+	validate := func(query string) error {
+		values, err := url.ParseQuery(query)
+		require.NoError(t, err)
+		return spec.ValidateQuery(parameters, values.Get)
+	}
+
+	assert.NoError(t, validate("page=1&pageSize=10&status=failed,aborted&startDate=2022-01-31"))
+	assert.EqualError(t, validate("page=first&status=broken&startDate=31.01.2022"), "invalid request: "+
+		"query parameter page must be an integer; "+
+		"query parameter status value broken must be one of queued, running, passed, failed, aborted, timeout, skipped; "+
+		"query parameter startDate must be a date in yyyy-mm-dd format")
+}
+
+func TestValidateBody(t *testing.T) {
+	spec := loadSpec(t)
+	operation, _, err := spec.Find(http.MethodPost, "/test-suites")
+	require.NoError(t, err)
+
+	err = spec.ValidateBody(operation, "application/json", []byte(`{"name":"suite","namespace":"testkube","steps":[{"stopTestOnFailure":"yes","delay":{"duration":"1s"}}],"labels":{"app":1}}`), false)
+	assert.EqualError(t, err, "invalid request: "+
+		"body.labels.app must be a string; "+
+		"body.steps[0].delay.duration must be an integer; "+
+		"body.steps[0].stopTestOnFailure must be a boolean")
+
+	err = spec.ValidateBody(operation, "application/json", []byte(`{"name":"suite","namespace":null}`), false)
+	assert.EqualError(t, err, "invalid request: body.steps is required; body.namespace is required")
+
+	err = spec.ValidateBody(operation, "application/json", []byte(`{"labels":{"app":"api"}}`), true)
+	assert.NoError(t, err, "required properties aren't checked for partial updates")
+
+	err = spec.ValidateBody(operation, "application/json", []byte(`{"name":`), false)
+	assert.Error(t, err)
+
+	err = spec.ValidateBody(operation, "text/plain", []byte(`not json`), false)
+	assert.NoError(t, err)
+}
+
+func TestValidateModels(t *testing.T) {
+	spec := loadSpec(t)
+	scanStatus := testkube.CLEAN_ArtifactScanStatus
+	bodies := map[string]interface{}{
+		"ExecutionRequest":          testkube.ExecutionRequest{Name: "run", Args: []string{"--verbose"}},
+		"Repository":                testkube.Repository{Uri: "https://github.com/kubeshop/testkube"},
+		"TestSuiteExecutionRequest": testkube.TestSuiteExecutionRequest{Name: "run"},
+		"TestUpsertRequest":         testkube.TestUpsertRequest{Name: "api", Created: time.Now()},
+		"TestSuiteUpsertRequest": testkube.TestSuiteUpsertRequest{Name: "suite", Namespace: "testkube",
+			Steps: []testkube.TestSuiteStep{{Execute: &testkube.TestSuiteStepExecuteTest{Name: "api"}}}},
+		"ArtifactScanResult": testkube.ArtifactScanResult{Name: "report.xml", Status: &scanStatus},
+	}
+
+	for name, value := range bodies {
+		body, err := json.Marshal(value)
+		require.NoError(t, err)
+
+		operation := &Operation{RequestBody: &RequestBody{Content: map[string]MediaType{
+			"application/json": {Schema: &Schema{Ref: schemaRefPrefix + name}},
+		}}}
+		assert.NoError(t, spec.ValidateBody(operation, "application/json", body, false), name)
+	}
+}
@@ -0,0 +1,206 @@
+package plugin
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/client"
+)
+
+const (
+	// Prefix is a prefix of plugin executables, testkube-release-gate is run for `testkube release-gate` command
+	Prefix = "testkube-"
+
+	// environment variables CLI passes its connection settings to plugins with
+	EnvNamespace = "TESTKUBE_NAMESPACE"
+	EnvClient    = "TESTKUBE_CLIENT"
+	EnvAPIURI    = "TESTKUBE_API_URI"
+	EnvAPIToken  = "TESTKUBE_API_TOKEN"
+
+	defaultNamespace = "testkube"
+	defaultClient    = "proxy"
+)
+
+// Plugin is an executable providing CLI subcommand
+type Plugin struct {
+	// Name is a subcommand name, e.g. release-gate
+	Name string
+	// Path is a path of plugin executable
+	Path string
+}
+
+// Settings are connection settings of CLI passed to plugins
+type Settings struct {
+	Namespace string
+	Client    string
+	APIURI    string
+	APIToken  string
+}
+
+// Find returns path of plugin executable for command args and args passed to the plugin, the longest
+// name made of leading args is preferred, so `release gate check` runs testkube-release-gate with check arg
+// when there is no testkube-release-gate-check plugin
+func Find(args []string, lookPath func(file string) (string, error)) (path string, pluginArgs []string, ok bool) {
+	var parts []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") || !validName(arg) {
+			break
+		}
+		parts = append(parts, arg)
+	}
+
+	for i := len(parts); i > 0; i-- {
+		path, err := lookPath(Prefix + strings.Join(parts[:i], "-"))
+		if err == nil {
+			return path, args[i:], true
+		}
+	}
+
+	return "", nil, false
+}
+
+// ParseFlags parses global flags leading plugin name into flags and returns args starting with plugin name,
+// parsing stops on first non-flag arg, so flags after plugin name are left for the plugin
+func ParseFlags(flags *pflag.FlagSet, args []string) ([]string, error) {
+	leading := pflag.NewFlagSet("plugin", pflag.ContinueOnError)
+	leading.AddFlagSet(flags)
+	leading.SetInterspersed(false)
+	leading.Usage = func() {}
+	leading.SetOutput(io.Discard)
+	if err := leading.Parse(args); err != nil {
+		return nil, err
+	}
+
+	return leading.Args(), nil
+}
+
+// List returns plugins found in directories of PATH list, the first one found wins for duplicate names
+func List(pathList string) (plugins []Plugin, err error) {
+	found := map[string]bool{}
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+
+		if err != nil {
+			return plugins, fmt.Errorf("reading plugins directory %s: %w", dir, err)
+		}
+
+		for _, entry := range entries {
+			name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+			if entry.IsDir() || !strings.HasPrefix(name, Prefix) || name == Prefix || found[name] {
+				continue
+			}
+
+			info, err := entry.Info()
+			if err != nil || info.Mode()&0111 == 0 {
+				continue
+			}
+
+			found[name] = true
+			plugins = append(plugins, Plugin{Name: strings.TrimPrefix(name, Prefix), Path: filepath.Join(dir, entry.Name())})
+		}
+	}
+
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins, nil
+}
+
+// Run runs plugin with CLI connection settings and standard streams, exit code of plugin is returned
+func Run(path string, args []string, settings Settings) (int, error) {
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = Env(os.Environ(), settings)
+
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode(), nil
+	}
+
+	if err != nil {
+		return 1, fmt.Errorf("running plugin %s: %w", path, err)
+	}
+
+	return 0, nil
+}
+
+// Env returns environment with connection settings set, settings override variables of environment
+func Env(environ []string, settings Settings) []string {
+	values := map[string]string{
+		EnvNamespace: settings.Namespace,
+		EnvClient:    settings.Client,
+		EnvAPIURI:    settings.APIURI,
+		EnvAPIToken:  settings.APIToken,
+	}
+
+	env := make([]string, 0, len(environ)+len(values))
+	for _, variable := range environ {
+		name, _, _ := strings.Cut(variable, "=")
+		if _, ok := values[name]; !ok {
+			env = append(env, variable)
+		}
+	}
+
+	for _, name := range []string{EnvNamespace, EnvClient, EnvAPIURI, EnvAPIToken} {
+		if values[name] != "" {
+			env = append(env, name+"="+values[name])
+		}
+	}
+
+	return env
+}
+
+// GetSettings returns connection settings CLI passed to plugin, defaults of CLI are used for missing ones
+func GetSettings() Settings {
+	settings := Settings{
+		Namespace: os.Getenv(EnvNamespace),
+		Client:    os.Getenv(EnvClient),
+		APIURI:    os.Getenv(EnvAPIURI),
+		APIToken:  os.Getenv(EnvAPIToken),
+	}
+
+	if settings.Namespace == "" {
+		settings.Namespace = defaultNamespace
+	}
+
+	if settings.Client == "" {
+		settings.Client = defaultClient
+	}
+
+	return settings
+}
+
+// GetClient returns API client configured the same way as CLI which started the plugin
+func GetClient() (client.Client, error) {
+	settings := GetSettings()
+	if settings.APIURI != "" {
+		return client.GetURIClient(settings.APIURI, settings.APIToken)
+	}
+
+	return client.GetClient(client.ClientType(settings.Client), settings.Namespace)
+}
+
+// validName checks if arg can be part of plugin name
+func validName(arg string) bool {
+	for _, r := range arg {
+		if !(r == '-' || r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')) {
+			return false
+		}
+	}
+
+	return arg != ""
+}
@@ -0,0 +1,90 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind(t *testing.T) {
+	lookPath := func(file string) (string, error) {
+		switch file {
+		case "testkube-release", "testkube-release-gate":
+			return "/usr/local/bin/" + file, nil
+		}
+		return "", fmt.Errorf("%s not found", file)
+	}
+
+	path, args, ok := Find([]string{"release", "gate", "check", "--strict"}, lookPath)
+	assert.True(t, ok)
+	assert.Equal(t, "/usr/local/bin/testkube-release-gate", path)
+	assert.Equal(t, []string{"check", "--strict"}, args)
+
+	path, args, ok = Find([]string{"release", "--gate"}, lookPath)
+	assert.True(t, ok)
+	assert.Equal(t, "/usr/local/bin/testkube-release", path)
+	assert.Equal(t, []string{"--gate"}, args)
+
+	_, _, ok = Find([]string{"deploy"}, lookPath)
+	assert.False(t, ok)
+
+	_, _, ok = Find([]string{"--namespace", "release"}, lookPath)
+	assert.False(t, ok, "plugin name can't follow flags")
+
+	_, _, ok = Find([]string{"../release"}, lookPath)
+	assert.False(t, ok)
+}
+
+func TestParseFlags(t *testing.T) {
+	flags := pflag.NewFlagSet("testkube", pflag.ContinueOnError)
+	namespace := flags.StringP("namespace", "s", "testkube", "")
+	verbose := flags.BoolP("verbose", "v", false, "")
+
+	args, err := ParseFlags(flags, []string{"-s", "staging", "--verbose", "release", "gate", "--namespace", "other"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"release", "gate", "--namespace", "other"}, args)
+	assert.Equal(t, "staging", *namespace)
+	assert.True(t, *verbose)
+
+	args, err = ParseFlags(flags, []string{"release"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"release"}, args)
+
+	_, err = ParseFlags(flags, []string{"--unknown", "release"})
+	assert.Error(t, err)
+}
+
+func TestList(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(first, "testkube-release-gate"), []byte("#!/bin/sh"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(second, "testkube-release-gate"), []byte("#!/bin/sh"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(second, "testkube-audit"), []byte("#!/bin/sh"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(second, "testkube-notes"), []byte("not executable"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(second, "kubectl"), []byte("#!/bin/sh"), 0755))
+
+	plugins, err := List(first + string(os.PathListSeparator) + second + string(os.PathListSeparator) + filepath.Join(first, "missing"))
+	require.NoError(t, err)
+	assert.Equal(t, []Plugin{
+		{Name: "audit", Path: filepath.Join(second, "testkube-audit")},
+		{Name: "release-gate", Path: filepath.Join(first, "testkube-release-gate")},
+	}, plugins)
+}
+
+func TestEnv(t *testing.T) {
+	env := Env([]string{"HOME=/root", "TESTKUBE_NAMESPACE=other", "TESTKUBE_API_TOKEN=secret"}, Settings{Namespace: "testkube", Client: "direct"})
+	assert.Equal(t, []string{"HOME=/root", "TESTKUBE_NAMESPACE=testkube", "TESTKUBE_CLIENT=direct"}, env)
+}
+
+func TestGetSettings(t *testing.T) {
+	t.Setenv(EnvNamespace, "")
+	t.Setenv(EnvClient, "")
+	t.Setenv(EnvAPIURI, "https://testkube.example.com")
+	t.Setenv(EnvAPIToken, "token")
+
+	assert.Equal(t, Settings{Namespace: "testkube", Client: "proxy", APIURI: "https://testkube.example.com", APIToken: "token"}, GetSettings())
+}
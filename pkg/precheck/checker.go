@@ -0,0 +1,230 @@
+package precheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/k8sclient"
+)
+
+const (
+	// defaultInterval is a time between evaluations of unmet prechecks
+	defaultInterval = 5 * time.Second
+	// probeTimeout is a timeout of single HTTP probe request
+	probeTimeout = 10 * time.Second
+	// maxRedirects is a maximum number of redirects followed by HTTP probe
+	maxRedirects = 10
+)
+
+// Checker evaluates test prechecks against kubernetes resources and HTTP endpoints
+type Checker struct {
+	ClientSet  kubernetes.Interface
+	HTTPClient *http.Client
+	// Namespace is a namespace of resources without namespace
+	Namespace string
+	// Interval is a time between evaluations of unmet prechecks
+	Interval time.Duration
+	// AllowedHosts are hosts HTTP endpoints can be probed on, "*." prefix allows subdomains, probes aren't allowed
+	// when empty, so tests can't make API server call internal services
+	AllowedHosts []string
+}
+
+// NewChecker creates prechecks checker of cluster resources
+func NewChecker(namespace string, allowedHosts []string) (*Checker, error) {
+	clientSet, err := k8sclient.ConnectToK8s()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Checker{
+		ClientSet:    clientSet,
+		HTTPClient:   &http.Client{Timeout: probeTimeout},
+		Namespace:    namespace,
+		Interval:     defaultInterval,
+		AllowedHosts: allowedHosts,
+	}, nil
+}
+
+// Wait evaluates prechecks until all of them are met, error of first unmet check is returned when they aren't met
+// within prechecks timeout
+func (c *Checker) Wait(ctx context.Context, prechecks testkube.Prechecks) error {
+	timeout := prechecks.GetTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	interval := c.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := c.Check(ctx, prechecks.Checks)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("prechecks not met within %s: %w", timeout, err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check evaluates prechecks once, error of first unmet check is returned
+func (c *Checker) Check(ctx context.Context, checks []testkube.Precheck) error {
+	for _, check := range checks {
+		var err error
+		if check.Url != "" {
+			err = c.probe(ctx, check)
+		} else {
+			err = c.checkResource(ctx, check)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// probe checks that HTTP endpoint responds with expected status
+func (c *Checker) probe(ctx context.Context, check testkube.Precheck) error {
+	if err := ValidateURL(check.Url, c.AllowedHosts); err != nil {
+		return fmt.Errorf("precheck %s: %w", check, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, check.Url, nil)
+	if err != nil {
+		return fmt.Errorf("precheck %s: %w", check, err)
+	}
+
+	client := http.Client{Timeout: probeTimeout}
+	if c.HTTPClient != nil {
+		client = *c.HTTPClient
+	}
+
+	// redirects can't lead probes to hosts which aren't allowed
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+
+		return ValidateURL(req.URL.String(), c.AllowedHosts)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("precheck %s: %w", check, err)
+	}
+	resp.Body.Close()
+
+	if check.ExpectedStatus != 0 && resp.StatusCode != int(check.ExpectedStatus) {
+		return fmt.Errorf("precheck %s responded with status %d, expected %d", check, resp.StatusCode, check.ExpectedStatus)
+	}
+
+	if check.ExpectedStatus == 0 && (resp.StatusCode < 200 || resp.StatusCode > 299) {
+		return fmt.Errorf("precheck %s responded with status %d", check, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ValidateURL checks that HTTP endpoint URL has host which can be probed
+func ValidateURL(rawURL string, allowedHosts []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(allowed)
+		if host == allowed || (strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:])) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("host %q isn't allowed for HTTP prechecks", host)
+}
+
+// checkResource checks that kubernetes resource is ready
+func (c *Checker) checkResource(ctx context.Context, check testkube.Precheck) error {
+	namespace := check.Namespace
+	if namespace == "" {
+		namespace = c.Namespace
+	}
+
+	kind, name := check.KindName()
+	var ready, desired int32
+	switch kind {
+	case "deployment":
+		deployment, err := c.ClientSet.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("precheck %s: %w", check, err)
+		}
+		ready, desired = deployment.Status.AvailableReplicas, replicas(deployment.Spec.Replicas)
+	case "statefulset":
+		statefulSet, err := c.ClientSet.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("precheck %s: %w", check, err)
+		}
+		ready, desired = statefulSet.Status.ReadyReplicas, replicas(statefulSet.Spec.Replicas)
+	case "daemonset":
+		daemonSet, err := c.ClientSet.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("precheck %s: %w", check, err)
+		}
+		ready, desired = daemonSet.Status.NumberReady, daemonSet.Status.DesiredNumberScheduled
+	case "pod":
+		pod, err := c.ClientSet.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("precheck %s: %w", check, err)
+		}
+
+		if !isPodReady(*pod) {
+			return fmt.Errorf("precheck %s: pod isn't ready", check)
+		}
+		return nil
+	default:
+		return fmt.Errorf("precheck %s: unsupported kind %s", check, kind)
+	}
+
+	if ready < desired || desired == 0 {
+		return fmt.Errorf("precheck %s: %d of %d replicas ready", check, ready, desired)
+	}
+
+	return nil
+}
+
+// replicas returns desired replicas, kubernetes defaults unset replicas to 1
+func replicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+
+	return *replicas
+}
+
+// isPodReady checks if pod has ready condition
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
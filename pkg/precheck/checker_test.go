@@ -0,0 +1,104 @@
+package precheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestCheckerCheck(t *testing.T) {
+	two := int32(2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "http://internal.local/health", http.StatusFound)
+			return
+		}
+
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	checker := Checker{
+		ClientSet: fake.NewSimpleClientset(
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "staging"},
+				Spec:       appsv1.DeploymentSpec{Replicas: &two},
+				Status:     appsv1.DeploymentStatus{AvailableReplicas: 2},
+			},
+			&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "testkube"},
+				Spec:       appsv1.StatefulSetSpec{Replicas: &two},
+				Status:     appsv1.StatefulSetStatus{ReadyReplicas: 1},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "testkube"},
+				Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+			},
+		),
+		Namespace:    "testkube",
+		AllowedHosts: []string{"127.0.0.1"},
+	}
+
+	tests := map[string]struct {
+		check testkube.Precheck
+		err   string
+	}{
+		"ready deployment":        {check: testkube.Precheck{Resource: "deployment/backend", Namespace: "staging"}},
+		"ready pod":               {check: testkube.Precheck{Resource: "pod/cache"}},
+		"healthy endpoint":        {check: testkube.Precheck{Url: server.URL + "/health"}},
+		"expected status":         {check: testkube.Precheck{Url: server.URL + "/ready", ExpectedStatus: http.StatusServiceUnavailable}},
+		"not ready statefulset":   {check: testkube.Precheck{Resource: "statefulset/db"}, err: "precheck statefulset/db: 1 of 2 replicas ready"},
+		"missing deployment":      {check: testkube.Precheck{Resource: "deployment/backend"}, err: "not found"},
+		"unavailable endpoint":    {check: testkube.Precheck{Url: server.URL + "/ready"}, err: "responded with status 503"},
+		"unexpected status":       {check: testkube.Precheck{Url: server.URL + "/health", ExpectedStatus: http.StatusNoContent}, err: "expected 204"},
+		"deployment in namespace": {check: testkube.Precheck{Resource: "Deployment/backend", Namespace: "staging"}},
+		"not allowed host":        {check: testkube.Precheck{Url: "http://metadata.google.internal/health"}, err: `host "metadata.google.internal" isn't allowed`},
+		"redirect to other host":  {check: testkube.Precheck{Url: server.URL + "/redirect"}, err: `host "internal.local" isn't allowed`},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checker.Check(context.Background(), []testkube.Precheck{test.check})
+			if test.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), test.err)
+			}
+		})
+	}
+}
+
+func TestCheckerWait(t *testing.T) {
+	checker := Checker{ClientSet: fake.NewSimpleClientset(), Namespace: "testkube", Interval: 10 * time.Millisecond}
+
+	err := checker.Wait(context.Background(), testkube.Prechecks{Timeout: "50ms", Checks: []testkube.Precheck{{Resource: "pod/cache"}}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "prechecks not met within 50ms: precheck pod/cache")
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	allowedHosts := []string{"backend.staging.svc", "*.example.com"}
+
+	assert.NoError(t, ValidateURL("http://backend.staging.svc:8080/health", allowedHosts))
+	assert.NoError(t, ValidateURL("https://API.example.com/health", allowedHosts))
+	assert.Error(t, ValidateURL("https://example.com/health", allowedHosts))
+	assert.Error(t, ValidateURL("https://evil-example.com/health", allowedHosts))
+	assert.Error(t, ValidateURL("http://169.254.169.254/latest", allowedHosts))
+	assert.Error(t, ValidateURL("http://backend.staging.svc/health", nil), "probes aren't allowed by default")
+}
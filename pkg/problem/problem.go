@@ -1,13 +1,109 @@
 package problem
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/moogar0880/problems"
 )
 
-// Porblem is struct defining RFC7807 Problem Details
-type Problem problems.DefaultProblem
+// Code is machine readable problem code, clients should be checking codes instead of problem details
+type Code string
+
+const (
+	CodeBadRequest                 Code = "bad_request"
+	CodeInvalidRequest             Code = "invalid_request"
+	CodeUnauthorized               Code = "unauthorized"
+	CodeForbidden                  Code = "forbidden"
+	CodeNotFound                   Code = "not_found"
+	CodeTestNotFound               Code = "test_not_found"
+	CodeTestSuiteNotFound          Code = "test_suite_not_found"
+	CodeExecutionNotFound          Code = "execution_not_found"
+	CodeTestSuiteExecutionNotFound Code = "test_suite_execution_not_found"
+	CodeExecutionGroupNotFound     Code = "execution_group_not_found"
+	CodeExecutorNotFound           Code = "executor_not_found"
+	CodeWebhookNotFound            Code = "webhook_not_found"
+	CodeArtifactNotFound           Code = "artifact_not_found"
+	CodeExecutorMissing            Code = "executor_missing"
+	CodeConflict                   Code = "conflict"
+	CodeDuplicateExecution         Code = "duplicate_execution"
+	CodeDisabled                   Code = "disabled"
+	CodeNotFinished                Code = "not_finished"
+	CodePolicyViolation            Code = "policy_violation"
+	CodeQuotaExceeded              Code = "quota_exceeded"
+	CodeArtifactQuotaExceeded      Code = "artifact_quota_exceeded"
+	CodePayloadTooLarge            Code = "payload_too_large"
+	CodeRateLimited                Code = "rate_limited"
+	CodeInternal                   Code = "internal_error"
+	CodeNotImplemented             Code = "not_implemented"
+	CodeBadGateway                 Code = "bad_gateway"
+	CodeUnavailable                Code = "unavailable"
+)
+
+// statusCodes are problem codes of errors without code by response status
+var statusCodes = map[int]Code{
+	http.StatusBadRequest:            CodeBadRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusConflict:              CodeConflict,
+	http.StatusRequestEntityTooLarge: CodePayloadTooLarge,
+	http.StatusUnprocessableEntity:   CodeInvalidRequest,
+	http.StatusTooManyRequests:       CodeRateLimited,
+	http.StatusInternalServerError:   CodeInternal,
+	http.StatusNotImplemented:        CodeNotImplemented,
+	http.StatusBadGateway:            CodeBadGateway,
+	http.StatusServiceUnavailable:    CodeUnavailable,
+}
+
+// Porblem is struct defining RFC7807 Problem Details extended with machine readable code and request id
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     Code   `json:"code,omitempty"`
+	// RequestId is id of request the problem was returned for
+	RequestId string `json:"requestId,omitempty"`
+}
 
 func New(status int, details string) Problem {
 	pr := problems.NewDetailedProblem(status, details)
-	return Problem(*pr)
+	return Problem{
+		Type:     pr.Type,
+		Title:    pr.Title,
+		Status:   pr.Status,
+		Detail:   pr.Detail,
+		Instance: pr.Instance,
+	}
+}
+
+// Error is an error with problem code
+type Error struct {
+	Code Code
+	Err  error
+}
+
+func (e Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// WithCode sets problem code of error
+func WithCode(code Code, err error) error {
+	return Error{Code: code, Err: err}
+}
+
+// CodeOf returns problem code of error, code of response status is returned for errors without code
+func CodeOf(err error, status int) Code {
+	var coded Error
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+
+	return statusCodes[status]
 }
@@ -0,0 +1,19 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeOf(t *testing.T) {
+	err := fmt.Errorf("can't run test: %w", WithCode(CodeExecutorMissing, errors.New("executor type 'k6/script' is not handled")))
+
+	assert.Equal(t, CodeExecutorMissing, CodeOf(err, http.StatusInternalServerError))
+	assert.Equal(t, "can't run test: executor type 'k6/script' is not handled", err.Error())
+	assert.Equal(t, CodeNotFound, CodeOf(errors.New("not found"), http.StatusNotFound))
+	assert.Equal(t, Code(""), CodeOf(errors.New("teapot"), http.StatusTeapot))
+}
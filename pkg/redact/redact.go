@@ -0,0 +1,169 @@
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/output"
+)
+
+const (
+	// Mask replaces redacted values
+	Mask = "***"
+	// minValueLength is a length of shortest redacted value, shorter values would mask most of the output
+	minValueLength = 4
+)
+
+// DefaultPatterns are patterns of well known credentials redacted in addition to configured patterns
+var DefaultPatterns = []string{
+	// AWS access key IDs
+	`\b(AKIA|ASIA)[0-9A-Z]{16}\b`,
+	// GitHub tokens
+	`\bgh[pousr]_[A-Za-z0-9]{36,}\b`,
+	// Slack tokens
+	`\bxox[abposr]-[A-Za-z0-9-]{10,}`,
+	// PEM private keys
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,
+}
+
+var defaultPatterns = mustCompilePatterns(DefaultPatterns)
+
+// CompilePatterns compiles redaction regex patterns
+func CompilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %s: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+
+	return compiled, nil
+}
+
+func mustCompilePatterns(patterns []string) []*regexp.Regexp {
+	compiled, err := CompilePatterns(patterns)
+	if err != nil {
+		panic(err)
+	}
+
+	return compiled
+}
+
+// New returns redactor replacing given secret values and texts matching default or given patterns with mask
+func New(values []string, patterns []*regexp.Regexp) *Redactor {
+	var redacted []string
+	for _, value := range values {
+		if len(value) >= minValueLength {
+			redacted = append(redacted, value)
+		}
+	}
+
+	// longer values are replaced first, so values containing other values are masked whole
+	sort.Slice(redacted, func(i, j int) bool { return len(redacted[i]) > len(redacted[j]) })
+
+	var replacer *strings.Replacer
+	if len(redacted) != 0 {
+		oldnew := make([]string, 0, len(redacted)*2)
+		for _, value := range redacted {
+			oldnew = append(oldnew, value, Mask)
+		}
+		replacer = strings.NewReplacer(oldnew...)
+	}
+
+	return &Redactor{replacer: replacer, patterns: append(append([]*regexp.Regexp{}, defaultPatterns...), patterns...)}
+}
+
+// Redactor masks secret values in texts, nil redactor keeps texts as they are
+type Redactor struct {
+	replacer *strings.Replacer
+	patterns []*regexp.Regexp
+}
+
+// String returns text with masked secret values
+func (r *Redactor) String(text string) string {
+	if r == nil || text == "" {
+		return text
+	}
+
+	if r.replacer != nil {
+		text = r.replacer.Replace(text)
+	}
+
+	for _, re := range r.patterns {
+		text = re.ReplaceAllLiteralString(text, Mask)
+	}
+
+	return text
+}
+
+// Result returns execution result with masked output, error messages and output variables, steps are copied
+func (r *Redactor) Result(result testkube.ExecutionResult) testkube.ExecutionResult {
+	if r == nil {
+		return result
+	}
+
+	result.Output = r.String(result.Output)
+	result.ErrorMessage = r.String(result.ErrorMessage)
+	result.Steps = r.steps(result.Steps)
+
+	if result.OutputVariables != nil {
+		variables := make(map[string]string, len(result.OutputVariables))
+		for name, value := range result.OutputVariables {
+			variables[name] = r.String(value)
+		}
+		result.OutputVariables = variables
+	}
+
+	if result.Iterations != nil {
+		iterations := make([]testkube.ExecutionIteration, len(result.Iterations))
+		for i, iteration := range result.Iterations {
+			iteration.ErrorMessage = r.String(iteration.ErrorMessage)
+			iteration.Steps = r.steps(iteration.Steps)
+			iterations[i] = iteration
+		}
+		result.Iterations = iterations
+	}
+
+	return result
+}
+
+// Output returns log output with masked content and result
+func (r *Redactor) Output(out output.Output) output.Output {
+	if r == nil {
+		return out
+	}
+
+	out.Content = r.String(out.Content)
+	if out.Result != nil {
+		result := r.Result(*out.Result)
+		out.Result = &result
+	}
+
+	return out
+}
+
+func (r *Redactor) steps(steps []testkube.ExecutionStepResult) []testkube.ExecutionStepResult {
+	if steps == nil {
+		return nil
+	}
+
+	redacted := make([]testkube.ExecutionStepResult, len(steps))
+	for i, step := range steps {
+		if step.AssertionResults != nil {
+			assertions := make([]testkube.AssertionResult, len(step.AssertionResults))
+			for j, assertion := range step.AssertionResults {
+				assertion.ErrorMessage = r.String(assertion.ErrorMessage)
+				assertions[j] = assertion
+			}
+			step.AssertionResults = assertions
+		}
+		redacted[i] = step
+	}
+
+	return redacted
+}
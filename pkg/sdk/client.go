@@ -0,0 +1,309 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeshop/testkube/pkg/problem"
+)
+
+const (
+	// apiVersion is a version of API paths the client calls
+	apiVersion = "v1"
+	// projectParam is a query param API server reads project from when auth layer doesn't set it
+	projectParam = "project"
+
+	defaultRetries = 3
+	defaultBackoff = 500 * time.Millisecond
+	// maxBackoff limits backoff doubled on each retry and Retry-After wait
+	maxBackoff = 30 * time.Second
+)
+
+// Client is a Testkube API client, it's safe for concurrent use
+type Client struct {
+	uri        string
+	token      string
+	project    string
+	httpClient *http.Client
+	retries    int
+	backoff    time.Duration
+}
+
+// Option configures client
+type Option func(c *Client)
+
+// WithToken sets bearer token passed to API server
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithProject limits requests to project, API server ignores it for callers with project set by auth layer
+func WithProject(project string) Option {
+	return func(c *Client) {
+		c.project = project
+	}
+}
+
+// WithHTTPClient sets HTTP client requests are sent with, e.g. with custom TLS config, client timeout
+// ends log streams too, so contexts are preferred to limit request duration
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetries sets number of retries of failed requests and initial backoff between them, backoff is doubled
+// on each retry, zero retries disables retrying
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// New returns client of Testkube API server on given URI, e.g. https://testkube.example.com
+func New(uri string, options ...Option) *Client {
+	c := &Client{
+		uri:        strings.TrimSuffix(uri, "/"),
+		httpClient: http.DefaultClient,
+		retries:    defaultRetries,
+		backoff:    defaultBackoff,
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// Error is an error response of API server
+type Error struct {
+	// StatusCode is HTTP status code of response
+	StatusCode int
+	// Problem is RFC7807 problem details of response, detail is set to response body when it's not a problem
+	Problem problem.Problem
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("api server returned %d: %s", e.StatusCode, e.Problem.Detail)
+}
+
+// IsNotFound checks if error is API server not found response
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsConflict checks if error is API server conflict response, e.g. when execution isn't finished or test is disabled
+func IsConflict(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+// HasCode checks if error is API server problem with code, e.g. problem.CodeExecutorMissing
+func HasCode(err error, code problem.Code) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Problem.Code == code
+}
+
+// request is API request, body is sent as JSON unless it's a reader
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        interface{}
+	contentType string
+	accept      string
+}
+
+// do sends request and decodes JSON response to result when it's set
+func (c *Client) do(ctx context.Context, req request, result interface{}) error {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if result == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("decoding %s %s response: %w", req.method, req.path, err)
+	}
+
+	return nil
+}
+
+// raw sends request and returns whole response body
+func (c *Client) raw(ctx context.Context, req request) ([]byte, error) {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
+
+// send sends request retrying on network errors and overloaded server responses, successful response is returned
+func (c *Client) send(ctx context.Context, req request) (*http.Response, error) {
+	var body []byte
+	var reader io.Reader
+	switch b := req.body.(type) {
+	case nil:
+	case io.Reader:
+		reader = b
+	default:
+		var err error
+		if body, err = json.Marshal(b); err != nil {
+			return nil, fmt.Errorf("encoding %s %s request: %w", req.method, req.path, err)
+		}
+	}
+
+	// streamed bodies can't be sent again
+	rewindable := req.body == nil || body != nil
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		httpReq, err := c.newRequest(ctx, req, reader)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpClient.Do(httpReq)
+		retry := attempt < c.retries && rewindable
+		if err != nil {
+			if !retry || !idempotent(req.method) || ctx.Err() != nil {
+				return nil, fmt.Errorf("%s %s: %w", req.method, req.path, err)
+			}
+		} else if resp.StatusCode < http.StatusBadRequest {
+			return resp, nil
+		} else if !retry || !retryable(req.method, resp.StatusCode) {
+			return nil, responseError(resp)
+		}
+
+		wait := backoff
+		if resp != nil {
+			if after := retryAfter(resp); after > 0 {
+				wait = after
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if wait > maxBackoff {
+			wait = maxBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) newRequest(ctx context.Context, req request, body io.Reader) (*http.Request, error) {
+	query := url.Values{}
+	for name, values := range req.query {
+		query[name] = values
+	}
+
+	if c.project != "" {
+		query.Set(projectParam, c.project)
+	}
+
+	uri := c.uri + "/" + apiVersion + req.path
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, uri, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		contentType := req.contentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+
+	if req.accept != "" {
+		httpReq.Header.Set("Accept", req.accept)
+	}
+
+	if c.token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	return httpReq, nil
+}
+
+// idempotent checks if request can be sent again after network error
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// retryable checks if request can be sent again after error response, requests rejected when server
+// is overloaded or draining weren't handled, so they're retried for all methods
+func retryable(method string, statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent(method)
+	}
+
+	return false
+}
+
+// retryAfter returns wait time of Retry-After header in seconds
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// responseError returns error of unsuccessful response, response body is closed
+func responseError(resp *http.Response) error {
+	defer resp.Body.Close()
+
+	apiErr := &Error{StatusCode: resp.StatusCode}
+	content, _ := io.ReadAll(resp.Body)
+
+	// responses of proxies in front of API server aren't problems
+	if json.Unmarshal(content, &apiErr.Problem) != nil || apiErr.Problem.Detail == "" {
+		apiErr.Problem = problem.New(resp.StatusCode, strings.TrimSpace(string(content)))
+	}
+
+	return apiErr
+}
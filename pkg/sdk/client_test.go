@@ -0,0 +1,146 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/output"
+)
+
+func TestClientRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/tests/api-tests/executions", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("force"))
+		assert.Equal(t, "payments", r.URL.Query().Get("project"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"1","testName":"api-tests"}`)
+	}))
+	defer server.Close()
+
+	client := New(server.URL+"/", WithToken("token"), WithProject("payments"))
+	execution, err := client.ExecuteTest(context.Background(), "api-tests", testkube.ExecutionRequest{Name: "run"}, ExecuteOptions{Force: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, "1", execution.Id)
+}
+
+func TestListQuotas(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/quotas", r.URL.Path)
+		fmt.Fprint(w, `[{"quota":{"name":"payments","maxExecutionsPerDay":10},"executionsToday":10,"exceeded":["maxExecutionsPerDay"]}]`)
+	}))
+	defer server.Close()
+
+	usages, err := New(server.URL).ListQuotas(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, usages, 1)
+	assert.Equal(t, "payments", usages[0].Quota.Name)
+	assert.Equal(t, []string{"maxExecutionsPerDay"}, usages[0].Exceeded)
+}
+
+func TestClientRetries(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		fmt.Fprint(w, `{"id":"1"}`)
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithRetries(3, time.Millisecond))
+	execution, err := client.RerunExecution(context.Background(), "1", ExecuteOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "1", execution.Id)
+	assert.Equal(t, int32(3), requests)
+}
+
+func TestClientErrors(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path == "/v1/tests/missing" {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"type":"about:blank","title":"Not Found","status":404,"detail":"test missing not found"}`)
+			return
+		}
+
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "bad gateway")
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithRetries(2, time.Millisecond))
+	_, err := client.GetTest(context.Background(), "missing")
+	assert.True(t, IsNotFound(err))
+	assert.EqualError(t, err, "api server returned 404: test missing not found")
+	assert.Equal(t, int32(1), requests, "client errors aren't retried")
+
+	atomic.StoreInt32(&requests, 0)
+	_, err = client.ExecuteTests(context.Background(), "app=api", testkube.ExecutionRequest{}, ExecuteOptions{})
+	assert.EqualError(t, err, "api server returned 502: bad gateway")
+	assert.Equal(t, int32(1), requests, "executions aren't started again after gateway errors")
+
+	atomic.StoreInt32(&requests, 0)
+	_, err = client.GetExecution(context.Background(), "1")
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), requests)
+}
+
+func TestStreamExecutionLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+		fmt.Fprint(w, "data: {\"type\":\"line\",\"content\":\"first\"}\n\n")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "data: {\"type\":\"line\",\"content\":\"second\"}\n\n")
+	}))
+	defer server.Close()
+
+	var lines []string
+	err := New(server.URL).StreamExecutionLogs(context.Background(), "1", func(out output.Output) error {
+		lines = append(lines, out.Content)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, lines)
+}
+
+func TestWaitForExecution(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := "running"
+		if atomic.AddInt32(&requests, 1) > 1 {
+			status = "passed"
+		}
+		fmt.Fprintf(w, `{"id":"1","executionResult":{"status":"%s"}}`, status)
+	}))
+	defer server.Close()
+
+	execution, err := New(server.URL).WaitForExecution(context.Background(), "1", time.Millisecond)
+
+	require.NoError(t, err)
+	assert.True(t, execution.ExecutionResult.IsPassed())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = New(server.URL).WaitForExecution(ctx, "1", time.Millisecond)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,122 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/output"
+)
+
+// ListExecutions returns executions matching options
+func (c *Client) ListExecutions(ctx context.Context, options ExecutionListOptions) (executions testkube.ExecutionsResult, err error) {
+	return executions, c.do(ctx, request{method: http.MethodGet, path: "/executions", query: options.query()}, &executions)
+}
+
+// GetExecution returns execution by id or name
+func (c *Client) GetExecution(ctx context.Context, id string) (execution testkube.Execution, err error) {
+	return execution, c.do(ctx, request{method: http.MethodGet, path: "/executions/" + url.PathEscape(id)}, &execution)
+}
+
+// GetExecutionsFeed returns Atom feed of executions matching options, failed executions are returned when status isn't set
+func (c *Client) GetExecutionsFeed(ctx context.Context, options ExecutionListOptions) ([]byte, error) {
+	return c.raw(ctx, request{method: http.MethodGet, path: "/executions/feed", query: options.query()})
+}
+
+// GetExecutionDiff compares execution with base execution, previous execution of the test is used for empty base
+func (c *Client) GetExecutionDiff(ctx context.Context, id, baseID string) (diff testkube.ExecutionDiff, err error) {
+	query := url.Values{}
+	setParam(query, "base", baseID)
+	return diff, c.do(ctx, request{method: http.MethodGet, path: "/executions/" + url.PathEscape(id) + "/diff", query: query}, &diff)
+}
+
+// GetExecutionPod returns diagnostics of execution pod
+func (c *Client) GetExecutionPod(ctx context.Context, id string) (diagnostics testkube.ExecutionDiagnostics, err error) {
+	return diagnostics, c.do(ctx, request{method: http.MethodGet, path: "/executions/" + url.PathEscape(id) + "/pod"}, &diagnostics)
+}
+
+// RestoreExecution restores archived execution
+func (c *Client) RestoreExecution(ctx context.Context, id string) (execution testkube.Execution, err error) {
+	return execution, c.do(ctx, request{method: http.MethodPost, path: "/executions/" + url.PathEscape(id) + "/restore"}, &execution)
+}
+
+// RerunExecution starts new execution of finished execution with its recorded inputs
+func (c *Client) RerunExecution(ctx context.Context, id string, options ExecuteOptions) (execution testkube.Execution, err error) {
+	path := "/executions/" + url.PathEscape(id) + "/rerun"
+	return execution, c.do(ctx, request{method: http.MethodPost, path: path, query: options.query()}, &execution)
+}
+
+// ListArtifacts returns artifacts of execution, presigned artifacts have storage download URLs
+func (c *Client) ListArtifacts(ctx context.Context, id string, presigned bool) (artifacts []testkube.Artifact, err error) {
+	query := url.Values{}
+	if presigned {
+		query.Set("presigned", "true")
+	}
+
+	return artifacts, c.do(ctx, request{method: http.MethodGet, path: "/executions/" + url.PathEscape(id) + "/artifacts", query: query}, &artifacts)
+}
+
+// DownloadArtifact returns content of execution artifact, it has to be closed by caller
+func (c *Client) DownloadArtifact(ctx context.Context, id, fileName string) (io.ReadCloser, error) {
+	resp, err := c.send(ctx, request{method: http.MethodGet, path: "/executions/" + url.PathEscape(id) + "/artifacts/" + url.PathEscape(fileName)})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// ReportArtifactScans stores scan results of execution artifacts, merged scan results are returned
+func (c *Client) ReportArtifactScans(ctx context.Context, id string, scans []testkube.ArtifactScanResult) (results []testkube.ArtifactScanResult, err error) {
+	path := "/executions/" + url.PathEscape(id) + "/artifacts/scans"
+	return results, c.do(ctx, request{method: http.MethodPut, path: path, body: scans}, &results)
+}
+
+// GetExecutionGroup returns executions of execution group, e.g. of matrix executions
+func (c *Client) GetExecutionGroup(ctx context.Context, id string) (group testkube.ExecutionGroup, err error) {
+	return group, c.do(ctx, request{method: http.MethodGet, path: "/execution-groups/" + url.PathEscape(id)}, &group)
+}
+
+// AbortExecutionGroup aborts queued and running executions of execution group
+func (c *Client) AbortExecutionGroup(ctx context.Context, id string) error {
+	return c.do(ctx, request{method: http.MethodPost, path: "/execution-groups/" + url.PathEscape(id) + "/abort"}, nil)
+}
+
+// StreamExecutionLogs calls handler with logs of execution until execution ends, context is cancelled
+// or handler returns error
+func (c *Client) StreamExecutionLogs(ctx context.Context, id string, handler func(out output.Output) error) error {
+	return c.stream(ctx, "/executions/"+url.PathEscape(id)+"/logs", func(data []byte) error {
+		out, err := output.GetLogEntry(data)
+		if err != nil {
+			return nil
+		}
+
+		return handler(out)
+	})
+}
+
+// WaitForExecution polls execution in interval until it's completed
+func (c *Client) WaitForExecution(ctx context.Context, id string, interval time.Duration) (execution testkube.Execution, err error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		execution, err = c.GetExecution(ctx, id)
+		if err != nil {
+			return execution, err
+		}
+
+		if execution.ExecutionResult != nil && execution.ExecutionResult.Status != nil && execution.ExecutionResult.IsCompleted() {
+			return execution, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return execution, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
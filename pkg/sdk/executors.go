@@ -0,0 +1,59 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// ListExecutors returns executors matching selector
+func (c *Client) ListExecutors(ctx context.Context, selector string) (executors []testkube.ExecutorDetails, err error) {
+	return executors, c.do(ctx, request{method: http.MethodGet, path: "/executors", query: selectorQuery(selector)}, &executors)
+}
+
+// GetExecutor returns executor by name
+func (c *Client) GetExecutor(ctx context.Context, name string) (executor testkube.ExecutorDetails, err error) {
+	return executor, c.do(ctx, request{method: http.MethodGet, path: "/executors/" + url.PathEscape(name)}, &executor)
+}
+
+// CreateExecutor creates executor
+func (c *Client) CreateExecutor(ctx context.Context, create testkube.ExecutorCreateRequest) (executor testkube.ExecutorDetails, err error) {
+	return executor, c.do(ctx, request{method: http.MethodPost, path: "/executors", body: create}, &executor)
+}
+
+// DeleteExecutor deletes executor by name
+func (c *Client) DeleteExecutor(ctx context.Context, name string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: "/executors/" + url.PathEscape(name)}, nil)
+}
+
+// DeleteExecutors deletes executors matching selector
+func (c *Client) DeleteExecutors(ctx context.Context, selector string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: "/executors", query: selectorQuery(selector)}, nil)
+}
+
+// ListWebhooks returns webhooks matching selector
+func (c *Client) ListWebhooks(ctx context.Context, selector string) (webhooks []testkube.Webhook, err error) {
+	return webhooks, c.do(ctx, request{method: http.MethodGet, path: "/webhooks", query: selectorQuery(selector)}, &webhooks)
+}
+
+// GetWebhook returns webhook by name
+func (c *Client) GetWebhook(ctx context.Context, name string) (webhook testkube.Webhook, err error) {
+	return webhook, c.do(ctx, request{method: http.MethodGet, path: "/webhooks/" + url.PathEscape(name)}, &webhook)
+}
+
+// CreateWebhook creates webhook
+func (c *Client) CreateWebhook(ctx context.Context, create testkube.WebhookCreateRequest) (webhook testkube.Webhook, err error) {
+	return webhook, c.do(ctx, request{method: http.MethodPost, path: "/webhooks", body: create}, &webhook)
+}
+
+// DeleteWebhook deletes webhook by name
+func (c *Client) DeleteWebhook(ctx context.Context, name string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: "/webhooks/" + url.PathEscape(name)}, nil)
+}
+
+// DeleteWebhooks deletes webhooks matching selector
+func (c *Client) DeleteWebhooks(ctx context.Context, selector string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: "/webhooks", query: selectorQuery(selector)}, nil)
+}
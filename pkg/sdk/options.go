@@ -0,0 +1,135 @@
+package sdk
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// dateFormat is a format of start and end date filters
+const dateFormat = "2006-01-02"
+
+// ListOptions filter listed tests, test suites, executors and webhooks
+type ListOptions struct {
+	// Selector is a label selector, e.g. app=api,team
+	Selector string
+	// TextSearch filters tests and test suites by name
+	TextSearch string
+	// Owner filters tests and test suites by owner
+	Owner string
+}
+
+func (o ListOptions) query() url.Values {
+	query := url.Values{}
+	setParam(query, "selector", o.Selector)
+	setParam(query, "textSearch", o.TextSearch)
+	setParam(query, "owner", o.Owner)
+	return query
+}
+
+// ExecutionListOptions filter listed executions and test suite executions
+type ExecutionListOptions struct {
+	// TestName filters executions of test or test suite
+	TestName   string
+	TextSearch string
+	// Status is comma separated list of statuses, e.g. failed,aborted
+	Status    string
+	Selector  string
+	Type      string
+	StartDate time.Time
+	EndDate   time.Time
+	Page      int
+	PageSize  int
+	// GroupId filters executions of execution group, e.g. of matrix executions
+	GroupId string
+	// ExecutorImageDigest, NodeName, KubernetesVersion and RunnerVersion filter executions by their environment
+	ExecutorImageDigest string
+	NodeName            string
+	KubernetesVersion   string
+	RunnerVersion       string
+	// Archived includes page of archived executions
+	Archived bool
+}
+
+func (o ExecutionListOptions) query() url.Values {
+	query := url.Values{}
+	setParam(query, "testName", o.TestName)
+	setParam(query, "textSearch", o.TextSearch)
+	setParam(query, "status", o.Status)
+	setParam(query, "selector", o.Selector)
+	setParam(query, "type", o.Type)
+	setParam(query, "groupId", o.GroupId)
+	setParam(query, "executorImageDigest", o.ExecutorImageDigest)
+	setParam(query, "nodeName", o.NodeName)
+	setParam(query, "kubernetesVersion", o.KubernetesVersion)
+	setParam(query, "runnerVersion", o.RunnerVersion)
+
+	if !o.StartDate.IsZero() {
+		query.Set("startDate", o.StartDate.Format(dateFormat))
+	}
+
+	if !o.EndDate.IsZero() {
+		query.Set("endDate", o.EndDate.Format(dateFormat))
+	}
+
+	if o.Page > 0 {
+		query.Set("page", strconv.Itoa(o.Page))
+	}
+
+	if o.PageSize > 0 {
+		query.Set("pageSize", strconv.Itoa(o.PageSize))
+	}
+
+	if o.Archived {
+		query.Set("archived", "true")
+	}
+
+	return query
+}
+
+// ExecuteOptions are options of starting executions
+type ExecuteOptions struct {
+	// Force runs disabled tests and test suites
+	Force bool
+	// ConcurrencyLevel limits number of executions run in parallel when tests or test suites are run by selector
+	ConcurrencyLevel int
+}
+
+func (o ExecuteOptions) query() url.Values {
+	query := url.Values{}
+	if o.Force {
+		query.Set("force", "true")
+	}
+
+	if o.ConcurrencyLevel > 0 {
+		query.Set("concurrency", strconv.Itoa(o.ConcurrencyLevel))
+	}
+
+	return query
+}
+
+// ReportOptions filter executions reports are made of
+type ReportOptions struct {
+	Selector string
+	// Since is a report period, e.g. 7d or 24h
+	Since string
+}
+
+func (o ReportOptions) query() url.Values {
+	query := url.Values{}
+	setParam(query, "selector", o.Selector)
+	setParam(query, "since", o.Since)
+	return query
+}
+
+func setParam(query url.Values, name, value string) {
+	if value != "" {
+		query.Set(name, value)
+	}
+}
+
+func selectorQuery(selector string) url.Values {
+	query := url.Values{}
+	setParam(query, "selector", selector)
+	return query
+}
@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// GetServerInfo returns version and features of API server
+func (c *Client) GetServerInfo(ctx context.Context) (info testkube.ServerInfo, err error) {
+	return info, c.do(ctx, request{method: http.MethodGet, path: "/info"}, &info)
+}
+
+// GetHealth returns health report of API server dependencies
+func (c *Client) GetHealth(ctx context.Context) (report testkube.HealthReport, err error) {
+	return report, c.do(ctx, request{method: http.MethodGet, path: "/health"}, &report)
+}
+
+// GetReadiness returns readiness report of API server, not ready server returns error
+func (c *Client) GetReadiness(ctx context.Context) (report testkube.HealthReport, err error) {
+	return report, c.do(ctx, request{method: http.MethodGet, path: "/ready"}, &report)
+}
+
+// GetConfig returns API server settings
+func (c *Client) GetConfig(ctx context.Context) (settings testkube.ServerSettings, err error) {
+	return settings, c.do(ctx, request{method: http.MethodGet, path: "/config"}, &settings)
+}
+
+// UpdateConfig updates API server settings set in request
+func (c *Client) UpdateConfig(ctx context.Context, update testkube.ServerSettingsUpdateRequest) (settings testkube.ServerSettings, err error) {
+	return settings, c.do(ctx, request{method: http.MethodPatch, path: "/config", body: update}, &settings)
+}
+
+// ListQuotas returns quotas of API server settings with their current usage, quotas of other projects aren't listed
+// for clients limited to project
+func (c *Client) ListQuotas(ctx context.Context) (usages []testkube.QuotaUsage, err error) {
+	return usages, c.do(ctx, request{method: http.MethodGet, path: "/quotas"}, &usages)
+}
+
+// ListLabels returns label keys of executions with their values
+func (c *Client) ListLabels(ctx context.Context) (labels map[string][]string, err error) {
+	return labels, c.do(ctx, request{method: http.MethodGet, path: "/labels"}, &labels)
+}
+
+// ListFlakyTests returns flakiness of tests matching selector
+func (c *Client) ListFlakyTests(ctx context.Context, selector string) (tests []testkube.TestFlakiness, err error) {
+	return tests, c.do(ctx, request{method: http.MethodGet, path: "/reports/flaky-tests", query: selectorQuery(selector)}, &tests)
+}
+
+// GetSummaryReport returns summary of test executions in report period
+func (c *Client) GetSummaryReport(ctx context.Context, options ReportOptions) (report testkube.TestsSummaryReport, err error) {
+	return report, c.do(ctx, request{method: http.MethodGet, path: "/reports/summary", query: options.query()}, &report)
+}
+
+// GetErrorsReport returns most common errors of failed executions in report period, limited to given number when set
+func (c *Client) GetErrorsReport(ctx context.Context, options ReportOptions, limit int) (report testkube.ErrorsReport, err error) {
+	query := options.query()
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	return report, c.do(ctx, request{method: http.MethodGet, path: "/reports/errors", query: query}, &report)
+}
+
+// GetCostsReport returns estimated costs of test executions in report period per test and per label
+func (c *Client) GetCostsReport(ctx context.Context, options ReportOptions) (report testkube.CostsReport, err error) {
+	return report, c.do(ctx, request{method: http.MethodGet, path: "/reports/costs", query: options.query()}, &report)
+}
+
+// Backup returns gzipped backup archive of tests, test suites and executors, executions are included when set,
+// archive has to be closed by caller
+func (c *Client) Backup(ctx context.Context, executions bool) (io.ReadCloser, error) {
+	query := url.Values{}
+	if executions {
+		query.Set("executions", "true")
+	}
+
+	resp, err := c.send(ctx, request{method: http.MethodPost, path: "/admin/backup", query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// Restore restores gzipped backup archive, existing resources are overwritten when set
+func (c *Client) Restore(ctx context.Context, archive io.Reader, overwrite bool) (result testkube.RestoreResult, err error) {
+	query := url.Values{}
+	if overwrite {
+		query.Set("overwrite", "true")
+	}
+
+	return result, c.do(ctx, request{method: http.MethodPost, path: "/admin/restore", query: query, body: archive,
+		contentType: "application/gzip"}, &result)
+}
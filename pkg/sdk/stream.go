@@ -0,0 +1,48 @@
+package sdk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+)
+
+// maxEventSize limits size of single server-sent event, test suite executions with many steps can be long
+const maxEventSize = 16 * 1024 * 1024
+
+var eventDataPrefix = []byte("data:")
+
+// stream reads server-sent events of path and calls handler with data of each event until stream ends,
+// context is cancelled or handler returns error
+func (c *Client) stream(ctx context.Context, path string, handler func(data []byte) error) error {
+	resp, err := c.send(ctx, request{method: http.MethodGet, path: path, accept: "text/event-stream"})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if !bytes.HasPrefix(line, eventDataPrefix) {
+			continue
+		}
+
+		data := bytes.TrimSpace(bytes.TrimPrefix(line, eventDataPrefix))
+		if len(data) == 0 {
+			continue
+		}
+
+		if err = handler(data); err != nil {
+			return err
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return scanner.Err()
+}
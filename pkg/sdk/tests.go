@@ -0,0 +1,120 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// ListTests returns tests matching options
+func (c *Client) ListTests(ctx context.Context, options ListOptions) (tests []testkube.Test, err error) {
+	return tests, c.do(ctx, request{method: http.MethodGet, path: "/tests", query: options.query()}, &tests)
+}
+
+// ListTestsWithExecutions returns tests matching options with their latest executions
+func (c *Client) ListTestsWithExecutions(ctx context.Context, options ListOptions) (tests []testkube.TestWithExecution, err error) {
+	return tests, c.do(ctx, request{method: http.MethodGet, path: "/test-with-executions", query: options.query()}, &tests)
+}
+
+// GetTest returns test by name
+func (c *Client) GetTest(ctx context.Context, name string) (test testkube.Test, err error) {
+	return test, c.do(ctx, request{method: http.MethodGet, path: "/tests/" + url.PathEscape(name)}, &test)
+}
+
+// GetTestWithExecution returns test by name with its latest execution
+func (c *Client) GetTestWithExecution(ctx context.Context, name string) (test testkube.TestWithExecution, err error) {
+	return test, c.do(ctx, request{method: http.MethodGet, path: "/test-with-executions/" + url.PathEscape(name)}, &test)
+}
+
+// CreateTest creates test
+func (c *Client) CreateTest(ctx context.Context, upsert testkube.TestUpsertRequest) (test testkube.Test, err error) {
+	return test, c.do(ctx, request{method: http.MethodPost, path: "/tests", body: upsert}, &test)
+}
+
+// UpdateTest updates test of request name
+func (c *Client) UpdateTest(ctx context.Context, upsert testkube.TestUpsertRequest) (test testkube.Test, err error) {
+	return test, c.do(ctx, request{method: http.MethodPatch, path: "/tests/" + url.PathEscape(upsert.Name), body: upsert}, &test)
+}
+
+// DeleteTest deletes test by name
+func (c *Client) DeleteTest(ctx context.Context, name string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: "/tests/" + url.PathEscape(name)}, nil)
+}
+
+// DeleteTests deletes tests matching selector, all tests are deleted for empty selector
+func (c *Client) DeleteTests(ctx context.Context, selector string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: "/tests", query: selectorQuery(selector)}, nil)
+}
+
+// PurgeTestJobs deletes Kubernetes jobs of test executions
+func (c *Client) PurgeTestJobs(ctx context.Context, name string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: "/tests/" + url.PathEscape(name) + "/jobs"}, nil)
+}
+
+// GetTestBadge returns SVG status badge of test
+func (c *Client) GetTestBadge(ctx context.Context, name string) ([]byte, error) {
+	return c.raw(ctx, request{method: http.MethodGet, path: "/tests/" + url.PathEscape(name) + "/badge.svg"})
+}
+
+// ExecuteTest starts execution of test
+func (c *Client) ExecuteTest(ctx context.Context, name string, executionRequest testkube.ExecutionRequest,
+	options ExecuteOptions) (execution testkube.Execution, err error) {
+	path := "/tests/" + url.PathEscape(name) + "/executions"
+	return execution, c.do(ctx, request{method: http.MethodPost, path: path, query: options.query(), body: executionRequest}, &execution)
+}
+
+// ExecuteTests starts executions of tests matching selector
+func (c *Client) ExecuteTests(ctx context.Context, selector string, executionRequest testkube.ExecutionRequest,
+	options ExecuteOptions) (executions []testkube.Execution, err error) {
+	query := options.query()
+	setParam(query, "selector", selector)
+	return executions, c.do(ctx, request{method: http.MethodPost, path: "/executions", query: query, body: executionRequest}, &executions)
+}
+
+// ListTestExecutions returns executions of test matching options
+func (c *Client) ListTestExecutions(ctx context.Context, name string, options ExecutionListOptions) (executions testkube.ExecutionsResult, err error) {
+	path := "/tests/" + url.PathEscape(name) + "/executions"
+	return executions, c.do(ctx, request{method: http.MethodGet, path: path, query: options.query()}, &executions)
+}
+
+// GetTestExecution returns execution of test by execution id or name
+func (c *Client) GetTestExecution(ctx context.Context, name, executionID string) (execution testkube.Execution, err error) {
+	path := "/tests/" + url.PathEscape(name) + "/executions/" + url.PathEscape(executionID)
+	return execution, c.do(ctx, request{method: http.MethodGet, path: path}, &execution)
+}
+
+// GetTestExecutionByNumber returns execution of test by its number
+func (c *Client) GetTestExecutionByNumber(ctx context.Context, name string, number int) (execution testkube.Execution, err error) {
+	path := fmt.Sprintf("/tests/%s/executions/number/%d", url.PathEscape(name), number)
+	return execution, c.do(ctx, request{method: http.MethodGet, path: path}, &execution)
+}
+
+// GetLatestTestExecution returns latest execution of test
+func (c *Client) GetLatestTestExecution(ctx context.Context, name string) (execution testkube.Execution, err error) {
+	path := "/tests/" + url.PathEscape(name) + "/executions/latest"
+	return execution, c.do(ctx, request{method: http.MethodGet, path: path}, &execution)
+}
+
+// GetLatestSuccessfulTestExecution returns latest passed execution of test
+func (c *Client) GetLatestSuccessfulTestExecution(ctx context.Context, name string) (execution testkube.Execution, err error) {
+	path := "/tests/" + url.PathEscape(name) + "/executions/latest-success"
+	return execution, c.do(ctx, request{method: http.MethodGet, path: path}, &execution)
+}
+
+// GetTestExecutionsTrend returns trend of test executions in buckets of interval (hour, day or week) over window, e.g. 30d
+func (c *Client) GetTestExecutionsTrend(ctx context.Context, name, interval, window string) (trend testkube.ExecutionsTrend, err error) {
+	query := url.Values{}
+	setParam(query, "interval", interval)
+	setParam(query, "window", window)
+	path := "/tests/" + url.PathEscape(name) + "/executions/trends"
+	return trend, c.do(ctx, request{method: http.MethodGet, path: path, query: query}, &trend)
+}
+
+// AbortExecution aborts execution of test
+func (c *Client) AbortExecution(ctx context.Context, name, executionID string) error {
+	path := "/tests/" + url.PathEscape(name) + "/executions/" + url.PathEscape(executionID)
+	return c.do(ctx, request{method: http.MethodDelete, path: path}, nil)
+}
@@ -0,0 +1,131 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/executor/output"
+)
+
+// ListTestSuites returns test suites matching options
+func (c *Client) ListTestSuites(ctx context.Context, options ListOptions) (testSuites []testkube.TestSuite, err error) {
+	return testSuites, c.do(ctx, request{method: http.MethodGet, path: "/test-suites", query: options.query()}, &testSuites)
+}
+
+// ListTestSuitesWithExecutions returns test suites matching options with their latest executions
+func (c *Client) ListTestSuitesWithExecutions(ctx context.Context, options ListOptions) (testSuites []testkube.TestSuiteWithExecution, err error) {
+	return testSuites, c.do(ctx, request{method: http.MethodGet, path: "/test-suite-with-executions", query: options.query()}, &testSuites)
+}
+
+// GetTestSuite returns test suite by name
+func (c *Client) GetTestSuite(ctx context.Context, name string) (testSuite testkube.TestSuite, err error) {
+	return testSuite, c.do(ctx, request{method: http.MethodGet, path: "/test-suites/" + url.PathEscape(name)}, &testSuite)
+}
+
+// GetTestSuiteWithExecution returns test suite by name with its latest execution
+func (c *Client) GetTestSuiteWithExecution(ctx context.Context, name string) (testSuite testkube.TestSuiteWithExecution, err error) {
+	return testSuite, c.do(ctx, request{method: http.MethodGet, path: "/test-suite-with-executions/" + url.PathEscape(name)}, &testSuite)
+}
+
+// CreateTestSuite creates test suite
+func (c *Client) CreateTestSuite(ctx context.Context, upsert testkube.TestSuiteUpsertRequest) (testSuite testkube.TestSuite, err error) {
+	return testSuite, c.do(ctx, request{method: http.MethodPost, path: "/test-suites", body: upsert}, &testSuite)
+}
+
+// UpdateTestSuite updates test suite of request name
+func (c *Client) UpdateTestSuite(ctx context.Context, upsert testkube.TestSuiteUpsertRequest) (testSuite testkube.TestSuite, err error) {
+	return testSuite, c.do(ctx, request{method: http.MethodPatch, path: "/test-suites/" + url.PathEscape(upsert.Name), body: upsert}, &testSuite)
+}
+
+// DeleteTestSuite deletes test suite by name
+func (c *Client) DeleteTestSuite(ctx context.Context, name string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: "/test-suites/" + url.PathEscape(name)}, nil)
+}
+
+// DeleteTestSuites deletes test suites matching selector, all test suites are deleted for empty selector
+func (c *Client) DeleteTestSuites(ctx context.Context, selector string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: "/test-suites", query: selectorQuery(selector)}, nil)
+}
+
+// ExecuteTestSuite starts execution of test suite
+func (c *Client) ExecuteTestSuite(ctx context.Context, name string, executionRequest testkube.TestSuiteExecutionRequest,
+	options ExecuteOptions) (execution testkube.TestSuiteExecution, err error) {
+	path := "/test-suites/" + url.PathEscape(name) + "/executions"
+	return execution, c.do(ctx, request{method: http.MethodPost, path: path, query: options.query(), body: executionRequest}, &execution)
+}
+
+// ExecuteTestSuites starts executions of test suites matching selector
+func (c *Client) ExecuteTestSuites(ctx context.Context, selector string, executionRequest testkube.TestSuiteExecutionRequest,
+	options ExecuteOptions) (executions []testkube.TestSuiteExecution, err error) {
+	query := options.query()
+	setParam(query, "selector", selector)
+	return executions, c.do(ctx, request{method: http.MethodPost, path: "/test-suite-executions", query: query, body: executionRequest}, &executions)
+}
+
+// ListTestSuiteExecutions returns test suite executions matching options, test name filters executions of test suite
+func (c *Client) ListTestSuiteExecutions(ctx context.Context, options ExecutionListOptions) (executions testkube.TestSuiteExecutionsResult, err error) {
+	// test suite executions are filtered by test suite name in id param
+	query := options.query()
+	query.Del("testName")
+	setParam(query, "id", options.TestName)
+	return executions, c.do(ctx, request{method: http.MethodGet, path: "/test-suite-executions", query: query}, &executions)
+}
+
+// GetTestSuiteExecution returns test suite execution by id or name
+func (c *Client) GetTestSuiteExecution(ctx context.Context, id string) (execution testkube.TestSuiteExecution, err error) {
+	return execution, c.do(ctx, request{method: http.MethodGet, path: "/test-suite-executions/" + url.PathEscape(id)}, &execution)
+}
+
+// AbortTestSuiteExecution aborts test suite execution
+func (c *Client) AbortTestSuiteExecution(ctx context.Context, id string) error {
+	return c.do(ctx, request{method: http.MethodPost, path: "/test-suite-executions/" + url.PathEscape(id) + "/abort"}, nil)
+}
+
+// RerunTestSuiteExecution starts new execution of finished test suite execution from its first failed step
+func (c *Client) RerunTestSuiteExecution(ctx context.Context, id string) (execution testkube.TestSuiteExecution, err error) {
+	query := url.Values{"from": []string{"failed"}}
+	path := "/test-suite-executions/" + url.PathEscape(id) + "/rerun"
+	return execution, c.do(ctx, request{method: http.MethodPost, path: path, query: query}, &execution)
+}
+
+// ApproveTestSuiteStep records decision of test suite execution approval step
+func (c *Client) ApproveTestSuiteStep(ctx context.Context, id string, step int,
+	decision testkube.TestSuiteStepApprovalDecision) (result testkube.TestSuiteStepExecutionResult, err error) {
+	path := fmt.Sprintf("/test-suite-executions/%s/steps/%d/approve", url.PathEscape(id), step)
+	return result, c.do(ctx, request{method: http.MethodPost, path: path, body: decision}, &result)
+}
+
+// GetComplianceReport returns compliance report of test suite execution
+func (c *Client) GetComplianceReport(ctx context.Context, id string) (report testkube.ComplianceReport, err error) {
+	return report, c.do(ctx, request{method: http.MethodGet, path: "/test-suite-executions/" + url.PathEscape(id) + "/compliance-report"}, &report)
+}
+
+// StreamTestSuiteExecutionLogs calls handler with logs of consecutive test suite execution steps until execution
+// ends, context is cancelled or handler returns error
+func (c *Client) StreamTestSuiteExecutionLogs(ctx context.Context, id string, handler func(out output.Output) error) error {
+	return c.stream(ctx, "/test-suite-executions/"+url.PathEscape(id)+"/logs", func(data []byte) error {
+		out, err := output.GetLogEntry(data)
+		if err != nil {
+			return nil
+		}
+
+		return handler(out)
+	})
+}
+
+// WatchTestSuiteExecution calls handler with test suite execution whenever its status or status of its steps
+// changes until execution is completed, context is cancelled or handler returns error
+func (c *Client) WatchTestSuiteExecution(ctx context.Context, id string, handler func(execution testkube.TestSuiteExecution) error) error {
+	return c.stream(ctx, "/test-suite-executions/"+url.PathEscape(id)+"/watch", func(data []byte) error {
+		var execution testkube.TestSuiteExecution
+		if err := json.Unmarshal(data, &execution); err != nil {
+			return nil
+		}
+
+		return handler(execution)
+	})
+}
@@ -1,11 +1,31 @@
 package server
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Config for HTTP server
 type Config struct {
 	Port     int
 	Fullname string
+	// BodyLimit is max request body size in bytes, fiber default (4MB) is used when not set
+	BodyLimit int
+	// RateLimitWindow is a time window for rate limits
+	RateLimitWindow time.Duration `default:"1m"`
+	// ExecutionRateLimit is max number of execution triggering requests per client in time window, 0 disables limit
+	ExecutionRateLimit int
+	// ReadRateLimit is max number of read requests per client in time window, 0 disables limit
+	ReadRateLimit int
+	// TrustedProxies are IPs or CIDRs of reverse proxies which client IP header is trusted, client IP is
+	// read from connection when not set
+	TrustedProxies []string
+	// ProxyHeader is a header with client IP set by trusted proxies
+	ProxyHeader string `default:"X-Forwarded-For"`
+	// RequestValidation enables validation of request query params and JSON bodies against OpenAPI document
+	RequestValidation bool `default:"true"`
+	// ShutdownTimeout is max time of waiting for in-flight requests on shutdown
+	ShutdownTimeout time.Duration `default:"30s"`
 }
 
 // Addr returns port based address
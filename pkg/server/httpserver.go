@@ -2,6 +2,8 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/gofiber/adaptor/v2"
 	"github.com/gofiber/fiber/v2"
@@ -14,7 +16,7 @@ import (
 // NewServer returns new HTTP server instance, initializes logger and metrics
 func NewServer(config Config) HTTPServer {
 	s := HTTPServer{
-		Mux:    fiber.New(),
+		Mux:    fiber.New(newFiberConfig(config)),
 		Log:    log.DefaultLogger,
 		Config: config,
 	}
@@ -34,11 +36,9 @@ type HTTPServer struct {
 // Init initializes router and setting up basic routes for health and metrics
 func (s *HTTPServer) Init() {
 
-	// global log for requests
-	s.Mux.Use(func(c *fiber.Ctx) error {
-		s.Log.Debugw("request", "method", string(c.Request().Header.Method()), "path", c.Request().URI().String())
-		return c.Next()
-	})
+	// request ids are set before logging, so all request log lines can be correlated
+	s.Mux.Use(RequestIDMiddleware())
+	s.Mux.Use(s.requestLogMiddleware())
 
 	// server generic endpoints
 	s.Mux.Get("/health", s.HealthEndpoint())
@@ -53,20 +53,31 @@ func (s *HTTPServer) Init() {
 
 // Warn writes RFC-7807 json problem to response
 func (s *HTTPServer) Warn(c *fiber.Ctx, status int, err error, context ...interface{}) error {
-	c.Status(status)
-	c.Response().Header.Set("Content-Type", "application/problem+json")
-	s.Log.Warnw(err.Error(), "status", status)
-	pr := problem.New(status, s.getProblemMessage(err, context))
-	return c.JSON(pr)
+	code := problem.CodeOf(err, status)
+	s.Logger(c.Context()).Warnw(err.Error(), "status", status, "code", code)
+	return s.writeProblem(c, status, code, err, context)
 }
 
 // Error writes RFC-7807 json problem to response
 func (s *HTTPServer) Error(c *fiber.Ctx, status int, err error, context ...interface{}) error {
+	code := problem.CodeOf(err, status)
+	s.Logger(c.Context()).Errorw(err.Error(), "status", status, "code", code)
+	return s.writeProblem(c, status, code, err, context)
+}
+
+// writeProblem writes RFC-7807 json problem with machine readable code
+func (s *HTTPServer) writeProblem(c *fiber.Ctx, status int, code problem.Code, err error, context []interface{}) error {
+	pr := problem.New(status, s.getProblemMessage(err, context...))
+	pr.Code = code
+	pr.RequestId = RequestID(c.Context())
+	body, err := json.Marshal(pr)
+	if err != nil {
+		return err
+	}
+
 	c.Status(status)
 	c.Response().Header.Set("Content-Type", "application/problem+json")
-	s.Log.Errorw(err.Error(), "status", status)
-	pr := problem.New(status, s.getProblemMessage(err, context))
-	return c.JSON(pr)
+	return c.Send(body)
 }
 
 // getProblemMessage creates new JSON based problem message and returns it as string
@@ -86,3 +97,30 @@ func (s *HTTPServer) getProblemMessage(err error, context ...interface{}) string
 func (s HTTPServer) Run() error {
 	return s.Mux.Listen(s.Config.Addr())
 }
+
+// Shutdown stops accepting new connections and waits for in-flight requests until timeout passes
+func (s HTTPServer) Shutdown(timeout time.Duration) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Mux.Shutdown()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("in-flight requests not finished in %s", timeout)
+	}
+}
+
+// newFiberConfig returns fiber config, proxy header is only read from requests of trusted proxies
+func newFiberConfig(config Config) fiber.Config {
+	fiberConfig := fiber.Config{BodyLimit: config.BodyLimit}
+	if len(config.TrustedProxies) > 0 {
+		fiberConfig.ProxyHeader = config.ProxyHeader
+		fiberConfig.EnableTrustedProxyCheck = true
+		fiberConfig.TrustedProxies = config.TrustedProxies
+	}
+
+	return fiberConfig
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/problem"
+)
+
+func TestProblemResponses(t *testing.T) {
+	s := NewServer(Config{})
+	s.Mux.Get("/tests/:name", func(c *fiber.Ctx) error {
+		return s.Warn(c, http.StatusNotFound, problem.WithCode(problem.CodeTestNotFound, fmt.Errorf("test %s not found", c.Params("name"))))
+	})
+	s.Mux.Get("/fail", func(c *fiber.Ctx) error {
+		return s.Error(c, http.StatusInternalServerError, fmt.Errorf("storage unavailable"))
+	})
+
+	resp, err := s.Mux.Test(httptest.NewRequest(http.MethodGet, "/tests/api", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "application/problem+json", resp.Header.Get(fiber.HeaderContentType))
+
+	var pr problem.Problem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&pr))
+	assert.Equal(t, problem.CodeTestNotFound, pr.Code)
+	assert.Equal(t, "test api not found", pr.Detail)
+	assert.Equal(t, http.StatusNotFound, pr.Status)
+
+	resp, err = s.Mux.Test(httptest.NewRequest(http.MethodGet, "/fail", nil))
+	require.NoError(t, err)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&pr))
+	assert.Equal(t, problem.CodeInternal, pr.Code, "errors without code get code of status")
+}
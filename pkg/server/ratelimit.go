@@ -0,0 +1,95 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+)
+
+// RateLimiter returns middleware limiting number of requests per client in given time window,
+// every limiter has its own budget, limiter is disabled when max is not positive
+func (s HTTPServer) RateLimiter(max int, window time.Duration, next func(c *fiber.Ctx) bool) fiber.Handler {
+	if max <= 0 {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	return limiter.New(limiter.Config{
+		Next:         next,
+		Max:          max,
+		Expiration:   window,
+		KeyGenerator: newClientKeys(s.Config).ClientKey,
+		// Retry-After header is set by limiter before calling this handler
+		LimitReached: func(c *fiber.Ctx) error {
+			return s.Warn(c, http.StatusTooManyRequests, fmt.Errorf("rate limit of %d requests per %s exceeded", max, window))
+		},
+	})
+}
+
+// clientKeys identify clients for rate limiting
+type clientKeys struct {
+	proxies     []*net.IPNet
+	proxyHeader string
+}
+
+// newClientKeys returns client identifiers of server config, invalid trusted proxies are skipped
+func newClientKeys(config Config) clientKeys {
+	keys := clientKeys{proxyHeader: config.ProxyHeader}
+	for _, proxy := range config.TrustedProxies {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			keys.proxies = append(keys.proxies, network)
+		}
+	}
+
+	return keys
+}
+
+// ClientKey returns client identifier for rate limiting, it's client IP as bearer tokens aren't verified by server.
+// Proxy header of trusted proxies is read hop by hop from the right and client IP is the rightmost address which
+// is not a trusted proxy, so clients can't get new budget by prepending addresses to the header
+func (k clientKeys) ClientKey(c *fiber.Ctx) string {
+	ip := c.Context().RemoteIP()
+	if !k.isTrusted(ip) || k.proxyHeader == "" {
+		return "ip:" + ip.String()
+	}
+
+	hops := strings.Split(c.Get(k.proxyHeader), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+
+		ip = hop
+		if !k.isTrusted(hop) {
+			break
+		}
+	}
+
+	return "ip:" + ip.String()
+}
+
+// isTrusted checks if IP is trusted proxy
+func (k clientKeys) isTrusted(ip net.IP) bool {
+	for _, proxy := range k.proxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
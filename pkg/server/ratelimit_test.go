@@ -0,0 +1,93 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+
+	t.Run("requests over limit are rejected with retry after header", func(t *testing.T) {
+		s := NewServer(Config{})
+		s.Mux.Get("/limited", s.RateLimiter(1, time.Minute, nil), func(c *fiber.Ctx) error {
+			return c.SendStatus(http.StatusOK)
+		})
+
+		resp, err := s.Mux.Test(httptest.NewRequest(http.MethodGet, "/limited", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, err = s.Mux.Test(httptest.NewRequest(http.MethodGet, "/limited", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.NotEmpty(t, resp.Header.Get(fiber.HeaderRetryAfter))
+	})
+
+	t.Run("unverified tokens and proxy headers don't change budget", func(t *testing.T) {
+		s := NewServer(Config{})
+		s.Mux.Get("/limited", s.RateLimiter(1, time.Minute, nil), func(c *fiber.Ctx) error {
+			return c.SendStatus(http.StatusOK)
+		})
+
+		codes := []int{}
+		for _, token := range []string{"token1", "token2"} {
+			req := httptest.NewRequest(http.MethodGet, "/limited", nil)
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+			req.Header.Set(fiber.HeaderXForwardedFor, "10.0.0."+token[len(token)-1:])
+			resp, err := s.Mux.Test(req)
+			assert.NoError(t, err)
+			codes = append(codes, resp.StatusCode)
+		}
+		assert.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests}, codes)
+	})
+
+	t.Run("clients behind trusted proxy have separate budgets", func(t *testing.T) {
+		s := NewServer(Config{TrustedProxies: []string{"0.0.0.0"}, ProxyHeader: fiber.HeaderXForwardedFor})
+		s.Mux.Get("/limited", s.RateLimiter(1, time.Minute, nil), func(c *fiber.Ctx) error {
+			return c.SendStatus(http.StatusOK)
+		})
+
+		for _, ip := range []string{"10.0.0.1", "10.0.0.2"} {
+			req := httptest.NewRequest(http.MethodGet, "/limited", nil)
+			req.Header.Set(fiber.HeaderXForwardedFor, ip)
+			resp, err := s.Mux.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+	})
+
+	t.Run("forged proxy header addresses don't change budget", func(t *testing.T) {
+		s := NewServer(Config{TrustedProxies: []string{"0.0.0.0", "10.0.0.0/8"}, ProxyHeader: fiber.HeaderXForwardedFor})
+		s.Mux.Get("/limited", s.RateLimiter(1, time.Minute, nil), func(c *fiber.Ctx) error {
+			return c.SendStatus(http.StatusOK)
+		})
+
+		codes := []int{}
+		for _, forged := range []string{"1.2.3.4", "5.6.7.8, 9.9.9.9", "invalid"} {
+			req := httptest.NewRequest(http.MethodGet, "/limited", nil)
+			req.Header.Set(fiber.HeaderXForwardedFor, forged+", 192.168.1.5, 10.0.0.7")
+			resp, err := s.Mux.Test(req)
+			assert.NoError(t, err)
+			codes = append(codes, resp.StatusCode)
+		}
+		assert.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests}, codes)
+	})
+
+	t.Run("limiter is disabled when max is not set", func(t *testing.T) {
+		s := NewServer(Config{})
+		s.Mux.Get("/limited", s.RateLimiter(0, time.Minute, nil), func(c *fiber.Ctx) error {
+			return c.SendStatus(http.StatusOK)
+		})
+
+		for i := 0; i < 3; i++ {
+			resp, err := s.Mux.Test(httptest.NewRequest(http.MethodGet, "/limited", nil))
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		}
+	})
+}
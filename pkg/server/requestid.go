@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	// RequestIDHeader is a header with id of API request, ids sent by clients are propagated to responses
+	RequestIDHeader = "X-Request-ID"
+	// requestIDLocal is a key of request id in request locals, fasthttp request context values are read by string keys
+	requestIDLocal = "requestId"
+	// maxRequestIDLength is max length of request ids sent by clients
+	maxRequestIDLength = 128
+)
+
+// requestIDKey is a context key of request id of background work started by request
+type requestIDKey struct{}
+
+// RequestIDMiddleware sets id of request to request locals and response header, id is generated for requests
+// without valid one
+func RequestIDMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id := c.Get(RequestIDHeader)
+		if !isValidRequestID(id) {
+			id = utils.UUIDv4()
+		}
+
+		c.Locals(requestIDLocal, id)
+		c.Set(RequestIDHeader, id)
+		return c.Next()
+	}
+}
+
+// RequestID returns id of request from request context, e.g. fiber.Ctx.Context(), or empty string when it's not set
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return id
+	}
+
+	id, _ := ctx.Value(requestIDLocal).(string)
+	return id
+}
+
+// WithRequestID returns context with request id, e.g. for background work outliving request
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// Logger returns logger with request id of context
+func (s *HTTPServer) Logger(ctx context.Context) *zap.SugaredLogger {
+	if id := RequestID(ctx); id != "" {
+		return s.Log.With("requestId", id)
+	}
+
+	return s.Log
+}
+
+// requestLogMiddleware logs requests with their status and duration
+func (s *HTTPServer) requestLogMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		s.Logger(c.Context()).Debugw("request",
+			"method", c.Method(),
+			"path", c.Request().URI().String(),
+			"status", c.Response().StatusCode(),
+			"duration", time.Since(start).String(),
+		)
+
+		return err
+	}
+}
+
+// isValidRequestID checks if request id sent by client can be logged and returned in header
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range id {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/problem"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	s := NewServer(Config{})
+	s.Mux.Get("/id", func(c *fiber.Ctx) error {
+		return c.SendString(RequestID(c.Context()))
+	})
+	s.Mux.Get("/fail", func(c *fiber.Ctx) error {
+		return s.Warn(c, http.StatusNotFound, fmt.Errorf("test api not found"))
+	})
+
+	send := func(path, id string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if id != "" {
+			req.Header.Set(RequestIDHeader, id)
+		}
+
+		resp, err := s.Mux.Test(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("client request id is propagated", func(t *testing.T) {
+		resp := send("/id", "support-1234")
+		assert.Equal(t, "support-1234", resp.Header.Get(RequestIDHeader))
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "support-1234", string(body))
+	})
+
+	t.Run("request id is generated", func(t *testing.T) {
+		resp := send("/id", "")
+		assert.Len(t, resp.Header.Get(RequestIDHeader), 36)
+
+		resp = send("/id", strings.Repeat("x", maxRequestIDLength+1))
+		assert.Len(t, resp.Header.Get(RequestIDHeader), 36, "too long ids are replaced")
+	})
+
+	t.Run("problems contain request id", func(t *testing.T) {
+		resp := send("/fail", "support-1234")
+
+		var pr problem.Problem
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&pr))
+		assert.Equal(t, "support-1234", pr.RequestId)
+	})
+}
+
+func TestWithRequestID(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "support-1234")
+	assert.Equal(t, "support-1234", RequestID(ctx))
+	assert.Equal(t, "", RequestID(context.Background()))
+	assert.Equal(t, "", RequestID(WithRequestID(context.Background(), "")))
+}
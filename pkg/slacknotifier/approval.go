@@ -0,0 +1,150 @@
+package slacknotifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+const (
+	approveActionID = "approve"
+	rejectActionID  = "reject"
+)
+
+// SendApprovalRequest sends approval request with approve and reject buttons to slack
+func SendApprovalRequest(request testkube.ApprovalRequest) error {
+	if notifier == nil {
+		return nil
+	}
+
+	return notifier.NotifyApproval(request)
+}
+
+// NotifyApproval sends approval request message to channels routed by test suite execution labels
+func (n *Notifier) NotifyApproval(request testkube.ApprovalRequest) error {
+	blocks, text := newApprovalMessage(request)
+
+	var err error
+	for _, channel := range channels(n.Routes, n.DefaultChannel, request.Labels) {
+		if _, _, postErr := n.Client.PostMessage(channel, slack.MsgOptionBlocks(blocks...), slack.MsgOptionText(text, false)); postErr != nil {
+			err = postErr
+		}
+	}
+
+	return err
+}
+
+// newApprovalMessage returns Block Kit blocks and notification text of approval request, buttons values
+// identify approval step and are posted to interactivity request URL of Slack app
+func newApprovalMessage(request testkube.ApprovalRequest) ([]slack.Block, string) {
+	title := fmt.Sprintf(":raised_hand: %s waits for approval", request.TestSuiteName)
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, title, true, false)),
+	}
+
+	fields := []*slack.TextBlockObject{
+		field("Execution", request.TestSuiteExecutionName),
+		field("Step", strconv.Itoa(int(request.Step))),
+	}
+	if request.Timeout > 0 {
+		fields = append(fields, field("Timeout", (time.Duration(request.Timeout)*time.Second).String()))
+	}
+	blocks = append(blocks, slack.NewSectionBlock(nil, fields, nil))
+
+	if request.Message != "" {
+		blocks = append(blocks, slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, request.Message, false, false), nil, nil))
+	}
+
+	value := approvalValue(request.TestSuiteExecutionId, int(request.Step))
+	approve := slack.NewButtonBlockElement(approveActionID, value, slack.NewTextBlockObject(slack.PlainTextType, "Approve", false, false))
+	reject := slack.NewButtonBlockElement(rejectActionID, value, slack.NewTextBlockObject(slack.PlainTextType, "Reject", false, false))
+	blocks = append(blocks,
+		slack.NewActionBlock("", approve.WithStyle(slack.StylePrimary), reject.WithStyle(slack.StyleDanger)),
+		slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType,
+			fmt.Sprintf("or run `kubectl testkube approve testsuiteexecution %s %d`", request.TestSuiteExecutionId, request.Step), false, false)),
+	)
+
+	return blocks, title
+}
+
+// ApprovalInteraction is an approval decision made with Slack message button
+type ApprovalInteraction struct {
+	TestSuiteExecutionId string
+	Step                 int
+	Decision             testkube.TestSuiteStepApprovalDecision
+}
+
+// ParseApprovalInteraction verifies Slack interaction request signature and returns decision of clicked approval button
+func ParseApprovalInteraction(header http.Header, body []byte) (interaction ApprovalInteraction, err error) {
+	return parseApprovalInteraction(header, body, signingSecret)
+}
+
+func parseApprovalInteraction(header http.Header, body []byte, secret string) (interaction ApprovalInteraction, err error) {
+	if secret == "" {
+		return interaction, fmt.Errorf("slack signing secret isn't configured")
+	}
+
+	verifier, err := slack.NewSecretsVerifier(header, secret)
+	if err != nil {
+		return interaction, err
+	}
+
+	if _, err = verifier.Write(body); err != nil {
+		return interaction, err
+	}
+
+	if err = verifier.Ensure(); err != nil {
+		return interaction, err
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return interaction, err
+	}
+
+	var callback slack.InteractionCallback
+	if err = json.Unmarshal([]byte(form.Get("payload")), &callback); err != nil {
+		return interaction, fmt.Errorf("invalid slack interaction payload: %w", err)
+	}
+
+	for _, action := range callback.ActionCallback.BlockActions {
+		if action.ActionID != approveActionID && action.ActionID != rejectActionID {
+			continue
+		}
+
+		executionID, step, ok := strings.Cut(action.Value, "/")
+		if !ok {
+			return interaction, fmt.Errorf("invalid approval action value: %s", action.Value)
+		}
+
+		if interaction.Step, err = strconv.Atoi(step); err != nil {
+			return interaction, fmt.Errorf("invalid approval action value: %s", action.Value)
+		}
+
+		approver := callback.User.Name
+		if approver == "" {
+			approver = callback.User.ID
+		}
+
+		interaction.TestSuiteExecutionId = executionID
+		interaction.Decision = testkube.TestSuiteStepApprovalDecision{
+			Approved: action.ActionID == approveActionID,
+			Approver: "slack:" + approver,
+		}
+		return interaction, nil
+	}
+
+	return interaction, fmt.Errorf("slack interaction has no approval action")
+}
+
+func approvalValue(executionID string, step int) string {
+	return fmt.Sprintf("%s/%d", executionID, step)
+}
@@ -0,0 +1,76 @@
+package slacknotifier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func signedInteraction(secret, payload string) (http.Header, []byte) {
+	body := []byte("payload=" + url.QueryEscape(payload))
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:%s", timestamp, body)
+
+	header := http.Header{}
+	header.Set("X-Slack-Request-Timestamp", timestamp)
+	header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return header, body
+}
+
+func TestParseApprovalInteraction(t *testing.T) {
+	payload := `{"type": "block_actions", "user": {"id": "U1", "name": "jane"},
+		"actions": [{"type": "button", "block_id": "b1", "action_id": "reject", "value": "62f1/2"}]}`
+
+	t.Run("signed interaction is parsed", func(t *testing.T) {
+		header, body := signedInteraction("secret", payload)
+		interaction, err := parseApprovalInteraction(header, body, "secret")
+
+		require.NoError(t, err)
+		assert.Equal(t, "62f1", interaction.TestSuiteExecutionId)
+		assert.Equal(t, 2, interaction.Step)
+		assert.Equal(t, testkube.TestSuiteStepApprovalDecision{Approved: false, Approver: "slack:jane"}, interaction.Decision)
+	})
+
+	t.Run("interaction with invalid signature is rejected", func(t *testing.T) {
+		header, body := signedInteraction("other", payload)
+		_, err := parseApprovalInteraction(header, body, "secret")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("interactions aren't accepted without signing secret", func(t *testing.T) {
+		header, body := signedInteraction("", payload)
+		_, err := parseApprovalInteraction(header, body, "")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNotifyApproval(t *testing.T) {
+	routes, err := ParseRoutes(`[{"selector": "team=checkout", "channel": "checkout"}]`)
+	require.NoError(t, err)
+	client := &fakeClient{}
+	n := NewNotifier(client, "default", routes, "")
+
+	err = n.NotifyApproval(testkube.ApprovalRequest{TestSuiteExecutionId: "62f1", TestSuiteName: "release", Step: 1,
+		Labels: map[string]string{"team": "checkout"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"checkout"}, client.posted)
+
+	blocks, text := newApprovalMessage(testkube.ApprovalRequest{TestSuiteExecutionId: "62f1", TestSuiteName: "release", Step: 1, Message: "Deploy?"})
+	assert.Equal(t, ":raised_hand: release waits for approval", text)
+	assert.Len(t, blocks, 5)
+}
@@ -0,0 +1,201 @@
+package slacknotifier
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/kubeshop/testkube/pkg/types"
+)
+
+// maxFailedSteps is a number of failed steps listed in the message
+const maxFailedSteps = 5
+
+var statusEmojis = map[testkube.ExecutionStatus]string{
+	testkube.QUEUED_ExecutionStatus:  ":clock3:",
+	testkube.RUNNING_ExecutionStatus: ":arrow_forward:",
+	testkube.PASSED_ExecutionStatus:  ":white_check_mark:",
+	testkube.FAILED_ExecutionStatus:  ":x:",
+	testkube.ABORTED_ExecutionStatus: ":no_entry_sign:",
+	testkube.TIMEOUT_ExecutionStatus: ":alarm_clock:",
+}
+
+// newMessage returns Block Kit blocks and notification text of execution event, buttons link to logs
+// and artifacts when linksURI is set
+func newMessage(eventType *testkube.WebhookEventType, execution testkube.Execution, linksURI string) ([]slack.Block, string) {
+	var status testkube.ExecutionStatus
+	if execution.ExecutionResult != nil && execution.ExecutionResult.Status != nil {
+		status = *execution.ExecutionResult.Status
+	}
+
+	title := fmt.Sprintf("%s %s %s", statusEmoji(status), execution.TestName, status)
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, title, true, false)),
+	}
+
+	executionName := execution.Name
+	if execution.Number > 0 {
+		executionName = fmt.Sprintf("#%d %s", execution.Number, execution.Name)
+	}
+
+	fields := []*slack.TextBlockObject{
+		field("Event Type", eventType.String()),
+		field("Execution", executionName),
+		field("Test Type", execution.TestType),
+	}
+	if execution.TestNamespace != "" {
+		fields = append(fields, field("Namespace", execution.TestNamespace))
+	}
+	if !execution.StartTime.IsZero() {
+		fields = append(fields, field("Start Time", execution.StartTime.String()))
+	}
+	if execution.Duration != "" {
+		fields = append(fields, field("Duration", types.FormatDuration(execution.Duration)))
+	}
+	// owners are listed so on-call knows who to ping about failure
+	if owner := execution.Ownership.String(); owner != "" {
+		fields = append(fields, field("Owner", owner))
+	}
+	if execution.Ownership != nil && execution.Ownership.Contact != "" {
+		fields = append(fields, field("Contact", execution.Ownership.Contact))
+	}
+	blocks = append(blocks, slack.NewSectionBlock(nil, fields, nil))
+
+	if summary := failureSummary(execution.ExecutionResult); summary != "" {
+		blocks = append(blocks, slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, summary, false, false), nil, nil))
+	}
+
+	if linksURI != "" {
+		executionURI := strings.TrimSuffix(linksURI, "/") + "/v1/executions/" + execution.Id
+		blocks = append(blocks, slack.NewActionBlock("",
+			button("logs", "Logs", executionURI+"/logs"),
+			button("artifacts", "Artifacts", executionURI+"/artifacts"),
+		))
+	}
+
+	return blocks, title
+}
+
+// failureSummary lists failed steps with first failed assertion, execution error is used when no step failed
+func failureSummary(result *testkube.ExecutionResult) string {
+	if result == nil {
+		return ""
+	}
+
+	var lines []string
+	for _, step := range result.Steps {
+		if step.Status != string(testkube.FAILED_ExecutionStatus) {
+			continue
+		}
+
+		if len(lines) == maxFailedSteps {
+			lines = append(lines, "• ...")
+			break
+		}
+
+		line := "• " + step.Name
+		for _, assertion := range step.AssertionResults {
+			if assertion.Status == string(testkube.FAILED_ExecutionStatus) && assertion.ErrorMessage != "" {
+				line += ": " + assertion.ErrorMessage
+				break
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	if len(lines) != 0 {
+		return "*Failed Steps:*\n" + strings.Join(lines, "\n")
+	}
+
+	if result.ErrorMessage != "" {
+		return "*Error:*\n" + result.ErrorMessage
+	}
+
+	return ""
+}
+
+// newTestSuiteMessage returns Block Kit blocks and notification text of finished test suite execution
+func newTestSuiteMessage(execution testkube.TestSuiteExecution) ([]slack.Block, string) {
+	var status testkube.TestSuiteExecutionStatus
+	if execution.Status != nil {
+		status = *execution.Status
+	}
+
+	var testSuiteName string
+	if execution.TestSuite != nil {
+		testSuiteName = execution.TestSuite.Name
+	}
+
+	title := fmt.Sprintf("%s %s %s", statusEmoji(testkube.ExecutionStatus(status)), testSuiteName, status)
+	fields := []*slack.TextBlockObject{
+		field("Event Type", testkube.WebhookTypeEndTestSuite.String()),
+		field("Execution", execution.Name),
+	}
+	if execution.Duration != "" {
+		fields = append(fields, field("Duration", types.FormatDuration(execution.Duration)))
+	}
+
+	return []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, title, true, false)),
+		slack.NewSectionBlock(nil, fields, nil),
+	}, title
+}
+
+func field(name, value string) *slack.TextBlockObject {
+	return slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*%s:*\n%s", name, value), false, false)
+}
+
+func button(actionID, text, url string) *slack.ButtonBlockElement {
+	b := slack.NewButtonBlockElement(actionID, "", slack.NewTextBlockObject(slack.PlainTextType, text, false, false))
+	b.URL = url
+	return b
+}
+
+// maxDigestExecutions is a number of not passed executions listed in digest message
+const maxDigestExecutions = 10
+
+// newDigestMessage returns Block Kit blocks and notification text of executions digest
+func newDigestMessage(digest testkube.ExecutionsDigest) ([]slack.Block, string) {
+	title := fmt.Sprintf(":bar_chart: Testkube digest: %d passed, %d failed", digest.Passed, digest.Failed)
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, title, true, false)),
+		slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType,
+			fmt.Sprintf("%d executions from %s to %s", len(digest.Executions),
+				digest.StartTime.Format(time.RFC3339), digest.EndTime.Format(time.RFC3339)), false, false)),
+	}
+
+	var lines []string
+	for _, execution := range digest.Executions {
+		if execution.Status == nil || *execution.Status == testkube.PASSED_ExecutionStatus {
+			continue
+		}
+
+		if len(lines) == maxDigestExecutions {
+			lines = append(lines, "• ...")
+			break
+		}
+
+		name := execution.Name
+		if execution.Number > 0 {
+			name = fmt.Sprintf("#%d", execution.Number)
+		}
+		lines = append(lines, fmt.Sprintf("• %s %s %s %s", statusEmoji(*execution.Status), execution.TestName, name, *execution.Status))
+	}
+
+	if len(lines) != 0 {
+		blocks = append(blocks, slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, strings.Join(lines, "\n"), false, false), nil, nil))
+	}
+
+	return blocks, title
+}
+
+func statusEmoji(status testkube.ExecutionStatus) string {
+	if emoji, ok := statusEmojis[status]; ok {
+		return emoji
+	}
+
+	return ":grey_question:"
+}
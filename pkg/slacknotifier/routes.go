@@ -0,0 +1,61 @@
+package slacknotifier
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Route sends events of executions matching label selector to a channel
+type Route struct {
+	Selector string `json:"selector"`
+	Channel  string `json:"channel"`
+
+	selector labels.Selector
+}
+
+// ParseRoutes parses JSON list of routes, e.g. [{"selector": "team=checkout", "channel": "C0123"}]
+func ParseRoutes(data string) ([]Route, error) {
+	if data == "" {
+		return nil, nil
+	}
+
+	var routes []Route
+	if err := json.Unmarshal([]byte(data), &routes); err != nil {
+		return nil, fmt.Errorf("parsing slack routes: %w", err)
+	}
+
+	for i := range routes {
+		if routes[i].Channel == "" {
+			return nil, fmt.Errorf("slack route %q has no channel", routes[i].Selector)
+		}
+
+		selector, err := labels.Parse(routes[i].Selector)
+		if err != nil {
+			return nil, fmt.Errorf("parsing slack route %q selector: %w", routes[i].Selector, err)
+		}
+		routes[i].selector = selector
+	}
+
+	return routes, nil
+}
+
+// channels returns channels of all routes matching execution labels, default channel is used when no route matches
+func channels(routes []Route, defaultChannel string, executionLabels map[string]string) (result []string) {
+	seen := map[string]bool{}
+	for _, route := range routes {
+		if route.selector == nil || !route.selector.Matches(labels.Set(executionLabels)) || seen[route.Channel] {
+			continue
+		}
+
+		seen[route.Channel] = true
+		result = append(result, route.Channel)
+	}
+
+	if len(result) == 0 && defaultChannel != "" {
+		result = append(result, defaultChannel)
+	}
+
+	return result
+}
@@ -1,137 +1,106 @@
 package slacknotifier
 
 import (
-	"bytes"
-	"encoding/json"
+	"net/http"
 	"os"
-	"text/template"
+	"sync"
+	"time"
 
 	"github.com/slack-go/slack"
 
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	digestpkg "github.com/kubeshop/testkube/pkg/digest"
+	"github.com/kubeshop/testkube/pkg/log"
 )
 
-// can be generated here https://app.slack.com/block-kit-builder
-const messageTemplate string = `{
-	"blocks": [
-		{
-			"type": "header",
-			"text": {
-				"type": "plain_text",
-				"text": "Teskube activity",
-				"emoji": true
-			}
-		},
-		{
-			"type": "section",
-			"fields": [
-				{
-					"type": "mrkdwn",
-					"text": "*Event Type:*\n{{ .EventType }}"
-				}
-				{{ if .Namespace }}
-				,
-				{
-					"type": "mrkdwn",
-					"text": "*Namespace:*\n{{ .Namespace }}"
-				}
-				{{ end }}
-			]
-		},
-		{
-			"type": "section",
-			"fields": [
-				{
-					"type": "mrkdwn",
-					"text": "*Test Name:*\n{{ .TestName }}"
-				},
-				{
-					"type": "mrkdwn",
-					"text": "*Test Type:*\n{{ .TestType }}"
-				}
-			]
-		},
-		{
-			"type": "section",
-			"fields": [
-				{
-					"type": "mrkdwn",
-					"text": "*Status:*\n{{ .Status }}"
-				}
-			]
-		},
-		{
-			"type": "section",
-			"fields": [
-				{
-					"type": "mrkdwn",
-					"text": "*Start Time:*\n{{ .StartTime }}"
-				},
-				{
-					"type": "mrkdwn",
-					"text": "*End Time:*\n{{ .EndTime }}"
-				}
-			]
-		}
-		{{ if .Duration }}
-		,
-		{
-			"type": "section",
-			"fields": [
-				{
-					"type": "mrkdwn",
-					"text": "*Duration:*\n{{ .Duration }}"
-				}
-			]
-		}
-		{{ end }}
-		{{ if .Output }}
-		,
-		{
-			"type": "section",
-			"fields": [
-				{
-					"type": "mrkdwn",
-					"text": "*Output:*\n{{ .Output }}"
-				}
-			]
-		}
-		{{ end }}
-	]
-}`
+// slackClient is a part of Slack API used by notifier
+type slackClient interface {
+	PostMessage(channelID string, options ...slack.MsgOption) (string, string, error)
+	UpdateMessage(channelID, timestamp string, options ...slack.MsgOption) (string, string, string, error)
+}
 
-type messageArgs struct {
-	EventType string
-	Namespace string
-	TestName  string
-	TestType  string
-	Status    string
-	StartTime string
-	EndTime   string
-	Duration  string
-	Output    string
+// postedMessage is a start event message updated in place by end event
+type postedMessage struct {
+	// Channel is a routed channel, ChannelID is returned by Slack as messages can be updated only by channel id
+	Channel   string
+	ChannelID string
+	Timestamp string
 }
 
-type client struct {
-	SlackClient *slack.Client
-	ChannelId   string
+// messagesTTL is time start event messages are kept for end event, messages of executions which never end, e.g. of
+// lost executor jobs, are dropped after it
+const messagesTTL = 24 * time.Hour
+
+// startMessages are start event messages of execution posted at the same time
+type startMessages struct {
+	posted   []postedMessage
+	postedAt time.Time
 }
 
-var c *client
+// Notifier sends execution events to channels selected by routes
+type Notifier struct {
+	Client         slackClient
+	DefaultChannel string
+	Routes         []Route
+	// LinksURI is a Testkube API URI used in logs and artifacts buttons
+	LinksURI string
+
+	mutex sync.Mutex
+	// messages are start event messages by execution id
+	messages map[string]startMessages
+}
+
+// NewNotifier returns notifier, events of executions not matching any route are sent to default channel
+func NewNotifier(client slackClient, defaultChannel string, routes []Route, linksURI string) *Notifier {
+	return &Notifier{
+		Client:         client,
+		DefaultChannel: defaultChannel,
+		Routes:         routes,
+		LinksURI:       linksURI,
+		messages:       map[string]startMessages{},
+	}
+}
+
+var (
+	notifier *Notifier
+	token    string
+	// signingSecret verifies interaction requests of Slack app, e.g. approval buttons clicks
+	signingSecret string
+)
 
 func init() {
-	if id, ok := os.LookupEnv("SLACK_CHANNEL_ID"); ok {
-		c = &client{ChannelId: id}
-		if token, ok := os.LookupEnv("SLACK_TOKEN"); ok {
-			c.SlackClient = slack.New(token, slack.OptionDebug(true))
-		}
+	signingSecret = os.Getenv("SLACK_SIGNING_SECRET")
+
+	var ok bool
+	token, ok = os.LookupEnv("SLACK_TOKEN")
+	if !ok {
+		return
 	}
+
+	routes, err := ParseRoutes(os.Getenv("SLACK_ROUTES"))
+	if err != nil {
+		log.DefaultLogger.Errorw("invalid slack routes, only default channel is notified", "error", err)
+	}
+
+	notifier = NewNotifier(slack.New(token, slack.OptionDebug(true)), os.Getenv("SLACK_CHANNEL_ID"), routes, os.Getenv("SLACK_LINKS_URI"))
+}
+
+// SetHTTPClient sets client used to call Slack API, e.g. with proxy or custom CA
+func SetHTTPClient(client *http.Client) {
+	if notifier != nil {
+		notifier.Client = slack.New(token, slack.OptionDebug(true), slack.OptionHTTPClient(client))
+	}
+}
+
+// IsConfigured checks if slack token and channel or routes are configured
+func IsConfigured() bool {
+	return notifier != nil && (notifier.DefaultChannel != "" || len(notifier.Routes) != 0)
 }
 
 // SendMessage posts a message to the slack configured channel
 func SendMessage(message string) error {
-	if c != nil && c.SlackClient != nil {
-		_, _, err := c.SlackClient.PostMessage(c.ChannelId, slack.MsgOptionText(message, false))
+	if notifier != nil && notifier.DefaultChannel != "" {
+		_, _, err := notifier.Client.PostMessage(notifier.DefaultChannel, slack.MsgOptionText(message, false))
 		if err != nil {
 			return err
 		}
@@ -141,39 +110,130 @@ func SendMessage(message string) error {
 
 // SendEvent composes an event message and sends it to slack
 func SendEvent(eventType *testkube.WebhookEventType, execution testkube.Execution) error {
+	if notifier == nil {
+		return nil
+	}
 
-	t, err := template.New("message").Parse(messageTemplate)
-	if err != nil {
-		return err
+	return notifier.Notify(eventType, execution)
+}
+
+// SendDigest sends executions digest to slack
+func SendDigest(digest testkube.ExecutionsDigest) error {
+	if notifier == nil {
+		return nil
 	}
 
-	args := messageArgs{
-		EventType: string(*eventType),
-		Namespace: execution.TestNamespace,
-		TestName:  execution.TestName,
-		TestType:  execution.TestType,
-		Status:    string(*execution.ExecutionResult.Status),
-		StartTime: execution.StartTime.String(),
-		EndTime:   execution.EndTime.String(),
-		Duration:  execution.Duration,
-		Output:    execution.ExecutionResult.Output}
-
-	var message bytes.Buffer
-	err = t.Execute(&message, args)
-	if err != nil {
-		return err
+	return notifier.NotifyDigest(digest)
+}
+
+// SendTestSuiteEvent sends finished test suite execution message to slack
+func SendTestSuiteEvent(execution testkube.TestSuiteExecution) error {
+	if notifier == nil {
+		return nil
 	}
 
-	view := slack.Message{}
-	err = json.Unmarshal(message.Bytes(), &view)
-	if err != nil {
+	return notifier.NotifyTestSuite(execution)
+}
+
+// NotifyTestSuite sends finished test suite execution message to channels routed by test suite execution labels
+func (n *Notifier) NotifyTestSuite(execution testkube.TestSuiteExecution) error {
+	blocks, text := newTestSuiteMessage(execution)
+
+	var err error
+	for _, channel := range channels(n.Routes, n.DefaultChannel, execution.Labels) {
+		if _, _, postErr := n.Client.PostMessage(channel, slack.MsgOptionBlocks(blocks...), slack.MsgOptionText(text, false)); postErr != nil {
+			err = postErr
+		}
+	}
+
+	return err
+}
+
+// NotifyDigest sends single digest message per channel with executions routed to the channel
+func (n *Notifier) NotifyDigest(digest testkube.ExecutionsDigest) error {
+	routed := map[string][]testkube.ExecutionSummary{}
+	var order []string
+	for _, execution := range digest.Executions {
+		for _, channel := range channels(n.Routes, n.DefaultChannel, execution.Labels) {
+			if _, ok := routed[channel]; !ok {
+				order = append(order, channel)
+			}
+			routed[channel] = append(routed[channel], execution)
+		}
+	}
+
+	var err error
+	for _, channel := range order {
+		blocks, text := newDigestMessage(digestpkg.NewDigest(routed[channel], digest.StartTime, digest.EndTime))
+		if _, _, postErr := n.Client.PostMessage(channel, slack.MsgOptionBlocks(blocks...), slack.MsgOptionText(text, false)); postErr != nil {
+			err = postErr
+		}
+	}
+
+	return err
+}
+
+// Notify sends event message to routed channels, start event messages are updated in place by end event
+func (n *Notifier) Notify(eventType *testkube.WebhookEventType, execution testkube.Execution) error {
+	blocks, text := newMessage(eventType, execution, n.LinksURI)
+	options := []slack.MsgOption{slack.MsgOptionBlocks(blocks...), slack.MsgOptionText(text, false)}
+
+	if *eventType == testkube.START_TEST_WebhookEventType {
+		var posted []postedMessage
+		var err error
+		for _, channel := range channels(n.Routes, n.DefaultChannel, execution.Labels) {
+			channelID, timestamp, postErr := n.Client.PostMessage(channel, options...)
+			if postErr != nil {
+				err = postErr
+				continue
+			}
+			posted = append(posted, postedMessage{Channel: channel, ChannelID: channelID, Timestamp: timestamp})
+		}
+
+		now := time.Now()
+		n.mutex.Lock()
+		n.pruneMessages(now)
+		n.messages[execution.Id] = startMessages{posted: posted, postedAt: now}
+		n.mutex.Unlock()
 		return err
 	}
-	if c != nil && c.SlackClient != nil {
-		_, _, err := c.SlackClient.PostMessage(c.ChannelId, slack.MsgOptionBlocks(view.Blocks.BlockSet...))
-		if err != nil {
-			return err
+
+	n.mutex.Lock()
+	posted := n.messages[execution.Id].posted
+	delete(n.messages, execution.Id)
+	n.mutex.Unlock()
+
+	var err error
+	for _, channel := range channels(n.Routes, n.DefaultChannel, execution.Labels) {
+		if message, ok := findMessage(posted, channel); ok {
+			if _, _, _, updateErr := n.Client.UpdateMessage(message.ChannelID, message.Timestamp, options...); updateErr == nil {
+				continue
+			}
+		}
+
+		if _, _, postErr := n.Client.PostMessage(channel, options...); postErr != nil {
+			err = postErr
 		}
 	}
-	return nil
+
+	return err
+}
+
+// pruneMessages drops start event messages older than messagesTTL, notifier mutex has to be locked
+func (n *Notifier) pruneMessages(now time.Time) {
+	for id, messages := range n.messages {
+		if now.Sub(messages.postedAt) > messagesTTL {
+			delete(n.messages, id)
+		}
+	}
+}
+
+func findMessage(messages []postedMessage, channel string) (postedMessage, bool) {
+	for _, message := range messages {
+		if message.Channel == channel {
+			return message, true
+		}
+	}
+
+	return postedMessage{}, false
 }
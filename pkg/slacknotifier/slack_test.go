@@ -0,0 +1,144 @@
+package slacknotifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+type fakeClient struct {
+	posted  []string
+	updated []string
+}
+
+func (c *fakeClient) PostMessage(channelID string, options ...slack.MsgOption) (string, string, error) {
+	c.posted = append(c.posted, channelID)
+	return "id-" + channelID, "ts-" + channelID, nil
+}
+
+func (c *fakeClient) UpdateMessage(channelID, timestamp string, options ...slack.MsgOption) (string, string, string, error) {
+	c.updated = append(c.updated, channelID+"/"+timestamp)
+	return channelID, timestamp, "", nil
+}
+
+func TestParseRoutes(t *testing.T) {
+	t.Run("routes are parsed with selectors", func(t *testing.T) {
+		routes, err := ParseRoutes(`[{"selector": "team=checkout", "channel": "checkout"}, {"selector": "critical=true", "channel": "alerts"}]`)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"checkout", "alerts"}, channels(routes, "default", map[string]string{"team": "checkout", "critical": "true"}))
+		assert.Equal(t, []string{"default"}, channels(routes, "default", map[string]string{"team": "search"}))
+	})
+
+	t.Run("route without channel is invalid", func(t *testing.T) {
+		_, err := ParseRoutes(`[{"selector": "team=checkout"}]`)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNotify(t *testing.T) {
+	routes, err := ParseRoutes(`[{"selector": "team=checkout", "channel": "checkout"}]`)
+	require.NoError(t, err)
+
+	client := &fakeClient{}
+	notifier := NewNotifier(client, "default", routes, "")
+	execution := testkube.Execution{
+		Id:              "exec-1",
+		Labels:          map[string]string{"team": "checkout"},
+		ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusRunning},
+	}
+
+	t.Run("start message is updated in place by end event", func(t *testing.T) {
+		err := notifier.Notify(testkube.WebhookTypeStartTest, execution)
+		require.NoError(t, err)
+
+		execution.ExecutionResult = &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed}
+		err = notifier.Notify(testkube.WebhookTypeEndTest, execution)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"checkout"}, client.posted)
+		assert.Equal(t, []string{"id-checkout/ts-checkout"}, client.updated)
+	})
+
+	t.Run("end event without start message is posted", func(t *testing.T) {
+		err := notifier.Notify(testkube.WebhookTypeEndTest, execution)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"checkout", "checkout"}, client.posted)
+	})
+
+	t.Run("start messages of executions which didn't end expire", func(t *testing.T) {
+		err := notifier.Notify(testkube.WebhookTypeStartTest, execution)
+		require.NoError(t, err)
+
+		notifier.pruneMessages(time.Now())
+		assert.Len(t, notifier.messages, 1)
+
+		notifier.pruneMessages(time.Now().Add(messagesTTL + time.Minute))
+		assert.Empty(t, notifier.messages)
+	})
+}
+
+func TestNewMessage(t *testing.T) {
+	t.Run("failed steps and links are rendered", func(t *testing.T) {
+		blocks, text := newMessage(testkube.WebhookTypeEndTest, testkube.Execution{
+			Id:       "exec-1",
+			TestName: "api-test",
+			ExecutionResult: &testkube.ExecutionResult{
+				Status: testkube.ExecutionStatusFailed,
+				Steps: []testkube.ExecutionStepResult{
+					{Name: "health", Status: "passed"},
+					{Name: "login", Status: "failed", AssertionResults: []testkube.AssertionResult{
+						{Name: "status code is 200", Status: "failed", ErrorMessage: "expected 200 got 401"},
+					}},
+				},
+			},
+		}, "http://testkube.example.com/")
+
+		assert.Equal(t, ":x: api-test failed", text)
+		require.Len(t, blocks, 4)
+		summary := blocks[2].(*slack.SectionBlock)
+		assert.Equal(t, "*Failed Steps:*\n• login: expected 200 got 401", summary.Text.Text)
+		actions := blocks[3].(*slack.ActionBlock)
+		assert.Equal(t, "http://testkube.example.com/v1/executions/exec-1/logs", actions.Elements.ElementSet[0].(*slack.ButtonBlockElement).URL)
+	})
+
+	t.Run("ownership", func(t *testing.T) {
+		blocks, _ := newMessage(testkube.WebhookTypeEndTest, testkube.Execution{
+			TestName:        "api-test",
+			ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusFailed},
+			Ownership:       &testkube.Ownership{Owner: "jane", Team: "payments", Contact: "#payments-oncall"},
+		}, "")
+
+		fields := blocks[1].(*slack.SectionBlock).Fields
+		assert.Equal(t, "*Owner:*\njane (payments)", fields[len(fields)-2].Text)
+		assert.Equal(t, "*Contact:*\n#payments-oncall", fields[len(fields)-1].Text)
+	})
+}
+
+func TestNotifyTestSuite(t *testing.T) {
+	routes, err := ParseRoutes(`[{"selector": "team=checkout", "channel": "checkout"}]`)
+	require.NoError(t, err)
+
+	client := &fakeClient{}
+	notifier := NewNotifier(client, "default", routes, "")
+	err = notifier.NotifyTestSuite(testkube.TestSuiteExecution{
+		Name:      "e2e-1",
+		TestSuite: &testkube.ObjectRef{Name: "e2e"},
+		Status:    testkube.TestSuiteExecutionStatusAborted,
+		Labels:    map[string]string{"team": "checkout"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"checkout"}, client.posted)
+
+	_, text := newTestSuiteMessage(testkube.TestSuiteExecution{TestSuite: &testkube.ObjectRef{Name: "e2e"},
+		Status: testkube.TestSuiteExecutionStatusAborted})
+	assert.Equal(t, ":no_entry_sign: e2e aborted", text)
+}
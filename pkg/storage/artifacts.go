@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+	"github.com/minio/minio-go/v7"
+)
+
+const (
+	// ProjectBucketPrefix is a name prefix of buckets storing artifacts of project executions
+	ProjectBucketPrefix = "testkube-project-"
+	// maxBucketNameLength is a maximum length of S3 bucket name
+	maxBucketNameLength = 63
+	// projectHashLength is a length of project name hash in project bucket names
+	projectHashLength = 8
+)
+
+// invalidBucketChars are characters not allowed in bucket names
+var invalidBucketChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// ProjectBucket returns bucket name of project artifacts, bucket name has project name hash suffix, so projects
+// with the same name after removing characters not valid in bucket names don't share bucket
+func ProjectBucket(project string) string {
+	hash := sha256.Sum256([]byte(project))
+	suffix := hex.EncodeToString(hash[:])[:projectHashLength]
+
+	name := strings.Trim(invalidBucketChars.ReplaceAllString(strings.ToLower(project), "-"), "-")
+	if maxLength := maxBucketNameLength - len(ProjectBucketPrefix) - len(suffix) - 1; len(name) > maxLength {
+		name = strings.TrimRight(name[:maxLength], "-")
+	}
+
+	if name == "" {
+		return ProjectBucketPrefix + suffix
+	}
+
+	return ProjectBucketPrefix + name + "-" + suffix
+}
+
+// NewArtifacts returns artifacts of execution, artifacts of project executions are stored in project bucket
+// with execution id prefix, artifacts of other executions are stored in bucket named by execution id
+func NewArtifacts(client Client, executionID, project string) Artifacts {
+	if project == "" {
+		return Artifacts{client: client, Bucket: executionID}
+	}
+
+	return Artifacts{client: client, Bucket: ProjectBucket(project), Prefix: executionID + "/"}
+}
+
+// Artifacts are artifacts of single execution, artifact names are relative to execution
+type Artifacts struct {
+	client Client
+	// Bucket is a bucket of execution artifacts
+	Bucket string
+	// Prefix is a name prefix of execution artifacts objects
+	Prefix string
+}
+
+// List lists execution artifacts
+func (a Artifacts) List() ([]testkube.Artifact, error) {
+	files, err := a.client.ListFilesWithPrefix(a.Bucket, a.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range files {
+		files[i].Name = strings.TrimPrefix(files[i].Name, a.Prefix)
+	}
+
+	return files, nil
+}
+
+// Upload uploads execution artifact read from reader
+func (a Artifacts) Upload(name string, reader io.Reader, size int64) error {
+	return a.client.UploadFile(a.Bucket, a.Prefix+name, reader, size)
+}
+
+// Download downloads execution artifact
+func (a Artifacts) Download(name string) (*minio.Object, error) {
+	return a.client.DownloadFile(a.Bucket, a.Prefix+name)
+}
+
+// PresignDownload returns presigned URL for downloading execution artifact
+func (a Artifacts) PresignDownload(name string, expires time.Duration) (string, error) {
+	return a.client.PresignDownloadFile(a.Bucket, a.Prefix+name, expires)
+}
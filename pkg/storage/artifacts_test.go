@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// listedClient lists objects of single bucket
+type listedClient struct {
+	Client
+	bucket  string
+	objects []string
+}
+
+func (c listedClient) ListFilesWithPrefix(bucket, prefix string) ([]testkube.Artifact, error) {
+	var files []testkube.Artifact
+	for _, object := range c.objects {
+		if bucket == c.bucket && strings.HasPrefix(object, prefix) {
+			files = append(files, testkube.Artifact{Name: object})
+		}
+	}
+
+	return files, nil
+}
+
+func TestProjectBucket(t *testing.T) {
+	assert.Regexp(t, `^testkube-project-payments-[0-9a-f]{8}$`, ProjectBucket("payments"))
+	assert.Regexp(t, `^testkube-project-team-a-[0-9a-f]{8}$`, ProjectBucket("Team_A"))
+	assert.NotEqual(t, ProjectBucket("team-a"), ProjectBucket("Team_A"), "projects don't share bucket")
+	assert.Regexp(t, `^testkube-project-[0-9a-f]{8}$`, ProjectBucket("__"))
+	assert.LessOrEqual(t, len(ProjectBucket(strings.Repeat("a", 63))), maxBucketNameLength)
+}
+
+func TestArtifactsList(t *testing.T) {
+	client := listedClient{bucket: ProjectBucket("payments"), objects: []string{"1/report.html", "12/report.html", "1/logs.txt"}}
+
+	files, err := NewArtifacts(client, "1", "payments").List()
+	assert.NoError(t, err)
+	assert.Equal(t, []testkube.Artifact{{Name: "report.html"}, {Name: "logs.txt"}}, files)
+
+	artifacts := NewArtifacts(client, "1", "")
+	assert.Equal(t, Artifacts{client: client, Bucket: "1"}, artifacts, "executions without project have own bucket")
+}
@@ -2,10 +2,16 @@ package minio
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/kubeshop/testkube/pkg/log"
@@ -20,6 +26,9 @@ var _ storage.Client = (*Client)(nil)
 // ErrArtifactsNotFound contains error for not existing artifacts
 var ErrArtifactsNotFound = errors.New("Execution doesn't have any artifacts associated with it")
 
+// Sha256MetadataKey is a user metadata key of objects SHA-256 checksum, it's stored as X-Amz-Meta-Sha256 header
+const Sha256MetadataKey = "Sha256"
+
 // Client for managing MinIO storage server
 type Client struct {
 	Endpoint        string
@@ -83,6 +92,10 @@ func (c *Client) DeleteBucket(bucket string, force bool) error {
 
 // ListBuckets lists available buckets
 func (c *Client) ListBuckets() ([]string, error) {
+	if err := c.Connect(); err != nil {
+		return nil, err
+	}
+
 	toReturn := []string{}
 	if buckets, err := c.minioclient.ListBuckets(context.TODO()); err != nil {
 		return nil, err
@@ -96,6 +109,11 @@ func (c *Client) ListBuckets() ([]string, error) {
 
 // ListFiles lists available files in given bucket
 func (c *Client) ListFiles(bucket string) ([]testkube.Artifact, error) {
+	return c.ListFilesWithPrefix(bucket, "")
+}
+
+// ListFilesWithPrefix lists objects of bucket which names start with prefix
+func (c *Client) ListFilesWithPrefix(bucket, prefix string) ([]testkube.Artifact, error) {
 	if err := c.Connect(); err != nil {
 		return nil, err
 	}
@@ -110,11 +128,17 @@ func (c *Client) ListFiles(bucket string) ([]testkube.Artifact, error) {
 		return nil, ErrArtifactsNotFound
 	}
 
-	for obj := range c.minioclient.ListObjects(context.TODO(), bucket, minio.ListObjectsOptions{Recursive: true}) {
+	// metadata are listed by MinIO only, other S3 storages don't return SHA-256 checksums
+	for obj := range c.minioclient.ListObjects(context.TODO(), bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true, WithMetadata: true}) {
 		if obj.Err != nil {
 			return nil, obj.Err
 		}
-		toReturn = append(toReturn, testkube.Artifact{Name: obj.Key, Size: int32(obj.Size)})
+		toReturn = append(toReturn, testkube.Artifact{
+			Name:     obj.Key,
+			Size:     int32(obj.Size),
+			Checksum: etagChecksum(obj.ETag),
+			Sha256:   Sha256Checksum(obj.UserMetadata),
+		})
 	}
 
 	return toReturn, nil
@@ -137,8 +161,16 @@ func (c *Client) SaveFile(bucket, filePath string) error {
 
 	fileName := objectStat.Name()
 
-	c.Log.Debugw("saving object in minio", "filePath", filePath, "fileName", fileName, "bucket", bucket, "size", objectStat.Size())
-	_, err = c.minioclient.PutObject(context.Background(), bucket, fileName, object, objectStat.Size(), minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	checksum, err := sha256Sum(object)
+	if err != nil {
+		return fmt.Errorf("minio computing file (%s) checksum error: %w", filePath, err)
+	}
+
+	c.Log.Debugw("saving object in minio", "filePath", filePath, "fileName", fileName, "bucket", bucket, "size", objectStat.Size(), "sha256", checksum)
+	_, err = c.minioclient.PutObject(context.Background(), bucket, fileName, object, objectStat.Size(), minio.PutObjectOptions{
+		ContentType:  "application/octet-stream",
+		UserMetadata: map[string]string{Sha256MetadataKey: checksum},
+	})
 	if err != nil {
 		return fmt.Errorf("minio saving file (%s) put object error: %w", fileName, err)
 	}
@@ -146,6 +178,45 @@ func (c *Client) SaveFile(bucket, filePath string) error {
 	return nil
 }
 
+// UploadFile uploads object read from reader to S3 bucket, bucket is created when it doesn't exist
+func (c *Client) UploadFile(bucket, object string, reader io.Reader, size int64) error {
+	if err := c.Connect(); err != nil {
+		return err
+	}
+
+	exists, err := c.minioclient.BucketExists(context.Background(), bucket)
+	if err != nil {
+		return fmt.Errorf("minio checking bucket (%s) error: %w", bucket, err)
+	}
+
+	if !exists {
+		// bucket could be created by concurrent upload in the meantime
+		if err = c.CreateBucket(bucket); err != nil {
+			if exists, _ = c.minioclient.BucketExists(context.Background(), bucket); !exists {
+				return fmt.Errorf("minio creating bucket (%s) error: %w", bucket, err)
+			}
+		}
+	}
+
+	options := minio.PutObjectOptions{ContentType: "application/octet-stream"}
+	// checksum of streamed objects isn't known before upload
+	if seeker, ok := reader.(io.ReadSeeker); ok {
+		checksum, err := sha256Sum(seeker)
+		if err != nil {
+			return fmt.Errorf("minio computing object (%s) checksum error: %w", object, err)
+		}
+		options.UserMetadata = map[string]string{Sha256MetadataKey: checksum}
+	}
+
+	c.Log.Debugw("uploading object to minio", "object", object, "bucket", bucket, "size", size)
+	_, err = c.minioclient.PutObject(context.Background(), bucket, object, reader, size, options)
+	if err != nil {
+		return fmt.Errorf("minio uploading object (%s) put object error: %w", object, err)
+	}
+
+	return nil
+}
+
 // DownloadFile downloads file in bucket
 func (c *Client) DownloadFile(bucket, file string) (*minio.Object, error) {
 	if err := c.Connect(); err != nil {
@@ -174,6 +245,56 @@ func (c *Client) DownloadFile(bucket, file string) (*minio.Object, error) {
 	return reader, nil
 }
 
+// PresignDownloadFile returns presigned URL for downloading file in bucket
+func (c *Client) PresignDownloadFile(bucket, file string, expires time.Duration) (string, error) {
+	if err := c.Connect(); err != nil {
+		return "", fmt.Errorf("minio PresignDownloadFile .Connect error: %w", err)
+	}
+
+	u, err := c.minioclient.PresignedGetObject(context.Background(), bucket, file, expires, url.Values{})
+	if err != nil {
+		return "", fmt.Errorf("minio PresignDownloadFile PresignedGetObject error: %w", err)
+	}
+
+	return u.String(), nil
+}
+
+// etagChecksum returns MD5 checksum from object ETag, multipart uploads ETags are not checksums of content
+func etagChecksum(etag string) string {
+	etag = strings.Trim(etag, "\"")
+	if strings.Contains(etag, "-") {
+		return ""
+	}
+
+	return etag
+}
+
+// Sha256Checksum returns SHA-256 checksum from listed or stat object user metadata, listed metadata keys keep
+// X-Amz-Meta- prefix
+func Sha256Checksum(metadata map[string]string) string {
+	for key, value := range metadata {
+		if strings.EqualFold(strings.TrimPrefix(strings.ToLower(key), "x-amz-meta-"), Sha256MetadataKey) {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// sha256Sum returns hex SHA-256 checksum of reader content, reader is rewound to the start
+func sha256Sum(reader io.ReadSeeker) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // ScrapeArtefacts pushes local files located in directories to given bucket ID
 func (c *Client) ScrapeArtefacts(id string, directories ...string) error {
 	if err := c.Connect(); err != nil {
@@ -185,6 +306,37 @@ func (c *Client) ScrapeArtefacts(id string, directories ...string) error {
 		return fmt.Errorf("minio failed to create a bucket %s: %w", id, err)
 	}
 
+	return c.walkArtefacts(directories, func(path string) error {
+		return c.SaveFile(id, path) //The function will detect if there is a subdirectory and store accordingly
+	})
+}
+
+// ScrapeProjectArtefacts pushes local files located in directories to artifacts of execution with given ID,
+// artifacts of project executions are stored in project bucket which is created when it doesn't exist
+func (c *Client) ScrapeProjectArtefacts(id, project string, directories ...string) error {
+	if project == "" {
+		return c.ScrapeArtefacts(id, directories...)
+	}
+
+	artifacts := storage.NewArtifacts(c, id, project)
+	return c.walkArtefacts(directories, func(path string) error {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+
+		return artifacts.Upload(info.Name(), file, info.Size())
+	})
+}
+
+// walkArtefacts calls save for files in directories, missing directories are skipped
+func (c *Client) walkArtefacts(directories []string, save func(path string) error) error {
 	for _, directory := range directories {
 
 		if _, err := os.Stat(directory); os.IsNotExist(err) {
@@ -193,14 +345,14 @@ func (c *Client) ScrapeArtefacts(id string, directories ...string) error {
 		}
 
 		// if directory exists walk through recursively
-		err = filepath.Walk(directory,
+		err := filepath.Walk(directory,
 			func(path string, info os.FileInfo, err error) error {
 				if err != nil {
 					return fmt.Errorf("minio path (%s) walk error: %w", path, err)
 				}
 
 				if !info.IsDir() {
-					err = c.SaveFile(id, path) //The function will detect if there is a subdirectory and store accordingly
+					err = save(path)
 					if err != nil {
 						return fmt.Errorf("minio save file (%s) error: %w", path, err)
 					}
@@ -0,0 +1,22 @@
+package minio
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSha256Checksum(t *testing.T) {
+	assert.Equal(t, "abc", Sha256Checksum(map[string]string{"X-Amz-Meta-Sha256": "abc"}), "listed metadata")
+	assert.Equal(t, "abc", Sha256Checksum(map[string]string{"Sha256": "abc"}), "stat metadata")
+	assert.Empty(t, Sha256Checksum(nil))
+}
+
+func TestSha256Sum(t *testing.T) {
+	reader := bytes.NewReader([]byte("artifact"))
+	checksum, err := sha256Sum(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "c7c5c1d70c5dec4416ab6158afd0b223ef40c29b1dc1f97ed9428b94d4cadb1c", checksum)
+	assert.Equal(t, 8, reader.Len(), "reader is rewound")
+}
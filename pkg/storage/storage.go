@@ -1,6 +1,9 @@
 package storage
 
 import (
+	"io"
+	"time"
+
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/minio/minio-go/v7"
 )
@@ -11,6 +14,11 @@ type Client interface {
 	DeleteBucket(bucket string, force bool) error
 	ListBuckets() ([]string, error)
 	ListFiles(bucket string) ([]testkube.Artifact, error)
+	// ListFilesWithPrefix lists objects of bucket which names start with prefix
+	ListFilesWithPrefix(bucket, prefix string) ([]testkube.Artifact, error)
 	SaveFile(bucket, filePath string) error
+	// UploadFile uploads object read from reader, bucket is created when it doesn't exist
+	UploadFile(bucket, object string, reader io.Reader, size int64) error
 	DownloadFile(bucket, file string) (*minio.Object, error)
+	PresignDownloadFile(bucket, file string, expires time.Duration) (string, error)
 }
@@ -1,10 +1,26 @@
 package ui
 
 import (
+	goerrors "errors"
 	"fmt"
 	"os"
 )
 
+// exitCoder is an error with its own exit code, e.g. API server problem
+type exitCoder interface {
+	ExitCode() int
+}
+
+// ExitCode returns exit code of error, 1 is returned for errors without own exit code
+func ExitCode(err error) int {
+	var coder exitCoder
+	if goerrors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+
+	return 1
+}
+
 func (ui *UI) ExitOnError(item string, errors ...error) {
 	ui.printAndExit(item, true, errors...)
 }
@@ -19,7 +35,7 @@ func (ui *UI) printAndExit(item string, exitOnError bool, errors ...error) {
 			if err != nil {
 				fmt.Fprintf(Writer, "%s %s (error: %s)\n\n", LightRed("⨯"), Red(item), err)
 				if exitOnError {
-					os.Exit(1)
+					os.Exit(ExitCode(err))
 				}
 			}
 		}
@@ -69,7 +69,7 @@ func (ui *UI) Errf(err string, params ...interface{}) {
 func (ui *UI) Fail(err error) {
 	ui.Err(err)
 	fmt.Fprintln(ui.Writer)
-	os.Exit(1)
+	os.Exit(ExitCode(err))
 }
 
 func (ui *UI) Failf(err string, params ...interface{}) {
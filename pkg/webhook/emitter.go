@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/kubeshop/testkube/pkg/log"
@@ -20,6 +21,7 @@ func NewEmitter() *Emitter {
 		Events:    make(chan testkube.WebhookEvent, eventsBuffer),
 		Responses: make(chan WebhookResult, eventsBuffer),
 		Log:       log.DefaultLogger,
+		Client:    http.DefaultClient,
 	}
 }
 
@@ -28,6 +30,7 @@ type Emitter struct {
 	Events    chan testkube.WebhookEvent
 	Responses chan WebhookResult
 	Log       *zap.SugaredLogger
+	Client    *http.Client
 }
 
 // WebhookResult is a wrapper for results from HTTP client for given webhook
@@ -77,15 +80,25 @@ func (s *Emitter) Send(event testkube.WebhookEvent) {
 		return
 	}
 
-	request, err := http.NewRequest(http.MethodPost, event.Uri, body)
+	request, err := http.NewRequest(http.MethodPost, event.Uri, bytes.NewReader(body.Bytes()))
 	if err != nil {
 		l.Errorw("webhook request creating error", "error", err)
 		s.Responses <- WebhookResult{Error: err, Event: event}
 		return
 	}
 
+	request.Header.Set("Content-Type", "application/json")
+	if event.SigningSecret != "" {
+		request.Header.Set(SignatureHeader, Sign(event.SigningSecret, body.Bytes(), time.Now()))
+	}
+
+	client := s.Client
+	if event.HTTPClient != nil {
+		client = event.HTTPClient
+	}
+
 	// TODO use custom client with sane timeout values this one can starve queue in case of very slow clients
-	resp, err := http.DefaultClient.Do(request)
+	resp, err := client.Do(request)
 	if err != nil {
 		l.Errorw("webhook send error", "error", err)
 		s.Responses <- WebhookResult{Error: err, Event: event}
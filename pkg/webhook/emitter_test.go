@@ -2,9 +2,11 @@ package webhook
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 	"github.com/stretchr/testify/assert"
@@ -85,6 +87,34 @@ func TestWebhook(t *testing.T) {
 		assert.Error(t, r.Error)
 	})
 
+	t.Run("send signed event", func(t *testing.T) {
+		// given
+		testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			// then
+			assert.NoError(t, VerifySignature("secret", r.Header.Get(SignatureHeader), body, time.Minute, time.Now()))
+			assert.NotContains(t, string(body), "secret")
+		})
+
+		svr := httptest.NewServer(testHandler)
+		defer svr.Close()
+
+		s := NewEmitter()
+
+		// when
+		s.Send(testkube.WebhookEvent{
+			Type_:         testkube.WebhookTypeEndTest,
+			Uri:           svr.URL,
+			Execution:     exampleExecution(),
+			SigningSecret: "secret",
+		})
+
+		// then
+		r := <-s.Responses
+		assert.Equal(t, 200, r.Response.StatusCode)
+	})
+
 }
 
 func exampleExecution() *testkube.Execution {
@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+// Matches checks if execution passes webhook test selector and status filters, empty filters match all executions
+func Matches(webhook testkube.Webhook, execution testkube.Execution) (bool, error) {
+	if webhook.Selector != "" {
+		selector, err := labels.Parse(webhook.Selector)
+		if err != nil {
+			return false, fmt.Errorf("invalid webhook %s selector %q: %w", webhook.Name, webhook.Selector, err)
+		}
+
+		if !selector.Matches(labels.Set(execution.Labels)) {
+			return false, nil
+		}
+	}
+
+	if len(webhook.Statuses) == 0 {
+		return true, nil
+	}
+
+	if execution.ExecutionResult == nil || execution.ExecutionResult.Status == nil {
+		return false, nil
+	}
+
+	for _, status := range webhook.Statuses {
+		if status == *execution.ExecutionResult.Status {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
+)
+
+func TestMatches(t *testing.T) {
+	failedCheckout := testkube.Execution{
+		Labels:          map[string]string{"team": "checkout"},
+		ExecutionResult: &testkube.ExecutionResult{Status: testkube.ExecutionStatusFailed},
+	}
+
+	t.Run("webhook without filters matches all executions", func(t *testing.T) {
+		matches, err := Matches(testkube.Webhook{}, testkube.Execution{})
+
+		assert.NoError(t, err)
+		assert.True(t, matches)
+	})
+
+	t.Run("selector and status filters must both match", func(t *testing.T) {
+		webhook := testkube.Webhook{Selector: "team=checkout", Statuses: []testkube.ExecutionStatus{testkube.FAILED_ExecutionStatus}}
+
+		matches, err := Matches(webhook, failedCheckout)
+		assert.NoError(t, err)
+		assert.True(t, matches)
+
+		passed := failedCheckout
+		passed.ExecutionResult = &testkube.ExecutionResult{Status: testkube.ExecutionStatusPassed}
+		matches, err = Matches(webhook, passed)
+		assert.NoError(t, err)
+		assert.False(t, matches)
+
+		otherTeam := failedCheckout
+		otherTeam.Labels = map[string]string{"team": "search"}
+		matches, err = Matches(webhook, otherTeam)
+		assert.NoError(t, err)
+		assert.False(t, matches)
+	})
+
+	t.Run("invalid selector returns error", func(t *testing.T) {
+		_, err := Matches(testkube.Webhook{Selector: "team in"}, failedCheckout)
+
+		assert.Error(t, err)
+	})
+}
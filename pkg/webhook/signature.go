@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader is a header with HMAC-SHA256 signature of webhook payload
+	SignatureHeader = "X-Testkube-Signature"
+	// SigningSecretKey is a key of signing secret in Kubernetes Secret data
+	SigningSecretKey = "signingSecret"
+)
+
+// Sign returns signature header value in "t=<unix timestamp>,v1=<hex HMAC-SHA256>" format,
+// timestamp is part of signed material so receivers can reject replayed payloads
+func Sign(secret string, body []byte, timestamp time.Time) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + t + ",v1=" + signature(secret, t, body)
+}
+
+// VerifySignature checks signature header of payload, payloads signed more than tolerance ago are rejected
+func VerifySignature(secret, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var t, v1 string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			t = value
+		case "v1":
+			v1 = value
+		}
+	}
+
+	if t == "" || v1 == "" {
+		return errors.New("invalid signature header format")
+	}
+
+	unix, err := strconv.ParseInt(t, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid signature timestamp: %w", err)
+	}
+
+	if age := now.Sub(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("signature timestamp is outside of %s tolerance", tolerance)
+	}
+
+	if !hmac.Equal([]byte(signature(secret, t, body)), []byte(v1)) {
+		return errors.New("signature mismatch")
+	}
+
+	return nil
+}
+
+func signature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
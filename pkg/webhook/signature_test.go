@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignature(t *testing.T) {
+	now := time.Unix(1660000000, 0)
+	body := []byte(`{"type":"end-test"}`)
+	header := Sign("secret", body, now)
+
+	t.Run("valid signature", func(t *testing.T) {
+		assert.Regexp(t, "^t=1660000000,v1=[0-9a-f]{64}$", header)
+		assert.NoError(t, VerifySignature("secret", header, body, time.Minute, now.Add(30*time.Second)))
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		assert.EqualError(t, VerifySignature("other", header, body, time.Minute, now), "signature mismatch")
+	})
+
+	t.Run("modified body", func(t *testing.T) {
+		assert.EqualError(t, VerifySignature("secret", header, []byte(`{}`), time.Minute, now), "signature mismatch")
+	})
+
+	t.Run("replayed payload", func(t *testing.T) {
+		assert.Error(t, VerifySignature("secret", header, body, time.Minute, now.Add(2*time.Minute)))
+	})
+
+	t.Run("modified timestamp", func(t *testing.T) {
+		replayed := "t=1660000120" + header[len("t=1660000000"):]
+		assert.EqualError(t, VerifySignature("secret", replayed, body, time.Minute, now.Add(2*time.Minute)), "signature mismatch")
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		assert.Error(t, VerifySignature("secret", "sha256=abc", body, time.Minute, now))
+	})
+}
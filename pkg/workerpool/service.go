@@ -38,17 +38,12 @@ type Response[E Returnable] struct {
 	Err    error
 }
 
-// execute is a method wrapper for ExecFn execution
+// execute is a method wrapper for ExecFn execution, result is kept when error is returned
 func (r Request[R, T, E]) execute(ctx context.Context) Response[E] {
 	result, err := r.ExecFn(ctx, r.Object, r.Options)
-	if err != nil {
-		return Response[E]{
-			Err: err,
-		}
-	}
-
 	return Response[E]{
 		Result: result,
+		Err:    err,
 	}
 }
 
@@ -57,6 +52,8 @@ type Service[R Runnable, T Requestable, E Returnable] struct {
 	concurrencyLevel int
 	requests         chan Request[R, T, E]
 	responses        chan Response[E]
+	// done is closed when workers stop, so requests aren't sent after cancellation
+	done chan struct{}
 }
 
 // New is a constructor for worker pool service
@@ -65,6 +62,7 @@ func New[R Runnable, T Requestable, E Returnable](concurrencyLevel int) Service[
 		concurrencyLevel: concurrencyLevel,
 		requests:         make(chan Request[R, T, E], concurrencyLevel),
 		responses:        make(chan Response[E], concurrencyLevel),
+		done:             make(chan struct{}),
 	}
 }
 
@@ -78,6 +76,7 @@ func (s Service[R, T, E]) Run(ctx context.Context) {
 	}
 
 	wg.Wait()
+	close(s.done)
 	close(s.responses)
 }
 
@@ -86,10 +85,15 @@ func (s Service[R, T, E]) GetResponses() <-chan Response[E] {
 	return s.responses
 }
 
-// SendRequests sends requests to workers
+// SendRequests sends requests to workers, it blocks while workers are busy and returns
+// without sending queued requests when workers are stopped by context cancellation
 func (s Service[R, T, E]) SendRequests(requests []Request[R, T, E]) {
 	for i := range requests {
-		s.requests <- requests[i]
+		select {
+		case s.requests <- requests[i]:
+		case <-s.done:
+			return
+		}
 	}
 	close(s.requests)
 }
@@ -111,3 +115,30 @@ func worker[R Runnable, T Requestable, E Returnable](ctx context.Context, wg *sy
 		}
 	}
 }
+
+// Pool is a long running worker pool with bounded concurrency shared by callers executing single requests
+type Pool[R Runnable, T Requestable, E Returnable] struct {
+	workers chan struct{}
+}
+
+// NewPool is a constructor for long running worker pool
+func NewPool[R Runnable, T Requestable, E Returnable](concurrencyLevel int) Pool[R, T, E] {
+	return Pool[R, T, E]{workers: make(chan struct{}, concurrencyLevel)}
+}
+
+// Execute executes request when there is a free worker and blocks until then, request isn't executed
+// and context error is returned when context is cancelled before
+func (p Pool[R, T, E]) Execute(ctx context.Context, request Request[R, T, E]) Response[E] {
+	select {
+	case p.workers <- struct{}{}:
+	case <-ctx.Done():
+		return Response[E]{Err: ctx.Err()}
+	}
+	defer func() { <-p.workers }()
+
+	if err := ctx.Err(); err != nil {
+		return Response[E]{Err: err}
+	}
+
+	return request.execute(ctx)
+}
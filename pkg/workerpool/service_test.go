@@ -3,7 +3,10 @@ package workerpool
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/kubeshop/testkube/pkg/api/v1/testkube"
 )
@@ -56,3 +59,97 @@ func testRequests() []Request[testkube.Test, testkube.ExecutionRequest, testkube
 	}
 	return requests
 }
+
+func TestWorkerPoolKeepsResultOnError(t *testing.T) {
+	service := New[testkube.Test, testkube.ExecutionRequest, testkube.Execution](concurrencylevel)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	go service.SendRequests([]Request[testkube.Test, testkube.ExecutionRequest, testkube.Execution]{{
+		Object: testkube.Test{Name: "test"},
+		ExecFn: func(ctx context.Context, object testkube.Test, options testkube.ExecutionRequest) (testkube.Execution, error) {
+			return testkube.Execution{TestName: object.Name}, fmt.Errorf("execution failed")
+		},
+	}})
+
+	go service.Run(ctx)
+
+	for r := range service.GetResponses() {
+		if r.Err == nil || r.Result.TestName != "test" {
+			t.Fatalf("wrong response %v; expected result with error", r)
+		}
+	}
+}
+
+func TestWorkerPoolCancellation(t *testing.T) {
+	service := New[testkube.Test, testkube.ExecutionRequest, testkube.Execution](1)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	requests := testRequests()
+	requests[0].ExecFn = func(ctx context.Context, object testkube.Test, options testkube.ExecutionRequest) (testkube.Execution, error) {
+		cancel()
+		return testkube.Execution{TestName: object.Name}, nil
+	}
+
+	sent := make(chan struct{})
+	go func() {
+		service.SendRequests(requests)
+		close(sent)
+	}()
+
+	go service.Run(ctx)
+
+	for range service.GetResponses() {
+	}
+
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("queued requests are still sent after cancellation")
+	}
+}
+
+func TestPool(t *testing.T) {
+	pool := NewPool[testkube.Test, testkube.ExecutionRequest, testkube.Execution](concurrencylevel)
+
+	var running, maxRunning int32
+	request := Request[testkube.Test, testkube.ExecutionRequest, testkube.Execution]{
+		Object: testkube.Test{Name: "test"},
+		ExecFn: func(ctx context.Context, object testkube.Test, options testkube.ExecutionRequest) (testkube.Execution, error) {
+			current := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+			return testkube.Execution{TestName: object.Name}, nil
+		},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < requestCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if r := pool.Execute(context.TODO(), request); r.Err != nil || r.Result.TestName != "test" {
+				t.Errorf("wrong response %v", r)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxRunning > concurrencylevel {
+		t.Fatalf("wrong concurrency %v; expected at most %v", maxRunning, concurrencylevel)
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	if r := pool.Execute(ctx, request); r.Err != context.Canceled {
+		t.Fatalf("wrong error %v; expected %v", r.Err, context.Canceled)
+	}
+}